@@ -43,6 +43,11 @@ func (gvkr GroupVersionKindResource) GroupResource() schema.GroupResource {
 	return schema.GroupResource{Group: gvkr.Group, Resource: gvkr.Resource}
 }
 
+// IsArgoRollout returns whether the GroupVersionKindResource is the one of an Argo Rollout
+func (gvkr GroupVersionKindResource) IsArgoRollout() bool {
+	return gvkr.Group == "argoproj.io" && gvkr.Kind == "Rollout"
+}
+
 // GVKString returns the group, version and kind in string format
 func (gvkr GroupVersionKindResource) GVKString() string {
 	return gvkr.Group + "/" + gvkr.Version + "." + gvkr.Kind
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -24,6 +26,9 @@ import (
 const (
 	defaultScaledJobMaxReplicaCount = 100
 	defaultScaledJobMinReplicaCount = 0
+
+	// JobCreationOrderPriority creates the Jobs for the work of the highest priority first
+	JobCreationOrderPriority = "priority"
 )
 
 // +genclient
@@ -63,6 +68,10 @@ type ScaledJobSpec struct {
 	Rollout Rollout `json:"rollout,omitempty"`
 	// +optional
 	EnvSourceContainerName string `json:"envSourceContainerName,omitempty"`
+	// InjectMetricEnv adds the KEDA_SCALER_METRIC_NAME and KEDA_SCALER_METRIC_VALUE environment variables
+	// to the containers of the created Jobs, describing the metric the Jobs were created for
+	// +optional
+	InjectMetricEnv bool `json:"injectMetricEnv,omitempty"`
 	// +optional
 	MinReplicaCount *int32 `json:"minReplicaCount,omitempty"`
 	// +optional
@@ -108,6 +117,11 @@ type ScalingStrategy struct {
 	PendingPodConditions []string `json:"pendingPodConditions,omitempty"`
 	// +optional
 	MultipleScalersCalculation string `json:"multipleScalersCalculation,omitempty"`
+	// JobCreationOrder is the order the Jobs are created in, with "priority" the Jobs for the work of the highest
+	// priority reported by the scalers are created first when maxReplicaCount limits the creation
+	// +kubebuilder:validation:Enum=fifo;priority
+	// +optional
+	JobCreationOrder string `json:"jobCreationOrder,omitempty"`
 }
 
 // Rollout defines the strategy for job rollouts
@@ -150,3 +164,27 @@ func (s ScaledJob) MinReplicaCount() int64 {
 func (s *ScaledJob) GenerateIdentifier() string {
 	return GenerateIdentifier("ScaledJob", s.Namespace, s.Name)
 }
+
+// ValidateScaledJobTriggers validates the triggers of a ScaledJob like ValidateTriggers, along with
+// the properties of the triggers which ScaledJobs don't support
+func ValidateScaledJobTriggers(triggers []ScaleTriggers) error {
+	if err := checkScaledJobTriggers(triggers); err != nil {
+		return err
+	}
+	return ValidateTriggers(triggers)
+}
+
+// checkScaledJobTriggers checks that no trigger of a ScaledJob has a weight, the number of jobs being
+// calculated by the scalingStrategy of the ScaledJob, nor a pollingInterval, all the triggers of a
+// ScaledJob being polled every pollingInterval of the ScaledJob
+func checkScaledJobTriggers(triggers []ScaleTriggers) error {
+	for _, trigger := range triggers {
+		if trigger.Weight != "" {
+			return fmt.Errorf("property \"weight\" is not supported by ScaledJobs")
+		}
+		if trigger.PollingInterval != nil {
+			return fmt.Errorf("property \"pollingInterval\" of the triggers is not supported by ScaledJobs, set the pollingInterval of the ScaledJob instead")
+		}
+	}
+	return nil
+}
@@ -18,6 +18,9 @@ package v1alpha1
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
 )
 
 func TestScaledJob(t *testing.T) {
@@ -96,3 +99,12 @@ func TestScaledJob(t *testing.T) {
 func int32Ptr(i int32) *int32 {
 	return &i
 }
+
+func TestValidateScaledJobTriggers(t *testing.T) {
+	assert.NoError(t, ValidateScaledJobTriggers([]ScaleTriggers{{Name: "queue", Type: "rabbitmq"}}))
+	assert.EqualError(t, ValidateScaledJobTriggers([]ScaleTriggers{{Name: "queue", Type: "rabbitmq", Weight: "2"}}),
+		"property \"weight\" is not supported by ScaledJobs")
+	assert.EqualError(t, ValidateScaledJobTriggers([]ScaleTriggers{{Name: "queue", Type: "rabbitmq", PollingInterval: ptr.To[int32](5)}}),
+		"property \"pollingInterval\" of the triggers is not supported by ScaledJobs, set the pollingInterval of the ScaledJob instead")
+	assert.EqualError(t, ValidateScaledJobTriggers(nil), "no triggers defined in the ScaledObject/ScaledJob")
+}
@@ -19,10 +19,14 @@ package v1alpha1
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
+	"time"
 
+	"github.com/robfig/cron/v3"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // +genclient
@@ -56,6 +60,7 @@ const ScaledObjectTransferHpaOwnershipAnnotation = "scaledobject.keda.sh/transfe
 const ValidationsHpaOwnershipAnnotation = "validations.keda.sh/hpa-ownership"
 const PausedReplicasAnnotation = "autoscaling.keda.sh/paused-replicas"
 const PausedAnnotation = "autoscaling.keda.sh/paused"
+const ReconcileNowAnnotation = "autoscaling.keda.sh/reconcile-now"
 
 // HealthStatus is the status for a ScaledObject's health
 type HealthStatus struct {
@@ -80,6 +85,9 @@ const (
 
 	defaultHPAMinReplicas int32 = 1
 	defaultHPAMaxReplicas int32 = 100
+
+	// maxStabilizationWindowSeconds is the longest stabilization window accepted by the HPA
+	maxStabilizationWindowSeconds int32 = 3600
 )
 
 // ScaledObjectSpec is the spec for a ScaledObject resource
@@ -96,8 +104,12 @@ type ScaledObjectSpec struct {
 	// +optional
 	MinReplicaCount *int32 `json:"minReplicaCount,omitempty"`
 	// +optional
+	MinReplicaCountSchedules []MinReplicaCountSchedule `json:"minReplicaCountSchedules,omitempty"`
+	// +optional
 	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
 	// +optional
+	MaxReplicaCountCapacity *MaxReplicaCountCapacity `json:"maxReplicaCountCapacity,omitempty"`
+	// +optional
 	Advanced *AdvancedConfig `json:"advanced,omitempty"`
 
 	Triggers []ScaleTriggers `json:"triggers"`
@@ -111,6 +123,63 @@ type Fallback struct {
 	Replicas         int32 `json:"replicas"`
 }
 
+// MinReplicaCountSchedule overrides the minReplicaCount from the time its schedule fires
+// until another schedule of the ScaledObject fires
+type MinReplicaCountSchedule struct {
+	// Schedule is a cron expression, e.g. "0 8 * * 1-5"
+	Schedule string `json:"schedule"`
+	// Timezone is an IANA time zone name used to evaluate the schedule, defaults to UTC
+	// +optional
+	Timezone        string `json:"timezone,omitempty"`
+	MinReplicaCount int32  `json:"minReplicaCount"`
+}
+
+// GetCronSchedule parses the schedule and its timezone
+func (s *MinReplicaCountSchedule) GetCronSchedule() (cron.Schedule, *time.Location, error) {
+	return parseCronSchedule(s.Schedule, s.Timezone)
+}
+
+func parseCronSchedule(cronSchedule, timezone string) (cron.Schedule, *time.Location, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load timezone %q: %w", timezone, err)
+	}
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	schedule, err := parser.Parse(cronSchedule)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing schedule %q: %w", cronSchedule, err)
+	}
+	return schedule, location, nil
+}
+
+// MaxReplicaCountCapacity caps the replicas to a percentage of the schedulable capacity of the cluster,
+// which is resolved again periodically. maxReplicaCount remains an upper bound when it's set.
+// The operator must run with KEDA_ENABLE_MAX_REPLICA_COUNT_CAPACITY=true and be allowed to list the nodes.
+type MaxReplicaCountCapacity struct {
+	// Percentage of the capacity, e.g. 50 allows a replica on half of the nodes
+	// +kubebuilder:validation:Minimum=1
+	Percentage int32 `json:"percentage"`
+	// Resource is the capacity the percentage applies to, either the count of schedulable nodes or
+	// their allocatable CPU divided by the CPU requested by a replica of the scale target
+	// +kubebuilder:validation:Enum=nodes;cpu
+	// +kubebuilder:default=nodes
+	// +optional
+	Resource MaxReplicaCountCapacityResource `json:"resource,omitempty"`
+	// NodeSelector restricts the capacity to the nodes with these labels
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// MaxReplicaCountCapacityResource is the capacity a MaxReplicaCountCapacity is a percentage of
+type MaxReplicaCountCapacityResource string
+
+const (
+	// MaxReplicaCountCapacityNodes is the count of schedulable nodes
+	MaxReplicaCountCapacityNodes MaxReplicaCountCapacityResource = "nodes"
+	// MaxReplicaCountCapacityCPU is the allocatable CPU of the schedulable nodes
+	MaxReplicaCountCapacityCPU MaxReplicaCountCapacityResource = "cpu"
+)
+
 // AdvancedConfig specifies advance scaling options
 type AdvancedConfig struct {
 	// +optional
@@ -119,29 +188,111 @@ type AdvancedConfig struct {
 	RestoreToOriginalReplicaCount bool `json:"restoreToOriginalReplicaCount,omitempty"`
 	// +optional
 	ScalingModifiers ScalingModifiers `json:"scalingModifiers,omitempty"`
+	// RespectPodDisruptionBudget prevents deactivating the scale target, to zero or idleReplicaCount,
+	// while it would violate the minAvailable of a PodDisruptionBudget of its pods
+	// +optional
+	RespectPodDisruptionBudget bool `json:"respectPodDisruptionBudget,omitempty"`
+	// ScaleDownStabilizationWindowSeconds is a shortcut for behavior.scaleDown.stabilizationWindowSeconds of
+	// the HPA, the value set in horizontalPodAutoscalerConfig.behavior takes precedence.
+	// It can't be used with horizontalPodAutoscalerConfig.behaviorRef
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	ScaleDownStabilizationWindowSeconds *int32 `json:"scaleDownStabilizationWindowSeconds,omitempty"`
+	// ErrorBackoff increases the polling interval exponentially while the triggers keep failing
+	// +optional
+	ErrorBackoff *ErrorBackoff `json:"errorBackoff,omitempty"`
+	// ReplicaWindows override minReplicaCount and maxReplicaCount during time windows,
+	// when several windows are open at the same time the first one listed applies.
+	// The maxReplicaCount of a window can't be less than the minReplicaCount of any minReplicaCountSchedule
+	// +optional
+	ReplicaWindows []ReplicaWindow `json:"replicaWindows,omitempty"`
+}
+
+// ReplicaWindow overrides the replica counts of the ScaledObject from each time its schedule fires for its duration
+type ReplicaWindow struct {
+	// Schedule is a cron expression of the opening of the window, e.g. "0 8 * * 1-5"
+	Schedule string `json:"schedule"`
+	// Duration the window stays open for, e.g. "10h"
+	Duration string `json:"duration"`
+	// Timezone is an IANA time zone name used to evaluate the schedule, defaults to UTC
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+	// MinReplicaCount while the window is open, the minReplicaCount of the ScaledObject applies when it's not set
+	// +optional
+	MinReplicaCount *int32 `json:"minReplicaCount,omitempty"`
+	// MaxReplicaCount while the window is open, the maxReplicaCount of the ScaledObject applies when it's not set
+	// +optional
+	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
+}
+
+// GetCronSchedule parses the schedule and its timezone
+func (w *ReplicaWindow) GetCronSchedule() (cron.Schedule, *time.Location, error) {
+	return parseCronSchedule(w.Schedule, w.Timezone)
+}
+
+// GetDuration parses the duration of the window
+func (w *ReplicaWindow) GetDuration() (time.Duration, error) {
+	duration, err := time.ParseDuration(w.Duration)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing duration %q: %w", w.Duration, err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration %q must be positive", w.Duration)
+	}
+	return duration, nil
+}
+
+// ErrorBackoff specifies how the polling of a ScaledObject is backed off on consecutive failed polls
+type ErrorBackoff struct {
+	// FailureThreshold is the number of consecutive failed polls after which the polling interval is doubled
+	// on each failed poll, until a poll succeeds
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+	// MaxInterval caps the backed off polling interval, in seconds
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxInterval int32 `json:"maxInterval,omitempty"`
 }
 
 // ScalingModifiers describes advanced scaling logic options like formula
 type ScalingModifiers struct {
+	// Formula composes the metrics of the named triggers, baseline("<trigger>") returns the value of a trigger
+	// multiplied by the target while the trigger is active and 0 otherwise, e.g. max(queue, baseline("office-hours"))
+	// keeps at least the replicas of a cron trigger during its window
 	Formula string `json:"formula,omitempty"`
 	Target  string `json:"target,omitempty"`
 	// +optional
 	ActivationTarget string `json:"activationTarget,omitempty"`
 	// +optional
 	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
+	// Derivative is the name of a trigger whose metric is reported as its per-second rate of change across polls,
+	// a decreasing metric is reported as 0. It can't be combined with Formula
+	// +optional
+	Derivative string `json:"derivative,omitempty"`
 }
 
 // HorizontalPodAutoscalerConfig specifies horizontal scale config
 type HorizontalPodAutoscalerConfig struct {
 	// +optional
 	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+	// BehaviorRef references a ScalingBehaviorTemplate used as base for the behavior, fields set in Behavior take precedence
+	// +optional
+	BehaviorRef *ScalingBehaviorTemplateRef `json:"behaviorRef,omitempty"`
 	// +optional
 	Name string `json:"name,omitempty"`
 }
 
 // ScaleTarget holds the reference to the scale target Object
 type ScaleTarget struct {
-	Name string `json:"name"`
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Selector selects the workload to scale by its labels instead of its name, it must match exactly one workload
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 	// +optional
 	APIVersion string `json:"apiVersion,omitempty"`
 	// +optional
@@ -159,6 +310,9 @@ type ScaledObjectStatus struct {
 	ScaleTargetKind string `json:"scaleTargetKind,omitempty"`
 	// +optional
 	ScaleTargetGVKR *GroupVersionKindResource `json:"scaleTargetGVKR,omitempty"`
+	// ScaleTargetName is the name of the workload matched by scaleTargetRef.selector
+	// +optional
+	ScaleTargetName string `json:"scaleTargetName,omitempty"`
 	// +optional
 	OriginalReplicaCount *int32 `json:"originalReplicaCount,omitempty"`
 	// +optional
@@ -181,6 +335,33 @@ type ScaledObjectStatus struct {
 	TriggersTypes *string `json:"triggersTypes,omitempty"`
 	// +optional
 	AuthenticationsTypes *string `json:"authenticationsTypes,omitempty"`
+	// +optional
+	TriggersResolvedMetadata []TriggerResolvedMetadata `json:"triggersResolvedMetadata,omitempty"`
+	// NextPollTime is the time the triggers are expected to be polled next.
+	// With polling intervals shorter than 30 seconds it is only refreshed every 30 seconds.
+	// +optional
+	NextPollTime *metav1.Time `json:"nextPollTime,omitempty"`
+	// PollingBackoff is set while the polling interval is backed off because of consecutive failed polls
+	// +optional
+	PollingBackoff *PollingBackoffStatus `json:"pollingBackoff,omitempty"`
+}
+
+// PollingBackoffStatus describes the current backoff of the polling interval
+type PollingBackoffStatus struct {
+	// ConsecutiveErrors is the number of consecutive failed polls when the interval last changed
+	ConsecutiveErrors int32 `json:"consecutiveErrors"`
+	// Interval is the backed off polling interval, in seconds
+	Interval int32 `json:"interval"`
+}
+
+// TriggerResolvedMetadata contains the metadata a trigger has been built with,
+// after env and authentication resolution. Secret values are always redacted.
+type TriggerResolvedMetadata struct {
+	Type string `json:"type"`
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -201,6 +382,15 @@ func (so *ScaledObject) GenerateIdentifier() string {
 	return GenerateIdentifier("ScaledObject", so.Namespace, so.Name)
 }
 
+// ScaleTargetName returns the name of the workload to scale, either given in scaleTargetRef
+// or the one matched by scaleTargetRef.selector at the last reconcile
+func (so *ScaledObject) ScaleTargetName() string {
+	if so.Spec.ScaleTargetRef != nil && so.Spec.ScaleTargetRef.Name != "" {
+		return so.Spec.ScaleTargetRef.Name
+	}
+	return so.Status.ScaleTargetName
+}
+
 func (so *ScaledObject) HasPausedReplicaAnnotation() bool {
 	_, pausedReplicasAnnotationFound := so.GetAnnotations()[PausedReplicasAnnotation]
 	return pausedReplicasAnnotationFound
@@ -254,6 +444,56 @@ func (so *ScaledObject) GetHPAMaxReplicas() int32 {
 	return defaultHPAMaxReplicas
 }
 
+// scaleTargetSelectorGroupKinds are the kinds with a scale subresource whose workload can be selected
+// by scaleTargetRef.selector, the operator is allowed to list them
+var scaleTargetSelectorGroupKinds = []schema.GroupKind{
+	{Group: "apps", Kind: "Deployment"},
+	{Group: "apps", Kind: "StatefulSet"},
+	{Group: "apps", Kind: "ReplicaSet"},
+	{Group: "argoproj.io", Kind: "Rollout"},
+}
+
+// IsScaleTargetSelectorSupported checks if the workload of the given kind can be selected by scaleTargetRef.selector
+func IsScaleTargetSelectorSupported(groupKind schema.GroupKind) bool {
+	return slices.Contains(scaleTargetSelectorGroupKinds, groupKind)
+}
+
+// CheckScaleTargetRefIsValid checks that the workload to scale is given either by name or by a non-empty selector
+func CheckScaleTargetRefIsValid(scaledObject *ScaledObject) error {
+	ref := scaledObject.Spec.ScaleTargetRef
+	if ref == nil || (ref.Name == "" && ref.Selector == nil) {
+		return fmt.Errorf("ScaledObject.spec.scaleTargetRef.name or ScaledObject.spec.scaleTargetRef.selector is missing")
+	}
+	if ref.Name != "" && ref.Selector != nil {
+		return fmt.Errorf("only one of ScaledObject.spec.scaleTargetRef.name or ScaledObject.spec.scaleTargetRef.selector can be given")
+	}
+	if ref.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(ref.Selector)
+		if err != nil {
+			return fmt.Errorf("invalid ScaledObject.spec.scaleTargetRef.selector: %w", err)
+		}
+		if selector.Empty() {
+			return fmt.Errorf("ScaledObject.spec.scaleTargetRef.selector must not be empty")
+		}
+
+		groupKind := schema.GroupKind{Group: defaultGroup, Kind: defaultKind}
+		if ref.APIVersion != "" {
+			groupVersion, err := schema.ParseGroupVersion(ref.APIVersion)
+			if err != nil {
+				return fmt.Errorf("invalid ScaledObject.spec.scaleTargetRef.apiVersion: %w", err)
+			}
+			groupKind.Group = groupVersion.Group
+		}
+		if ref.Kind != "" {
+			groupKind.Kind = ref.Kind
+		}
+		if !IsScaleTargetSelectorSupported(groupKind) {
+			return fmt.Errorf("ScaledObject.spec.scaleTargetRef.selector isn't supported for %s, only for Deployment, StatefulSet, ReplicaSet and Argo Rollout", groupKind)
+		}
+	}
+	return nil
+}
+
 // checkReplicaCountBoundsAreValid checks that Idle/Min/Max ReplicaCount defined in ScaledObject are correctly specified
 // i.e. that Min is not greater than Max or Idle greater or equal to Min
 func CheckReplicaCountBoundsAreValid(scaledObject *ScaledObject) error {
@@ -271,6 +511,74 @@ func CheckReplicaCountBoundsAreValid(scaledObject *ScaledObject) error {
 		return fmt.Errorf("IdleReplicaCount=%d must be less than MinReplicaCount=%d", *scaledObject.Spec.IdleReplicaCount, min)
 	}
 
+	for _, schedule := range scaledObject.Spec.MinReplicaCountSchedules {
+		if _, _, err := schedule.GetCronSchedule(); err != nil {
+			return fmt.Errorf("invalid minReplicaCountSchedules: %w", err)
+		}
+		if schedule.MinReplicaCount < 0 {
+			return fmt.Errorf("MinReplicaCount=%d of schedule %q must be greater than or equal to 0", schedule.MinReplicaCount, schedule.Schedule)
+		}
+		if schedule.MinReplicaCount > max {
+			return fmt.Errorf("MinReplicaCount=%d of schedule %q must be less than MaxReplicaCount=%d", schedule.MinReplicaCount, schedule.Schedule, max)
+		}
+		if scaledObject.Spec.IdleReplicaCount != nil && *scaledObject.Spec.IdleReplicaCount >= schedule.MinReplicaCount {
+			return fmt.Errorf("IdleReplicaCount=%d must be less than MinReplicaCount=%d of schedule %q", *scaledObject.Spec.IdleReplicaCount, schedule.MinReplicaCount, schedule.Schedule)
+		}
+	}
+
+	if scaledObject.Spec.Advanced == nil {
+		return nil
+	}
+	for _, window := range scaledObject.Spec.Advanced.ReplicaWindows {
+		if _, _, err := window.GetCronSchedule(); err != nil {
+			return fmt.Errorf("invalid replicaWindows: %w", err)
+		}
+		if _, err := window.GetDuration(); err != nil {
+			return fmt.Errorf("invalid replicaWindows: %w", err)
+		}
+		// the replica counts the window doesn't override are the ones of the ScaledObject
+		windowMin, windowMax := min, max
+		if window.MinReplicaCount != nil {
+			windowMin = *window.MinReplicaCount
+		}
+		if window.MaxReplicaCount != nil {
+			windowMax = *window.MaxReplicaCount
+		}
+		if windowMin < 0 {
+			return fmt.Errorf("MinReplicaCount=%d of window %q must be greater than or equal to 0", windowMin, window.Schedule)
+		}
+		if windowMax < 1 {
+			return fmt.Errorf("MaxReplicaCount=%d of window %q must be greater than 0", windowMax, window.Schedule)
+		}
+		if windowMin > windowMax {
+			return fmt.Errorf("MinReplicaCount=%d of window %q must be less than MaxReplicaCount=%d", windowMin, window.Schedule, windowMax)
+		}
+		if window.MinReplicaCount != nil && scaledObject.Spec.IdleReplicaCount != nil && *scaledObject.Spec.IdleReplicaCount >= windowMin {
+			return fmt.Errorf("IdleReplicaCount=%d must be less than MinReplicaCount=%d of window %q", *scaledObject.Spec.IdleReplicaCount, windowMin, window.Schedule)
+		}
+		// a schedule can fire while the window is open
+		for _, schedule := range scaledObject.Spec.MinReplicaCountSchedules {
+			if schedule.MinReplicaCount > windowMax {
+				return fmt.Errorf("MinReplicaCount=%d of schedule %q must be less than MaxReplicaCount=%d of window %q", schedule.MinReplicaCount, schedule.Schedule, windowMax, window.Schedule)
+			}
+		}
+	}
+
+	return nil
+}
+
+// CheckScaleDownStabilizationWindowValid checks that the scaleDownStabilizationWindowSeconds shortcut is within the range allowed by the HPA
+// and isn't used with a behaviorRef, whose template would be silently overridden
+func CheckScaleDownStabilizationWindowValid(scaledObject *ScaledObject) error {
+	if scaledObject.Spec.Advanced == nil || scaledObject.Spec.Advanced.ScaleDownStabilizationWindowSeconds == nil {
+		return nil
+	}
+	if hpaConfig := scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig; hpaConfig != nil && hpaConfig.BehaviorRef != nil {
+		return fmt.Errorf("ScaleDownStabilizationWindowSeconds can't be used with behaviorRef, set the window in the ScalingBehaviorTemplate or in behavior instead")
+	}
+	if window := *scaledObject.Spec.Advanced.ScaleDownStabilizationWindowSeconds; window < 0 || window > maxStabilizationWindowSeconds {
+		return fmt.Errorf("ScaleDownStabilizationWindowSeconds=%d must be between 0 and %d", window, maxStabilizationWindowSeconds)
+	}
 	return nil
 }
 
@@ -0,0 +1,471 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestCheckReplicaCountBoundsAreValidWithMinReplicaCountSchedules(t *testing.T) {
+	maxReplicas := int32(10)
+	idleReplicas := int32(0)
+
+	tests := []struct {
+		name           string
+		schedules      []MinReplicaCountSchedule
+		idleReplicas   *int32
+		expectedErrMsg string
+	}{
+		{
+			name: "valid schedules",
+			schedules: []MinReplicaCountSchedule{
+				{Schedule: "0 8 * * 1-5", Timezone: "Europe/Paris", MinReplicaCount: 10},
+				{Schedule: "0 20 * * *", MinReplicaCount: 0},
+			},
+		},
+		{
+			name:           "invalid schedule",
+			schedules:      []MinReplicaCountSchedule{{Schedule: "every morning", MinReplicaCount: 2}},
+			expectedErrMsg: "invalid minReplicaCountSchedules: error parsing schedule",
+		},
+		{
+			name:           "invalid timezone",
+			schedules:      []MinReplicaCountSchedule{{Schedule: "0 8 * * *", Timezone: "Mars/Olympus", MinReplicaCount: 2}},
+			expectedErrMsg: "invalid minReplicaCountSchedules: unable to load timezone",
+		},
+		{
+			name:           "negative minReplicaCount",
+			schedules:      []MinReplicaCountSchedule{{Schedule: "0 8 * * *", MinReplicaCount: -1}},
+			expectedErrMsg: "MinReplicaCount=-1 of schedule \"0 8 * * *\" must be greater than or equal to 0",
+		},
+		{
+			name:           "minReplicaCount greater than maxReplicaCount",
+			schedules:      []MinReplicaCountSchedule{{Schedule: "0 8 * * *", MinReplicaCount: 11}},
+			expectedErrMsg: "MinReplicaCount=11 of schedule \"0 8 * * *\" must be less than MaxReplicaCount=10",
+		},
+		{
+			name:           "minReplicaCount not greater than idleReplicaCount",
+			schedules:      []MinReplicaCountSchedule{{Schedule: "0 8 * * *", MinReplicaCount: 0}},
+			idleReplicas:   &idleReplicas,
+			expectedErrMsg: "IdleReplicaCount=0 must be less than MinReplicaCount=0 of schedule \"0 8 * * *\"",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scaledObject := &ScaledObject{
+				Spec: ScaledObjectSpec{
+					MaxReplicaCount:          &maxReplicas,
+					IdleReplicaCount:         test.idleReplicas,
+					MinReplicaCountSchedules: test.schedules,
+				},
+			}
+			if test.idleReplicas != nil {
+				minReplicas := int32(1)
+				scaledObject.Spec.MinReplicaCount = &minReplicas
+			}
+
+			err := CheckReplicaCountBoundsAreValid(scaledObject)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
+
+func TestCheckReplicaCountBoundsAreValidWithReplicaWindows(t *testing.T) {
+	minReplicas := int32(2)
+	maxReplicas := int32(10)
+	idleReplicas := int32(0)
+	replicas := func(count int32) *int32 { return &count }
+
+	tests := []struct {
+		name           string
+		windows        []ReplicaWindow
+		schedules      []MinReplicaCountSchedule
+		idleReplicas   *int32
+		expectedErrMsg string
+	}{
+		{
+			name: "valid windows",
+			windows: []ReplicaWindow{
+				{Schedule: "0 8 * * 1-5", Timezone: "Europe/Paris", Duration: "10h", MinReplicaCount: replicas(5), MaxReplicaCount: replicas(50)},
+				{Schedule: "0 0 * * 6", Duration: "48h", MaxReplicaCount: replicas(3)},
+			},
+		},
+		{
+			name:           "invalid schedule",
+			windows:        []ReplicaWindow{{Schedule: "every morning", Duration: "1h"}},
+			expectedErrMsg: "invalid replicaWindows: error parsing schedule",
+		},
+		{
+			name:           "invalid timezone",
+			windows:        []ReplicaWindow{{Schedule: "0 8 * * *", Timezone: "Mars/Olympus", Duration: "1h"}},
+			expectedErrMsg: "invalid replicaWindows: unable to load timezone",
+		},
+		{
+			name:           "invalid duration",
+			windows:        []ReplicaWindow{{Schedule: "0 8 * * *", Duration: "all day"}},
+			expectedErrMsg: "invalid replicaWindows: error parsing duration",
+		},
+		{
+			name:           "duration not positive",
+			windows:        []ReplicaWindow{{Schedule: "0 8 * * *", Duration: "0s"}},
+			expectedErrMsg: "invalid replicaWindows: duration \"0s\" must be positive",
+		},
+		{
+			name:           "negative minReplicaCount",
+			windows:        []ReplicaWindow{{Schedule: "0 8 * * *", Duration: "1h", MinReplicaCount: replicas(-1)}},
+			expectedErrMsg: "MinReplicaCount=-1 of window \"0 8 * * *\" must be greater than or equal to 0",
+		},
+		{
+			name:           "maxReplicaCount not positive",
+			windows:        []ReplicaWindow{{Schedule: "0 8 * * *", Duration: "1h", MinReplicaCount: replicas(0), MaxReplicaCount: replicas(0)}},
+			expectedErrMsg: "MaxReplicaCount=0 of window \"0 8 * * *\" must be greater than 0",
+		},
+		{
+			name:           "minReplicaCount greater than maxReplicaCount of the ScaledObject",
+			windows:        []ReplicaWindow{{Schedule: "0 8 * * *", Duration: "1h", MinReplicaCount: replicas(11)}},
+			expectedErrMsg: "MinReplicaCount=11 of window \"0 8 * * *\" must be less than MaxReplicaCount=10",
+		},
+		{
+			name:           "maxReplicaCount less than minReplicaCount of the ScaledObject",
+			windows:        []ReplicaWindow{{Schedule: "0 8 * * *", Duration: "1h", MaxReplicaCount: replicas(1)}},
+			expectedErrMsg: "MinReplicaCount=2 of window \"0 8 * * *\" must be less than MaxReplicaCount=1",
+		},
+		{
+			name:      "maxReplicaCount not less than the minReplicaCount of the schedules",
+			windows:   []ReplicaWindow{{Schedule: "0 0 * * 6", Duration: "48h", MaxReplicaCount: replicas(4)}},
+			schedules: []MinReplicaCountSchedule{{Schedule: "0 8 * * *", MinReplicaCount: 4}},
+		},
+		{
+			name:           "maxReplicaCount less than the minReplicaCount of a schedule",
+			windows:        []ReplicaWindow{{Schedule: "0 0 * * 6", Duration: "48h", MaxReplicaCount: replicas(3)}},
+			schedules:      []MinReplicaCountSchedule{{Schedule: "0 8 * * *", MinReplicaCount: 4}},
+			expectedErrMsg: "MinReplicaCount=4 of schedule \"0 8 * * *\" must be less than MaxReplicaCount=3 of window \"0 0 * * 6\"",
+		},
+		{
+			name:           "minReplicaCount not greater than idleReplicaCount",
+			windows:        []ReplicaWindow{{Schedule: "0 8 * * *", Duration: "1h", MinReplicaCount: replicas(0)}},
+			idleReplicas:   &idleReplicas,
+			expectedErrMsg: "IdleReplicaCount=0 must be less than MinReplicaCount=0 of window \"0 8 * * *\"",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scaledObject := &ScaledObject{
+				Spec: ScaledObjectSpec{
+					MinReplicaCount:          &minReplicas,
+					MaxReplicaCount:          &maxReplicas,
+					IdleReplicaCount:         test.idleReplicas,
+					MinReplicaCountSchedules: test.schedules,
+					Advanced:                 &AdvancedConfig{ReplicaWindows: test.windows},
+				},
+			}
+
+			err := CheckReplicaCountBoundsAreValid(scaledObject)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
+
+func TestCheckScaleTargetRefIsValid(t *testing.T) {
+	tests := []struct {
+		name           string
+		scaleTargetRef *ScaleTarget
+		expectedErrMsg string
+	}{
+		{
+			name:           "name",
+			scaleTargetRef: &ScaleTarget{Name: "my-deployment"},
+		},
+		{
+			name:           "selector",
+			scaleTargetRef: &ScaleTarget{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-app"}}},
+		},
+		{
+			name:           "missing scaleTargetRef",
+			expectedErrMsg: "ScaledObject.spec.scaleTargetRef.name or ScaledObject.spec.scaleTargetRef.selector is missing",
+		},
+		{
+			name:           "neither name nor selector",
+			scaleTargetRef: &ScaleTarget{Kind: "Deployment"},
+			expectedErrMsg: "ScaledObject.spec.scaleTargetRef.name or ScaledObject.spec.scaleTargetRef.selector is missing",
+		},
+		{
+			name: "both name and selector",
+			scaleTargetRef: &ScaleTarget{
+				Name:     "my-deployment",
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-app"}},
+			},
+			expectedErrMsg: "only one of ScaledObject.spec.scaleTargetRef.name or ScaledObject.spec.scaleTargetRef.selector can be given",
+		},
+		{
+			name: "selector of a StatefulSet",
+			scaleTargetRef: &ScaleTarget{
+				APIVersion: "apps/v1",
+				Kind:       "StatefulSet",
+				Selector:   &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-app"}},
+			},
+		},
+		{
+			name: "selector of a kind without scale subresource",
+			scaleTargetRef: &ScaleTarget{
+				APIVersion: "v1",
+				Kind:       "Pod",
+				Selector:   &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-app"}},
+			},
+			expectedErrMsg: "ScaledObject.spec.scaleTargetRef.selector isn't supported for Pod",
+		},
+		{
+			name: "selector of a custom resource",
+			scaleTargetRef: &ScaleTarget{
+				APIVersion: "example.com/v1",
+				Kind:       "Workload",
+				Selector:   &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-app"}},
+			},
+			expectedErrMsg: "ScaledObject.spec.scaleTargetRef.selector isn't supported for Workload.example.com",
+		},
+		{
+			name:           "empty selector",
+			scaleTargetRef: &ScaleTarget{Selector: &metav1.LabelSelector{}},
+			expectedErrMsg: "ScaledObject.spec.scaleTargetRef.selector must not be empty",
+		},
+		{
+			name: "invalid selector",
+			scaleTargetRef: &ScaleTarget{Selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Matches"}},
+			}},
+			expectedErrMsg: "invalid ScaledObject.spec.scaleTargetRef.selector",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scaledObject := &ScaledObject{Spec: ScaledObjectSpec{ScaleTargetRef: test.scaleTargetRef}}
+
+			err := CheckScaleTargetRefIsValid(scaledObject)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
+
+func TestCheckScaleDownStabilizationWindowValid(t *testing.T) {
+	tests := []struct {
+		name           string
+		advanced       *AdvancedConfig
+		expectedErrMsg string
+	}{
+		{name: "no advanced config"},
+		{name: "no window", advanced: &AdvancedConfig{}},
+		{name: "zero", advanced: &AdvancedConfig{ScaleDownStabilizationWindowSeconds: ptr.To[int32](0)}},
+		{name: "one hour", advanced: &AdvancedConfig{ScaleDownStabilizationWindowSeconds: ptr.To[int32](3600)}},
+		{
+			name:           "negative",
+			advanced:       &AdvancedConfig{ScaleDownStabilizationWindowSeconds: ptr.To[int32](-1)},
+			expectedErrMsg: "ScaleDownStabilizationWindowSeconds=-1 must be between 0 and 3600",
+		},
+		{
+			name:           "over one hour",
+			advanced:       &AdvancedConfig{ScaleDownStabilizationWindowSeconds: ptr.To[int32](3601)},
+			expectedErrMsg: "ScaleDownStabilizationWindowSeconds=3601 must be between 0 and 3600",
+		},
+		{
+			name: "with behavior",
+			advanced: &AdvancedConfig{
+				ScaleDownStabilizationWindowSeconds: ptr.To[int32](60),
+				HorizontalPodAutoscalerConfig:       &HorizontalPodAutoscalerConfig{Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{}},
+			},
+		},
+		{
+			name: "with behaviorRef",
+			advanced: &AdvancedConfig{
+				ScaleDownStabilizationWindowSeconds: ptr.To[int32](60),
+				HorizontalPodAutoscalerConfig:       &HorizontalPodAutoscalerConfig{BehaviorRef: &ScalingBehaviorTemplateRef{Name: "shared"}},
+			},
+			expectedErrMsg: "ScaleDownStabilizationWindowSeconds can't be used with behaviorRef, set the window in the ScalingBehaviorTemplate or in behavior instead",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scaledObject := &ScaledObject{Spec: ScaledObjectSpec{Advanced: test.advanced}}
+
+			err := CheckScaleDownStabilizationWindowValid(scaledObject)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
+
+func TestScaleTargetName(t *testing.T) {
+	byName := &ScaledObject{Spec: ScaledObjectSpec{ScaleTargetRef: &ScaleTarget{Name: "my-deployment"}}}
+	assert.Equal(t, "my-deployment", byName.ScaleTargetName())
+
+	bySelector := &ScaledObject{
+		Spec:   ScaledObjectSpec{ScaleTargetRef: &ScaleTarget{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-app"}}}},
+		Status: ScaledObjectStatus{ScaleTargetName: "matched-deployment"},
+	}
+	assert.Equal(t, "matched-deployment", bySelector.ScaleTargetName())
+
+	notResolved := &ScaledObject{Spec: ScaledObjectSpec{ScaleTargetRef: &ScaleTarget{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-app"}}}}}
+	assert.Equal(t, "", notResolved.ScaleTargetName())
+}
+
+func TestValidateAndCompileScalingModifiersDerivative(t *testing.T) {
+	triggers := []ScaleTriggers{
+		{Name: "backlog", Type: "rabbitmq"},
+		{Name: "usage", Type: "cpu"},
+	}
+
+	tests := []struct {
+		name           string
+		modifiers      ScalingModifiers
+		expectedErrMsg string
+	}{
+		{
+			name:      "valid derivative",
+			modifiers: ScalingModifiers{Derivative: "backlog", Target: "2", ActivationTarget: "0.5"},
+		},
+		{
+			name:           "missing target",
+			modifiers:      ScalingModifiers{Derivative: "backlog"},
+			expectedErrMsg: "derivative is given but target is empty",
+		},
+		{
+			name:           "with formula",
+			modifiers:      ScalingModifiers{Derivative: "backlog", Formula: "backlog * 2", Target: "2"},
+			expectedErrMsg: "formula and derivative can't be given together",
+		},
+		{
+			name:           "unknown trigger",
+			modifiers:      ScalingModifiers{Derivative: "lag", Target: "2"},
+			expectedErrMsg: "no trigger named \"lag\" for derivative",
+		},
+		{
+			name:           "cpu trigger",
+			modifiers:      ScalingModifiers{Derivative: "usage", Target: "2"},
+			expectedErrMsg: "derivative of cpu trigger \"usage\" isn't supported",
+		},
+		{
+			name:           "invalid target",
+			modifiers:      ScalingModifiers{Derivative: "backlog", Target: "two"},
+			expectedErrMsg: "error validating target in ScalingModifiers",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{
+				Spec: ScaledObjectSpec{
+					Advanced: &AdvancedConfig{ScalingModifiers: test.modifiers},
+					Triggers: triggers,
+				},
+			}
+
+			program, err := ValidateAndCompileScalingModifiers(so)
+			assert.Nil(t, program)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
+
+func TestValidateAndCompileScalingModifiersBaseline(t *testing.T) {
+	tests := []struct {
+		name           string
+		formula        string
+		triggers       []ScaleTriggers
+		expectedErrMsg string
+	}{
+		{
+			name:     "max with baseline",
+			formula:  `max(queue, baseline("office-hours"))`,
+			triggers: []ScaleTriggers{{Name: "queue", Type: "rabbitmq"}, {Name: "office-hours", Type: "cron"}},
+		},
+		{
+			name:           "unknown trigger",
+			formula:        `max(queue, baseline("weekend"))`,
+			triggers:       []ScaleTriggers{{Name: "queue", Type: "rabbitmq"}, {Name: "office-hours", Type: "cron"}},
+			expectedErrMsg: "no trigger named \"weekend\" for baseline",
+		},
+		{
+			name:           "cpu trigger",
+			formula:        `max(queue, baseline("usage"))`,
+			triggers:       []ScaleTriggers{{Name: "queue", Type: "rabbitmq"}, {Name: "usage", Type: "cpu"}},
+			expectedErrMsg: "no trigger named \"usage\" for baseline",
+		},
+		{
+			name:           "weighted trigger",
+			formula:        `max(queue, baseline("office-hours"))`,
+			triggers:       []ScaleTriggers{{Name: "queue", Type: "rabbitmq", Weight: "2"}, {Name: "office-hours", Type: "cron"}},
+			expectedErrMsg: "property \"weight\" of trigger \"queue\" is not supported with ScalingModifiers",
+		},
+		{
+			name:     "trigger named baseline",
+			formula:  `max(queue, baseline)`,
+			triggers: []ScaleTriggers{{Name: "queue", Type: "rabbitmq"}, {Name: "baseline", Type: "cron"}},
+		},
+		{
+			name:           "baseline function shadowed by a trigger",
+			formula:        `max(queue, baseline("queue"))`,
+			triggers:       []ScaleTriggers{{Name: "queue", Type: "rabbitmq"}, {Name: "baseline", Type: "cron"}},
+			expectedErrMsg: "error validating formula in ScalingModifiers",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{
+				Spec: ScaledObjectSpec{
+					Advanced: &AdvancedConfig{ScalingModifiers: ScalingModifiers{Formula: test.formula, Target: "10"}},
+					Triggers: test.triggers,
+				},
+			}
+
+			program, err := ValidateAndCompileScalingModifiers(so)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+				assert.NotNil(t, program)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/expr-lang/expr"
 	"github.com/expr-lang/expr/vm"
+	"github.com/prometheus/prometheus/promql/parser"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
@@ -47,6 +48,7 @@ var scaledobjectlog = logf.Log.WithName("scaledobject-validation-webhook")
 var kc client.Client
 var cacheMissToDirectClient bool
 var directClient client.Client
+var apiReader client.Reader
 var restMapper meta.RESTMapper
 
 var memoryString = "memory"
@@ -54,6 +56,8 @@ var cpuString = "cpu"
 
 func (so *ScaledObject) SetupWebhookWithManager(mgr ctrl.Manager, cacheMissFallback bool) error {
 	kc = mgr.GetClient()
+	// secrets are read without the cache, not to watch all the secrets of the cluster
+	apiReader = mgr.GetAPIReader()
 	restMapper = mgr.GetRESTMapper()
 	cacheMissToDirectClient = cacheMissFallback
 	if cacheMissToDirectClient {
@@ -149,11 +153,13 @@ func validateWorkload(so *ScaledObject, action string, dryRun bool) (admission.W
 	metricscollector.RecordScaledObjectValidatingTotal(so.Namespace, action)
 
 	verifyFunctions := []func(*ScaledObject, string, bool) error{
+		verifyScaleTargetRef,
 		verifyCPUMemoryScalers,
 		verifyScaledObjects,
 		verifyHpas,
 		verifyReplicaCount,
 		verifyFallback,
+		verifyScaleDownStabilizationWindow,
 	}
 
 	for i := range verifyFunctions {
@@ -174,8 +180,22 @@ func validateWorkload(so *ScaledObject, action string, dryRun bool) (admission.W
 		}
 	}
 
+	warnings, err := verifyTriggerAuthentications(so, action)
+	if err != nil {
+		return warnings, err
+	}
+
 	scaledobjectlog.V(1).Info(fmt.Sprintf("scaledobject %s is valid", so.Name))
-	return nil, nil
+	return warnings, nil
+}
+
+func verifyScaleTargetRef(incomingSo *ScaledObject, action string, _ bool) error {
+	err := CheckScaleTargetRefIsValid(incomingSo)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-scale-target-ref")
+	}
+	return err
 }
 
 func verifyReplicaCount(incomingSo *ScaledObject, action string, _ bool) error {
@@ -196,6 +216,15 @@ func verifyFallback(incomingSo *ScaledObject, action string, _ bool) error {
 	return nil
 }
 
+func verifyScaleDownStabilizationWindow(incomingSo *ScaledObject, action string, _ bool) error {
+	err := CheckScaleDownStabilizationWindowValid(incomingSo)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-stabilization-window")
+	}
+	return err
+}
+
 func verifyTriggers(incomingObject interface{}, action string, _ bool) error {
 	var triggers []ScaleTriggers
 	var name string
@@ -209,6 +238,11 @@ func verifyTriggers(incomingObject interface{}, action string, _ bool) error {
 		triggers = obj.Spec.Triggers
 		name = obj.Name
 		namespace = obj.Namespace
+		if err := checkScaledJobTriggers(triggers); err != nil {
+			scaledobjectlog.WithValues("name", name).Error(err, "validation error")
+			metricscollector.RecordScaledObjectValidatingErrors(namespace, action, "incorrect-triggers")
+			return err
+		}
 	default:
 		return fmt.Errorf("unknown scalable object type %v", incomingObject)
 	}
@@ -217,11 +251,130 @@ func verifyTriggers(incomingObject interface{}, action string, _ bool) error {
 	if err != nil {
 		scaledobjectlog.WithValues("name", name).Error(err, "validation error")
 		metricscollector.RecordScaledObjectValidatingErrors(namespace, action, "incorrect-triggers")
+		return err
+	}
+
+	err = validatePrometheusQueries(triggers)
+	if err != nil {
+		scaledobjectlog.WithValues("name", name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(namespace, action, "incorrect-prometheus-query")
 	}
 	return err
 }
 
+// verifyTriggerAuthentications checks that the (Cluster)TriggerAuthentications referenced by the triggers exist
+// and that the keys of the secrets they reference are present, a secret that can't be read is only a warning
+func verifyTriggerAuthentications(incomingSo *ScaledObject, action string) (admission.Warnings, error) {
+	ctx := context.Background()
+	var warnings admission.Warnings
+	for i, trigger := range incomingSo.Spec.Triggers {
+		if trigger.AuthenticationRef == nil {
+			continue
+		}
+		triggerName := trigger.Name
+		if triggerName == "" {
+			triggerName = strconv.Itoa(i)
+		}
+
+		authWarnings, err := verifyAuthenticationRef(ctx, incomingSo.Namespace, trigger.AuthenticationRef)
+		for _, warning := range authWarnings {
+			warnings = append(warnings, fmt.Sprintf("trigger %s: %s", triggerName, warning))
+		}
+		if err != nil {
+			err = fmt.Errorf("trigger %s: %w", triggerName, err)
+			scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+			metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-authentication-ref")
+			return warnings, err
+		}
+	}
+	return warnings, nil
+}
+
+// verifyAuthenticationRef returns an error when the (Cluster)TriggerAuthentication of authRef or a secret or key
+// it references is missing, and a warning for the secrets the webhooks aren't allowed to read.
+// The secrets of ClusterTriggerAuthentications live in the namespace of KEDA and aren't checked, as the errors
+// would tell whether they exist to users that aren't allowed to read them
+func verifyAuthenticationRef(ctx context.Context, namespace string, authRef *AuthenticationRef) (admission.Warnings, error) {
+	switch authRef.Kind {
+	case "", "TriggerAuthentication":
+	case "ClusterTriggerAuthentication":
+		clusterTriggerAuth := &ClusterTriggerAuthentication{}
+		if err := getFromCacheOrDirect(ctx, types.NamespacedName{Name: authRef.Name}, clusterTriggerAuth); err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil, fmt.Errorf("ClusterTriggerAuthentication %s not found", authRef.Name)
+			}
+			return nil, err
+		}
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown authenticationRef kind %s", authRef.Kind)
+	}
+
+	triggerAuth := &TriggerAuthentication{}
+	if err := getFromCacheOrDirect(ctx, types.NamespacedName{Name: authRef.Name, Namespace: namespace}, triggerAuth); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("TriggerAuthentication %s/%s not found", namespace, authRef.Name)
+		}
+		return nil, err
+	}
+
+	var warnings admission.Warnings
+	secrets := map[string]*corev1.Secret{}
+	for _, secretRef := range triggerAuth.Spec.SecretTargetRef {
+		secret, found := secrets[secretRef.Name]
+		if !found {
+			secret = &corev1.Secret{}
+			err := apiReader.Get(ctx, types.NamespacedName{Name: secretRef.Name, Namespace: namespace}, secret)
+			switch {
+			case kerrors.IsNotFound(err):
+				return warnings, fmt.Errorf("secret %s/%s referenced by %s not found", namespace, secretRef.Name, authRef.Name)
+			case kerrors.IsForbidden(err):
+				warnings = append(warnings, fmt.Sprintf("secret %s/%s referenced by %s can't be read by the webhooks, its keys haven't been checked", namespace, secretRef.Name, authRef.Name))
+				secret = nil
+			case err != nil:
+				return warnings, err
+			}
+			secrets[secretRef.Name] = secret
+		}
+		if secret == nil {
+			continue
+		}
+		if _, ok := secret.Data[secretRef.Key]; !ok {
+			return warnings, fmt.Errorf("key %s not found in secret %s/%s referenced by %s", secretRef.Key, namespace, secretRef.Name, authRef.Name)
+		}
+	}
+	return warnings, nil
+}
+
+// validatePrometheusQueries checks the PromQL syntax of the queries of the prometheus triggers,
+// whether the queried series exist can only be known at runtime
+func validatePrometheusQueries(triggers []ScaleTriggers) error {
+	for i, trigger := range triggers {
+		if trigger.Type != "prometheus" {
+			continue
+		}
+		query := trigger.Metadata["query"]
+		if query == "" {
+			// missing parameters are reported by the scaler
+			continue
+		}
+		if _, err := parser.ParseExpr(query); err != nil {
+			name := trigger.Name
+			if name == "" {
+				name = strconv.Itoa(i)
+			}
+			return fmt.Errorf("invalid query in prometheus trigger %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
 func verifyHpas(incomingSo *ScaledObject, action string, _ bool) error {
+	// a workload matched by a selector is only known once the ScaledObject has been reconciled
+	if incomingSo.ScaleTargetName() == "" {
+		return nil
+	}
+
 	hpaList := &autoscalingv2.HorizontalPodAutoscalerList{}
 	opt := &client.ListOptions{
 		Namespace: incomingSo.Namespace,
@@ -252,7 +405,7 @@ func verifyHpas(incomingSo *ScaledObject, action string, _ bool) error {
 		}
 
 		if hpaGvkr.GVKString() == incomingSoGvkr.GVKString() &&
-			hpa.Spec.ScaleTargetRef.Name == incomingSo.Spec.ScaleTargetRef.Name {
+			hpa.Spec.ScaleTargetRef.Name == incomingSo.ScaleTargetName() {
 			owned := false
 			for _, owner := range hpa.OwnerReferences {
 				if owner.Kind == incomingSo.Kind {
@@ -268,7 +421,7 @@ func verifyHpas(incomingSo *ScaledObject, action string, _ bool) error {
 					incomingSo.Spec.Advanced.HorizontalPodAutoscalerConfig.Name == hpa.Name {
 					scaledobjectlog.Info(fmt.Sprintf("%s hpa ownership being transferred to %s", hpa.Name, incomingSo.Name))
 				} else {
-					err = fmt.Errorf("the workload '%s' of type '%s' is already managed by the hpa '%s'", incomingSo.Spec.ScaleTargetRef.Name, incomingSoGvkr.GVKString(), hpa.Name)
+					err = fmt.Errorf("the workload '%s' of type '%s' is already managed by the hpa '%s'", incomingSo.ScaleTargetName(), incomingSoGvkr.GVKString(), hpa.Name)
 					scaledobjectlog.Error(err, "validation error")
 					metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "other-hpa")
 					return err
@@ -280,6 +433,10 @@ func verifyHpas(incomingSo *ScaledObject, action string, _ bool) error {
 }
 
 func verifyScaledObjects(incomingSo *ScaledObject, action string, _ bool) error {
+	if incomingSo.ScaleTargetName() == "" {
+		return nil
+	}
+
 	soList := &ScaledObjectList{}
 	opt := &client.ListOptions{
 		Namespace: incomingSo.Namespace,
@@ -310,8 +467,8 @@ func verifyScaledObjects(incomingSo *ScaledObject, action string, _ bool) error
 		}
 
 		if soGckr.GVKString() == incomingSoGckr.GVKString() &&
-			so.Spec.ScaleTargetRef.Name == incomingSo.Spec.ScaleTargetRef.Name {
-			err = fmt.Errorf("the workload '%s' of type '%s' is already managed by the ScaledObject '%s'", so.Spec.ScaleTargetRef.Name, incomingSoGckr.GVKString(), so.Name)
+			so.ScaleTargetName() == incomingSo.ScaleTargetName() {
+			err = fmt.Errorf("the workload '%s' of type '%s' is already managed by the ScaledObject '%s'", so.ScaleTargetName(), incomingSoGckr.GVKString(), so.Name)
 			scaledobjectlog.Error(err, "validation error")
 			metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "other-scaled-object")
 			return err
@@ -351,7 +508,7 @@ func getFromCacheOrDirect(ctx context.Context, key client.ObjectKey, obj client.
 }
 
 func verifyCPUMemoryScalers(incomingSo *ScaledObject, action string, dryRun bool) error {
-	if dryRun {
+	if dryRun || incomingSo.ScaleTargetName() == "" {
 		return nil
 	}
 
@@ -361,7 +518,7 @@ func verifyCPUMemoryScalers(incomingSo *ScaledObject, action string, dryRun bool
 			if podSpec == nil {
 				key := types.NamespacedName{
 					Namespace: incomingSo.Namespace,
-					Name:      incomingSo.Spec.ScaleTargetRef.Name,
+					Name:      incomingSo.ScaleTargetName(),
 				}
 				incomingSoGvkr, err := ParseGVKR(restMapper, incomingSo.Spec.ScaleTargetRef.APIVersion, incomingSo.Spec.ScaleTargetRef.Kind)
 				if err != nil {
@@ -436,6 +593,23 @@ func verifyCPUMemoryScalers(incomingSo *ScaledObject, action string, dryRun bool
 func ValidateAndCompileScalingModifiers(so *ScaledObject) (*vm.Program, error) {
 	sm := so.Spec.Advanced.ScalingModifiers
 
+	// the triggers are weighted in the formula itself
+	for _, trigger := range so.Spec.Triggers {
+		if trigger.Weight != "" {
+			return nil, fmt.Errorf("property \"weight\" of trigger %q is not supported with ScalingModifiers", trigger.Name)
+		}
+	}
+
+	if sm.Derivative != "" {
+		if err := validateScalingModifiersDerivative(so); err != nil {
+			return nil, errors.Join(fmt.Errorf("error validating derivative in ScalingModifiers"), err)
+		}
+		if err := validateScalingModifiersTarget(so); err != nil {
+			return nil, errors.Join(fmt.Errorf("error validating target in ScalingModifiers"), err)
+		}
+		return nil, nil
+	}
+
 	if sm.Formula == "" {
 		return nil, fmt.Errorf("error ScalingModifiers.Formula is mandatory")
 	}
@@ -459,6 +633,10 @@ func ValidateAndCompileScalingModifiers(so *ScaledObject) (*vm.Program, error) {
 	return compiledFormula, nil
 }
 
+// ScalingModifiersBaselineFunction is the name of the formula function returning the value of a trigger
+// multiplied by the target while the trigger is active, and 0 otherwise
+const ScalingModifiersBaselineFunction = "baseline"
+
 // validateScalingModifiersFormula helps validate the ScalingModifiers struct,
 // specifically the formula.
 func validateScalingModifiersFormula(so *ScaledObject) (*vm.Program, error) {
@@ -478,7 +656,7 @@ func validateScalingModifiersFormula(so *ScaledObject) (*vm.Program, error) {
 
 	// Compile & Run with dummy values to determine if all triggers in formula are
 	// defined (have names)
-	triggersMap := make(map[string]float64)
+	triggersMap := make(map[string]any)
 	for _, trig := range so.Spec.Triggers {
 		// if resource metrics are given, skip
 		if trig.Type == cpuString || trig.Type == memoryString {
@@ -488,6 +666,15 @@ func validateScalingModifiersFormula(so *ScaledObject) (*vm.Program, error) {
 			triggersMap[trig.Name] = dummyValue
 		}
 	}
+	// a trigger named like the baseline function takes precedence over it
+	if _, found := triggersMap[ScalingModifiersBaselineFunction]; !found {
+		triggersMap[ScalingModifiersBaselineFunction] = func(trigger string) (float64, error) {
+			if _, found := triggersMap[trigger].(float64); !found {
+				return 0, fmt.Errorf("no trigger named %q for %s", trigger, ScalingModifiersBaselineFunction)
+			}
+			return dummyValue, nil
+		}
+	}
 	compiled, err := expr.Compile(sm.Formula, expr.Env(triggersMap), expr.AsFloat64())
 	if err != nil {
 		return nil, err
@@ -499,6 +686,31 @@ func validateScalingModifiersFormula(so *ScaledObject) (*vm.Program, error) {
 	return compiled, nil
 }
 
+// validateScalingModifiersDerivative helps validate the ScalingModifiers struct,
+// specifically the derivative.
+func validateScalingModifiersDerivative(so *ScaledObject) error {
+	sm := so.Spec.Advanced.ScalingModifiers
+
+	if sm.Formula != "" {
+		return fmt.Errorf("formula and derivative can't be given together")
+	}
+	// derivative needs target because it's always transformed to composite-scaler
+	if sm.Target == "" {
+		return fmt.Errorf("derivative is given but target is empty")
+	}
+
+	for _, trig := range so.Spec.Triggers {
+		if trig.Name != sm.Derivative {
+			continue
+		}
+		if trig.Type == cpuString || trig.Type == memoryString {
+			return fmt.Errorf("derivative of %s trigger %q isn't supported", trig.Type, sm.Derivative)
+		}
+		return nil
+	}
+	return fmt.Errorf("no trigger named %q for derivative", sm.Derivative)
+}
+
 func validateScalingModifiersTarget(so *ScaledObject) error {
 	sm := so.Spec.Advanced.ScalingModifiers
 
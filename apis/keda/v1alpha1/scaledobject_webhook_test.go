@@ -18,6 +18,8 @@ package v1alpha1
 
 import (
 	"context"
+	"errors"
+	"testing"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -25,11 +27,16 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 var _ = It("should validate the so creation when there isn't any hpa", func() {
@@ -665,6 +672,58 @@ var _ = It("should validate empty triggers in ScaledObject", func() {
 	}).Should(HaveOccurred())
 })
 
+var _ = It("should validate the so creation with a valid prometheus query", func() {
+	namespaceName := "prometheus-query-good"
+	namespace := createNamespace(namespaceName)
+	workload := createDeployment(namespaceName, false, false)
+
+	so := createScaledObject(soName, namespaceName, workloadName, "apps/v1", "Deployment", false, map[string]string{}, "")
+	so.Spec.Triggers = append(so.Spec.Triggers, ScaleTriggers{
+		Type: "prometheus",
+		Metadata: map[string]string{
+			"serverAddress": "http://prometheus:9090",
+			"query":         `sum(rate(http_requests_total{job="api"}[2m]))`,
+			"threshold":     "100",
+		},
+	})
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = k8sClient.Create(context.Background(), workload)
+	Expect(err).ToNot(HaveOccurred())
+
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), so)
+	}).ShouldNot(HaveOccurred())
+})
+
+var _ = It("shouldn't validate the so creation with a malformed prometheus query", func() {
+	namespaceName := "prometheus-query-malformed"
+	namespace := createNamespace(namespaceName)
+	workload := createDeployment(namespaceName, false, false)
+
+	so := createScaledObject(soName, namespaceName, workloadName, "apps/v1", "Deployment", false, map[string]string{}, "")
+	so.Spec.Triggers = append(so.Spec.Triggers, ScaleTriggers{
+		Type: "prometheus",
+		Metadata: map[string]string{
+			"serverAddress": "http://prometheus:9090",
+			"query":         `sum(rate(http_requests_total{job="api"}[2m])`,
+			"threshold":     "100",
+		},
+	})
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = k8sClient.Create(context.Background(), workload)
+	Expect(err).ToNot(HaveOccurred())
+
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), so)
+	}).Should(HaveOccurred())
+})
+
 // ============================ SCALING MODIFIERS ============================ \\
 // =========================================================================== \\
 
@@ -1349,3 +1408,130 @@ func createLimitRange(name, namespace string, limitType v1.LimitType, cpu, memor
 		},
 	}
 }
+
+func TestValidatePrometheusQueries(t *testing.T) {
+	g := NewWithT(t)
+
+	prometheusTrigger := func(query string) ScaleTriggers {
+		return ScaleTriggers{Type: "prometheus", Metadata: map[string]string{"query": query}}
+	}
+
+	g.Expect(validatePrometheusQueries([]ScaleTriggers{prometheusTrigger(`sum(rate(http_requests_total{job="api"}[2m]))`)})).To(Succeed())
+	g.Expect(validatePrometheusQueries([]ScaleTriggers{prometheusTrigger(`kube_deployment_status_replicas{deployment="unknown"} > 5`)})).To(Succeed())
+	// missing query is reported by the scaler
+	g.Expect(validatePrometheusQueries([]ScaleTriggers{prometheusTrigger("")})).To(Succeed())
+	// other scalers aren't checked
+	g.Expect(validatePrometheusQueries([]ScaleTriggers{{Type: "cron", Metadata: map[string]string{"query": "sum("}}})).To(Succeed())
+
+	g.Expect(validatePrometheusQueries([]ScaleTriggers{prometheusTrigger(`sum(rate(http_requests_total[2m])`)})).ToNot(Succeed())
+	g.Expect(validatePrometheusQueries([]ScaleTriggers{prometheusTrigger(`http_requests_total{job=}`)})).ToNot(Succeed())
+	g.Expect(validatePrometheusQueries([]ScaleTriggers{prometheusTrigger(`rate(http_requests_total)`)})).ToNot(Succeed())
+
+	named := prometheusTrigger("sum by (job (http_requests_total)")
+	named.Name = "requests"
+	g.Expect(validatePrometheusQueries([]ScaleTriggers{named})).To(MatchError(ContainSubstring("prometheus trigger requests")))
+}
+
+func TestVerifyTriggerAuthentications(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(AddToScheme(scheme)).To(Succeed())
+
+	triggerAuth := &TriggerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{Name: "auth", Namespace: "default"},
+		Spec: TriggerAuthenticationSpec{
+			SecretTargetRef: []AuthSecretTargetRef{
+				{Parameter: "username", Name: "credentials", Key: "username"},
+				{Parameter: "password", Name: "credentials", Key: "password"},
+			},
+		},
+	}
+	missingKeyAuth := &TriggerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{Name: "missing-key", Namespace: "default"},
+		Spec: TriggerAuthenticationSpec{
+			SecretTargetRef: []AuthSecretTargetRef{{Parameter: "token", Name: "credentials", Key: "token"}},
+		},
+	}
+	missingSecretAuth := &TriggerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{Name: "missing-secret", Namespace: "default"},
+		Spec: TriggerAuthenticationSpec{
+			SecretTargetRef: []AuthSecretTargetRef{{Parameter: "token", Name: "unknown", Key: "token"}},
+		},
+	}
+	forbiddenAuth := &TriggerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{Name: "forbidden", Namespace: "default"},
+		Spec: TriggerAuthenticationSpec{
+			SecretTargetRef: []AuthSecretTargetRef{{Parameter: "token", Name: "restricted", Key: "token"}},
+		},
+	}
+	// the secrets of ClusterTriggerAuthentications aren't checked
+	clusterTriggerAuth := &ClusterTriggerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-auth"},
+		Spec: TriggerAuthenticationSpec{
+			SecretTargetRef: []AuthSecretTargetRef{{Parameter: "token", Name: "cluster-credentials", Key: "token"}},
+		},
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "default"},
+		Data:       map[string][]byte{"username": []byte("keda"), "password": []byte("secret")},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(triggerAuth, missingKeyAuth, missingSecretAuth, forbiddenAuth, clusterTriggerAuth, secret).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if key.Name == "restricted" {
+					return kerrors.NewForbidden(v1.Resource("secrets"), key.Name, errors.New("rbac"))
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).
+		Build()
+
+	previousClient, previousReader, previousCacheMiss := kc, apiReader, cacheMissToDirectClient
+	kc, apiReader, cacheMissToDirectClient = fakeClient, fakeClient, false
+	defer func() {
+		kc, apiReader, cacheMissToDirectClient = previousClient, previousReader, previousCacheMiss
+	}()
+
+	soWithAuthRef := func(authRef *AuthenticationRef) *ScaledObject {
+		return &ScaledObject{
+			ObjectMeta: metav1.ObjectMeta{Name: "so", Namespace: "default"},
+			Spec: ScaledObjectSpec{
+				Triggers: []ScaleTriggers{
+					{Type: "cron"},
+					{Type: "rabbitmq", Name: "queue", AuthenticationRef: authRef},
+				},
+			},
+		}
+	}
+
+	warnings, err := verifyTriggerAuthentications(soWithAuthRef(&AuthenticationRef{Name: "auth"}), "create")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(warnings).To(BeEmpty())
+
+	warnings, err = verifyTriggerAuthentications(soWithAuthRef(&AuthenticationRef{Name: "cluster-auth", Kind: "ClusterTriggerAuthentication"}), "create")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(warnings).To(BeEmpty())
+
+	_, err = verifyTriggerAuthentications(soWithAuthRef(&AuthenticationRef{Name: "unknown"}), "create")
+	g.Expect(err).To(MatchError("trigger queue: TriggerAuthentication default/unknown not found"))
+
+	_, err = verifyTriggerAuthentications(soWithAuthRef(&AuthenticationRef{Name: "auth", Kind: "ClusterTriggerAuthentication"}), "create")
+	g.Expect(err).To(MatchError("trigger queue: ClusterTriggerAuthentication auth not found"))
+
+	_, err = verifyTriggerAuthentications(soWithAuthRef(&AuthenticationRef{Name: "missing-key"}), "create")
+	g.Expect(err).To(MatchError("trigger queue: key token not found in secret default/credentials referenced by missing-key"))
+
+	_, err = verifyTriggerAuthentications(soWithAuthRef(&AuthenticationRef{Name: "missing-secret"}), "create")
+	g.Expect(err).To(MatchError("trigger queue: secret default/unknown referenced by missing-secret not found"))
+
+	_, err = verifyTriggerAuthentications(soWithAuthRef(&AuthenticationRef{Name: "auth", Kind: "Unknown"}), "create")
+	g.Expect(err).To(MatchError("trigger queue: unknown authenticationRef kind Unknown"))
+
+	warnings, err = verifyTriggerAuthentications(soWithAuthRef(&AuthenticationRef{Name: "forbidden"}), "create")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(warnings).To(ConsistOf(ContainSubstring("trigger queue: secret default/restricted referenced by forbidden can't be read")))
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+)
+
+// ScaledObjectDefaults are cluster wide defaults, configured on the operator, for the ScaledObjects
+// not setting the fields themselves. Explicit values on a ScaledObject always win.
+type ScaledObjectDefaults struct {
+	PollingInterval *int32
+	CooldownPeriod  *int32
+	Fallback        *Fallback
+}
+
+// Validate checks that the defaults would be valid on a ScaledObject
+func (d *ScaledObjectDefaults) Validate() error {
+	if d.PollingInterval != nil && *d.PollingInterval <= 0 {
+		return fmt.Errorf("default pollingInterval=%d must be greater than 0", *d.PollingInterval)
+	}
+	if d.CooldownPeriod != nil && *d.CooldownPeriod < 0 {
+		return fmt.Errorf("default cooldownPeriod=%d must be greater than or equal to 0", *d.CooldownPeriod)
+	}
+	if d.Fallback != nil && (d.Fallback.FailureThreshold < 0 || d.Fallback.Replicas < 0) {
+		return fmt.Errorf("default fallback FailureThreshold=%d & Replicas=%d must both be greater than or equal to 0",
+			d.Fallback.FailureThreshold, d.Fallback.Replicas)
+	}
+	return nil
+}
+
+// Apply fills the fields the ScaledObject doesn't set with the defaults. It only changes the object in memory,
+// the defaults must never be written back to the cluster. The fallback is only applied if the triggers support it.
+func (d *ScaledObjectDefaults) Apply(scaledObject *ScaledObject) {
+	if d == nil {
+		return
+	}
+	if scaledObject.Spec.PollingInterval == nil && d.PollingInterval != nil {
+		pollingInterval := *d.PollingInterval
+		scaledObject.Spec.PollingInterval = &pollingInterval
+	}
+	if scaledObject.Spec.CooldownPeriod == nil && d.CooldownPeriod != nil {
+		cooldownPeriod := *d.CooldownPeriod
+		scaledObject.Spec.CooldownPeriod = &cooldownPeriod
+	}
+	if scaledObject.Spec.Fallback == nil && d.Fallback != nil {
+		scaledObject.Spec.Fallback = d.Fallback.DeepCopy()
+		if CheckFallbackValid(scaledObject) != nil {
+			scaledObject.Spec.Fallback = nil
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/utils/ptr"
+)
+
+func TestScaledObjectDefaultsApply(t *testing.T) {
+	defaults := &ScaledObjectDefaults{
+		PollingInterval: ptr.To[int32](15),
+		CooldownPeriod:  ptr.To[int32](600),
+		Fallback:        &Fallback{FailureThreshold: 3, Replicas: 5},
+	}
+	averageValueTriggers := []ScaleTriggers{{Type: "kafka", MetricType: autoscalingv2.AverageValueMetricType}}
+
+	tests := []struct {
+		name             string
+		defaults         *ScaledObjectDefaults
+		spec             ScaledObjectSpec
+		expectedPolling  *int32
+		expectedCooldown *int32
+		expectedFallback *Fallback
+	}{
+		{
+			name:             "unset fields get the defaults",
+			defaults:         defaults,
+			spec:             ScaledObjectSpec{Triggers: averageValueTriggers},
+			expectedPolling:  ptr.To[int32](15),
+			expectedCooldown: ptr.To[int32](600),
+			expectedFallback: &Fallback{FailureThreshold: 3, Replicas: 5},
+		},
+		{
+			name:     "explicit values win",
+			defaults: defaults,
+			spec: ScaledObjectSpec{
+				PollingInterval: ptr.To[int32](5),
+				CooldownPeriod:  ptr.To[int32](0),
+				Fallback:        &Fallback{FailureThreshold: 1, Replicas: 2},
+				Triggers:        averageValueTriggers,
+			},
+			expectedPolling:  ptr.To[int32](5),
+			expectedCooldown: ptr.To[int32](0),
+			expectedFallback: &Fallback{FailureThreshold: 1, Replicas: 2},
+		},
+		{
+			name:             "fallback isn't applied to unsupported triggers",
+			defaults:         defaults,
+			spec:             ScaledObjectSpec{Triggers: []ScaleTriggers{{Type: cpuString, MetricType: autoscalingv2.UtilizationMetricType}}},
+			expectedPolling:  ptr.To[int32](15),
+			expectedCooldown: ptr.To[int32](600),
+		},
+		{
+			name:             "only the configured defaults are applied",
+			defaults:         &ScaledObjectDefaults{CooldownPeriod: ptr.To[int32](60)},
+			spec:             ScaledObjectSpec{Triggers: averageValueTriggers},
+			expectedCooldown: ptr.To[int32](60),
+		},
+		{
+			name: "no defaults",
+			spec: ScaledObjectSpec{Triggers: averageValueTriggers},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{Spec: test.spec}
+			test.defaults.Apply(so)
+			assert.Equal(t, test.expectedPolling, so.Spec.PollingInterval)
+			assert.Equal(t, test.expectedCooldown, so.Spec.CooldownPeriod)
+			assert.Equal(t, test.expectedFallback, so.Spec.Fallback)
+		})
+	}
+
+	// the defaults must not be shared with the ScaledObjects
+	so := &ScaledObject{Spec: ScaledObjectSpec{Triggers: averageValueTriggers}}
+	defaults.Apply(so)
+	*so.Spec.PollingInterval = 1
+	so.Spec.Fallback.Replicas = 1
+	assert.Equal(t, int32(15), *defaults.PollingInterval)
+	assert.Equal(t, int32(5), defaults.Fallback.Replicas)
+}
+
+func TestScaledObjectDefaultsValidate(t *testing.T) {
+	assert.NoError(t, (&ScaledObjectDefaults{}).Validate())
+	assert.NoError(t, (&ScaledObjectDefaults{PollingInterval: ptr.To[int32](1), CooldownPeriod: ptr.To[int32](0), Fallback: &Fallback{}}).Validate())
+	assert.Error(t, (&ScaledObjectDefaults{PollingInterval: ptr.To[int32](0)}).Validate())
+	assert.Error(t, (&ScaledObjectDefaults{CooldownPeriod: ptr.To[int32](-1)}).Validate())
+	assert.Error(t, (&ScaledObjectDefaults{Fallback: &Fallback{FailureThreshold: -1, Replicas: 1}}).Validate())
+}
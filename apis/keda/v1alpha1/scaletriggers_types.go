@@ -18,10 +18,13 @@ package v1alpha1
 
 import (
 	"fmt"
+	"math"
 	"slices"
+	"strconv"
 	"strings"
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // ScaleTriggers reference the scaler that will be used
@@ -29,14 +32,28 @@ type ScaleTriggers struct {
 	Type string `json:"type"`
 	// +optional
 	Name string `json:"name,omitempty"`
+	// MetricName overrides the auto-generated name of the external metric exposed for this trigger
+	// +optional
+	MetricName string `json:"metricName,omitempty"`
 
 	UseCachedMetrics bool `json:"useCachedMetrics,omitempty"`
+	// PollingInterval in seconds overrides the pollingInterval of the ScaledObject for this trigger,
+	// the HPA being served the metrics of its last poll in between. It isn't supported by ScaledJobs
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
 
 	Metadata map[string]string `json:"metadata"`
 	// +optional
 	AuthenticationRef *AuthenticationRef `json:"authenticationRef,omitempty"`
 	// +optional
 	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
+	// Weight multiplies the metric values of the trigger served to the HPA, e.g. "0.5", for the trigger to weigh
+	// less or more in the max of the replicas desired by the triggers. With both the AverageValue and Value
+	// metricTypes, the replicas desired by the trigger are multiplied by the weight. Not supported by cpu/memory
+	// triggers, with scalingModifiers or by ScaledJobs
+	// +optional
+	Weight string `json:"weight,omitempty"`
 }
 
 // AuthenticationRef points to the TriggerAuthentication or ClusterTriggerAuthentication object that
@@ -51,6 +68,9 @@ type AuthenticationRef struct {
 // ValidateTriggers checks that general trigger metadata are valid, it checks:
 // - triggerNames in ScaledObject are unique
 // - useCachedMetrics is defined only for a supported triggers
+// - pollingInterval is defined only for a supported triggers
+// - weights are positive numbers defined only for a supported triggers
+// - metricNames are unique, DNS-compatible and defined only for a supported triggers
 func ValidateTriggers(triggers []ScaleTriggers) error {
 	triggersCount := len(triggers)
 
@@ -60,6 +80,7 @@ func ValidateTriggers(triggers []ScaleTriggers) error {
 
 	if triggers != nil && triggersCount > 0 {
 		triggerNames := make(map[string]bool, triggersCount)
+		metricNames := make(map[string]bool, triggersCount)
 		for i := 0; i < triggersCount; i++ {
 			trigger := triggers[i]
 
@@ -69,6 +90,19 @@ func ValidateTriggers(triggers []ScaleTriggers) error {
 				}
 			}
 
+			// the metrics of cpu/memory triggers are read by the HPA from the metrics server
+			if trigger.PollingInterval != nil && (trigger.Type == "cpu" || trigger.Type == "memory") {
+				return fmt.Errorf("property \"pollingInterval\" is not supported for %q scaler", trigger.Type)
+			}
+			if trigger.Weight != "" {
+				if trigger.Type == "cpu" || trigger.Type == "memory" {
+					return fmt.Errorf("property \"weight\" is not supported for %q scaler", trigger.Type)
+				}
+				if _, err := trigger.GetWeight(); err != nil {
+					return err
+				}
+			}
+
 			name := trigger.Name
 			if name != "" {
 				if _, found := triggerNames[name]; found {
@@ -77,12 +111,38 @@ func ValidateTriggers(triggers []ScaleTriggers) error {
 				}
 				triggerNames[name] = true
 			}
+
+			metricName := trigger.MetricName
+			if metricName != "" {
+				if trigger.Type == "cpu" || trigger.Type == "memory" {
+					return fmt.Errorf("property \"metricName\" is not supported for %q scaler", trigger.Type)
+				}
+				if errs := validation.IsDNS1123Subdomain(metricName); len(errs) > 0 {
+					return fmt.Errorf("metricName %q is invalid: %s", metricName, strings.Join(errs, ", "))
+				}
+				if _, found := metricNames[metricName]; found {
+					return fmt.Errorf("metricName %q is defined multiple times in the ScaledObject/ScaledJob, but it must be unique", metricName)
+				}
+				metricNames[metricName] = true
+			}
 		}
 	}
 
 	return nil
 }
 
+// GetWeight returns the weight of the trigger, 1 if it isn't set
+func (t ScaleTriggers) GetWeight() (float64, error) {
+	if t.Weight == "" {
+		return 1, nil
+	}
+	weight, err := strconv.ParseFloat(t.Weight, 64)
+	if err != nil || weight <= 0 || math.IsInf(weight, 0) {
+		return 0, fmt.Errorf("weight %q must be a number greater than 0", t.Weight)
+	}
+	return weight, nil
+}
+
 // CombinedTriggersAndAuthenticationsTypes returns a comma separated string of all trigger types and authentication types
 func CombinedTriggersAndAuthenticationsTypes(triggers []ScaleTriggers) (string, string) {
 	var triggersTypes []string
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
 )
 
 func TestValidateTriggers(t *testing.T) {
@@ -84,6 +85,121 @@ func TestValidateTriggers(t *testing.T) {
 			},
 			expectedErrMsg: "",
 		},
+		{
+			name: "unsupported pollingInterval property for cpu scaler",
+			triggers: []ScaleTriggers{
+				{
+					Name:            "trigger5",
+					Type:            "cpu",
+					PollingInterval: ptr.To[int32](5),
+				},
+			},
+			expectedErrMsg: "property \"pollingInterval\" is not supported for \"cpu\" scaler",
+		},
+		{
+			name: "supported pollingInterval property for cron scaler",
+			triggers: []ScaleTriggers{
+				{
+					Name:            "trigger6",
+					Type:            "cron",
+					PollingInterval: ptr.To[int32](5),
+				},
+			},
+			expectedErrMsg: "",
+		},
+		{
+			name: "valid weight",
+			triggers: []ScaleTriggers{
+				{
+					Type:   "prometheus",
+					Weight: "0.5",
+				},
+			},
+			expectedErrMsg: "",
+		},
+		{
+			name: "unsupported weight property for cpu scaler",
+			triggers: []ScaleTriggers{
+				{
+					Type:   "cpu",
+					Weight: "2",
+				},
+			},
+			expectedErrMsg: "property \"weight\" is not supported for \"cpu\" scaler",
+		},
+		{
+			name: "negative weight",
+			triggers: []ScaleTriggers{
+				{
+					Type:   "prometheus",
+					Weight: "-1",
+				},
+			},
+			expectedErrMsg: "weight \"-1\" must be a number greater than 0",
+		},
+		{
+			name: "invalid weight",
+			triggers: []ScaleTriggers{
+				{
+					Type:   "prometheus",
+					Weight: "high",
+				},
+			},
+			expectedErrMsg: "weight \"high\" must be a number greater than 0",
+		},
+		{
+			name: "valid metric names",
+			triggers: []ScaleTriggers{
+				{
+					Type:       "prometheus",
+					MetricName: "http-requests",
+				},
+				{
+					Type:       "kafka",
+					MetricName: "orders.lag",
+				},
+				{
+					Type: "kafka",
+				},
+			},
+			expectedErrMsg: "",
+		},
+		{
+			name: "duplicate metric names",
+			triggers: []ScaleTriggers{
+				{
+					Name:       "trigger1",
+					Type:       "prometheus",
+					MetricName: "http-requests",
+				},
+				{
+					Name:       "trigger2",
+					Type:       "kafka",
+					MetricName: "http-requests",
+				},
+			},
+			expectedErrMsg: "metricName \"http-requests\" is defined multiple times in the ScaledObject/ScaledJob, but it must be unique",
+		},
+		{
+			name: "metric name isn't DNS-compatible",
+			triggers: []ScaleTriggers{
+				{
+					Type:       "prometheus",
+					MetricName: "HTTP_requests",
+				},
+			},
+			expectedErrMsg: "metricName \"HTTP_requests\" is invalid: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')",
+		},
+		{
+			name: "unsupported metricName property for cpu scaler",
+			triggers: []ScaleTriggers{
+				{
+					Type:       "cpu",
+					MetricName: "cpu",
+				},
+			},
+			expectedErrMsg: "property \"metricName\" is not supported for \"cpu\" scaler",
+		},
 		{
 			name:           "empty triggers array should be blocked",
 			triggers:       []ScaleTriggers{},
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScalingBehaviorTemplate defines a HPA behavior shared by ScaledObjects across the cluster
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:resource:path=scalingbehaviortemplates,scope=Cluster,shortName=sbt
+type ScalingBehaviorTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScalingBehaviorTemplateSpec `json:"spec"`
+}
+
+// ScalingBehaviorTemplateSpec is the spec for a ScalingBehaviorTemplate
+type ScalingBehaviorTemplateSpec struct {
+	Behavior autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScalingBehaviorTemplateList contains a list of ScalingBehaviorTemplate
+type ScalingBehaviorTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ScalingBehaviorTemplate `json:"items"`
+}
+
+// ScalingBehaviorTemplateRef points to the ScalingBehaviorTemplate used as base for the HPA behavior
+type ScalingBehaviorTemplateRef struct {
+	Name string `json:"name"`
+}
+
+// MergeHPABehavior returns the behavior of the template overridden by the inline behavior. The inline
+// behavior takes precedence for every field it sets, the remaining fields are taken from the template
+func MergeHPABehavior(template, inline *autoscalingv2.HorizontalPodAutoscalerBehavior) *autoscalingv2.HorizontalPodAutoscalerBehavior {
+	if template == nil {
+		return inline
+	}
+	merged := template.DeepCopy()
+	if inline == nil {
+		return merged
+	}
+	merged.ScaleUp = mergeHPAScalingRules(merged.ScaleUp, inline.ScaleUp)
+	merged.ScaleDown = mergeHPAScalingRules(merged.ScaleDown, inline.ScaleDown)
+	return merged
+}
+
+func mergeHPAScalingRules(template, inline *autoscalingv2.HPAScalingRules) *autoscalingv2.HPAScalingRules {
+	if inline == nil {
+		return template
+	}
+	if template == nil {
+		return inline.DeepCopy()
+	}
+	merged := template.DeepCopy()
+	if inline.StabilizationWindowSeconds != nil {
+		merged.StabilizationWindowSeconds = inline.StabilizationWindowSeconds
+	}
+	if inline.SelectPolicy != nil {
+		merged.SelectPolicy = inline.SelectPolicy
+	}
+	if len(inline.Policies) > 0 {
+		merged.Policies = append([]autoscalingv2.HPAScalingPolicy(nil), inline.Policies...)
+	}
+	return merged
+}
+
+func init() {
+	SchemeBuilder.Register(&ScalingBehaviorTemplate{}, &ScalingBehaviorTemplateList{})
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/utils/ptr"
+)
+
+func TestMergeHPABehavior(t *testing.T) {
+	maxPolicy := autoscalingv2.MaxChangePolicySelect
+	minPolicy := autoscalingv2.MinChangePolicySelect
+	template := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleUp: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To[int32](0),
+			SelectPolicy:               &maxPolicy,
+			Policies:                   []autoscalingv2.HPAScalingPolicy{{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15}},
+		},
+		ScaleDown: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To[int32](300),
+			Policies:                   []autoscalingv2.HPAScalingPolicy{{Type: autoscalingv2.PodsScalingPolicy, Value: 1, PeriodSeconds: 60}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template *autoscalingv2.HorizontalPodAutoscalerBehavior
+		inline   *autoscalingv2.HorizontalPodAutoscalerBehavior
+		expected *autoscalingv2.HorizontalPodAutoscalerBehavior
+	}{
+		{
+			name:     "no template",
+			inline:   &autoscalingv2.HorizontalPodAutoscalerBehavior{ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: ptr.To[int32](60)}},
+			expected: &autoscalingv2.HorizontalPodAutoscalerBehavior{ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: ptr.To[int32](60)}},
+		},
+		{
+			name:     "no inline behavior",
+			template: template,
+			expected: template,
+		},
+		{
+			name:     "inline fields override the template",
+			template: template,
+			inline: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleDown: &autoscalingv2.HPAScalingRules{
+					StabilizationWindowSeconds: ptr.To[int32](600),
+					SelectPolicy:               &minPolicy,
+				},
+			},
+			expected: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleUp: template.ScaleUp,
+				ScaleDown: &autoscalingv2.HPAScalingRules{
+					StabilizationWindowSeconds: ptr.To[int32](600),
+					SelectPolicy:               &minPolicy,
+					Policies:                   []autoscalingv2.HPAScalingPolicy{{Type: autoscalingv2.PodsScalingPolicy, Value: 1, PeriodSeconds: 60}},
+				},
+			},
+		},
+		{
+			name:     "inline policies replace the template policies",
+			template: template,
+			inline: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleUp: &autoscalingv2.HPAScalingRules{
+					Policies: []autoscalingv2.HPAScalingPolicy{{Type: autoscalingv2.PodsScalingPolicy, Value: 4, PeriodSeconds: 30}},
+				},
+			},
+			expected: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleUp: &autoscalingv2.HPAScalingRules{
+					StabilizationWindowSeconds: ptr.To[int32](0),
+					SelectPolicy:               &maxPolicy,
+					Policies:                   []autoscalingv2.HPAScalingPolicy{{Type: autoscalingv2.PodsScalingPolicy, Value: 4, PeriodSeconds: 30}},
+				},
+				ScaleDown: template.ScaleDown,
+			},
+		},
+		{
+			name:     "inline rules missing in the template",
+			template: &autoscalingv2.HorizontalPodAutoscalerBehavior{ScaleDown: template.ScaleDown},
+			inline:   &autoscalingv2.HorizontalPodAutoscalerBehavior{ScaleUp: template.ScaleUp},
+			expected: template,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var templateCopy *autoscalingv2.HorizontalPodAutoscalerBehavior
+			if test.template != nil {
+				templateCopy = test.template.DeepCopy()
+			}
+
+			assert.Equal(t, test.expected, MergeHPABehavior(test.template, test.inline))
+			// the template is shared across ScaledObjects and must not be modified
+			assert.Equal(t, templateCopy, test.template)
+		})
+	}
+}
@@ -89,6 +89,18 @@ func (t *WithTriggers) GetPollingInterval() time.Duration {
 	return time.Second * time.Duration(defaultPollingInterval)
 }
 
+// GetScaleLoopInterval returns the interval of the scale loop, the shortest of the polling intervals
+// of the object and of its triggers
+func (t *WithTriggers) GetScaleLoopInterval() time.Duration {
+	interval := t.GetPollingInterval()
+	for _, trigger := range t.Spec.Triggers {
+		if trigger.PollingInterval != nil {
+			interval = min(interval, time.Second*time.Duration(*trigger.PollingInterval))
+		}
+	}
+	return interval
+}
+
 // GenerateIdentifier returns identifier for the object in for "kind.namespace.name"
 func (t *WithTriggers) GenerateIdentifier() string {
 	return GenerateIdentifier(t.InternalKind, t.Namespace, t.Name)
@@ -23,6 +23,7 @@ package v1alpha1
 import (
 	"k8s.io/api/autoscaling/v2"
 	"k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -35,6 +36,23 @@ func (in *AdvancedConfig) DeepCopyInto(out *AdvancedConfig) {
 		(*in).DeepCopyInto(*out)
 	}
 	out.ScalingModifiers = in.ScalingModifiers
+	if in.ScaleDownStabilizationWindowSeconds != nil {
+		in, out := &in.ScaleDownStabilizationWindowSeconds, &out.ScaleDownStabilizationWindowSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ErrorBackoff != nil {
+		in, out := &in.ErrorBackoff, &out.ErrorBackoff
+		*out = new(ErrorBackoff)
+		**out = **in
+	}
+	if in.ReplicaWindows != nil {
+		in, out := &in.ReplicaWindows, &out.ReplicaWindows
+		*out = make([]ReplicaWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedConfig.
@@ -462,6 +480,21 @@ func (in *Credential) DeepCopy() *Credential {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErrorBackoff) DeepCopyInto(out *ErrorBackoff) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorBackoff.
+func (in *ErrorBackoff) DeepCopy() *ErrorBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(ErrorBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Fallback) DeepCopyInto(out *Fallback) {
 	*out = *in
@@ -622,6 +655,11 @@ func (in *HorizontalPodAutoscalerConfig) DeepCopyInto(out *HorizontalPodAutoscal
 		*out = new(v2.HorizontalPodAutoscalerBehavior)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BehaviorRef != nil {
+		in, out := &in.BehaviorRef, &out.BehaviorRef
+		*out = new(ScalingBehaviorTemplateRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizontalPodAutoscalerConfig.
@@ -634,6 +672,83 @@ func (in *HorizontalPodAutoscalerConfig) DeepCopy() *HorizontalPodAutoscalerConf
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxReplicaCountCapacity) DeepCopyInto(out *MaxReplicaCountCapacity) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxReplicaCountCapacity.
+func (in *MaxReplicaCountCapacity) DeepCopy() *MaxReplicaCountCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxReplicaCountCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MinReplicaCountSchedule) DeepCopyInto(out *MinReplicaCountSchedule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MinReplicaCountSchedule.
+func (in *MinReplicaCountSchedule) DeepCopy() *MinReplicaCountSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(MinReplicaCountSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PollingBackoffStatus) DeepCopyInto(out *PollingBackoffStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PollingBackoffStatus.
+func (in *PollingBackoffStatus) DeepCopy() *PollingBackoffStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PollingBackoffStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaWindow) DeepCopyInto(out *ReplicaWindow) {
+	*out = *in
+	if in.MinReplicaCount != nil {
+		in, out := &in.MinReplicaCount, &out.MinReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicaCount != nil {
+		in, out := &in.MaxReplicaCount, &out.MaxReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaWindow.
+func (in *ReplicaWindow) DeepCopy() *ReplicaWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rollout) DeepCopyInto(out *Rollout) {
 	*out = *in
@@ -652,6 +767,11 @@ func (in *Rollout) DeepCopy() *Rollout {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaleTarget) DeepCopyInto(out *ScaleTarget) {
 	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleTarget.
@@ -667,6 +787,11 @@ func (in *ScaleTarget) DeepCopy() *ScaleTarget {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaleTriggers) DeepCopyInto(out *ScaleTriggers) {
 	*out = *in
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
 		*out = make(map[string]string, len(*in))
@@ -880,6 +1005,36 @@ func (in *ScaledObjectCustomValidator) DeepCopy() *ScaledObjectCustomValidator {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaledObjectDefaults) DeepCopyInto(out *ScaledObjectDefaults) {
+	*out = *in
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CooldownPeriod != nil {
+		in, out := &in.CooldownPeriod, &out.CooldownPeriod
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(Fallback)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectDefaults.
+func (in *ScaledObjectDefaults) DeepCopy() *ScaledObjectDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaledObjectDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaledObjectList) DeepCopyInto(out *ScaledObjectList) {
 	*out = *in
@@ -918,7 +1073,7 @@ func (in *ScaledObjectSpec) DeepCopyInto(out *ScaledObjectSpec) {
 	if in.ScaleTargetRef != nil {
 		in, out := &in.ScaleTargetRef, &out.ScaleTargetRef
 		*out = new(ScaleTarget)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.PollingInterval != nil {
 		in, out := &in.PollingInterval, &out.PollingInterval
@@ -945,11 +1100,21 @@ func (in *ScaledObjectSpec) DeepCopyInto(out *ScaledObjectSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MinReplicaCountSchedules != nil {
+		in, out := &in.MinReplicaCountSchedules, &out.MinReplicaCountSchedules
+		*out = make([]MinReplicaCountSchedule, len(*in))
+		copy(*out, *in)
+	}
 	if in.MaxReplicaCount != nil {
 		in, out := &in.MaxReplicaCount, &out.MaxReplicaCount
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MaxReplicaCountCapacity != nil {
+		in, out := &in.MaxReplicaCountCapacity, &out.MaxReplicaCountCapacity
+		*out = new(MaxReplicaCountCapacity)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Advanced != nil {
 		in, out := &in.Advanced, &out.Advanced
 		*out = new(AdvancedConfig)
@@ -1033,6 +1198,22 @@ func (in *ScaledObjectStatus) DeepCopyInto(out *ScaledObjectStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TriggersResolvedMetadata != nil {
+		in, out := &in.TriggersResolvedMetadata, &out.TriggersResolvedMetadata
+		*out = make([]TriggerResolvedMetadata, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NextPollTime != nil {
+		in, out := &in.NextPollTime, &out.NextPollTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PollingBackoff != nil {
+		in, out := &in.PollingBackoff, &out.PollingBackoff
+		*out = new(PollingBackoffStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectStatus.
@@ -1045,6 +1226,95 @@ func (in *ScaledObjectStatus) DeepCopy() *ScaledObjectStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingBehaviorTemplate) DeepCopyInto(out *ScalingBehaviorTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingBehaviorTemplate.
+func (in *ScalingBehaviorTemplate) DeepCopy() *ScalingBehaviorTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingBehaviorTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScalingBehaviorTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingBehaviorTemplateList) DeepCopyInto(out *ScalingBehaviorTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScalingBehaviorTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingBehaviorTemplateList.
+func (in *ScalingBehaviorTemplateList) DeepCopy() *ScalingBehaviorTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingBehaviorTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScalingBehaviorTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingBehaviorTemplateRef) DeepCopyInto(out *ScalingBehaviorTemplateRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingBehaviorTemplateRef.
+func (in *ScalingBehaviorTemplateRef) DeepCopy() *ScalingBehaviorTemplateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingBehaviorTemplateRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingBehaviorTemplateSpec) DeepCopyInto(out *ScalingBehaviorTemplateSpec) {
+	*out = *in
+	in.Behavior.DeepCopyInto(&out.Behavior)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingBehaviorTemplateSpec.
+func (in *ScalingBehaviorTemplateSpec) DeepCopy() *ScalingBehaviorTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingBehaviorTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalingModifiers) DeepCopyInto(out *ScalingModifiers) {
 	*out = *in
@@ -1229,6 +1499,28 @@ func (in *TriggerAuthenticationStatus) DeepCopy() *TriggerAuthenticationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerResolvedMetadata) DeepCopyInto(out *TriggerResolvedMetadata) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerResolvedMetadata.
+func (in *TriggerResolvedMetadata) DeepCopy() *TriggerResolvedMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerResolvedMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueFromSecret) DeepCopyInto(out *ValueFromSecret) {
 	*out = *in
@@ -19,6 +19,7 @@ package main
 import (
 	"flag"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -41,13 +42,17 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	eventingcontrollers "github.com/kedacore/keda/v2/controllers/eventing"
 	kedacontrollers "github.com/kedacore/keda/v2/controllers/keda"
+	kedacontrollersutil "github.com/kedacore/keda/v2/controllers/keda/util"
 	"github.com/kedacore/keda/v2/pkg/certificates"
+	"github.com/kedacore/keda/v2/pkg/debugserver"
 	"github.com/kedacore/keda/v2/pkg/eventemitter"
 	"github.com/kedacore/keda/v2/pkg/k8s"
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/metricsservice"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling/executor"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
+	"github.com/kedacore/keda/v2/pkg/webhookreceiver"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -86,6 +91,9 @@ func main() {
 	var validatingWebhookName string
 	var caDirs []string
 	var enableWebhookPatching bool
+	var webhookReceiverAddr string
+	var debugServerAddr string
+	var debugServerTokenFile string
 	pflag.BoolVar(&enablePrometheusMetrics, "enable-prometheus-metrics", true, "Enable the prometheus metric of keda-operator.")
 	pflag.BoolVar(&enableOpenTelemetryMetrics, "enable-opentelemetry-metrics", false, "Enable the opentelemetry metric of keda-operator.")
 	pflag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the prometheus metric endpoint binds to.")
@@ -109,6 +117,9 @@ func main() {
 	pflag.StringVar(&validatingWebhookName, "validating-webhook-name", "keda-admission", "ValidatingWebhookConfiguration name. Defaults to keda-admission")
 	pflag.StringArrayVar(&caDirs, "ca-dir", []string{"/custom/ca"}, "Directory with CA certificates for scalers to authenticate TLS connections. Can be specified multiple times. Defaults to /custom/ca")
 	pflag.BoolVar(&enableWebhookPatching, "enable-webhook-patching", true, "Enable patching of webhook resources. Defaults to true.")
+	pflag.StringVar(&webhookReceiverAddr, "webhook-receiver-bind-address", "", "The address the receiver of the values POSTed for the webhook scalers binds to, it's served over TLS with the certificate of --cert-dir. Disabled when empty.")
+	pflag.StringVar(&debugServerAddr, "debug-server-bind-address", "", "The address the debug endpoint dumping the current scaler values of the ScaledObjects binds to, it's served over TLS with the certificate of --cert-dir. Disabled when empty.")
+	pflag.StringVar(&debugServerTokenFile, "debug-server-token-file", "", "File with the bearer token the requests to the debug endpoint must pass. Required when the debug endpoint is enabled.")
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
@@ -201,6 +212,36 @@ func main() {
 		os.Exit(1)
 	}
 
+	// the lastActiveTime of the ScaledObjects is written at most once per interval while their triggers are active,
+	// the default being on each poll. The cooldown period may then be shortened by up to the interval
+	var statusUpdateMinInterval time.Duration
+	minInterval, err := kedautil.ResolveOsEnvDuration("KEDA_SCALEDOBJECT_STATUS_UPDATE_MIN_INTERVAL")
+	if err != nil || (minInterval != nil && *minInterval < 0) {
+		setupLog.Error(err, "invalid KEDA_SCALEDOBJECT_STATUS_UPDATE_MIN_INTERVAL")
+		os.Exit(1)
+	}
+	if minInterval != nil {
+		statusUpdateMinInterval = *minInterval
+	}
+
+	// the scale actuations of the ScaledObjects are limited to KEDA_SCALEDOBJECT_MAX_SCALE_ACTUATIONS per interval,
+	// the ones above the limit being deferred. Only the activations and deactivations done by KEDA are limited,
+	// not the scaling done by the HPA. It's not limited by default
+	maxScaleActuations, err := kedautil.ResolveOsEnvInt("KEDA_SCALEDOBJECT_MAX_SCALE_ACTUATIONS", 0)
+	if err != nil || maxScaleActuations < 0 {
+		setupLog.Error(err, "invalid KEDA_SCALEDOBJECT_MAX_SCALE_ACTUATIONS")
+		os.Exit(1)
+	}
+	scaleActuationsInterval := time.Second
+	actuationsInterval, err := kedautil.ResolveOsEnvDuration("KEDA_SCALEDOBJECT_SCALE_ACTUATIONS_INTERVAL")
+	if err != nil || (actuationsInterval != nil && *actuationsInterval <= 0) {
+		setupLog.Error(err, "invalid KEDA_SCALEDOBJECT_SCALE_ACTUATIONS_INTERVAL")
+		os.Exit(1)
+	}
+	if actuationsInterval != nil {
+		scaleActuationsInterval = *actuationsInterval
+	}
+
 	globalHTTPTimeout := time.Duration(globalHTTPTimeoutMS) * time.Millisecond
 	eventRecorder := mgr.GetEventRecorderFor("keda-operator")
 
@@ -225,15 +266,22 @@ func main() {
 		os.Exit(1)
 	}
 
-	scaledHandler := scaling.NewScaleHandler(mgr.GetClient(), scaleClient, mgr.GetScheme(), globalHTTPTimeout, eventRecorder, secretInformer.Lister())
+	scaledObjectDefaults, err := kedacontrollersutil.GetScaledObjectDefaults()
+	if err != nil {
+		setupLog.Error(err, "invalid ScaledObject defaults")
+		os.Exit(1)
+	}
+
+	scaledHandler := scaling.NewScaleHandler(mgr.GetClient(), scaleClient, mgr.GetScheme(), globalHTTPTimeout, eventRecorder, secretInformer.Lister(), scaledObjectDefaults, statusUpdateMinInterval, executor.NewActuationLimiter(maxScaleActuations, scaleActuationsInterval))
 	eventEmitter := eventemitter.NewEventEmitter(mgr.GetClient(), eventRecorder, k8sClusterName, secretInformer.Lister())
 
 	if err = (&kedacontrollers.ScaledObjectReconciler{
-		Client:       mgr.GetClient(),
-		Scheme:       mgr.GetScheme(),
-		ScaleClient:  scaleClient,
-		ScaleHandler: scaledHandler,
-		EventEmitter: eventEmitter,
+		Client:               mgr.GetClient(),
+		Scheme:               mgr.GetScheme(),
+		ScaleClient:          scaleClient,
+		ScaleHandler:         scaledHandler,
+		ScaledObjectDefaults: scaledObjectDefaults,
+		EventEmitter:         eventEmitter,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: scaledObjectMaxReconciles,
 	}); err != nil {
@@ -325,6 +373,28 @@ func main() {
 		os.Exit(1)
 	}
 
+	if webhookReceiverAddr != "" {
+		webhookReceiver := webhookreceiver.NewServer(webhookreceiver.DefaultStore())
+		// the values are posted with bearer tokens, they're received over TLS only
+		if err := mgr.Add(kedautil.NewHTTPSServer("webhook_receiver", webhookReceiverAddr, certDir, certReady, webhookReceiver.Handler())); err != nil {
+			setupLog.Error(err, "unable to set up webhook receiver")
+			os.Exit(1)
+		}
+	}
+
+	if debugServerAddr != "" {
+		token, err := os.ReadFile(debugServerTokenFile)
+		if err != nil || strings.TrimSpace(string(token)) == "" {
+			setupLog.Error(err, "the debug endpoint requires a token, set --debug-server-token-file to a file with it")
+			os.Exit(1)
+		}
+		debugServer := debugserver.NewServer(strings.TrimSpace(string(token)), scaledHandler)
+		if err := mgr.Add(kedautil.NewHTTPSServer("debug_server", debugServerAddr, certDir, certReady, debugServer.Handler())); err != nil {
+			setupLog.Error(err, "unable to set up debug server")
+			os.Exit(1)
+		}
+	}
+
 	kedautil.PrintWelcome(setupLog, kubeVersion, "manager")
 
 	kubeInformerFactory.Start(ctx.Done())
@@ -22,17 +22,21 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/go-logr/logr"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
 	"github.com/kedacore/keda/v2/pkg/scaling/executor"
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 	version "github.com/kedacore/keda/v2/version"
@@ -74,11 +78,9 @@ func (r *ScaledObjectReconciler) newHPAForScaledObject(ctx context.Context, logg
 		return nil, err
 	}
 
-	var behavior *autoscalingv2.HorizontalPodAutoscalerBehavior
-	if scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig != nil {
-		behavior = scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior
-	} else {
-		behavior = nil
+	behavior, err := r.getHPABehavior(ctx, scaledObject)
+	if err != nil {
+		return nil, err
 	}
 
 	// label can have max 63 chars
@@ -99,8 +101,21 @@ func (r *ScaledObjectReconciler) newHPAForScaledObject(ctx context.Context, logg
 		labels[key] = value
 	}
 
-	minReplicas := scaledObject.GetHPAMinReplicas()
-	maxReplicas := scaledObject.GetHPAMaxReplicas()
+	maxReplicas, err := executor.GetMaxReplicaCount(ctx, r.Client, scaledObject, gvkr)
+	if err != nil {
+		return nil, err
+	}
+
+	// a scheduled minReplicaCount of 0 gives the HPA default, the executor scales to zero when inactive
+	minReplicas, err := executor.GetHPAMinReplicaCount(scaledObject, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	// the validation keeps the maxReplicaCount of the replicaWindows above the scheduled minReplicaCounts,
+	// a maxReplicaCountCapacity can still lower it below them and then the minReplicaCount wins
+	if *minReplicas > maxReplicas {
+		maxReplicas = *minReplicas
+	}
 
 	pausedCount, err := executor.GetPausedReplicaCount(scaledObject)
 	if err != nil {
@@ -122,7 +137,7 @@ func (r *ScaledObjectReconciler) newHPAForScaledObject(ctx context.Context, logg
 			Metrics:     scaledObjectMetricSpecs,
 			Behavior:    behavior,
 			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
-				Name:       scaledObject.Spec.ScaleTargetRef.Name,
+				Name:       scaledObject.ScaleTargetName(),
 				Kind:       gvkr.Kind,
 				APIVersion: gvkr.GroupVersion().String(),
 			}},
@@ -145,6 +160,40 @@ func (r *ScaledObjectReconciler) newHPAForScaledObject(ctx context.Context, logg
 	return hpa, nil
 }
 
+// getHPABehavior returns the behavior specified in ScaledObject merged into the referenced ScalingBehaviorTemplate, if any.
+// The scaleDownStabilizationWindowSeconds shortcut applies unless the inline behavior sets the scale down window,
+// and never when a template is referenced, so it doesn't override the window of the template
+func (r *ScaledObjectReconciler) getHPABehavior(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (*autoscalingv2.HorizontalPodAutoscalerBehavior, error) {
+	if scaledObject.Spec.Advanced == nil {
+		return nil, nil
+	}
+	hpaConfig := scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig
+	var behavior *autoscalingv2.HorizontalPodAutoscalerBehavior
+	if window := scaledObject.Spec.Advanced.ScaleDownStabilizationWindowSeconds; window != nil && (hpaConfig == nil || hpaConfig.BehaviorRef == nil) {
+		behavior = &autoscalingv2.HorizontalPodAutoscalerBehavior{
+			ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: window},
+		}
+	}
+
+	if hpaConfig == nil {
+		return behavior, nil
+	}
+	behavior = kedav1alpha1.MergeHPABehavior(behavior, hpaConfig.Behavior)
+	if hpaConfig.BehaviorRef == nil {
+		return behavior, nil
+	}
+
+	template := &kedav1alpha1.ScalingBehaviorTemplate{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: hpaConfig.BehaviorRef.Name}, template); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("ScalingBehaviorTemplate %q referenced in behaviorRef not found", hpaConfig.BehaviorRef.Name)
+		}
+		return nil, fmt.Errorf("error getting ScalingBehaviorTemplate %q: %w", hpaConfig.BehaviorRef.Name, err)
+	}
+
+	return kedav1alpha1.MergeHPABehavior(&template.Spec.Behavior, behavior), nil
+}
+
 // updateHPAIfNeeded checks whether update of HPA is needed
 func (r *ScaledObjectReconciler) updateHPAIfNeeded(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, foundHpa *autoscalingv2.HorizontalPodAutoscaler, gvkr *kedav1alpha1.GroupVersionKindResource) error {
 	hpa, err := r.newHPAForScaledObject(ctx, logger, scaledObject, gvkr)
@@ -253,6 +302,7 @@ func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(ctx context.Context,
 	status.ResourceMetricNames = resourceMetricNames
 
 	updateHealthStatus(scaledObject, externalMetricNames, status)
+	updateTriggersResolvedMetadata(cache, status)
 
 	// if ScalingModifiers struct is not empty, expect Formula and Target to be
 	// non-empty (is validated beforehand - in cache). Only if target is > 0.0
@@ -335,6 +385,20 @@ func updateHealthStatus(scaledObject *kedav1alpha1.ScaledObject, externalMetricN
 	status.Health = newHealth
 }
 
+// updateTriggersResolvedMetadata stores the resolved metadata of every trigger in the status, secret values are redacted
+func updateTriggersResolvedMetadata(scalersCache *cache.ScalersCache, status *kedav1alpha1.ScaledObjectStatus) {
+	_, configs := scalersCache.GetScalers()
+	resolvedMetadata := make([]kedav1alpha1.TriggerResolvedMetadata, 0, len(configs))
+	for _, config := range configs {
+		resolvedMetadata = append(resolvedMetadata, kedav1alpha1.TriggerResolvedMetadata{
+			Type:     config.TriggerType,
+			Name:     config.TriggerName,
+			Metadata: config.RedactedMetadata(),
+		})
+	}
+	status.TriggersResolvedMetadata = resolvedMetadata
+}
+
 // getHPAName returns generated HPA name for ScaledObject specified in the parameter
 func getHPAName(scaledObject *kedav1alpha1.ScaledObject) string {
 	if scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig != nil && scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.Name != "" {
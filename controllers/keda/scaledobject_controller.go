@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
@@ -38,8 +39,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	eventingv1alpha1 "github.com/kedacore/keda/v2/apis/eventing/v1alpha1"
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
@@ -50,11 +53,14 @@ import (
 	"github.com/kedacore/keda/v2/pkg/fallback"
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling/executor"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 	"github.com/kedacore/keda/v2/pkg/util"
 )
 
 // +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects;scaledobjects/finalizers;scaledobjects/status,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=keda.sh,resources=scalingbehaviortemplates,verbs=get;list;watch
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;update;patch;create;delete
 // +kubebuilder:rbac:groups="",resources=configmaps;configmaps/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
@@ -63,8 +69,12 @@ import (
 // +kubebuilder:rbac:groups="",resources="serviceaccounts",verbs=list;watch
 // +kubebuilder:rbac:groups="*",resources="*",verbs=get
 // +kubebuilder:rbac:groups="apps",resources=deployments;statefulsets,verbs=list;watch
+// +kubebuilder:rbac:groups="apps",resources=replicasets,verbs=list
+// +kubebuilder:rbac:groups="argoproj.io",resources=rollouts,verbs=list
 // +kubebuilder:rbac:groups="coordination.k8s.io",namespace=keda,resources=leases,verbs=get;list;watch;update;patch;create;delete
 // +kubebuilder:rbac:groups="",resources="limitranges",verbs=list;watch
+// +kubebuilder:rbac:groups="policy",resources=poddisruptionbudgets,verbs=list;watch
+// +kubebuilder:rbac:urls=/metrics,verbs=get
 
 // ScaledObjectReconciler reconciles a ScaledObject object
 type ScaledObjectReconciler struct {
@@ -73,11 +83,16 @@ type ScaledObjectReconciler struct {
 	ScaleClient  scale.ScalesGetter
 	ScaleHandler scaling.ScaleHandler
 	EventEmitter eventemitter.EventHandler
+	// ScaledObjectDefaults are applied in memory to the ScaledObjects not setting the fields themselves
+	ScaledObjectDefaults *kedav1alpha1.ScaledObjectDefaults
 
 	restMapper               meta.RESTMapper
 	scaledObjectsGenerations *sync.Map
 }
 
+// maxReplicaCountCapacityResyncInterval is how often the maxReplicaCountCapacity of a ScaledObject is resolved again
+const maxReplicaCountCapacityResyncInterval = 5 * time.Minute
+
 type scaledObjectMetricsData struct {
 	namespace    string
 	triggerTypes []string
@@ -131,6 +146,7 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 			predicate.Or(
 				kedacontrollerutil.PausedPredicate{},
 				kedacontrollerutil.PausedReplicasPredicate{},
+				kedacontrollerutil.ReconcileNowPredicate{},
 				kedacontrollerutil.ScaleObjectReadyConditionPredicate{},
 				predicate.GenerationChangedPredicate{},
 			),
@@ -144,9 +160,34 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 				predicate.AnnotationChangedPredicate{},
 				kedacontrollerutil.HPASpecChangedPredicate{},
 			))).
+		// Update the HPAs of the ScaledObjects referencing a ScalingBehaviorTemplate when it changes
+		Watches(&kedav1alpha1.ScalingBehaviorTemplate{},
+			handler.EnqueueRequestsFromMapFunc(r.scaledObjectsForBehaviorTemplate),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Complete(r)
 }
 
+// scaledObjectsForBehaviorTemplate returns reconcile requests for all ScaledObjects referencing the ScalingBehaviorTemplate
+func (r *ScaledObjectReconciler) scaledObjectsForBehaviorTemplate(ctx context.Context, template client.Object) []reconcile.Request {
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	if err := r.Client.List(ctx, scaledObjects); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list ScaledObjects for ScalingBehaviorTemplate", "template", template.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, scaledObject := range scaledObjects.Items {
+		advanced := scaledObject.Spec.Advanced
+		if advanced == nil || advanced.HorizontalPodAutoscalerConfig == nil || advanced.HorizontalPodAutoscalerConfig.BehaviorRef == nil {
+			continue
+		}
+		if advanced.HorizontalPodAutoscalerConfig.BehaviorRef.Name == template.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: scaledObject.Namespace, Name: scaledObject.Name}})
+		}
+	}
+	return requests
+}
+
 // Reconcile performs reconciliation on the identified ScaledObject resource based on the request information passed, returns the result and an error (if any).
 func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	reqLogger := log.FromContext(ctx)
@@ -220,7 +261,25 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		reqLogger.Error(err, "Failed to update TriggerAuthentication Status after removing a finalizer")
 	}
 
-	return ctrl.Result{}, err
+	result := ctrl.Result{}
+	// reconcile again periodically to resolve the HPA maxReplicas against the current capacity of the cluster
+	if scaledObject.Spec.MaxReplicaCountCapacity != nil {
+		result.RequeueAfter = maxReplicaCountCapacityResyncInterval
+	}
+	// reconcile again when the next minReplicaCountSchedule fires to update the HPA minReplicas
+	if next, found, scheduleErr := executor.GetNextMinReplicaCountScheduleTime(scaledObject, time.Now()); scheduleErr == nil && found {
+		if untilNext := time.Until(next); result.RequeueAfter == 0 || untilNext < result.RequeueAfter {
+			result.RequeueAfter = untilNext
+		}
+	}
+	// and when the next replicaWindow opens or closes to update the HPA minReplicas and maxReplicas
+	if next, found, windowErr := executor.GetNextReplicaWindowTime(scaledObject, time.Now()); windowErr == nil && found {
+		if untilNext := time.Until(next); result.RequeueAfter == 0 || untilNext < result.RequeueAfter {
+			result.RequeueAfter = untilNext
+		}
+	}
+
+	return result, err
 }
 
 // reconcileScaledObject implements reconciler logic for ScaledObject
@@ -254,9 +313,8 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 		conditions.SetPausedCondition(metav1.ConditionFalse, "ScaledObjectUnpaused", "pause annotation removed for ScaledObject")
 	}
 
-	// Check scale target Name is specified
-	if scaledObject.Spec.ScaleTargetRef.Name == "" {
-		err := fmt.Errorf("ScaledObject.spec.scaleTargetRef.name is missing")
+	// Check scale target Name or Selector is specified
+	if err := kedav1alpha1.CheckScaleTargetRefIsValid(scaledObject); err != nil {
 		return message.ScaleTargetErrMsg, err
 	}
 
@@ -266,6 +324,9 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 		return "failed to update ScaledObject with scaledObjectName label", err
 	}
 
+	// The defaults are applied after the last update of the ScaledObject, so they are never persisted
+	r.ScaledObjectDefaults.Apply(scaledObject)
+
 	// Check if resource targeted for scaling exists and exposes /scale subresource
 	gvkr, err := r.checkTargetResourceIsScalable(ctx, logger, scaledObject)
 	if err != nil {
@@ -309,6 +370,11 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 			return "failed to start a new scale loop with scaling logic", err
 		}
 		logger.Info("Initializing Scaling logic according to ScaledObject Specification")
+	} else if _, found := scaledObject.GetAnnotations()[kedav1alpha1.ReconcileNowAnnotation]; found {
+		// a new value of the reconcile-now annotation makes the running ScaleLoop poll the scalers immediately
+		if err := r.ScaleHandler.RequestImmediatePoll(ctx, scaledObject); err != nil {
+			return "failed to request an immediate poll of the scalers", err
+		}
 	}
 	if scaledObject.HasPausedReplicaAnnotation() && conditions.GetPausedCondition().Status != metav1.ConditionTrue {
 		return "ScaledObject paused replicas are being scaled", fmt.Errorf("ScaledObject paused replicas are being scaled")
@@ -354,7 +420,7 @@ func (r *ScaledObjectReconciler) checkIfTargetResourceReachPausedCount(ctx conte
 	// check if we already know.
 	var scale *autoscalingv1.Scale
 	gr := gvkr.GroupResource()
-	scale, errScale := (r.ScaleClient).Scales(scaledObject.Namespace).Get(ctx, gr, scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
+	scale, errScale := (r.ScaleClient).Scales(scaledObject.Namespace).Get(ctx, gr, scaledObject.ScaleTargetName(), metav1.GetOptions{})
 	if errScale != nil {
 		return true
 	}
@@ -373,6 +439,19 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 	gvkString := gvkr.GVKString()
 	logger.V(1).Info("Parsed Group, Version, Kind, Resource", "GVK", gvkString, "Resource", gvkr.Resource)
 
+	// the name of a workload matched by a selector is stored in the status, for the other components to use it
+	targetName := scaledObject.Spec.ScaleTargetRef.Name
+	statusTargetName := ""
+	if scaledObject.Spec.ScaleTargetRef.Selector != nil {
+		targetName, err = resolver.ResolveScaleTargetName(ctx, r.Client, gvkr.GroupVersionKind(), scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Selector)
+		if err != nil {
+			logger.Error(err, message.ScaleTargetNotFoundMsg, "resource", gvkString)
+			r.EventEmitter.Emit(scaledObject, scaledObject.Namespace, corev1.EventTypeWarning, eventingv1alpha1.ScaledObjectFailedType, eventreason.ScaledObjectCheckFailed, err.Error())
+			return gvkr, err
+		}
+		statusTargetName = targetName
+	}
+
 	statusGvkString := ""
 	if scaledObject.Status.ScaleTargetGVKR != nil {
 		statusGvkr, _ := kedav1alpha1.ParseGVKR(r.restMapper, scaledObject.Status.ScaleTargetGVKR.Version, scaledObject.Status.ScaleTargetGVKR.Kind)
@@ -386,6 +465,7 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 	wantStatusUpdate := scaledObject.Status.ScaleTargetKind != gvkString ||
 		statusGvkString != gvkString ||
 		scaledObject.Status.OriginalReplicaCount == nil ||
+		scaledObject.Status.ScaleTargetName != statusTargetName ||
 		removePausedStatus
 
 	// check if we already know.
@@ -396,19 +476,19 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 		// not cached, let's try to detect /scale subresource
 		// also rechecks when we need to update the status.
 		var errScale error
-		scale, errScale = (r.ScaleClient).Scales(scaledObject.Namespace).Get(ctx, gr, scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
+		scale, errScale = (r.ScaleClient).Scales(scaledObject.Namespace).Get(ctx, gr, targetName, metav1.GetOptions{})
 		if errScale != nil {
 			// not able to get /scale subresource -> let's check if the resource even exist in the cluster
 			unstruct := &unstructured.Unstructured{}
 			unstruct.SetGroupVersionKind(gvkr.GroupVersionKind())
-			if err := r.Client.Get(ctx, client.ObjectKey{Namespace: scaledObject.Namespace, Name: scaledObject.Spec.ScaleTargetRef.Name}, unstruct); err != nil {
+			if err := r.Client.Get(ctx, client.ObjectKey{Namespace: scaledObject.Namespace, Name: targetName}, unstruct); err != nil {
 				// resource doesn't exist
-				logger.Error(err, message.ScaleTargetNotFoundMsg, "resource", gvkString, "name", scaledObject.Spec.ScaleTargetRef.Name)
+				logger.Error(err, message.ScaleTargetNotFoundMsg, "resource", gvkString, "name", targetName)
 				r.EventEmitter.Emit(scaledObject, scaledObject.Namespace, corev1.EventTypeWarning, eventingv1alpha1.ScaledObjectFailedType, eventreason.ScaledObjectCheckFailed, message.ScaleTargetNotFoundMsg)
 				return gvkr, err
 			}
 			// resource exist but doesn't expose /scale subresource
-			logger.Error(errScale, message.ScaleTargetNoSubresourceMsg, "resource", gvkString, "name", scaledObject.Spec.ScaleTargetRef.Name)
+			logger.Error(errScale, message.ScaleTargetNoSubresourceMsg, "resource", gvkString, "name", targetName)
 			r.EventEmitter.Emit(scaledObject, scaledObject.Namespace, corev1.EventTypeWarning, eventingv1alpha1.ScaledObjectFailedType, eventreason.ScaledObjectCheckFailed, message.ScaleTargetNoSubresourceMsg)
 			return gvkr, errScale
 		}
@@ -427,6 +507,7 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 		if scaledObject.Status.OriginalReplicaCount == nil {
 			status.OriginalReplicaCount = &scale.Spec.Replicas
 		}
+		status.ScaleTargetName = statusTargetName
 
 		if removePausedStatus {
 			status.PausedReplicaCount = nil
@@ -435,7 +516,7 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 		if err := kedastatus.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status); err != nil {
 			return gvkr, err
 		}
-		logger.Info("Detected resource targeted for scaling", "resource", gvkString, "name", scaledObject.Spec.ScaleTargetRef.Name)
+		logger.Info("Detected resource targeted for scaling", "resource", gvkString, "name", targetName)
 	}
 
 	return gvkr, nil
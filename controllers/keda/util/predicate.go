@@ -92,6 +92,22 @@ func (PausedPredicate) Update(e event.UpdateEvent) bool {
 	return newPausedValue != oldPausedValue
 }
 
+// ReconcileNowPredicate passes the updates changing the reconcile-now annotation, requesting an immediate poll
+type ReconcileNowPredicate struct {
+	predicate.Funcs
+}
+
+func (ReconcileNowPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	newValue := e.ObjectNew.GetAnnotations()[kedav1alpha1.ReconcileNowAnnotation]
+	oldValue := e.ObjectOld.GetAnnotations()[kedav1alpha1.ReconcileNowAnnotation]
+
+	return newValue != "" && newValue != oldValue
+}
+
 type HPASpecChangedPredicate struct {
 	predicate.Funcs
 }
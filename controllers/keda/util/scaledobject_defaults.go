@@ -0,0 +1,48 @@
+package util
+
+import (
+	"fmt"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	DefaultPollingIntervalEnvVar          = "KEDA_SCALEDOBJECT_DEFAULT_POLLING_INTERVAL"
+	DefaultCooldownPeriodEnvVar           = "KEDA_SCALEDOBJECT_DEFAULT_COOLDOWN_PERIOD"
+	DefaultFallbackFailureThresholdEnvVar = "KEDA_SCALEDOBJECT_DEFAULT_FALLBACK_FAILURE_THRESHOLD"
+	DefaultFallbackReplicasEnvVar         = "KEDA_SCALEDOBJECT_DEFAULT_FALLBACK_REPLICAS"
+)
+
+// GetScaledObjectDefaults returns the cluster wide ScaledObject defaults configured on the operator through env vars
+func GetScaledObjectDefaults() (*kedav1alpha1.ScaledObjectDefaults, error) {
+	defaults := &kedav1alpha1.ScaledObjectDefaults{}
+
+	var err error
+	if defaults.PollingInterval, err = kedautil.ResolveOsEnvOptionalInt32(DefaultPollingIntervalEnvVar); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", DefaultPollingIntervalEnvVar, err)
+	}
+	if defaults.CooldownPeriod, err = kedautil.ResolveOsEnvOptionalInt32(DefaultCooldownPeriodEnvVar); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", DefaultCooldownPeriodEnvVar, err)
+	}
+
+	failureThreshold, err := kedautil.ResolveOsEnvOptionalInt32(DefaultFallbackFailureThresholdEnvVar)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", DefaultFallbackFailureThresholdEnvVar, err)
+	}
+	replicas, err := kedautil.ResolveOsEnvOptionalInt32(DefaultFallbackReplicasEnvVar)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", DefaultFallbackReplicasEnvVar, err)
+	}
+	if (failureThreshold == nil) != (replicas == nil) {
+		return nil, fmt.Errorf("%s and %s must be set together", DefaultFallbackFailureThresholdEnvVar, DefaultFallbackReplicasEnvVar)
+	}
+	if failureThreshold != nil {
+		defaults.Fallback = &kedav1alpha1.Fallback{FailureThreshold: *failureThreshold, Replicas: *replicas}
+	}
+
+	if err := defaults.Validate(); err != nil {
+		return nil, err
+	}
+	return defaults, nil
+}
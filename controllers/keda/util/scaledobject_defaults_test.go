@@ -0,0 +1,51 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestGetScaledObjectDefaults(t *testing.T) {
+	t.Run("no defaults", func(t *testing.T) {
+		defaults, err := GetScaledObjectDefaults()
+		require.NoError(t, err)
+		assert.Equal(t, &kedav1alpha1.ScaledObjectDefaults{}, defaults)
+	})
+
+	t.Run("all defaults", func(t *testing.T) {
+		t.Setenv(DefaultPollingIntervalEnvVar, "15")
+		t.Setenv(DefaultCooldownPeriodEnvVar, "600")
+		t.Setenv(DefaultFallbackFailureThresholdEnvVar, "3")
+		t.Setenv(DefaultFallbackReplicasEnvVar, "5")
+		defaults, err := GetScaledObjectDefaults()
+		require.NoError(t, err)
+		assert.Equal(t, &kedav1alpha1.ScaledObjectDefaults{
+			PollingInterval: ptr.To[int32](15),
+			CooldownPeriod:  ptr.To[int32](600),
+			Fallback:        &kedav1alpha1.Fallback{FailureThreshold: 3, Replicas: 5},
+		}, defaults)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Setenv(DefaultPollingIntervalEnvVar, "fast")
+		_, err := GetScaledObjectDefaults()
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid pollingInterval", func(t *testing.T) {
+		t.Setenv(DefaultPollingIntervalEnvVar, "0")
+		_, err := GetScaledObjectDefaults()
+		assert.Error(t, err)
+	})
+
+	t.Run("incomplete fallback", func(t *testing.T) {
+		t.Setenv(DefaultFallbackFailureThresholdEnvVar, "3")
+		_, err := GetScaledObjectDefaults()
+		assert.Error(t, err)
+	})
+}
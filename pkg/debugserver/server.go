@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugserver
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kedacore/keda/v2/pkg/scaling"
+)
+
+var log = logf.Log.WithName("debug_server")
+
+// TriggerStatesProvider returns the state of the last poll of the triggers of a ScaledObject,
+// it is implemented by the ScaleHandler
+type TriggerStatesProvider interface {
+	GetScaledObjectTriggerStates(scaledObjectName, scaledObjectNamespace string) ([]scaling.TriggerState, bool)
+}
+
+// scaledObjectResponse is the body returned for a ScaledObject
+type scaledObjectResponse struct {
+	Namespace string                 `json:"namespace"`
+	Name      string                 `json:"name"`
+	Triggers  []scaling.TriggerState `json:"triggers"`
+}
+
+// Server dumps the current scaler values of a ScaledObject on /debug/namespaces/{namespace}/scaledobjects/{name},
+// the caller passing the token as a bearer token, it's served over TLS by a kedautil.HTTPSServer
+type Server struct {
+	token    string
+	provider TriggerStatesProvider
+}
+
+// NewServer creates a new instance of Server
+func NewServer(token string, provider TriggerStatesProvider) Server {
+	return Server{
+		token:    token,
+		provider: provider,
+	}
+}
+
+// Handler returns the handler of the requests of the debug server
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /debug/namespaces/{namespace}/scaledobjects/{name}", s.handleScaledObject)
+	return mux
+}
+
+func (s *Server) handleScaledObject(w http.ResponseWriter, r *http.Request) {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || s.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	namespace, name := r.PathValue("namespace"), r.PathValue("name")
+	states, found := s.provider.GetScaledObjectTriggerStates(name, namespace)
+	if !found {
+		http.Error(w, fmt.Sprintf("no scaler values found for ScaledObject %s/%s", namespace, name), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(scaledObjectResponse{Namespace: namespace, Name: name, Triggers: states}); err != nil {
+		log.Error(err, "error writing the scaler values", "namespace", namespace, "name", name)
+	}
+}
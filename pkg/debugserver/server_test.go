@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scaling"
+)
+
+type fakeTriggerStatesProvider map[string][]scaling.TriggerState
+
+func (p fakeTriggerStatesProvider) GetScaledObjectTriggerStates(scaledObjectName, scaledObjectNamespace string) ([]scaling.TriggerState, bool) {
+	states, found := p[scaledObjectNamespace+"/"+scaledObjectName]
+	return states, found
+}
+
+func getScaledObject(server *Server, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDumpScaledObject(t *testing.T) {
+	pollTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	states := []scaling.TriggerState{
+		{
+			TriggerIndex: 0,
+			TriggerName:  "orders",
+			TriggerType:  "rabbitmq",
+			IsActive:     true,
+			Metrics:      []scaling.TriggerMetricValue{{MetricName: "s0-rabbitmq-orders", Value: 42}},
+			LastPollTime: pollTime,
+		},
+		{
+			TriggerIndex: 1,
+			TriggerName:  "prometheus",
+			TriggerType:  "prometheus",
+			Metrics:      []scaling.TriggerMetricValue{},
+			LastPollTime: pollTime,
+			LastError:    "connection refused",
+		},
+	}
+	server := NewServer("secret", fakeTriggerStatesProvider{"default/app": states})
+
+	rec := getScaledObject(&server, "/debug/namespaces/default/scaledobjects/app", "secret")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var response scaledObjectResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, scaledObjectResponse{Namespace: "default", Name: "app", Triggers: states}, response)
+	assert.Contains(t, rec.Body.String(), `"lastError":"connection refused"`)
+	assert.Contains(t, rec.Body.String(), `"lastPollTime":"2024-01-01T00:00:00Z"`)
+}
+
+func TestDumpScaledObjectNotFound(t *testing.T) {
+	server := NewServer("secret", fakeTriggerStatesProvider{"default/app": {}})
+
+	rec := getScaledObject(&server, "/debug/namespaces/default/scaledobjects/other", "secret")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = getScaledObject(&server, "/debug/namespaces/other/scaledobjects/app", "secret")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestDumpScaledObjectAuth(t *testing.T) {
+	provider := fakeTriggerStatesProvider{"default/app": {}}
+	server := NewServer("secret", provider)
+
+	// missing token
+	rec := getScaledObject(&server, "/debug/namespaces/default/scaledobjects/app", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// wrong token
+	rec = getScaledObject(&server, "/debug/namespaces/default/scaledobjects/app", "other")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// a server without a token refuses every request
+	server = NewServer("", provider)
+	rec = getScaledObject(&server, "/debug/namespaces/default/scaledobjects/app", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestDumpScaledObjectMethod(t *testing.T) {
+	server := NewServer("secret", fakeTriggerStatesProvider{"default/app": {}})
+
+	req := httptest.NewRequest(http.MethodPost, "/debug/namespaces/default/scaledobjects/app", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -44,6 +44,9 @@ const (
 	// KEDAScalersInfo is for event when Scaler has additional info
 	KEDAScalersInfo = "KEDAScalerInfo"
 
+	// KEDAScalersWarning is for event when Scaler configuration may not behave as expected
+	KEDAScalersWarning = "KEDAScalerWarning"
+
 	// KEDAScalersStarted is for event when scalers watch started for ScaledObject or ScaledJob
 	KEDAScalersStarted = "KEDAScalersStarted"
 
@@ -68,9 +71,21 @@ const (
 	// KEDAScaleTargetDeactivationFailed is for event when the deactivation of the scale target for ScaledObject fails
 	KEDAScaleTargetDeactivationFailed = "KEDAScaleTargetDeactivationFailed"
 
+	// KEDAScaleTargetDeactivationBlocked is for event when the deactivation of the scale target for ScaledObject would violate a PodDisruptionBudget
+	KEDAScaleTargetDeactivationBlocked = "KEDAScaleTargetDeactivationBlocked"
+
+	// FallbackActivated is for event when a trigger of ScaledObject failed too often and falls back to the fallback replicas
+	FallbackActivated = "FallbackActivated"
+
+	// FallbackCleared is for event when a trigger of ScaledObject falling back recovered
+	FallbackCleared = "FallbackCleared"
+
 	// KEDAJobsCreated is for event when jobs for ScaledJob are created
 	KEDAJobsCreated = "KEDAJobsCreated"
 
+	// KEDAJobCreateFailed is for event when jobs for ScaledJob could not be created
+	KEDAJobCreateFailed = "KEDAJobCreateFailed"
+
 	// TriggerAuthenticationDeleted is for event when a TriggerAuthentication is deleted
 	TriggerAuthenticationDeleted = "TriggerAuthenticationDeleted"
 
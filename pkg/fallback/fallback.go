@@ -82,6 +82,16 @@ func GetMetricsWithFallback(ctx context.Context, client runtimeclient.Client, me
 	}
 }
 
+// IsFallbackActive returns whether the metric of the ScaledObject currently falls back to the fallback replicas
+func IsFallbackActive(scaledObject *kedav1alpha1.ScaledObject, metricName string) bool {
+	if scaledObject.Spec.Fallback == nil {
+		return false
+	}
+	healthStatus, ok := scaledObject.Status.Health[metricName]
+	return ok && healthStatus.Status == kedav1alpha1.HealthStatusFailing &&
+		healthStatus.NumberOfFailures != nil && *healthStatus.NumberOfFailures > scaledObject.Spec.Fallback.FailureThreshold
+}
+
 func fallbackExistsInScaledObject(scaledObject *kedav1alpha1.ScaledObject) bool {
 	for _, element := range scaledObject.Status.Health {
 		if element.Status == kedav1alpha1.HealthStatusFailing && *element.NumberOfFailures > scaledObject.Spec.Fallback.FailureThreshold {
@@ -40,6 +40,10 @@ func (c *FakeKedaV1alpha1) ScaledObjects(namespace string) v1alpha1.ScaledObject
 	return &FakeScaledObjects{c, namespace}
 }
 
+func (c *FakeKedaV1alpha1) ScalingBehaviorTemplates() v1alpha1.ScalingBehaviorTemplateInterface {
+	return &FakeScalingBehaviorTemplates{c}
+}
+
 func (c *FakeKedaV1alpha1) TriggerAuthentications(namespace string) v1alpha1.TriggerAuthenticationInterface {
 	return &FakeTriggerAuthentications{c, namespace}
 }
@@ -24,4 +24,6 @@ type ScaledJobExpansion interface{}
 
 type ScaledObjectExpansion interface{}
 
+type ScalingBehaviorTemplateExpansion interface{}
+
 type TriggerAuthenticationExpansion interface{}
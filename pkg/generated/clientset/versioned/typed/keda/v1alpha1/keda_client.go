@@ -31,6 +31,7 @@ type KedaV1alpha1Interface interface {
 	ClusterTriggerAuthenticationsGetter
 	ScaledJobsGetter
 	ScaledObjectsGetter
+	ScalingBehaviorTemplatesGetter
 	TriggerAuthenticationsGetter
 }
 
@@ -51,6 +52,10 @@ func (c *KedaV1alpha1Client) ScaledObjects(namespace string) ScaledObjectInterfa
 	return newScaledObjects(c, namespace)
 }
 
+func (c *KedaV1alpha1Client) ScalingBehaviorTemplates() ScalingBehaviorTemplateInterface {
+	return newScalingBehaviorTemplates(c)
+}
+
 func (c *KedaV1alpha1Client) TriggerAuthentications(namespace string) TriggerAuthenticationInterface {
 	return newTriggerAuthentications(c, namespace)
 }
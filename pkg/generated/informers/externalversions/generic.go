@@ -59,6 +59,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().ScaledJobs().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("scaledobjects"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().ScaledObjects().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("scalingbehaviortemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().ScalingBehaviorTemplates().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("triggerauthentications"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().TriggerAuthentications().Informer()}, nil
 
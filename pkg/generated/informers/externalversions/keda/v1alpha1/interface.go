@@ -30,6 +30,8 @@ type Interface interface {
 	ScaledJobs() ScaledJobInformer
 	// ScaledObjects returns a ScaledObjectInformer.
 	ScaledObjects() ScaledObjectInformer
+	// ScalingBehaviorTemplates returns a ScalingBehaviorTemplateInformer.
+	ScalingBehaviorTemplates() ScalingBehaviorTemplateInformer
 	// TriggerAuthentications returns a TriggerAuthenticationInformer.
 	TriggerAuthentications() TriggerAuthenticationInformer
 }
@@ -60,6 +62,11 @@ func (v *version) ScaledObjects() ScaledObjectInformer {
 	return &scaledObjectInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ScalingBehaviorTemplates returns a ScalingBehaviorTemplateInformer.
+func (v *version) ScalingBehaviorTemplates() ScalingBehaviorTemplateInformer {
+	return &scalingBehaviorTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // TriggerAuthentications returns a TriggerAuthenticationInformer.
 func (v *version) TriggerAuthentications() TriggerAuthenticationInformer {
 	return &triggerAuthenticationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -38,6 +38,10 @@ type ScaledObjectListerExpansion interface{}
 // ScaledObjectNamespaceLister.
 type ScaledObjectNamespaceListerExpansion interface{}
 
+// ScalingBehaviorTemplateListerExpansion allows custom methods to be added to
+// ScalingBehaviorTemplateLister.
+type ScalingBehaviorTemplateListerExpansion interface{}
+
 // TriggerAuthenticationListerExpansion allows custom methods to be added to
 // TriggerAuthenticationLister.
 type TriggerAuthenticationListerExpansion interface{}
@@ -38,16 +38,22 @@ var (
 )
 
 type MetricsCollector interface {
-	RecordScalerMetric(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, value float64)
+	// RecordScalerMetric create a measurement of the external metric used by the HPA, triggerType and triggerName
+	// are empty for the composite metric of the scaling modifiers
+	RecordScalerMetric(namespace string, scaledResource string, scaler string, triggerType string, triggerName string, triggerIndex int, metric string, isScaledObject bool, value float64)
 
 	// RecordScalerLatency create a measurement of the latency to external metric
 	RecordScalerLatency(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, value time.Duration)
 
+	// RecordScalerDuration observes the duration of a query of a scaler for its metrics
+	RecordScalerDuration(scalerType string, value time.Duration)
+
 	// RecordScalableObjectLatency create a measurement of the latency executing scalable object loop
 	RecordScalableObjectLatency(namespace string, name string, isScaledObject bool, value time.Duration)
 
-	// RecordScalerActive create a measurement of the activity of the scaler
-	RecordScalerActive(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, active bool)
+	// RecordScalerActive create a measurement of the activity of the scaler, triggerType and triggerName
+	// are empty for the composite metric of the scaling modifiers
+	RecordScalerActive(namespace string, scaledResource string, scaler string, triggerType string, triggerName string, triggerIndex int, metric string, isScaledObject bool, active bool)
 
 	// RecordScaledObjectPaused marks whether the current ScaledObject is paused.
 	RecordScaledObjectPaused(namespace string, scaledObject string, active bool)
@@ -58,6 +64,9 @@ type MetricsCollector interface {
 	// RecordScaledObjectError counts the number of errors with the scaled object
 	RecordScaledObjectError(namespace string, scaledObject string, err error)
 
+	// RecordScaledObjectFallback counts the number of times the fallback of a trigger of the scaled object was activated or cleared
+	RecordScaledObjectFallback(namespace string, scaledObject string, trigger string, active bool)
+
 	// RecordScaledJobError counts the number of errors with the scaled job
 	RecordScaledJobError(namespace string, scaledJob string, err error)
 
@@ -96,9 +105,9 @@ func NewMetricsCollectors(enablePrometheusMetrics bool, enableOpenTelemetryMetri
 }
 
 // RecordScalerMetric create a measurement of the external metric used by the HPA
-func RecordScalerMetric(namespace string, scaledObject string, scaler string, triggerIndex int, metric string, isScaledObject bool, value float64) {
+func RecordScalerMetric(namespace string, scaledObject string, scaler string, triggerType string, triggerName string, triggerIndex int, metric string, isScaledObject bool, value float64) {
 	for _, element := range collectors {
-		element.RecordScalerMetric(namespace, scaledObject, scaler, triggerIndex, metric, isScaledObject, value)
+		element.RecordScalerMetric(namespace, scaledObject, scaler, triggerType, triggerName, triggerIndex, metric, isScaledObject, value)
 	}
 }
 
@@ -109,6 +118,13 @@ func RecordScalerLatency(namespace string, scaledObject string, scaler string, t
 	}
 }
 
+// RecordScalerDuration observes the duration of a query of a scaler for its metrics
+func RecordScalerDuration(scalerType string, value time.Duration) {
+	for _, element := range collectors {
+		element.RecordScalerDuration(scalerType, value)
+	}
+}
+
 // RecordScalableObjectLatency create a measurement of the latency executing scalable object loop
 func RecordScalableObjectLatency(namespace string, name string, isScaledObject bool, value time.Duration) {
 	for _, element := range collectors {
@@ -117,9 +133,9 @@ func RecordScalableObjectLatency(namespace string, name string, isScaledObject b
 }
 
 // RecordScalerActive create a measurement of the activity of the scaler
-func RecordScalerActive(namespace string, scaledObject string, scaler string, triggerIndex int, metric string, isScaledObject bool, active bool) {
+func RecordScalerActive(namespace string, scaledObject string, scaler string, triggerType string, triggerName string, triggerIndex int, metric string, isScaledObject bool, active bool) {
 	for _, element := range collectors {
-		element.RecordScalerActive(namespace, scaledObject, scaler, triggerIndex, metric, isScaledObject, active)
+		element.RecordScalerActive(namespace, scaledObject, scaler, triggerType, triggerName, triggerIndex, metric, isScaledObject, active)
 	}
 }
 
@@ -144,6 +160,21 @@ func RecordScaledObjectError(namespace string, scaledObject string, err error) {
 	}
 }
 
+// RecordScaledObjectFallback counts the number of times the fallback of a trigger of the scaled object was activated or cleared
+func RecordScaledObjectFallback(namespace string, scaledObject string, trigger string, active bool) {
+	for _, element := range collectors {
+		element.RecordScaledObjectFallback(namespace, scaledObject, trigger, active)
+	}
+}
+
+// getFallbackState returns the state label of a fallback transition
+func getFallbackState(active bool) string {
+	if active {
+		return "activated"
+	}
+	return "cleared"
+}
+
 // RecordScaledJobError counts the number of errors with the scaled job
 func RecordScaledJobError(namespace string, scaledJob string, err error) {
 	for _, element := range collectors {
@@ -29,11 +29,13 @@ var (
 	meter                            api.Meter
 	otScalerErrorsCounter            api.Int64Counter
 	otScaledObjectErrorsCounter      api.Int64Counter
+	otScaledObjectFallbackCounter    api.Int64Counter
 	otScaledJobErrorsCounter         api.Int64Counter
 	otTriggerTotalsCounterDeprecated api.Int64UpDownCounter
 	otCrdTotalsCounterDeprecated     api.Int64UpDownCounter
 	otTriggerRegisteredTotalsCounter api.Int64UpDownCounter
 	otCrdRegisteredTotalsCounter     api.Int64UpDownCounter
+	otScalerMetricsDuration          api.Float64Histogram
 
 	otelScalerMetricVals                  []OtelMetricFloat64Val
 	otelScalerMetricsLatencyVals          []OtelMetricFloat64Val
@@ -110,6 +112,11 @@ func initMeters() {
 		otLog.Error(err, msg)
 	}
 
+	otScaledObjectFallbackCounter, err = meter.Int64Counter("keda.scaledobject.fallback.transitions", api.WithDescription("Number of times the fallback of a trigger of a scaled object was activated or cleared"))
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
 	otScaledJobErrorsCounter, err = meter.Int64Counter("keda.scaledjob.errors", api.WithDescription("Number of scaled job errors"))
 	if err != nil {
 		otLog.Error(err, msg)
@@ -135,6 +142,16 @@ func initMeters() {
 		otLog.Error(err, msg)
 	}
 
+	otScalerMetricsDuration, err = meter.Float64Histogram(
+		"keda.scaler.metrics.duration",
+		api.WithDescription("The duration of the queries of the scalers for their metrics, per scaler type"),
+		api.WithUnit("s"),
+		api.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60),
+	)
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
 	_, err = meter.Float64ObservableGauge(
 		"keda.scaler.metrics.value",
 		api.WithDescription("The current value for each scaler's metric that would be used by the HPA in computing the target average"),
@@ -251,10 +268,10 @@ func ScalerMetricValueCallback(_ context.Context, obsrv api.Float64Observer) err
 	return nil
 }
 
-func (o *OtelMetrics) RecordScalerMetric(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, value float64) {
+func (o *OtelMetrics) RecordScalerMetric(namespace string, scaledResource string, scaler string, triggerType string, triggerName string, triggerIndex int, metric string, isScaledObject bool, value float64) {
 	otelScalerMetric := OtelMetricFloat64Val{}
 	otelScalerMetric.val = value
-	otelScalerMetric.measurementOption = getScalerMeasurementOption(namespace, scaledResource, scaler, triggerIndex, metric, isScaledObject)
+	otelScalerMetric.measurementOption = getScalerTriggerMeasurementOption(namespace, scaledResource, scaler, triggerType, triggerName, triggerIndex, metric, isScaledObject)
 	otelScalerMetricVals = append(otelScalerMetricVals, otelScalerMetric)
 }
 
@@ -287,6 +304,11 @@ func (o *OtelMetrics) RecordScalerLatency(namespace string, scaledResource strin
 	otelScalerMetricsLatencyValDeprecated = append(otelScalerMetricsLatencyValDeprecated, otelScalerMetricsLatencyValD)
 }
 
+// RecordScalerDuration observes the duration of a query of a scaler for its metrics
+func (o *OtelMetrics) RecordScalerDuration(scalerType string, value time.Duration) {
+	otScalerMetricsDuration.Record(context.Background(), value.Seconds(), api.WithAttributes(attribute.Key("type").String(scalerType)))
+}
+
 func ScalableObjectLatencyCallback(_ context.Context, obsrv api.Float64Observer) error {
 	for _, v := range otelInternalLoopLatencyVals {
 		obsrv.Observe(v.val, v.measurementOption)
@@ -335,14 +357,14 @@ func ScalerActiveCallback(_ context.Context, obsrv api.Float64Observer) error {
 }
 
 // RecordScalerActive create a measurement of the activity of the scaler
-func (o *OtelMetrics) RecordScalerActive(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, active bool) {
+func (o *OtelMetrics) RecordScalerActive(namespace string, scaledResource string, scaler string, triggerType string, triggerName string, triggerIndex int, metric string, isScaledObject bool, active bool) {
 	activeVal := 0
 	if active {
 		activeVal = 1
 	}
 	otelScalerActive := OtelMetricFloat64Val{}
 	otelScalerActive.val = float64(activeVal)
-	otelScalerActive.measurementOption = getScalerMeasurementOption(namespace, scaledResource, scaler, triggerIndex, metric, isScaledObject)
+	otelScalerActive.measurementOption = getScalerTriggerMeasurementOption(namespace, scaledResource, scaler, triggerType, triggerName, triggerIndex, metric, isScaledObject)
 	otelScalerActiveVals = append(otelScalerActiveVals, otelScalerActive)
 }
 
@@ -391,6 +413,16 @@ func (o *OtelMetrics) RecordScaledObjectError(namespace string, scaledObject str
 	}
 }
 
+// RecordScaledObjectFallback counts the number of times the fallback of a trigger of the scaled object was activated or cleared
+func (o *OtelMetrics) RecordScaledObjectFallback(namespace string, scaledObject string, trigger string, active bool) {
+	opt := api.WithAttributes(
+		attribute.Key("namespace").String(namespace),
+		attribute.Key("scaledObject").String(scaledObject),
+		attribute.Key("trigger").String(trigger),
+		attribute.Key("state").String(getFallbackState(active)))
+	otScaledObjectFallbackCounter.Add(context.Background(), 1, opt)
+}
+
 // RecordScaledJobError counts the number of errors with the scaled job
 func (o *OtelMetrics) RecordScaledJobError(namespace string, scaledJob string, err error) {
 	opt := api.WithAttributes(
@@ -443,22 +475,37 @@ func (o *OtelMetrics) DecrementCRDTotal(crdType, namespace string) {
 }
 
 func getScalerMeasurementOption(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool) api.MeasurementOption {
+	return api.WithAttributes(getScalerAttributes(namespace, scaledResource, scaler, triggerIndex, metric, isScaledObject)...)
+}
+
+// getScalerTriggerMeasurementOption adds the type and the name of the trigger to the scaler attributes,
+// the name being empty when the trigger isn't named
+func getScalerTriggerMeasurementOption(namespace string, scaledResource string, scaler string, triggerType string, triggerName string, triggerIndex int, metric string, isScaledObject bool) api.MeasurementOption {
+	attributes := getScalerAttributes(namespace, scaledResource, scaler, triggerIndex, metric, isScaledObject)
+	attributes = append(attributes,
+		attribute.Key("type").String(triggerType),
+		attribute.Key("triggerName").String(triggerName),
+	)
+	return api.WithAttributes(attributes...)
+}
+
+func getScalerAttributes(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool) []attribute.KeyValue {
 	if isScaledObject {
-		return api.WithAttributes(
+		return []attribute.KeyValue{
 			attribute.Key("namespace").String(namespace),
 			attribute.Key("scaledObject").String(scaledResource),
 			attribute.Key("scaler").String(scaler),
 			attribute.Key("scalerIndex").String(strconv.Itoa(triggerIndex)),
 			attribute.Key("metric").String(metric),
-		)
+		}
 	}
-	return api.WithAttributes(
+	return []attribute.KeyValue{
 		attribute.Key("namespace").String(namespace),
 		attribute.Key("scaledJob").String(scaledResource),
 		attribute.Key("scaler").String(scaler),
 		attribute.Key("triggerIndex").String(strconv.Itoa(triggerIndex)),
 		attribute.Key("metric").String(metric),
-	)
+	}
 }
 
 // RecordCloudEventEmitted counts the number of cloudevent that emitted to user's sink
@@ -104,8 +104,8 @@ func TestLoopLatency(t *testing.T) {
 }
 
 func TestContinuousMetrics(t *testing.T) {
-	testOtel.RecordScalerActive("testnamespace", "testresource", "testscaler", 0, "testmetric", true, true)
-	testOtel.RecordScalerActive("testnamespace2", "testresource2", "testscaler2", 0, "testmetric", false, false)
+	testOtel.RecordScalerActive("testnamespace", "testresource", "testscaler", "testtype", "testscaler", 0, "testmetric", true, true)
+	testOtel.RecordScalerActive("testnamespace2", "testresource2", "testscaler2", "testtype2", "", 0, "testmetric", false, false)
 	got := metricdata.ResourceMetrics{}
 	err := testReader.Collect(context.Background(), &got)
 
@@ -134,6 +134,10 @@ func TestContinuousMetrics(t *testing.T) {
 	assert.Equal(t, attribute.AsString(), "testscaler")
 	attribute, _ = scaledObjectMetric.Attributes.Value("metric")
 	assert.Equal(t, attribute.AsString(), "testmetric")
+	attribute, _ = scaledObjectMetric.Attributes.Value("type")
+	assert.Equal(t, attribute.AsString(), "testtype")
+	attribute, _ = scaledObjectMetric.Attributes.Value("triggerName")
+	assert.Equal(t, attribute.AsString(), "testscaler")
 	assert.Equal(t, scaledObjectMetric.Value, 1.0)
 
 	var scaledJobMetric metricdata.DataPoint[float64]
@@ -153,5 +157,144 @@ func TestContinuousMetrics(t *testing.T) {
 	assert.Equal(t, attribute.AsString(), "testscaler2")
 	attribute, _ = scaledJobMetric.Attributes.Value("metric")
 	assert.Equal(t, attribute.AsString(), "testmetric")
+	attribute, _ = scaledJobMetric.Attributes.Value("type")
+	assert.Equal(t, attribute.AsString(), "testtype2")
+	attribute, _ = scaledJobMetric.Attributes.Value("triggerName")
+	assert.Equal(t, attribute.AsString(), "")
 	assert.Equal(t, scaledJobMetric.Value, 0.0)
 }
+
+func TestScaledObjectFallback(t *testing.T) {
+	testOtel.RecordScaledObjectFallback("testnamespace", "testresource", "testtrigger", true)
+	testOtel.RecordScaledObjectFallback("testnamespace", "testresource", "testtrigger", false)
+	testOtel.RecordScaledObjectFallback("testnamespace", "testresource", "testtrigger", true)
+	got := metricdata.ResourceMetrics{}
+	err := testReader.Collect(context.Background(), &got)
+
+	assert.Nil(t, err)
+	scopeMetrics := got.ScopeMetrics[0]
+	transitions := retrieveMetric(scopeMetrics.Metrics, "keda.scaledobject.fallback.transitions")
+	assert.NotNil(t, transitions)
+
+	counts := map[string]int64{}
+	for _, data := range transitions.Data.(metricdata.Sum[int64]).DataPoints {
+		state, _ := data.Attributes.Value("state")
+		trigger, _ := data.Attributes.Value("trigger")
+		assert.Equal(t, "testtrigger", trigger.AsString())
+		counts[state.AsString()] = data.Value
+	}
+	assert.Equal(t, map[string]int64{"activated": 2, "cleared": 1}, counts)
+}
+
+func TestScalerDuration(t *testing.T) {
+	testOtel.RecordScalerDuration("testscaler", 100*time.Millisecond)
+	testOtel.RecordScalerDuration("testscaler", 300*time.Millisecond)
+	got := metricdata.ResourceMetrics{}
+	err := testReader.Collect(context.Background(), &got)
+
+	assert.Nil(t, err)
+	scopeMetrics := got.ScopeMetrics[0]
+	duration := retrieveMetric(scopeMetrics.Metrics, "keda.scaler.metrics.duration")
+	assert.NotNil(t, duration)
+	assert.Equal(t, "s", duration.Unit)
+
+	dataPoints := duration.Data.(metricdata.Histogram[float64]).DataPoints
+	assert.Len(t, dataPoints, 1)
+	scalerType, _ := dataPoints[0].Attributes.Value("type")
+	assert.Equal(t, "testscaler", scalerType.AsString())
+	assert.Equal(t, uint64(2), dataPoints[0].Count)
+	assert.InDelta(t, 0.4, dataPoints[0].Sum, 0.0001)
+}
+
+func TestScalerMetricValue(t *testing.T) {
+	// a named trigger and an unnamed one
+	testOtel.RecordScalerMetric("testnamespace", "testresource", "testtrigger", "prometheus", "testtrigger", 1, "s1-testmetric", true, 42.5)
+	testOtel.RecordScalerMetric("testnamespace2", "testresource2", "prometheusScaler", "prometheus", "", 0, "s0-testmetric", false, 3)
+	got := metricdata.ResourceMetrics{}
+	err := testReader.Collect(context.Background(), &got)
+
+	assert.Nil(t, err)
+	scopeMetrics := got.ScopeMetrics[0]
+	valueMetric := retrieveMetric(scopeMetrics.Metrics, "keda.scaler.metrics.value")
+	assert.NotNil(t, valueMetric)
+
+	dataPoints := valueMetric.Data.(metricdata.Gauge[float64]).DataPoints
+	assert.Len(t, dataPoints, 2)
+
+	for _, v := range dataPoints {
+		namespace, _ := v.Attributes.Value("namespace")
+		if namespace.AsString() == "testnamespace" {
+			attribute, _ := v.Attributes.Value("scaledObject")
+			assert.Equal(t, "testresource", attribute.AsString())
+			attribute, _ = v.Attributes.Value("scaler")
+			assert.Equal(t, "testtrigger", attribute.AsString())
+			attribute, _ = v.Attributes.Value("scalerIndex")
+			assert.Equal(t, "1", attribute.AsString())
+			attribute, _ = v.Attributes.Value("metric")
+			assert.Equal(t, "s1-testmetric", attribute.AsString())
+			attribute, _ = v.Attributes.Value("type")
+			assert.Equal(t, "prometheus", attribute.AsString())
+			attribute, _ = v.Attributes.Value("triggerName")
+			assert.Equal(t, "testtrigger", attribute.AsString())
+			assert.Equal(t, 42.5, v.Value)
+		} else {
+			attribute, _ := v.Attributes.Value("scaledJob")
+			assert.Equal(t, "testresource2", attribute.AsString())
+			attribute, _ = v.Attributes.Value("triggerIndex")
+			assert.Equal(t, "0", attribute.AsString())
+			attribute, _ = v.Attributes.Value("type")
+			assert.Equal(t, "prometheus", attribute.AsString())
+			attribute, _ = v.Attributes.Value("triggerName")
+			assert.Equal(t, "", attribute.AsString())
+			assert.Equal(t, 3.0, v.Value)
+		}
+	}
+
+	// the values are only reported once, until they are recorded again on the next poll
+	got = metricdata.ResourceMetrics{}
+	err = testReader.Collect(context.Background(), &got)
+	assert.Nil(t, err)
+	valueMetric = retrieveMetric(got.ScopeMetrics[0].Metrics, "keda.scaler.metrics.value")
+	if valueMetric != nil {
+		assert.Len(t, valueMetric.Data.(metricdata.Gauge[float64]).DataPoints, 0)
+	}
+}
+
+func TestScalerMetricsWithoutOpenTelemetry(t *testing.T) {
+	defer func(previous []MetricsCollector) { collectors = previous }(collectors)
+	collectors = nil
+
+	// OpenTelemetry isn't among the collectors unless it's enabled, recording the metrics is then a no-op for it
+	NewMetricsCollectors(false, false)
+	assert.Empty(t, collectors)
+	RecordScalerMetric("testnamespace", "testresource", "disabledscaler", "testtype", "", 0, "testmetric", true, 10)
+	RecordScalerActive("testnamespace", "testresource", "disabledscaler", "testtype", "", 0, "testmetric", true, true)
+	assert.Equal(t, 0, countScalerDataPoints(t, "disabledscaler"))
+
+	// the same calls are recorded once it's among the collectors
+	collectors = []MetricsCollector{testOtel}
+	RecordScalerMetric("testnamespace", "testresource", "enabledscaler", "testtype", "", 0, "testmetric", true, 10)
+	RecordScalerActive("testnamespace", "testresource", "enabledscaler", "testtype", "", 0, "testmetric", true, true)
+	assert.Equal(t, 2, countScalerDataPoints(t, "enabledscaler"))
+}
+
+// countScalerDataPoints collects the scaler metric value and activity gauges and counts their data points of scaler
+func countScalerDataPoints(t *testing.T, scaler string) int {
+	got := metricdata.ResourceMetrics{}
+	err := testReader.Collect(context.Background(), &got)
+	assert.Nil(t, err)
+
+	count := 0
+	for _, name := range []string{"keda.scaler.metrics.value", "keda.scaler.active"} {
+		m := retrieveMetric(got.ScopeMetrics[0].Metrics, name)
+		if m == nil {
+			continue
+		}
+		for _, v := range m.Data.(metricdata.Gauge[float64]).DataPoints {
+			if attribute, _ := v.Attributes.Value("scaler"); attribute.AsString() == scaler {
+				count++
+			}
+		}
+	}
+	return count
+}
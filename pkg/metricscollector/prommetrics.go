@@ -59,6 +59,16 @@ var (
 		},
 		metricLabels,
 	)
+	scalerMetricsDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaler",
+			Name:      "metrics_duration_seconds",
+			Help:      "The duration of the queries of the scalers for their metrics, in seconds, per scaler type.",
+			Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		},
+		[]string{"type"},
+	)
 	scalerActive = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: DefaultPromMetricsNamespace,
@@ -95,6 +105,15 @@ var (
 		},
 		[]string{"namespace", "scaledObject"},
 	)
+	scaledObjectFallbackTransitions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaled_object",
+			Name:      "fallback_transitions_total",
+			Help:      "The number of times the fallback of a trigger of each ScaledObject was activated or cleared. 'state': activated or cleared",
+		},
+		[]string{"namespace", "scaledObject", "trigger", "state"},
+	)
 	scaledJobErrors = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: DefaultPromMetricsNamespace,
@@ -160,11 +179,13 @@ type PromMetrics struct {
 func NewPromMetrics() *PromMetrics {
 	metrics.Registry.MustRegister(scalerMetricsValue)
 	metrics.Registry.MustRegister(scalerMetricsLatency)
+	metrics.Registry.MustRegister(scalerMetricsDuration)
 	metrics.Registry.MustRegister(internalLoopLatency)
 	metrics.Registry.MustRegister(scalerActive)
 	metrics.Registry.MustRegister(scalerErrors)
 	metrics.Registry.MustRegister(scaledObjectErrors)
 	metrics.Registry.MustRegister(scaledObjectPaused)
+	metrics.Registry.MustRegister(scaledObjectFallbackTransitions)
 	metrics.Registry.MustRegister(triggerRegistered)
 	metrics.Registry.MustRegister(crdRegistered)
 	metrics.Registry.MustRegister(scaledJobErrors)
@@ -183,8 +204,9 @@ func RecordBuildInfo() {
 	buildInfo.WithLabelValues(version.Version, version.GitCommit, runtime.Version(), runtime.GOOS, runtime.GOARCH).Set(1)
 }
 
-// RecordScalerMetric create a measurement of the external metric used by the HPA
-func (p *PromMetrics) RecordScalerMetric(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, value float64) {
+// RecordScalerMetric create a measurement of the external metric used by the HPA, the trigger type and name
+// aren't labels of the Prometheus metrics, the scaler label is already the name of the trigger or its scaler
+func (p *PromMetrics) RecordScalerMetric(namespace string, scaledResource string, scaler string, _ string, _ string, triggerIndex int, metric string, isScaledObject bool, value float64) {
 	scalerMetricsValue.With(getLabels(namespace, scaledResource, scaler, triggerIndex, metric, isScaledObject)).Set(value)
 }
 
@@ -193,13 +215,19 @@ func (p *PromMetrics) RecordScalerLatency(namespace string, scaledResource strin
 	scalerMetricsLatency.With(getLabels(namespace, scaledResource, scaler, triggerIndex, metric, isScaledObject)).Set(value.Seconds())
 }
 
+// RecordScalerDuration observes the duration of a query of a scaler for its metrics, labeled by scaler type only
+// to keep the cardinality low
+func (p *PromMetrics) RecordScalerDuration(scalerType string, value time.Duration) {
+	scalerMetricsDuration.WithLabelValues(scalerType).Observe(value.Seconds())
+}
+
 // RecordScalableObjectLatency create a measurement of the latency executing scalable object loop
 func (p *PromMetrics) RecordScalableObjectLatency(namespace string, name string, isScaledObject bool, value time.Duration) {
 	internalLoopLatency.WithLabelValues(namespace, getResourceType(isScaledObject), name).Set(value.Seconds())
 }
 
 // RecordScalerActive create a measurement of the activity of the scaler
-func (p *PromMetrics) RecordScalerActive(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, active bool) {
+func (p *PromMetrics) RecordScalerActive(namespace string, scaledResource string, scaler string, _ string, _ string, triggerIndex int, metric string, isScaledObject bool, active bool) {
 	activeVal := 0
 	if active {
 		activeVal = 1
@@ -249,6 +277,11 @@ func (p *PromMetrics) RecordScaledObjectError(namespace string, scaledObject str
 	}
 }
 
+// RecordScaledObjectFallback counts the number of times the fallback of a trigger of the scaled object was activated or cleared
+func (p *PromMetrics) RecordScaledObjectFallback(namespace string, scaledObject string, trigger string, active bool) {
+	scaledObjectFallbackTransitions.With(prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject, "trigger": trigger, "state": getFallbackState(active)}).Inc()
+}
+
 // RecordScaledJobError counts the number of errors with the scaled job
 func (p *PromMetrics) RecordScaledJobError(namespace string, scaledJob string, err error) {
 	labels := prometheus.Labels{"namespace": namespace, "scaledJob": scaledJob}
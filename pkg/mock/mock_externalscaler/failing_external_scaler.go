@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mock_externalscaler provides external scaler servers for the tests of the external scalers
+package mock_externalscaler
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
+)
+
+// FailingExternalScaler is an external scaler exposing the queue-length metric, whose value can't be read
+type FailingExternalScaler struct {
+	pb.UnimplementedExternalScalerServer
+}
+
+// GetMetricSpec returns the queue-length metric with a target of 10
+func (e *FailingExternalScaler) GetMetricSpec(context.Context, *pb.ScaledObjectRef) (*pb.GetMetricSpecResponse, error) {
+	return &pb.GetMetricSpecResponse{MetricSpecs: []*pb.MetricSpec{{MetricName: "queue-length", TargetSize: 10}}}, nil
+}
+
+// GetMetrics always fails as if the backend of the scaler were down
+func (e *FailingExternalScaler) GetMetrics(context.Context, *pb.GetMetricsRequest) (*pb.GetMetricsResponse, error) {
+	return nil, status.Error(codes.Unavailable, "backend down")
+}
+
+// StartFailingExternalScaler serves a FailingExternalScaler on a local port until the end of the test,
+// returning its address
+func StartFailingExternalScaler(t testing.TB) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterExternalScalerServer(grpcServer, &FailingExternalScaler{})
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	t.Cleanup(grpcServer.Stop)
+	return lis.Addr().String()
+}
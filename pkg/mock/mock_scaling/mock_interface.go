@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	scaling "github.com/kedacore/keda/v2/pkg/scaling"
 	cache "github.com/kedacore/keda/v2/pkg/scaling/cache"
 	gomock "go.uber.org/mock/gomock"
 	external_metrics "k8s.io/metrics/pkg/apis/external_metrics"
@@ -85,6 +86,21 @@ func (mr *MockScaleHandlerMockRecorder) GetScaledObjectMetrics(ctx, scaledObject
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScaledObjectMetrics", reflect.TypeOf((*MockScaleHandler)(nil).GetScaledObjectMetrics), ctx, scaledObjectName, scaledObjectNamespace, metricName)
 }
 
+// GetScaledObjectTriggerStates mocks base method.
+func (m *MockScaleHandler) GetScaledObjectTriggerStates(scaledObjectName, scaledObjectNamespace string) ([]scaling.TriggerState, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScaledObjectTriggerStates", scaledObjectName, scaledObjectNamespace)
+	ret0, _ := ret[0].([]scaling.TriggerState)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetScaledObjectTriggerStates indicates an expected call of GetScaledObjectTriggerStates.
+func (mr *MockScaleHandlerMockRecorder) GetScaledObjectTriggerStates(scaledObjectName, scaledObjectNamespace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScaledObjectTriggerStates", reflect.TypeOf((*MockScaleHandler)(nil).GetScaledObjectTriggerStates), scaledObjectName, scaledObjectNamespace)
+}
+
 // GetScalersCache mocks base method.
 func (m *MockScaleHandler) GetScalersCache(ctx context.Context, scalableObject any) (*cache.ScalersCache, error) {
 	m.ctrl.T.Helper()
@@ -113,3 +129,17 @@ func (mr *MockScaleHandlerMockRecorder) HandleScalableObject(ctx, scalableObject
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleScalableObject", reflect.TypeOf((*MockScaleHandler)(nil).HandleScalableObject), ctx, scalableObject)
 }
+
+// RequestImmediatePoll mocks base method.
+func (m *MockScaleHandler) RequestImmediatePoll(ctx context.Context, scalableObject any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestImmediatePoll", ctx, scalableObject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestImmediatePoll indicates an expected call of RequestImmediatePoll.
+func (mr *MockScaleHandlerMockRecorder) RequestImmediatePoll(ctx, scalableObject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestImmediatePoll", reflect.TypeOf((*MockScaleHandler)(nil).RequestImmediatePoll), ctx, scalableObject)
+}
@@ -8,6 +8,7 @@ import (
 	"html/template"
 	"net/http"
 	"net/url"
+	"path"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -18,7 +19,7 @@ import (
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
-const defaultActiveMQRestAPITemplate = "http://{{.ManagementEndpoint}}/api/jolokia/read/org.apache.activemq:type=Broker,brokerName={{.BrokerName}},destinationType=Queue,destinationName={{.DestinationName}}/QueueSize"
+const defaultActiveMQRestAPITemplate = "{{.Scheme}}://{{.ManagementEndpoint}}/api/jolokia/read/org.apache.activemq:type=Broker,brokerName={{.BrokerName}},destinationType=Queue,destinationName={{.DestinationName}}/QueueSize"
 
 type activeMQScaler struct {
 	metricType v2.MetricTargetType
@@ -31,6 +32,10 @@ type activeMQMetadata struct {
 	metricName   string
 	triggerIndex int
 
+	// destinationName is a comma separated list of queues, which may contain Jolokia wildcards,
+	// the sizes of all the matching queues are summed up
+	destinationNames []string
+
 	ManagementEndpoint string `keda:"name=managementEndpoint, order=triggerMetadata, optional"`
 	DestinationName    string `keda:"name=destinationName,    order=triggerMetadata, optional"`
 	BrokerName         string `keda:"name=brokerName,         order=triggerMetadata, optional"`
@@ -41,6 +46,10 @@ type activeMQMetadata struct {
 
 	CorsHeader string `keda:"name=corsHeader, order=triggerMetadata, optional"`
 
+	// TLS
+	UseHTTPS  bool `keda:"name=useHttps,  order=triggerMetadata, default=false"`
+	UnsafeSsl bool `keda:"name=unsafeSsl, order=triggerMetadata, default=false"`
+
 	RestAPITemplate           string `keda:"name=restAPITemplate,           order=triggerMetadata, optional"`
 	TargetQueueSize           int64  `keda:"name=targetQueueSize,           order=triggerMetadata, default=10"`
 	ActivationTargetQueueSize int64  `keda:"name=activationTargetQueueSize, order=triggerMetadata, default=0"`
@@ -82,17 +91,34 @@ func (a *activeMQMetadata) Validate() error {
 			return fmt.Errorf("no broker name given")
 		}
 	}
+	a.destinationNames = nil
+	for _, destinationName := range strings.Split(a.DestinationName, ",") {
+		destinationName = strings.TrimSpace(destinationName)
+		if destinationName == "" {
+			return fmt.Errorf("empty destination in destinationName %q", a.DestinationName)
+		}
+		if _, err := path.Match(destinationName, ""); err != nil {
+			return fmt.Errorf("invalid destination pattern %q: %w", destinationName, err)
+		}
+		a.destinationNames = append(a.destinationNames, destinationName)
+	}
 	if a.CorsHeader == "" {
 		a.CorsHeader = fmt.Sprintf(defaultCorsHeader, a.ManagementEndpoint)
 	}
-	a.metricName = GenerateMetricNameWithIndex(a.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("activemq-%s", a.DestinationName)))
+	metricDestinationName := strings.NewReplacer("*", "all").Replace(strings.Join(a.destinationNames, "-"))
+	a.metricName = GenerateMetricNameWithIndex(a.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("activemq-%s", metricDestinationName)))
 	return nil
 }
 
+// isPattern returns whether several queues are read, in which case Jolokia returns the attributes per MBean
+func (a *activeMQMetadata) isPattern() bool {
+	return len(a.destinationNames) > 1 || strings.Contains(a.destinationNames[0], "*")
+}
+
 type activeMQMonitoring struct {
-	MsgCount  int   `json:"value"`
-	Status    int   `json:"status"`
-	Timestamp int64 `json:"timestamp"`
+	Value     json.RawMessage `json:"value"`
+	Status    int             `json:"status"`
+	Timestamp int64           `json:"timestamp"`
 }
 
 // NewActiveMQScaler creates a new activeMQ Scaler
@@ -106,7 +132,7 @@ func NewActiveMQScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error parsing ActiveMQ metadata: %w", err)
 	}
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
 
 	return &activeMQScaler{
 		metricType: metricType,
@@ -127,10 +153,20 @@ func parseActiveMQMetadata(config *scalersconfig.ScalerConfig) (*activeMQMetadat
 
 func (s *activeMQScaler) getMonitoringEndpoint() (string, error) {
 	var buf bytes.Buffer
+	scheme := "http"
+	if s.metadata.UseHTTPS {
+		scheme = "https"
+	}
+	destinationName := s.metadata.DestinationName
+	if len(s.metadata.destinationNames) > 1 {
+		// the queues are filtered from the response
+		destinationName = "*"
+	}
 	endpoint := map[string]string{
+		"Scheme":             scheme,
 		"ManagementEndpoint": s.metadata.ManagementEndpoint,
 		"BrokerName":         s.metadata.BrokerName,
-		"DestinationName":    s.metadata.DestinationName,
+		"DestinationName":    destinationName,
 	}
 	template, err := template.New("monitoring_endpoint").Parse(s.metadata.RestAPITemplate)
 	if err != nil {
@@ -174,17 +210,63 @@ func (s *activeMQScaler) getQueueMessageCount(ctx context.Context) (int64, error
 	if err := json.NewDecoder(resp.Body).Decode(&monitoringInfo); err != nil {
 		return -1, err
 	}
-	if resp.StatusCode == 200 && monitoringInfo.Status == 200 {
-		queueMessageCount = int64(monitoringInfo.MsgCount)
-	} else {
+	if resp.StatusCode != 200 || monitoringInfo.Status != 200 {
 		return -1, fmt.Errorf("ActiveMQ management endpoint response error code : %d %d", resp.StatusCode, monitoringInfo.Status)
 	}
+	queueMessageCount, err = getActiveMQQueueSize(monitoringInfo.Value, s.metadata.destinationNames, s.metadata.isPattern())
+	if err != nil {
+		return -1, err
+	}
 
 	s.logger.V(1).Info(fmt.Sprintf("ActiveMQ scaler: Providing metrics based on current queue size %d queue size limit %d", queueMessageCount, s.metadata.TargetQueueSize))
 
 	return queueMessageCount, nil
 }
 
+// getActiveMQQueueSize returns the queue size read by Jolokia. A pattern read returns the attributes per MBean,
+// the sizes of the queues matching one of the destinationNames are summed up
+func getActiveMQQueueSize(value json.RawMessage, destinationNames []string, isPattern bool) (int64, error) {
+	if !isPattern {
+		var queueSize int64
+		if err := json.Unmarshal(value, &queueSize); err != nil {
+			return -1, fmt.Errorf("unable to parse ActiveMQ queue size: %w", err)
+		}
+		return queueSize, nil
+	}
+
+	var mbeans map[string]struct {
+		QueueSize int64 `json:"QueueSize"`
+	}
+	if err := json.Unmarshal(value, &mbeans); err != nil {
+		return -1, fmt.Errorf("unable to parse ActiveMQ queue sizes: %w", err)
+	}
+
+	var queueSize int64
+	for mbean, attributes := range mbeans {
+		if matchActiveMQDestination(mbean, destinationNames) {
+			queueSize += attributes.QueueSize
+		}
+	}
+	return queueSize, nil
+}
+
+// matchActiveMQDestination returns whether the destinationName of the MBean matches one of the destinationNames
+func matchActiveMQDestination(mbean string, destinationNames []string) bool {
+	_, properties, _ := strings.Cut(mbean, ":")
+	for _, property := range strings.Split(properties, ",") {
+		key, destination, _ := strings.Cut(property, "=")
+		if key != "destinationName" {
+			continue
+		}
+		for _, destinationName := range destinationNames {
+			if matched, _ := path.Match(destinationName, destination); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
 func (s *activeMQScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
@@ -2,11 +2,18 @@ package scalers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 const (
@@ -31,6 +38,7 @@ type activeMQMetricIdentifier struct {
 var activeMQMetricIdentifiers = []activeMQMetricIdentifier{
 	{&testActiveMQMetadata[1], 0, "s0-activemq-testQueue"},
 	{&testActiveMQMetadata[10], 1, "s1-activemq-testQueue"},
+	{&testActiveMQMetadata[14], 2, "s2-activemq-orders-invoices-all"},
 }
 
 var testActiveMQMetadata = []parseActiveMQMetadataTestData{
@@ -220,6 +228,48 @@ var testActiveMQMetadata = []parseActiveMQMetadataTestData{
 		},
 		isError: true,
 	},
+	{
+		name: "multiple destinations",
+		metadata: map[string]string{
+			"managementEndpoint": "localhost:8161",
+			"destinationName":    "orders, invoices.*",
+			"brokerName":         "localhost",
+			"useHttps":           "true",
+			"unsafeSsl":          "true",
+		},
+		authParams: map[string]string{
+			"username": "testUsername",
+			"password": "pass123",
+		},
+		isError: false,
+	},
+	{
+		name: "empty destination, should fail",
+		metadata: map[string]string{
+			"managementEndpoint": "localhost:8161",
+			"destinationName":    "orders,,invoices",
+			"brokerName":         "localhost",
+		},
+		authParams: map[string]string{
+			"username": "testUsername",
+			"password": "pass123",
+		},
+		isError: true,
+	},
+	{
+		name: "invalid unsafeSsl, should fail",
+		metadata: map[string]string{
+			"managementEndpoint": "localhost:8161",
+			"destinationName":    "testQueue",
+			"brokerName":         "localhost",
+			"unsafeSsl":          "maybe",
+		},
+		authParams: map[string]string{
+			"username": "testUsername",
+			"password": "pass123",
+		},
+		isError: true,
+	},
 }
 
 func TestActiveMQDefaultCorsHeader(t *testing.T) {
@@ -338,6 +388,23 @@ var getMonitoringEndpointData = []getMonitoringEndpointTestData{
 			"restAPITemplate": "https://myBrokerHost:8162/api/jolokia/read/org.apache.activemq:type=Broker,brokerName=myBrokerName,destinationType=Queue,destinationName=keda-test/QueueSize",
 		},
 	},
+	{
+		expected: "https://localhost:8161/api/jolokia/read/org.apache.activemq:type=Broker,brokerName=localhost,destinationType=Queue,destinationName=*/QueueSize",
+		metadata: map[string]string{
+			"managementEndpoint": "localhost:8161",
+			"destinationName":    "orders,invoices",
+			"brokerName":         "localhost",
+			"useHttps":           "true",
+		},
+	},
+	{
+		expected: "http://localhost:8161/api/jolokia/read/org.apache.activemq:type=Broker,brokerName=localhost,destinationType=Queue,destinationName=orders.*/QueueSize",
+		metadata: map[string]string{
+			"managementEndpoint": "localhost:8161",
+			"destinationName":    "orders.*",
+			"brokerName":         "localhost",
+		},
+	},
 }
 
 func TestActiveMQGetMonitoringEndpoint(t *testing.T) {
@@ -365,3 +432,94 @@ func TestActiveMQGetMonitoringEndpoint(t *testing.T) {
 		}
 	}
 }
+
+const testActiveMQJolokiaPatternResponse = `{
+	"request": {"mbean": "org.apache.activemq:brokerName=localhost,destinationName=*,destinationType=Queue,type=Broker", "attribute": "QueueSize", "type": "read"},
+	"value": {
+		"org.apache.activemq:brokerName=localhost,destinationName=orders,destinationType=Queue,type=Broker": {"QueueSize": 12},
+		"org.apache.activemq:brokerName=localhost,destinationName=invoices.eu,destinationType=Queue,type=Broker": {"QueueSize": 5},
+		"org.apache.activemq:brokerName=localhost,destinationName=invoices.us,destinationType=Queue,type=Broker": {"QueueSize": 3},
+		"org.apache.activemq:brokerName=localhost,destinationName=ActiveMQ.DLQ,destinationType=Queue,type=Broker": {"QueueSize": 100}
+	},
+	"timestamp": 1700000000,
+	"status": 200
+}`
+
+func TestGetActiveMQQueueSize(t *testing.T) {
+	testCases := []struct {
+		name             string
+		response         string
+		destinationNames []string
+		isPattern        bool
+		expected         int64
+		isError          bool
+	}{
+		{"single destination", `{"value": 42, "timestamp": 1700000000, "status": 200}`, []string{"orders"}, false, 42, false},
+		{"multiple destinations", testActiveMQJolokiaPatternResponse, []string{"orders", "invoices.eu"}, true, 17, false},
+		{"wildcard destination", testActiveMQJolokiaPatternResponse, []string{"invoices.*"}, true, 8, false},
+		{"wildcard and destination", testActiveMQJolokiaPatternResponse, []string{"orders", "invoices.*"}, true, 20, false},
+		{"all destinations", testActiveMQJolokiaPatternResponse, []string{"*"}, true, 120, false},
+		{"no matching destination", testActiveMQJolokiaPatternResponse, []string{"payments"}, true, 0, false},
+		{"unexpected single value", testActiveMQJolokiaPatternResponse, []string{"orders"}, false, -1, true},
+		{"unexpected pattern value", `{"value": 42, "timestamp": 1700000000, "status": 200}`, []string{"orders", "invoices"}, true, -1, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var monitoringInfo activeMQMonitoring
+			if err := json.Unmarshal([]byte(tc.response), &monitoringInfo); err != nil {
+				t.Fatal("Could not parse the response:", err)
+			}
+
+			queueSize, err := getActiveMQQueueSize(monitoringInfo.Value, tc.destinationNames, tc.isPattern)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, queueSize)
+		})
+	}
+}
+
+func TestActiveMQGetMetricsAndActivityOverTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "testUsername" || password != "pass123" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"status": 401}`))
+			return
+		}
+		assert.Equal(t, "/api/jolokia/read/org.apache.activemq:type=Broker,brokerName=localhost,destinationType=Queue,destinationName=*/QueueSize", r.URL.Path)
+		_, _ = w.Write([]byte(testActiveMQJolokiaPatternResponse))
+	}))
+	defer server.Close()
+
+	metadata, err := parseActiveMQMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"managementEndpoint":        strings.TrimPrefix(server.URL, "https://"),
+			"destinationName":           "orders,invoices.*",
+			"brokerName":                "localhost",
+			"useHttps":                  "true",
+			"unsafeSsl":                 "true",
+			"activationTargetQueueSize": "19",
+		},
+		AuthParams: map[string]string{
+			"username": "testUsername",
+			"password": "pass123",
+		},
+	})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	scaler := activeMQScaler{
+		metadata:   metadata,
+		httpClient: kedautil.CreateHTTPClient(0, metadata.UnsafeSsl),
+		logger:     logr.Discard(),
+	}
+
+	metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "activemq")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), metrics[0].Value.Value())
+	assert.True(t, isActive)
+}
@@ -0,0 +1,389 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/Azure/go-amqp"
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// amqpManagementProtocol is the AMQP Management 1.0 draft, implemented e.g. by Qpid Broker-J
+	amqpManagementProtocol = "amqpManagement"
+	// amqpArtemisProtocol is the ActiveMQ Artemis management API over AMQP
+	amqpArtemisProtocol = "artemis"
+
+	amqpDefaultManagementAddress        = "$management"
+	amqpArtemisDefaultManagementAddress = "activemq.management"
+
+	amqpArtemisResourceNameProperty       = "_AMQ_ResourceName"
+	amqpArtemisOperationNameProperty      = "_AMQ_OperationName"
+	amqpArtemisOperationSucceededProperty = "_AMQ_OperationSucceeded"
+)
+
+type amqpScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *amqpMetadata
+	client     amqpManagementClient
+	logger     logr.Logger
+}
+
+type amqpMetadata struct {
+	Host            string  `keda:"name=host,            order=triggerMetadata;authParams;resolvedEnv"`
+	QueueName       string  `keda:"name=queueName,       order=triggerMetadata"`
+	Value           float64 `keda:"name=value,           order=triggerMetadata, default=5"`
+	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, default=0"`
+
+	// management request
+	ManagementProtocol    string `keda:"name=managementProtocol,    order=triggerMetadata, enum=amqpManagement;artemis, default=amqpManagement"`
+	ManagementAddress     string `keda:"name=managementAddress,     order=triggerMetadata, optional"`
+	EntityType            string `keda:"name=entityType,            order=triggerMetadata, default=org.apache.qpid.Queue"`
+	MessageCountAttribute string `keda:"name=messageCountAttribute, order=triggerMetadata, default=queueDepthMessages"`
+
+	// SASL PLAIN, SASL ANONYMOUS is used when no credentials are provided
+	Username string `keda:"name=username, order=authParams;resolvedEnv, optional"`
+	Password string `keda:"name=password, order=authParams;resolvedEnv, optional"`
+
+	// TLS
+	UnsafeSsl   bool   `keda:"name=unsafeSsl,   order=triggerMetadata, default=false"`
+	Ca          string `keda:"name=ca,          order=authParams, optional"`
+	Cert        string `keda:"name=cert,        order=authParams, optional"`
+	Key         string `keda:"name=key,         order=authParams, optional"`
+	KeyPassword string `keda:"name=keyPassword, order=authParams, optional"`
+
+	triggerIndex int
+}
+
+func (m *amqpMetadata) Validate() error {
+	u, err := url.Parse(m.Host)
+	if err != nil {
+		return fmt.Errorf("can't parse host: %w", err)
+	}
+	switch u.Scheme {
+	case "amqp":
+		if m.Ca != "" || m.Cert != "" || m.Key != "" {
+			return fmt.Errorf("TLS settings require an amqps host")
+		}
+	case "amqps", "amqp+ssl":
+	default:
+		return fmt.Errorf("host must use the amqp or amqps scheme, got %q", u.Scheme)
+	}
+
+	if (m.Cert == "") != (m.Key == "") {
+		return fmt.Errorf("both cert and key must be provided")
+	}
+	if (m.Username == "") != (m.Password == "") {
+		return fmt.Errorf("both username and password must be provided")
+	}
+	if m.Value <= 0 {
+		return fmt.Errorf("value must be greater than 0")
+	}
+
+	if m.ManagementAddress == "" {
+		m.ManagementAddress = amqpDefaultManagementAddress
+		if m.ManagementProtocol == amqpArtemisProtocol {
+			m.ManagementAddress = amqpArtemisDefaultManagementAddress
+		}
+	}
+	return nil
+}
+
+// amqpManagementClient sends a management request and waits for its response
+type amqpManagementClient interface {
+	Request(ctx context.Context, msg *amqp.Message) (*amqp.Message, error)
+	Close(ctx context.Context) error
+}
+
+// amqpRequestSender is the sender link of the management requests
+type amqpRequestSender interface {
+	Send(ctx context.Context, msg *amqp.Message, opts *amqp.SendOptions) error
+}
+
+// amqpResponseReceiver is the receiver link of the management responses
+type amqpResponseReceiver interface {
+	Address() string
+	Receive(ctx context.Context, opts *amqp.ReceiveOptions) (*amqp.Message, error)
+	AcceptMessage(ctx context.Context, msg *amqp.Message) error
+}
+
+// amqpLinkManagementClient sends management requests over a sender link
+// and receives the responses on a receiver link with a dynamic address
+type amqpLinkManagementClient struct {
+	conn     *amqp.Conn
+	session  *amqp.Session
+	sender   amqpRequestSender
+	receiver amqpResponseReceiver
+
+	// requestMu serializes the requests, the concurrent polls would otherwise
+	// receive and skip the responses of each other on the shared receiver
+	requestMu sync.Mutex
+}
+
+// NewAmqpScaler creates a new amqpScaler
+func NewAmqpScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseAmqpMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing amqp metadata: %w", err)
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, config.GlobalHTTPTimeout)
+	defer cancel()
+
+	client, err := newAmqpLinkManagementClient(dialCtx, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &amqpScaler{
+		metricType: metricType,
+		metadata:   meta,
+		client:     client,
+		logger:     InitializeLogger(config, "amqp_scaler"),
+	}, nil
+}
+
+func parseAmqpMetadata(config *scalersconfig.ScalerConfig) (*amqpMetadata, error) {
+	meta := &amqpMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+func newAmqpLinkManagementClient(ctx context.Context, meta *amqpMetadata) (*amqpLinkManagementClient, error) {
+	// credentials in the host take precedence over the SASL type
+	opts := &amqp.ConnOptions{SASLType: amqp.SASLTypeAnonymous()}
+	if meta.Username != "" {
+		opts.SASLType = amqp.SASLTypePlain(meta.Username, meta.Password)
+	}
+
+	// the TLS config is only used by go-amqp for amqps hosts
+	tlsConfig, err := kedautil.NewTLSConfigWithPassword(meta.Cert, meta.Key, meta.KeyPassword, meta.Ca, meta.UnsafeSsl)
+	if err != nil {
+		return nil, err
+	}
+	opts.TLSConfig = tlsConfig
+
+	conn, err := amqp.Dial(ctx, meta.Host, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to the amqp broker: %w", err)
+	}
+
+	client := &amqpLinkManagementClient{conn: conn}
+	if err := client.open(ctx, meta.ManagementAddress); err != nil {
+		_ = client.Close(ctx)
+		return nil, err
+	}
+	return client, nil
+}
+
+func (c *amqpLinkManagementClient) open(ctx context.Context, managementAddress string) error {
+	var err error
+	c.session, err = c.conn.NewSession(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error creating amqp session: %w", err)
+	}
+	c.sender, err = c.session.NewSender(ctx, managementAddress, nil)
+	if err != nil {
+		return fmt.Errorf("error creating amqp sender to %s: %w", managementAddress, err)
+	}
+	c.receiver, err = c.session.NewReceiver(ctx, "", &amqp.ReceiverOptions{DynamicAddress: true})
+	if err != nil {
+		return fmt.Errorf("error creating amqp receiver for the management responses: %w", err)
+	}
+	return nil
+}
+
+// Request sends the management request and returns the response correlated to it
+func (c *amqpLinkManagementClient) Request(ctx context.Context, msg *amqp.Message) (*amqp.Message, error) {
+	c.requestMu.Lock()
+	defer c.requestMu.Unlock()
+
+	messageID := uuid.NewString()
+	replyTo := c.receiver.Address()
+	if msg.Properties == nil {
+		msg.Properties = &amqp.MessageProperties{}
+	}
+	msg.Properties.MessageID = messageID
+	msg.Properties.ReplyTo = &replyTo
+
+	if err := c.sender.Send(ctx, msg, nil); err != nil {
+		return nil, fmt.Errorf("error sending management request: %w", err)
+	}
+
+	for {
+		response, err := c.receiver.Receive(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error receiving management response: %w", err)
+		}
+		if err := c.receiver.AcceptMessage(ctx, response); err != nil {
+			return nil, fmt.Errorf("error accepting management response: %w", err)
+		}
+		// skip the responses of previous requests which timed out
+		if response.Properties != nil && response.Properties.CorrelationID == messageID {
+			return response, nil
+		}
+	}
+}
+
+func (c *amqpLinkManagementClient) Close(ctx context.Context) error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// newAmqpManagementRequest builds the management request reading the message count of the queue
+func newAmqpManagementRequest(meta *amqpMetadata) *amqp.Message {
+	if meta.ManagementProtocol == amqpArtemisProtocol {
+		return &amqp.Message{
+			ApplicationProperties: map[string]any{
+				amqpArtemisResourceNameProperty:  "queue." + meta.QueueName,
+				amqpArtemisOperationNameProperty: "getMessageCount",
+			},
+			Value: "[]",
+		}
+	}
+
+	return &amqp.Message{
+		ApplicationProperties: map[string]any{
+			"operation": "READ",
+			"type":      meta.EntityType,
+			"name":      meta.QueueName,
+		},
+		Value: map[string]any{},
+	}
+}
+
+// parseAmqpManagementResponse extracts the message count from the management response
+func parseAmqpManagementResponse(meta *amqpMetadata, msg *amqp.Message) (float64, error) {
+	if meta.ManagementProtocol == amqpArtemisProtocol {
+		if succeeded, _ := msg.ApplicationProperties[amqpArtemisOperationSucceededProperty].(bool); !succeeded {
+			return 0, fmt.Errorf("management operation failed: %v", msg.Value)
+		}
+		body, ok := msg.Value.(string)
+		if !ok {
+			return 0, fmt.Errorf("unexpected management response body type %T", msg.Value)
+		}
+		var result []json.Number
+		if err := json.Unmarshal([]byte(body), &result); err != nil || len(result) != 1 {
+			return 0, fmt.Errorf("unexpected management response body %q", body)
+		}
+		return result[0].Float64()
+	}
+
+	statusCode, err := amqpNumber(msg.ApplicationProperties["statusCode"])
+	if err != nil {
+		return 0, fmt.Errorf("invalid management response status code: %w", err)
+	}
+	if statusCode != 200 {
+		return 0, fmt.Errorf("management request failed with status code %v: %v", statusCode, msg.ApplicationProperties["statusDescription"])
+	}
+
+	var attribute any
+	switch body := msg.Value.(type) {
+	case map[string]any:
+		attribute = body[meta.MessageCountAttribute]
+	case map[any]any:
+		attribute = body[meta.MessageCountAttribute]
+	default:
+		return 0, fmt.Errorf("unexpected management response body type %T", msg.Value)
+	}
+	if attribute == nil {
+		return 0, fmt.Errorf("attribute %s not found in the management response", meta.MessageCountAttribute)
+	}
+	return amqpNumber(attribute)
+}
+
+// amqpNumber converts the numeric AMQP types to float64
+func amqpNumber(v any) (float64, error) {
+	switch n := v.(type) {
+	case int8:
+		return float64(n), nil
+	case int16:
+		return float64(n), nil
+	case int32:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case int:
+		return float64(n), nil
+	case uint8:
+		return float64(n), nil
+	case uint16:
+		return float64(n), nil
+	case uint32:
+		return float64(n), nil
+	case uint64:
+		return float64(n), nil
+	case float32:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	case string:
+		return strconv.ParseFloat(n, 64)
+	case nil:
+		return 0, errors.New("value is missing")
+	default:
+		return 0, fmt.Errorf("value %v of type %T is not a number", v, v)
+	}
+}
+
+func (s *amqpScaler) Close(ctx context.Context) error {
+	if s.client != nil {
+		err := s.client.Close(ctx)
+		s.client = nil
+		if err != nil {
+			s.logger.Error(err, "error closing amqp connection")
+		}
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *amqpScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("amqp-%s", s.metadata.QueueName))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
+func (s *amqpScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	response, err := s.client.Request(ctx, newAmqpManagementRequest(s.metadata))
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	messageCount, err := parseAmqpManagementResponse(s.metadata, response)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error reading the message count of queue %s: %w", s.metadata.QueueName, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, messageCount)
+
+	return []external_metrics.ExternalMetricValue{metric}, messageCount > s.metadata.ActivationValue, nil
+}
@@ -0,0 +1,351 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-amqp"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseAmqpMetadataTestData struct {
+	name        string
+	metadata    map[string]string
+	authParams  map[string]string
+	resolvedEnv map[string]string
+	isError     bool
+	expected    *amqpMetadata
+}
+
+type amqpMetricIdentifier struct {
+	triggerIndex int
+	name         string
+}
+
+var testAmqpMetadata = []parseAmqpMetadataTestData{
+	{
+		name:     "properly formed",
+		metadata: map[string]string{"host": "amqp://broker:5672", "queueName": "orders", "value": "10", "activationValue": "2"},
+		expected: &amqpMetadata{
+			Host:                  "amqp://broker:5672",
+			QueueName:             "orders",
+			Value:                 10,
+			ActivationValue:       2,
+			ManagementProtocol:    amqpManagementProtocol,
+			ManagementAddress:     amqpDefaultManagementAddress,
+			EntityType:            "org.apache.qpid.Queue",
+			MessageCountAttribute: "queueDepthMessages",
+		},
+	},
+	{
+		name:       "artemis with SASL and TLS from auth params",
+		metadata:   map[string]string{"queueName": "orders", "managementProtocol": "artemis", "unsafeSsl": "true"},
+		authParams: map[string]string{"host": "amqps://broker:5671", "username": "user", "password": "pass", "ca": "caaa"},
+		expected: &amqpMetadata{
+			Host:                  "amqps://broker:5671",
+			QueueName:             "orders",
+			Value:                 5,
+			ManagementProtocol:    amqpArtemisProtocol,
+			ManagementAddress:     amqpArtemisDefaultManagementAddress,
+			EntityType:            "org.apache.qpid.Queue",
+			MessageCountAttribute: "queueDepthMessages",
+			Username:              "user",
+			Password:              "pass",
+			UnsafeSsl:             true,
+			Ca:                    "caaa",
+		},
+	},
+	{
+		name:        "host from env and custom management request",
+		metadata:    map[string]string{"hostFromEnv": "AMQP_HOST", "queueName": "orders", "managementAddress": "$mgmt", "entityType": "queue", "messageCountAttribute": "messageCount"},
+		resolvedEnv: map[string]string{"AMQP_HOST": "amqp://broker"},
+		expected: &amqpMetadata{
+			Host:                  "amqp://broker",
+			QueueName:             "orders",
+			Value:                 5,
+			ManagementProtocol:    amqpManagementProtocol,
+			ManagementAddress:     "$mgmt",
+			EntityType:            "queue",
+			MessageCountAttribute: "messageCount",
+		},
+	},
+	{
+		name:     "missing host",
+		metadata: map[string]string{"queueName": "orders"},
+		isError:  true,
+	},
+	{
+		name:     "missing queueName",
+		metadata: map[string]string{"host": "amqp://broker"},
+		isError:  true,
+	},
+	{
+		name:     "unsupported scheme",
+		metadata: map[string]string{"host": "http://broker", "queueName": "orders"},
+		isError:  true,
+	},
+	{
+		name:     "unknown management protocol",
+		metadata: map[string]string{"host": "amqp://broker", "queueName": "orders", "managementProtocol": "jolokia"},
+		isError:  true,
+	},
+	{
+		name:     "invalid value",
+		metadata: map[string]string{"host": "amqp://broker", "queueName": "orders", "value": "0"},
+		isError:  true,
+	},
+	{
+		name:     "malformed activationValue",
+		metadata: map[string]string{"host": "amqp://broker", "queueName": "orders", "activationValue": "a"},
+		isError:  true,
+	},
+	{
+		name:       "username without password",
+		metadata:   map[string]string{"host": "amqp://broker", "queueName": "orders"},
+		authParams: map[string]string{"username": "user"},
+		isError:    true,
+	},
+	{
+		name:       "cert without key",
+		metadata:   map[string]string{"host": "amqps://broker", "queueName": "orders"},
+		authParams: map[string]string{"cert": "ceert"},
+		isError:    true,
+	},
+	{
+		name:       "TLS settings without amqps",
+		metadata:   map[string]string{"host": "amqp://broker", "queueName": "orders"},
+		authParams: map[string]string{"ca": "caaa"},
+		isError:    true,
+	},
+}
+
+var amqpMetricIdentifiers = []amqpMetricIdentifier{
+	{0, "s0-amqp-orders"},
+	{1, "s1-amqp-orders"},
+}
+
+func TestAmqpParseMetadata(t *testing.T) {
+	for _, testData := range testAmqpMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			meta, err := parseAmqpMetadata(&scalersconfig.ScalerConfig{
+				TriggerMetadata: testData.metadata,
+				AuthParams:      testData.authParams,
+				ResolvedEnv:     testData.resolvedEnv,
+			})
+			if testData.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, meta)
+		})
+	}
+}
+
+func TestAmqpGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range amqpMetricIdentifiers {
+		meta, err := parseAmqpMetadata(&scalersconfig.ScalerConfig{
+			TriggerMetadata: testAmqpMetadata[0].metadata,
+			TriggerIndex:    testData.triggerIndex,
+		})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockAmqpScaler := amqpScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockAmqpScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+// fakeAmqpManagementClient is a fake AMQP management endpoint answering with a fixed response
+type fakeAmqpManagementClient struct {
+	requests []*amqp.Message
+	response *amqp.Message
+	err      error
+}
+
+func (c *fakeAmqpManagementClient) Request(_ context.Context, msg *amqp.Message) (*amqp.Message, error) {
+	c.requests = append(c.requests, msg)
+	return c.response, c.err
+}
+
+func (c *fakeAmqpManagementClient) Close(context.Context) error {
+	return nil
+}
+
+func TestAmqpGetMetricsAndActivity(t *testing.T) {
+	testCases := []struct {
+		name               string
+		managementProtocol string
+		response           *amqp.Message
+		requestErr         error
+		expectedRequest    *amqp.Message
+		expectedValue      int64
+		expectedActive     bool
+		expectedError      string
+	}{
+		{
+			name:               "amqp management",
+			managementProtocol: amqpManagementProtocol,
+			response: &amqp.Message{
+				ApplicationProperties: map[string]any{"statusCode": int32(200)},
+				Value:                 map[string]any{"queueDepthMessages": int64(12), "name": "orders"},
+			},
+			expectedRequest: &amqp.Message{
+				ApplicationProperties: map[string]any{"operation": "READ", "type": "org.apache.qpid.Queue", "name": "orders"},
+				Value:                 map[string]any{},
+			},
+			expectedValue:  12,
+			expectedActive: true,
+		},
+		{
+			name:               "amqp management below activation",
+			managementProtocol: amqpManagementProtocol,
+			response: &amqp.Message{
+				ApplicationProperties: map[string]any{"statusCode": int64(200)},
+				Value:                 map[string]any{"queueDepthMessages": uint32(2)},
+			},
+			expectedValue:  2,
+			expectedActive: false,
+		},
+		{
+			name:               "amqp management error status",
+			managementProtocol: amqpManagementProtocol,
+			response: &amqp.Message{
+				ApplicationProperties: map[string]any{"statusCode": int32(404), "statusDescription": "not found"},
+			},
+			expectedError: "management request failed with status code 404: not found",
+		},
+		{
+			name:               "amqp management missing attribute",
+			managementProtocol: amqpManagementProtocol,
+			response: &amqp.Message{
+				ApplicationProperties: map[string]any{"statusCode": int32(200)},
+				Value:                 map[string]any{"name": "orders"},
+			},
+			expectedError: "attribute queueDepthMessages not found",
+		},
+		{
+			name:               "artemis",
+			managementProtocol: amqpArtemisProtocol,
+			response: &amqp.Message{
+				ApplicationProperties: map[string]any{"_AMQ_OperationSucceeded": true},
+				Value:                 "[7]",
+			},
+			expectedRequest: &amqp.Message{
+				ApplicationProperties: map[string]any{"_AMQ_ResourceName": "queue.orders", "_AMQ_OperationName": "getMessageCount"},
+				Value:                 "[]",
+			},
+			expectedValue:  7,
+			expectedActive: true,
+		},
+		{
+			name:               "artemis operation failed",
+			managementProtocol: amqpArtemisProtocol,
+			response: &amqp.Message{
+				ApplicationProperties: map[string]any{"_AMQ_OperationSucceeded": false},
+				Value:                 "queue orders doesn't exist",
+			},
+			expectedError: "management operation failed",
+		},
+		{
+			name:               "request error",
+			managementProtocol: amqpArtemisProtocol,
+			requestErr:         errors.New("link detached"),
+			expectedError:      "link detached",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakeAmqpManagementClient{response: tc.response, err: tc.requestErr}
+			s := amqpScaler{
+				metadata: &amqpMetadata{
+					QueueName:             "orders",
+					ActivationValue:       5,
+					ManagementProtocol:    tc.managementProtocol,
+					EntityType:            "org.apache.qpid.Queue",
+					MessageCountAttribute: "queueDepthMessages",
+				},
+				client: client,
+				logger: logr.Discard(),
+			}
+
+			metrics, active, err := s.GetMetricsAndActivity(context.Background(), "s0-amqp-orders")
+			if tc.expectedRequest != nil && assert.Len(t, client.requests, 1) {
+				assert.Equal(t, tc.expectedRequest, client.requests[0])
+			}
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedActive, active)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+		})
+	}
+}
+
+// fakeAmqpLink is a fake pair of management links, replying to each request on the shared receiver
+type fakeAmqpLink struct {
+	responses chan *amqp.Message
+}
+
+func (l *fakeAmqpLink) Send(_ context.Context, msg *amqp.Message, _ *amqp.SendOptions) error {
+	l.responses <- &amqp.Message{
+		Properties: &amqp.MessageProperties{CorrelationID: msg.Properties.MessageID},
+		Value:      msg.Value,
+	}
+	// leave the time to the other requests to be sent before the response is received
+	time.Sleep(time.Millisecond)
+	return nil
+}
+
+func (l *fakeAmqpLink) Address() string {
+	return "reply-to"
+}
+
+func (l *fakeAmqpLink) Receive(ctx context.Context, _ *amqp.ReceiveOptions) (*amqp.Message, error) {
+	select {
+	case msg := <-l.responses:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *fakeAmqpLink) AcceptMessage(context.Context, *amqp.Message) error {
+	return nil
+}
+
+func TestAmqpLinkManagementClientConcurrentRequests(t *testing.T) {
+	link := &fakeAmqpLink{responses: make(chan *amqp.Message, 100)}
+	client := &amqpLinkManagementClient{sender: link, receiver: link}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			response, err := client.Request(ctx, &amqp.Message{Value: fmt.Sprint(i)})
+			if assert.NoError(t, err) {
+				assert.Equal(t, fmt.Sprint(i), response.Value)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
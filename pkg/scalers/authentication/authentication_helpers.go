@@ -165,7 +165,9 @@ func NewTLSConfig(auth *AuthMeta, unsafeSsl bool) (*tls.Config, error) {
 func CreateHTTPRoundTripper(roundTripperType TransportType, auth *AuthMeta, conf ...*HTTPTransport) (rt http.RoundTripper, err error) {
 	unsafeSsl := false
 	tlsConfig := kedautil.CreateTLSClientConfig(unsafeSsl)
-	if auth != nil && (auth.CA != "" || auth.EnableTLS) {
+	if auth != nil && auth.TLSConfig != nil {
+		tlsConfig = auth.TLSConfig
+	} else if auth != nil && (auth.CA != "" || auth.EnableTLS) {
 		tlsConfig, err = NewTLSConfig(auth, unsafeSsl)
 		if err != nil || tlsConfig == nil {
 			return nil, fmt.Errorf("error creating the TLS config: %w", err)
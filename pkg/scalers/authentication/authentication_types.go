@@ -1,6 +1,7 @@
 package authentication
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net/url"
 	"time"
@@ -49,6 +50,8 @@ type AuthMeta struct {
 	Cert      string
 	Key       string
 	CA        string
+	// TLSConfig already parsed from Cert, Key and CA, they aren't parsed again when it's set
+	TLSConfig *tls.Config
 
 	// oAuth2
 	EnableOAuth    bool
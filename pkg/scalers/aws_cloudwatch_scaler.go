@@ -36,7 +36,7 @@ type awsCloudwatchMetadata struct {
 
 	TargetMetricValue           float64 `keda:"name=targetMetricValue,           order=triggerMetadata"`
 	ActivationTargetMetricValue float64 `keda:"name=activationTargetMetricValue, order=triggerMetadata, optional"`
-	MinMetricValue              float64 `keda:"name=minMetricValue,              order=triggerMetadata"`
+	MinMetricValue              float64 `keda:"name=minMetricValue,              order=triggerMetadata, default=0"`
 	IgnoreNullValues            bool    `keda:"name=ignoreNullValues,            order=triggerMetadata, default=true"`
 
 	MetricCollectionTime int64  `keda:"name=metricCollectionTime, order=triggerMetadata, default=300"`
@@ -83,9 +83,13 @@ func (a *awsCloudwatchMetadata) Validate() error {
 	if err = checkMetricStatPeriod(a.MetricStatPeriod); err != nil {
 		return err
 	}
-	if a.MetricCollectionTime < 0 || a.MetricCollectionTime%a.MetricStatPeriod != 0 {
+	if a.MetricCollectionTime <= 0 || a.MetricCollectionTime%a.MetricStatPeriod != 0 {
 		return fmt.Errorf("metricCollectionTime must be greater than 0 and a multiple of metricStatPeriod(%d), %d is given", a.MetricStatPeriod, a.MetricCollectionTime)
 	}
+	// a negative offset would move the end of the query window into the future
+	if a.MetricEndTimeOffset < 0 {
+		return fmt.Errorf("metricEndTimeOffset must be greater than or equal to 0, %d is given", a.MetricEndTimeOffset)
+	}
 
 	return nil
 }
@@ -281,21 +285,17 @@ func (s *awsCloudwatchScaler) GetCloudwatchMetrics(ctx context.Context) (float64
 
 	s.logger.V(1).Info("Received Metric Data", "data", output)
 
-	// If no metric data results or the first result has no values, and ignoreNullValues is false,
-	// the scaler should return an error to prevent any further scaling actions.
-	if len(output.MetricDataResults) > 0 && len(output.MetricDataResults[0].Values) == 0 && !s.metadata.IgnoreNullValues {
-		emptyMetricsErrMsg := "empty metric data received, ignoreNullValues is false, returning error"
-		s.logger.Error(nil, emptyMetricsErrMsg)
-		return -1, fmt.Errorf("%s", emptyMetricsErrMsg)
-	}
-
-	var metricValue float64
-
-	if len(output.MetricDataResults) > 0 && len(output.MetricDataResults[0].Values) > 0 {
-		metricValue = output.MetricDataResults[0].Values[0]
-	} else {
+	// Sparse metrics, e.g. custom metrics only published while there is work, have no datapoint in the query window.
+	// Unless ignoreNullValues is set, the scaler returns an error to prevent any further scaling actions
+	if len(output.MetricDataResults) == 0 || len(output.MetricDataResults[0].Values) == 0 {
+		if !s.metadata.IgnoreNullValues {
+			emptyMetricsErrMsg := "empty metric data received, ignoreNullValues is false, returning error"
+			s.logger.Error(nil, emptyMetricsErrMsg)
+			return -1, fmt.Errorf("%s", emptyMetricsErrMsg)
+		}
 		s.logger.Info("empty metric data received, returning minMetricValue")
-		metricValue = s.metadata.MinMetricValue
+		return s.metadata.MinMetricValue, nil
 	}
-	return metricValue, nil
+
+	return output.MetricDataResults[0].Values[0], nil
 }
@@ -381,6 +381,50 @@ var testAWSCloudwatchMetadata = []parseAWSCloudwatchMetadataTestData{
 		testAWSAuthentication, true,
 		"metricCollectionTime smaller than metricStatPeriod",
 	},
+	{
+		map[string]string{
+			"namespace":            "AWS/SQS",
+			"dimensionName":        "QueueName",
+			"dimensionValue":       "keda",
+			"metricName":           "ApproximateNumberOfMessagesVisible",
+			"targetMetricValue":    "2",
+			"metricCollectionTime": "0",
+			"metricStat":           "Average",
+			"awsRegion":            "eu-west-1",
+		},
+		testAWSAuthentication, true,
+		"metricCollectionTime is 0",
+	},
+	{
+		map[string]string{
+			"namespace":           "AWS/SQS",
+			"dimensionName":       "QueueName",
+			"dimensionValue":      "keda",
+			"metricName":          "ApproximateNumberOfMessagesVisible",
+			"targetMetricValue":   "2",
+			"metricStat":          "Average",
+			"metricEndTimeOffset": "-60",
+			"awsRegion":           "eu-west-1",
+		},
+		testAWSAuthentication, true,
+		"negative metricEndTimeOffset",
+	},
+	{
+		map[string]string{
+			"namespace":           "AWS/SQS",
+			"dimensionName":       "QueueName",
+			"dimensionValue":      "keda",
+			"metricName":          "ApproximateNumberOfMessagesVisible",
+			"targetMetricValue":   "2",
+			"metricStat":          "Sum",
+			"metricStatPeriod":    "60",
+			"metricEndTimeOffset": "120",
+			"ignoreNullValues":    "true",
+			"awsRegion":           "eu-west-1",
+		},
+		testAWSAuthentication, false,
+		"missing minMetricValue defaults to 0 for sparse metrics",
+	},
 	{
 		map[string]string{
 			"namespace":         "AWS/SQS",
@@ -701,9 +745,7 @@ func TestAWSCloudwatchScalerGetMetrics(t *testing.T) {
 		switch meta.MetricsName {
 		case testAWSCloudwatchErrorMetric:
 			assert.Error(t, err, "expect error because of cloudwatch api error")
-		case testAWSCloudwatchNoValueMetric:
-			assert.NoError(t, err, "dont expect error when returning empty metric list from cloudwatch")
-		case testAWSCloudwatchEmptyValues:
+		case testAWSCloudwatchNoValueMetric, testAWSCloudwatchEmptyValues:
 			if meta.IgnoreNullValues {
 				assert.NoError(t, err, "dont expect error when returning empty metric list from cloudwatch")
 			} else {
@@ -715,6 +757,51 @@ func TestAWSCloudwatchScalerGetMetrics(t *testing.T) {
 	}
 }
 
+func TestAWSCloudwatchScalerEmptyDatapoints(t *testing.T) {
+	testCases := []struct {
+		name             string
+		metricName       string
+		ignoreNullValues bool
+		minMetricValue   float64
+		isError          bool
+		expected         float64
+	}{
+		{name: "no result, ignoreNullValues", metricName: testAWSCloudwatchNoValueMetric, ignoreNullValues: true, expected: 0},
+		{name: "no result, ignoreNullValues with minMetricValue", metricName: testAWSCloudwatchNoValueMetric, ignoreNullValues: true, minMetricValue: 2, expected: 2},
+		{name: "no result", metricName: testAWSCloudwatchNoValueMetric, isError: true},
+		{name: "no datapoint, ignoreNullValues", metricName: testAWSCloudwatchEmptyValues, ignoreNullValues: true, expected: 0},
+		{name: "no datapoint, ignoreNullValues with minMetricValue", metricName: testAWSCloudwatchEmptyValues, ignoreNullValues: true, minMetricValue: 2, expected: 2},
+		{name: "no datapoint", metricName: testAWSCloudwatchEmptyValues, isError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			meta := &awsCloudwatchMetadata{
+				Namespace:            "Custom",
+				MetricsName:          tc.metricName,
+				DimensionName:        []string{"DIM"},
+				DimensionValue:       []string{"DIM_VALUE"},
+				TargetMetricValue:    100,
+				MinMetricValue:       tc.minMetricValue,
+				IgnoreNullValues:     tc.ignoreNullValues,
+				MetricCollectionTime: 60,
+				MetricStat:           "Average",
+				MetricStatPeriod:     60,
+				AwsRegion:            "us-west-2",
+			}
+			scaler := awsCloudwatchScaler{"", meta, &mockCloudwatch{}, logr.Discard()}
+
+			value, err := scaler.GetCloudwatchMetrics(context.Background())
+			if tc.isError {
+				assert.ErrorContains(t, err, "empty metric data received")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
 type computeQueryWindowTestArgs struct {
 	name                    string
 	current                 string
@@ -0,0 +1,171 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// maximum number of keys S3 returns in a page of ListObjectsV2
+const awsS3MaxPageSize = 1000
+
+type awsS3Scaler struct {
+	metricType v2.MetricTargetType
+	metadata   *awsS3Metadata
+	s3Client   s3.ListObjectsV2APIClient
+	logger     logr.Logger
+}
+
+type awsS3Metadata struct {
+	Bucket          string `keda:"name=bucket, order=triggerMetadata"`
+	Prefix          string `keda:"name=prefix, order=triggerMetadata, optional"`
+	TargetValue     int64  `keda:"name=targetValue, order=triggerMetadata, default=5"`
+	ActivationValue int64  `keda:"name=activationValue, order=triggerMetadata, default=0"`
+	// the listing stops once maxObjectCount objects are counted, bounding the number of requests per poll
+	MaxObjectCount int64  `keda:"name=maxObjectCount, order=triggerMetadata, default=10000"`
+	PageSize       int32  `keda:"name=pageSize, order=triggerMetadata, default=1000"`
+	AwsRegion      string `keda:"name=awsRegion, order=triggerMetadata;authParams"`
+	AwsEndpoint    string `keda:"name=awsEndpoint, order=triggerMetadata, optional"`
+	UsePathStyle   bool   `keda:"name=usePathStyle, order=triggerMetadata, default=false"`
+
+	awsAuthorization awsutils.AuthorizationMetadata
+	triggerIndex     int
+}
+
+func (m *awsS3Metadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return fmt.Errorf("targetValue must be greater than 0")
+	}
+	if m.ActivationValue < 0 {
+		return fmt.Errorf("activationValue must be greater than or equal to 0")
+	}
+	if m.MaxObjectCount <= 0 {
+		return fmt.Errorf("maxObjectCount must be greater than 0")
+	}
+	if m.PageSize <= 0 || m.PageSize > awsS3MaxPageSize {
+		return fmt.Errorf("pageSize must be between 1 and %d", awsS3MaxPageSize)
+	}
+	return nil
+}
+
+// NewAwsS3Scaler creates a new awsS3Scaler
+func NewAwsS3Scaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseAwsS3Metadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing S3 metadata: %w", err)
+	}
+	s3Client, err := createS3Client(ctx, meta)
+	if err != nil {
+		return nil, fmt.Errorf("error when creating s3 client: %w", err)
+	}
+	return &awsS3Scaler{
+		metricType: metricType,
+		metadata:   meta,
+		s3Client:   s3Client,
+		logger:     InitializeLogger(config, "aws_s3_scaler"),
+	}, nil
+}
+
+func parseAwsS3Metadata(config *scalersconfig.ScalerConfig) (*awsS3Metadata, error) {
+	meta := &awsS3Metadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+
+	auth, err := awsutils.GetAwsAuthorization(config.TriggerUniqueKey, meta.AwsRegion, config.PodIdentity, config.TriggerMetadata, config.AuthParams, config.ResolvedEnv)
+	if err != nil {
+		return nil, err
+	}
+	meta.awsAuthorization = auth
+	meta.triggerIndex = config.TriggerIndex
+
+	return meta, nil
+}
+
+func createS3Client(ctx context.Context, metadata *awsS3Metadata) (*s3.Client, error) {
+	cfg, err := awsutils.GetAwsConfig(ctx, metadata.awsAuthorization)
+	if err != nil {
+		return nil, err
+	}
+	return s3.NewFromConfig(*cfg, func(options *s3.Options) {
+		if metadata.AwsEndpoint != "" {
+			options.BaseEndpoint = aws.String(metadata.AwsEndpoint)
+		}
+		options.UsePathStyle = metadata.UsePathStyle
+	}), nil
+}
+
+func (s *awsS3Scaler) Close(context.Context) error {
+	awsutils.ClearAwsConfig(s.metadata.awsAuthorization)
+	return nil
+}
+
+func (s *awsS3Scaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("aws-s3-%s-%s", s.metadata.Bucket, s.metadata.Prefix))),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
+func (s *awsS3Scaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	objectCount, err := s.getObjectCount(ctx)
+	if err != nil {
+		s.logger.Error(err, "Error getting object count")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(objectCount))
+
+	return []external_metrics.ExternalMetricValue{metric}, objectCount > s.metadata.ActivationValue, nil
+}
+
+// getObjectCount counts the objects under the prefix of the bucket, up to maxObjectCount.
+// The keys ending with "/", used as folder placeholders, aren't counted.
+func (s *awsS3Scaler) getObjectCount(ctx context.Context) (int64, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(s.metadata.Bucket),
+		MaxKeys: aws.Int32(s.metadata.PageSize),
+	}
+	if s.metadata.Prefix != "" {
+		input.Prefix = aws.String(s.metadata.Prefix)
+	}
+
+	var count int64
+	paginator := s3.NewListObjectsV2Paginator(s.s3Client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return -1, fmt.Errorf("error listing objects of bucket %s: %w", s.metadata.Bucket, err)
+		}
+		for _, object := range page.Contents {
+			if strings.HasSuffix(aws.ToString(object.Key), "/") {
+				continue
+			}
+			count++
+			if count >= s.metadata.MaxObjectCount {
+				return s.metadata.MaxObjectCount, nil
+			}
+		}
+	}
+	return count, nil
+}
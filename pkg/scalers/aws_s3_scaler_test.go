@@ -0,0 +1,174 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+var testAWSS3Authentication = map[string]string{
+	"awsAccessKeyId":     "none",
+	"awsSecretAccessKey": "none",
+}
+
+type parseAWSS3MetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type awsS3MetricIdentifier struct {
+	metadataTestData *parseAWSS3MetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testAWSS3Metadata = []parseAWSS3MetadataTestData{
+	{map[string]string{}, testAWSS3Authentication, true, "metadata empty"},
+	{map[string]string{"bucket": "incoming", "awsRegion": "eu-west-1"}, testAWSS3Authentication, false, "properly formed bucket"},
+	{map[string]string{"bucket": "incoming", "prefix": "jobs/", "targetValue": "10", "activationValue": "2", "awsRegion": "eu-west-1"}, testAWSS3Authentication, false, "properly formed bucket and prefix"},
+	{map[string]string{"prefix": "jobs/", "awsRegion": "eu-west-1"}, testAWSS3Authentication, true, "missing bucket"},
+	{map[string]string{"bucket": "incoming"}, testAWSS3Authentication, true, "missing region"},
+	{map[string]string{"bucket": "incoming", "awsRegion": "eu-west-1"}, map[string]string{}, true, "missing credentials"},
+	{map[string]string{"bucket": "incoming", "targetValue": "0", "awsRegion": "eu-west-1"}, testAWSS3Authentication, true, "targetValue not positive"},
+	{map[string]string{"bucket": "incoming", "activationValue": "-1", "awsRegion": "eu-west-1"}, testAWSS3Authentication, true, "negative activationValue"},
+	{map[string]string{"bucket": "incoming", "maxObjectCount": "0", "awsRegion": "eu-west-1"}, testAWSS3Authentication, true, "maxObjectCount not positive"},
+	{map[string]string{"bucket": "incoming", "pageSize": "1001", "awsRegion": "eu-west-1"}, testAWSS3Authentication, true, "pageSize above the S3 limit"},
+	{map[string]string{"bucket": "incoming", "pageSize": "abc", "awsRegion": "eu-west-1"}, testAWSS3Authentication, true, "invalid pageSize"},
+	{map[string]string{"bucket": "incoming", "awsRegion": "eu-west-1", "awsEndpoint": "http://localhost:4566", "usePathStyle": "true"}, testAWSS3Authentication, false, "custom endpoint"},
+}
+
+var awsS3MetricIdentifiers = []awsS3MetricIdentifier{
+	{&testAWSS3Metadata[1], 0, "s0-aws-s3-incoming-"},
+	{&testAWSS3Metadata[2], 1, "s1-aws-s3-incoming-jobs-"},
+}
+
+func TestAWSS3ParseMetadata(t *testing.T) {
+	for _, testData := range testAWSS3Metadata {
+		_, err := parseAwsS3Metadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("%s: expected success but got error %s", testData.comment, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("%s: expected error but got success", testData.comment)
+		}
+	}
+}
+
+func TestAWSS3GetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range awsS3MetricIdentifiers {
+		meta, err := parseAwsS3Metadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockAWSS3Scaler := awsS3Scaler{"", meta, &mockS3ListObjects{}, logr.Discard()}
+
+		metricSpec := mockAWSS3Scaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		assert.Equal(t, testData.name, metricName)
+	}
+}
+
+// mockS3ListObjects serves the keys of a bucket by pages, following the continuation tokens
+type mockS3ListObjects struct {
+	keys  []string
+	err   error
+	calls int
+}
+
+func (m *mockS3ListObjects) ListObjectsV2(_ context.Context, input *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	start := 0
+	if input.ContinuationToken != nil {
+		start, _ = strconv.Atoi(*input.ContinuationToken)
+	}
+	end := start + int(aws.ToInt32(input.MaxKeys))
+	if end > len(m.keys) {
+		end = len(m.keys)
+	}
+
+	output := &s3.ListObjectsV2Output{KeyCount: aws.Int32(int32(end - start))}
+	for _, key := range m.keys[start:end] {
+		output.Contents = append(output.Contents, types.Object{Key: aws.String(key)})
+	}
+	if end < len(m.keys) {
+		output.IsTruncated = aws.Bool(true)
+		output.NextContinuationToken = aws.String(strconv.Itoa(end))
+	}
+	return output, nil
+}
+
+func generateS3Keys(count int) []string {
+	keys := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		keys = append(keys, fmt.Sprintf("jobs/%d.json", i))
+	}
+	return keys
+}
+
+func TestAWSS3GetMetricsAndActivity(t *testing.T) {
+	testCases := []struct {
+		name            string
+		keys            []string
+		listErr         error
+		pageSize        int32
+		maxObjectCount  int64
+		activationValue int64
+		expectedCount   float64
+		expectedCalls   int
+		expectedActive  bool
+		expectedError   bool
+	}{
+		{name: "empty bucket", pageSize: 1000, maxObjectCount: 10000, expectedCount: 0, expectedCalls: 1, expectedActive: false},
+		{name: "single page", keys: generateS3Keys(7), pageSize: 1000, maxObjectCount: 10000, expectedCount: 7, expectedCalls: 1, expectedActive: true},
+		{name: "several pages", keys: generateS3Keys(25), pageSize: 10, maxObjectCount: 10000, expectedCount: 25, expectedCalls: 3, expectedActive: true},
+		{name: "bounded by maxObjectCount", keys: generateS3Keys(25), pageSize: 10, maxObjectCount: 15, expectedCount: 15, expectedCalls: 2, expectedActive: true},
+		{name: "folder placeholders skipped", keys: []string{"jobs/", "jobs/a.json", "jobs/sub/", "jobs/sub/b.json"}, pageSize: 1000, maxObjectCount: 10000, expectedCount: 2, expectedCalls: 1, expectedActive: true},
+		{name: "below activation", keys: generateS3Keys(3), pageSize: 1000, maxObjectCount: 10000, activationValue: 3, expectedCount: 3, expectedCalls: 1, expectedActive: false},
+		{name: "list error", listErr: errors.New("access denied"), pageSize: 1000, maxObjectCount: 10000, expectedCalls: 1, expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			client := &mockS3ListObjects{keys: testCase.keys, err: testCase.listErr}
+			scaler := awsS3Scaler{
+				metadata: &awsS3Metadata{
+					Bucket:          "incoming",
+					Prefix:          "jobs/",
+					TargetValue:     5,
+					ActivationValue: testCase.activationValue,
+					MaxObjectCount:  testCase.maxObjectCount,
+					PageSize:        testCase.pageSize,
+				},
+				s3Client: client,
+				logger:   logr.Discard(),
+			}
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "s0-aws-s3-incoming-jobs-")
+			assert.Equal(t, testCase.expectedCalls, client.calls)
+			if testCase.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, metrics, 1)
+			assert.Equal(t, testCase.expectedCount, metrics[0].Value.AsApproximateFloat64())
+			assert.Equal(t, testCase.expectedActive, isActive)
+		})
+	}
+}
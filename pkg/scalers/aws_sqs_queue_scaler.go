@@ -29,17 +29,30 @@ type awsSqsQueueScaler struct {
 type awsSqsQueueMetadata struct {
 	TargetQueueLength           int64  `keda:"name=queueLength, order=triggerMetadata, default=5"`
 	ActivationTargetQueueLength int64  `keda:"name=activationQueueLength, order=triggerMetadata, default=0"`
-	QueueURL                    string `keda:"name=queueURL;queueURLFromEnv, order=triggerMetadata;resolvedEnv"`
-	queueName                   string
+	QueueURL                    string `keda:"name=queueURL;queueURLFromEnv, order=triggerMetadata;resolvedEnv"` // comma separated, the lengths of the queues are summed up
+	queueURLs                   []string
+	queueNames                  []string
+	SkipInaccessibleQueues      bool   `keda:"name=skipInaccessibleQueues, order=triggerMetadata, default=false"`
 	AwsRegion                   string `keda:"name=awsRegion, order=triggerMetadata;authParams"`
 	AwsEndpoint                 string `keda:"name=awsEndpoint, order=triggerMetadata, optional"`
 	awsAuthorization            awsutils.AuthorizationMetadata
 	triggerIndex                int
-	ScaleOnInFlight             bool `keda:"name=scaleOnInFlight, order=triggerMetadata, default=true"`
-	ScaleOnDelayed              bool `keda:"name=scaleOnDelayed, order=triggerMetadata, default=false"`
+	ScaleOnInFlight             bool    `keda:"name=scaleOnInFlight, order=triggerMetadata, default=true"`
+	InFlightWeight              float64 `keda:"name=inFlightWeight, order=triggerMetadata, default=1"` // weight of the in-flight messages in the queue length
+	ScaleOnDelayed              bool    `keda:"name=scaleOnDelayed, order=triggerMetadata, default=false"`
 	awsSqsQueueMetricNames      []types.QueueAttributeName
 }
 
+func (m *awsSqsQueueMetadata) Validate() error {
+	if m.InFlightWeight < 0 {
+		return fmt.Errorf("inFlightWeight must be greater than or equal to 0")
+	}
+	if m.InFlightWeight != 1 && !m.ScaleOnInFlight {
+		return fmt.Errorf("inFlightWeight can only be set when scaleOnInFlight is enabled")
+	}
+	return nil
+}
+
 // NewAwsSqsQueueScaler creates a new awsSqsQueueScaler
 func NewAwsSqsQueueScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
@@ -95,18 +108,24 @@ func parseAwsSqsQueueMetadata(config *scalersconfig.ScalerConfig) (*awsSqsQueueM
 		meta.awsSqsQueueMetricNames = append(meta.awsSqsQueueMetricNames, types.QueueAttributeNameApproximateNumberOfMessagesDelayed)
 	}
 
-	queueURL, err := url.ParseRequestURI(meta.QueueURL)
-	if err != nil {
-		// queueURL is not a valid URL, using it as queueName
-		meta.queueName = meta.QueueURL
-	} else {
-		queueURLPath := queueURL.Path
-		queueURLPathParts := strings.Split(queueURLPath, "/")
-		if len(queueURLPathParts) != 3 || len(queueURLPathParts[2]) == 0 {
-			return nil, fmt.Errorf("cannot get queueName from queueURL")
+	queueURLs := strings.Split(meta.QueueURL, ",")
+	seenQueueURLs := make(map[string]bool, len(queueURLs))
+	for _, queueURL := range queueURLs {
+		queueURL = strings.TrimSpace(queueURL)
+		if queueURL == "" && len(queueURLs) > 1 {
+			return nil, fmt.Errorf("empty queue in queueURL %q", meta.QueueURL)
 		}
+		if seenQueueURLs[queueURL] {
+			return nil, fmt.Errorf("queue %s is given multiple times", queueURL)
+		}
+		seenQueueURLs[queueURL] = true
 
-		meta.queueName = queueURLPathParts[2]
+		queueName, err := getSqsQueueName(queueURL)
+		if err != nil {
+			return nil, err
+		}
+		meta.queueURLs = append(meta.queueURLs, queueURL)
+		meta.queueNames = append(meta.queueNames, queueName)
 	}
 
 	auth, err := awsutils.GetAwsAuthorization(config.TriggerUniqueKey, meta.AwsRegion, config.PodIdentity, config.TriggerMetadata, config.AuthParams, config.ResolvedEnv)
@@ -121,6 +140,21 @@ func parseAwsSqsQueueMetadata(config *scalersconfig.ScalerConfig) (*awsSqsQueueM
 	return meta, nil
 }
 
+// getSqsQueueName returns the name of the queue, given either by its URL or by its name
+func getSqsQueueName(queueURL string) (string, error) {
+	parsedURL, err := url.ParseRequestURI(queueURL)
+	if err != nil {
+		// queueURL is not a valid URL, using it as queueName
+		return queueURL, nil
+	}
+
+	queueURLPathParts := strings.Split(parsedURL.Path, "/")
+	if len(queueURLPathParts) != 3 || len(queueURLPathParts[2]) == 0 {
+		return "", fmt.Errorf("cannot get queueName from queueURL %s", queueURL)
+	}
+	return queueURLPathParts[2], nil
+}
+
 func createSqsClient(ctx context.Context, metadata *awsSqsQueueMetadata) (*sqs.Client, error) {
 	cfg, err := awsutils.GetAwsConfig(ctx, metadata.awsAuthorization)
 	if err != nil {
@@ -141,7 +175,7 @@ func (s *awsSqsQueueScaler) Close(context.Context) error {
 func (s *awsSqsQueueScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("aws-sqs-%s", s.metadata.queueName))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("aws-sqs-%s", strings.Join(s.metadata.queueNames, "-")))),
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.TargetQueueLength),
 	}
@@ -158,28 +192,50 @@ func (s *awsSqsQueueScaler) GetMetricsAndActivity(ctx context.Context, metricNam
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
 
-	metric := GenerateMetricInMili(metricName, float64(queuelen))
+	metric := GenerateMetricInMili(metricName, queuelen)
 
-	return []external_metrics.ExternalMetricValue{metric}, queuelen > s.metadata.ActivationTargetQueueLength, nil
+	return []external_metrics.ExternalMetricValue{metric}, queuelen > float64(s.metadata.ActivationTargetQueueLength), nil
 }
 
-// Get SQS Queue Length
-func (s *awsSqsQueueScaler) getAwsSqsQueueLength(ctx context.Context) (int64, error) {
-	input := &sqs.GetQueueAttributesInput{
-		AttributeNames: s.metadata.awsSqsQueueMetricNames,
-		QueueUrl:       aws.String(s.metadata.QueueURL),
-	}
+// Get SQS Queue Length, summed up over all the queues
+func (s *awsSqsQueueScaler) getAwsSqsQueueLength(ctx context.Context) (float64, error) {
+	var queueLength float64
+	var lastErr error
+	accessibleQueues := 0
+	for _, queueURL := range s.metadata.queueURLs {
+		input := &sqs.GetQueueAttributesInput{
+			AttributeNames: s.metadata.awsSqsQueueMetricNames,
+			QueueUrl:       aws.String(queueURL),
+		}
 
-	output, err := s.sqsWrapperClient.GetQueueAttributes(ctx, input)
-	if err != nil {
-		return -1, err
+		output, err := s.sqsWrapperClient.GetQueueAttributes(ctx, input)
+		if err != nil {
+			if !s.metadata.SkipInaccessibleQueues {
+				return -1, err
+			}
+			s.logger.Error(err, "Skipping inaccessible queue", "queueURL", queueURL)
+			lastErr = err
+			continue
+		}
+		accessibleQueues++
+
+		length, err := s.processQueueLengthFromSqsQueueAttributesOutput(output)
+		if err != nil {
+			return -1, err
+		}
+		queueLength += length
 	}
 
-	return s.processQueueLengthFromSqsQueueAttributesOutput(output)
+	if accessibleQueues == 0 {
+		return -1, fmt.Errorf("none of the queues is accessible: %w", lastErr)
+	}
+	return queueLength, nil
 }
 
-func (s *awsSqsQueueScaler) processQueueLengthFromSqsQueueAttributesOutput(output *sqs.GetQueueAttributesOutput) (int64, error) {
-	var approximateNumberOfMessages int64
+// processQueueLengthFromSqsQueueAttributesOutput returns the queue length of the attributes, the in-flight messages
+// being weighted by inFlightWeight
+func (s *awsSqsQueueScaler) processQueueLengthFromSqsQueueAttributesOutput(output *sqs.GetQueueAttributesOutput) (float64, error) {
+	var approximateNumberOfMessages float64
 
 	for _, awsSqsQueueMetric := range s.metadata.awsSqsQueueMetricNames {
 		metricValueString, exists := output.Attributes[string(awsSqsQueueMetric)]
@@ -192,7 +248,11 @@ func (s *awsSqsQueueScaler) processQueueLengthFromSqsQueueAttributesOutput(outpu
 			return -1, err
 		}
 
-		approximateNumberOfMessages += metricValue
+		if awsSqsQueueMetric == types.QueueAttributeNameApproximateNumberOfMessagesNotVisible {
+			approximateNumberOfMessages += s.metadata.InFlightWeight * float64(metricValue)
+			continue
+		}
+		approximateNumberOfMessages += float64(metricValue)
 	}
 
 	return approximateNumberOfMessages, nil
@@ -21,6 +21,7 @@ const (
 	testAWSSQSSessionToken    = "none"
 
 	testAWSSQSProperQueueURL    = "https://sqs.eu-west-1.amazonaws.com/account_id/DeleteArtifactQ"
+	testAWSSQSRetryQueueURL     = "https://sqs.eu-west-1.amazonaws.com/account_id/DeleteArtifactRetryQ"
 	testAWSSQSImproperQueueURL1 = "https://sqs.eu-west-1.amazonaws.com/account_id"
 	testAWSSQSImproperQueueURL2 = "https://sqs.eu-west-1.amazonaws.com"
 	testAWSSimpleQueueURL       = "my-queue"
@@ -306,6 +307,93 @@ var testAWSSQSMetadata = []parseAWSSQSMetadataTestData{
 		},
 		false,
 		"empty QUEUE_URL env value"},
+	{map[string]string{
+		"queueURL":    testAWSSQSProperQueueURL + ", " + testAWSSQSRetryQueueURL,
+		"queueLength": "1",
+		"awsRegion":   "eu-west-1"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		false,
+		"multiple queues"},
+	{map[string]string{
+		"queueURL":               testAWSSQSProperQueueURL + "," + testAWSSimpleQueueURL,
+		"queueLength":            "1",
+		"awsRegion":              "eu-west-1",
+		"skipInaccessibleQueues": "true"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		false,
+		"multiple queues by URL and name skipping inaccessible queues"},
+	{map[string]string{
+		"queueURL":    testAWSSQSProperQueueURL + "," + testAWSSQSImproperQueueURL1,
+		"queueLength": "1",
+		"awsRegion":   "eu-west-1"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"multiple queues with an improperly formed one"},
+	{map[string]string{
+		"queueURL":    testAWSSQSProperQueueURL + ",",
+		"queueLength": "1",
+		"awsRegion":   "eu-west-1"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"multiple queues with an empty one"},
+	{map[string]string{
+		"queueURL":    testAWSSQSProperQueueURL + "," + testAWSSQSProperQueueURL,
+		"queueLength": "1",
+		"awsRegion":   "eu-west-1"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"same queue given twice"},
+	{map[string]string{
+		"queueURL":               testAWSSQSProperQueueURL,
+		"queueLength":            "1",
+		"awsRegion":              "eu-west-1",
+		"skipInaccessibleQueues": "maybe"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"invalid skipInaccessibleQueues"},
+	{map[string]string{
+		"queueURL":       testAWSSQSProperQueueURL,
+		"queueLength":    "1",
+		"awsRegion":      "eu-west-1",
+		"inFlightWeight": "0.5"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		false,
+		"not error with inFlightWeight"},
+	{map[string]string{
+		"queueURL":       testAWSSQSProperQueueURL,
+		"queueLength":    "1",
+		"awsRegion":      "eu-west-1",
+		"inFlightWeight": "-1"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"negative inFlightWeight"},
+	{map[string]string{
+		"queueURL":       testAWSSQSProperQueueURL,
+		"queueLength":    "1",
+		"awsRegion":      "eu-west-1",
+		"inFlightWeight": "a"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"invalid inFlightWeight"},
+	{map[string]string{
+		"queueURL":        testAWSSQSProperQueueURL,
+		"queueLength":     "1",
+		"awsRegion":       "eu-west-1",
+		"scaleOnInFlight": "false",
+		"inFlightWeight":  "0.5"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"inFlightWeight with scaleOnInFlight disabled"},
 }
 
 var awsSQSMetricIdentifiers = []awsSQSMetricIdentifier{
@@ -446,19 +534,112 @@ func TestAWSSQSScalerGetMetrics(t *testing.T) {
 	}
 }
 
+func TestAWSSQSScalerGetMetricsMultipleQueues(t *testing.T) {
+	queueLength := int64(testAWSSQSApproximateNumberOfMessagesVisible + testAWSSQSApproximateNumberOfMessagesNotVisible)
+
+	testCases := []struct {
+		name                   string
+		queueURL               string
+		skipInaccessibleQueues string
+		expected               int64
+		isError                bool
+	}{
+		{"sum of the queues", testAWSSQSProperQueueURL + "," + testAWSSQSRetryQueueURL, "false", 2 * queueLength, false},
+		{"inaccessible queue", testAWSSQSProperQueueURL + "," + testAWSSQSErrorQueueURL, "false", 0, true},
+		{"skipped inaccessible queue", testAWSSQSProperQueueURL + "," + testAWSSQSErrorQueueURL + "," + testAWSSQSRetryQueueURL, "true", 2 * queueLength, false},
+		{"all queues inaccessible", testAWSSQSErrorQueueURL, "true", 0, true},
+		{"bad data isn't skipped", testAWSSQSProperQueueURL + "," + testAWSSQSBadDataQueueURL, "true", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			meta, err := parseAwsSqsQueueMetadata(&scalersconfig.ScalerConfig{
+				TriggerMetadata: map[string]string{"queueURL": tc.queueURL, "awsRegion": "eu-west-1", "skipInaccessibleQueues": tc.skipInaccessibleQueues},
+				AuthParams:      testAWSSQSAuthentication,
+			})
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			scaler := awsSqsQueueScaler{"", meta, &mockSqs{}, logr.Discard()}
+
+			value, _, err := scaler.GetMetricsAndActivity(context.Background(), "MetricName")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, tc.expected, value[0].Value.Value())
+		})
+	}
+}
+
+func TestAWSSQSScalerGetMetricsWeightedInFlight(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		inFlightWeight        string
+		activationQueueLength string
+		expected              float64
+		isActive              bool
+	}{
+		// 200 visible + 0.5 * 100 in flight
+		{"weighted in flight", "0.5", "0", 250, true},
+		{"below activation", "0.5", "250", 250, false},
+		{"above activation", "0.5", "249", 250, true},
+		{"in flight ignored", "0", "0", 200, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			meta, err := parseAwsSqsQueueMetadata(&scalersconfig.ScalerConfig{
+				TriggerMetadata: map[string]string{"queueURL": testAWSSQSProperQueueURL, "awsRegion": "eu-west-1", "inFlightWeight": tc.inFlightWeight, "activationQueueLength": tc.activationQueueLength},
+				AuthParams:      testAWSSQSAuthentication,
+			})
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			scaler := awsSqsQueueScaler{"", meta, &mockSqs{}, logr.Discard()}
+
+			value, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "MetricName")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, value[0].Value.AsApproximateFloat64())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
+
+func TestAWSSQSGetMetricSpecForScalingMultipleQueues(t *testing.T) {
+	meta, err := parseAwsSqsQueueMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"queueURL": testAWSSQSProperQueueURL + "," + testAWSSimpleQueueURL, "awsRegion": "eu-west-1"},
+		AuthParams:      testAWSSQSAuthentication,
+	})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	scaler := awsSqsQueueScaler{"", meta, &mockSqs{}, logr.Discard()}
+
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, "s0-aws-sqs-DeleteArtifactQ-my-queue", metricSpec[0].External.Metric.Name)
+}
+
 func TestProcessQueueLengthFromSqsQueueAttributesOutput(t *testing.T) {
 	scalerCreationFunc := func() *awsSqsQueueScaler {
 		return &awsSqsQueueScaler{
 			metadata: &awsSqsQueueMetadata{
 				awsSqsQueueMetricNames: []types.QueueAttributeName{types.QueueAttributeNameApproximateNumberOfMessages, types.QueueAttributeNameApproximateNumberOfMessagesNotVisible, types.QueueAttributeNameApproximateNumberOfMessagesDelayed},
+				InFlightWeight:         1,
 			},
 		}
 	}
+	weightedScalerCreationFunc := func(inFlightWeight float64) *awsSqsQueueScaler {
+		s := scalerCreationFunc()
+		s.metadata.InFlightWeight = inFlightWeight
+		return s
+	}
 
 	tests := map[string]struct {
 		s           *awsSqsQueueScaler
 		attributes  *sqs.GetQueueAttributesOutput
-		expected    int64
+		expected    float64
 		errExpected bool
 	}{
 		"properly formed queue attributes": {
@@ -517,6 +698,42 @@ func TestProcessQueueLengthFromSqsQueueAttributesOutput(t *testing.T) {
 			expected:    2147483648,
 			errExpected: false,
 		},
+		"in-flight messages weighted": {
+			s: weightedScalerCreationFunc(0.25),
+			attributes: &sqs.GetQueueAttributesOutput{
+				Attributes: map[string]string{
+					"ApproximateNumberOfMessages":           "10",
+					"ApproximateNumberOfMessagesNotVisible": "40",
+					"ApproximateNumberOfMessagesDelayed":    "5",
+				},
+			},
+			expected:    25,
+			errExpected: false,
+		},
+		"in-flight messages ignored": {
+			s: weightedScalerCreationFunc(0),
+			attributes: &sqs.GetQueueAttributesOutput{
+				Attributes: map[string]string{
+					"ApproximateNumberOfMessages":           "10",
+					"ApproximateNumberOfMessagesNotVisible": "40",
+					"ApproximateNumberOfMessagesDelayed":    "0",
+				},
+			},
+			expected:    10,
+			errExpected: false,
+		},
+		"in-flight messages overweighted": {
+			s: weightedScalerCreationFunc(1.5),
+			attributes: &sqs.GetQueueAttributesOutput{
+				Attributes: map[string]string{
+					"ApproximateNumberOfMessages":           "10",
+					"ApproximateNumberOfMessagesNotVisible": "3",
+					"ApproximateNumberOfMessagesDelayed":    "0",
+				},
+			},
+			expected:    14.5,
+			errExpected: false,
+		},
 	}
 
 	for name, test := range tests {
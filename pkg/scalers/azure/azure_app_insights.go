@@ -31,6 +31,9 @@ type AppInsightsInfo struct {
 	AggregationTimespan     string
 	AggregationType         string
 	Filter                  string
+	Query                   string
+	QueryTimespan           string
+	AppKey                  string
 	ClientID                string
 	ClientPassword          string
 	AppInsightsResourceURL  string
@@ -41,6 +44,22 @@ type ApplicationInsightsMetric struct {
 	Value map[string]interface{}
 }
 
+// ApplicationInsightsQueryResult is the response of the query API of App Insights
+type ApplicationInsightsQueryResult struct {
+	Tables []ApplicationInsightsQueryTable `json:"tables"`
+}
+
+type ApplicationInsightsQueryTable struct {
+	Name    string                           `json:"name"`
+	Columns []ApplicationInsightsQueryColumn `json:"columns"`
+	Rows    [][]interface{}                  `json:"rows"`
+}
+
+type ApplicationInsightsQueryColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
 var azureAppInsightsLog = logf.Log.WithName("azure_app_insights_scaler")
 
 func toISO8601(time string) (string, error) {
@@ -72,6 +91,20 @@ func getAuthConfig(ctx context.Context, info AppInsightsInfo, podIdentity kedav1
 	return nil
 }
 
+// getAuthorization returns the decorator authenticating the requests, with the API key of the application when given
+func getAuthorization(ctx context.Context, info AppInsightsInfo, podIdentity kedav1alpha1.AuthPodIdentity) (autorest.PrepareDecorator, error) {
+	if info.AppKey != "" {
+		return autorest.WithHeader("x-api-key", info.AppKey), nil
+	}
+
+	config := getAuthConfig(ctx, info, podIdentity)
+	authorizer, err := config.Authorizer()
+	if err != nil {
+		return nil, err
+	}
+	return authorizer.WithAuthorization(), nil
+}
+
 func extractAppInsightValue(info AppInsightsInfo, metric ApplicationInsightsMetric) (float64, error) {
 	if _, ok := metric.Value[info.MetricID]; !ok {
 		return -1, fmt.Errorf("metric named %s not found in app insights response", info.MetricID)
@@ -92,6 +125,53 @@ func extractAppInsightValue(info AppInsightsInfo, metric ApplicationInsightsMetr
 	return floatVal, nil
 }
 
+// extractAppInsightsQueryValue returns the first numeric column of the single row the query must return
+func extractAppInsightsQueryValue(info AppInsightsInfo, result ApplicationInsightsQueryResult) (float64, error) {
+	switch {
+	case len(result.Tables) == 0 || len(result.Tables[0].Rows) == 0:
+		return -1, fmt.Errorf("query %q returned no results", info.Query)
+	case len(result.Tables) > 1:
+		return -1, fmt.Errorf("query %q returned %d tables, expected 1", info.Query, len(result.Tables))
+	case len(result.Tables[0].Rows) > 1:
+		return -1, fmt.Errorf("query %q returned %d rows, expected 1", info.Query, len(result.Tables[0].Rows))
+	}
+
+	table := result.Tables[0]
+	for i, column := range table.Columns {
+		if i >= len(table.Rows[0]) {
+			break
+		}
+		switch column.Type {
+		case "int", "long", "real", "decimal":
+		default:
+			continue
+		}
+
+		var floatVal float64
+		switch val := table.Rows[0][i].(type) {
+		case nil:
+			return -1, fmt.Errorf("column %s of query %q was nil", column.Name, info.Query)
+		case float64:
+			floatVal = val
+		case string:
+			// decimals are serialized as strings to keep their precision
+			parsed, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return -1, fmt.Errorf("error parsing column %s of query %q: %w", column.Name, info.Query, err)
+			}
+			floatVal = parsed
+		default:
+			return -1, fmt.Errorf("column %s of query %q has an unexpected value %v", column.Name, info.Query, val)
+		}
+
+		azureAppInsightsLog.V(2).Info("value extracted from query request", "column", column.Name, "query value", floatVal)
+
+		return floatVal, nil
+	}
+
+	return -1, fmt.Errorf("query %q returned no numeric column", info.Query)
+}
+
 func queryParamsForAppInsightsRequest(info AppInsightsInfo) (map[string]interface{}, error) {
 	timespan, err := toISO8601(info.AggregationTimespan)
 	if err != nil {
@@ -111,8 +191,7 @@ func queryParamsForAppInsightsRequest(info AppInsightsInfo) (map[string]interfac
 
 // GetAzureAppInsightsMetricValue returns the value of an Azure App Insights metric, rounded to the nearest int
 func GetAzureAppInsightsMetricValue(ctx context.Context, info AppInsightsInfo, podIdentity kedav1alpha1.AuthPodIdentity, ignoreNullValues bool) (float64, error) {
-	config := getAuthConfig(ctx, info, podIdentity)
-	authorizer, err := config.Authorizer()
+	authorization, err := getAuthorization(ctx, info, podIdentity)
 	if err != nil {
 		return -1, err
 	}
@@ -129,7 +208,7 @@ func GetAzureAppInsightsMetricValue(ctx context.Context, info AppInsightsInfo, p
 		autorest.WithPath("metrics"),
 		autorest.WithPath(info.MetricID),
 		autorest.WithQueryParameters(queryParams),
-		authorizer.WithAuthorization())
+		authorization)
 	if err != nil {
 		return -1, err
 	}
@@ -155,3 +234,64 @@ func GetAzureAppInsightsMetricValue(ctx context.Context, info AppInsightsInfo, p
 	}
 	return val, err
 }
+
+func bodyForAppInsightsQueryRequest(info AppInsightsInfo) (map[string]interface{}, error) {
+	body := map[string]interface{}{
+		"query": info.Query,
+	}
+	if info.QueryTimespan != "" {
+		timespan, err := toISO8601(info.QueryTimespan)
+		if err != nil {
+			return nil, err
+		}
+		body["timespan"] = timespan
+	}
+
+	return body, nil
+}
+
+// GetAzureAppInsightsQueryValue returns the value of the first numeric column of the single row returned by a KQL query
+func GetAzureAppInsightsQueryValue(ctx context.Context, info AppInsightsInfo, podIdentity kedav1alpha1.AuthPodIdentity, ignoreNullValues bool) (float64, error) {
+	authorization, err := getAuthorization(ctx, info, podIdentity)
+	if err != nil {
+		return -1, err
+	}
+
+	body, err := bodyForAppInsightsQueryRequest(info)
+	if err != nil {
+		return -1, err
+	}
+
+	req, err := autorest.Prepare(&http.Request{},
+		autorest.AsPost(),
+		autorest.WithBaseURL(info.AppInsightsResourceURL),
+		autorest.WithPath("v1/apps"),
+		autorest.WithPath(info.ApplicationInsightsID),
+		autorest.WithPath("query"),
+		autorest.WithJSON(body),
+		authorization)
+	if err != nil {
+		return -1, err
+	}
+
+	resp, err := autorest.Send(req,
+		autorest.DoErrorUnlessStatusCode(http.StatusOK),
+		autorest.DoCloseIfError())
+	if err != nil {
+		return -1, err
+	}
+
+	result := &ApplicationInsightsQueryResult{}
+	err = autorest.Respond(resp,
+		autorest.ByUnmarshallingJSON(result),
+		autorest.ByClosing())
+	if err != nil {
+		return -1, err
+	}
+
+	val, err := extractAppInsightsQueryValue(info, *result)
+	if err != nil && ignoreNullValues {
+		return 0.0, nil
+	}
+	return val, err
+}
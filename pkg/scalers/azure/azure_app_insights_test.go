@@ -2,9 +2,13 @@ package azure
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/stretchr/testify/assert"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
@@ -184,3 +188,73 @@ func TestQueryParamsForAppInsightsRequest(t *testing.T) {
 		}
 	}
 }
+
+type testExtractAzAppInsightsQueryTestData struct {
+	testName      string
+	isError       bool
+	expectedValue float64
+	response      string
+}
+
+var testExtractAzAppInsightsQueryData = []testExtractAzAppInsightsQueryTestData{
+	{"count", false, 42, `{"tables":[{"name":"PrimaryResult","columns":[{"name":"count_","type":"long"}],"rows":[[42]]}]}`},
+	{"average after a string column", false, 12.5, `{"tables":[{"name":"PrimaryResult","columns":[{"name":"cloud_RoleName","type":"string"},{"name":"avg_duration","type":"real"}],"rows":[["api",12.5]]}]}`},
+	{"decimal", false, 3.25, `{"tables":[{"name":"PrimaryResult","columns":[{"name":"value","type":"decimal"}],"rows":[["3.25"]]}]}`},
+	{"no tables", true, -1, `{"tables":[]}`},
+	{"no rows", true, -1, `{"tables":[{"name":"PrimaryResult","columns":[{"name":"count_","type":"long"}],"rows":[]}]}`},
+	{"too many rows", true, -1, `{"tables":[{"name":"PrimaryResult","columns":[{"name":"count_","type":"long"}],"rows":[[1],[2]]}]}`},
+	{"too many tables", true, -1, `{"tables":[{"name":"PrimaryResult","columns":[{"name":"count_","type":"long"}],"rows":[[1]]},{"name":"Other","columns":[],"rows":[]}]}`},
+	{"no numeric column", true, -1, `{"tables":[{"name":"PrimaryResult","columns":[{"name":"cloud_RoleName","type":"string"}],"rows":[["api"]]}]}`},
+	{"null value", true, -1, `{"tables":[{"name":"PrimaryResult","columns":[{"name":"avg_duration","type":"real"}],"rows":[[null]]}]}`},
+}
+
+func TestAzExtractAppInsightsQueryValue(t *testing.T) {
+	for _, testData := range testExtractAzAppInsightsQueryData {
+		result := ApplicationInsightsQueryResult{}
+		if err := json.Unmarshal([]byte(testData.response), &result); err != nil {
+			t.Fatalf("Test: %v; error unmarshalling the response: %v", testData.testName, err)
+		}
+		value, err := extractAppInsightsQueryValue(AppInsightsInfo{Query: "query"}, result)
+		if testData.isError {
+			if err == nil {
+				t.Errorf("Test: %v; Expected error but got success. testData: %v", testData.testName, testData)
+			}
+		} else {
+			if err == nil {
+				if testData.expectedValue != value {
+					t.Errorf("Test: %v; Expected value %v but got %v testData: %v", testData.testName, testData.expectedValue, value, testData)
+				}
+			} else {
+				t.Errorf("Test: %v; Expected success but got error: %v", testData.testName, err)
+			}
+		}
+	}
+}
+
+func TestGetAzureAppInsightsQueryValueWithAppKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/apps/1234/query", r.URL.Path)
+		assert.Equal(t, "key", r.Header.Get("x-api-key"))
+
+		body := map[string]string{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "requests | summarize count()", body["query"])
+		assert.Equal(t, "PT00H05M", body["timespan"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tables":[{"name":"PrimaryResult","columns":[{"name":"count_","type":"long"}],"rows":[[7]]}]}`))
+	}))
+	defer server.Close()
+
+	info := AppInsightsInfo{
+		ApplicationInsightsID:  "1234",
+		Query:                  "requests | summarize count()",
+		QueryTimespan:          "00:05",
+		AppKey:                 "key",
+		AppInsightsResourceURL: server.URL,
+	}
+	value, err := GetAzureAppInsightsQueryValue(context.TODO(), info, kedav1alpha1.AuthPodIdentity{}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(7), value)
+}
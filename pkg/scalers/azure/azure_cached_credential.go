@@ -0,0 +1,107 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/go-logr/logr"
+
+	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const (
+	// tokenRefreshBefore is how long before its expiry a cached token is refreshed
+	tokenRefreshBefore = 5 * time.Minute
+)
+
+var (
+	sharedCredentials     = map[string]*CachedTokenCredential{}
+	sharedCredentialsLock sync.Mutex
+)
+
+// GetSharedChainedCredential returns the chained credential of the pod identity, shared by all the scalers
+// using the same identity so they reuse the tokens instead of each requesting its own
+func GetSharedChainedCredential(logger logr.Logger, podIdentity v1alpha1.AuthPodIdentity) (azcore.TokenCredential, error) {
+	key := fmt.Sprintf("%s/%s/%s", podIdentity.Provider, podIdentity.GetIdentityID(), podIdentity.GetIdentityTenantID())
+
+	sharedCredentialsLock.Lock()
+	defer sharedCredentialsLock.Unlock()
+
+	if cred, ok := sharedCredentials[key]; ok {
+		return cred, nil
+	}
+
+	chainedCred, err := NewChainedCredential(logger, podIdentity)
+	if err != nil {
+		return nil, err
+	}
+	cred := NewCachedTokenCredential(chainedCred)
+	sharedCredentials[key] = cred
+	return cred, nil
+}
+
+// CachedTokenCredential is a azcore.TokenCredential caching the tokens of the wrapped credential per scope,
+// refreshing them a while before they expire
+type CachedTokenCredential struct {
+	credential azcore.TokenCredential
+	now        func() time.Time
+
+	lock   sync.Mutex
+	tokens map[string]*cachedToken
+}
+
+type cachedToken struct {
+	// lock serializes the requests of the token, so concurrent callers wait for the token being requested
+	lock  sync.Mutex
+	token azcore.AccessToken
+}
+
+// NewCachedTokenCredential creates a CachedTokenCredential wrapping the credential
+func NewCachedTokenCredential(credential azcore.TokenCredential) *CachedTokenCredential {
+	return &CachedTokenCredential{
+		credential: credential,
+		now:        time.Now,
+		tokens:     map[string]*cachedToken{},
+	}
+}
+
+// GetToken returns the cached token for the scopes, requesting a new one when it's about to expire
+func (c *CachedTokenCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	// a claims challenge means the cached token has been rejected
+	if options.Claims != "" {
+		return c.credential.GetToken(ctx, options)
+	}
+
+	key := fmt.Sprintf("%s|%s|%t", strings.Join(options.Scopes, " "), options.TenantID, options.EnableCAE)
+	c.lock.Lock()
+	entry, ok := c.tokens[key]
+	if !ok {
+		entry = &cachedToken{}
+		c.tokens[key] = entry
+	}
+	c.lock.Unlock()
+
+	entry.lock.Lock()
+	defer entry.lock.Unlock()
+
+	now := c.now()
+	if entry.token.Token != "" && now.Add(tokenRefreshBefore).Before(entry.token.ExpiresOn) {
+		return entry.token, nil
+	}
+
+	token, err := c.credential.GetToken(ctx, options)
+	if err != nil {
+		// the token being refreshed is returned as long as it's still valid
+		if entry.token.Token != "" && now.Before(entry.token.ExpiresOn) {
+			return entry.token, nil
+		}
+		return azcore.AccessToken{}, err
+	}
+	entry.token = token
+	return token, nil
+}
@@ -0,0 +1,139 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTokenCredential struct {
+	lock     sync.Mutex
+	requests int
+	now      func() time.Time
+	err      error
+}
+
+func (f *fakeTokenCredential) GetToken(_ context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.requests++
+	if f.err != nil {
+		return azcore.AccessToken{}, f.err
+	}
+	return azcore.AccessToken{
+		Token:     fmt.Sprintf("%s-%d", options.Scopes[0], f.requests),
+		ExpiresOn: f.now().Add(time.Hour),
+	}, nil
+}
+
+func newTestCachedTokenCredential() (*CachedTokenCredential, *fakeTokenCredential, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := &fakeTokenCredential{now: func() time.Time { return now }}
+	cred := NewCachedTokenCredential(fake)
+	cred.now = func() time.Time { return now }
+	fake.now = cred.now
+	return cred, fake, &now
+}
+
+func TestCachedTokenCredentialReusesToken(t *testing.T) {
+	cred, fake, now := newTestCachedTokenCredential()
+	options := policy.TokenRequestOptions{Scopes: []string{"https://servicebus.azure.net/.default"}}
+
+	first, err := cred.GetToken(context.Background(), options)
+	assert.NoError(t, err)
+
+	*now = now.Add(50 * time.Minute)
+	second, err := cred.GetToken(context.Background(), options)
+	assert.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, fake.requests)
+}
+
+func TestCachedTokenCredentialCachesPerScope(t *testing.T) {
+	cred, fake, _ := newTestCachedTokenCredential()
+
+	serviceBus, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: []string{"https://servicebus.azure.net/.default"}})
+	assert.NoError(t, err)
+	storage, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: []string{"https://storage.azure.com/.default"}})
+	assert.NoError(t, err)
+	_, err = cred.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: []string{"https://storage.azure.com/.default"}})
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, serviceBus.Token, storage.Token)
+	assert.Equal(t, 2, fake.requests)
+}
+
+func TestCachedTokenCredentialRefreshesBeforeExpiry(t *testing.T) {
+	cred, fake, now := newTestCachedTokenCredential()
+	options := policy.TokenRequestOptions{Scopes: []string{"https://servicebus.azure.net/.default"}}
+
+	first, err := cred.GetToken(context.Background(), options)
+	assert.NoError(t, err)
+
+	// the token is still valid for 4 minutes, but within the refresh window
+	*now = now.Add(56 * time.Minute)
+	second, err := cred.GetToken(context.Background(), options)
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, first.Token, second.Token)
+	assert.Equal(t, 2, fake.requests)
+}
+
+func TestCachedTokenCredentialKeepsValidTokenOnRefreshError(t *testing.T) {
+	cred, fake, now := newTestCachedTokenCredential()
+	options := policy.TokenRequestOptions{Scopes: []string{"https://servicebus.azure.net/.default"}}
+
+	first, err := cred.GetToken(context.Background(), options)
+	assert.NoError(t, err)
+
+	fake.err = errors.New("IMDS unavailable")
+	*now = now.Add(56 * time.Minute)
+	second, err := cred.GetToken(context.Background(), options)
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	// once expired, the error is returned
+	*now = now.Add(5 * time.Minute)
+	_, err = cred.GetToken(context.Background(), options)
+	assert.Error(t, err)
+}
+
+func TestCachedTokenCredentialBypassesCacheOnClaimsChallenge(t *testing.T) {
+	cred, fake, _ := newTestCachedTokenCredential()
+	options := policy.TokenRequestOptions{Scopes: []string{"https://servicebus.azure.net/.default"}}
+
+	_, err := cred.GetToken(context.Background(), options)
+	assert.NoError(t, err)
+
+	options.Claims = `{"access_token":{"nbf":{"essential":true}}}`
+	_, err = cred.GetToken(context.Background(), options)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, fake.requests)
+}
+
+func TestCachedTokenCredentialConcurrentRequests(t *testing.T) {
+	cred, fake, _ := newTestCachedTokenCredential()
+	options := policy.TokenRequestOptions{Scopes: []string{"https://servicebus.azure.net/.default"}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := cred.GetToken(context.Background(), options)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, fake.requests)
+}
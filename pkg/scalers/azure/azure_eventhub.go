@@ -39,7 +39,7 @@ func GetEventHubClient(info EventHubInfo, logger logr.Logger) (*azeventhubs.Prod
 		}
 		return hub, nil
 	case kedav1alpha1.PodIdentityProviderAzureWorkload:
-		creds, chainedErr := NewChainedCredential(logger, info.PodIdentity)
+		creds, chainedErr := GetSharedChainedCredential(logger, info.PodIdentity)
 		if chainedErr != nil {
 			return nil, chainedErr
 		}
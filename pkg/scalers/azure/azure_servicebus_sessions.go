@@ -0,0 +1,324 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	amqpAuth "github.com/Azure/azure-amqp-common-go/v4/auth"
+	"github.com/Azure/azure-amqp-common-go/v4/cbs"
+	"github.com/Azure/azure-amqp-common-go/v4/rpc"
+	"github.com/Azure/azure-amqp-common-go/v4/sas"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/go-amqp"
+)
+
+const (
+	serviceBusSessionsPageSize = 100
+	// serviceBusClaimRefreshInterval is how often the claim of the connection is renewed, well within the
+	// lifetime of the SAS tokens and of the Microsoft Entra ID tokens
+	serviceBusClaimRefreshInterval = 30 * time.Minute
+)
+
+// serviceBusActiveSessionsTime is the last-updated-time making get-message-sessions return the sessions
+// having messages instead of the ones whose state has been updated since a given time
+var serviceBusActiveSessionsTime = time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+
+// ServiceBusSessionInfo is the runtime info of a session of a session-enabled queue or subscription
+type ServiceBusSessionInfo struct {
+	SessionID string
+	// MessageCount is the count of messages of the session, capped to the limit of the request
+	MessageCount int64
+}
+
+// serviceBusRPCLink is the *rpc.Link to the management node of the entity
+type serviceBusRPCLink interface {
+	RPC(ctx context.Context, msg *amqp.Message) (*rpc.Response, error)
+	Close(ctx context.Context) error
+}
+
+// ServiceBusSessionClient reads the sessions of a queue or subscription through the AMQP management
+// operations of the entity, which the Service Bus SDK doesn't expose. Unlike receiving from a session,
+// these operations don't lock the sessions, so they don't get in the way of the consumers.
+// The connection is kept open between the calls and is only dialed again after an error.
+type ServiceBusSessionClient struct {
+	fullyQualifiedNamespace string
+	entityPath              string
+	tokenProvider           amqpAuth.TokenProvider
+
+	lock              sync.Mutex
+	conn              *amqp.Conn
+	management        serviceBusRPCLink
+	claimNegotiatedAt time.Time
+}
+
+// NewServiceBusSessionClient creates a ServiceBusSessionClient authenticating with the credential
+func NewServiceBusSessionClient(fullyQualifiedNamespace, entityPath string, credential azcore.TokenCredential) *ServiceBusSessionClient {
+	return &ServiceBusSessionClient{
+		fullyQualifiedNamespace: fullyQualifiedNamespace,
+		entityPath:              entityPath,
+		tokenProvider:           &serviceBusCredentialTokenProvider{credential: credential},
+	}
+}
+
+// NewServiceBusSessionClientFromConnectionString creates a ServiceBusSessionClient authenticating with
+// the shared access key or signature of the connection string
+func NewServiceBusSessionClientFromConnectionString(connectionString, entityPath string) (*ServiceBusSessionClient, error) {
+	var endpoint, keyName, key, signature string
+	for _, part := range strings.Split(connectionString, ";") {
+		name, value, _ := strings.Cut(part, "=")
+		switch strings.TrimSpace(name) {
+		case "Endpoint":
+			endpoint = value
+		case "SharedAccessKeyName":
+			keyName = value
+		case "SharedAccessKey":
+			key = value
+		case "SharedAccessSignature":
+			signature = value
+		}
+	}
+
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil || endpointURL.Host == "" {
+		return nil, errors.New("can't parse service bus connection string, missing or invalid Endpoint")
+	}
+
+	var tokenProvider amqpAuth.TokenProvider
+	switch {
+	case signature != "":
+		tokenProvider = serviceBusSignatureTokenProvider(signature)
+	case keyName != "" && key != "":
+		if tokenProvider, err = sas.NewTokenProvider(sas.TokenProviderWithKey(keyName, key)); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("can't parse service bus connection string, missing SharedAccessKeyName and SharedAccessKey or SharedAccessSignature")
+	}
+
+	return &ServiceBusSessionClient{
+		fullyQualifiedNamespace: endpointURL.Host,
+		entityPath:              entityPath,
+		tokenProvider:           tokenProvider,
+	}, nil
+}
+
+// GetSessionRuntimeInfo returns the given session or, when sessionID is empty, the sessions having messages,
+// counting the messages of each session up to messageLimit when countMessages is set
+func (c *ServiceBusSessionClient) GetSessionRuntimeInfo(ctx context.Context, sessionID string, countMessages bool, messageLimit int64) ([]ServiceBusSessionInfo, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	management, err := c.getManagementLink(ctx)
+	if err != nil {
+		c.closeConnection(ctx)
+		return nil, err
+	}
+
+	sessions, err := getServiceBusSessionRuntimeInfo(ctx, management, sessionID, countMessages, messageLimit)
+	if err != nil {
+		// the connection may be broken, it's dialed again on the next call
+		c.closeConnection(ctx)
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// Close closes the connection to the namespace
+func (c *ServiceBusSessionClient) Close(ctx context.Context) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.closeConnection(ctx)
+}
+
+// getManagementLink returns the link to the management node of the entity, dialing the namespace
+// and renewing the claim of the connection when needed
+func (c *ServiceBusSessionClient) getManagementLink(ctx context.Context) (serviceBusRPCLink, error) {
+	if c.conn == nil {
+		conn, err := amqp.Dial(ctx, fmt.Sprintf("amqps://%s", c.fullyQualifiedNamespace), &amqp.ConnOptions{
+			SASLType: amqp.SASLTypeAnonymous(),
+			HostName: c.fullyQualifiedNamespace,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to service bus: %w", err)
+		}
+		c.conn = conn
+	}
+
+	if time.Since(c.claimNegotiatedAt) > serviceBusClaimRefreshInterval {
+		audience := fmt.Sprintf("amqps://%s/%s", c.fullyQualifiedNamespace, c.entityPath)
+		if err := cbs.NegotiateClaim(ctx, audience, c.conn, c.tokenProvider); err != nil {
+			return nil, fmt.Errorf("error authorizing the service bus connection: %w", err)
+		}
+		c.claimNegotiatedAt = time.Now()
+	}
+
+	if c.management == nil {
+		management, err := rpc.NewLink(ctx, c.conn, fmt.Sprintf("%s/$management", c.entityPath))
+		if err != nil {
+			return nil, fmt.Errorf("error opening the management link of %s: %w", c.entityPath, err)
+		}
+		c.management = management
+	}
+	return c.management, nil
+}
+
+func (c *ServiceBusSessionClient) closeConnection(ctx context.Context) {
+	if c.management != nil {
+		_ = c.management.Close(ctx)
+		c.management = nil
+	}
+	if c.conn != nil {
+		_ = c.conn.Close()
+		c.conn = nil
+	}
+	c.claimNegotiatedAt = time.Time{}
+}
+
+func getServiceBusSessionRuntimeInfo(ctx context.Context, management serviceBusRPCLink, sessionID string, countMessages bool, messageLimit int64) ([]ServiceBusSessionInfo, error) {
+	var sessionIDs []string
+	if sessionID != "" {
+		sessionIDs = []string{sessionID}
+	} else {
+		var err error
+		if sessionIDs, err = getServiceBusActiveSessions(ctx, management); err != nil {
+			return nil, err
+		}
+	}
+
+	sessions := make([]ServiceBusSessionInfo, 0, len(sessionIDs))
+	for _, id := range sessionIDs {
+		info := ServiceBusSessionInfo{SessionID: id}
+		if countMessages {
+			var err error
+			if info.MessageCount, err = countServiceBusSessionMessages(ctx, management, id, messageLimit); err != nil {
+				return nil, err
+			}
+		}
+		sessions = append(sessions, info)
+	}
+	return sessions, nil
+}
+
+func getServiceBusActiveSessions(ctx context.Context, management serviceBusRPCLink) ([]string, error) {
+	var sessionIDs []string
+	for skip := int32(0); ; skip += serviceBusSessionsPageSize {
+		response, err := serviceBusManagementRequest(ctx, management, &amqp.Message{
+			ApplicationProperties: map[string]any{"operation": "com.microsoft:get-message-sessions"},
+			Value: map[string]any{
+				"last-updated-time": serviceBusActiveSessionsTime,
+				"skip":              skip,
+				"top":               int32(serviceBusSessionsPageSize),
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error listing sessions: %w", err)
+		}
+		page, err := parseServiceBusSessionsResponse(response)
+		if err != nil {
+			return nil, err
+		}
+		sessionIDs = append(sessionIDs, page...)
+		if len(page) < serviceBusSessionsPageSize {
+			return sessionIDs, nil
+		}
+	}
+}
+
+// countServiceBusSessionMessages peeks the messages of the session with a single request of at most limit
+// messages, so the count saturates at limit for a backlogged session
+func countServiceBusSessionMessages(ctx context.Context, management serviceBusRPCLink, sessionID string, limit int64) (int64, error) {
+	response, err := serviceBusManagementRequest(ctx, management, &amqp.Message{
+		ApplicationProperties: map[string]any{"operation": "com.microsoft:peek-message"},
+		Value: map[string]any{
+			"from-sequence-number": int64(0),
+			"message-count":        int32(limit),
+			"session-id":           sessionID,
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error peeking the messages of session %s: %w", sessionID, err)
+	}
+	count, err := parseServiceBusPeekResponse(response)
+	if err != nil {
+		return 0, err
+	}
+	return min(count, limit), nil
+}
+
+// serviceBusManagementRequest sends the request to the management node, failing on an unsuccessful status
+func serviceBusManagementRequest(ctx context.Context, management serviceBusRPCLink, message *amqp.Message) (*rpc.Response, error) {
+	response, err := management.RPC(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+	if response.Code < 200 || response.Code >= 300 {
+		return nil, fmt.Errorf("status code %d: %s", response.Code, response.Description)
+	}
+	return response, nil
+}
+
+// parseServiceBusSessionsResponse returns the session ids of a get-message-sessions response,
+// the service answering with no content once there are no more sessions
+func parseServiceBusSessionsResponse(response *rpc.Response) ([]string, error) {
+	if response.Code == 204 {
+		return nil, nil
+	}
+	body, ok := response.Message.Value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected get-message-sessions response %T", response.Message.Value)
+	}
+	ids, ok := body["sessions-ids"].([]string)
+	if !ok {
+		if body["sessions-ids"] == nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unexpected sessions-ids %T in get-message-sessions response", body["sessions-ids"])
+	}
+	return ids, nil
+}
+
+// parseServiceBusPeekResponse returns the count of messages of a peek-message response
+func parseServiceBusPeekResponse(response *rpc.Response) (int64, error) {
+	if response.Code == 204 {
+		return 0, nil
+	}
+	body, ok := response.Message.Value.(map[string]any)
+	if !ok {
+		return 0, fmt.Errorf("unexpected peek-message response %T", response.Message.Value)
+	}
+	entries, ok := body["messages"].([]any)
+	if !ok {
+		return 0, fmt.Errorf("unexpected messages %T in peek-message response", body["messages"])
+	}
+	return int64(len(entries)), nil
+}
+
+// serviceBusSignatureTokenProvider provides the shared access signature of a connection string as is
+type serviceBusSignatureTokenProvider string
+
+func (p serviceBusSignatureTokenProvider) GetToken(_ string) (*amqpAuth.Token, error) {
+	return amqpAuth.NewToken(amqpAuth.CBSTokenTypeSAS, string(p), "0"), nil
+}
+
+// serviceBusCredentialTokenProvider provides the Microsoft Entra ID tokens of a credential
+type serviceBusCredentialTokenProvider struct {
+	credential azcore.TokenCredential
+}
+
+func (p *serviceBusCredentialTokenProvider) GetToken(_ string) (*amqpAuth.Token, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	token, err := p.credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{"https://servicebus.azure.net//.default"}})
+	if err != nil {
+		return nil, err
+	}
+	return amqpAuth.NewToken(amqpAuth.CBSTokenTypeJWT, token.Token, strconv.FormatInt(token.ExpiresOn.Unix(), 10)), nil
+}
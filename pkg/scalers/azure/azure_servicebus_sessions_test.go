@@ -0,0 +1,121 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	amqpAuth "github.com/Azure/azure-amqp-common-go/v4/auth"
+	"github.com/Azure/azure-amqp-common-go/v4/rpc"
+	"github.com/Azure/go-amqp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewServiceBusSessionClientFromConnectionString(t *testing.T) {
+	testCases := []struct {
+		name       string
+		connection string
+		isError    bool
+	}{
+		{"shared access key", "Endpoint=sb://ns.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=a2V5", false},
+		{"shared access signature", "Endpoint=sb://ns.servicebus.windows.net/;SharedAccessSignature=SharedAccessSignature sr=x&sig=y&se=1&skn=z", false},
+		{"no endpoint", "SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=a2V5", true},
+		{"no key", "Endpoint=sb://ns.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey", true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := NewServiceBusSessionClientFromConnectionString(tc.connection, "orders")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "ns.servicebus.windows.net", client.fullyQualifiedNamespace)
+		})
+	}
+}
+
+func TestServiceBusSignatureTokenProvider(t *testing.T) {
+	client, err := NewServiceBusSessionClientFromConnectionString("Endpoint=sb://ns.servicebus.windows.net/;SharedAccessSignature=SharedAccessSignature sr=x&sig=y&se=1&skn=z", "orders")
+	assert.NoError(t, err)
+
+	// a signature of the connection string is used as is
+	token, err := client.tokenProvider.GetToken("amqps://ns.servicebus.windows.net/orders")
+	assert.NoError(t, err)
+	assert.Equal(t, amqpAuth.CBSTokenTypeSAS, token.TokenType)
+	assert.Equal(t, "SharedAccessSignature sr=x&sig=y&se=1&skn=z", token.Token)
+}
+
+func TestParseServiceBusSessionsResponse(t *testing.T) {
+	ids, err := parseServiceBusSessionsResponse(&rpc.Response{Code: 200, Message: &amqp.Message{
+		Value: map[string]any{"skip": int32(0), "sessions-ids": []string{"session-1", "session-2"}},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"session-1", "session-2"}, ids)
+
+	ids, err = parseServiceBusSessionsResponse(&rpc.Response{Code: 204, Message: &amqp.Message{}})
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+// fakeServiceBusManagement answers the management requests with the sessions and their message counts
+type fakeServiceBusManagement struct {
+	messageCounts map[string]int
+	requests      []*amqp.Message
+}
+
+func (f *fakeServiceBusManagement) RPC(_ context.Context, msg *amqp.Message) (*rpc.Response, error) {
+	f.requests = append(f.requests, msg)
+	body, _ := msg.Value.(map[string]any)
+	switch msg.ApplicationProperties["operation"] {
+	case "com.microsoft:get-message-sessions":
+		var ids []string
+		for id := range f.messageCounts {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		skip := int(body["skip"].(int32))
+		if skip >= len(ids) {
+			return &rpc.Response{Code: 204, Message: &amqp.Message{}}, nil
+		}
+		ids = ids[skip:min(len(ids), skip+int(body["top"].(int32)))]
+		return &rpc.Response{Code: 200, Message: &amqp.Message{Value: map[string]any{"sessions-ids": ids}}}, nil
+	case "com.microsoft:peek-message":
+		count := min(f.messageCounts[body["session-id"].(string)], int(body["message-count"].(int32)))
+		if count == 0 {
+			return &rpc.Response{Code: 204, Message: &amqp.Message{}}, nil
+		}
+		return &rpc.Response{Code: 200, Message: &amqp.Message{Value: map[string]any{"messages": make([]any, count)}}}, nil
+	}
+	return &rpc.Response{Code: 400, Description: "unknown operation"}, nil
+}
+
+func (f *fakeServiceBusManagement) Close(context.Context) error {
+	return nil
+}
+
+func TestGetServiceBusSessionRuntimeInfo(t *testing.T) {
+	management := &fakeServiceBusManagement{messageCounts: map[string]int{}}
+	for i := 0; i < serviceBusSessionsPageSize+1; i++ {
+		management.messageCounts[fmt.Sprintf("session-%03d", i)] = 1
+	}
+	management.messageCounts["session-000"] = 5000
+
+	sessions, err := getServiceBusSessionRuntimeInfo(context.Background(), management, "", true, 100)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, serviceBusSessionsPageSize+1)
+	// the backlogged session is counted up to the limit, with a single peek
+	assert.Equal(t, ServiceBusSessionInfo{SessionID: "session-000", MessageCount: 100}, sessions[0])
+	assert.Equal(t, ServiceBusSessionInfo{SessionID: "session-001", MessageCount: 1}, sessions[1])
+	assert.Len(t, management.requests, 2+serviceBusSessionsPageSize+1)
+
+	management.requests = nil
+	sessions, err = getServiceBusSessionRuntimeInfo(context.Background(), management, "session-001", false, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []ServiceBusSessionInfo{{SessionID: "session-001"}}, sessions)
+	assert.Empty(t, management.requests)
+
+	_, err = serviceBusManagementRequest(context.Background(), management, &amqp.Message{ApplicationProperties: map[string]any{"operation": "unknown"}})
+	assert.EqualError(t, err, "status code 400: unknown operation")
+}
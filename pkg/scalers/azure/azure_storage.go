@@ -98,7 +98,7 @@ func GetStorageBlobClient(logger logr.Logger, podIdentity kedav1alpha1.AuthPodId
 		}
 		return blobClient, nil
 	case kedav1alpha1.PodIdentityProviderAzureWorkload:
-		creds, chainedErr := NewChainedCredential(logger, podIdentity)
+		creds, chainedErr := GetSharedChainedCredential(logger, podIdentity)
 		if chainedErr != nil {
 			return nil, chainedErr
 		}
@@ -125,7 +125,7 @@ func GetStorageQueueClient(logger logr.Logger, podIdentity kedav1alpha1.AuthPodI
 		}
 		return queueClient, nil
 	case kedav1alpha1.PodIdentityProviderAzureWorkload:
-		creds, chainedErr := NewChainedCredential(logger, podIdentity)
+		creds, chainedErr := GetSharedChainedCredential(logger, podIdentity)
 		if chainedErr != nil {
 			return nil, chainedErr
 		}
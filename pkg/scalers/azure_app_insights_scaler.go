@@ -24,6 +24,9 @@ const (
 	azureAppInsightsMetricAggregationTimespanName = "metricAggregationTimespan"
 	azureAppInsightsMetricAggregationTypeName     = "metricAggregationType"
 	azureAppInsightsMetricFilterName              = "metricFilter"
+	azureAppInsightsQueryName                     = "query"
+	azureAppInsightsQueryTimespanName             = "queryTimespan"
+	azureAppInsightsAppKeyName                    = "appKey"
 	azureAppInsightsTenantIDName                  = "tenantId"
 	azureAppInsightsIgnoreNullValues              = "ignoreNullValues"
 )
@@ -104,32 +107,47 @@ func parseAzureAppInsightsMetadata(config *scalersconfig.ScalerConfig, logger lo
 		meta.activationTargetValue = activationTargetValue
 	}
 
-	val, err = getParameterFromConfig(config, azureAppInsightsMetricID, false)
-	if err != nil {
-		return nil, err
-	}
-	meta.azureAppInsightsInfo.MetricID = val
+	if val, ok := config.TriggerMetadata[azureAppInsightsQueryName]; ok && val != "" {
+		if config.TriggerMetadata[azureAppInsightsMetricID] != "" {
+			return nil, fmt.Errorf("only one of %s or %s can be provided", azureAppInsightsMetricID, azureAppInsightsQueryName)
+		}
+		meta.azureAppInsightsInfo.Query = val
 
-	val, err = getParameterFromConfig(config, azureAppInsightsMetricAggregationTimespanName, false)
-	if err != nil {
-		return nil, err
-	}
-	aggregationTimespan := strings.Split(val, ":")
-	if len(aggregationTimespan) != 2 {
-		return nil, fmt.Errorf("%s not in the correct format. Should be hh:mm", azureAppInsightsMetricAggregationTimespanName)
-	}
-	meta.azureAppInsightsInfo.AggregationTimespan = val
+		if val, ok := config.TriggerMetadata[azureAppInsightsQueryTimespanName]; ok && val != "" {
+			queryTimespan := strings.Split(val, ":")
+			if len(queryTimespan) != 2 {
+				return nil, fmt.Errorf("%s not in the correct format. Should be hh:mm", azureAppInsightsQueryTimespanName)
+			}
+			meta.azureAppInsightsInfo.QueryTimespan = val
+		}
+	} else {
+		val, err = getParameterFromConfig(config, azureAppInsightsMetricID, false)
+		if err != nil {
+			return nil, err
+		}
+		meta.azureAppInsightsInfo.MetricID = val
 
-	val, err = getParameterFromConfig(config, azureAppInsightsMetricAggregationTypeName, false)
-	if err != nil {
-		return nil, err
-	}
-	meta.azureAppInsightsInfo.AggregationType = val
+		val, err = getParameterFromConfig(config, azureAppInsightsMetricAggregationTimespanName, false)
+		if err != nil {
+			return nil, err
+		}
+		aggregationTimespan := strings.Split(val, ":")
+		if len(aggregationTimespan) != 2 {
+			return nil, fmt.Errorf("%s not in the correct format. Should be hh:mm", azureAppInsightsMetricAggregationTimespanName)
+		}
+		meta.azureAppInsightsInfo.AggregationTimespan = val
 
-	if val, ok := config.TriggerMetadata[azureAppInsightsMetricFilterName]; ok && val != "" {
-		meta.azureAppInsightsInfo.Filter = val
-	} else {
-		meta.azureAppInsightsInfo.Filter = ""
+		val, err = getParameterFromConfig(config, azureAppInsightsMetricAggregationTypeName, false)
+		if err != nil {
+			return nil, err
+		}
+		meta.azureAppInsightsInfo.AggregationType = val
+
+		if val, ok := config.TriggerMetadata[azureAppInsightsMetricFilterName]; ok && val != "" {
+			meta.azureAppInsightsInfo.Filter = val
+		} else {
+			meta.azureAppInsightsInfo.Filter = ""
+		}
 	}
 
 	meta.azureAppInsightsInfo.AppInsightsResourceURL = azure.DefaultAppInsightsResourceURL
@@ -171,18 +189,26 @@ func parseAzureAppInsightsMetadata(config *scalersconfig.ScalerConfig, logger lo
 	}
 	meta.azureAppInsightsInfo.ApplicationInsightsID = val
 
-	val, err = getParameterFromConfig(config, azureAppInsightsTenantIDName, true)
-	if err != nil {
-		return nil, err
+	// the API key of the application replaces the Azure AD authentication
+	if val, ok := config.AuthParams[azureAppInsightsAppKeyName]; ok && val != "" {
+		meta.azureAppInsightsInfo.AppKey = val
+	} else if val, ok := config.TriggerMetadata[azureAppInsightsAppKeyName+"FromEnv"]; ok && val != "" {
+		meta.azureAppInsightsInfo.AppKey = config.ResolvedEnv[val]
 	}
-	meta.azureAppInsightsInfo.TenantID = val
+	if meta.azureAppInsightsInfo.AppKey == "" {
+		val, err = getParameterFromConfig(config, azureAppInsightsTenantIDName, true)
+		if err != nil {
+			return nil, err
+		}
+		meta.azureAppInsightsInfo.TenantID = val
 
-	clientID, clientPassword, err := parseAzurePodIdentityParams(config)
-	if err != nil {
-		return nil, err
+		clientID, clientPassword, err := parseAzurePodIdentityParams(config)
+		if err != nil {
+			return nil, err
+		}
+		meta.azureAppInsightsInfo.ClientID = clientID
+		meta.azureAppInsightsInfo.ClientPassword = clientPassword
 	}
-	meta.azureAppInsightsInfo.ClientID = clientID
-	meta.azureAppInsightsInfo.ClientPassword = clientPassword
 
 	meta.triggerIndex = config.TriggerIndex
 
@@ -196,7 +222,7 @@ func (s *azureAppInsightsScaler) Close(context.Context) error {
 func (s *azureAppInsightsScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("azure-app-insights-%s", s.metadata.azureAppInsightsInfo.MetricID))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("azure-app-insights-%s", s.metricIdentifier()))),
 		},
 		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
 	}
@@ -204,9 +230,23 @@ func (s *azureAppInsightsScaler) GetMetricSpecForScaling(context.Context) []v2.M
 	return []v2.MetricSpec{metricSpec}
 }
 
+// metricIdentifier returns the metric id, or "query" when the value comes from a query
+func (s *azureAppInsightsScaler) metricIdentifier() string {
+	if s.metadata.azureAppInsightsInfo.Query != "" {
+		return azureAppInsightsQueryName
+	}
+	return s.metadata.azureAppInsightsInfo.MetricID
+}
+
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *azureAppInsightsScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	val, err := azure.GetAzureAppInsightsMetricValue(ctx, s.metadata.azureAppInsightsInfo, s.podIdentity, s.metadata.ignoreNullValues)
+	var val float64
+	var err error
+	if s.metadata.azureAppInsightsInfo.Query != "" {
+		val, err = azure.GetAzureAppInsightsQueryValue(ctx, s.metadata.azureAppInsightsInfo, s.podIdentity, s.metadata.ignoreNullValues)
+	} else {
+		val, err = azure.GetAzureAppInsightsMetricValue(ctx, s.metadata.azureAppInsightsInfo, s.podIdentity, s.metadata.ignoreNullValues)
+	}
 	if err != nil {
 		s.logger.Error(err, "error getting azure app insights metric")
 		return []external_metrics.ExternalMetricValue{}, false, err
@@ -227,6 +227,60 @@ var azureAppInsightsScalerData = []azureAppInsightsScalerTestData{
 			"tenantId": "tenantId", "activeDirectoryClientId": "adClientId", "activeDirectoryClientPassword": "adClientPassword",
 		},
 	}},
+	{name: "query", isError: false, config: scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"targetValue": "11", "applicationInsightsId": "1234", "query": "requests | summarize count()", "queryTimespan": "00:05", "tenantId": "1234",
+		},
+		AuthParams: map[string]string{
+			"activeDirectoryClientId": "5678", "activeDirectoryClientPassword": "pw",
+		},
+	}},
+	{name: "query with workload identity", isError: false, config: scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"targetValue": "11", "applicationInsightsId": "1234", "query": "requests | summarize count()", "tenantId": "1234",
+		},
+		PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzureWorkload},
+	}},
+	{name: "query and metric id", isError: true, config: scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"targetValue": "11", "applicationInsightsId": "1234", "query": "requests | summarize count()", "metricId": "unittest/test", "tenantId": "1234",
+		},
+		AuthParams: map[string]string{
+			"activeDirectoryClientId": "5678", "activeDirectoryClientPassword": "pw",
+		},
+	}},
+	{name: "query timespan in wrong format", isError: true, config: scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"targetValue": "11", "applicationInsightsId": "1234", "query": "requests | summarize count()", "queryTimespan": "5m", "tenantId": "1234",
+		},
+		AuthParams: map[string]string{
+			"activeDirectoryClientId": "5678", "activeDirectoryClientPassword": "pw",
+		},
+	}},
+	{name: "app key without azure ad", isError: false, config: scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"targetValue": "11", "applicationInsightsId": "1234", "query": "requests | summarize count()",
+		},
+		AuthParams: map[string]string{
+			"appKey": "key",
+		},
+	}},
+	{name: "app key from env", isError: false, config: scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"targetValue": "11", "applicationInsightsId": "1234", "metricId": "unittest/test", "metricAggregationTimespan": "01:02", "metricAggregationType": "max", "appKeyFromEnv": "APP_KEY",
+		},
+		ResolvedEnv: map[string]string{
+			"APP_KEY": "key",
+		},
+	}},
+	{name: "app key from env - missing environment variable", isError: true, config: scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"targetValue": "11", "applicationInsightsId": "1234", "metricId": "unittest/test", "metricAggregationTimespan": "01:02", "metricAggregationType": "max", "appKeyFromEnv": "MISSING_APP_KEY",
+		},
+		ResolvedEnv: map[string]string{
+			"APP_KEY": "key",
+		},
+	}},
 	{name: "unsupported cloud", isError: true, config: scalersconfig.ScalerConfig{
 		TriggerMetadata: map[string]string{
 			"metricAggregationTimespan": "00:01", "metricAggregationType": "count", "metricId": "unittest/test", "targetValue": "10",
@@ -269,6 +323,9 @@ func TestAzureAppInsightsGetMetricSpecForScaling(t *testing.T) {
 			metricSpec := mockAzureAppInsightsScaler.GetMetricSpecForScaling(ctx)
 			metricName := metricSpec[0].External.Metric.Name
 			expectedName := fmt.Sprintf("s%d-azure-app-insights-%s", triggerIndex, strings.ReplaceAll(testData.config.TriggerMetadata["metricId"], "/", "-"))
+			if testData.config.TriggerMetadata["query"] != "" {
+				expectedName = fmt.Sprintf("s%d-azure-app-insights-query", triggerIndex)
+			}
 			if metricName != expectedName {
 				t.Errorf("Wrong External metric name. expected: %s, actual: %s", expectedName, metricName)
 			}
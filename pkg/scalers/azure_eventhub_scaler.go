@@ -61,6 +61,8 @@ type eventHubMetadata struct {
 	threshold                   int64
 	activationThreshold         int64
 	stalePartitionInfoThreshold int64
+	scaleToPartitionCount       bool
+	busyPartitionThreshold      int64
 	triggerIndex                int
 }
 
@@ -177,6 +179,30 @@ func parseCommonAzureEventHubMetadata(config *scalersconfig.ScalerConfig, meta *
 		meta.stalePartitionInfoThreshold = stalePartitionInfoThreshold
 	}
 
+	meta.scaleToPartitionCount = false
+	if val, ok := config.TriggerMetadata["scaleToPartitionCount"]; ok {
+		scaleToPartitionCount, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("error parsing azure eventhub metadata scaleToPartitionCount: %w", err)
+		}
+		meta.scaleToPartitionCount = scaleToPartitionCount
+	}
+
+	meta.busyPartitionThreshold = 0
+	if val, ok := config.TriggerMetadata["busyPartitionThreshold"]; ok {
+		if !meta.scaleToPartitionCount {
+			return fmt.Errorf("busyPartitionThreshold can only be set when scaleToPartitionCount is enabled")
+		}
+		busyPartitionThreshold, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing azure eventhub metadata busyPartitionThreshold: %w", err)
+		}
+		if busyPartitionThreshold < 0 {
+			return fmt.Errorf("busyPartitionThreshold must be a positive number or 0")
+		}
+		meta.busyPartitionThreshold = busyPartitionThreshold
+	}
+
 	meta.triggerIndex = config.TriggerIndex
 
 	return nil
@@ -345,6 +371,18 @@ func getTotalLagRelatedToPartitionAmount(unprocessedEventsCount int64, partition
 	return unprocessedEventsCount
 }
 
+// getBusyPartitionCount returns the number of partitions having more unprocessed events than busyPartitionThreshold
+func getBusyPartitionCount(unprocessedEventCounts []int64, busyPartitionThreshold int64) int64 {
+	busyPartitionCount := int64(0)
+	for _, unprocessedEventCount := range unprocessedEventCounts {
+		if unprocessedEventCount > busyPartitionThreshold {
+			busyPartitionCount++
+		}
+	}
+
+	return busyPartitionCount
+}
+
 // Close closes Azure Event Hub Scaler
 func (s *azureEventHubScaler) Close(ctx context.Context) error {
 	if s.eventHubClient != nil {
@@ -367,6 +405,7 @@ func (s *azureEventHubScaler) GetMetricsAndActivity(ctx context.Context, metricN
 	}
 
 	partitionIDs := runtimeInfo.PartitionIDs
+	unprocessedEventCounts := make([]int64, 0, len(partitionIDs))
 
 	for i := 0; i < len(partitionIDs); i++ {
 		partitionID := partitionIDs[i]
@@ -383,6 +422,7 @@ func (s *azureEventHubScaler) GetMetricsAndActivity(ctx context.Context, metricN
 		}
 
 		totalUnprocessedEventCount += unprocessedEventCount
+		unprocessedEventCounts = append(unprocessedEventCounts, unprocessedEventCount)
 
 		s.logger.V(1).Info(fmt.Sprintf("Partition ID: %s, Last SequenceNumber: %d, Checkpoint SequenceNumber: %d, Total new events in partition: %d",
 			partitionRuntimeInfo.PartitionID, partitionRuntimeInfo.LastEnqueuedSequenceNumber, checkpoint.SequenceNumber, unprocessedEventCount))
@@ -394,6 +434,18 @@ func (s *azureEventHubScaler) GetMetricsAndActivity(ctx context.Context, metricN
 		totalUnprocessedEventCount = math.MaxInt64
 	}
 
+	if s.metadata.scaleToPartitionCount {
+		// report one threshold worth of lag per busy partition, so the HPA runs one replica per busy partition
+		busyPartitionCount := getBusyPartitionCount(unprocessedEventCounts, s.metadata.busyPartitionThreshold)
+
+		s.logger.V(1).Info(fmt.Sprintf("Unprocessed events in event hub total: %d, %d of %d partitions have more than %d unprocessed events",
+			totalUnprocessedEventCount, busyPartitionCount, len(partitionIDs), s.metadata.busyPartitionThreshold))
+
+		metric := GenerateMetricInMili(metricName, float64(busyPartitionCount*s.metadata.threshold))
+
+		return []external_metrics.ExternalMetricValue{metric}, busyPartitionCount > 0 && totalUnprocessedEventCount > s.metadata.activationThreshold, nil
+	}
+
 	// don't scale out beyond the number of partitions
 	lagRelatedToPartitionCount := getTotalLagRelatedToPartitionAmount(totalUnprocessedEventCount, int64(len(partitionIDs)), s.metadata.threshold)
 
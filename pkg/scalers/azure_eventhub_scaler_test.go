@@ -3,6 +3,7 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"math"
 	"os"
 	"testing"
 
@@ -109,6 +110,30 @@ var parseEventHubMetadataDataset = []parseEventHubMetadataTestData{
 		resolvedEnv: map[string]string{eventHubConnectionSetting: "Endpoint=sb://testEventHubNamespace.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=testKey;", storageConnectionSetting: "none"},
 		isError:     false,
 	},
+	// scale to busy partition count
+	{
+		metadata:    map[string]string{"storageConnectionFromEnv": storageConnectionSetting, "consumerGroup": eventHubConsumerGroup, "connectionFromEnv": eventHubConnectionSetting, "scaleToPartitionCount": "true", "busyPartitionThreshold": "10"},
+		resolvedEnv: sampleEventHubResolvedEnv,
+		isError:     false,
+	},
+	// invalid scaleToPartitionCount
+	{
+		metadata:    map[string]string{"storageConnectionFromEnv": storageConnectionSetting, "consumerGroup": eventHubConsumerGroup, "connectionFromEnv": eventHubConnectionSetting, "scaleToPartitionCount": "AA"},
+		resolvedEnv: sampleEventHubResolvedEnv,
+		isError:     true,
+	},
+	// invalid busyPartitionThreshold
+	{
+		metadata:    map[string]string{"storageConnectionFromEnv": storageConnectionSetting, "consumerGroup": eventHubConsumerGroup, "connectionFromEnv": eventHubConnectionSetting, "scaleToPartitionCount": "true", "busyPartitionThreshold": "-1"},
+		resolvedEnv: sampleEventHubResolvedEnv,
+		isError:     true,
+	},
+	// busyPartitionThreshold without scaleToPartitionCount
+	{
+		metadata:    map[string]string{"storageConnectionFromEnv": storageConnectionSetting, "consumerGroup": eventHubConsumerGroup, "connectionFromEnv": eventHubConnectionSetting, "busyPartitionThreshold": "10"},
+		resolvedEnv: sampleEventHubResolvedEnv,
+		isError:     true,
+	},
 }
 
 var parseEventHubMetadataDatasetWithPodIdentity = []parseEventHubMetadataTestData{
@@ -658,3 +683,59 @@ func TestCalculateUnprocessedEvents(t *testing.T) {
 		}
 	}
 }
+
+type busyPartitionCountTestData struct {
+	partitionInfos         []azeventhubs.PartitionProperties
+	checkpoints            []azure.Checkpoint
+	busyPartitionThreshold int64
+	busyPartitionCount     int64
+}
+
+var busyPartitionCountDataset = []busyPartitionCountTestData{
+	// all partitions caught up
+	{
+		partitionInfos:     []azeventhubs.PartitionProperties{{LastEnqueuedSequenceNumber: 10}, {LastEnqueuedSequenceNumber: 20}},
+		checkpoints:        []azure.Checkpoint{azure.NewCheckpoint(10), azure.NewCheckpoint(20)},
+		busyPartitionCount: 0,
+	},
+	// one partition with unprocessed events
+	{
+		partitionInfos:     []azeventhubs.PartitionProperties{{LastEnqueuedSequenceNumber: 10}, {LastEnqueuedSequenceNumber: 25}},
+		checkpoints:        []azure.Checkpoint{azure.NewCheckpoint(10), azure.NewCheckpoint(20)},
+		busyPartitionCount: 1,
+	},
+	// all partitions with unprocessed events
+	{
+		partitionInfos:     []azeventhubs.PartitionProperties{{LastEnqueuedSequenceNumber: 15}, {LastEnqueuedSequenceNumber: 25}, {LastEnqueuedSequenceNumber: 1}},
+		checkpoints:        []azure.Checkpoint{azure.NewCheckpoint(10), azure.NewCheckpoint(20), azure.NewCheckpoint(0)},
+		busyPartitionCount: 3,
+	},
+	// only partitions above the threshold are busy
+	{
+		partitionInfos:         []azeventhubs.PartitionProperties{{LastEnqueuedSequenceNumber: 15}, {LastEnqueuedSequenceNumber: 40}, {LastEnqueuedSequenceNumber: 30}},
+		checkpoints:            []azure.Checkpoint{azure.NewCheckpoint(10), azure.NewCheckpoint(20), azure.NewCheckpoint(20)},
+		busyPartitionThreshold: 10,
+		busyPartitionCount:     1,
+	},
+	// sequence number wrapped around
+	{
+		partitionInfos:         []azeventhubs.PartitionProperties{{LastEnqueuedSequenceNumber: 5}, {LastEnqueuedSequenceNumber: 20}},
+		checkpoints:            []azure.Checkpoint{azure.NewCheckpoint(math.MaxInt64 - 10), azure.NewCheckpoint(20)},
+		busyPartitionThreshold: 10,
+		busyPartitionCount:     1,
+	},
+}
+
+func TestGetBusyPartitionCount(t *testing.T) {
+	for _, testData := range busyPartitionCountDataset {
+		unprocessedEventCounts := make([]int64, 0, len(testData.partitionInfos))
+		for i, partitionInfo := range testData.partitionInfos {
+			unprocessedEventCounts = append(unprocessedEventCounts, calculateUnprocessedEvents(partitionInfo, testData.checkpoints[i], defaultStalePartitionInfoThreshold))
+		}
+
+		v := getBusyPartitionCount(unprocessedEventCounts, testData.busyPartitionThreshold)
+		if v != testData.busyPartitionCount {
+			t.Errorf("Wrong busy partition count: expected %d, got %d", testData.busyPartitionCount, v)
+		}
+	}
+}
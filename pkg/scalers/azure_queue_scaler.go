@@ -19,6 +19,7 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azqueue"
@@ -54,7 +55,19 @@ type azureQueueMetadata struct {
 	AccountName           string `keda:"name=accountName,           order=triggerMetadata, optional"`
 	EndpointSuffix        string `keda:"name=endpointSuffix,        order=triggerMetadata, optional"`
 	QueueLengthStrategy   string `keda:"name=queueLengthStrategy,   order=triggerMetadata, enum=all;visibleonly, default=all"`
-	TriggerIndex          int
+	// InFlightFactor is the estimated fraction of the approximate message count that is invisible, i.e. being
+	// processed by the workers. The approximate message count returned by the queue includes these messages, so
+	// it is scaled down by this factor. This is only an approximation, the actual share of in-flight messages
+	// changes with the processing time and the number of workers.
+	InFlightFactor float64 `keda:"name=inFlightFactor, order=triggerMetadata, default=0"`
+	TriggerIndex   int
+}
+
+func (m *azureQueueMetadata) Validate() error {
+	if m.InFlightFactor < 0 || m.InFlightFactor >= 1 {
+		return fmt.Errorf("inFlightFactor must be in the range [0, 1), got %v", m.InFlightFactor)
+	}
+	return nil
 }
 
 func NewAzureQueueScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
@@ -147,12 +160,13 @@ func (s *azureQueueScaler) GetMetricsAndActivity(ctx context.Context, metricName
 }
 
 func (s *azureQueueScaler) getMessageCount(ctx context.Context) (int64, error) {
+	var visibleMessageCount int
 	if strings.ToLower(s.metadata.QueueLengthStrategy) == queueLengthStrategyVisibleOnly {
 		queue, err := s.queueClient.PeekMessages(ctx, &azqueue.PeekMessagesOptions{NumberOfMessages: &maxPeekMessages})
 		if err != nil {
 			return 0, err
 		}
-		visibleMessageCount := len(queue.Messages)
+		visibleMessageCount = len(queue.Messages)
 
 		// Queue has less messages than we allowed to peek for,
 		// so no need to fall back to the 'all' strategy
@@ -165,5 +179,18 @@ func (s *azureQueueScaler) getMessageCount(ctx context.Context) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return int64(*props.ApproximateMessagesCount), nil
+	return estimatePendingMessageCount(int64(*props.ApproximateMessagesCount), s.metadata.InFlightFactor, int64(visibleMessageCount)), nil
+}
+
+// estimatePendingMessageCount removes the estimated in-flight messages from the approximate message count.
+// The estimate is never lower than the number of messages known to be visible from peeking.
+func estimatePendingMessageCount(approximateMessageCount int64, inFlightFactor float64, visibleMessageCount int64) int64 {
+	if inFlightFactor == 0 {
+		return approximateMessageCount
+	}
+	pending := int64(math.Ceil(float64(approximateMessageCount) * (1 - inFlightFactor)))
+	if pending < visibleMessageCount {
+		return visibleMessageCount
+	}
+	return pending
 }
@@ -177,6 +177,30 @@ var testAzQueueMetadata = []parseAzQueueMetadataTestData{
 		authParams:  map[string]string{},
 		podIdentity: "",
 	},
+	{
+		name:        "valid inFlightFactor",
+		metadata:    map[string]string{"connectionFromEnv": "CONNECTION", "queueName": "sample", "queueLength": "5", "inFlightFactor": "0.25"},
+		isError:     false,
+		resolvedEnv: testAzQueueResolvedEnv,
+		authParams:  map[string]string{},
+		podIdentity: "",
+	},
+	{
+		name:        "inFlightFactor out of range",
+		metadata:    map[string]string{"connectionFromEnv": "CONNECTION", "queueName": "sample", "queueLength": "5", "inFlightFactor": "1"},
+		isError:     true,
+		resolvedEnv: testAzQueueResolvedEnv,
+		authParams:  map[string]string{},
+		podIdentity: "",
+	},
+	{
+		name:        "negative inFlightFactor",
+		metadata:    map[string]string{"connectionFromEnv": "CONNECTION", "queueName": "sample", "queueLength": "5", "inFlightFactor": "-0.5"},
+		isError:     true,
+		resolvedEnv: testAzQueueResolvedEnv,
+		authParams:  map[string]string{},
+		podIdentity: "",
+	},
 	{
 		name:        "invalid queueLengthStrategy",
 		metadata:    map[string]string{"connectionFromEnv": "CONNECTION", "queueName": "sample", "queueLength": "5", "queueLengthStrategy": "invalid"},
@@ -320,3 +344,58 @@ func TestAzQueueGetMessageCount(t *testing.T) {
 		})
 	}
 }
+
+func TestAzQueueEstimatePendingMessageCount(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		approximateMessageCount int64
+		inFlightFactor          float64
+		visibleMessageCount     int64
+		expectedCount           int64
+	}{
+		{
+			name:                    "no inFlightFactor",
+			approximateMessageCount: 100,
+			expectedCount:           100,
+		},
+		{
+			name:                    "inFlightFactor applied",
+			approximateMessageCount: 100,
+			inFlightFactor:          0.25,
+			expectedCount:           75,
+		},
+		{
+			name:                    "rounded up",
+			approximateMessageCount: 10,
+			inFlightFactor:          0.33,
+			expectedCount:           7,
+		},
+		{
+			name:                    "empty queue",
+			approximateMessageCount: 0,
+			inFlightFactor:          0.5,
+			expectedCount:           0,
+		},
+		{
+			name:                    "not lower than the peeked visible messages",
+			approximateMessageCount: 40,
+			inFlightFactor:          0.5,
+			visibleMessageCount:     32,
+			expectedCount:           32,
+		},
+		{
+			name:                    "peeked visible messages below the estimate",
+			approximateMessageCount: 100,
+			inFlightFactor:          0.5,
+			visibleMessageCount:     32,
+			expectedCount:           50,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			count := estimatePendingMessageCount(tc.approximateMessageCount, tc.inFlightFactor, tc.visibleMessageCount)
+			assert.Equal(t, tc.expectedCount, count)
+		})
+	}
+}
@@ -45,8 +45,20 @@ const (
 	messageCountMetricName                      = "messageCount"
 	activationMessageCountMetricName            = "activationMessageCount"
 	defaultTargetMessageCount                   = 5
+
+	// sessionMessageCountMode scales on the messages of the sessions, activeSessionsMode on the count of sessions having messages
+	sessionMessageCountMode = "messageCount"
+	activeSessionsMode      = "activeSessions"
+	// maxSessionMessageCount caps the messages peeked per session, in a single request, so a backlogged
+	// session doesn't make the polling slow; the message count of a session saturates at this value
+	maxSessionMessageCount = 100
 )
 
+type azureServiceBusSessionClient interface {
+	GetSessionRuntimeInfo(ctx context.Context, sessionID string, countMessages bool, messageLimit int64) ([]azure.ServiceBusSessionInfo, error)
+	Close(ctx context.Context)
+}
+
 type azureServiceBusScaler struct {
 	ctx         context.Context
 	metricType  v2.MetricTargetType
@@ -54,6 +66,9 @@ type azureServiceBusScaler struct {
 	podIdentity kedav1alpha1.AuthPodIdentity
 	client      *admin.Client
 	logger      logr.Logger
+
+	sessionClient         azureServiceBusSessionClient
+	sessionEnabledChecked bool
 }
 
 type azureServiceBusMetadata struct {
@@ -70,6 +85,8 @@ type azureServiceBusMetadata struct {
 	operation               string
 	triggerIndex            int
 	timeout                 time.Duration
+	sessionMode             string
+	sessionID               string
 }
 
 // NewAzureServiceBusScaler creates a new AzureServiceBusScaler
@@ -131,8 +148,26 @@ func parseAzureServiceBusMetadata(config *scalersconfig.ScalerConfig, logger log
 		meta.useRegex = useRegex
 	}
 
+	meta.sessionMode = config.TriggerMetadata["sessionMode"]
+	meta.sessionID = config.TriggerMetadata["sessionId"]
+	switch meta.sessionMode {
+	case "":
+		if meta.sessionID != "" {
+			return nil, fmt.Errorf("sessionId requires sessionMode %s", sessionMessageCountMode)
+		}
+	case sessionMessageCountMode, activeSessionsMode:
+		if meta.useRegex {
+			return nil, fmt.Errorf("sessionMode can't be used with useRegex")
+		}
+		if meta.sessionMode == activeSessionsMode && meta.sessionID != "" {
+			return nil, fmt.Errorf("sessionId can't be used with sessionMode %s", activeSessionsMode)
+		}
+	default:
+		return nil, fmt.Errorf("sessionMode must be one of %s or %s", sessionMessageCountMode, activeSessionsMode)
+	}
+
 	meta.operation = sumOperation
-	if meta.useRegex {
+	if meta.useRegex || (meta.sessionMode == sessionMessageCountMode && meta.sessionID == "") {
 		if val, ok := config.TriggerMetadata["operation"]; ok {
 			meta.operation = val
 		}
@@ -228,7 +263,10 @@ func parseAzureServiceBusMetadata(config *scalersconfig.ScalerConfig, logger log
 }
 
 // Close - nothing to close for SB
-func (s *azureServiceBusScaler) Close(context.Context) error {
+func (s *azureServiceBusScaler) Close(ctx context.Context) error {
+	if s.sessionClient != nil {
+		s.sessionClient.Close(ctx)
+	}
 	return nil
 }
 
@@ -280,6 +318,9 @@ func (s *azureServiceBusScaler) getAzureServiceBusLength(ctx context.Context) (i
 	if err != nil {
 		return -1, err
 	}
+	if s.metadata.sessionMode != "" {
+		return s.getSessionsLength(ctx, adminClient)
+	}
 	// switch case for queue vs topic here
 	switch s.metadata.entityType {
 	case queue:
@@ -308,7 +349,7 @@ func (s *azureServiceBusScaler) getServiceBusAdminClient() (*admin.Client, error
 	case "", kedav1alpha1.PodIdentityProviderNone:
 		client, err = admin.NewClientFromConnectionString(s.metadata.connection, opts)
 	case kedav1alpha1.PodIdentityProviderAzureWorkload:
-		creds, chainedErr := azure.NewChainedCredential(s.logger, s.podIdentity)
+		creds, chainedErr := azure.GetSharedChainedCredential(s.logger, s.podIdentity)
 		if chainedErr != nil {
 			return nil, chainedErr
 		}
@@ -321,6 +362,102 @@ func (s *azureServiceBusScaler) getServiceBusAdminClient() (*admin.Client, error
 	return client, err
 }
 
+// Returns the count of active sessions or the messages of the sessions, depending on the session mode
+func (s *azureServiceBusScaler) getSessionsLength(ctx context.Context, adminClient *admin.Client) (int64, error) {
+	if !s.sessionEnabledChecked {
+		if err := checkSessionEnabled(ctx, adminClient, s.metadata); err != nil {
+			return -1, err
+		}
+		s.sessionEnabledChecked = true
+	}
+
+	sessionClient, err := s.getServiceBusSessionClient()
+	if err != nil {
+		return -1, err
+	}
+
+	countMessages := s.metadata.sessionMode == sessionMessageCountMode
+	sessions, err := sessionClient.GetSessionRuntimeInfo(ctx, s.metadata.sessionID, countMessages, maxSessionMessageCount)
+	if err != nil {
+		return -1, err
+	}
+	if !countMessages {
+		return int64(len(sessions)), nil
+	}
+
+	messageCounts := make([]int64, 0, len(sessions))
+	for _, session := range sessions {
+		messageCounts = append(messageCounts, session.MessageCount)
+	}
+	return performOperation(messageCounts, s.metadata.operation), nil
+}
+
+// Returns the client reading the sessions of the queue or subscription
+func (s *azureServiceBusScaler) getServiceBusSessionClient() (azureServiceBusSessionClient, error) {
+	if s.sessionClient != nil {
+		return s.sessionClient, nil
+	}
+
+	entityPath := s.metadata.queueName
+	if s.metadata.entityType == subscription {
+		entityPath = fmt.Sprintf("%s/Subscriptions/%s", s.metadata.topicName, s.metadata.subscriptionName)
+	}
+
+	switch s.podIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		client, err := azure.NewServiceBusSessionClientFromConnectionString(s.metadata.connection, entityPath)
+		if err != nil {
+			return nil, err
+		}
+		s.sessionClient = client
+	case kedav1alpha1.PodIdentityProviderAzureWorkload:
+		creds, err := azure.GetSharedChainedCredential(s.logger, s.podIdentity)
+		if err != nil {
+			return nil, err
+		}
+		s.sessionClient = azure.NewServiceBusSessionClient(s.metadata.fullyQualifiedNamespace, entityPath, creds)
+	default:
+		return nil, fmt.Errorf("incorrect podIdentity type")
+	}
+	return s.sessionClient, nil
+}
+
+// checkSessionEnabled fails when the queue or subscription doesn't require sessions, as it has no sessions to scale on
+func checkSessionEnabled(ctx context.Context, adminClient *admin.Client, meta *azureServiceBusMetadata) error {
+	switch meta.entityType {
+	case queue:
+		queueEntity, err := adminClient.GetQueue(ctx, meta.queueName, nil)
+		if err != nil {
+			return err
+		}
+		if queueEntity == nil {
+			return fmt.Errorf("queue %s doesn't exist", meta.queueName)
+		}
+		return sessionEnabledError(meta, queueEntity.RequiresSession)
+	case subscription:
+		subscriptionEntity, err := adminClient.GetSubscription(ctx, meta.topicName, meta.subscriptionName, nil)
+		if err != nil {
+			return err
+		}
+		if subscriptionEntity == nil {
+			return fmt.Errorf("subscription %s doesn't exist in topic %s", meta.subscriptionName, meta.topicName)
+		}
+		return sessionEnabledError(meta, subscriptionEntity.RequiresSession)
+	default:
+		return fmt.Errorf("no entity type")
+	}
+}
+
+func sessionEnabledError(meta *azureServiceBusMetadata, requiresSession *bool) error {
+	if requiresSession != nil && *requiresSession {
+		return nil
+	}
+	if meta.entityType == queue {
+		return fmt.Errorf("queue %s isn't session-enabled, sessionMode can't be used", meta.queueName)
+	}
+	return fmt.Errorf("subscription %s of topic %s isn't session-enabled, sessionMode can't be used", meta.subscriptionName, meta.topicName)
+}
+
 func getQueueLength(ctx context.Context, adminClient *admin.Client, meta *azureServiceBusMetadata) (int64, error) {
 	if !meta.useRegex {
 		queueEntity, err := adminClient.GetQueueRuntimeProperties(ctx, meta.queueName, &admin.GetQueueRuntimePropertiesOptions{})
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/azure"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
 
@@ -123,6 +124,22 @@ var parseServiceBusMetadataDataset = []parseServiceBusMetadataTestData{
 	{map[string]string{"topicName": topicName, "subscriptionName": subscriptionName, "connectionFromEnv": connectionSetting, "useRegex": "true", "operation": "random"}, true, subscription, defaultSuffix, map[string]string{}, ""},
 	// subscription with invalid regex string
 	{map[string]string{"topicName": topicName, "subscriptionName": "*", "connectionFromEnv": connectionSetting, "useRegex": "true", "operation": "avg"}, true, subscription, defaultSuffix, map[string]string{}, ""},
+
+	// properly formed session modes
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "sessionMode": "messageCount"}, false, queue, defaultSuffix, map[string]string{}, ""},
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "sessionMode": "messageCount", "operation": maxOperation}, false, queue, defaultSuffix, map[string]string{}, ""},
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "sessionMode": "messageCount", "sessionId": "session-1"}, false, queue, defaultSuffix, map[string]string{}, ""},
+	{map[string]string{"topicName": topicName, "subscriptionName": subscriptionName, "connectionFromEnv": connectionSetting, "sessionMode": "activeSessions"}, false, subscription, defaultSuffix, map[string]string{}, ""},
+	// invalid session mode
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "sessionMode": "sessions"}, true, queue, defaultSuffix, map[string]string{}, ""},
+	// session mode with invalid operation
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "sessionMode": "messageCount", "operation": "random"}, true, queue, defaultSuffix, map[string]string{}, ""},
+	// session id without session mode
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "sessionId": "session-1"}, true, queue, defaultSuffix, map[string]string{}, ""},
+	// session id with active sessions mode
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "sessionMode": "activeSessions", "sessionId": "session-1"}, true, queue, defaultSuffix, map[string]string{}, ""},
+	// session mode with regex
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "sessionMode": "messageCount", "useRegex": "true"}, true, queue, defaultSuffix, map[string]string{}, ""},
 }
 
 var azServiceBusMetricIdentifiers = []azServiceBusMetricIdentifier{
@@ -250,3 +267,82 @@ func TestAzServiceBusGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+type fakeServiceBusSessionClient struct {
+	sessions []azure.ServiceBusSessionInfo
+}
+
+func (c *fakeServiceBusSessionClient) GetSessionRuntimeInfo(_ context.Context, sessionID string, countMessages bool, _ int64) ([]azure.ServiceBusSessionInfo, error) {
+	var sessions []azure.ServiceBusSessionInfo
+	for _, session := range c.sessions {
+		if sessionID != "" && session.SessionID != sessionID {
+			continue
+		}
+		if !countMessages {
+			session.MessageCount = 0
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+func (c *fakeServiceBusSessionClient) Close(context.Context) {}
+
+func TestGetServiceBusSessionsLength(t *testing.T) {
+	sessionClient := &fakeServiceBusSessionClient{
+		sessions: []azure.ServiceBusSessionInfo{
+			{SessionID: "session-1", MessageCount: 10},
+			{SessionID: "session-2", MessageCount: 4},
+			{SessionID: "session-3", MessageCount: 1},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		metadata       map[string]string
+		expectedLength int64
+		expectedActive bool
+	}{
+		{"sum of the sessions", map[string]string{"sessionMode": "messageCount"}, 15, true},
+		{"max of the sessions", map[string]string{"sessionMode": "messageCount", "operation": maxOperation}, 10, true},
+		{"avg of the sessions", map[string]string{"sessionMode": "messageCount", "operation": avgOperation}, 5, true},
+		{"single session", map[string]string{"sessionMode": "messageCount", "sessionId": "session-2"}, 4, true},
+		{"single session below activation", map[string]string{"sessionMode": "messageCount", "sessionId": "session-3", "activationMessageCount": "1"}, 1, false},
+		{"unknown session", map[string]string{"sessionMode": "messageCount", "sessionId": "session-4"}, 0, false},
+		{"active sessions", map[string]string{"sessionMode": "activeSessions"}, 3, true},
+		{"active sessions below activation", map[string]string{"sessionMode": "activeSessions", "activationMessageCount": "3"}, 3, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["queueName"] = queueName
+			tc.metadata["connectionFromEnv"] = connectionSetting
+			meta, err := parseAzureServiceBusMetadata(&scalersconfig.ScalerConfig{ResolvedEnv: connectionResolvedEnv, TriggerMetadata: tc.metadata}, logr.Discard())
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			scaler := azureServiceBusScaler{
+				metadata:              meta,
+				logger:                logr.Discard(),
+				sessionClient:         sessionClient,
+				sessionEnabledChecked: true,
+			}
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "s0-azure-servicebus-testqueue")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedActive, isActive)
+			assert.Equal(t, tc.expectedLength, metrics[0].Value.Value())
+		})
+	}
+}
+
+func TestServiceBusSessionEnabledError(t *testing.T) {
+	enabled, disabled := true, false
+	queueMeta := &azureServiceBusMetadata{entityType: queue, queueName: queueName}
+	subscriptionMeta := &azureServiceBusMetadata{entityType: subscription, topicName: topicName, subscriptionName: subscriptionName}
+
+	assert.NoError(t, sessionEnabledError(queueMeta, &enabled))
+	assert.NoError(t, sessionEnabledError(subscriptionMeta, &enabled))
+	assert.EqualError(t, sessionEnabledError(queueMeta, &disabled), "queue testqueue isn't session-enabled, sessionMode can't be used")
+	assert.EqualError(t, sessionEnabledError(subscriptionMeta, nil), "subscription testsubscription of topic testtopic isn't session-enabled, sessionMode can't be used")
+}
@@ -26,21 +26,27 @@ type cassandraScaler struct {
 }
 
 type cassandraMetadata struct {
-	Username                   string `keda:"name=username,                   order=triggerMetadata"`
-	Password                   string `keda:"name=password,                   order=authParams"`
-	TLS                        string `keda:"name=tls,                        order=authParams, enum=enable;disable, default=disable"`
-	Cert                       string `keda:"name=cert,                       order=authParams, optional"`
-	Key                        string `keda:"name=key,                        order=authParams, optional"`
-	CA                         string `keda:"name=ca,                         order=authParams, optional"`
-	ClusterIPAddress           string `keda:"name=clusterIPAddress,           order=triggerMetadata"`
-	Port                       int    `keda:"name=port,                       order=triggerMetadata, optional"`
-	Consistency                string `keda:"name=consistency,                order=triggerMetadata, default=one"`
-	ProtocolVersion            int    `keda:"name=protocolVersion,            order=triggerMetadata, default=4"`
-	Keyspace                   string `keda:"name=keyspace,                   order=triggerMetadata"`
-	Query                      string `keda:"name=query,                      order=triggerMetadata"`
-	TargetQueryValue           int64  `keda:"name=targetQueryValue,           order=triggerMetadata"`
-	ActivationTargetQueryValue int64  `keda:"name=activationTargetQueryValue, order=triggerMetadata, default=0"`
+	Username                   string   `keda:"name=username,                   order=triggerMetadata"`
+	Password                   string   `keda:"name=password,                   order=authParams"`
+	TLS                        string   `keda:"name=tls,                        order=authParams, enum=enable;disable, default=disable"`
+	Cert                       string   `keda:"name=cert,                       order=authParams, optional"`
+	Key                        string   `keda:"name=key,                        order=authParams, optional"`
+	CA                         string   `keda:"name=ca,                         order=authParams, optional"`
+	ClusterIPAddress           string   `keda:"name=clusterIPAddress,           order=triggerMetadata, optional"`
+	ClusterIPs                 []string `keda:"name=clusterIPs,                 order=triggerMetadata, optional"`
+	Port                       int      `keda:"name=port,                       order=triggerMetadata, optional"`
+	LocalDC                    string   `keda:"name=localDC,                    order=triggerMetadata, optional"`
+	Consistency                string   `keda:"name=consistency,                order=triggerMetadata, default=one"`
+	ProtocolVersion            int      `keda:"name=protocolVersion,            order=triggerMetadata, default=4"`
+	Keyspace                   string   `keda:"name=keyspace,                   order=triggerMetadata"`
+	Query                      string   `keda:"name=query,                      order=triggerMetadata"`
+	TargetQueryValue           int64    `keda:"name=targetQueryValue,           order=triggerMetadata"`
+	ActivationTargetQueryValue int64    `keda:"name=activationTargetQueryValue, order=triggerMetadata, default=0"`
 	TriggerIndex               int
+
+	// contactPoints are the hosts with their port the session is initially connected to
+	contactPoints []string
+	consistency   gocql.Consistency
 }
 
 const (
@@ -52,21 +58,50 @@ func (m *cassandraMetadata) Validate() error {
 		return errors.New("both cert and key are required when TLS is enabled")
 	}
 
-	// Handle port in ClusterIPAddress
-	splitVal := strings.Split(m.ClusterIPAddress, ":")
-	if len(splitVal) == 2 {
-		if port, err := strconv.Atoi(splitVal[1]); err == nil {
-			m.Port = port
-			return nil
+	consistency, err := gocql.ParseConsistencyWrapper(m.Consistency)
+	if err != nil {
+		return fmt.Errorf("invalid consistency %q: %w", m.Consistency, err)
+	}
+	m.consistency = consistency
+
+	switch {
+	case m.ClusterIPAddress != "" && len(m.ClusterIPs) > 0:
+		return errors.New("clusterIPAddress and clusterIPs can't be used together")
+	case m.ClusterIPAddress != "":
+		contactPoint, err := cassandraContactPoint(m.ClusterIPAddress, m.Port)
+		if err != nil {
+			return err
 		}
+		m.ClusterIPAddress = contactPoint
+		m.contactPoints = []string{contactPoint}
+	case len(m.ClusterIPs) > 0:
+		m.contactPoints = make([]string, 0, len(m.ClusterIPs))
+		for _, host := range m.ClusterIPs {
+			contactPoint, err := cassandraContactPoint(strings.TrimSpace(host), m.Port)
+			if err != nil {
+				return err
+			}
+			m.contactPoints = append(m.contactPoints, contactPoint)
+		}
+	default:
+		return errors.New("no clusterIPAddress or clusterIPs given")
 	}
+	return nil
+}
 
-	if m.Port == 0 {
-		return fmt.Errorf("no port given")
+// cassandraContactPoint returns the host with its port, the port given with the host taking precedence
+func cassandraContactPoint(host string, port int) (string, error) {
+	splitVal := strings.Split(host, ":")
+	if len(splitVal) == 2 {
+		if _, err := strconv.Atoi(splitVal[1]); err == nil {
+			return host, nil
+		}
 	}
 
-	m.ClusterIPAddress = net.JoinHostPort(m.ClusterIPAddress, fmt.Sprintf("%d", m.Port))
-	return nil
+	if port == 0 {
+		return "", fmt.Errorf("no port given for %s", host)
+	}
+	return net.JoinHostPort(host, fmt.Sprintf("%d", port)), nil
 }
 
 // NewCassandraScaler creates a new Cassandra scaler
@@ -163,11 +198,15 @@ func parseCassandraTLS(meta *cassandraMetadata) error {
 	return nil
 }
 
-// newCassandraSession returns a new Cassandra session for the provided CassandraMetadata
-func newCassandraSession(meta cassandraMetadata, logger logr.Logger) (*gocql.Session, error) {
-	cluster := gocql.NewCluster(meta.ClusterIPAddress)
+// newCassandraClusterConfig returns the config of the sessions for the provided CassandraMetadata
+func newCassandraClusterConfig(meta cassandraMetadata) *gocql.ClusterConfig {
+	cluster := gocql.NewCluster(meta.contactPoints...)
 	cluster.ProtoVersion = meta.ProtocolVersion
-	cluster.Consistency = gocql.ParseConsistency(meta.Consistency)
+	cluster.Consistency = meta.consistency
+	if meta.LocalDC != "" {
+		// the queries go to the replicas of the local datacenter first, then to its other nodes
+		cluster.PoolConfig.HostSelectionPolicy = gocql.TokenAwareHostPolicy(gocql.DCAwareRoundRobinPolicy(meta.LocalDC))
+	}
 	cluster.Authenticator = gocql.PasswordAuthenticator{
 		Username: meta.Username,
 		Password: meta.Password,
@@ -180,8 +219,12 @@ func newCassandraSession(meta cassandraMetadata, logger logr.Logger) (*gocql.Ses
 			CaPath:   meta.CA,
 		}
 	}
+	return cluster
+}
 
-	session, err := cluster.CreateSession()
+// newCassandraSession returns a new Cassandra session for the provided CassandraMetadata
+func newCassandraSession(meta cassandraMetadata, logger logr.Logger) (*gocql.Session, error) {
+	session, err := newCassandraClusterConfig(meta).CreateSession()
 	if err != nil {
 		logger.Error(err, "found error creating session")
 		return nil, err
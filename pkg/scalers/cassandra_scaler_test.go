@@ -159,6 +159,59 @@ var testCassandraMetadata = []parseCassandraMetadataTestData{
 		authParams: map[string]string{"password": "Y2Fzc2FuZHJhCg=="},
 		isError:    false,
 	},
+	{
+		name: "multiple clusterIPs",
+		metadata: map[string]string{
+			"query":            "SELECT COUNT(*) FROM test_keyspace.test_table;",
+			"targetQueryValue": "1",
+			"username":         "cassandra",
+			"port":             "9042",
+			"clusterIPs":       "cassandra-0.test,cassandra-1.test:9043",
+			"localDC":          "eu-west-1",
+			"consistency":      "local_quorum",
+			"keyspace":         "test_keyspace",
+		},
+		authParams: map[string]string{"password": "Y2Fzc2FuZHJhCg=="},
+		isError:    false,
+	},
+	{
+		name: "clusterIPs without port",
+		metadata: map[string]string{
+			"query":            "SELECT COUNT(*) FROM test_keyspace.test_table;",
+			"targetQueryValue": "1",
+			"username":         "cassandra",
+			"clusterIPs":       "cassandra-0.test:9042,cassandra-1.test",
+			"keyspace":         "test_keyspace",
+		},
+		authParams: map[string]string{"password": "Y2Fzc2FuZHJhCg=="},
+		isError:    true,
+	},
+	{
+		name: "both clusterIPAddress and clusterIPs",
+		metadata: map[string]string{
+			"query":            "SELECT COUNT(*) FROM test_keyspace.test_table;",
+			"targetQueryValue": "1",
+			"username":         "cassandra",
+			"clusterIPAddress": "cassandra.test:9042",
+			"clusterIPs":       "cassandra-0.test:9042,cassandra-1.test:9042",
+			"keyspace":         "test_keyspace",
+		},
+		authParams: map[string]string{"password": "Y2Fzc2FuZHJhCg=="},
+		isError:    true,
+	},
+	{
+		name: "invalid consistency",
+		metadata: map[string]string{
+			"query":            "SELECT COUNT(*) FROM test_keyspace.test_table;",
+			"targetQueryValue": "1",
+			"username":         "cassandra",
+			"clusterIPAddress": "cassandra.test:9042",
+			"consistency":      "most",
+			"keyspace":         "test_keyspace",
+		},
+		authParams: map[string]string{"password": "Y2Fzc2FuZHJhCg=="},
+		isError:    true,
+	},
 }
 
 var tlsAuthParamsTestData = []parseCassandraTLSTestData{
@@ -317,3 +370,53 @@ func TestParseCassandraTLS(t *testing.T) {
 		})
 	}
 }
+
+func TestCassandraClusterConfig(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		metadata              map[string]string
+		expectedContactPoints []string
+		expectedConsistency   gocql.Consistency
+		expectedPolicy        gocql.HostSelectionPolicy
+	}{
+		{
+			name:                  "single clusterIPAddress",
+			metadata:              map[string]string{"clusterIPAddress": "cassandra.test", "port": "9042"},
+			expectedContactPoints: []string{"cassandra.test:9042"},
+			expectedConsistency:   gocql.One,
+		},
+		{
+			name:                  "multiple clusterIPs in the local datacenter",
+			metadata:              map[string]string{"clusterIPs": "cassandra-0.test, cassandra-1.test:9043", "port": "9042", "localDC": "eu-west-1", "consistency": "local_quorum"},
+			expectedContactPoints: []string{"cassandra-0.test:9042", "cassandra-1.test:9043"},
+			expectedConsistency:   gocql.LocalQuorum,
+			expectedPolicy:        gocql.TokenAwareHostPolicy(gocql.DCAwareRoundRobinPolicy("eu-west-1")),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata := map[string]string{
+				"query":            "SELECT COUNT(*) FROM test_keyspace.test_table;",
+				"targetQueryValue": "1",
+				"username":         "cassandra",
+				"keyspace":         "test_keyspace",
+			}
+			for key, value := range tc.metadata {
+				metadata[key] = value
+			}
+			meta, err := parseCassandraMetadata(&scalersconfig.ScalerConfig{
+				TriggerMetadata: metadata,
+				AuthParams:      map[string]string{"password": "Y2Fzc2FuZHJhCg=="},
+			})
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+
+			cluster := newCassandraClusterConfig(meta)
+			assert.Equal(t, tc.expectedContactPoints, cluster.Hosts)
+			assert.Equal(t, tc.expectedConsistency, cluster.Consistency)
+			assert.Equal(t, tc.expectedPolicy, cluster.PoolConfig.HostSelectionPolicy)
+			assert.Equal(t, "cassandra", cluster.Authenticator.(gocql.PasswordAuthenticator).Username)
+		})
+	}
+}
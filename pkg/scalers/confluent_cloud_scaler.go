@@ -0,0 +1,241 @@
+package scalers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	confluentCloudConsumerLagMetric = "io.confluent.kafka.server/consumer_lag_offsets"
+	// the points of the Metrics API are available after a couple of minutes,
+	// so the lag is read from the last minute which is complete
+	confluentCloudQueryInterval = "PT1M/now-2m|m"
+)
+
+// confluentCloudScaler scales on the consumer lag of a Kafka cluster of Confluent Cloud read from
+// the Confluent Cloud Metrics API, so the brokers don't have to be reachable from KEDA
+type confluentCloudScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *confluentCloudMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type confluentCloudMetadata struct {
+	triggerIndex int
+
+	ClusterID       string  `keda:"name=clusterId,       order=triggerMetadata"`
+	ConsumerGroup   string  `keda:"name=consumerGroup,   order=triggerMetadata"`
+	Topic           string  `keda:"name=topic,           order=triggerMetadata, optional"`
+	TargetValue     float64 `keda:"name=targetValue,     order=triggerMetadata, default=10"`
+	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, default=0"`
+	MetricsEndpoint string  `keda:"name=metricsEndpoint, order=triggerMetadata, default=https://api.telemetry.confluent.cloud"`
+
+	// Cloud API key with the MetricsViewer role
+	APIKey    string `keda:"name=apiKey,    order=authParams;resolvedEnv"`
+	APISecret string `keda:"name=apiSecret, order=authParams;resolvedEnv"`
+}
+
+func (m *confluentCloudMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return fmt.Errorf("targetValue must be positive")
+	}
+	if m.ActivationValue < 0 {
+		return fmt.Errorf("activationValue must not be negative")
+	}
+	return nil
+}
+
+type confluentCloudQueryFilter struct {
+	Field   string                      `json:"field,omitempty"`
+	Op      string                      `json:"op"`
+	Value   string                      `json:"value,omitempty"`
+	Filters []confluentCloudQueryFilter `json:"filters,omitempty"`
+}
+
+type confluentCloudQuery struct {
+	Aggregations []confluentCloudAggregation `json:"aggregations"`
+	Filter       confluentCloudQueryFilter   `json:"filter"`
+	Granularity  string                      `json:"granularity"`
+	Intervals    []string                    `json:"intervals"`
+	GroupBy      []string                    `json:"group_by"`
+	Limit        int                         `json:"limit"`
+}
+
+type confluentCloudAggregation struct {
+	Metric string `json:"metric"`
+}
+
+type confluentCloudQueryResponse struct {
+	Data []struct {
+		Timestamp string  `json:"timestamp"`
+		Value     float64 `json:"value"`
+		Topic     string  `json:"metric.topic"`
+	} `json:"data"`
+	Errors []struct {
+		Status string `json:"status"`
+		Detail string `json:"detail"`
+	} `json:"errors"`
+}
+
+// NewConfluentCloudScaler creates a new Confluent Cloud scaler
+func NewConfluentCloudScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseConfluentCloudMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Confluent Cloud metadata: %w", err)
+	}
+
+	return &confluentCloudScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		logger:     InitializeLogger(config, "confluent_cloud_scaler"),
+	}, nil
+}
+
+func parseConfluentCloudMetadata(config *scalersconfig.ScalerConfig) (*confluentCloudMetadata, error) {
+	meta := &confluentCloudMetadata{}
+	meta.triggerIndex = config.TriggerIndex
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing confluent cloud metadata: %w", err)
+	}
+	return meta, nil
+}
+
+// newConfluentCloudLagQuery returns the query of the consumer lag of the consumer group per topic
+func newConfluentCloudLagQuery(meta *confluentCloudMetadata) confluentCloudQuery {
+	filters := []confluentCloudQueryFilter{
+		{Field: "resource.kafka.id", Op: "EQ", Value: meta.ClusterID},
+		{Field: "metric.consumer_group_id", Op: "EQ", Value: meta.ConsumerGroup},
+	}
+	if meta.Topic != "" {
+		filters = append(filters, confluentCloudQueryFilter{Field: "metric.topic", Op: "EQ", Value: meta.Topic})
+	}
+
+	return confluentCloudQuery{
+		Aggregations: []confluentCloudAggregation{{Metric: confluentCloudConsumerLagMetric}},
+		Filter:       confluentCloudQueryFilter{Op: "AND", Filters: filters},
+		Granularity:  "PT1M",
+		Intervals:    []string{confluentCloudQueryInterval},
+		GroupBy:      []string{"metric.topic"},
+		Limit:        1000,
+	}
+}
+
+// getConsumerLag returns the consumer lag of the consumer group over its topics
+func (s *confluentCloudScaler) getConsumerLag(ctx context.Context) (float64, error) {
+	body, err := json.Marshal(newConfluentCloudLagQuery(s.metadata))
+	if err != nil {
+		return 0, err
+	}
+
+	u := fmt.Sprintf("%s/v2/metrics/cloud/query", strings.TrimSuffix(s.metadata.MetricsEndpoint, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(s.metadata.APIKey, s.metadata.APISecret)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error sending request to the Confluent Cloud Metrics API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	return parseConfluentCloudConsumerLag(resp.StatusCode, respBody)
+}
+
+// parseConfluentCloudConsumerLag sums the lag of the topics of a query response, only the latest point
+// of each topic being used
+func parseConfluentCloudConsumerLag(statusCode int, body []byte) (float64, error) {
+	var queryResp confluentCloudQueryResponse
+	if err := json.Unmarshal(body, &queryResp); err != nil {
+		if statusCode != http.StatusOK {
+			return 0, fmt.Errorf("confluent cloud metrics api returned status %d: %s", statusCode, string(body))
+		}
+		return 0, fmt.Errorf("error parsing confluent cloud metrics api response: %w", err)
+	}
+	if len(queryResp.Errors) > 0 {
+		details := make([]string, 0, len(queryResp.Errors))
+		for _, e := range queryResp.Errors {
+			details = append(details, e.Detail)
+		}
+		return 0, fmt.Errorf("confluent cloud metrics api returned status %d: %s", statusCode, strings.Join(details, "; "))
+	}
+	if statusCode != http.StatusOK {
+		return 0, fmt.Errorf("confluent cloud metrics api returned status %d: %s", statusCode, string(body))
+	}
+
+	// the timestamps are RFC 3339 in UTC, so they're ordered as strings
+	latest := make(map[string]int, len(queryResp.Data))
+	for i, point := range queryResp.Data {
+		if j, found := latest[point.Topic]; !found || point.Timestamp > queryResp.Data[j].Timestamp {
+			latest[point.Topic] = i
+		}
+	}
+	lag := float64(0)
+	for _, i := range latest {
+		lag += queryResp.Data[i].Value
+	}
+	return lag, nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *confluentCloudScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := fmt.Sprintf("confluent-cloud-%s-%s", s.metadata.ClusterID, s.metadata.ConsumerGroup)
+	if s.metadata.Topic != "" {
+		metricName = fmt.Sprintf("%s-%s", metricName, s.metadata.Topic)
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
+func (s *confluentCloudScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	lag, err := s.getConsumerLag(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting Confluent Cloud consumer lag: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, lag)
+
+	return []external_metrics.ExternalMetricValue{metric}, lag > s.metadata.ActivationValue, nil
+}
+
+// Close closes the http client connection.
+func (s *confluentCloudScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+const confluentCloudLagResponse = `{
+  "data": [
+    {"timestamp": "2024-05-02T10:14:00Z", "value": 120.0, "metric.topic": "orders"},
+    {"timestamp": "2024-05-02T10:15:00Z", "value": 100.0, "metric.topic": "orders"},
+    {"timestamp": "2024-05-02T10:15:00Z", "value": 25.0, "metric.topic": "payments"}
+  ],
+  "meta": {"pagination": {"page_size": 1000}}
+}`
+
+const confluentCloudEmptyResponse = `{"data": [], "meta": {"pagination": {"page_size": 1000}}}`
+
+const confluentCloudErrorResponse = `{
+  "errors": [{"id": "4c1a3e9d", "status": "400", "detail": "Invalid filter: resource.kafka.id"}]
+}`
+
+type parseConfluentCloudMetadataTestData struct {
+	name       string
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+var confluentCloudAuthParams = map[string]string{"apiKey": "key", "apiSecret": "secret"}
+
+var testConfluentCloudMetadata = []parseConfluentCloudMetadataTestData{
+	{"properly formed metadata", map[string]string{"clusterId": "lkc-abc123", "consumerGroup": "my-group", "topic": "orders", "targetValue": "50", "activationValue": "5"}, confluentCloudAuthParams, false},
+	{"all topics of the consumer group", map[string]string{"clusterId": "lkc-abc123", "consumerGroup": "my-group"}, confluentCloudAuthParams, false},
+	{"custom metrics endpoint", map[string]string{"clusterId": "lkc-abc123", "consumerGroup": "my-group", "metricsEndpoint": "http://localhost:8080"}, confluentCloudAuthParams, false},
+	{"no clusterId", map[string]string{"consumerGroup": "my-group"}, confluentCloudAuthParams, true},
+	{"no consumerGroup", map[string]string{"clusterId": "lkc-abc123"}, confluentCloudAuthParams, true},
+	{"no apiKey", map[string]string{"clusterId": "lkc-abc123", "consumerGroup": "my-group"}, map[string]string{"apiSecret": "secret"}, true},
+	{"no apiSecret", map[string]string{"clusterId": "lkc-abc123", "consumerGroup": "my-group"}, map[string]string{"apiKey": "key"}, true},
+	{"targetValue isn't positive", map[string]string{"clusterId": "lkc-abc123", "consumerGroup": "my-group", "targetValue": "0"}, confluentCloudAuthParams, true},
+	{"activationValue is negative", map[string]string{"clusterId": "lkc-abc123", "consumerGroup": "my-group", "activationValue": "-1"}, confluentCloudAuthParams, true},
+}
+
+func TestParseConfluentCloudMetadata(t *testing.T) {
+	for _, testData := range testConfluentCloudMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			_, err := parseConfluentCloudMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseConfluentCloudMetadataDefaults(t *testing.T) {
+	meta, err := parseConfluentCloudMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testConfluentCloudMetadata[1].metadata, AuthParams: confluentCloudAuthParams})
+	require.NoError(t, err)
+	assert.Equal(t, float64(10), meta.TargetValue)
+	assert.Equal(t, float64(0), meta.ActivationValue)
+	assert.Equal(t, "https://api.telemetry.confluent.cloud", meta.MetricsEndpoint)
+}
+
+type confluentCloudMetricIdentifier struct {
+	metadataTestData *parseConfluentCloudMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var confluentCloudMetricIdentifiers = []confluentCloudMetricIdentifier{
+	{&testConfluentCloudMetadata[0], 0, "s0-confluent-cloud-lkc-abc123-my-group-orders"},
+	{&testConfluentCloudMetadata[1], 1, "s1-confluent-cloud-lkc-abc123-my-group"},
+}
+
+func TestConfluentCloudGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range confluentCloudMetricIdentifiers {
+		meta, err := parseConfluentCloudMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		require.NoError(t, err)
+		mockScaler := confluentCloudScaler{metadata: meta}
+
+		metricSpec := mockScaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+func TestParseConfluentCloudConsumerLag(t *testing.T) {
+	testCases := []struct {
+		name        string
+		statusCode  int
+		body        string
+		expectedLag float64
+		expectedErr string
+	}{
+		{name: "latest point of each topic", statusCode: http.StatusOK, body: confluentCloudLagResponse, expectedLag: 125},
+		{name: "no lag reported", statusCode: http.StatusOK, body: confluentCloudEmptyResponse, expectedLag: 0},
+		{name: "error response", statusCode: http.StatusBadRequest, body: confluentCloudErrorResponse, expectedErr: "confluent cloud metrics api returned status 400: Invalid filter: resource.kafka.id"},
+		{name: "unauthorized", statusCode: http.StatusUnauthorized, body: "Unauthorized", expectedErr: "confluent cloud metrics api returned status 401: Unauthorized"},
+		{name: "invalid json", statusCode: http.StatusOK, body: "{", expectedErr: "error parsing confluent cloud metrics api response: unexpected end of JSON input"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lag, err := parseConfluentCloudConsumerLag(tc.statusCode, []byte(tc.body))
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedLag, lag)
+		})
+	}
+}
+
+func TestConfluentCloudGetMetricsAndActivity(t *testing.T) {
+	var query confluentCloudQuery
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if r.URL.Path != "/v2/metrics/cloud/query" || !ok || username != "key" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(confluentCloudLagResponse))
+	}))
+	defer server.Close()
+
+	scaler, err := NewConfluentCloudScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"clusterId": "lkc-abc123", "consumerGroup": "my-group", "topic": "orders", "activationValue": "200", "metricsEndpoint": server.URL},
+		AuthParams:      confluentCloudAuthParams,
+	})
+	require.NoError(t, err)
+
+	metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-confluent-cloud")
+	require.NoError(t, err)
+	assert.Equal(t, float64(125), metrics[0].Value.AsApproximateFloat64())
+	assert.False(t, active)
+
+	assert.Equal(t, confluentCloudConsumerLagMetric, query.Aggregations[0].Metric)
+	assert.Equal(t, []string{"metric.topic"}, query.GroupBy)
+	assert.Equal(t, []confluentCloudQueryFilter{
+		{Field: "resource.kafka.id", Op: "EQ", Value: "lkc-abc123"},
+		{Field: "metric.consumer_group_id", Op: "EQ", Value: "my-group"},
+		{Field: "metric.topic", Op: "EQ", Value: "orders"},
+	}, query.Filter.Filters)
+}
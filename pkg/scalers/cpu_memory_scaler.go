@@ -8,29 +8,47 @@ import (
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
 
 type cpuMemoryScaler struct {
 	metadata     cpuMemoryMetadata
 	resourceName v1.ResourceName
+	kubeClient   client.Client
 	logger       logr.Logger
 }
 
 type cpuMemoryMetadata struct {
-	Type               string `keda:"name=type,          order=triggerMetadata, enum=Utilization;AverageValue, optional, deprecatedAnnounce=The 'type' setting is DEPRECATED and will be removed in v2.18 - Use 'metricType' instead."`
-	Value              string `keda:"name=value,         order=triggerMetadata"`
-	ContainerName      string `keda:"name=containerName, order=triggerMetadata, optional"`
+	Type               string `keda:"name=type,            order=triggerMetadata, enum=Utilization;AverageValue, optional, deprecatedAnnounce=The 'type' setting is DEPRECATED and will be removed in v2.18 - Use 'metricType' instead."`
+	Value              string `keda:"name=value,           order=triggerMetadata"`
+	ActivationValue    string `keda:"name=activationValue, order=triggerMetadata, optional"`
+	ContainerName      string `keda:"name=containerName,   order=triggerMetadata, optional"`
 	AverageValue       *resource.Quantity
 	AverageUtilization *int32
 	MetricType         v2.MetricTargetType
+
+	// activationThreshold is the activationValue as a utilization percentage or a quantity,
+	// nil when the trigger doesn't take part in the activation
+	activationThreshold *float64
+	scalableObjectName  string
+	scalableObjectType  string
+	namespace           string
 }
 
 // NewCPUMemoryScaler creates a new cpuMemoryScaler
-func NewCPUMemoryScaler(resourceName v1.ResourceName, config *scalersconfig.ScalerConfig) (Scaler, error) {
+//
+// The cpu/memory metrics are read by the HPA from the metrics server, so the trigger doesn't scale the workload itself.
+// With an activationValue the trigger takes part in the activation, being active while the current value reported
+// in the status of the HPA is above it. As the HPA only reports it while the workload has ready pods and refreshes it
+// every sync period, the trigger can keep the workload from being deactivated but never activate it from zero.
+func NewCPUMemoryScaler(kubeClient client.Client, resourceName v1.ResourceName, config *scalersconfig.ScalerConfig) (Scaler, error) {
 	logger := InitializeLogger(config, "cpu_memory_scaler")
 
 	meta, err := parseResourceMetadata(config)
@@ -41,6 +59,7 @@ func NewCPUMemoryScaler(resourceName v1.ResourceName, config *scalersconfig.Scal
 	scaler := &cpuMemoryScaler{
 		metadata:     meta,
 		resourceName: resourceName,
+		kubeClient:   kubeClient,
 		logger:       logger,
 	}
 
@@ -84,9 +103,40 @@ func parseResourceMetadata(config *scalersconfig.ScalerConfig) (cpuMemoryMetadat
 		return meta, fmt.Errorf("unknown metric type: %s, allowed values are 'Utilization' or 'AverageValue'", string(meta.MetricType))
 	}
 
+	if meta.ActivationValue != "" {
+		if config.ScalableObjectType == "ScaledJob" {
+			return meta, fmt.Errorf("activationValue isn't supported for ScaledJob")
+		}
+		activationThreshold, err := parseActivationThreshold(meta.MetricType, meta.ActivationValue)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing activationValue: %w", err)
+		}
+		meta.activationThreshold = &activationThreshold
+	}
+	meta.scalableObjectName = config.ScalableObjectName
+	meta.scalableObjectType = config.ScalableObjectType
+	meta.namespace = config.ScalableObjectNamespace
+
 	return meta, nil
 }
 
+// parseActivationThreshold parses the activationValue like the value, i.e. as a percentage for Utilization
+// and as a quantity for AverageValue
+func parseActivationThreshold(metricType v2.MetricTargetType, value string) (float64, error) {
+	if metricType == v2.UtilizationMetricType {
+		utilization, err := parseUtilization(value)
+		if err != nil {
+			return 0, err
+		}
+		return float64(*utilization), nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, err
+	}
+	return quantity.AsApproximateFloat64(), nil
+}
+
 func parseUtilization(value string) (*int32, error) {
 	valueNum, err := strconv.ParseInt(value, 10, 32)
 	if err != nil {
@@ -132,7 +182,69 @@ func (s *cpuMemoryScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSp
 	return []v2.MetricSpec{metricSpec}
 }
 
-// GetMetricsAndActivity no need for cpu/memory scaler and always active for cpu/memory scaler
-func (s *cpuMemoryScaler) GetMetricsAndActivity(_ context.Context, _ string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	return nil, true, nil
+// GetMetricsAndActivity returns the current value of the resource reported by the HPA, the trigger being inactive
+// when it has no activationValue
+func (s *cpuMemoryScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	if s.metadata.activationThreshold == nil {
+		return nil, false, nil
+	}
+
+	value, found, err := s.getCurrentValue(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting the current %s of the workload: %w", s.resourceName, err)
+	}
+	if !found {
+		return []external_metrics.ExternalMetricValue{}, false, nil
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return []external_metrics.ExternalMetricValue{metric}, value > *s.metadata.activationThreshold, nil
+}
+
+// getCurrentValue returns the current utilization or average value of the resource from the status of the HPA,
+// which isn't reported when the workload has no ready pods or the HPA hasn't been synced yet
+func (s *cpuMemoryScaler) getCurrentValue(ctx context.Context) (float64, bool, error) {
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	err := s.kubeClient.Get(ctx, types.NamespacedName{Name: s.metadata.scalableObjectName, Namespace: s.metadata.namespace}, scaledObject)
+	if err != nil {
+		return 0, false, err
+	}
+	if scaledObject.Status.HpaName == "" {
+		return 0, false, nil
+	}
+
+	hpa := &v2.HorizontalPodAutoscaler{}
+	err = s.kubeClient.Get(ctx, types.NamespacedName{Name: scaledObject.Status.HpaName, Namespace: s.metadata.namespace}, hpa)
+	if errors.IsNotFound(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	for _, metric := range hpa.Status.CurrentMetrics {
+		var current v2.MetricValueStatus
+		switch {
+		case s.metadata.ContainerName == "" && metric.Resource != nil && metric.Resource.Name == s.resourceName:
+			current = metric.Resource.Current
+		case s.metadata.ContainerName != "" && metric.ContainerResource != nil && metric.ContainerResource.Name == s.resourceName &&
+			metric.ContainerResource.Container == s.metadata.ContainerName:
+			current = metric.ContainerResource.Current
+		default:
+			continue
+		}
+
+		if s.metadata.MetricType == v2.UtilizationMetricType {
+			if current.AverageUtilization == nil {
+				return 0, false, nil
+			}
+			return float64(*current.AverageUtilization), true, nil
+		}
+		if current.AverageValue == nil {
+			return 0, false, nil
+		}
+		return current.AverageValue.AsApproximateFloat64(), true, nil
+	}
+	return 0, false, nil
 }
@@ -7,7 +7,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	v2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
 
@@ -39,6 +46,10 @@ var testCPUMemoryMetadata = []parseCPUMemoryMetadataTestData{
 	{v2.ValueMetricType, map[string]string{"value": "50"}, true},
 	{"", map[string]string{"type": "AverageValue"}, true},
 	{"", map[string]string{"type": "xxx", "value": "50"}, true},
+	{v2.UtilizationMetricType, map[string]string{"value": "50", "activationValue": "10"}, false},
+	{v2.UtilizationMetricType, map[string]string{"value": "50", "activationValue": "10%"}, true},
+	{v2.AverageValueMetricType, map[string]string{"value": "500m", "activationValue": "100m"}, false},
+	{v2.AverageValueMetricType, map[string]string{"value": "500m", "activationValue": "a lot"}, true},
 }
 
 func TestCPUMemoryParseMetadata(t *testing.T) {
@@ -62,7 +73,7 @@ func TestGetMetricSpecForScaling(t *testing.T) {
 	config := &scalersconfig.ScalerConfig{
 		TriggerMetadata: validCPUMemoryMetadata,
 	}
-	scaler, _ := NewCPUMemoryScaler(v1.ResourceCPU, config)
+	scaler, _ := NewCPUMemoryScaler(nil, v1.ResourceCPU, config)
 	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
 
 	assert.Equal(t, metricSpec[0].Type, v2.ResourceMetricSourceType)
@@ -74,7 +85,7 @@ func TestGetMetricSpecForScaling(t *testing.T) {
 		TriggerMetadata: map[string]string{"value": "50"},
 		MetricType:      v2.UtilizationMetricType,
 	}
-	scaler, _ = NewCPUMemoryScaler(v1.ResourceCPU, config)
+	scaler, _ = NewCPUMemoryScaler(nil, v1.ResourceCPU, config)
 	metricSpec = scaler.GetMetricSpecForScaling(context.Background())
 
 	assert.Equal(t, metricSpec[0].Type, v2.ResourceMetricSourceType)
@@ -87,7 +98,7 @@ func TestGetContainerMetricSpecForScaling(t *testing.T) {
 	config := &scalersconfig.ScalerConfig{
 		TriggerMetadata: validContainerCPUMemoryMetadata,
 	}
-	scaler, _ := NewCPUMemoryScaler(v1.ResourceCPU, config)
+	scaler, _ := NewCPUMemoryScaler(nil, v1.ResourceCPU, config)
 	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
 
 	assert.Equal(t, metricSpec[0].Type, v2.ContainerResourceMetricSourceType)
@@ -100,7 +111,7 @@ func TestGetContainerMetricSpecForScaling(t *testing.T) {
 		TriggerMetadata: map[string]string{"value": "50", "containerName": "bar"},
 		MetricType:      v2.UtilizationMetricType,
 	}
-	scaler, _ = NewCPUMemoryScaler(v1.ResourceCPU, config)
+	scaler, _ = NewCPUMemoryScaler(nil, v1.ResourceCPU, config)
 	metricSpec = scaler.GetMetricSpecForScaling(context.Background())
 
 	assert.Equal(t, metricSpec[0].Type, v2.ContainerResourceMetricSourceType)
@@ -108,3 +119,140 @@ func TestGetContainerMetricSpecForScaling(t *testing.T) {
 	assert.Equal(t, metricSpec[0].ContainerResource.Target.Type, v2.UtilizationMetricType)
 	assert.Equal(t, metricSpec[0].ContainerResource.Container, "bar")
 }
+
+func TestCPUMemoryActivationValueScaledJob(t *testing.T) {
+	config := &scalersconfig.ScalerConfig{
+		TriggerMetadata:    map[string]string{"value": "50", "activationValue": "10"},
+		MetricType:         v2.UtilizationMetricType,
+		ScalableObjectType: "ScaledJob",
+	}
+	_, err := parseResourceMetadata(config)
+	assert.Error(t, err)
+}
+
+func TestCPUMemoryGetMetricsAndActivity(t *testing.T) {
+	tests := []struct {
+		name           string
+		metricType     v2.MetricTargetType
+		metadata       map[string]string
+		currentMetrics []v2.MetricStatus
+		hpaName        string
+		expectedValue  *float64
+		expectedActive bool
+	}{
+		{
+			name:           "no activation value",
+			metricType:     v2.UtilizationMetricType,
+			metadata:       map[string]string{"value": "50"},
+			currentMetrics: []v2.MetricStatus{newResourceMetricStatus(v1.ResourceCPU, ptr.To[int32](80), nil)},
+			hpaName:        "keda-hpa-test",
+			expectedActive: false,
+		},
+		{
+			name:           "utilization above activation value",
+			metricType:     v2.UtilizationMetricType,
+			metadata:       map[string]string{"value": "50", "activationValue": "10"},
+			currentMetrics: []v2.MetricStatus{newResourceMetricStatus(v1.ResourceCPU, ptr.To[int32](30), nil)},
+			hpaName:        "keda-hpa-test",
+			expectedValue:  ptr.To(30.0),
+			expectedActive: true,
+		},
+		{
+			name:           "utilization below activation value",
+			metricType:     v2.UtilizationMetricType,
+			metadata:       map[string]string{"value": "50", "activationValue": "10"},
+			currentMetrics: []v2.MetricStatus{newResourceMetricStatus(v1.ResourceCPU, ptr.To[int32](5), nil)},
+			hpaName:        "keda-hpa-test",
+			expectedValue:  ptr.To(5.0),
+			expectedActive: false,
+		},
+		{
+			name:           "average value above activation value",
+			metricType:     v2.AverageValueMetricType,
+			metadata:       map[string]string{"value": "500m", "activationValue": "100m"},
+			currentMetrics: []v2.MetricStatus{newResourceMetricStatus(v1.ResourceCPU, nil, resource.NewMilliQuantity(250, resource.DecimalSI))},
+			hpaName:        "keda-hpa-test",
+			expectedValue:  ptr.To(0.25),
+			expectedActive: true,
+		},
+		{
+			name:           "other resource reported",
+			metricType:     v2.UtilizationMetricType,
+			metadata:       map[string]string{"value": "50", "activationValue": "10"},
+			currentMetrics: []v2.MetricStatus{newResourceMetricStatus(v1.ResourceMemory, ptr.To[int32](80), nil)},
+			hpaName:        "keda-hpa-test",
+			expectedActive: false,
+		},
+		{
+			name:       "container utilization above activation value",
+			metricType: v2.UtilizationMetricType,
+			metadata:   map[string]string{"value": "50", "activationValue": "10", "containerName": "app"},
+			currentMetrics: []v2.MetricStatus{{
+				Type: v2.ContainerResourceMetricSourceType,
+				ContainerResource: &v2.ContainerResourceMetricStatus{
+					Name:      v1.ResourceCPU,
+					Container: "app",
+					Current:   v2.MetricValueStatus{AverageUtilization: ptr.To[int32](20)},
+				},
+			}},
+			hpaName:        "keda-hpa-test",
+			expectedValue:  ptr.To(20.0),
+			expectedActive: true,
+		},
+		{
+			name:           "HPA not created yet",
+			metricType:     v2.UtilizationMetricType,
+			metadata:       map[string]string{"value": "50", "activationValue": "10"},
+			expectedActive: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testScheme := runtime.NewScheme()
+			assert.NoError(t, scheme.AddToScheme(testScheme))
+			assert.NoError(t, kedav1alpha1.AddToScheme(testScheme))
+
+			scaledObject := &kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Status:     kedav1alpha1.ScaledObjectStatus{HpaName: test.hpaName},
+			}
+			builder := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(scaledObject)
+			if test.hpaName != "" {
+				builder = builder.WithObjects(&v2.HorizontalPodAutoscaler{
+					ObjectMeta: metav1.ObjectMeta{Name: test.hpaName, Namespace: "default"},
+					Status:     v2.HorizontalPodAutoscalerStatus{CurrentMetrics: test.currentMetrics},
+				})
+			}
+
+			scaler, err := NewCPUMemoryScaler(builder.Build(), v1.ResourceCPU, &scalersconfig.ScalerConfig{
+				TriggerMetadata:         test.metadata,
+				MetricType:              test.metricType,
+				ScalableObjectName:      "test",
+				ScalableObjectNamespace: "default",
+				ScalableObjectType:      "ScaledObject",
+			})
+			assert.NoError(t, err)
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "cpu")
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedActive, isActive)
+			if test.expectedValue != nil {
+				assert.Len(t, metrics, 1)
+				assert.InDelta(t, *test.expectedValue, metrics[0].Value.AsApproximateFloat64(), 0.001)
+			} else {
+				assert.Empty(t, metrics)
+			}
+		})
+	}
+}
+
+func newResourceMetricStatus(name v1.ResourceName, averageUtilization *int32, averageValue *resource.Quantity) v2.MetricStatus {
+	return v2.MetricStatus{
+		Type: v2.ResourceMetricSourceType,
+		Resource: &v2.ResourceMetricStatus{
+			Name:    name,
+			Current: v2.MetricValueStatus{AverageUtilization: averageUtilization, AverageValue: averageValue},
+		},
+	}
+}
@@ -4,25 +4,32 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/robfig/cron/v3"
 	v2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
+	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 const (
 	cronMetricType = "External"
+
+	// cronDSTLookahead is how far the DST transitions are checked for ambiguous schedules
+	cronDSTLookahead = 366 * 24 * time.Hour
 )
 
 type cronScaler struct {
 	metricType    v2.MetricTargetType
 	metadata      cronMetadata
 	logger        logr.Logger
+	location      *time.Location
 	startSchedule cron.Schedule
 	endSchedule   cron.Schedule
 }
@@ -30,12 +37,16 @@ type cronScaler struct {
 type cronMetadata struct {
 	Start           string `keda:"name=start,           order=triggerMetadata"`
 	End             string `keda:"name=end,             order=triggerMetadata"`
-	Timezone        string `keda:"name=timezone,        order=triggerMetadata"`
+	Timezone        string `keda:"name=timezone,        order=triggerMetadata"` // IANA name, "Local" for the cluster timezone or "UTC"
 	DesiredReplicas int64  `keda:"name=desiredReplicas, order=triggerMetadata"`
 	TriggerIndex    int
 }
 
 func (m *cronMetadata) Validate() error {
+	if _, err := time.LoadLocation(m.Timezone); err != nil {
+		return fmt.Errorf("unable to load timezone: %w", err)
+	}
+
 	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
 	if _, err := parser.Parse(m.Start); err != nil {
 		return fmt.Errorf("error parsing start schedule: %w", err)
@@ -71,19 +82,104 @@ func NewCronScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 
 	startSchedule, _ := parser.Parse(meta.Start)
 	endSchedule, _ := parser.Parse(meta.End)
+	location, _ := time.LoadLocation(meta.Timezone)
 
-	return &cronScaler{
+	s := &cronScaler{
 		metricType:    metricType,
 		metadata:      meta,
 		logger:        InitializeLogger(config, "cron_scaler"),
+		location:      location,
 		startSchedule: startSchedule,
 		endSchedule:   endSchedule,
-	}, nil
+	}
+	s.warnAboutDSTTransitions(config, time.Now())
+	return s, nil
+}
+
+// cronDSTWarnings holds the DST warnings last emitted for each trigger, by TriggerUniqueKey, as the scalers
+// are built again on every rebuild of the scalers cache
+var cronDSTWarnings sync.Map
+
+// warnAboutDSTTransitions emits an event when the start or end schedule falls inside a DST gap or overlap,
+// unless the same warnings were already emitted for the trigger
+func (s *cronScaler) warnAboutDSTTransitions(config *scalersconfig.ScalerConfig, now time.Time) {
+	schedules := []struct {
+		name     string
+		spec     string
+		schedule cron.Schedule
+	}{
+		{"start", s.metadata.Start, s.startSchedule},
+		{"end", s.metadata.End, s.endSchedule},
+	}
+	var messages []string
+	for _, schedule := range schedules {
+		ambiguity := getCronDSTAmbiguity(s.location, schedule.schedule, now)
+		if ambiguity == "" {
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("Scaler cron warning: %s schedule %q %s", schedule.name, schedule.spec, ambiguity))
+	}
+
+	if len(messages) == 0 {
+		cronDSTWarnings.Delete(config.TriggerUniqueKey)
+		return
+	}
+	warnings := strings.Join(messages, "\n")
+	if previous, loaded := cronDSTWarnings.Swap(config.TriggerUniqueKey, warnings); loaded && previous == warnings {
+		return
+	}
+	for _, message := range messages {
+		s.logger.Info(message)
+		if config.Recorder != nil && config.ScaledObject != nil {
+			config.Recorder.Event(config.ScaledObject, corev1.EventTypeWarning, eventreason.KEDAScalersWarning, message)
+		}
+	}
 }
 
-func getCronTime(location *time.Location, schedule cron.Schedule) time.Time {
-	// Use the pre-parsed cron schedule directly to get the next time
-	return schedule.Next(time.Now().In(location))
+// getCronWallClock returns the wall clock of t as a UTC time, so the schedules are evaluated without DST.
+// During the hour repeated when the clocks go back, the wall clock is frozen to the end of its first pass,
+// so a window inside the overlap isn't activated twice.
+func getCronWallClock(t time.Time) time.Time {
+	if start, _ := t.ZoneBounds(); !start.IsZero() {
+		_, offset := t.Zone()
+		_, previousOffset := start.Add(-time.Nanosecond).Zone()
+		if previousOffset > offset && t.Before(start.Add(time.Duration(previousOffset-offset)*time.Second)) {
+			t = start.Add(-time.Nanosecond)
+		}
+	}
+	return asCronWallClock(t)
+}
+
+func asCronWallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// getCronDSTAmbiguity describes the first DST transition of the coming year whose skipped or repeated
+// wall clock times contain an activation of the schedule, or returns an empty string if there is none
+func getCronDSTAmbiguity(location *time.Location, schedule cron.Schedule, now time.Time) string {
+	t := now.In(location)
+	for {
+		_, transition := t.ZoneBounds()
+		if transition.IsZero() || transition.After(now.Add(cronDSTLookahead)) {
+			return ""
+		}
+		_, previousOffset := transition.Add(-time.Nanosecond).Zone()
+		_, offset := transition.Zone()
+		shift := time.Duration(offset-previousOffset) * time.Second
+		newWallClock := asCronWallClock(transition)
+		oldWallClock := newWallClock.Add(-shift)
+
+		// the wall clock jumps forward over a gap, or back over an overlap
+		from, to, kind := oldWallClock, newWallClock, "is skipped"
+		if shift < 0 {
+			from, to, kind = newWallClock, oldWallClock, "happens twice"
+		}
+		if next := schedule.Next(from.Add(-time.Second)); !next.IsZero() && next.Before(to) {
+			return fmt.Sprintf("falls inside the DST transition of %s on %s: the local time %s %s",
+				location, transition.Format(time.DateOnly), next.Format(time.TimeOnly), kind)
+		}
+		t = transition
+	}
 }
 
 func parseCronMetadata(config *scalersconfig.ScalerConfig) (cronMetadata, error) {
@@ -119,27 +215,17 @@ func (s *cronScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	return []v2.MetricSpec{metricSpec}
 }
 
-func (s *cronScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	location, err := time.LoadLocation(s.metadata.Timezone)
-	if err != nil {
-		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("unable to load timezone: %w", err)
-	}
-
-	currentTime := time.Now().In(location)
-
-	// Use the pre-parsed schedules to get the next start and end times
-	nextStartTime := getCronTime(location, s.startSchedule)
-	nextEndTime := getCronTime(location, s.endSchedule)
-
-	isWithinInterval := false
+// isWithinInterval checks if the time is between an activation of the start schedule and the following
+// activation of the end schedule, which is the case when the end schedule is the next one to activate
+func (s *cronScaler) isWithinInterval(now time.Time) bool {
+	wallClock := getCronWallClock(now.In(s.location))
+	nextStartTime := s.startSchedule.Next(wallClock)
+	nextEndTime := s.endSchedule.Next(wallClock)
+	return !nextStartTime.Before(nextEndTime)
+}
 
-	if nextStartTime.Before(nextEndTime) {
-		// Interval within the same day
-		isWithinInterval = currentTime.After(nextStartTime) && currentTime.Before(nextEndTime)
-	} else {
-		// Interval spans midnight
-		isWithinInterval = currentTime.After(nextStartTime) || currentTime.Before(nextEndTime)
-	}
+func (s *cronScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	isWithinInterval := s.isWithinInterval(time.Now())
 
 	metricValue := float64(1)
 	if isWithinInterval {
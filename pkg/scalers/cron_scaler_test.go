@@ -8,7 +8,9 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/robfig/cron/v3"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/record"
 
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
 
@@ -142,3 +144,100 @@ func TestCronGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func newDSTTestCronScaler(t *testing.T, start, end string) *cronScaler {
+	scaler, err := NewCronScaler(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{
+		"timezone":        "America/New_York",
+		"start":           start,
+		"end":             end,
+		"desiredReplicas": "10",
+	}})
+	if err != nil {
+		t.Fatal("Could not create scaler:", err)
+	}
+	return scaler.(*cronScaler)
+}
+
+func TestCronIsWithinIntervalSpringForward(t *testing.T) {
+	// on 2024-03-10 the clocks go from 02:00 EST (07:00 UTC) to 03:00 EDT, skipping 02:30
+	scaler := newDSTTestCronScaler(t, "30 2 * * *", "30 3 * * *")
+
+	assert.False(t, scaler.isWithinInterval(time.Date(2024, 3, 10, 6, 50, 0, 0, time.UTC)), "01:50 EST")
+	assert.True(t, scaler.isWithinInterval(time.Date(2024, 3, 10, 7, 0, 0, 0, time.UTC)), "03:00 EDT")
+	assert.True(t, scaler.isWithinInterval(time.Date(2024, 3, 10, 7, 20, 0, 0, time.UTC)), "03:20 EDT")
+	assert.False(t, scaler.isWithinInterval(time.Date(2024, 3, 10, 7, 40, 0, 0, time.UTC)), "03:40 EDT")
+	assert.True(t, scaler.isWithinInterval(time.Date(2024, 3, 11, 6, 40, 0, 0, time.UTC)), "02:40 EDT the next day")
+}
+
+func TestCronIsWithinIntervalFallBack(t *testing.T) {
+	// on 2024-11-03 the clocks go from 02:00 EDT (06:00 UTC) back to 01:00 EST, repeating 01:30
+	scaler := newDSTTestCronScaler(t, "30 1 * * *", "45 1 * * *")
+
+	assert.False(t, scaler.isWithinInterval(time.Date(2024, 11, 3, 5, 20, 0, 0, time.UTC)), "01:20 EDT")
+	assert.True(t, scaler.isWithinInterval(time.Date(2024, 11, 3, 5, 35, 0, 0, time.UTC)), "01:35 EDT")
+	assert.False(t, scaler.isWithinInterval(time.Date(2024, 11, 3, 5, 50, 0, 0, time.UTC)), "01:50 EDT")
+	assert.False(t, scaler.isWithinInterval(time.Date(2024, 11, 3, 6, 35, 0, 0, time.UTC)), "01:35 EST")
+	assert.False(t, scaler.isWithinInterval(time.Date(2024, 11, 3, 7, 0, 0, 0, time.UTC)), "02:00 EST")
+
+	// a window covering the repeated hour stays active during both passes
+	scaler = newDSTTestCronScaler(t, "0 1 * * *", "0 2 * * *")
+	assert.True(t, scaler.isWithinInterval(time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC)), "01:30 EDT")
+	assert.True(t, scaler.isWithinInterval(time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC)), "01:30 EST")
+	assert.False(t, scaler.isWithinInterval(time.Date(2024, 11, 3, 7, 1, 0, 0, time.UTC)), "02:01 EST")
+}
+
+func TestGetCronDSTAmbiguity(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		location *time.Location
+		schedule string
+		expected string
+	}{
+		{"spring forward gap", newYork, "30 2 * * *", "falls inside the DST transition of America/New_York on 2024-03-10: the local time 02:30:00 is skipped"},
+		{"fall back overlap", newYork, "30 1 * * *", "falls inside the DST transition of America/New_York on 2024-11-03: the local time 01:30:00 happens twice"},
+		{"outside the transitions", newYork, "0 3 * * *", ""},
+		{"not on the day of the transition", newYork, "30 2 * * Mon-Sat", ""},
+		{"no DST", time.UTC, "30 2 * * *", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := parser.Parse(tc.schedule)
+			if err != nil {
+				t.Fatal("Could not parse schedule:", err)
+			}
+			assert.Equal(t, tc.expected, getCronDSTAmbiguity(tc.location, schedule, from))
+		})
+	}
+}
+
+func TestCronScalerWarnsAboutDSTTransitions(t *testing.T) {
+	recorder := record.NewFakeRecorder(2)
+	newScaler := func(start string) {
+		_, err := NewCronScaler(&scalersconfig.ScalerConfig{
+			TriggerMetadata:  map[string]string{"timezone": "America/New_York", "start": start, "end": "0 4 * * *", "desiredReplicas": "10"},
+			TriggerUniqueKey: "ScaledObject-default-dst-0",
+			Recorder:         recorder,
+			ScaledObject:     &kedav1alpha1.ScaledObject{},
+		})
+		assert.NoError(t, err)
+	}
+
+	newScaler("30 2 * * *")
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, `Warning KEDAScalerWarning Scaler cron warning: start schedule "30 2 * * *" falls inside the DST transition of America/New_York`)
+
+	// the scalers cache is rebuilt, the warning isn't emitted again
+	newScaler("30 2 * * *")
+	assert.Len(t, recorder.Events, 0)
+
+	// the schedule is fixed, then broken again
+	newScaler("30 5 * * *")
+	assert.Len(t, recorder.Events, 0)
+	newScaler("30 2 * * *")
+	assert.Len(t, recorder.Events, 1)
+}
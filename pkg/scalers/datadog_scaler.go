@@ -27,6 +27,7 @@ type datadogScaler struct {
 	metadata             *datadogMetadata
 	apiClient            *datadog.APIClient
 	httpClient           *http.Client
+	httpClientKey        string
 	logger               logr.Logger
 	useClusterAgentProxy bool
 }
@@ -61,6 +62,7 @@ type datadogMetadata struct {
 	age                      int
 	timeWindowOffset         int
 	lastAvailablePointOffset int
+	monitorID                int64
 
 	// TriggerMetadata Common
 	hpaMetricName string
@@ -68,11 +70,22 @@ type datadogMetadata struct {
 	targetValue   float64
 	useFiller     bool
 	vType         v2.MetricTargetType
+	useHTTP2      bool
 }
 
 const maxString = "max"
 const avgString = "average"
 
+// datadogMonitorStateValues maps the overall states of a monitor to the metric value reported for them,
+// the states missing here mean the monitor state is unavailable
+var datadogMonitorStateValues = map[datadog.MonitorOverallStates]float64{
+	datadog.MONITOROVERALLSTATES_ALERT:   2,
+	datadog.MONITOROVERALLSTATES_WARN:    1,
+	datadog.MONITOROVERALLSTATES_OK:      0,
+	datadog.MONITOROVERALLSTATES_IGNORED: 0,
+	datadog.MONITOROVERALLSTATES_SKIPPED: 0,
+}
+
 var filter *regexp.Regexp
 
 func init() {
@@ -88,6 +101,7 @@ func NewDatadogScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (
 	var err error
 	var apiClient *datadog.APIClient
 	var httpClient *http.Client
+	var httpClientKey string
 
 	if val, ok := config.TriggerMetadata["useClusterAgentProxy"]; ok {
 		useClusterAgentProxy, err = strconv.ParseBool(val)
@@ -101,14 +115,17 @@ func NewDatadogScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (
 		if err != nil {
 			return nil, fmt.Errorf("error parsing Datadog metadata: %w", err)
 		}
-		httpClient = kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.unsafeSsl)
+		httpClientKey = httpClientCacheKey(config.GlobalHTTPTimeout, meta.unsafeSsl, meta.useHTTP2, meta.datadogMetricServiceURL, meta.bearerToken)
+		httpClient = sharedHTTPClients.acquire(httpClientKey, config.GlobalHTTPTimeout, meta.unsafeSsl, meta.useHTTP2)
 	} else {
 		meta, err = parseDatadogAPIMetadata(config, logger)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing Datadog metadata: %w", err)
 		}
-		apiClient, err = newDatadogAPIConnection(ctx, meta, config)
+		httpClientKey = httpClientCacheKey(config.GlobalHTTPTimeout, false, meta.useHTTP2, meta.datadogSite, meta.apiKey, meta.appKey)
+		apiClient, err = newDatadogAPIConnection(ctx, meta, sharedHTTPClients.acquire(httpClientKey, config.GlobalHTTPTimeout, false, meta.useHTTP2))
 		if err != nil {
+			sharedHTTPClients.release(httpClientKey)
 			return nil, fmt.Errorf("error establishing Datadog connection: %w", err)
 		}
 	}
@@ -117,6 +134,7 @@ func NewDatadogScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (
 		metadata:             meta,
 		apiClient:            apiClient,
 		httpClient:           httpClient,
+		httpClientKey:        httpClientKey,
 		logger:               logger,
 		useClusterAgentProxy: useClusterAgentProxy,
 	}, nil
@@ -142,6 +160,18 @@ func buildMetricURL(datadogClusterAgentURL, datadogMetricNamespace, datadogMetri
 	return fmt.Sprintf("%s/namespaces/%s/%s", datadogClusterAgentURL, datadogMetricNamespace, datadogMetricName)
 }
 
+// parseDatadogUseHTTP2 parses if the connections to Datadog are made over HTTP/2, which is the default
+func parseDatadogUseHTTP2(config *scalersconfig.ScalerConfig) (bool, error) {
+	if val, ok := config.TriggerMetadata["useHTTP2"]; ok {
+		useHTTP2, err := strconv.ParseBool(val)
+		if err != nil {
+			return false, fmt.Errorf("useHTTP2 parsing error %w", err)
+		}
+		return useHTTP2, nil
+	}
+	return true, nil
+}
+
 func parseDatadogAPIMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger) (*datadogMetadata, error) {
 	meta := datadogMetadata{}
 
@@ -189,7 +219,19 @@ func parseDatadogAPIMetadata(config *scalersconfig.ScalerConfig, logger logr.Log
 		meta.lastAvailablePointOffset = 0 // Default use the last point
 	}
 
-	if val, ok := config.TriggerMetadata["query"]; ok {
+	if val, ok := config.TriggerMetadata["monitorId"]; ok {
+		if _, ok := config.TriggerMetadata["query"]; ok {
+			return nil, fmt.Errorf("only one of query or monitorId should be given")
+		}
+		monitorID, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("monitorId parsing error %w", err)
+		}
+		if monitorID <= 0 {
+			return nil, fmt.Errorf("monitorId should be greater than 0")
+		}
+		meta.monitorID = monitorID
+	} else if val, ok := config.TriggerMetadata["query"]; ok {
 		_, err := parseDatadogQuery(val)
 
 		if err != nil {
@@ -197,7 +239,7 @@ func parseDatadogAPIMetadata(config *scalersconfig.ScalerConfig, logger logr.Log
 		}
 		meta.query = val
 	} else {
-		return nil, fmt.Errorf("no query given")
+		return nil, fmt.Errorf("no query or monitorId given")
 	}
 
 	if val, ok := config.TriggerMetadata["targetValue"]; ok {
@@ -292,6 +334,17 @@ func parseDatadogAPIMetadata(config *scalersconfig.ScalerConfig, logger logr.Log
 
 	meta.datadogSite = siteVal
 
+	useHTTP2, err := parseDatadogUseHTTP2(config)
+	if err != nil {
+		return nil, err
+	}
+	meta.useHTTP2 = useHTTP2
+
+	if meta.monitorID != 0 {
+		meta.hpaMetricName = GenerateMetricNameWithIndex(config.TriggerIndex, fmt.Sprintf("datadog-monitor-%d", meta.monitorID))
+		return &meta, nil
+	}
+
 	hpaMetricName := meta.query[0:strings.Index(meta.query, "{")]
 	meta.hpaMetricName = GenerateMetricNameWithIndex(config.TriggerIndex, kedautil.NormalizeString(fmt.Sprintf("datadog-%s", hpaMetricName)))
 
@@ -402,6 +455,12 @@ func parseDatadogClusterAgentMetadata(config *scalersconfig.ScalerConfig, logger
 		meta.vType = metricType
 	}
 
+	useHTTP2, err := parseDatadogUseHTTP2(config)
+	if err != nil {
+		return nil, err
+	}
+	meta.useHTTP2 = useHTTP2
+
 	authMode, ok := config.AuthParams["authMode"]
 	// no authMode specified
 	if !ok {
@@ -424,8 +483,8 @@ func parseDatadogClusterAgentMetadata(config *scalersconfig.ScalerConfig, logger
 	return &meta, nil
 }
 
-// newDatadogAPIConnection tests a connection to the Datadog API
-func newDatadogAPIConnection(ctx context.Context, meta *datadogMetadata, config *scalersconfig.ScalerConfig) (*datadog.APIClient, error) {
+// datadogAPIContext returns the context carrying the keys and the site used by the Datadog API client
+func datadogAPIContext(ctx context.Context, meta *datadogMetadata) context.Context {
 	ctx = context.WithValue(
 		ctx,
 		datadog.ContextAPIKeys,
@@ -439,14 +498,19 @@ func newDatadogAPIConnection(ctx context.Context, meta *datadogMetadata, config
 		},
 	)
 
-	ctx = context.WithValue(ctx,
+	return context.WithValue(ctx,
 		datadog.ContextServerVariables,
 		map[string]string{
 			"site": meta.datadogSite,
 		})
+}
+
+// newDatadogAPIConnection tests a connection to the Datadog API
+func newDatadogAPIConnection(ctx context.Context, meta *datadogMetadata, httpClient *http.Client) (*datadog.APIClient, error) {
+	ctx = datadogAPIContext(ctx, meta)
 
 	configuration := datadog.NewConfiguration()
-	configuration.HTTPClient = kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	configuration.HTTPClient = httpClient
 	apiClient := datadog.NewAPIClient(configuration)
 
 	_, _, err := apiClient.AuthenticationApi.Validate(ctx) //nolint:bodyclose
@@ -457,34 +521,18 @@ func newDatadogAPIConnection(ctx context.Context, meta *datadogMetadata, config
 	return apiClient, nil
 }
 
-// No need to close connections
+// Close releases the shared HTTP client, its connections are closed once no scaler uses it anymore
 func (s *datadogScaler) Close(context.Context) error {
-	if s.apiClient != nil {
-		s.apiClient.GetConfig().HTTPClient.CloseIdleConnections()
+	if s.httpClientKey != "" {
+		sharedHTTPClients.release(s.httpClientKey)
+		s.httpClientKey = ""
 	}
 	return nil
 }
 
 // getQueryResult returns result of the scaler query
 func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
-	ctx = context.WithValue(
-		ctx,
-		datadog.ContextAPIKeys,
-		map[string]datadog.APIKey{
-			"apiKeyAuth": {
-				Key: s.metadata.apiKey,
-			},
-			"appKeyAuth": {
-				Key: s.metadata.appKey,
-			},
-		},
-	)
-
-	ctx = context.WithValue(ctx,
-		datadog.ContextServerVariables,
-		map[string]string{
-			"site": s.metadata.datadogSite,
-		})
+	ctx = datadogAPIContext(ctx, s.metadata)
 
 	timeWindowTo := time.Now().Unix() - int64(s.metadata.timeWindowOffset)
 	timeWindowFrom := timeWindowTo - int64(s.metadata.age)
@@ -568,6 +616,34 @@ func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
 	}
 }
 
+// getMonitorStateValue returns the value the overall state of the scaler monitor maps to
+func (s *datadogScaler) getMonitorStateValue(ctx context.Context) (float64, error) {
+	ctx = datadogAPIContext(ctx, s.metadata)
+
+	monitor, r, err := s.apiClient.MonitorsApi.GetMonitor(ctx, s.metadata.monitorID) //nolint:bodyclose
+	if r != nil && r.StatusCode == 429 {
+		rateLimit := r.Header.Get("X-Ratelimit-Limit")
+		rateLimitReset := r.Header.Get("X-Ratelimit-Reset")
+		rateLimitPeriod := r.Header.Get("X-Ratelimit-Period")
+
+		return -1, fmt.Errorf("your Datadog account reached the %s queries per %s seconds rate limit, next limit reset will happen in %s seconds", rateLimit, rateLimitPeriod, rateLimitReset)
+	}
+	if err != nil {
+		return -1, fmt.Errorf("error when retrieving Datadog monitor %d: %w", s.metadata.monitorID, err)
+	}
+
+	state := monitor.GetOverallState()
+	value, ok := datadogMonitorStateValues[state]
+	if !ok {
+		if !s.metadata.useFiller {
+			return -1, fmt.Errorf("monitor %d is in state %q", s.metadata.monitorID, state)
+		}
+		return s.metadata.fillValue, nil
+	}
+
+	return value, nil
+}
+
 func (s *datadogScaler) getDatadogMetricValue(req *http.Request) (float64, error) {
 	resp, err := s.httpClient.Do(req)
 
@@ -664,7 +740,11 @@ func (s *datadogScaler) GetMetricsAndActivity(ctx context.Context, metricName st
 		metric = GenerateMetricInMili(metricName, num)
 		return []external_metrics.ExternalMetricValue{metric}, num > s.metadata.activationTargetValue, nil
 	}
-	num, err = s.getQueryResult(ctx)
+	if s.metadata.monitorID != 0 {
+		num, err = s.getMonitorStateValue(ctx)
+	} else {
+		num, err = s.getQueryResult(ctx)
+	}
 	if err != nil {
 		s.logger.Error(err, "error getting metrics from Datadog")
 		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting metrics from Datadog: %w", err)
@@ -2,9 +2,14 @@ package scalers
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	datadog "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
 	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
 	v2 "k8s.io/api/autoscaling/v2"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
@@ -163,6 +168,14 @@ var testDatadogAPIMetadata = []datadogAuthMetadataTestData{
 	{"", map[string]string{"query": "sum:trace.redis.command.hits{env:none,service:redis}.as_count()", "queryValue": "7"}, map[string]string{"apiKey": "apiKey"}, true},
 	// invalid query missing {
 	{"", map[string]string{"query": "sum:trace.redis.command.hits.as_count()", "queryValue": "7"}, map[string]string{}, true},
+	// monitor properly formed
+	{"", map[string]string{"monitorId": "12345", "queryValue": "1", "activationQueryValue": "1"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, false},
+	// both monitorId and query
+	{"", map[string]string{"monitorId": "12345", "query": "sum:trace.redis.command.hits{env:none,service:redis}.as_count()", "queryValue": "1"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// wrong monitorId type
+	{"", map[string]string{"monitorId": "notanint", "queryValue": "1"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// negative monitorId
+	{"", map[string]string{"monitorId": "-1", "queryValue": "1"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
 }
 
 func TestDatadogScalerAPIAuthParams(t *testing.T) {
@@ -195,6 +208,7 @@ var datadogMetricIdentifiers = []datadogMetricIdentifier{
 	{&testDatadogAPIMetadata[1], apiType, 0, "s0-datadog-sum-trace-redis-command-hits"},
 	{&testDatadogAPIMetadata[1], apiType, 1, "s1-datadog-sum-trace-redis-command-hits"},
 	{&testDatadogClusterAgentMetadata[1], clusterAgentType, 0, "datadogmetric@default:nginx-hits"},
+	{&testDatadogAPIMetadata[21], apiType, 2, "s2-datadog-monitor-12345"},
 }
 
 func TestDatadogGetMetricSpecForScaling(t *testing.T) {
@@ -240,3 +254,57 @@ func TestBuildMetricURL(t *testing.T) {
 		t.Error("Expected https://localhost:8080/apis/datadoghq.com/v1alpha1/namespaces/datadogMetricNamespace/datadogMetricName, got ", url)
 	}
 }
+
+func TestDatadogMonitorState(t *testing.T) {
+	testCases := []struct {
+		state                  datadog.MonitorOverallStates
+		metricUnavailableValue string
+		expected               float64
+		isActive               bool
+		isError                bool
+	}{
+		{state: datadog.MONITOROVERALLSTATES_ALERT, expected: 2, isActive: true},
+		{state: datadog.MONITOROVERALLSTATES_WARN, expected: 1, isActive: false},
+		{state: datadog.MONITOROVERALLSTATES_OK, expected: 0, isActive: false},
+		{state: datadog.MONITOROVERALLSTATES_IGNORED, expected: 0, isActive: false},
+		{state: datadog.MONITOROVERALLSTATES_NO_DATA, isError: true},
+		{state: datadog.MONITOROVERALLSTATES_NO_DATA, metricUnavailableValue: "2", expected: 2, isActive: true},
+		{state: datadog.MONITOROVERALLSTATES_UNKNOWN, metricUnavailableValue: "0", expected: 0, isActive: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s %s", tc.state, tc.metricUnavailableValue), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v1/monitor/12345", r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"id":12345,"type":"metric alert","query":"avg(last_5m):avg:system.load.1{*} > 2","overall_state":%q}`, tc.state)
+			}))
+			defer server.Close()
+
+			// only ALERT activates the scaler
+			metadata := map[string]string{"monitorId": "12345", "queryValue": "1", "activationQueryValue": "1"}
+			if tc.metricUnavailableValue != "" {
+				metadata["metricUnavailableValue"] = tc.metricUnavailableValue
+			}
+			meta, err := parseDatadogAPIMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{"apiKey": "apiKey", "appKey": "appKey"}}, logr.Discard())
+			assert.NoError(t, err)
+
+			configuration := datadog.NewConfiguration()
+			configuration.Servers = datadog.ServerConfigurations{{URL: server.URL}}
+			scaler := datadogScaler{
+				metadata:  meta,
+				apiClient: datadog.NewAPIClient(configuration),
+				logger:    logr.Discard(),
+			}
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "monitor")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, metrics[0].Value.AsApproximateFloat64())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	debeziumServerNameLabel     = "name"
+	debeziumPluginLabel         = "plugin"
+	debeziumContextLabel        = "context"
+	debeziumStreamingContextTag = "streaming"
+)
+
+// debeziumScaler scales on the lag of Debezium connectors, their MilliSecondsBehindSource streaming metric,
+// read from the metrics endpoint of the JMX exporter of the Kafka Connect workers
+type debeziumScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *debeziumMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type debeziumMetadata struct {
+	URL string `keda:"name=url, order=triggerMetadata"`
+	// ServerName is the topic prefix of the connector, the server label of its MBeans
+	ServerName string `keda:"name=serverName,      order=triggerMetadata, optional"`
+	Plugin     string `keda:"name=plugin,          order=triggerMetadata, optional"`
+	// MetricName defaults to the name the rules of the JMX exporter shipped with the Debezium images give
+	// to the MilliSecondsBehindSource attribute of the streaming MBean of the connectors
+	MetricName      string  `keda:"name=metricName,      order=triggerMetadata, default=debezium_metrics_MilliSecondsBehindSource"`
+	Value           float64 `keda:"name=value,           order=triggerMetadata"`
+	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, default=0"`
+	UnsafeSsl       bool    `keda:"name=unsafeSsl,       order=triggerMetadata, default=false"`
+
+	// Authentication
+	Username    string `keda:"name=username,    order=authParams, optional"`
+	Password    string `keda:"name=password,    order=authParams, optional"`
+	BearerToken string `keda:"name=bearerToken, order=authParams, optional"`
+
+	triggerIndex int
+}
+
+func (m *debeziumMetadata) Validate() error {
+	if m.Value <= 0 {
+		return fmt.Errorf("value must be greater than 0")
+	}
+	if m.ActivationValue < 0 {
+		return fmt.Errorf("activationValue must be greater than or equal to 0")
+	}
+	if m.Password != "" && m.Username == "" {
+		return fmt.Errorf("password requires username")
+	}
+	if m.Username != "" && m.BearerToken != "" {
+		return fmt.Errorf("username and bearerToken can't be used together")
+	}
+	return nil
+}
+
+// NewDebeziumScaler creates a new debezium scaler
+func NewDebeziumScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseDebeziumMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing debezium metadata: %w", err)
+	}
+
+	return &debeziumScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "debezium_scaler"),
+	}, nil
+}
+
+func parseDebeziumMetadata(config *scalersconfig.ScalerConfig) (*debeziumMetadata, error) {
+	meta := &debeziumMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// getDebeziumLag returns the highest lag in milliseconds of the connectors matching the server name and the plugin,
+// a connector which hasn't streamed any event yet reports -1 and counts as not lagging
+func getDebeziumLag(body []byte, meta *debeziumMetadata) (float64, error) {
+	// Ensure EOL
+	reader := strings.NewReader(strings.ReplaceAll(string(body), "\r\n", "\n"))
+	familiesParser := expfmt.TextParser{}
+	families, err := familiesParser.TextToMetricFamilies(reader)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing metrics: %w", err)
+	}
+	family, ok := families[meta.MetricName]
+	if !ok {
+		return 0, fmt.Errorf("metric '%s' not found", meta.MetricName)
+	}
+
+	var lag float64
+	var found bool
+	for _, metric := range family.GetMetric() {
+		labels := make(map[string]string, len(metric.GetLabel()))
+		for _, label := range metric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		if metricContext, ok := labels[debeziumContextLabel]; ok && metricContext != debeziumStreamingContextTag {
+			continue
+		}
+		if meta.ServerName != "" && labels[debeziumServerNameLabel] != meta.ServerName {
+			continue
+		}
+		if meta.Plugin != "" && labels[debeziumPluginLabel] != meta.Plugin {
+			continue
+		}
+
+		value, err := getDebeziumMetricValue(family.GetType(), metric)
+		if err != nil {
+			return 0, fmt.Errorf("metric '%s' %w", meta.MetricName, err)
+		}
+		if value > lag {
+			lag = value
+		}
+		found = true
+	}
+	if !found {
+		return 0, fmt.Errorf("no connector of metric '%s' matches the server name and plugin", meta.MetricName)
+	}
+	return lag, nil
+}
+
+func getDebeziumMetricValue(metricType dto.MetricType, metric *dto.Metric) (float64, error) {
+	switch metricType {
+	case dto.MetricType_GAUGE:
+		return metric.GetGauge().GetValue(), nil
+	case dto.MetricType_UNTYPED:
+		return metric.GetUntyped().GetValue(), nil
+	default:
+		return 0, fmt.Errorf("must be a gauge or untyped metric")
+	}
+}
+
+func (s *debeziumScaler) getLag(ctx context.Context) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.URL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", string(expfmt.NewFormat(expfmt.TypeTextPlain)))
+	switch {
+	case s.metadata.BearerToken != "":
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.metadata.BearerToken))
+	case s.metadata.Username != "":
+		req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error requesting metrics endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("metrics endpoint returned status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	return getDebeziumLag(body, s.metadata)
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *debeziumScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := "debezium-lag"
+	if s.metadata.ServerName != "" {
+		metricName = fmt.Sprintf("debezium-lag-%s", s.metadata.ServerName)
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the lag in milliseconds and the activity of the scaler
+func (s *debeziumScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	lag, err := s.getLag(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting debezium lag: %w", err)
+	}
+
+	s.logger.V(1).Info("Debezium lag", "serverName", s.metadata.ServerName, "lag", lag)
+
+	metric := GenerateMetricInMili(metricName, lag)
+
+	return []external_metrics.ExternalMetricValue{metric}, lag > s.metadata.ActivationValue, nil
+}
+
+// Close closes the http client connection
+func (s *debeziumScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+const debeziumSamplePayload = `# HELP debezium_metrics_MilliSecondsBehindSource The number of milliseconds between the last change event's timestamp and the connector processing it.
+# TYPE debezium_metrics_MilliSecondsBehindSource gauge
+debezium_metrics_MilliSecondsBehindSource{context="streaming",name="inventory",plugin="mysql",} 1500.0
+debezium_metrics_MilliSecondsBehindSource{context="streaming",name="orders",plugin="postgres",} 250.0
+debezium_metrics_MilliSecondsBehindSource{context="streaming",name="idle",plugin="postgres",} -1.0
+# HELP debezium_metrics_TotalNumberOfEventsSeen The total number of events seen.
+# TYPE debezium_metrics_TotalNumberOfEventsSeen counter
+debezium_metrics_TotalNumberOfEventsSeen{context="streaming",name="inventory",plugin="mysql",} 42000.0
+# HELP debezium_metrics_RemainingTableCount The number of tables left to snapshot.
+# TYPE debezium_metrics_RemainingTableCount gauge
+debezium_metrics_RemainingTableCount{context="snapshot",name="inventory",plugin="mysql",} 3.0
+# HELP connector_lag_ms The lag under a custom name.
+# TYPE connector_lag_ms untyped
+connector_lag_ms{name="inventory",} 900.0
+`
+
+type parseDebeziumMetadataTestData struct {
+	name       string
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+var testDebeziumMetadata = []parseDebeziumMetadataTestData{
+	{
+		name:     "server name",
+		metadata: map[string]string{"url": "http://connect:9404/metrics", "serverName": "inventory", "value": "5000", "activationValue": "1000"},
+	},
+	{
+		name:     "plugin and custom metric name",
+		metadata: map[string]string{"url": "http://connect:9404/metrics", "plugin": "postgres", "metricName": "connector_lag_ms", "value": "5000"},
+	},
+	{
+		name:       "basic auth",
+		metadata:   map[string]string{"url": "https://connect:9404/metrics", "value": "5000", "unsafeSsl": "true"},
+		authParams: map[string]string{"username": "user", "password": "pass"},
+	},
+	{
+		name:     "missing url",
+		metadata: map[string]string{"serverName": "inventory", "value": "5000"},
+		isError:  true,
+	},
+	{
+		name:     "missing value",
+		metadata: map[string]string{"url": "http://connect:9404/metrics", "serverName": "inventory"},
+		isError:  true,
+	},
+	{
+		name:     "invalid value",
+		metadata: map[string]string{"url": "http://connect:9404/metrics", "value": "0"},
+		isError:  true,
+	},
+	{
+		name:     "invalid activationValue",
+		metadata: map[string]string{"url": "http://connect:9404/metrics", "value": "5000", "activationValue": "-1"},
+		isError:  true,
+	},
+	{
+		name:       "basic and bearer auth",
+		metadata:   map[string]string{"url": "http://connect:9404/metrics", "value": "5000"},
+		authParams: map[string]string{"username": "user", "bearerToken": "token"},
+		isError:    true,
+	},
+}
+
+func TestParseDebeziumMetadata(t *testing.T) {
+	for _, testData := range testDebeziumMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			meta, err := parseDebeziumMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if testData.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if testData.metadata["metricName"] == "" {
+				assert.Equal(t, "debezium_metrics_MilliSecondsBehindSource", meta.MetricName)
+			}
+		})
+	}
+}
+
+func TestGetDebeziumLag(t *testing.T) {
+	testCases := []struct {
+		name       string
+		serverName string
+		plugin     string
+		metricName string
+		expected   float64
+		isError    bool
+	}{
+		{name: "highest lag of all connectors", expected: 1500},
+		{name: "server name", serverName: "orders", expected: 250},
+		{name: "plugin", plugin: "postgres", expected: 250},
+		{name: "connector without events", serverName: "idle", expected: 0},
+		{name: "custom metric name", metricName: "connector_lag_ms", serverName: "inventory", expected: 900},
+		{name: "unknown server name", serverName: "unknown", isError: true},
+		{name: "snapshot metric", metricName: "debezium_metrics_RemainingTableCount", isError: true},
+		{name: "counter", metricName: "debezium_metrics_TotalNumberOfEventsSeen", isError: true},
+		{name: "unknown metric", metricName: "debezium_metrics_Unknown", isError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			meta := &debeziumMetadata{ServerName: tc.serverName, Plugin: tc.plugin, MetricName: tc.metricName}
+			if meta.MetricName == "" {
+				meta.MetricName = "debezium_metrics_MilliSecondsBehindSource"
+			}
+
+			lag, err := getDebeziumLag([]byte(debeziumSamplePayload), meta)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tc.expected, lag, 0.0001)
+		})
+	}
+}
+
+func TestGetDebeziumLagInvalidPayload(t *testing.T) {
+	_, err := getDebeziumLag([]byte("not a metrics payload {"), &debeziumMetadata{MetricName: "debezium_metrics_MilliSecondsBehindSource"})
+	assert.Error(t, err)
+}
+
+func TestDebeziumGetMetricSpecForScaling(t *testing.T) {
+	testCases := []struct {
+		metadata     map[string]string
+		triggerIndex int
+		name         string
+	}{
+		{map[string]string{"url": "http://connect:9404/metrics", "serverName": "inventory", "value": "5000"}, 0, "s0-debezium-lag-inventory"},
+		{map[string]string{"url": "http://connect:9404/metrics", "value": "5000"}, 1, "s1-debezium-lag"},
+	}
+
+	for _, tc := range testCases {
+		meta, err := parseDebeziumMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, TriggerIndex: tc.triggerIndex})
+		require.NoError(t, err)
+
+		scaler := debeziumScaler{metadata: meta}
+		metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, tc.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+func TestDebeziumGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, debeziumSamplePayload)
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name            string
+		activationValue string
+		password        string
+		expectedValue   int64
+		isActive        bool
+		isError         bool
+	}{
+		{name: "active", activationValue: "1000", password: "pass", expectedValue: 1500, isActive: true},
+		{name: "inactive", activationValue: "1500", password: "pass", expectedValue: 1500, isActive: false},
+		{name: "unauthorized", activationValue: "0", password: "wrong", isError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scaler, err := NewDebeziumScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata: map[string]string{
+					"url":             server.URL,
+					"serverName":      "inventory",
+					"value":           "5000",
+					"activationValue": tc.activationValue,
+				},
+				AuthParams: map[string]string{"username": "user", "password": tc.password},
+			})
+			require.NoError(t, err)
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "metric")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"strconv"
 	"strings"
 
@@ -20,6 +22,11 @@ import (
 	"github.com/kedacore/keda/v2/pkg/util"
 )
 
+const (
+	elasticsearchModeSearch = "search"
+	elasticsearchModeCount  = "count"
+)
+
 type elasticsearchScaler struct {
 	metricType v2.MetricTargetType
 	metadata   elasticsearchMetadata
@@ -30,6 +37,7 @@ type elasticsearchScaler struct {
 type elasticsearchMetadata struct {
 	Addresses             []string `keda:"name=addresses,             order=authParams;triggerMetadata, optional"`
 	UnsafeSsl             bool     `keda:"name=unsafeSsl,             order=triggerMetadata, default=false"`
+	TLSServerName         string   `keda:"name=tlsServerName,         order=triggerMetadata, optional"`
 	Username              string   `keda:"name=username,              order=authParams;triggerMetadata, optional"`
 	Password              string   `keda:"name=password,              order=authParams;resolvedEnv;triggerMetadata, optional"`
 	CloudID               string   `keda:"name=cloudID,               order=authParams;triggerMetadata, optional"`
@@ -38,7 +46,8 @@ type elasticsearchMetadata struct {
 	SearchTemplateName    string   `keda:"name=searchTemplateName,    order=authParams;triggerMetadata, optional"`
 	Query                 string   `keda:"name=query,                 order=authParams;triggerMetadata, optional"`
 	Parameters            []string `keda:"name=parameters,            order=triggerMetadata, optional, separator=;"`
-	ValueLocation         string   `keda:"name=valueLocation,         order=authParams;triggerMetadata"`
+	Mode                  string   `keda:"name=mode,                  order=triggerMetadata, enum=search;count, default=search"`
+	ValueLocation         string   `keda:"name=valueLocation,         order=authParams;triggerMetadata, optional"`
 	TargetValue           float64  `keda:"name=targetValue,           order=authParams;triggerMetadata"`
 	ActivationTargetValue float64  `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
 	MetricName            string   `keda:"name=metricName,            order=triggerMetadata, optional"`
@@ -47,6 +56,10 @@ type elasticsearchMetadata struct {
 }
 
 func (m *elasticsearchMetadata) Validate() error {
+	return errors.Join(m.validateConnection(), m.validateQuery())
+}
+
+func (m *elasticsearchMetadata) validateConnection() error {
 	if (m.CloudID != "" || m.APIKey != "") && (len(m.Addresses) > 0 || m.Username != "" || m.Password != "") {
 		return fmt.Errorf("can't provide both cloud config and endpoint addresses")
 	}
@@ -59,12 +72,25 @@ func (m *elasticsearchMetadata) Validate() error {
 	if len(m.Addresses) > 0 && (m.Username == "" || m.Password == "") {
 		return fmt.Errorf("both username and password must be provided when addresses is used")
 	}
+	return nil
+}
+
+func (m *elasticsearchMetadata) validateQuery() error {
+	if m.Mode == elasticsearchModeCount {
+		if m.SearchTemplateName != "" {
+			return fmt.Errorf("searchTemplateName can't be used with mode %q", elasticsearchModeCount)
+		}
+		return nil
+	}
 	if m.SearchTemplateName == "" && m.Query == "" {
 		return fmt.Errorf("either searchTemplateName or query must be provided")
 	}
 	if m.SearchTemplateName != "" && m.Query != "" {
 		return fmt.Errorf("cannot provide both searchTemplateName and query")
 	}
+	if m.ValueLocation == "" {
+		return fmt.Errorf("missing required parameter \"valueLocation\"")
+	}
 
 	return nil
 }
@@ -102,9 +128,12 @@ func parseElasticsearchMetadata(config *scalersconfig.ScalerConfig) (elasticsear
 		return meta, err
 	}
 
-	if meta.SearchTemplateName != "" {
+	switch {
+	case meta.Mode == elasticsearchModeCount:
+		meta.MetricName = GenerateMetricNameWithIndex(config.TriggerIndex, "elasticsearch-count")
+	case meta.SearchTemplateName != "":
 		meta.MetricName = GenerateMetricNameWithIndex(config.TriggerIndex, util.NormalizeString(fmt.Sprintf("elasticsearch-%s", meta.SearchTemplateName)))
-	} else {
+	default:
 		meta.MetricName = GenerateMetricNameWithIndex(config.TriggerIndex, "elasticsearch-query")
 	}
 
@@ -129,7 +158,7 @@ func newElasticsearchClient(meta elasticsearchMetadata, logger logr.Logger) (*el
 		}
 	}
 
-	config.Transport = util.CreateHTTPTransport(meta.UnsafeSsl)
+	config.Transport = newElasticsearchTransport(meta)
 	esClient, err := elasticsearch.NewClient(config)
 	if err != nil {
 		logger.Error(err, fmt.Sprintf("Found error when creating client: %s", err))
@@ -144,6 +173,14 @@ func newElasticsearchClient(meta elasticsearchMetadata, logger logr.Logger) (*el
 	return esClient, nil
 }
 
+// newElasticsearchTransport returns the transport of the client, tlsServerName overriding the server name
+// of the certificates
+func newElasticsearchTransport(meta elasticsearchMetadata) *http.Transport {
+	transport := util.CreateHTTPTransport(meta.UnsafeSsl)
+	util.SetTLSServerName(transport.TLSClientConfig, meta.TLSServerName)
+	return transport
+}
+
 func (s *elasticsearchScaler) Close(_ context.Context) error {
 	return nil
 }
@@ -154,6 +191,10 @@ func (s *elasticsearchScaler) getQueryResult(ctx context.Context) (float64, erro
 	var res *esapi.Response
 	var err error
 
+	if s.metadata.Mode == elasticsearchModeCount {
+		return s.getCountResult(ctx)
+	}
+
 	if s.metadata.SearchTemplateName != "" {
 		// Using SearchTemplateName
 		var body bytes.Buffer
@@ -191,6 +232,41 @@ func (s *elasticsearchScaler) getQueryResult(ctx context.Context) (float64, erro
 	return v, nil
 }
 
+// getCountResult returns the number of documents matching the query using the _count API
+func (s *elasticsearchScaler) getCountResult(ctx context.Context) (float64, error) {
+	opts := []func(*esapi.CountRequest){
+		s.esClient.Count.WithIndex(s.metadata.Index...),
+		s.esClient.Count.WithContext(ctx),
+	}
+	if s.metadata.Query != "" {
+		opts = append(opts, s.esClient.Count.WithBody(strings.NewReader(s.metadata.Query)))
+	}
+
+	res, err := s.esClient.Count(opts...)
+	if err != nil {
+		s.logger.Error(err, fmt.Sprintf("Could not query elasticsearch: %s", err))
+		return 0, err
+	}
+
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, err
+	}
+	if res.IsError() {
+		if reason := gjson.GetBytes(b, "error.type"); reason.String() == "index_not_found_exception" {
+			return 0, fmt.Errorf("index not found: %s", gjson.GetBytes(b, "error.index").String())
+		}
+		return 0, fmt.Errorf("error counting documents: %s: %s", res.Status(), string(b))
+	}
+
+	r := gjson.GetBytes(b, "count")
+	if r.Type != gjson.Number {
+		return 0, fmt.Errorf("count response doesn't contain a numeric count: %s", string(b))
+	}
+	return r.Num, nil
+}
+
 func buildQuery(metadata *elasticsearchMetadata) map[string]interface{} {
 	parameters := map[string]interface{}{}
 	for _, p := range metadata.Parameters {
@@ -3,8 +3,13 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
@@ -172,6 +177,7 @@ var testCases = []parseElasticsearchMetadataTestData{
 			Password:              "password",
 			SearchTemplateName:    "myAwesomeSearch",
 			Parameters:            []string{"param1:value1"},
+			Mode:                  "search",
 			ValueLocation:         "hits.hits[0]._source.value",
 			TargetValue:           12.2,
 			ActivationTargetValue: 3.33,
@@ -202,6 +208,7 @@ var testCases = []parseElasticsearchMetadataTestData{
 			Password:           "password",
 			SearchTemplateName: "myAwesomeSearch",
 			Parameters:         []string{"param1:value1"},
+			Mode:               "search",
 			ValueLocation:      "hits.hits[0]._source.value",
 			TargetValue:        12,
 			MetricName:         "s0-elasticsearch-myAwesomeSearch",
@@ -231,6 +238,7 @@ var testCases = []parseElasticsearchMetadataTestData{
 			Password:           "password",
 			SearchTemplateName: "myAwesomeSearch",
 			Parameters:         []string{"param1:value1"},
+			Mode:               "search",
 			ValueLocation:      "hits.hits[0]._source.value",
 			TargetValue:        12,
 			MetricName:         "s0-elasticsearch-myAwesomeSearch",
@@ -260,6 +268,7 @@ var testCases = []parseElasticsearchMetadataTestData{
 			Password:           "password",
 			SearchTemplateName: "myAwesomeSearch",
 			Parameters:         []string{"param1:value1"},
+			Mode:               "search",
 			ValueLocation:      "hits.hits[0]._source.value",
 			TargetValue:        12,
 			MetricName:         "s0-elasticsearch-myAwesomeSearch",
@@ -289,6 +298,7 @@ var testCases = []parseElasticsearchMetadataTestData{
 			Password:           "password",
 			SearchTemplateName: "myAwesomeSearch",
 			Parameters:         []string{"param1:value1"},
+			Mode:               "search",
 			ValueLocation:      "hits.hits[0]._source.value",
 			TargetValue:        12,
 			MetricName:         "s0-elasticsearch-myAwesomeSearch",
@@ -321,6 +331,7 @@ var testCases = []parseElasticsearchMetadataTestData{
 			Password:           "password",
 			SearchTemplateName: "myAwesomeSearch",
 			Parameters:         []string{"param1:value1"},
+			Mode:               "search",
 			ValueLocation:      "hits.hits[0]._source.value",
 			TargetValue:        12,
 			MetricName:         "s0-elasticsearch-myAwesomeSearch",
@@ -346,12 +357,69 @@ var testCases = []parseElasticsearchMetadataTestData{
 			Username:      "admin",
 			Password:      "password",
 			Query:         `{"match": {"field": "value"}}`,
+			Mode:          "search",
 			ValueLocation: "hits.total.value",
 			TargetValue:   12,
 			MetricName:    "s0-elasticsearch-query",
 		},
 		expectedError: nil,
 	},
+	{
+		name: "count mode without valueLocation",
+		metadata: map[string]string{
+			"addresses":   "http://localhost:9200",
+			"index":       "index1",
+			"mode":        "count",
+			"query":       `{"query": {"match": {"field": "value"}}}`,
+			"targetValue": "12",
+		},
+		authParams: map[string]string{
+			"username": "admin",
+			"password": "password",
+		},
+		expectedMetadata: &elasticsearchMetadata{
+			Addresses:   []string{"http://localhost:9200"},
+			Index:       []string{"index1"},
+			Username:    "admin",
+			Password:    "password",
+			Query:       `{"query": {"match": {"field": "value"}}}`,
+			Mode:        "count",
+			TargetValue: 12,
+			MetricName:  "s0-elasticsearch-count",
+		},
+		expectedError: nil,
+	},
+	{
+		name: "count mode with searchTemplateName",
+		metadata: map[string]string{
+			"addresses":          "http://localhost:9200",
+			"index":              "index1",
+			"mode":               "count",
+			"searchTemplateName": "myTemplate",
+			"targetValue":        "12",
+		},
+		authParams: map[string]string{
+			"username": "admin",
+			"password": "password",
+		},
+		expectedError: fmt.Errorf("searchTemplateName can't be used with mode \"count\""),
+	},
+	{
+		name: "invalid mode",
+		metadata: map[string]string{
+			"addresses":     "http://localhost:9200",
+			"index":         "index1",
+			"mode":          "sum",
+			"query":         `{"match": {"field": "value"}}`,
+			"valueLocation": "hits.total.value",
+			"targetValue":   "12",
+		},
+		authParams: map[string]string{
+			"username": "admin",
+			"password": "password",
+		},
+		expectedError: fmt.Errorf("parameter \"mode\" value \"sum\" must be one of [search count]"),
+	},
 }
 
 func TestParseElasticsearchMetadata(t *testing.T) {
@@ -397,6 +465,7 @@ func TestUnsafeSslDefaultValue(t *testing.T) {
 			Password:           "password",
 			SearchTemplateName: "myAwesomeSearch",
 			Parameters:         []string{"param1:value1"},
+			Mode:               "search",
 			ValueLocation:      "hits.hits[0]._source.value",
 			TargetValue:        12,
 			MetricName:         "s0-elasticsearch-myAwesomeSearch",
@@ -535,3 +604,128 @@ func TestElasticsearchGetMetricSpecForScaling(t *testing.T) {
 		assert.Equal(t, metricSpec[0].External.Metric.Name, testData.name)
 	}
 }
+
+func newTestElasticsearchClient(t *testing.T, handler http.HandlerFunc) *elasticsearch.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/" {
+			// product check performed by the client before the first request
+			_, _ = w.Write([]byte(`{"version": {"number": "7.17.0", "build_flavor": "default"}, "tagline": "You Know, for Search"}`))
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{server.URL}})
+	if err != nil {
+		t.Fatal("Could not create elasticsearch client:", err)
+	}
+	return client
+}
+
+func TestElasticsearchGetQueryResult(t *testing.T) {
+	testData := []struct {
+		name          string
+		metadata      elasticsearchMetadata
+		statusCode    int
+		response      string
+		expectedPath  string
+		expectedBody  string
+		expectedValue float64
+		expectedError string
+	}{
+		{
+			name: "search mode",
+			metadata: elasticsearchMetadata{
+				Index:         []string{"index1"},
+				Mode:          elasticsearchModeSearch,
+				Query:         `{"query": {"match_all": {}}}`,
+				ValueLocation: "hits.total.value",
+			},
+			statusCode:    http.StatusOK,
+			response:      `{"hits": {"total": {"value": 7}}}`,
+			expectedPath:  "/index1/_search",
+			expectedBody:  `{"query": {"match_all": {}}}`,
+			expectedValue: 7,
+		},
+		{
+			name: "count mode",
+			metadata: elasticsearchMetadata{
+				Index: []string{"index1", "index2"},
+				Mode:  elasticsearchModeCount,
+				Query: `{"query": {"term": {"status": "pending"}}}`,
+			},
+			statusCode:    http.StatusOK,
+			response:      `{"count": 42, "_shards": {"total": 1, "successful": 1, "skipped": 0, "failed": 0}}`,
+			expectedPath:  "/index1,index2/_count",
+			expectedBody:  `{"query": {"term": {"status": "pending"}}}`,
+			expectedValue: 42,
+		},
+		{
+			name: "count mode without query",
+			metadata: elasticsearchMetadata{
+				Index: []string{"index1"},
+				Mode:  elasticsearchModeCount,
+			},
+			statusCode:    http.StatusOK,
+			response:      `{"count": 3}`,
+			expectedPath:  "/index1/_count",
+			expectedValue: 3,
+		},
+		{
+			name: "count mode index not found",
+			metadata: elasticsearchMetadata{
+				Index: []string{"missing"},
+				Mode:  elasticsearchModeCount,
+			},
+			statusCode:    http.StatusNotFound,
+			response:      `{"error": {"type": "index_not_found_exception", "reason": "no such index [missing]", "index": "missing"}, "status": 404}`,
+			expectedPath:  "/missing/_count",
+			expectedError: "index not found: missing",
+		},
+		{
+			name: "count mode server error",
+			metadata: elasticsearchMetadata{
+				Index: []string{"index1"},
+				Mode:  elasticsearchModeCount,
+			},
+			statusCode:    http.StatusInternalServerError,
+			response:      `{"error": {"type": "search_phase_execution_exception"}, "status": 500}`,
+			expectedPath:  "/index1/_count",
+			expectedError: "error counting documents",
+		},
+	}
+
+	for _, tc := range testData {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newTestElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tc.expectedPath, r.URL.Path)
+				body, _ := io.ReadAll(r.Body)
+				assert.Equal(t, tc.expectedBody, string(body))
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.response))
+			})
+			s := elasticsearchScaler{metadata: tc.metadata, esClient: client, logger: logr.Discard()}
+
+			value, err := s.getQueryResult(context.Background())
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, value)
+		})
+	}
+}
+
+func TestElasticsearchTransportTLSServerName(t *testing.T) {
+	transport := newElasticsearchTransport(elasticsearchMetadata{TLSServerName: "search.example.com"})
+	assert.Equal(t, "search.example.com", transport.TLSClientConfig.ServerName)
+	assert.False(t, transport.TLSClientConfig.InsecureSkipVerify)
+
+	transport = newElasticsearchTransport(elasticsearchMetadata{})
+	assert.Empty(t, transport.TLSClientConfig.ServerName)
+}
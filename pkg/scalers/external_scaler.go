@@ -41,6 +41,7 @@ type externalScalerMetadata struct {
 	tlsClientCert    string
 	tlsClientKey     string
 	unsafeSsl        bool
+	failureMode      string
 }
 
 type connectionGroup struct {
@@ -52,6 +53,13 @@ var connectionPool sync.Map
 
 const grpcConfig = `{"loadBalancingConfig": [{"round_robin":{}}]}`
 
+const (
+	// externalScalerFailureModeFail makes the failures of the external scaler errors of the ScaledObject, triggering the fallback
+	externalScalerFailureModeFail = "fail"
+	// externalScalerFailureModeIgnore makes a failing external scaler report an inactive zero, so the other triggers keep scaling
+	externalScalerFailureModeIgnore = "ignore"
+)
+
 // NewExternalScaler creates a new external scaler - calls the GRPC interface
 // to create a new scaler
 func NewExternalScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
@@ -136,6 +144,16 @@ func parseExternalScalerMetadata(config *scalersconfig.ScalerConfig) (externalSc
 		}
 		meta.unsafeSsl = boolVal
 	}
+
+	meta.failureMode = externalScalerFailureModeFail
+	if val, ok := config.TriggerMetadata["failureMode"]; ok && val != "" {
+		switch val {
+		case externalScalerFailureModeFail, externalScalerFailureModeIgnore:
+			meta.failureMode = val
+		default:
+			return meta, fmt.Errorf("failureMode must be either %s or %s", externalScalerFailureModeFail, externalScalerFailureModeIgnore)
+		}
+	}
 	// Add elements to metadata
 	for key, value := range config.TriggerMetadata {
 		// Check if key is in resolved environment and resolve
@@ -171,7 +189,15 @@ func (s *externalScaler) GetMetricSpecForScaling(ctx context.Context) []v2.Metri
 		return nil
 	}
 
+	// a trigger can expose several metrics, each of them becomes a metric of the HPA
+	metricNames := make(map[string]struct{}, len(response.MetricSpecs))
 	for _, spec := range response.MetricSpecs {
+		if _, ok := metricNames[spec.MetricName]; ok {
+			s.logger.Error(fmt.Errorf("duplicate metric name %q", spec.MetricName), "ignoring metric spec returned by the external scaler")
+			continue
+		}
+		metricNames[spec.MetricName] = struct{}{}
+
 		externalMetric := &v2.ExternalMetricSource{
 			Metric: v2.MetricIdentifier{
 				Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, spec.MetricName),
@@ -191,8 +217,18 @@ func (s *externalScaler) GetMetricSpecForScaling(ctx context.Context) []v2.Metri
 	return result
 }
 
-// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
+// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric,
+// unless the failures are ignored, the metric being then zero and inactive
 func (s *externalScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	metrics, isActive, err := s.getMetricsAndActivity(ctx, metricName)
+	if err != nil && s.metadata.failureMode == externalScalerFailureModeIgnore {
+		s.logger.V(1).Info("ignoring failure of the external scaler", "metricName", metricName, "error", err.Error())
+		return []external_metrics.ExternalMetricValue{GenerateMetricInMili(metricName, 0)}, false, nil
+	}
+	return metrics, isActive, err
+}
+
+func (s *externalScaler) getMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
 	var metrics []external_metrics.ExternalMetricValue
 	grpcClient, err := getClientForConnectionPool(s.metadata)
 	if err != nil {
@@ -216,7 +252,7 @@ func (s *externalScaler) GetMetricsAndActivity(ctx context.Context, metricName s
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
 
-	for _, metricResult := range metricsResponse.MetricValues {
+	for _, metricResult := range getExternalMetricValues(metricsResponse.MetricValues, metricNameWithoutIndex) {
 		metric := GenerateMetricInMili(metricName, float64(metricResult.MetricValue))
 		metrics = append(metrics, metric)
 	}
@@ -230,6 +266,21 @@ func (s *externalScaler) GetMetricsAndActivity(ctx context.Context, metricName s
 	return metrics, isActiveResponse.Result, nil
 }
 
+// getExternalMetricValues returns the values of the requested metric, as an external scaler exposing several
+// metrics may return the values of all of them. The values of scalers not naming them are all returned.
+func getExternalMetricValues(values []*pb.MetricValue, metricName string) []*pb.MetricValue {
+	var result []*pb.MetricValue
+	for _, value := range values {
+		if value.MetricName == metricName {
+			result = append(result, value)
+		}
+	}
+	if len(result) == 0 {
+		return values
+	}
+	return result
+}
+
 // handleIsActiveStream is the only writer to the active channel and will close it on return.
 func (s *externalPushScaler) Run(ctx context.Context, active chan<- bool) {
 	defer close(active)
@@ -13,7 +13,9 @@ import (
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
+	v2 "k8s.io/api/autoscaling/v2"
 
+	"github.com/kedacore/keda/v2/pkg/mock/mock_externalscaler"
 	pb "github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
@@ -71,6 +73,10 @@ var testExternalScalerMetadata = []parseExternalScalerMetadataTestData{
 	{map[string]string{"scalerAddress": "myservice", "test1": "7", "test2": "SAMPLE_CREDS", "insecureSkipVerify": "true"}, false, map[string]string{"caCert": serverRootCA, "tlsClientCert": clientCert}},
 	// missing scalerAddress
 	{map[string]string{"test1": "1", "test2": "SAMPLE_CREDS"}, true, map[string]string{}},
+	// failures ignored
+	{map[string]string{"scalerAddress": "myservice", "failureMode": "ignore"}, false, map[string]string{}},
+	// invalid failureMode
+	{map[string]string{"scalerAddress": "myservice", "failureMode": "skip"}, true, map[string]string{}},
 }
 
 func TestExternalScalerParseMetadata(t *testing.T) {
@@ -296,3 +302,126 @@ func TestWaitForState(t *testing.T) {
 		t.Error("waitForState should be get connectivity.Shutdown.")
 	}
 }
+
+// testMultipleMetricsExternalScaler returns two metrics for every trigger, with the values of both
+// returned whatever metric is requested
+type testMultipleMetricsExternalScaler struct {
+	pb.UnimplementedExternalScalerServer
+}
+
+func (e *testMultipleMetricsExternalScaler) IsActive(context.Context, *pb.ScaledObjectRef) (*pb.IsActiveResponse, error) {
+	return &pb.IsActiveResponse{Result: true}, nil
+}
+
+func (e *testMultipleMetricsExternalScaler) GetMetricSpec(context.Context, *pb.ScaledObjectRef) (*pb.GetMetricSpecResponse, error) {
+	return &pb.GetMetricSpecResponse{MetricSpecs: []*pb.MetricSpec{
+		{MetricName: "queue-length", TargetSize: 10},
+		{MetricName: "processing-time", TargetSize: 200},
+		{MetricName: "queue-length", TargetSize: 20},
+	}}, nil
+}
+
+func (e *testMultipleMetricsExternalScaler) GetMetrics(context.Context, *pb.GetMetricsRequest) (*pb.GetMetricsResponse, error) {
+	return &pb.GetMetricsResponse{MetricValues: []*pb.MetricValue{
+		{MetricName: "queue-length", MetricValue: 42},
+		{MetricName: "processing-time", MetricValue: 350},
+	}}, nil
+}
+
+func TestExternalScalerMultipleMetrics(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterExternalScalerServer(grpcServer, &testMultipleMetricsExternalScaler{})
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			t.Error(err, "error from grpcServer")
+		}
+	}()
+	defer grpcServer.Stop()
+
+	scaler, err := NewExternalScaler(&scalersconfig.ScalerConfig{
+		ScalableObjectName:      "app",
+		ScalableObjectNamespace: "namespace",
+		TriggerMetadata:         map[string]string{"scalerAddress": lis.Addr().String()},
+		TriggerIndex:            1,
+		MetricType:              v2.AverageValueMetricType,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metricSpecs := scaler.GetMetricSpecForScaling(context.Background())
+	if len(metricSpecs) != 2 {
+		t.Fatalf("Expected 2 metric specs but got %d", len(metricSpecs))
+	}
+
+	expected := map[string]struct {
+		target int64
+		value  int64
+	}{
+		"s1-queue-length":    {10, 42},
+		"s1-processing-time": {200, 350},
+	}
+	for _, spec := range metricSpecs {
+		metricName := spec.External.Metric.Name
+		want, ok := expected[metricName]
+		if !ok {
+			t.Fatalf("Unexpected metric %q", metricName)
+		}
+		if target := spec.External.Target.AverageValue.Value(); target != want.target {
+			t.Errorf("Expected target %d for %q but got %d", want.target, metricName, target)
+		}
+
+		metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isActive {
+			t.Errorf("Expected %q to be active", metricName)
+		}
+		if len(metrics) != 1 {
+			t.Fatalf("Expected 1 value for %q but got %d", metricName, len(metrics))
+		}
+		if metrics[0].MetricName != metricName || metrics[0].Value.Value() != want.value {
+			t.Errorf("Expected %q=%d but got %q=%d", metricName, want.value, metrics[0].MetricName, metrics[0].Value.Value())
+		}
+	}
+}
+
+func TestExternalScalerFailureMode(t *testing.T) {
+	address := mock_externalscaler.StartFailingExternalScaler(t)
+
+	for _, failureMode := range []string{"", "fail", "ignore"} {
+		t.Run(failureMode, func(t *testing.T) {
+			scaler, err := NewExternalScaler(&scalersconfig.ScalerConfig{
+				ScalableObjectName:      "app",
+				ScalableObjectNamespace: "namespace",
+				TriggerMetadata:         map[string]string{"scalerAddress": address, "failureMode": failureMode},
+				MetricType:              v2.AverageValueMetricType,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "s0-queue-length")
+			if isActive {
+				t.Error("Expected the failing scaler to be inactive")
+			}
+			if failureMode != "ignore" {
+				if err == nil {
+					t.Error("Expected error but got success")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Expected the failure to be ignored but got error", err)
+			}
+			if len(metrics) != 1 || metrics[0].MetricName != "s0-queue-length" || metrics[0].Value.Value() != 0 {
+				t.Errorf("Expected s0-queue-length=0 but got %v", metrics)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	flinkJobStateRunning = "RUNNING"
+)
+
+type flinkScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *flinkMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type flinkMetadata struct {
+	RestURL string `keda:"name=restURL, order=triggerMetadata;resolvedEnv"`
+	// The job is either given by its ID or by its name, in which case the running job with that name is used.
+	// Using the name keeps the trigger working when the job is resubmitted with a new ID.
+	JobID    string `keda:"name=jobID,    order=triggerMetadata, optional"`
+	JobName  string `keda:"name=jobName,  order=triggerMetadata, optional"`
+	VertexID string `keda:"name=vertexID, order=triggerMetadata"`
+	// Metric is the name of the subtask metric, e.g. KafkaSourceReader.KafkaConsumer.records-lag-max
+	Metric          string  `keda:"name=metric,          order=triggerMetadata"`
+	Aggregation     string  `keda:"name=aggregation,     order=triggerMetadata, enum=max;min;avg;sum, default=max"`
+	Value           float64 `keda:"name=value,           order=triggerMetadata"`
+	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, default=0"`
+	UnsafeSsl       bool    `keda:"name=unsafeSsl,       order=triggerMetadata, default=false"`
+
+	FlinkAuth *authentication.Config `keda:"optional"`
+
+	triggerIndex int
+}
+
+func (m *flinkMetadata) Validate() error {
+	if (m.JobID == "") == (m.JobName == "") {
+		return fmt.Errorf("exactly one of jobID or jobName must be provided")
+	}
+	return nil
+}
+
+type flinkJobsOverview struct {
+	Jobs []struct {
+		JID   string `json:"jid"`
+		Name  string `json:"name"`
+		State string `json:"state"`
+	} `json:"jobs"`
+}
+
+// flinkAggregatedMetric is an entry of the response of the aggregated subtask metrics endpoint,
+// only the requested aggregation is set
+type flinkAggregatedMetric struct {
+	ID  string   `json:"id"`
+	Min *float64 `json:"min"`
+	Max *float64 `json:"max"`
+	Avg *float64 `json:"avg"`
+	Sum *float64 `json:"sum"`
+}
+
+// NewFlinkScaler creates a new Flink scaler
+func NewFlinkScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	logger := InitializeLogger(config, "flink_scaler")
+
+	meta, err := parseFlinkMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing flink metadata: %w", err)
+	}
+
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
+	if meta.FlinkAuth.EnabledTLS() || (!meta.FlinkAuth.Disabled() && meta.FlinkAuth.CA != "") {
+		transport, err := authentication.CreateHTTPRoundTripper(authentication.NetHTTP, meta.FlinkAuth.ToAuthMeta())
+		if err != nil {
+			logger.V(1).Error(err, "init Flink client http transport")
+			return nil, err
+		}
+		httpClient.Transport = transport
+	}
+
+	return &flinkScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		logger:     logger,
+	}, nil
+}
+
+func parseFlinkMetadata(config *scalersconfig.ScalerConfig) (*flinkMetadata, error) {
+	meta := &flinkMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	meta.RestURL = strings.TrimSuffix(meta.RestURL, "/")
+	return meta, nil
+}
+
+func (s *flinkScaler) doRequest(ctx context.Context, path string, query url.Values, out interface{}) error {
+	u := fmt.Sprintf("%s%s", s.metadata.RestURL, path)
+	if len(query) > 0 {
+		u = fmt.Sprintf("%s?%s", u, query.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case s.metadata.FlinkAuth.Disabled():
+		break
+	case s.metadata.FlinkAuth.EnabledBearerAuth():
+		req.Header.Set("Authorization", s.metadata.FlinkAuth.GetBearerToken())
+	case s.metadata.FlinkAuth.EnabledBasicAuth():
+		req.SetBasicAuth(s.metadata.FlinkAuth.Username, s.metadata.FlinkAuth.Password)
+	case s.metadata.FlinkAuth.EnabledCustomAuth():
+		req.Header.Set(s.metadata.FlinkAuth.CustomAuthHeader, s.metadata.FlinkAuth.CustomAuthValue)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("flink rest api returned error. status: %d response: %s", resp.StatusCode, string(body))
+	}
+	return json.Unmarshal(body, out)
+}
+
+// getJobID returns the configured job ID or the ID of the running job with the configured name
+func (s *flinkScaler) getJobID(ctx context.Context) (string, error) {
+	if s.metadata.JobID != "" {
+		return s.metadata.JobID, nil
+	}
+
+	var overview flinkJobsOverview
+	if err := s.doRequest(ctx, "/jobs/overview", nil, &overview); err != nil {
+		return "", fmt.Errorf("error listing flink jobs: %w", err)
+	}
+	for _, job := range overview.Jobs {
+		if job.Name == s.metadata.JobName && job.State == flinkJobStateRunning {
+			return job.JID, nil
+		}
+	}
+	return "", fmt.Errorf("no running flink job named %s", s.metadata.JobName)
+}
+
+func (s *flinkScaler) getMetricValue(ctx context.Context) (float64, error) {
+	jobID, err := s.getJobID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var metrics []flinkAggregatedMetric
+	path := fmt.Sprintf("/jobs/%s/vertices/%s/subtasks/metrics", url.PathEscape(jobID), url.PathEscape(s.metadata.VertexID))
+	query := url.Values{"get": []string{s.metadata.Metric}, "agg": []string{s.metadata.Aggregation}}
+	if err := s.doRequest(ctx, path, query, &metrics); err != nil {
+		return 0, fmt.Errorf("error getting flink subtask metrics: %w", err)
+	}
+	return getFlinkAggregatedValue(metrics, s.metadata.Metric, s.metadata.Aggregation)
+}
+
+// getFlinkAggregatedValue returns the requested aggregation of the metric from the aggregated subtask metrics
+func getFlinkAggregatedValue(metrics []flinkAggregatedMetric, metric, aggregation string) (float64, error) {
+	for _, m := range metrics {
+		if m.ID != metric {
+			continue
+		}
+		var value *float64
+		switch aggregation {
+		case "min":
+			value = m.Min
+		case "max":
+			value = m.Max
+		case "avg":
+			value = m.Avg
+		case "sum":
+			value = m.Sum
+		}
+		if value == nil {
+			return 0, fmt.Errorf("aggregation %s of metric %s not returned", aggregation, metric)
+		}
+		return *value, nil
+	}
+	// Flink returns an empty list for unknown metrics
+	return 0, fmt.Errorf("metric %s not found", metric)
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *flinkScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("flink-%s", s.metadata.Metric))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the value of the metric and the activity of the scaler
+func (s *flinkScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting flink: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationValue, nil
+}
+
+// Close closes the http client connection
+func (s *flinkScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
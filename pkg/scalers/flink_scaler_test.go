@@ -0,0 +1,223 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+const (
+	flinkTestJobID    = "a6b9b8f6c7d5e4f3a2b1c0d9e8f7a6b5"
+	flinkTestVertexID = "cbc357ccb763df2852fee8c4fc7d55f2"
+	flinkTestMetric   = "KafkaSourceReader.KafkaConsumer.records-lag-max"
+)
+
+const flinkJobsOverviewResponse = `{"jobs":[
+{"jid":"0f0e0d0c0b0a09080706050403020100","name":"orders","state":"CANCELED"},
+{"jid":"a6b9b8f6c7d5e4f3a2b1c0d9e8f7a6b5","name":"orders","state":"RUNNING"},
+{"jid":"00112233445566778899aabbccddeeff","name":"payments","state":"RUNNING"}
+]}`
+
+type parseFlinkMetadataTestData struct {
+	name       string
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+var testFlinkMetadata = []parseFlinkMetadataTestData{
+	{
+		name:     "job id",
+		metadata: map[string]string{"restURL": "http://flink-jobmanager:8081", "jobID": flinkTestJobID, "vertexID": flinkTestVertexID, "metric": flinkTestMetric, "value": "1000", "activationValue": "10"},
+	},
+	{
+		name:     "job name with aggregation",
+		metadata: map[string]string{"restURL": "http://flink-jobmanager:8081", "jobName": "orders", "vertexID": flinkTestVertexID, "metric": flinkTestMetric, "aggregation": "sum", "value": "1000"},
+	},
+	{
+		name:       "basic auth",
+		metadata:   map[string]string{"restURL": "https://flink-jobmanager:8081", "jobID": flinkTestJobID, "vertexID": flinkTestVertexID, "metric": flinkTestMetric, "value": "1000", "authModes": "basic"},
+		authParams: map[string]string{"username": "user", "password": "pass"},
+	},
+	{
+		name:     "missing restURL",
+		metadata: map[string]string{"jobID": flinkTestJobID, "vertexID": flinkTestVertexID, "metric": flinkTestMetric, "value": "1000"},
+		isError:  true,
+	},
+	{
+		name:     "missing job",
+		metadata: map[string]string{"restURL": "http://flink-jobmanager:8081", "vertexID": flinkTestVertexID, "metric": flinkTestMetric, "value": "1000"},
+		isError:  true,
+	},
+	{
+		name:     "job id and job name",
+		metadata: map[string]string{"restURL": "http://flink-jobmanager:8081", "jobID": flinkTestJobID, "jobName": "orders", "vertexID": flinkTestVertexID, "metric": flinkTestMetric, "value": "1000"},
+		isError:  true,
+	},
+	{
+		name:     "missing vertexID",
+		metadata: map[string]string{"restURL": "http://flink-jobmanager:8081", "jobID": flinkTestJobID, "metric": flinkTestMetric, "value": "1000"},
+		isError:  true,
+	},
+	{
+		name:     "missing metric",
+		metadata: map[string]string{"restURL": "http://flink-jobmanager:8081", "jobID": flinkTestJobID, "vertexID": flinkTestVertexID, "value": "1000"},
+		isError:  true,
+	},
+	{
+		name:     "invalid aggregation",
+		metadata: map[string]string{"restURL": "http://flink-jobmanager:8081", "jobID": flinkTestJobID, "vertexID": flinkTestVertexID, "metric": flinkTestMetric, "aggregation": "median", "value": "1000"},
+		isError:  true,
+	},
+	{
+		name:     "basic auth without username",
+		metadata: map[string]string{"restURL": "http://flink-jobmanager:8081", "jobID": flinkTestJobID, "vertexID": flinkTestVertexID, "metric": flinkTestMetric, "value": "1000", "authModes": "basic"},
+		isError:  true,
+	},
+}
+
+func TestParseFlinkMetadata(t *testing.T) {
+	for _, testData := range testFlinkMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			_, err := parseFlinkMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetFlinkAggregatedValue(t *testing.T) {
+	testCases := []struct {
+		name        string
+		response    string
+		aggregation string
+		expected    float64
+		isError     bool
+	}{
+		{name: "max", response: `[{"id":"` + flinkTestMetric + `","max":1520.0}]`, aggregation: "max", expected: 1520},
+		{name: "sum", response: `[{"id":"` + flinkTestMetric + `","sum":3042.0}]`, aggregation: "sum", expected: 3042},
+		{name: "all aggregations", response: `[{"id":"` + flinkTestMetric + `","min":2.0,"max":1520.0,"avg":760.5,"sum":3042.0}]`, aggregation: "avg", expected: 760.5},
+		{name: "zero", response: `[{"id":"` + flinkTestMetric + `","max":0.0}]`, aggregation: "max", expected: 0},
+		{name: "unknown metric", response: `[]`, aggregation: "max", isError: true},
+		{name: "other aggregation returned", response: `[{"id":"` + flinkTestMetric + `","min":2.0}]`, aggregation: "max", isError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var metrics []flinkAggregatedMetric
+			require.NoError(t, json.Unmarshal([]byte(tc.response), &metrics))
+
+			value, err := getFlinkAggregatedValue(metrics, flinkTestMetric, tc.aggregation)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestFlinkGetMetricSpecForScaling(t *testing.T) {
+	meta, err := parseFlinkMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"restURL": "http://flink-jobmanager:8081", "jobID": flinkTestJobID, "vertexID": flinkTestVertexID, "metric": flinkTestMetric, "value": "1000"},
+		TriggerIndex:    2,
+	})
+	require.NoError(t, err)
+
+	scaler := flinkScaler{metadata: meta}
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, "s2-flink-KafkaSourceReader-KafkaConsumer-records-lag-max", metricSpec[0].External.Metric.Name)
+}
+
+func TestFlinkGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/jobs/overview":
+			fmt.Fprint(w, flinkJobsOverviewResponse)
+		case fmt.Sprintf("/jobs/%s/vertices/%s/subtasks/metrics", flinkTestJobID, flinkTestVertexID):
+			if r.URL.Query().Get("get") != flinkTestMetric {
+				fmt.Fprint(w, `[]`)
+				return
+			}
+			fmt.Fprintf(w, `[{"id":"%s","%s":1520.0}]`, flinkTestMetric, r.URL.Query().Get("agg"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+		isError       bool
+	}{
+		{
+			name:          "job id",
+			metadata:      map[string]string{"jobID": flinkTestJobID, "metric": flinkTestMetric, "activationValue": "100"},
+			expectedValue: 1520,
+			isActive:      true,
+		},
+		{
+			name:          "running job by name",
+			metadata:      map[string]string{"jobName": "orders", "metric": flinkTestMetric, "activationValue": "2000"},
+			expectedValue: 1520,
+			isActive:      false,
+		},
+		{
+			name:     "no running job with name",
+			metadata: map[string]string{"jobName": "unknown", "metric": flinkTestMetric},
+			isError:  true,
+		},
+		{
+			name:     "unknown job",
+			metadata: map[string]string{"jobID": "ffffffffffffffffffffffffffffffff", "metric": flinkTestMetric},
+			isError:  true,
+		},
+		{
+			name:     "unknown metric",
+			metadata: map[string]string{"jobID": flinkTestJobID, "metric": "unknown"},
+			isError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata := map[string]string{"restURL": server.URL, "vertexID": flinkTestVertexID, "value": "1000", "authModes": "basic"}
+			for k, v := range tc.metadata {
+				metadata[k] = v
+			}
+			scaler, err := NewFlinkScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata: metadata,
+				AuthParams:      map[string]string{"username": "user", "password": "pass"},
+			})
+			require.NoError(t, err)
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "metric")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
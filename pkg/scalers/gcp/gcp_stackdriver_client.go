@@ -260,7 +260,8 @@ func (s StackDriverClient) GetMetrics(
 	// Get the value from the first metric returned
 	resp, err := it.Next()
 
-	if err == iterator.Done {
+	// an empty series, e.g. when no sample falls into the alignment period, is handled as a missing metric
+	if err == iterator.Done || (err == nil && len(resp.GetPoints()) == 0) {
 		if valueIfNull == nil {
 			return value, fmt.Errorf("could not find stackdriver metric with filter %s", filter)
 		}
@@ -271,13 +272,10 @@ func (s StackDriverClient) GetMetrics(
 		return value, err
 	}
 
-	if len(resp.GetPoints()) > 0 {
-		point := resp.GetPoints()[0]
-		value, err = extractValueFromPoint(point)
-
-		if err != nil {
-			return -1, err
-		}
+	point := resp.GetPoints()[0]
+	value, err = extractValueFromPoint(point)
+	if err != nil {
+		return -1, err
 	}
 
 	return value, nil
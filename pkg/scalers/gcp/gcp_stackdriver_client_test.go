@@ -1,9 +1,16 @@
 package gcp
 
 import (
+	"context"
+	"net"
 	"testing"
 
+	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
+	monitoringpb "cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
 	"github.com/stretchr/testify/assert"
+	option "google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 func TestBuildMQLQuery(t *testing.T) {
@@ -158,3 +165,96 @@ func TestGetActualProjectID(t *testing.T) {
 		})
 	}
 }
+
+type fakeMetricServiceServer struct {
+	monitoringpb.UnimplementedMetricServiceServer
+	requests   []*monitoringpb.ListTimeSeriesRequest
+	timeSeries []*monitoringpb.TimeSeries
+}
+
+func (s *fakeMetricServiceServer) ListTimeSeries(_ context.Context, req *monitoringpb.ListTimeSeriesRequest) (*monitoringpb.ListTimeSeriesResponse, error) {
+	s.requests = append(s.requests, req)
+	return &monitoringpb.ListTimeSeriesResponse{TimeSeries: s.timeSeries}, nil
+}
+
+// newFakeStackDriverClient returns a client pointing to a fake Monitoring API answering with the given time series
+func newFakeStackDriverClient(t *testing.T, server *fakeMetricServiceServer) *StackDriverClient {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("Could not listen:", err)
+	}
+	grpcServer := grpc.NewServer()
+	monitoringpb.RegisterMetricServiceServer(grpcServer, server)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	metricsClient, err := monitoring.NewMetricClient(context.Background(),
+		option.WithEndpoint(listener.Addr().String()),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	if err != nil {
+		t.Fatal("Could not create metrics client:", err)
+	}
+	t.Cleanup(func() { _ = metricsClient.Close() })
+
+	return &StackDriverClient{metricsClient: metricsClient}
+}
+
+func TestGetMetricsAggregation(t *testing.T) {
+	server := &fakeMetricServiceServer{
+		timeSeries: []*monitoringpb.TimeSeries{{
+			Points: []*monitoringpb.Point{{
+				Value: &monitoringpb.TypedValue{Value: &monitoringpb.TypedValue_DoubleValue{DoubleValue: 4.5}},
+			}},
+		}},
+	}
+	client := newFakeStackDriverClient(t, server)
+
+	aggregation, err := NewStackdriverAggregator(120, "max", "sum")
+	assert.NoError(t, err)
+
+	value, err := client.GetMetrics(context.Background(), `metric.type="x"`, "myproject", aggregation, nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 4.5, value)
+
+	if assert.Len(t, server.requests, 1) {
+		req := server.requests[0]
+		assert.Equal(t, "projects/myproject", req.Name)
+		assert.Equal(t, int64(120), req.Aggregation.GetAlignmentPeriod().GetSeconds())
+		assert.Equal(t, monitoringpb.Aggregation_ALIGN_MAX, req.Aggregation.GetPerSeriesAligner())
+		assert.Equal(t, monitoringpb.Aggregation_REDUCE_SUM, req.Aggregation.GetCrossSeriesReducer())
+	}
+}
+
+func TestGetMetricsEmptySeries(t *testing.T) {
+	valueIfNull := 2.0
+	for _, tc := range []struct {
+		name        string
+		timeSeries  []*monitoringpb.TimeSeries
+		valueIfNull *float64
+
+		expected float64
+		isError  bool
+	}{
+		{"no series", nil, nil, -1, true},
+		{"no series with valueIfNull", nil, &valueIfNull, 2, false},
+		{"series without points", []*monitoringpb.TimeSeries{{}}, nil, -1, true},
+		{"series without points with valueIfNull", []*monitoringpb.TimeSeries{{}}, &valueIfNull, 2, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newFakeStackDriverClient(t, &fakeMetricServiceServer{timeSeries: tc.timeSeries})
+
+			value, err := client.GetMetrics(context.Background(), `metric.type="x"`, "myproject", nil, tc.valueIfNull, 0)
+			if tc.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
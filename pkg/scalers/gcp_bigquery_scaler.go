@@ -0,0 +1,257 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/sync/singleflight"
+	bigquery "google.golang.org/api/bigquery/v2"
+	option "google.golang.org/api/option"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/gcp"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+const (
+	// How long a single jobs.query / jobs.getQueryResults call waits for the query to complete
+	bigQueryPollTimeout = 10 * time.Second
+	// How long the cancellation of a query that ran out of time may take
+	bigQueryCancelTimeout = 10 * time.Second
+)
+
+type gcpBigQueryScaler struct {
+	service    *bigquery.Service
+	metricType v2.MetricTargetType
+	metadata   *gcpBigQueryMetadata
+	logger     logr.Logger
+
+	// the last query result is cached to respect the BigQuery query quotas,
+	// as both the scale loop and the metrics server poll the scaler
+	cacheMutex     sync.Mutex
+	cachedValue    float64
+	cacheExpiresAt time.Time
+	// concurrent polls missing the cache share a single query, without holding cacheMutex while it runs
+	queryGroup singleflight.Group
+}
+
+type gcpBigQueryMetadata struct {
+	ProjectID            string  `keda:"name=projectID,            order=triggerMetadata;resolvedEnv"`
+	Query                string  `keda:"name=query,                order=triggerMetadata"`
+	Location             string  `keda:"name=location,             order=triggerMetadata, optional"`
+	TargetValue          float64 `keda:"name=targetValue,          order=triggerMetadata, default=1"`
+	ActivationValue      float64 `keda:"name=activationValue,      order=triggerMetadata, default=0"`
+	CacheDurationSeconds int64   `keda:"name=cacheDurationSeconds, order=triggerMetadata, default=30"`
+	QueryTimeout         string  `keda:"name=queryTimeout,         order=triggerMetadata, default=60s"`
+
+	queryTimeout     time.Duration
+	gcpAuthorization *gcp.AuthorizationMetadata
+	triggerIndex     int
+}
+
+func (m *gcpBigQueryMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return fmt.Errorf("targetValue must be greater than 0")
+	}
+	if m.CacheDurationSeconds < 0 {
+		return fmt.Errorf("cacheDurationSeconds must be greater than or equal to 0")
+	}
+	var err error
+	if m.queryTimeout, err = time.ParseDuration(m.QueryTimeout); err != nil || m.queryTimeout <= 0 {
+		return fmt.Errorf("queryTimeout must be a positive duration, got %q", m.QueryTimeout)
+	}
+	return nil
+}
+
+// NewGcpBigQueryScaler creates a new gcpBigQueryScaler
+func NewGcpBigQueryScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	logger := InitializeLogger(config, "gcp_bigquery_scaler")
+
+	meta, err := parseGcpBigQueryMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing BigQuery metadata: %w", err)
+	}
+
+	var opts []option.ClientOption
+	switch {
+	case meta.gcpAuthorization.PodIdentityProviderEnabled:
+	case meta.gcpAuthorization.GoogleApplicationCredentialsFile != "":
+		opts = append(opts, option.WithCredentialsFile(meta.gcpAuthorization.GoogleApplicationCredentialsFile))
+	default:
+		opts = append(opts, option.WithCredentialsJSON([]byte(meta.gcpAuthorization.GoogleApplicationCredentials)))
+	}
+
+	service, err := bigquery.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating BigQuery client: %w", err)
+	}
+
+	return &gcpBigQueryScaler{
+		service:    service,
+		metricType: metricType,
+		metadata:   meta,
+		logger:     logger,
+	}, nil
+}
+
+func parseGcpBigQueryMetadata(config *scalersconfig.ScalerConfig) (*gcpBigQueryMetadata, error) {
+	meta := &gcpBigQueryMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing BigQuery metadata: %w", err)
+	}
+
+	auth, err := gcp.GetGCPAuthorization(config)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.gcpAuthorization = auth
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+func (s *gcpBigQueryScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *gcpBigQueryScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, "gcp-bigquery"),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
+func (s *gcpBigQueryScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getCachedQueryResult(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting BigQuery: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationValue, nil
+}
+
+// getCachedQueryResult returns the cached query result if it's still fresh, otherwise it runs the query
+func (s *gcpBigQueryScaler) getCachedQueryResult(ctx context.Context) (float64, error) {
+	s.cacheMutex.Lock()
+	if time.Now().Before(s.cacheExpiresAt) {
+		value := s.cachedValue
+		s.cacheMutex.Unlock()
+		return value, nil
+	}
+	s.cacheMutex.Unlock()
+
+	// the shared query isn't stopped when the poll starting it ends, the other polls may still wait for it,
+	// it's bounded by queryTimeout instead
+	results := s.queryGroup.DoChan("query", func() (interface{}, error) {
+		value, err := s.runQuery(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		s.cacheMutex.Lock()
+		defer s.cacheMutex.Unlock()
+		s.cachedValue = value
+		s.cacheExpiresAt = time.Now().Add(time.Duration(s.metadata.CacheDurationSeconds) * time.Second)
+		return value, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case result := <-results:
+		if result.Err != nil {
+			return 0, result.Err
+		}
+		return result.Val.(float64), nil
+	}
+}
+
+// runQuery runs the standard SQL query and waits for its completion,
+// the query is canceled once it runs longer than queryTimeout
+func (s *gcpBigQueryScaler) runQuery(ctx context.Context) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.metadata.queryTimeout)
+	defer cancel()
+
+	// the calls return before the deadline, the job reference needed to cancel the query is then known
+	pollTimeout := min(bigQueryPollTimeout, s.metadata.queryTimeout)
+	useLegacySQL := false
+	response, err := s.service.Jobs.Query(s.metadata.ProjectID, &bigquery.QueryRequest{
+		Query:        s.metadata.Query,
+		Location:     s.metadata.Location,
+		UseLegacySql: &useLegacySQL,
+		TimeoutMs:    pollTimeout.Milliseconds(),
+	}).Context(ctx).Do()
+	if err != nil {
+		return 0, fmt.Errorf("error running query: %w", err)
+	}
+
+	complete, rows := response.JobComplete, response.Rows
+	for !complete {
+		if response.JobReference == nil {
+			return 0, fmt.Errorf("query didn't complete and has no job reference")
+		}
+		results, err := s.service.Jobs.GetQueryResults(s.metadata.ProjectID, response.JobReference.JobId).
+			Location(response.JobReference.Location).
+			TimeoutMs(pollTimeout.Milliseconds()).
+			Context(ctx).Do()
+		if err != nil {
+			if ctx.Err() != nil {
+				s.cancelJob(response.JobReference)
+				return 0, fmt.Errorf("query didn't complete within %s: %w", s.metadata.queryTimeout, ctx.Err())
+			}
+			return 0, fmt.Errorf("error getting query results: %w", err)
+		}
+		complete, rows = results.JobComplete, results.Rows
+	}
+
+	return parseBigQueryResult(rows)
+}
+
+// cancelJob cancels a query that ran out of time, so it doesn't keep running and being billed
+func (s *gcpBigQueryScaler) cancelJob(job *bigquery.JobReference) {
+	ctx, cancel := context.WithTimeout(context.Background(), bigQueryCancelTimeout)
+	defer cancel()
+
+	if _, err := s.service.Jobs.Cancel(s.metadata.ProjectID, job.JobId).Location(job.Location).Context(ctx).Do(); err != nil {
+		s.logger.Error(err, "error canceling BigQuery query", "jobId", job.JobId)
+	}
+}
+
+// parseBigQueryResult extracts the single numeric value of the query result, NULL is considered as 0
+func parseBigQueryResult(rows []*bigquery.TableRow) (float64, error) {
+	if len(rows) != 1 || len(rows[0].F) != 1 {
+		return 0, fmt.Errorf("query must return a single row with a single column, got %d rows", len(rows))
+	}
+
+	switch v := rows[0].F[0].V.(type) {
+	case nil:
+		return 0, nil
+	case string:
+		value, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("query result %q is not a number", v)
+		}
+		return value, nil
+	default:
+		return 0, fmt.Errorf("query result must be a number, got %T", v)
+	}
+}
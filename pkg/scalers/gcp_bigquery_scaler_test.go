@@ -0,0 +1,398 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	bigquery "google.golang.org/api/bigquery/v2"
+	option "google.golang.org/api/option"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/gcp"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+var testGcpBigQueryResolvedEnv = map[string]string{
+	"SAMPLE_CREDS": "{}",
+}
+
+type parseGcpBigQueryMetadataTestData struct {
+	authParams  map[string]string
+	metadata    map[string]string
+	podIdentity kedav1alpha1.PodIdentityProvider
+	isError     bool
+	expected    *gcpBigQueryMetadata
+	comment     string
+}
+
+type gcpBigQueryMetricIdentifier struct {
+	metadataTestData *parseGcpBigQueryMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testGcpBigQueryMetadata = []parseGcpBigQueryMetadataTestData{
+	{nil, map[string]string{}, "", true, nil, "empty metadata"},
+
+	{nil, map[string]string{"projectID": "myproject", "query": "SELECT COUNT(*) FROM jobs", "targetValue": "5", "activationValue": "1", "credentialsFromEnv": "SAMPLE_CREDS"}, "", false, &gcpBigQueryMetadata{
+		ProjectID:            "myproject",
+		Query:                "SELECT COUNT(*) FROM jobs",
+		TargetValue:          5,
+		ActivationValue:      1,
+		CacheDurationSeconds: 30,
+		QueryTimeout:         "60s",
+		queryTimeout:         time.Minute,
+		gcpAuthorization: &gcp.AuthorizationMetadata{
+			GoogleApplicationCredentials: "{}",
+		},
+	}, "all properly formed"},
+
+	{map[string]string{"GoogleApplicationCredentials": "Creds"}, map[string]string{"projectID": "myproject", "query": "SELECT 1", "location": "EU", "cacheDurationSeconds": "0"}, "", false, &gcpBigQueryMetadata{
+		ProjectID:            "myproject",
+		Query:                "SELECT 1",
+		Location:             "EU",
+		TargetValue:          1,
+		CacheDurationSeconds: 0,
+		QueryTimeout:         "60s",
+		queryTimeout:         time.Minute,
+		gcpAuthorization: &gcp.AuthorizationMetadata{
+			GoogleApplicationCredentials: "Creds",
+		},
+	}, "credentials from auth params and caching disabled"},
+
+	{nil, map[string]string{"projectID": "myproject", "query": "SELECT 1"}, kedav1alpha1.PodIdentityProviderGCP, false, &gcpBigQueryMetadata{
+		ProjectID:            "myproject",
+		Query:                "SELECT 1",
+		TargetValue:          1,
+		CacheDurationSeconds: 30,
+		QueryTimeout:         "60s",
+		queryTimeout:         time.Minute,
+		gcpAuthorization: &gcp.AuthorizationMetadata{
+			PodIdentityProviderEnabled: true,
+		},
+	}, "workload identity"},
+
+	{nil, map[string]string{"query": "SELECT 1", "credentialsFromEnv": "SAMPLE_CREDS"}, "", true, nil, "missing projectID"},
+
+	{nil, map[string]string{"projectID": "myproject", "credentialsFromEnv": "SAMPLE_CREDS"}, "", true, nil, "missing query"},
+
+	{nil, map[string]string{"projectID": "myproject", "query": "SELECT 1"}, "", true, nil, "missing credentials"},
+
+	{nil, map[string]string{"projectID": "myproject", "query": "SELECT 1", "targetValue": "0", "credentialsFromEnv": "SAMPLE_CREDS"}, "", true, nil, "targetValue isn't positive"},
+
+	{nil, map[string]string{"projectID": "myproject", "query": "SELECT 1", "activationValue": "AA", "credentialsFromEnv": "SAMPLE_CREDS"}, "", true, nil, "malformed activationValue"},
+
+	{nil, map[string]string{"projectID": "myproject", "query": "SELECT 1", "cacheDurationSeconds": "-1", "credentialsFromEnv": "SAMPLE_CREDS"}, "", true, nil, "negative cacheDurationSeconds"},
+
+	{nil, map[string]string{"projectID": "myproject", "query": "SELECT 1", "queryTimeout": "90s", "credentialsFromEnv": "SAMPLE_CREDS"}, "", false, &gcpBigQueryMetadata{
+		ProjectID:            "myproject",
+		Query:                "SELECT 1",
+		TargetValue:          1,
+		CacheDurationSeconds: 30,
+		QueryTimeout:         "90s",
+		queryTimeout:         90 * time.Second,
+		gcpAuthorization: &gcp.AuthorizationMetadata{
+			GoogleApplicationCredentials: "{}",
+		},
+	}, "custom queryTimeout"},
+
+	{nil, map[string]string{"projectID": "myproject", "query": "SELECT 1", "queryTimeout": "0s", "credentialsFromEnv": "SAMPLE_CREDS"}, "", true, nil, "queryTimeout isn't positive"},
+
+	{nil, map[string]string{"projectID": "myproject", "query": "SELECT 1", "queryTimeout": "60", "credentialsFromEnv": "SAMPLE_CREDS"}, "", true, nil, "queryTimeout without unit"},
+}
+
+var gcpBigQueryMetricIdentifiers = []gcpBigQueryMetricIdentifier{
+	{&testGcpBigQueryMetadata[1], 0, "s0-gcp-bigquery"},
+	{&testGcpBigQueryMetadata[1], 1, "s1-gcp-bigquery"},
+}
+
+func TestGcpBigQueryParseMetadata(t *testing.T) {
+	for _, testData := range testGcpBigQueryMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			meta, err := parseGcpBigQueryMetadata(&scalersconfig.ScalerConfig{
+				AuthParams:      testData.authParams,
+				TriggerMetadata: testData.metadata,
+				ResolvedEnv:     testGcpBigQueryResolvedEnv,
+				PodIdentity:     kedav1alpha1.AuthPodIdentity{Provider: testData.podIdentity},
+			})
+			if testData.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, meta)
+		})
+	}
+}
+
+func TestGcpBigQueryGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range gcpBigQueryMetricIdentifiers {
+		meta, err := parseGcpBigQueryMetadata(&scalersconfig.ScalerConfig{
+			AuthParams:      testData.metadataTestData.authParams,
+			TriggerMetadata: testData.metadataTestData.metadata,
+			ResolvedEnv:     testGcpBigQueryResolvedEnv,
+			TriggerIndex:    testData.triggerIndex,
+		})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockGcpBigQueryScaler := gcpBigQueryScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockGcpBigQueryScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+// newFakeBigQueryService returns a BigQuery client pointing to a fake BigQuery API
+// answering with the given responses in sequence
+func newFakeBigQueryService(t *testing.T, requests *[]string, responses ...string) *bigquery.Service {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(*requests) >= len(responses) {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		response := responses[len(*requests)]
+		*requests = append(*requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	service, err := bigquery.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatal("Could not create BigQuery client:", err)
+	}
+	return service
+}
+
+func TestGcpBigQueryGetMetricsAndActivity(t *testing.T) {
+	testCases := []struct {
+		name             string
+		responses        []string
+		expectedRequests []string
+		expectedValue    float64
+		expectedActive   bool
+		expectedError    string
+	}{
+		{
+			name:             "query completes immediately",
+			responses:        []string{`{"jobComplete": true, "rows": [{"f": [{"v": "12"}]}]}`},
+			expectedRequests: []string{"POST /projects/myproject/queries"},
+			expectedValue:    12,
+			expectedActive:   true,
+		},
+		{
+			name: "query completes asynchronously",
+			responses: []string{
+				`{"jobComplete": false, "jobReference": {"projectId": "myproject", "jobId": "job-1", "location": "EU"}}`,
+				`{"jobComplete": false, "jobReference": {"projectId": "myproject", "jobId": "job-1", "location": "EU"}}`,
+				`{"jobComplete": true, "rows": [{"f": [{"v": "2.5"}]}]}`,
+			},
+			expectedRequests: []string{
+				"POST /projects/myproject/queries",
+				"GET /projects/myproject/queries/job-1",
+				"GET /projects/myproject/queries/job-1",
+			},
+			expectedValue:  2.5,
+			expectedActive: true,
+		},
+		{
+			name:             "null result",
+			responses:        []string{`{"jobComplete": true, "rows": [{"f": [{"v": null}]}]}`},
+			expectedRequests: []string{"POST /projects/myproject/queries"},
+			expectedValue:    0,
+			expectedActive:   false,
+		},
+		{
+			name:             "no rows",
+			responses:        []string{`{"jobComplete": true}`},
+			expectedRequests: []string{"POST /projects/myproject/queries"},
+			expectedError:    "query must return a single row with a single column",
+		},
+		{
+			name:             "multiple columns",
+			responses:        []string{`{"jobComplete": true, "rows": [{"f": [{"v": "1"}, {"v": "2"}]}]}`},
+			expectedRequests: []string{"POST /projects/myproject/queries"},
+			expectedError:    "query must return a single row with a single column",
+		},
+		{
+			name:             "not a number",
+			responses:        []string{`{"jobComplete": true, "rows": [{"f": [{"v": "pending"}]}]}`},
+			expectedRequests: []string{"POST /projects/myproject/queries"},
+			expectedError:    "is not a number",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests []string
+			s := gcpBigQueryScaler{
+				service: newFakeBigQueryService(t, &requests, tc.responses...),
+				metadata: &gcpBigQueryMetadata{
+					ProjectID:       "myproject",
+					Query:           "SELECT COUNT(*) FROM jobs",
+					TargetValue:     1,
+					ActivationValue: 1,
+					queryTimeout:    time.Minute,
+				},
+				logger: logr.Discard(),
+			}
+
+			metrics, active, err := s.GetMetricsAndActivity(context.Background(), "s0-gcp-bigquery")
+			assert.Equal(t, tc.expectedRequests, requests)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedActive, active)
+			assert.Equal(t, int64(tc.expectedValue*1000), metrics[0].Value.MilliValue())
+		})
+	}
+}
+
+func TestGcpBigQueryQueryRequest(t *testing.T) {
+	var request bigquery.QueryRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		_, _ = w.Write([]byte(`{"jobComplete": true, "rows": [{"f": [{"v": "1"}]}]}`))
+	}))
+	defer server.Close()
+
+	service, err := bigquery.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	assert.NoError(t, err)
+
+	s := gcpBigQueryScaler{
+		service:  service,
+		metadata: &gcpBigQueryMetadata{ProjectID: "myproject", Query: "SELECT 1", Location: "EU", queryTimeout: time.Minute},
+		logger:   logr.Discard(),
+	}
+	_, err = s.runQuery(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "SELECT 1", request.Query)
+	assert.Equal(t, "EU", request.Location)
+	if assert.NotNil(t, request.UseLegacySql) {
+		assert.False(t, *request.UseLegacySql, "standard SQL must be used")
+	}
+}
+
+func TestGcpBigQueryCache(t *testing.T) {
+	var requests []string
+	s := gcpBigQueryScaler{
+		service: newFakeBigQueryService(t, &requests,
+			`{"jobComplete": true, "rows": [{"f": [{"v": "1"}]}]}`,
+			`{"jobComplete": true, "rows": [{"f": [{"v": "2"}]}]}`,
+		),
+		metadata: &gcpBigQueryMetadata{ProjectID: "myproject", Query: "SELECT 1", CacheDurationSeconds: 300, queryTimeout: time.Minute},
+		logger:   logr.Discard(),
+	}
+
+	for i := 0; i < 3; i++ {
+		value, err := s.getCachedQueryResult(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, float64(1), value)
+	}
+	assert.Len(t, requests, 1, "cached result must be reused")
+
+	// the query runs again once the cached result expired
+	s.cacheExpiresAt = time.Now().Add(-time.Second)
+	value, err := s.getCachedQueryResult(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), value)
+	assert.Len(t, requests, 2)
+}
+
+func TestGcpBigQueryQueryTimeout(t *testing.T) {
+	var mutex sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mutex.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost && r.URL.Path == "/projects/myproject/jobs/job-1/cancel" {
+			_, _ = w.Write([]byte(`{"job": {"jobReference": {"projectId": "myproject", "jobId": "job-1", "location": "EU"}}}`))
+			return
+		}
+		// the query never completes, each call waits a while before answering, as BigQuery does
+		time.Sleep(50 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"jobComplete": false, "jobReference": {"projectId": "myproject", "jobId": "job-1", "location": "EU"}}`))
+	}))
+	defer server.Close()
+
+	service, err := bigquery.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	assert.NoError(t, err)
+
+	s := gcpBigQueryScaler{
+		service:  service,
+		metadata: &gcpBigQueryMetadata{ProjectID: "myproject", Query: "SELECT 1", queryTimeout: 300 * time.Millisecond},
+		logger:   logr.Discard(),
+	}
+	_, err = s.runQuery(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Contains(t, requests, "POST /projects/myproject/jobs/job-1/cancel", "the query must be canceled once it timed out")
+}
+
+func TestGcpBigQuerySharedQuery(t *testing.T) {
+	var queries atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		queries.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jobComplete": true, "rows": [{"f": [{"v": "3"}]}]}`))
+	}))
+	defer server.Close()
+
+	service, err := bigquery.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	assert.NoError(t, err)
+
+	s := gcpBigQueryScaler{
+		service:  service,
+		metadata: &gcpBigQueryMetadata{ProjectID: "myproject", Query: "SELECT 1", CacheDurationSeconds: 300, queryTimeout: time.Minute},
+		logger:   logr.Discard(),
+	}
+
+	// a poll giving up doesn't stop the query the other polls wait for
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.getCachedQueryResult(canceledCtx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := s.getCachedQueryResult(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, float64(3), value)
+		}()
+	}
+
+	// the cache isn't locked while the query runs
+	assert.Eventually(t, func() bool { return queries.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, s.cacheMutex.TryLock(), "cache must not be locked during the query")
+	s.cacheMutex.Unlock()
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), queries.Load(), "concurrent polls must share a single query")
+}
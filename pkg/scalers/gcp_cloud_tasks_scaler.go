@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	cloudtasks "google.golang.org/api/cloudtasks/v2beta3"
+	option "google.golang.org/api/option"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
@@ -15,13 +17,18 @@ import (
 
 const (
 	cloudTasksStackDriverQueueSize = "cloudtasks.googleapis.com/queue/depth"
+
+	// cloudTasksSourceAPI reads the task count from the stats of the queue in the Cloud Tasks API
+	// instead of the queue depth metric of Cloud Monitoring, which is only sampled every 60 seconds
+	cloudTasksSourceAPI = "cloudTasksAPI"
 )
 
 type gcpCloudTasksScaler struct {
-	client     *gcp.StackDriverClient
-	metricType v2.MetricTargetType
-	metadata   *gcpCloudTaskMetadata
-	logger     logr.Logger
+	client       *gcp.StackDriverClient
+	tasksService *cloudtasks.Service
+	metricType   v2.MetricTargetType
+	metadata     *gcpCloudTaskMetadata
+	logger       logr.Logger
 }
 
 type gcpCloudTaskMetadata struct {
@@ -31,10 +38,25 @@ type gcpCloudTaskMetadata struct {
 
 	QueueName        string `keda:"name=queueName, order=triggerMetadata"`
 	ProjectID        string `keda:"name=projectID, order=triggerMetadata"`
+	Source           string `keda:"name=source, order=triggerMetadata, enum=stackdriver;cloudTasksAPI, optional"`
+	Location         string `keda:"name=location, order=triggerMetadata, optional"`
 	gcpAuthorization *gcp.AuthorizationMetadata
 	triggerIndex     int
 }
 
+func (m *gcpCloudTaskMetadata) Validate() error {
+	if m.Source != cloudTasksSourceAPI {
+		return nil
+	}
+	if m.Location == "" {
+		return fmt.Errorf("location is required with the %s source", cloudTasksSourceAPI)
+	}
+	if m.FilterDuration != 0 {
+		return fmt.Errorf("filterDuration isn't supported with the %s source", cloudTasksSourceAPI)
+	}
+	return nil
+}
+
 // NewGcpCloudTasksScaler creates a new cloudTaskScaler
 func NewGcpCloudTasksScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
@@ -73,6 +95,7 @@ func parseGcpCloudTasksMetadata(config *scalersconfig.ScalerConfig) (*gcpCloudTa
 }
 
 func (s *gcpCloudTasksScaler) Close(context.Context) error {
+	s.tasksService = nil
 	if s.client != nil {
 		err := s.client.Close()
 		s.client = nil
@@ -101,14 +124,23 @@ func (s *gcpCloudTasksScaler) GetMetricSpecForScaling(context.Context) []v2.Metr
 	return []v2.MetricSpec{metricSpec}
 }
 
-// GetMetricsAndActivity connects to Stack Driver and finds the size of the cloud task
+// GetMetricsAndActivity connects to Stack Driver or the Cloud Tasks API and finds the size of the cloud task queue
 func (s *gcpCloudTasksScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	metricType := cloudTasksStackDriverQueueSize
-
-	value, err := s.getMetrics(ctx, metricType)
-	if err != nil {
-		s.logger.Error(err, "error getting metric", "metricType", metricType)
-		return []external_metrics.ExternalMetricValue{}, false, err
+	var value float64
+	var err error
+	if s.metadata.Source == cloudTasksSourceAPI {
+		value, err = s.getQueueTasksCount(ctx)
+		if err != nil {
+			s.logger.Error(err, "error getting the stats of the queue", "queueName", s.metadata.QueueName)
+			return []external_metrics.ExternalMetricValue{}, false, err
+		}
+	} else {
+		metricType := cloudTasksStackDriverQueueSize
+		value, err = s.getMetrics(ctx, metricType)
+		if err != nil {
+			s.logger.Error(err, "error getting metric", "metricType", metricType)
+			return []external_metrics.ExternalMetricValue{}, false, err
+		}
 	}
 
 	metric := GenerateMetricInMili(metricName, value)
@@ -146,3 +178,40 @@ func (s *gcpCloudTasksScaler) getMetrics(ctx context.Context, metricType string)
 	// See: https://cloud.google.com/monitoring/api/metrics_gcp#gcp-cloudtasks
 	return s.client.GetMetrics(ctx, filter, s.metadata.ProjectID, nil, nil, s.metadata.FilterDuration)
 }
+
+func (s *gcpCloudTasksScaler) setCloudTasksService(ctx context.Context) error {
+	var opts []option.ClientOption
+	switch {
+	case s.metadata.gcpAuthorization.PodIdentityProviderEnabled:
+	case s.metadata.gcpAuthorization.GoogleApplicationCredentialsFile != "":
+		opts = append(opts, option.WithCredentialsFile(s.metadata.gcpAuthorization.GoogleApplicationCredentialsFile))
+	default:
+		opts = append(opts, option.WithCredentialsJSON([]byte(s.metadata.gcpAuthorization.GoogleApplicationCredentials)))
+	}
+
+	service, err := cloudtasks.NewService(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("error creating Cloud Tasks client: %w", err)
+	}
+	s.tasksService = service
+	return nil
+}
+
+// getQueueTasksCount gets the estimated number of tasks in the queue from its stats in the Cloud Tasks API
+func (s *gcpCloudTasksScaler) getQueueTasksCount(ctx context.Context) (float64, error) {
+	if s.tasksService == nil {
+		if err := s.setCloudTasksService(ctx); err != nil {
+			return -1, err
+		}
+	}
+
+	name := fmt.Sprintf("projects/%s/locations/%s/queues/%s", s.metadata.ProjectID, s.metadata.Location, s.metadata.QueueName)
+	queue, err := s.tasksService.Projects.Locations.Queues.Get(name).ReadMask("stats").Context(ctx).Do()
+	if err != nil {
+		return -1, fmt.Errorf("error getting queue %s: %w", name, err)
+	}
+	if queue.Stats == nil {
+		return 0, nil
+	}
+	return float64(queue.Stats.TasksCount), nil
+}
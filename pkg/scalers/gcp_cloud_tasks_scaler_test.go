@@ -2,10 +2,15 @@ package scalers
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	cloudtasks "google.golang.org/api/cloudtasks/v2beta3"
+	"google.golang.org/api/option"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/gcp"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
@@ -106,6 +111,26 @@ var testGcpCloudTasksMetadata = []parseGcpCloudTasksMetadataTestData{
 		triggerIndex: 0}, "test default value with specified activationVal"},
 
 	{nil, map[string]string{"queueName": "myQueue", "projectID": "myProject", "credentialsFromEnv": "SAMPLE_CREDS", "filterDuration": "invalid"}, true, nil, "test invalid filterDuration with default values"},
+
+	{nil, map[string]string{"queueName": "myQueue", "projectID": "myProject", "credentialsFromEnv": "SAMPLE_CREDS", "source": "cloudTasksAPI", "location": "europe-west1", "activationValue": "5"}, false, &gcpCloudTaskMetadata{
+		Value:           100,
+		ActivationValue: 5,
+		FilterDuration:  0,
+		QueueName:       "myQueue",
+		ProjectID:       "myProject",
+		Source:          "cloudTasksAPI",
+		Location:        "europe-west1",
+		gcpAuthorization: &gcp.AuthorizationMetadata{
+			GoogleApplicationCredentials: "{}",
+			PodIdentityProviderEnabled:   false,
+		},
+		triggerIndex: 0}, "Cloud Tasks API source"},
+
+	{nil, map[string]string{"queueName": "myQueue", "projectID": "myProject", "credentialsFromEnv": "SAMPLE_CREDS", "source": "cloudTasksAPI"}, true, nil, "Cloud Tasks API source without location"},
+
+	{nil, map[string]string{"queueName": "myQueue", "projectID": "myProject", "credentialsFromEnv": "SAMPLE_CREDS", "source": "cloudTasksAPI", "location": "europe-west1", "filterDuration": "5"}, true, nil, "Cloud Tasks API source with filterDuration"},
+
+	{nil, map[string]string{"queueName": "myQueue", "projectID": "myProject", "credentialsFromEnv": "SAMPLE_CREDS", "source": "monitoring"}, true, nil, "unknown source"},
 }
 
 var gcpCloudTasksMetricIdentifiers = []gcpCloudTasksMetricIdentifier{
@@ -143,7 +168,7 @@ func TestGcpCloudTasksGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockGcpCloudTasksScaler := gcpCloudTasksScaler{nil, "", meta, logr.Discard()}
+		mockGcpCloudTasksScaler := gcpCloudTasksScaler{nil, nil, "", meta, logr.Discard()}
 
 		metricSpec := mockGcpCloudTasksScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
@@ -152,3 +177,58 @@ func TestGcpCloudTasksGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestGcpCloudTasksGetMetricsAndActivityFromAPI(t *testing.T) {
+	testCases := []struct {
+		name           string
+		status         int
+		response       string
+		expectedValue  float64
+		expectedActive bool
+		isError        bool
+	}{
+		{name: "tasks in the queue", status: http.StatusOK, response: `{"name": "projects/myproject/locations/europe-west1/queues/myQueue", "stats": {"tasksCount": "12"}}`, expectedValue: 12, expectedActive: true},
+		{name: "tasks below activation", status: http.StatusOK, response: `{"stats": {"tasksCount": "3"}}`, expectedValue: 3, expectedActive: false},
+		{name: "empty queue", status: http.StatusOK, response: `{"stats": {}}`, expectedValue: 0, expectedActive: false},
+		{name: "queue not found", status: http.StatusNotFound, response: `{"error": {"code": 404, "message": "Queue does not exist."}}`, isError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v2beta3/projects/myproject/locations/europe-west1/queues/myQueue", r.URL.Path)
+				assert.Equal(t, "stats", r.URL.Query().Get("readMask"))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			service, err := cloudtasks.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			if err != nil {
+				t.Fatal("Could not create Cloud Tasks client:", err)
+			}
+			s := gcpCloudTasksScaler{
+				tasksService: service,
+				metadata: &gcpCloudTaskMetadata{
+					Value:           10,
+					ActivationValue: 5,
+					QueueName:       "myQueue",
+					ProjectID:       "myproject",
+					Source:          cloudTasksSourceAPI,
+					Location:        "europe-west1",
+				},
+				logger: logr.Discard(),
+			}
+
+			metrics, active, err := s.GetMetricsAndActivity(context.Background(), "s0-gcp-ct-myQueue")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedActive, active)
+			assert.Equal(t, int64(tc.expectedValue*1000), metrics[0].Value.MilliValue())
+		})
+	}
+}
@@ -145,25 +145,48 @@ func parseStackdriverMetadata(config *scalersconfig.ScalerConfig, logger logr.Lo
 }
 
 func parseAggregation(config *scalersconfig.ScalerConfig, logger logr.Logger) (*monitoringpb.Aggregation, error) {
-	if period, ok := config.TriggerMetadata["alignmentPeriodSeconds"]; ok {
-		if period == "" {
-			return nil, nil
-		}
+	aligner, err := getAggregationParameter(config, "alignmentPerSeriesAligner", "alignmentAligner")
+	if err != nil {
+		return nil, err
+	}
+	reducer, err := getAggregationParameter(config, "alignmentCrossSeriesReducer", "alignmentReducer")
+	if err != nil {
+		return nil, err
+	}
 
-		val, err := strconv.ParseInt(period, 10, 64)
-		if val < 60 {
-			logger.Error(err, "Error parsing alignmentPeriodSeconds - must be at least 60")
-			return nil, fmt.Errorf("error parsing alignmentPeriodSeconds - must be at least 60")
-		}
-		if err != nil {
-			logger.Error(err, "Error parsing alignmentPeriodSeconds")
-			return nil, fmt.Errorf("error parsing alignmentPeriodSeconds: %w", err)
+	period := config.TriggerMetadata["alignmentPeriodSeconds"]
+	if period == "" {
+		// the deprecated alignmentAligner and alignmentReducer were ignored without an alignment period,
+		// so they still are to keep the existing triggers working
+		if config.TriggerMetadata["alignmentPerSeriesAligner"] != "" || config.TriggerMetadata["alignmentCrossSeriesReducer"] != "" {
+			return nil, fmt.Errorf("alignmentPeriodSeconds is required when an aligner or a reducer is set")
 		}
+		return nil, nil
+	}
 
-		return gcp.NewStackdriverAggregator(val, config.TriggerMetadata["alignmentAligner"], config.TriggerMetadata["alignmentReducer"])
+	val, err := strconv.ParseInt(period, 10, 64)
+	if err != nil {
+		logger.Error(err, "Error parsing alignmentPeriodSeconds")
+		return nil, fmt.Errorf("error parsing alignmentPeriodSeconds: %w", err)
+	}
+	if val < 60 {
+		logger.Error(err, "Error parsing alignmentPeriodSeconds - must be at least 60")
+		return nil, fmt.Errorf("error parsing alignmentPeriodSeconds - must be at least 60")
 	}
 
-	return nil, nil
+	return gcp.NewStackdriverAggregator(val, aligner, reducer)
+}
+
+// getAggregationParameter returns the value of an aggregation parameter, falling back to its deprecated name
+func getAggregationParameter(config *scalersconfig.ScalerConfig, name, deprecatedName string) (string, error) {
+	val, deprecatedVal := config.TriggerMetadata[name], config.TriggerMetadata[deprecatedName]
+	if val != "" && deprecatedVal != "" {
+		return "", fmt.Errorf("%s and %s can't be set together, use only %s", name, deprecatedName, name)
+	}
+	if val != "" {
+		return val, nil
+	}
+	return deprecatedVal, nil
 }
 
 func initializeStackdriverClient(ctx context.Context, gcpAuthorization *gcp.AuthorizationMetadata, logger logr.Logger) (*gcp.StackDriverClient, error) {
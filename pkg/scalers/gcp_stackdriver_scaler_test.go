@@ -4,7 +4,11 @@ import (
 	"context"
 	"testing"
 
+	monitoringpb "cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
 	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
@@ -55,6 +59,18 @@ var testStackdriverMetadata = []parseStackdriverMetadataTestData{
 	{nil, map[string]string{"projectId": "myProject", "filter": sdFilter, "credentialsFromEnv": "SAMPLE_CREDS", "alignmentPeriodSeconds": "30"}, true},
 	// With bad alignment period
 	{nil, map[string]string{"projectId": "myProject", "filter": sdFilter, "credentialsFromEnv": "SAMPLE_CREDS", "alignmentPeriodSeconds": "a"}, true},
+	// With aggregation info using the per series aligner and cross series reducer
+	{nil, map[string]string{"projectId": "myProject", "filter": sdFilter, "credentialsFromEnv": "SAMPLE_CREDS", "alignmentPeriodSeconds": "120", "alignmentPerSeriesAligner": "max", "alignmentCrossSeriesReducer": "sum"}, false},
+	// With unknown per series aligner
+	{nil, map[string]string{"projectId": "myProject", "filter": sdFilter, "credentialsFromEnv": "SAMPLE_CREDS", "alignmentPeriodSeconds": "120", "alignmentPerSeriesAligner": "median"}, true},
+	// With unknown cross series reducer
+	{nil, map[string]string{"projectId": "myProject", "filter": sdFilter, "credentialsFromEnv": "SAMPLE_CREDS", "alignmentPeriodSeconds": "120", "alignmentCrossSeriesReducer": "avg"}, true},
+	// With both the per series aligner and its deprecated name
+	{nil, map[string]string{"projectId": "myProject", "filter": sdFilter, "credentialsFromEnv": "SAMPLE_CREDS", "alignmentPeriodSeconds": "120", "alignmentPerSeriesAligner": "max", "alignmentAligner": "sum"}, true},
+	// With aligner but without alignment period
+	{nil, map[string]string{"projectId": "myProject", "filter": sdFilter, "credentialsFromEnv": "SAMPLE_CREDS", "alignmentPerSeriesAligner": "max"}, true},
+	// With deprecated aligner and reducer but without alignment period, ignored as they always were
+	{nil, map[string]string{"projectId": "myProject", "filter": sdFilter, "credentialsFromEnv": "SAMPLE_CREDS", "alignmentAligner": "max", "alignmentReducer": "sum"}, false},
 	// properly formed float targetValue and activationTargetValue
 	{nil, map[string]string{"projectId": "myProject", "filter": sdFilter, "credentialsFromEnv": "SAMPLE_CREDS", "targetValue": "1.1", "activationTargetValue": "2.1"}, false},
 	// properly formed float valueIfNull
@@ -80,6 +96,49 @@ func TestStackdriverParseMetadata(t *testing.T) {
 	}
 }
 
+func TestStackdriverParseAggregation(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		metadata map[string]string
+		expected *monitoringpb.Aggregation
+	}{
+		{
+			"no aggregation",
+			map[string]string{},
+			nil,
+		},
+		{
+			"per series aligner and cross series reducer",
+			map[string]string{"alignmentPeriodSeconds": "300", "alignmentPerSeriesAligner": "rate", "alignmentCrossSeriesReducer": "percentile_95"},
+			&monitoringpb.Aggregation{
+				AlignmentPeriod:    &durationpb.Duration{Seconds: 300},
+				PerSeriesAligner:   monitoringpb.Aggregation_ALIGN_RATE,
+				CrossSeriesReducer: monitoringpb.Aggregation_REDUCE_PERCENTILE_95,
+			},
+		},
+		{
+			"deprecated aligner and reducer names without alignment period",
+			map[string]string{"alignmentAligner": "sum", "alignmentReducer": "mean"},
+			nil,
+		},
+		{
+			"deprecated aligner and reducer names",
+			map[string]string{"alignmentPeriodSeconds": "120", "alignmentAligner": "sum", "alignmentReducer": "mean"},
+			&monitoringpb.Aggregation{
+				AlignmentPeriod:    &durationpb.Duration{Seconds: 120},
+				PerSeriesAligner:   monitoringpb.Aggregation_ALIGN_SUM,
+				CrossSeriesReducer: monitoringpb.Aggregation_REDUCE_MEAN,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			aggregation, err := parseAggregation(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata}, logr.Discard())
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(tc.expected, aggregation), "expected %v, got %v", tc.expected, aggregation)
+		})
+	}
+}
+
 func TestGcpStackdriverGetMetricSpecForScaling(t *testing.T) {
 	for _, testData := range gcpStackdriverMetricIdentifiers {
 		meta, err := parseStackdriverMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, ResolvedEnv: testStackdriverResolvedEnv, TriggerIndex: testData.triggerIndex}, logr.Discard())
@@ -0,0 +1,238 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// gitlabPageSize is the maximum page size allowed by the GitLab API
+	gitlabPageSize = 100
+	// gitlabMaxPages bounds the pagination, so a misbehaving API can't make the scaler loop forever
+	gitlabMaxPages = 100
+)
+
+type gitlabRunnerScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *gitlabRunnerMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type gitlabRunnerMetadata struct {
+	GitLabAPIURL        string `keda:"name=gitlabAPIURL,        order=triggerMetadata;resolvedEnv, default=https://gitlab.com"`
+	PersonalAccessToken string `keda:"name=personalAccessToken, order=authParams"`
+	// The pending jobs are either looked up in a project or in all the projects of a group, including its subgroups.
+	// Both can be given by ID or by full path.
+	ProjectID string `keda:"name=projectID, order=triggerMetadata;resolvedEnv, optional"`
+	GroupID   string `keda:"name=groupID,   order=triggerMetadata;resolvedEnv, optional"`
+	// Tags are the tags of the runner, only jobs whose tags are all part of them are counted
+	Tags []string `keda:"name=tags, order=triggerMetadata, optional"`
+	// RunUntagged counts jobs without tags, which is always the case when the runner has no tags
+	RunUntagged       bool  `keda:"name=runUntagged,       order=triggerMetadata, default=false"`
+	TargetPendingJobs int64 `keda:"name=targetPendingJobs, order=triggerMetadata, default=1"`
+	ActivationValue   int64 `keda:"name=activationValue,   order=triggerMetadata, default=0"`
+	UnsafeSsl         bool  `keda:"name=unsafeSsl,         order=triggerMetadata, default=false"`
+
+	triggerIndex int
+}
+
+func (m *gitlabRunnerMetadata) Validate() error {
+	if (m.ProjectID == "") == (m.GroupID == "") {
+		return fmt.Errorf("exactly one of projectID or groupID must be provided")
+	}
+	if m.TargetPendingJobs <= 0 {
+		return fmt.Errorf("targetPendingJobs must be greater than 0")
+	}
+	return nil
+}
+
+type gitlabProject struct {
+	ID int64 `json:"id"`
+}
+
+type gitlabJob struct {
+	ID      int64    `json:"id"`
+	Status  string   `json:"status"`
+	TagList []string `json:"tag_list"`
+}
+
+// NewGitLabRunnerScaler creates a new GitLab runner scaler
+func NewGitLabRunnerScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseGitLabRunnerMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing gitlab-runner metadata: %w", err)
+	}
+
+	return &gitlabRunnerScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "gitlab_runner_scaler"),
+	}, nil
+}
+
+func parseGitLabRunnerMetadata(config *scalersconfig.ScalerConfig) (*gitlabRunnerMetadata, error) {
+	meta := &gitlabRunnerMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	meta.GitLabAPIURL = strings.TrimSuffix(meta.GitLabAPIURL, "/")
+	return meta, nil
+}
+
+// getPaginated requests all the pages of the list endpoint and passes the body of each one to handle
+func (s *gitlabRunnerScaler) getPaginated(ctx context.Context, path string, query url.Values, handle func([]byte) error) error {
+	query.Set("per_page", strconv.Itoa(gitlabPageSize))
+	page := "1"
+	for i := 0; i < gitlabMaxPages && page != ""; i++ {
+		query.Set("page", page)
+		u := fmt.Sprintf("%s/api/v4%s?%s", s.metadata.GitLabAPIURL, path, query.Encode())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("PRIVATE-TOKEN", s.metadata.PersonalAccessToken)
+
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("the GitLab API returned error. url: %s status: %d response: %s", path, resp.StatusCode, string(body))
+		}
+		if err := handle(body); err != nil {
+			return err
+		}
+		// GitLab doesn't send the header on the last page
+		page = resp.Header.Get("X-Next-Page")
+	}
+	return nil
+}
+
+// getProjects returns the projects the pending jobs are looked up in
+func (s *gitlabRunnerScaler) getProjects(ctx context.Context) ([]string, error) {
+	if s.metadata.ProjectID != "" {
+		return []string{s.metadata.ProjectID}, nil
+	}
+
+	var projects []string
+	path := fmt.Sprintf("/groups/%s/projects", url.PathEscape(s.metadata.GroupID))
+	query := url.Values{"include_subgroups": []string{"true"}, "archived": []string{"false"}}
+	err := s.getPaginated(ctx, path, query, func(body []byte) error {
+		var page []gitlabProject
+		if err := json.Unmarshal(body, &page); err != nil {
+			return err
+		}
+		for _, project := range page {
+			projects = append(projects, strconv.FormatInt(project.ID, 10))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing projects of group %s: %w", s.metadata.GroupID, err)
+	}
+	return projects, nil
+}
+
+// canGitLabRunnerPickJob checks if a runner with the given tags can pick the job
+func canGitLabRunnerPickJob(jobTags []string, runnerTags []string, runUntagged bool) bool {
+	if len(jobTags) == 0 {
+		return runUntagged || len(runnerTags) == 0
+	}
+	for _, jobTag := range jobTags {
+		if !contains(runnerTags, jobTag) {
+			return false
+		}
+	}
+	return true
+}
+
+// getPendingJobsCount returns the number of pending jobs the runner can pick
+func (s *gitlabRunnerScaler) getPendingJobsCount(ctx context.Context) (int64, error) {
+	projects, err := s.getProjects(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, project := range projects {
+		path := fmt.Sprintf("/projects/%s/jobs", url.PathEscape(project))
+		query := url.Values{"scope[]": []string{"pending"}}
+		err := s.getPaginated(ctx, path, query, func(body []byte) error {
+			var jobs []gitlabJob
+			if err := json.Unmarshal(body, &jobs); err != nil {
+				return err
+			}
+			for _, job := range jobs {
+				if job.Status == "pending" && canGitLabRunnerPickJob(job.TagList, s.metadata.Tags, s.metadata.RunUntagged) {
+					count++
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("error listing pending jobs of project %s: %w", project, err)
+		}
+	}
+	return count, nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *gitlabRunnerScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	scope := s.metadata.ProjectID
+	if scope == "" {
+		scope = s.metadata.GroupID
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("gitlab-runner-%s", scope))),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetPendingJobs),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of pending jobs and the activity of the scaler
+func (s *gitlabRunnerScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	count, err := s.getPendingJobsCount(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting gitlab pending jobs: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(count))
+
+	return []external_metrics.ExternalMetricValue{metric}, count > s.metadata.ActivationValue, nil
+}
+
+// Close closes the http client connection
+func (s *gitlabRunnerScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
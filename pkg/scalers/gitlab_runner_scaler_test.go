@@ -0,0 +1,241 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v2 "k8s.io/api/autoscaling/v2"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+const (
+	gitlabTestToken = "glpat-token"
+
+	gitlabPendingJobsPage1 = `[
+{"id":1,"status":"pending","tag_list":["docker"]},
+{"id":2,"status":"pending","tag_list":["docker","linux"]},
+{"id":3,"status":"pending","tag_list":["windows"]}
+]`
+	gitlabPendingJobsPage2 = `[
+{"id":4,"status":"pending","tag_list":[]},
+{"id":5,"status":"pending","tag_list":["docker"]}
+]`
+	gitlabOtherProjectPendingJobs = `[
+{"id":6,"status":"pending","tag_list":["linux"]}
+]`
+	gitlabGroupProjectsPage1 = `[{"id":42}]`
+	gitlabGroupProjectsPage2 = `[{"id":43}]`
+)
+
+type parseGitLabRunnerMetadataTestData struct {
+	name       string
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+var testGitLabRunnerMetadata = []parseGitLabRunnerMetadataTestData{
+	{
+		name:       "project",
+		metadata:   map[string]string{"projectID": "42", "tags": "docker,linux", "targetPendingJobs": "2", "activationValue": "1"},
+		authParams: map[string]string{"personalAccessToken": gitlabTestToken},
+	},
+	{
+		name:       "group path on self-hosted instance",
+		metadata:   map[string]string{"gitlabAPIURL": "https://gitlab.example.com/", "groupID": "platform/ci", "runUntagged": "true"},
+		authParams: map[string]string{"personalAccessToken": gitlabTestToken},
+	},
+	{
+		name:     "missing token",
+		metadata: map[string]string{"projectID": "42"},
+		isError:  true,
+	},
+	{
+		name:       "missing project and group",
+		metadata:   map[string]string{"tags": "docker"},
+		authParams: map[string]string{"personalAccessToken": gitlabTestToken},
+		isError:    true,
+	},
+	{
+		name:       "project and group",
+		metadata:   map[string]string{"projectID": "42", "groupID": "platform"},
+		authParams: map[string]string{"personalAccessToken": gitlabTestToken},
+		isError:    true,
+	},
+	{
+		name:       "invalid targetPendingJobs",
+		metadata:   map[string]string{"projectID": "42", "targetPendingJobs": "0"},
+		authParams: map[string]string{"personalAccessToken": gitlabTestToken},
+		isError:    true,
+	},
+	{
+		name:       "invalid runUntagged",
+		metadata:   map[string]string{"projectID": "42", "runUntagged": "maybe"},
+		authParams: map[string]string{"personalAccessToken": gitlabTestToken},
+		isError:    true,
+	},
+}
+
+func TestParseGitLabRunnerMetadata(t *testing.T) {
+	for _, testData := range testGitLabRunnerMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			_, err := parseGitLabRunnerMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCanGitLabRunnerPickJob(t *testing.T) {
+	assert.True(t, canGitLabRunnerPickJob([]string{"docker"}, []string{"docker", "linux"}, false))
+	assert.True(t, canGitLabRunnerPickJob([]string{"docker", "linux"}, []string{"docker", "linux"}, false))
+	assert.False(t, canGitLabRunnerPickJob([]string{"docker", "windows"}, []string{"docker", "linux"}, false))
+	assert.False(t, canGitLabRunnerPickJob([]string{"docker"}, nil, true))
+	assert.False(t, canGitLabRunnerPickJob(nil, []string{"docker"}, false))
+	assert.True(t, canGitLabRunnerPickJob(nil, []string{"docker"}, true))
+	assert.True(t, canGitLabRunnerPickJob(nil, nil, false))
+}
+
+func TestGitLabRunnerGetMetricSpecForScaling(t *testing.T) {
+	meta, err := parseGitLabRunnerMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"groupID": "platform/ci", "targetPendingJobs": "3"},
+		AuthParams:      map[string]string{"personalAccessToken": gitlabTestToken},
+		TriggerIndex:    1,
+	})
+	require.NoError(t, err)
+
+	scaler := gitlabRunnerScaler{metricType: v2.AverageValueMetricType, metadata: meta}
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, "s1-gitlab-runner-platform-ci", metricSpec[0].External.Metric.Name)
+	assert.Equal(t, int64(3), metricSpec[0].External.Target.AverageValue.Value())
+}
+
+func newGitLabTestServer(t *testing.T) *httptest.Server {
+	// paginate returns the page of the response requested, announcing the next one like GitLab does
+	paginate := func(w http.ResponseWriter, r *http.Request, pages ...string) {
+		page := 1
+		fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+		if page > len(pages) {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		if page < len(pages) {
+			w.Header().Set("X-Next-Page", fmt.Sprint(page+1))
+		}
+		fmt.Fprint(w, pages[page-1])
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != gitlabTestToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("per_page") != "100" {
+			t.Errorf("unexpected per_page %q", r.URL.Query().Get("per_page"))
+		}
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/42/jobs", "/api/v4/projects/platform%2Fci%2Fapp/jobs":
+			if r.URL.Query().Get("scope[]") != "pending" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			paginate(w, r, gitlabPendingJobsPage1, gitlabPendingJobsPage2)
+		case "/api/v4/projects/43/jobs":
+			paginate(w, r, gitlabOtherProjectPendingJobs)
+		case "/api/v4/groups/platform%2Fci/projects":
+			if r.URL.Query().Get("include_subgroups") != "true" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			paginate(w, r, gitlabGroupProjectsPage1, gitlabGroupProjectsPage2)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestGitLabRunnerGetMetricsAndActivity(t *testing.T) {
+	server := newGitLabTestServer(t)
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		token         string
+		expectedValue int64
+		isActive      bool
+		isError       bool
+	}{
+		{
+			name:          "project with tags",
+			metadata:      map[string]string{"projectID": "42", "tags": "docker,linux"},
+			expectedValue: 3,
+			isActive:      true,
+		},
+		{
+			name:          "project path with untagged jobs",
+			metadata:      map[string]string{"projectID": "platform/ci/app", "tags": "docker", "runUntagged": "true"},
+			expectedValue: 3,
+			isActive:      true,
+		},
+		{
+			name:          "below activation value",
+			metadata:      map[string]string{"projectID": "42", "tags": "windows", "activationValue": "1"},
+			expectedValue: 1,
+			isActive:      false,
+		},
+		{
+			name:          "group",
+			metadata:      map[string]string{"groupID": "platform/ci", "tags": "docker,linux"},
+			expectedValue: 4,
+			isActive:      true,
+		},
+		{
+			name:     "unknown project",
+			metadata: map[string]string{"projectID": "1"},
+			isError:  true,
+		},
+		{
+			name:     "invalid token",
+			metadata: map[string]string{"projectID": "42"},
+			token:    "wrong",
+			isError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata := map[string]string{"gitlabAPIURL": server.URL}
+			for k, v := range tc.metadata {
+				metadata[k] = v
+			}
+			token := tc.token
+			if token == "" {
+				token = gitlabTestToken
+			}
+			scaler, err := NewGitLabRunnerScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata: metadata,
+				AuthParams:      map[string]string{"personalAccessToken": token},
+			})
+			require.NoError(t, err)
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "metric")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package scalers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// httpClientCache shares the HTTP clients of the scalers querying the same endpoint with the same
+// credentials, so the connections to vendor APIs are reused across polls and scalers
+type httpClientCache struct {
+	lock    sync.Mutex
+	clients map[string]*sharedHTTPClient
+}
+
+type sharedHTTPClient struct {
+	client *http.Client
+	refs   int
+}
+
+var sharedHTTPClients = &httpClientCache{clients: map[string]*sharedHTTPClient{}}
+
+// httpClientCacheKey builds the cache key of an endpoint and its credentials, hashed so the
+// credentials aren't kept in clear as map keys
+func httpClientCacheKey(timeout time.Duration, unsafeSsl, useHTTP2 bool, endpointAndAuth ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(endpointAndAuth, "\x00")))
+	return fmt.Sprintf("%s-%s-%t-%t", hex.EncodeToString(hash[:]), timeout, unsafeSsl, useHTTP2)
+}
+
+// acquire returns the client cached for the key, creating it if needed. Every call must be
+// paired with a release of the key once the client isn't used anymore.
+func (c *httpClientCache) acquire(key string, timeout time.Duration, unsafeSsl, useHTTP2 bool) *http.Client {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	shared, ok := c.clients[key]
+	if !ok {
+		client := kedautil.CreateHTTPClient(timeout, unsafeSsl)
+		if transport, ok := client.Transport.(*http.Transport); ok {
+			// a custom TLS config disables HTTP/2 unless it is explicitly requested
+			transport.ForceAttemptHTTP2 = useHTTP2
+		}
+		shared = &sharedHTTPClient{client: client}
+		c.clients[key] = shared
+	}
+	shared.refs++
+	return shared.client
+}
+
+// release drops a reference to the client cached for the key, closing its connections
+// once no scaler uses it anymore
+func (c *httpClientCache) release(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	shared, ok := c.clients[key]
+	if !ok {
+		return
+	}
+	shared.refs--
+	if shared.refs <= 0 {
+		shared.client.CloseIdleConnections()
+		delete(c.clients, key)
+	}
+}
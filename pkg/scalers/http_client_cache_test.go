@@ -0,0 +1,143 @@
+package scalers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+func TestHTTPClientCacheReusesConnections(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Proto", r.Proto)
+	}))
+	server.EnableHTTP2 = true
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	cache := &httpClientCache{clients: map[string]*sharedHTTPClient{}}
+	key := httpClientCacheKey(time.Second, true, true, server.URL, "token")
+
+	// every poll acquires the client of its scaler, and the scalers share it
+	for i := 0; i < 3; i++ {
+		client := cache.acquire(key, time.Second, true, true)
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, "HTTP/2.0", resp.Header.Get("X-Proto"))
+	}
+	assert.Equal(t, int32(1), connections.Load())
+
+	for i := 0; i < 3; i++ {
+		cache.release(key)
+	}
+	assert.Empty(t, cache.clients)
+}
+
+func TestHTTPClientCacheWithoutHTTP2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Proto", r.Proto)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	cache := &httpClientCache{clients: map[string]*sharedHTTPClient{}}
+	key := httpClientCacheKey(time.Second, true, false, server.URL)
+	client := cache.acquire(key, time.Second, true, false)
+	defer cache.release(key)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "HTTP/1.1", resp.Header.Get("X-Proto"))
+}
+
+func TestHTTPClientCacheKeys(t *testing.T) {
+	cache := &httpClientCache{clients: map[string]*sharedHTTPClient{}}
+	first := httpClientCacheKey(time.Second, false, true, "https://api.example.com", "key-1")
+	second := httpClientCacheKey(time.Second, false, true, "https://api.example.com", "key-2")
+	assert.NotEqual(t, first, second)
+	assert.NotEqual(t, first, httpClientCacheKey(time.Second, false, false, "https://api.example.com", "key-1"))
+	assert.NotContains(t, first, "key-1")
+
+	assert.Same(t, cache.acquire(first, time.Second, false, true), cache.acquire(first, time.Second, false, true))
+	assert.NotSame(t, cache.acquire(first, time.Second, false, true), cache.acquire(second, time.Second, false, true))
+
+	cache.release(first)
+	cache.release(first)
+	assert.Contains(t, cache.clients, first)
+	cache.release(first)
+	assert.NotContains(t, cache.clients, first)
+	cache.release(second)
+	assert.Empty(t, cache.clients)
+
+	// releasing an unknown key is a no-op
+	cache.release(first)
+}
+
+func TestNewRelicScalerSharesHTTPClient(t *testing.T) {
+	newScaler := func(queryKey string) *newrelicScaler {
+		scaler, err := NewNewRelicScaler(&scalersconfig.ScalerConfig{
+			TriggerMetadata: map[string]string{"account": "0", "threshold": "100", "nrql": "SELECT average(duration) from Transaction"},
+			AuthParams:      map[string]string{"queryKey": queryKey},
+		})
+		require.NoError(t, err)
+		return scaler.(*newrelicScaler)
+	}
+
+	first := newScaler("shared-key")
+	second := newScaler("shared-key")
+	other := newScaler("other-key")
+	assert.Equal(t, first.httpClientKey, second.httpClientKey)
+	assert.NotEqual(t, first.httpClientKey, other.httpClientKey)
+	assert.Equal(t, 2, sharedHTTPClients.clients[first.httpClientKey].refs)
+
+	key := first.httpClientKey
+	assert.NoError(t, first.Close(context.Background()))
+	// closing twice must not release the client used by the other scaler
+	assert.NoError(t, first.Close(context.Background()))
+	assert.Contains(t, sharedHTTPClients.clients, key)
+	assert.NoError(t, second.Close(context.Background()))
+	assert.NotContains(t, sharedHTTPClients.clients, key)
+	assert.NoError(t, other.Close(context.Background()))
+}
+
+func TestDatadogClusterAgentScalerSharesHTTPClient(t *testing.T) {
+	newScaler := func(token string) *datadogScaler {
+		scaler, err := NewDatadogScaler(context.Background(), &scalersconfig.ScalerConfig{
+			TriggerMetadata: map[string]string{"useClusterAgentProxy": "true", "datadogMetricName": "nginx-hits", "datadogMetricNamespace": "default", "targetValue": "2", "type": "global"},
+			AuthParams:      map[string]string{"token": token, "datadogNamespace": "datadog", "datadogMetricsService": "datadog-cluster-agent-metrics-api", "datadogMetricsServicePort": "8080", "unsafeSsl": "true", "authMode": "bearer"},
+		})
+		require.NoError(t, err)
+		return scaler.(*datadogScaler)
+	}
+
+	first := newScaler("token")
+	second := newScaler("token")
+	other := newScaler("other-token")
+	assert.Same(t, first.httpClient, second.httpClient)
+	assert.NotSame(t, first.httpClient, other.httpClient)
+	assert.True(t, first.httpClient.Transport.(*http.Transport).ForceAttemptHTTP2)
+
+	key := first.httpClientKey
+	assert.NoError(t, first.Close(context.Background()))
+	assert.Contains(t, sharedHTTPClients.clients, key)
+	assert.NoError(t, second.Close(context.Background()))
+	assert.NotContains(t, sharedHTTPClients.clients, key)
+	assert.NoError(t, other.Close(context.Background()))
+}
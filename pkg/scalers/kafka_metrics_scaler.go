@@ -0,0 +1,231 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// kafkaMetricsScaler scales on a metric read from a metrics endpoint in the Prometheus text format,
+// e.g. the producer metrics of a Kafka client exposed through the JMX exporter
+type kafkaMetricsScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *kafkaMetricsMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type kafkaMetricsMetadata struct {
+	URL string `keda:"name=url, order=triggerMetadata"`
+	// Metric is a series selector, e.g. kafka_producer_producer_metrics_record_send_rate{client_id="app"}.
+	// The values of all the series matching it are summed up.
+	Metric          string  `keda:"name=metric,          order=triggerMetadata"`
+	Value           float64 `keda:"name=value,           order=triggerMetadata"`
+	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, default=0"`
+	UnsafeSsl       bool    `keda:"name=unsafeSsl,       order=triggerMetadata, default=false"`
+
+	// Authentication
+	Username    string `keda:"name=username,    order=authParams, optional"`
+	Password    string `keda:"name=password,    order=authParams, optional"`
+	BearerToken string `keda:"name=bearerToken, order=authParams, optional"`
+
+	// parsed from Metric
+	metricName string
+	matchers   []*labels.Matcher
+
+	triggerIndex int
+}
+
+func (m *kafkaMetricsMetadata) Validate() error {
+	if m.Value <= 0 {
+		return fmt.Errorf("value must be greater than 0")
+	}
+	if m.Password != "" && m.Username == "" {
+		return fmt.Errorf("password requires username")
+	}
+	if m.Username != "" && m.BearerToken != "" {
+		return fmt.Errorf("username and bearerToken can't be used together")
+	}
+	return nil
+}
+
+// NewKafkaMetricsScaler creates a new kafka-metrics scaler
+func NewKafkaMetricsScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseKafkaMetricsMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing kafka-metrics metadata: %w", err)
+	}
+
+	return &kafkaMetricsScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "kafka_metrics_scaler"),
+	}, nil
+}
+
+func parseKafkaMetricsMetadata(config *scalersconfig.ScalerConfig) (*kafkaMetricsMetadata, error) {
+	meta := &kafkaMetricsMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	metricName, matchers, err := parseKafkaMetricsSelector(meta.Metric)
+	if err != nil {
+		return nil, err
+	}
+	meta.metricName = metricName
+	meta.matchers = matchers
+	return meta, nil
+}
+
+// parseKafkaMetricsSelector splits the selector into the metric name and the label matchers
+func parseKafkaMetricsSelector(selector string) (string, []*labels.Matcher, error) {
+	matchers, err := parser.ParseMetricSelector(selector)
+	if err != nil {
+		return "", nil, fmt.Errorf("error parsing metric %q: %w", selector, err)
+	}
+
+	metricName := ""
+	labelMatchers := make([]*labels.Matcher, 0, len(matchers))
+	for _, matcher := range matchers {
+		if matcher.Name == labels.MetricName && matcher.Type == labels.MatchEqual {
+			metricName = matcher.Value
+			continue
+		}
+		labelMatchers = append(labelMatchers, matcher)
+	}
+	if metricName == "" {
+		return "", nil, fmt.Errorf("metric %q must contain a metric name", selector)
+	}
+	return metricName, labelMatchers, nil
+}
+
+// getKafkaMetricsValue sums the values of the series of the metric family matching all the matchers
+func getKafkaMetricsValue(body []byte, metricName string, matchers []*labels.Matcher) (float64, error) {
+	// Ensure EOL
+	reader := strings.NewReader(strings.ReplaceAll(string(body), "\r\n", "\n"))
+	familiesParser := expfmt.TextParser{}
+	families, err := familiesParser.TextToMetricFamilies(reader)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing metrics: %w", err)
+	}
+	family, ok := families[metricName]
+	if !ok {
+		return 0, fmt.Errorf("metric '%s' not found", metricName)
+	}
+
+	var value float64
+	var found bool
+	for _, metric := range family.GetMetric() {
+		seriesLabels := make(map[string]string, len(metric.GetLabel()))
+		for _, label := range metric.GetLabel() {
+			seriesLabels[label.GetName()] = label.GetValue()
+		}
+		match := true
+		for _, matcher := range matchers {
+			// a missing label matches like an empty one, as in PromQL
+			if !matcher.Matches(seriesLabels[matcher.Name]) {
+				match = false
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+
+		switch {
+		case metric.GetGauge() != nil:
+			value += metric.GetGauge().GetValue()
+		case metric.GetCounter() != nil:
+			value += metric.GetCounter().GetValue()
+		case metric.GetUntyped() != nil:
+			value += metric.GetUntyped().GetValue()
+		default:
+			return 0, fmt.Errorf("metric '%s' must be a gauge, counter or untyped metric", metricName)
+		}
+		found = true
+	}
+	if !found {
+		return 0, fmt.Errorf("no series of metric '%s' matches the labels", metricName)
+	}
+	return value, nil
+}
+
+func (s *kafkaMetricsScaler) getMetricValue(ctx context.Context) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.URL, nil)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case s.metadata.BearerToken != "":
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.metadata.BearerToken))
+	case s.metadata.Username != "":
+		req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error requesting metrics endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("metrics endpoint returned status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	return getKafkaMetricsValue(body, s.metadata.metricName, s.metadata.matchers)
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *kafkaMetricsScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("kafka-metrics-%s", s.metadata.metricName))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the value of the metric and the activity of the scaler
+func (s *kafkaMetricsScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting kafka metrics: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationValue, nil
+}
+
+// Close closes the http client connection
+func (s *kafkaMetricsScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
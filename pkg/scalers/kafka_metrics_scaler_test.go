@@ -0,0 +1,210 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+const kafkaMetricsSamplePayload = `# HELP kafka_producer_producer_metrics_record_send_rate The average number of records sent per second.
+# TYPE kafka_producer_producer_metrics_record_send_rate gauge
+kafka_producer_producer_metrics_record_send_rate{client_id="orders-1",} 120.5
+kafka_producer_producer_metrics_record_send_rate{client_id="orders-2",} 79.5
+kafka_producer_producer_metrics_record_send_rate{client_id="payments-1",} 10.0
+# HELP kafka_producer_producer_topic_metrics_record_send_total The total number of records sent for a topic.
+# TYPE kafka_producer_producer_topic_metrics_record_send_total counter
+kafka_producer_producer_topic_metrics_record_send_total{client_id="orders-1",topic="orders",} 5000.0
+kafka_producer_producer_topic_metrics_record_send_total{client_id="orders-1",topic="audit",} 42.0
+# HELP kafka_producer_producer_metrics_request_latency_avg The average request latency in ms.
+# TYPE kafka_producer_producer_metrics_request_latency_avg untyped
+kafka_producer_producer_metrics_request_latency_avg{client_id="orders-1",} 3.5
+# HELP kafka_producer_producer_metrics_batch_size summary
+# TYPE kafka_producer_producer_metrics_batch_size summary
+kafka_producer_producer_metrics_batch_size_sum 100
+kafka_producer_producer_metrics_batch_size_count 10
+`
+
+type parseKafkaMetricsMetadataTestData struct {
+	name       string
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+var testKafkaMetricsMetadata = []parseKafkaMetricsMetadataTestData{
+	{
+		name:     "metric with labels",
+		metadata: map[string]string{"url": "http://app:9404/metrics", "metric": `kafka_producer_producer_metrics_record_send_rate{client_id=~"orders-.*"}`, "value": "100", "activationValue": "5"},
+	},
+	{
+		name:       "basic auth",
+		metadata:   map[string]string{"url": "https://app:9404/metrics", "metric": "kafka_producer_producer_metrics_record_send_rate", "value": "100", "unsafeSsl": "true"},
+		authParams: map[string]string{"username": "user", "password": "pass"},
+	},
+	{
+		name:       "bearer auth",
+		metadata:   map[string]string{"url": "http://app:9404/metrics", "metric": "kafka_producer_producer_metrics_record_send_rate", "value": "100"},
+		authParams: map[string]string{"bearerToken": "token"},
+	},
+	{
+		name:     "missing url",
+		metadata: map[string]string{"metric": "kafka_producer_producer_metrics_record_send_rate", "value": "100"},
+		isError:  true,
+	},
+	{
+		name:     "missing metric",
+		metadata: map[string]string{"url": "http://app:9404/metrics", "value": "100"},
+		isError:  true,
+	},
+	{
+		name:     "missing value",
+		metadata: map[string]string{"url": "http://app:9404/metrics", "metric": "kafka_producer_producer_metrics_record_send_rate"},
+		isError:  true,
+	},
+	{
+		name:     "invalid value",
+		metadata: map[string]string{"url": "http://app:9404/metrics", "metric": "kafka_producer_producer_metrics_record_send_rate", "value": "0"},
+		isError:  true,
+	},
+	{
+		name:     "invalid selector",
+		metadata: map[string]string{"url": "http://app:9404/metrics", "metric": `kafka_producer_producer_metrics_record_send_rate{client_id=}`, "value": "100"},
+		isError:  true,
+	},
+	{
+		name:     "selector without metric name",
+		metadata: map[string]string{"url": "http://app:9404/metrics", "metric": `{client_id="orders-1"}`, "value": "100"},
+		isError:  true,
+	},
+	{
+		name:       "password without username",
+		metadata:   map[string]string{"url": "http://app:9404/metrics", "metric": "kafka_producer_producer_metrics_record_send_rate", "value": "100"},
+		authParams: map[string]string{"password": "pass"},
+		isError:    true,
+	},
+	{
+		name:       "basic and bearer auth",
+		metadata:   map[string]string{"url": "http://app:9404/metrics", "metric": "kafka_producer_producer_metrics_record_send_rate", "value": "100"},
+		authParams: map[string]string{"username": "user", "bearerToken": "token"},
+		isError:    true,
+	},
+}
+
+func TestParseKafkaMetricsMetadata(t *testing.T) {
+	for _, testData := range testKafkaMetricsMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			_, err := parseKafkaMetricsMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetKafkaMetricsValue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		metric   string
+		expected float64
+		isError  bool
+	}{
+		{name: "sum of all series", metric: "kafka_producer_producer_metrics_record_send_rate", expected: 210},
+		{name: "equal matcher", metric: `kafka_producer_producer_metrics_record_send_rate{client_id="orders-2"}`, expected: 79.5},
+		{name: "regex matcher", metric: `kafka_producer_producer_metrics_record_send_rate{client_id=~"orders-.*"}`, expected: 200},
+		{name: "not equal matcher", metric: `kafka_producer_producer_metrics_record_send_rate{client_id!="payments-1"}`, expected: 200},
+		{name: "counter", metric: `kafka_producer_producer_topic_metrics_record_send_total{topic="orders"}`, expected: 5000},
+		{name: "untyped", metric: "kafka_producer_producer_metrics_request_latency_avg", expected: 3.5},
+		{name: "unknown metric", metric: "kafka_producer_producer_metrics_unknown", isError: true},
+		{name: "no matching series", metric: `kafka_producer_producer_metrics_record_send_rate{client_id="unknown"}`, isError: true},
+		{name: "unsupported type", metric: "kafka_producer_producer_metrics_batch_size", isError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metricName, matchers, err := parseKafkaMetricsSelector(tc.metric)
+			require.NoError(t, err)
+
+			value, err := getKafkaMetricsValue([]byte(kafkaMetricsSamplePayload), metricName, matchers)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tc.expected, value, 0.0001)
+		})
+	}
+}
+
+func TestGetKafkaMetricsValueInvalidPayload(t *testing.T) {
+	_, err := getKafkaMetricsValue([]byte("not a metrics payload {"), "kafka_producer_producer_metrics_record_send_rate", nil)
+	assert.Error(t, err)
+}
+
+func TestKafkaMetricsGetMetricSpecForScaling(t *testing.T) {
+	meta, err := parseKafkaMetricsMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"url": "http://app:9404/metrics", "metric": `kafka_producer_producer_metrics_record_send_rate{client_id="orders-1"}`, "value": "100"},
+		TriggerIndex:    1,
+	})
+	require.NoError(t, err)
+
+	scaler := kafkaMetricsScaler{metadata: meta}
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, "s1-kafka-metrics-kafka_producer_producer_metrics_record_send_rate", metricSpec[0].External.Metric.Name)
+}
+
+func TestKafkaMetricsGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.Header.Get("Authorization"); token != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, kafkaMetricsSamplePayload)
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name            string
+		activationValue string
+		bearerToken     string
+		expectedValue   int64
+		isActive        bool
+		isError         bool
+	}{
+		{name: "active", activationValue: "100", bearerToken: "token", expectedValue: 200, isActive: true},
+		{name: "inactive", activationValue: "200", bearerToken: "token", expectedValue: 200, isActive: false},
+		{name: "unauthorized", activationValue: "0", bearerToken: "wrong", isError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scaler, err := NewKafkaMetricsScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata: map[string]string{
+					"url":             server.URL,
+					"metric":          `kafka_producer_producer_metrics_record_send_rate{client_id=~"orders-.*"}`,
+					"value":           "100",
+					"activationValue": tc.activationValue,
+				},
+				AuthParams: map[string]string{"bearerToken": tc.bearerToken},
+			})
+			require.NoError(t, err)
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "metric")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
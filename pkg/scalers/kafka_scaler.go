@@ -15,7 +15,7 @@ limitations under the License.
 */
 
 // This scaler is based on sarama library.
-// It lacks support for AWS MSK. For AWS MSK please see: apache-kafka scaler.
+// AWS MSK IAM authentication is supported through the aws_msk_iam SASL type.
 
 package scalers
 
@@ -23,16 +23,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
 	"github.com/kedacore/keda/v2/pkg/scalers/kafka"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
@@ -58,6 +63,7 @@ type kafkaMetadata struct {
 	bootstrapServers       []string
 	group                  string
 	topic                  string
+	topicRegex             *regexp.Regexp
 	partitionLimitation    []int32
 	lagThreshold           int64
 	activationLagThreshold int64
@@ -66,6 +72,33 @@ type kafkaMetadata struct {
 	excludePersistentLag   bool
 	version                sarama.KafkaVersion
 
+	// Whether the lag is the age in seconds of the oldest unconsumed message instead of an offset count,
+	// lagThreshold and activationLagThreshold being then given in seconds too
+	lagInSeconds bool
+
+	// Whether to scale on the size in bytes of the partition logs on the brokers instead of the lag,
+	// bytesThreshold and activationBytesThreshold being the targets then.
+	// Only the local segments are measured, DescribeLogDirs not reporting the size of the segments moved to
+	// remote storage, so scaleOnBytes doesn't support topics with tiered storage
+	scaleOnBytes             bool
+	bytesThreshold           int64
+	activationBytesThreshold int64
+
+	// When set, the lag is counted up to the offset of the first message produced at or after targetTimestamp
+	// instead of up to the latest offset, so the consumers catch up to a point in time when replaying a topic
+	targetTimestamp *time.Time
+
+	// When set, the lag of a partition doesn't count the records deleted by the retention: it's counted from the
+	// log start offset of the partition when the consumer group has no committed offset or committed one below it.
+	// The lag being an offset count, the records moved to remote storage by tiered storage (KIP-405) are counted
+	// whether this is set or not
+	clampLagToLogStartOffset bool
+
+	// Broker connection tuning, so an unreachable broker fails the poll instead of hanging it
+	dialTimeout time.Duration
+	readTimeout time.Duration
+	keepAlive   time.Duration
+
 	// If an invalid offset is found, whether to scale to 1 (false - the default) so consumption can
 	// occur or scale to 0 (true). See discussion in https://github.com/kedacore/keda/issues/2612
 	scaleToZeroOnInvalidOffset bool
@@ -94,12 +127,13 @@ type kafkaMetadata struct {
 	awsAuthorization awsutils.AuthorizationMetadata
 
 	// TLS
-	enableTLS   bool
-	cert        string
-	key         string
-	keyPassword string
-	ca          string
-	unsafeSsl   bool
+	enableTLS     bool
+	cert          string
+	key           string
+	keyPassword   string
+	ca            string
+	unsafeSsl     bool
+	tlsServerName string
 
 	triggerIndex int
 }
@@ -121,6 +155,7 @@ const (
 	KafkaSASLTypeSCRAMSHA512 kafkaSaslType = "scram_sha512"
 	KafkaSASLTypeOAuthbearer kafkaSaslType = "oauthbearer"
 	KafkaSASLTypeGSSAPI      kafkaSaslType = "gssapi"
+	KafkaSASLTypeAWSMSKIAM   kafkaSaslType = "aws_msk_iam"
 )
 
 type kafkaSaslOAuthTokenProvider string
@@ -139,6 +174,9 @@ const (
 	defaultKafkaActivationLagThreshold = 0
 	defaultOffsetResetPolicy           = latest
 	invalidOffset                      = -1
+	defaultKafkaDialTimeout            = 10 * time.Second
+	defaultKafkaReadTimeout            = 30 * time.Second
+	defaultKafkaKeepAlive              = 30 * time.Second
 )
 
 // NewKafkaScaler creates a new kafkaScaler
@@ -242,6 +280,11 @@ func parseKafkaAuthParams(config *scalersconfig.ScalerConfig, meta *kafkaMetadat
 			if err != nil {
 				return err
 			}
+		case mode == KafkaSASLTypeAWSMSKIAM:
+			err := parseSaslAWSMSKIAMParams(config, meta, mode)
+			if err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("err SASL mode %s given", mode)
 		}
@@ -273,7 +316,7 @@ func parseSaslOAuthParams(config *scalersconfig.ScalerConfig, meta *kafkaMetadat
 	case KafkaSASLOAuthTokenProviderBearer:
 		tokenProviderErr = parseSaslOAuthBearerParams(config, meta)
 	case KafkaSASLOAuthTokenProviderAWSMSKIAM:
-		tokenProviderErr = parseSaslOAuthAWSMSKIAMParams(config, meta)
+		tokenProviderErr = parseSaslOAuthAWSMSKIAMParams(config, meta, false)
 	default:
 		return fmt.Errorf("err SASL OAuth token provider %s given", tokenProviderType)
 	}
@@ -321,7 +364,49 @@ func parseSaslOAuthBearerParams(config *scalersconfig.ScalerConfig, meta *kafkaM
 	return nil
 }
 
-func parseSaslOAuthAWSMSKIAMParams(config *scalersconfig.ScalerConfig, meta *kafkaMetadata) error {
+// parseSaslAWSMSKIAMParams parses the aws_msk_iam SASL type, a shorthand for oauthbearer with the aws_msk_iam token provider
+// which falls back to the AWS default credentials chain of KEDA (e.g. IRSA) when no credentials are given
+func parseSaslAWSMSKIAMParams(config *scalersconfig.ScalerConfig, meta *kafkaMetadata, mode kafkaSaslType) error {
+	if err := parseSaslOAuthAWSMSKIAMParams(config, meta, true); err != nil {
+		return fmt.Errorf("error parsing AWS MSK IAM configuration: %w", err)
+	}
+
+	// the credentials must come from the pod identity, the operator identity, a role or access keys
+	auth := meta.awsAuthorization
+	if !auth.UsingPodIdentity {
+		switch identityOwner := config.TriggerMetadata["identityOwner"]; {
+		case identityOwner != "" && identityOwner != "pod" && identityOwner != "operator":
+			return fmt.Errorf("error parsing AWS MSK IAM configuration: identityOwner must be pod or operator, got %q", identityOwner)
+		case auth.PodIdentityOwner && auth.AwsRoleArn == "" && (auth.AwsAccessKeyID == "" || auth.AwsSecretAccessKey == ""):
+			return errors.New("error parsing AWS MSK IAM configuration: no awsRoleArn or AWS credentials given")
+		}
+	}
+
+	meta.saslType = mode
+	meta.tokenProvider = KafkaSASLOAuthTokenProviderAWSMSKIAM
+
+	return nil
+}
+
+// isAwsCredentialGiven checks if the trigger configures the AWS credentials to use, even partially
+func isAwsCredentialGiven(config *scalersconfig.ScalerConfig) bool {
+	if config.PodIdentity.Provider == kedav1alpha1.PodIdentityProviderAws {
+		return true
+	}
+	for _, param := range []string{"awsRoleArn", "awsAccessKeyID", "awsAccessKeyId", "awsSecretAccessKey", "awsSessionToken"} {
+		if config.AuthParams[param] != "" {
+			return true
+		}
+	}
+	for _, param := range []string{"identityOwner", "awsAccessKeyID", "awsAccessKeyIDFromEnv", "awsSecretAccessKeyFromEnv"} {
+		if config.TriggerMetadata[param] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func parseSaslOAuthAWSMSKIAMParams(config *scalersconfig.ScalerConfig, meta *kafkaMetadata, defaultToOperatorIdentity bool) error {
 	if !meta.enableTLS {
 		return errors.New("TLS is required for AWS MSK authentication")
 	}
@@ -332,7 +417,13 @@ func parseSaslOAuthAWSMSKIAMParams(config *scalersconfig.ScalerConfig, meta *kaf
 
 	meta.awsRegion = config.TriggerMetadata["awsRegion"]
 
-	auth, err := awsutils.GetAwsAuthorization(config.TriggerUniqueKey, meta.awsRegion, config.PodIdentity, config.TriggerMetadata, config.AuthParams, config.ResolvedEnv)
+	triggerMetadata := config.TriggerMetadata
+	if defaultToOperatorIdentity && !isAwsCredentialGiven(config) {
+		triggerMetadata = maps.Clone(config.TriggerMetadata)
+		triggerMetadata["identityOwner"] = "operator"
+	}
+
+	auth, err := awsutils.GetAwsAuthorization(config.TriggerUniqueKey, meta.awsRegion, config.PodIdentity, triggerMetadata, config.AuthParams, config.ResolvedEnv)
 	if err != nil {
 		return fmt.Errorf("error getting AWS authorization: %w", err)
 	}
@@ -362,6 +453,7 @@ func parseTLS(config *scalersconfig.ScalerConfig, meta *kafkaMetadata) error {
 		}
 		meta.unsafeSsl = unsafeSsl
 	}
+	meta.tlsServerName = config.TriggerMetadata["tlsServerName"]
 
 	if value, found := config.AuthParams["keyPassword"]; found {
 		meta.keyPassword = value
@@ -485,11 +577,24 @@ func parseKafkaMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger)
 		return meta, errors.New("no consumer group given")
 	}
 
+	if config.TriggerMetadata["topicRegex"] != "" && (config.TriggerMetadata["topic"] != "" || config.TriggerMetadata["topicFromEnv"] != "") {
+		return meta, errors.New("topic and topicRegex cannot be set simultaneously")
+	}
+
 	switch {
 	case config.TriggerMetadata["topicFromEnv"] != "":
 		meta.topic = config.ResolvedEnv[config.TriggerMetadata["topicFromEnv"]]
 	case config.TriggerMetadata["topic"] != "":
 		meta.topic = config.TriggerMetadata["topic"]
+	case config.TriggerMetadata["topicRegex"] != "":
+		// like the pattern subscriptions of the consumers, the regex has to match the whole topic name
+		topicRegex, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", config.TriggerMetadata["topicRegex"]))
+		if err != nil {
+			return meta, fmt.Errorf("error parsing topicRegex: %w", err)
+		}
+		meta.topicRegex = topicRegex
+		logger.V(1).Info(fmt.Sprintf("consumer group %q has a topic regex specified, "+
+			"will use the topics subscribed by the consumer group matching %q for scaling", meta.group, config.TriggerMetadata["topicRegex"]))
 	default:
 		meta.topic = ""
 		logger.V(1).Info(fmt.Sprintf("consumer group %q has no topic specified, "+
@@ -604,10 +709,152 @@ func parseKafkaMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger)
 		}
 		meta.version = version
 	}
+
+	meta.lagInSeconds = false
+	if val, ok := config.TriggerMetadata["lagInSeconds"]; ok {
+		t, err := strconv.ParseBool(val)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing lagInSeconds: %w", err)
+		}
+		meta.lagInSeconds = t
+
+		// messages only carry a timestamp since the message format v1 of Kafka 0.10
+		if meta.lagInSeconds && !meta.version.IsAtLeast(sarama.V0_10_0_0) {
+			return meta, fmt.Errorf("lagInSeconds requires kafka version 0.10.0.0 or later")
+		}
+	}
+
+	meta.scaleOnBytes = false
+	if val, ok := config.TriggerMetadata["scaleOnBytes"]; ok {
+		t, err := strconv.ParseBool(val)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing scaleOnBytes: %w", err)
+		}
+		meta.scaleOnBytes = t
+	}
+
+	if meta.scaleOnBytes {
+		if err := parseKafkaBytesThresholds(config, &meta); err != nil {
+			return meta, err
+		}
+	}
+
+	if val, ok := config.TriggerMetadata["targetTimestamp"]; ok {
+		if err := parseKafkaTargetTimestamp(val, &meta); err != nil {
+			return meta, err
+		}
+	}
+
+	meta.clampLagToLogStartOffset = false
+	if val, ok := config.TriggerMetadata["clampLagToLogStartOffset"]; ok {
+		t, err := strconv.ParseBool(val)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing clampLagToLogStartOffset: %w", err)
+		}
+		meta.clampLagToLogStartOffset = t
+
+		// the time lag already starts at the oldest retained message and the size of the logs doesn't depend on offsets
+		if meta.clampLagToLogStartOffset && (meta.lagInSeconds || meta.scaleOnBytes) {
+			return meta, fmt.Errorf("clampLagToLogStartOffset cannot be used with lagInSeconds or scaleOnBytes")
+		}
+	}
+
+	var err error
+	if meta.dialTimeout, err = parseKafkaDuration(config, "dialTimeout", defaultKafkaDialTimeout); err != nil {
+		return meta, err
+	}
+	if meta.dialTimeout <= 0 {
+		return meta, fmt.Errorf("dialTimeout must be positive")
+	}
+	if meta.readTimeout, err = parseKafkaDuration(config, "readTimeout", defaultKafkaReadTimeout); err != nil {
+		return meta, err
+	}
+	if meta.readTimeout <= 0 {
+		return meta, fmt.Errorf("readTimeout must be positive")
+	}
+	// a zero keepAlive leaves the keep-alive period to the operating system
+	if meta.keepAlive, err = parseKafkaDuration(config, "keepAlive", defaultKafkaKeepAlive); err != nil {
+		return meta, err
+	}
+	if meta.keepAlive < 0 {
+		return meta, fmt.Errorf("keepAlive must not be negative")
+	}
+
 	meta.triggerIndex = config.TriggerIndex
 	return meta, nil
 }
 
+// parseKafkaBytesThresholds parses the thresholds used when scaling on the size of the partition logs
+func parseKafkaBytesThresholds(config *scalersconfig.ScalerConfig, meta *kafkaMetadata) error {
+	// the size of the partition logs is read with the DescribeLogDirs API of Kafka 1.0 (KIP-113)
+	if !meta.version.IsAtLeast(sarama.V1_0_0_0) {
+		return fmt.Errorf("scaleOnBytes requires kafka version 1.0.0 or later")
+	}
+	if meta.lagInSeconds {
+		return fmt.Errorf("scaleOnBytes and lagInSeconds cannot be set simultaneously")
+	}
+	// the size of the logs doesn't depend on the offsets committed by the consumer group
+	if meta.excludePersistentLag || meta.limitToPartitionsWithLag {
+		return fmt.Errorf("scaleOnBytes cannot be used with excludePersistentLag or limitToPartitionsWithLag")
+	}
+
+	val, ok := config.TriggerMetadata["bytesThreshold"]
+	if !ok {
+		return fmt.Errorf("bytesThreshold must be given when using scaleOnBytes")
+	}
+	t, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return fmt.Errorf("error parsing bytesThreshold: %w", err)
+	}
+	if t <= 0 {
+		return fmt.Errorf("bytesThreshold must be positive number")
+	}
+	meta.bytesThreshold = t
+
+	meta.activationBytesThreshold = 0
+	if val, ok := config.TriggerMetadata["activationBytesThreshold"]; ok {
+		t, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing activationBytesThreshold: %w", err)
+		}
+		if t < 0 {
+			return fmt.Errorf("activationBytesThreshold must be positive number")
+		}
+		meta.activationBytesThreshold = t
+	}
+	return nil
+}
+
+// parseKafkaTargetTimestamp parses the RFC3339 timestamp the consumers have to catch up to
+func parseKafkaTargetTimestamp(val string, meta *kafkaMetadata) error {
+	targetTimestamp, err := time.Parse(time.RFC3339, strings.TrimSpace(val))
+	if err != nil {
+		return fmt.Errorf("error parsing targetTimestamp: %w", err)
+	}
+	// the offsets are looked up by timestamp with the ListOffsets API v1 of Kafka 0.10.1 (KIP-79)
+	if !meta.version.IsAtLeast(sarama.V0_10_1_0) {
+		return fmt.Errorf("targetTimestamp requires kafka version 0.10.1.0 or later")
+	}
+	if meta.lagInSeconds || meta.scaleOnBytes {
+		return fmt.Errorf("targetTimestamp cannot be used with lagInSeconds or scaleOnBytes")
+	}
+	meta.targetTimestamp = &targetTimestamp
+	return nil
+}
+
+// parseKafkaDuration parses the duration in the trigger metadata with the given name, e.g. "10s"
+func parseKafkaDuration(config *scalersconfig.ScalerConfig, name string, defaultValue time.Duration) (time.Duration, error) {
+	val, ok := config.TriggerMetadata[name]
+	if !ok {
+		return defaultValue, nil
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(val))
+	if err != nil {
+		return 0, fmt.Errorf("error parsing %s: %w", name, err)
+	}
+	return d, nil
+}
+
 func getKafkaClients(ctx context.Context, metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin, error) {
 	config, err := getKafkaClientConfig(ctx, metadata)
 	if err != nil {
@@ -633,6 +880,9 @@ func getKafkaClients(ctx context.Context, metadata kafkaMetadata) (sarama.Client
 func getKafkaClientConfig(ctx context.Context, metadata kafkaMetadata) (*sarama.Config, error) {
 	config := sarama.NewConfig()
 	config.Version = metadata.version
+	config.Net.DialTimeout = metadata.dialTimeout
+	config.Net.ReadTimeout = metadata.readTimeout
+	config.Net.KeepAlive = metadata.keepAlive
 
 	if metadata.saslType != KafkaSASLTypeNone && metadata.saslType != KafkaSASLTypeGSSAPI {
 		config.Net.SASL.Enable = true
@@ -646,6 +896,7 @@ func getKafkaClientConfig(ctx context.Context, metadata kafkaMetadata) (*sarama.
 		if err != nil {
 			return nil, err
 		}
+		kedautil.SetTLSServerName(tlsConfig, metadata.tlsServerName)
 		config.Net.TLS.Config = tlsConfig
 	}
 
@@ -663,7 +914,7 @@ func getKafkaClientConfig(ctx context.Context, metadata kafkaMetadata) (*sarama.
 		config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
 	}
 
-	if metadata.saslType == KafkaSASLTypeOAuthbearer {
+	if metadata.saslType == KafkaSASLTypeOAuthbearer || metadata.saslType == KafkaSASLTypeAWSMSKIAM {
 		config.Net.SASL.Mechanism = sarama.SASLTypeOAuth
 		switch metadata.tokenProvider {
 		case KafkaSASLOAuthTokenProviderBearer:
@@ -723,8 +974,16 @@ func (s *kafkaScaler) getTopicPartitions() (map[string][]int32, error) {
 		}
 
 		for topicName := range listCGOffsetResponse.Blocks {
+			if s.metadata.topicRegex != nil && !s.metadata.topicRegex.MatchString(topicName) {
+				continue
+			}
 			topicsToDescribe = append(topicsToDescribe, topicName)
 		}
+
+		if s.metadata.topicRegex != nil && len(topicsToDescribe) == 0 {
+			s.logger.V(1).Info(fmt.Sprintf("consumer group %q has no committed topic matching %q", s.metadata.group, s.metadata.topicRegex))
+			return map[string][]int32{}, nil
+		}
 	} else {
 		topicsToDescribe = []string{s.metadata.topic}
 	}
@@ -788,10 +1047,11 @@ func (s *kafkaScaler) getConsumerOffsets(topicPartitions map[string][]int32) (*s
 }
 
 // getLagForPartition returns (lag, lagWithPersistent, error)
+// When logStartOffsets is given, the lag doesn't count the records deleted below the log start offset of the partition
 // When excludePersistentLag is set to `false` (default), lag will always be equal to lagWithPersistent
 // When excludePersistentLag is set to `true`, if partition is deemed to have persistent lag, lag will be set to 0 and lagWithPersistent will be latestOffset - consumerOffset
 // These return values will allow proper scaling from 0 -> 1 replicas by the IsActive func.
-func (s *kafkaScaler) getLagForPartition(topic string, partitionID int32, offsets *sarama.OffsetFetchResponse, topicPartitionOffsets map[string]map[int32]int64) (int64, int64, error) {
+func (s *kafkaScaler) getLagForPartition(topic string, partitionID int32, offsets *sarama.OffsetFetchResponse, topicPartitionOffsets map[string]map[int32]int64, logStartOffsets map[string]map[int32]int64) (int64, int64, error) {
 	block := offsets.GetBlock(topic, partitionID)
 	if block == nil {
 		errMsg := fmt.Errorf("error finding offset block for topic %s and partition %d from offset block: %v", topic, partitionID, offsets.Blocks)
@@ -820,11 +1080,20 @@ func (s *kafkaScaler) getLagForPartition(topic string, partitionID int32, offset
 		return 0, 0, fmt.Errorf("error finding partition offset for topic %s", topic)
 	}
 	latestOffset := topicPartitionOffsets[topic][partitionID]
+	if s.metadata.targetTimestamp != nil && consumerOffset >= latestOffset {
+		// the consumer group has caught up to the target timestamp on this partition
+		return 0, 0, nil
+	}
+	logStartOffset := logStartOffsets[topic][partitionID]
 	if consumerOffset == invalidOffset && s.metadata.offsetResetPolicy == earliest {
 		if s.metadata.scaleToZeroOnInvalidOffset {
 			return 0, 0, nil
 		}
-		return latestOffset, latestOffset, nil
+		return latestOffset - logStartOffset, latestOffset - logStartOffset, nil
+	}
+	if consumerOffset < logStartOffset {
+		// the records at the committed offset were deleted, the consumer resumes from the log start offset
+		consumerOffset = logStartOffset
 	}
 
 	// This code block tries to prevent KEDA Kafka trigger from scaling the scale target based on erroneous events
@@ -880,11 +1149,16 @@ func (s *kafkaScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 		metricName = fmt.Sprintf("kafka-%s-topics", s.metadata.group)
 	}
 
+	threshold := s.metadata.lagThreshold
+	if s.metadata.scaleOnBytes {
+		threshold = s.metadata.bytesThreshold
+	}
+
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.lagThreshold),
+		Target: GetMetricTarget(s.metricType, threshold),
 	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: kafkaMetricType}
 	return []v2.MetricSpec{metricSpec}
@@ -910,6 +1184,9 @@ func (s *kafkaScaler) getConsumerAndProducerOffsets(topicPartitions map[string][
 	producerChan := make(chan producerOffsetResult, 1)
 	go func() {
 		producerOffsets, err := s.getProducerOffsets(topicPartitions)
+		if err == nil && s.metadata.targetTimestamp != nil {
+			producerOffsets, err = s.getTargetOffsets(topicPartitions, producerOffsets)
+		}
 		producerChan <- producerOffsetResult{producerOffsets, err}
 	}()
 
@@ -928,6 +1205,16 @@ func (s *kafkaScaler) getConsumerAndProducerOffsets(topicPartitions map[string][
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *kafkaScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	if s.metadata.scaleOnBytes {
+		totalBytes, err := s.getTotalBytes()
+		if err != nil {
+			return []external_metrics.ExternalMetricValue{}, false, err
+		}
+		metric := GenerateMetricInMili(metricName, float64(totalBytes))
+
+		return []external_metrics.ExternalMetricValue{metric}, totalBytes > s.metadata.activationBytesThreshold, nil
+	}
+
 	totalLag, totalLagWithPersistent, err := s.getTotalLag()
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, false, err
@@ -956,20 +1243,54 @@ func (s *kafkaScaler) getTotalLag() (int64, int64, error) {
 	totalTopicPartitions := int64(0)
 	partitionsWithLag := int64(0)
 
+	var logStartOffsets map[string]map[int32]int64
+	if s.metadata.clampLagToLogStartOffset {
+		logStartOffsets, err = s.getPartitionOffsets(topicPartitions, sarama.OffsetOldest)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	var timeLags map[string]map[int32]partitionTimeLag
+	if s.metadata.lagInSeconds {
+		timeLags, err = s.getTimeLags(consumerOffsets, producerOffsets)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
 	for topic, partitionsOffsets := range producerOffsets {
+		topicLag := int64(0)
 		for partition := range partitionsOffsets {
-			lag, lagWithPersistent, err := s.getLagForPartition(topic, partition, consumerOffsets, producerOffsets)
-			if err != nil {
-				return 0, 0, err
+			var lag, lagWithPersistent int64
+			if s.metadata.lagInSeconds {
+				lag, lagWithPersistent = timeLags[topic][partition].lag, timeLags[topic][partition].lagWithPersistent
+			} else {
+				lag, lagWithPersistent, err = s.getLagForPartition(topic, partition, consumerOffsets, producerOffsets, logStartOffsets)
+				if err != nil {
+					return 0, 0, err
+				}
+			}
+			if s.metadata.lagInSeconds {
+				// the ages of the partitions don't add up, the lag of the group is the one of its oldest message
+				topicLag = max(topicLag, lag)
+				totalLagWithPersistent = max(totalLagWithPersistent, lagWithPersistent)
+			} else {
+				topicLag += lag
+				totalLagWithPersistent += lagWithPersistent
 			}
-			totalLag += lag
-			totalLagWithPersistent += lagWithPersistent
 
 			if lag > 0 {
 				partitionsWithLag++
 			}
 		}
+		if s.metadata.lagInSeconds {
+			totalLag = max(totalLag, topicLag)
+		} else {
+			totalLag += topicLag
+		}
 		totalTopicPartitions += (int64)(len(partitionsOffsets))
+		s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: consumer group %s has lag %v on topic %s", s.metadata.group, topicLag, topic))
 	}
 	s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalLag %v, topicPartitions %v, threshold %v", totalLag, len(topicPartitions), s.metadata.lagThreshold))
 
@@ -987,12 +1308,315 @@ func (s *kafkaScaler) getTotalLag() (int64, int64, error) {
 	return totalLag, totalLagWithPersistent, nil
 }
 
+// getTotalBytes returns the size in bytes of the logs of the topic partitions on their leader brokers,
+// capped like the lag so the consumers don't outnumber the partitions unless allowIdleConsumers is set
+func (s *kafkaScaler) getTotalBytes() (int64, error) {
+	topicPartitions, err := s.getTopicPartitions()
+	if err != nil {
+		return 0, err
+	}
+
+	leaders := make(map[string]map[int32]int32, len(topicPartitions))
+	brokerIDs := make([]int32, 0)
+	for topic, partitions := range topicPartitions {
+		leaders[topic] = make(map[int32]int32, len(partitions))
+		for _, partition := range partitions {
+			broker, err := s.client.Leader(topic, partition)
+			if err != nil {
+				return 0, fmt.Errorf("error finding leader for topic %s and partition %d: %w", topic, partition, err)
+			}
+			leaders[topic][partition] = broker.ID()
+			if !slices.Contains(brokerIDs, broker.ID()) {
+				brokerIDs = append(brokerIDs, broker.ID())
+			}
+		}
+	}
+
+	logDirs, err := s.admin.DescribeLogDirs(brokerIDs)
+	if err != nil {
+		return 0, fmt.Errorf("error describing log dirs of brokers %v: %w", brokerIDs, err)
+	}
+
+	totalBytes := int64(0)
+	totalTopicPartitions := int64(0)
+	for topic, partitionSizes := range getPartitionSizes(leaders, logDirs) {
+		topicBytes := int64(0)
+		for _, size := range partitionSizes {
+			topicBytes += size
+		}
+		totalBytes += topicBytes
+		totalTopicPartitions += int64(len(partitionSizes))
+		s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: topic %s has a size of %v bytes", topic, topicBytes))
+	}
+	s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalBytes %v, topicPartitions %v, threshold %v", totalBytes, totalTopicPartitions, s.metadata.bytesThreshold))
+
+	if !s.metadata.allowIdleConsumers && (totalBytes/s.metadata.bytesThreshold) > totalTopicPartitions {
+		totalBytes = totalTopicPartitions * s.metadata.bytesThreshold
+	}
+	return totalBytes, nil
+}
+
+// getPartitionSizes returns the size in bytes of the log of each partition on its leader broker, given the
+// leader broker ID of each partition. The replicas on the followers hold the same records and a temporary
+// log is a copy being moved to another log dir of the broker, so neither is counted, nor are offline log dirs.
+func getPartitionSizes(leaders map[string]map[int32]int32, logDirs map[int32][]sarama.DescribeLogDirsResponseDirMetadata) map[string]map[int32]int64 {
+	sizes := make(map[string]map[int32]int64, len(leaders))
+	for topic, partitionLeaders := range leaders {
+		sizes[topic] = make(map[int32]int64, len(partitionLeaders))
+		for partition := range partitionLeaders {
+			sizes[topic][partition] = 0
+		}
+	}
+
+	for brokerID, dirs := range logDirs {
+		for _, dir := range dirs {
+			if dir.ErrorCode != sarama.ErrNoError {
+				continue
+			}
+			for _, topic := range dir.Topics {
+				partitionLeaders, found := leaders[topic.Topic]
+				if !found {
+					continue
+				}
+				for _, partition := range topic.Partitions {
+					leader, found := partitionLeaders[partition.PartitionID]
+					if !found || leader != brokerID || partition.IsTemporary {
+						continue
+					}
+					sizes[topic.Topic][partition.PartitionID] += partition.Size
+				}
+			}
+		}
+	}
+	return sizes
+}
+
+// partitionTimeLag is the lag of a partition in seconds, see getLagForPartition for the persistent lag
+type partitionTimeLag struct {
+	lag               int64
+	lagWithPersistent int64
+}
+
+// getTimeLags returns the age in seconds of the oldest unconsumed message of each partition, i.e. the message at
+// the committed offset of the consumer group, partitions without any unconsumed message having no time lag
+func (s *kafkaScaler) getTimeLags(consumerOffsets *sarama.OffsetFetchResponse, producerOffsets map[string]map[int32]int64) (map[string]map[int32]partitionTimeLag, error) {
+	timeLags := make(map[string]map[int32]partitionTimeLag, len(producerOffsets))
+	startOffsets := make(map[string]map[int32]int64)
+	persistentLags := make(map[string]map[int32]bool)
+
+	for topic, partitionsOffsets := range producerOffsets {
+		timeLags[topic] = make(map[int32]partitionTimeLag, len(partitionsOffsets))
+		for partition := range partitionsOffsets {
+			lag, lagWithPersistent, err := s.getLagForPartition(topic, partition, consumerOffsets, producerOffsets, nil)
+			if err != nil {
+				return nil, err
+			}
+			if lagWithPersistent <= 0 {
+				continue
+			}
+
+			startOffset := consumerOffsets.GetBlock(topic, partition).Offset
+			if startOffset == invalidOffset {
+				if s.metadata.offsetResetPolicy == latest {
+					// no message is waiting for a consumer starting from the latest offset, the lag of
+					// getLagForPartition only lets it scale to 1 so the consumer can commit its offsets
+					timeLags[topic][partition] = partitionTimeLag{lag: lag, lagWithPersistent: lagWithPersistent}
+					continue
+				}
+				startOffset, err = s.client.GetOffset(topic, partition, sarama.OffsetOldest)
+				if err != nil {
+					return nil, fmt.Errorf("error getting the oldest offset of topic %s and partition %d: %w", topic, partition, err)
+				}
+			}
+
+			if _, found := startOffsets[topic]; !found {
+				startOffsets[topic] = make(map[int32]int64)
+				persistentLags[topic] = make(map[int32]bool)
+			}
+			startOffsets[topic][partition] = startOffset
+			persistentLags[topic][partition] = lag == 0
+		}
+	}
+
+	timestamps, err := s.getRecordTimestamps(startOffsets)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for topic, partitions := range startOffsets {
+		for partition, startOffset := range partitions {
+			timestamp, found := timestamps[topic][partition]
+			if !found {
+				s.logger.V(1).Info(fmt.Sprintf("no message with a timestamp found from offset %d for topic %s and partition %d, returning no time lag", startOffset, topic, partition))
+				continue
+			}
+			seconds := max(int64(now.Sub(timestamp)/time.Second), 0)
+			if persistentLags[topic][partition] {
+				timeLags[topic][partition] = partitionTimeLag{lag: 0, lagWithPersistent: seconds}
+			} else {
+				timeLags[topic][partition] = partitionTimeLag{lag: seconds, lagWithPersistent: seconds}
+			}
+		}
+	}
+	return timeLags, nil
+}
+
+type brokerFetchResult struct {
+	fetchResp *sarama.FetchResponse
+	err       error
+}
+
+// getRecordTimestamps fetches the messages at the given offsets, returning the timestamp of the first message
+// found from the offset of each partition
+func (s *kafkaScaler) getRecordTimestamps(topicPartitionOffsets map[string]map[int32]int64) (map[string]map[int32]time.Time, error) {
+	version := int16(2)
+	if s.client.Config().Version.IsAtLeast(sarama.V0_11_0_0) {
+		version = 4
+	}
+
+	// Step 1: build one FetchRequest instance per broker.
+	requests := make(map[*sarama.Broker]*sarama.FetchRequest)
+
+	for topic, partitions := range topicPartitionOffsets {
+		for partitionID, offset := range partitions {
+			broker, err := s.client.Leader(topic, partitionID)
+			if err != nil {
+				return nil, err
+			}
+			request, ok := requests[broker]
+			if !ok {
+				request = &sarama.FetchRequest{Version: version, MinBytes: 1, MaxBytes: sarama.MaxResponseSize}
+				requests[broker] = request
+			}
+			request.AddBlock(topic, partitionID, offset, s.client.Config().Consumer.Fetch.Default, -1)
+		}
+	}
+
+	// Step 2: send requests, one per broker, and collect the timestamps
+	resultCh := make(chan brokerFetchResult, len(requests))
+	var wg sync.WaitGroup
+	wg.Add(len(requests))
+	for broker, request := range requests {
+		go func(brCopy *sarama.Broker, reqCopy *sarama.FetchRequest) {
+			defer wg.Done()
+			response, err := brCopy.Fetch(reqCopy)
+			resultCh <- brokerFetchResult{response, err}
+		}(broker, request)
+	}
+
+	wg.Wait()
+	close(resultCh)
+
+	timestamps := make(map[string]map[int32]time.Time)
+	for brokerFetchRes := range resultCh {
+		if brokerFetchRes.err != nil {
+			return nil, brokerFetchRes.err
+		}
+
+		for topic, blocks := range brokerFetchRes.fetchResp.Blocks {
+			for partitionID, block := range blocks {
+				switch block.Err {
+				case sarama.ErrNoError:
+				case sarama.ErrOffsetOutOfRange:
+					// the messages have been deleted by the retention in the meantime
+					continue
+				default:
+					return nil, block.Err
+				}
+				timestamp, found := getFetchedRecordTimestamp(block, topicPartitionOffsets[topic][partitionID])
+				if !found {
+					continue
+				}
+				if _, found := timestamps[topic]; !found {
+					timestamps[topic] = make(map[int32]time.Time)
+				}
+				timestamps[topic][partitionID] = timestamp
+			}
+		}
+	}
+
+	return timestamps, nil
+}
+
+// getFetchedRecordTimestamp returns the timestamp of the first message of the fetched block whose offset is at least
+// the given one, the block starting with the whole batch the offset is part of
+func getFetchedRecordTimestamp(block *sarama.FetchResponseBlock, offset int64) (time.Time, bool) {
+	for _, records := range block.RecordsSet {
+		if batch := records.RecordBatch; batch != nil {
+			if batch.Control {
+				continue
+			}
+			for _, record := range batch.Records {
+				if batch.FirstOffset+record.OffsetDelta < offset {
+					continue
+				}
+				if batch.FirstTimestamp.IsZero() {
+					return time.Time{}, false
+				}
+				if batch.LogAppendTime {
+					return batch.MaxTimestamp, true
+				}
+				return batch.FirstTimestamp.Add(record.TimestampDelta), true
+			}
+		}
+
+		if msgSet := records.MsgSet; msgSet != nil {
+			for _, msgBlock := range msgSet.Messages {
+				messages := msgBlock.Messages()
+				// the offsets of compressed messages are relative to the wrapper since the message format v1
+				baseOffset := int64(0)
+				if msgBlock.Msg.Set != nil && msgBlock.Msg.Version >= 1 && len(messages) > 0 {
+					baseOffset = msgBlock.Offset - messages[len(messages)-1].Offset
+				}
+				for _, message := range messages {
+					if baseOffset+message.Offset < offset {
+						continue
+					}
+					timestamp := message.Msg.Timestamp
+					if msgBlock.Msg.LogAppendTime {
+						timestamp = msgBlock.Msg.Timestamp
+					}
+					if timestamp.IsZero() {
+						return time.Time{}, false
+					}
+					return timestamp, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
 type brokerOffsetResult struct {
 	offsetResp *sarama.OffsetResponse
 	err        error
 }
 
 func (s *kafkaScaler) getProducerOffsets(topicPartitions map[string][]int32) (map[string]map[int32]int64, error) {
+	return s.getPartitionOffsets(topicPartitions, sarama.OffsetNewest)
+}
+
+// getTargetOffsets returns the offsets of the first messages produced at or after targetTimestamp, the latest
+// offsets being the targets of the partitions without any message produced since then
+func (s *kafkaScaler) getTargetOffsets(topicPartitions map[string][]int32, producerOffsets map[string]map[int32]int64) (map[string]map[int32]int64, error) {
+	timestampOffsets, err := s.getPartitionOffsets(topicPartitions, s.metadata.targetTimestamp.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	for topic, partitionsOffsets := range timestampOffsets {
+		for partition, offset := range partitionsOffsets {
+			if offset == invalidOffset {
+				timestampOffsets[topic][partition] = producerOffsets[topic][partition]
+			}
+		}
+	}
+	return timestampOffsets, nil
+}
+
+// getPartitionOffsets returns the offsets of the topic partitions at the given time, sarama.OffsetNewest being
+// the latest offsets and a timestamp in milliseconds the offsets of the first messages produced at or after it
+func (s *kafkaScaler) getPartitionOffsets(topicPartitions map[string][]int32, time int64) (map[string]map[int32]int64, error) {
 	version := int16(0)
 	if s.client.Config().Version.IsAtLeast(sarama.V0_10_1_0) {
 		version = 1
@@ -1012,7 +1636,7 @@ func (s *kafkaScaler) getProducerOffsets(topicPartitions map[string][]int32) (ma
 				request = &sarama.OffsetRequest{Version: version}
 				requests[broker] = request
 			}
-			request.AddBlock(topic, partitionID, sarama.OffsetNewest, 1)
+			request.AddBlock(topic, partitionID, time, 1)
 		}
 	}
 
@@ -2,16 +2,20 @@ package scalers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"reflect"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/go-logr/logr"
 
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kafka_oauth "github.com/kedacore/keda/v2/pkg/scalers/kafka"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
@@ -138,6 +142,50 @@ var parseKafkaMetadataTestDataset = []parseKafkaMetadataTestData{
 	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "allowIdleConsumers": "true", "limitToPartitionsWithLag": "false"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), true, false, false},
 	// failure, topic must be specified when limitToPartitionsWithLag is true
 	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "limitToPartitionsWithLag": "true"}, true, 1, []string{"foobar:9092"}, "my-group", "", nil, offsetResetPolicy("latest"), false, false, true},
+	// success, topicRegex
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topicRegex": "orders-.*"}, false, 1, []string{"foobar:9092"}, "my-group", "", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, topic and topicRegex
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "topicRegex": "orders-.*"}, true, 1, []string{"foobar:9092"}, "my-group", "", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, topicRegex isn't a valid regex
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topicRegex": "orders-("}, true, 1, []string{"foobar:9092"}, "my-group", "", nil, offsetResetPolicy("latest"), false, false, false},
+	// success, lagInSeconds
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagInSeconds": "true", "lagThreshold": "60"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, lagInSeconds is malformed
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagInSeconds": "notvalid"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, lagInSeconds with a version without message timestamps
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagInSeconds": "true", "version": "0.9.0.0"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// success, scaleOnBytes
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "scaleOnBytes": "true", "bytesThreshold": "1048576", "activationBytesThreshold": "1024"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, scaleOnBytes is malformed
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "scaleOnBytes": "notvalid", "bytesThreshold": "1048576"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, scaleOnBytes without bytesThreshold
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "scaleOnBytes": "true"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, bytesThreshold isn't positive
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "scaleOnBytes": "true", "bytesThreshold": "0"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, activationBytesThreshold is negative
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "scaleOnBytes": "true", "bytesThreshold": "1048576", "activationBytesThreshold": "-1"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, scaleOnBytes with a version without the DescribeLogDirs API
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "scaleOnBytes": "true", "bytesThreshold": "1048576", "version": "0.11.0.0"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, scaleOnBytes and lagInSeconds
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "scaleOnBytes": "true", "bytesThreshold": "1048576", "lagInSeconds": "true"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, scaleOnBytes and limitToPartitionsWithLag
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "scaleOnBytes": "true", "bytesThreshold": "1048576", "limitToPartitionsWithLag": "true"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, true},
+	// success, targetTimestamp
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "targetTimestamp": "2024-01-01T12:00:00Z"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, targetTimestamp is malformed
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "targetTimestamp": "2024-01-01 12:00"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, targetTimestamp with a version without offsets by timestamp
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "targetTimestamp": "2024-01-01T12:00:00Z", "version": "0.10.0.0"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, targetTimestamp and lagInSeconds
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "targetTimestamp": "2024-01-01T12:00:00Z", "lagInSeconds": "true"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// success, clampLagToLogStartOffset
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "clampLagToLogStartOffset": "true"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, clampLagToLogStartOffset is not a bool
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "clampLagToLogStartOffset": "yes please"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, clampLagToLogStartOffset and lagInSeconds
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "clampLagToLogStartOffset": "true", "lagInSeconds": "true"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, clampLagToLogStartOffset and scaleOnBytes
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "clampLagToLogStartOffset": "true", "scaleOnBytes": "true", "bytesThreshold": "1000"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
 }
 
 var parseKafkaAuthParamsTestDataset = []parseKafkaAuthParamsTestData{
@@ -601,6 +649,227 @@ func TestKafkaClientsOAuthTokenProvider(t *testing.T) {
 	}
 }
 
+func TestKafkaAWSMSKIAMSaslRequiredFields(t *testing.T) {
+	testData := []struct {
+		name            string
+		triggerMetadata map[string]string
+		authParams      map[string]string
+		expectedError   string
+	}{
+		{"no awsRegion", map[string]string{}, map[string]string{}, "no awsRegion given"},
+		{"empty awsRegion", map[string]string{"awsRegion": ""}, map[string]string{}, "no awsRegion given"},
+		{"unknown identityOwner", map[string]string{"awsRegion": "eu-west-1", "identityOwner": "node"}, map[string]string{}, "identityOwner must be pod or operator"},
+		{"pod identityOwner without credentials", map[string]string{"awsRegion": "eu-west-1", "identityOwner": "pod"}, map[string]string{}, "AWS MSK IAM configuration"},
+		{"session token without access keys", map[string]string{"awsRegion": "eu-west-1"}, map[string]string{"awsSessionToken": "token"}, "AWS MSK IAM configuration"},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			triggerMetadata := map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic"}
+			for k, v := range tt.triggerMetadata {
+				triggerMetadata[k] = v
+			}
+			authParams := map[string]string{"sasl": "aws_msk_iam", "tls": "enable"}
+			for k, v := range tt.authParams {
+				authParams[k] = v
+			}
+
+			_, err := parseKafkaMetadata(&scalersconfig.ScalerConfig{
+				TriggerMetadata: triggerMetadata,
+				AuthParams:      authParams,
+			}, logr.Discard())
+			if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("Expected error containing %q but got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestKafkaAWSMSKIAMSasl(t *testing.T) {
+	testData := []struct {
+		name                     string
+		authParams               map[string]string
+		podIdentity              kedav1alpha1.PodIdentityProvider
+		isError                  bool
+		expectedUsingPodIdentity bool
+		expectedPodIdentityOwner bool
+		expectedAccessKeyID      string
+		expectedRoleArn          string
+	}{
+		{"default credentials chain", map[string]string{"tls": "enable"}, "", false, false, false, "", ""},
+		{"pod identity", map[string]string{"tls": "enable"}, kedav1alpha1.PodIdentityProviderAws, false, true, false, "", ""},
+		{"access keys", map[string]string{"tls": "enable", "awsAccessKeyID": "key", "awsSecretAccessKey": "secret"}, "", false, false, true, "key", ""},
+		{"role", map[string]string{"tls": "enable", "awsRoleArn": "arn:aws:iam::123456789012:role/keda"}, "", false, false, true, "", "arn:aws:iam::123456789012:role/keda"},
+		{"no TLS", map[string]string{"tls": "disable"}, "", true, false, false, "", ""},
+		{"access key without secret", map[string]string{"tls": "enable", "awsAccessKeyID": "key"}, "", true, false, false, "", ""},
+		{"secret without access key", map[string]string{"tls": "enable", "awsSecretAccessKey": "secret"}, "", true, false, false, "", ""},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			authParams := map[string]string{"sasl": "aws_msk_iam"}
+			for k, v := range tt.authParams {
+				authParams[k] = v
+			}
+			meta, err := parseKafkaMetadata(&scalersconfig.ScalerConfig{
+				TriggerMetadata: map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "awsRegion": "eu-west-1"},
+				AuthParams:      authParams,
+				PodIdentity:     kedav1alpha1.AuthPodIdentity{Provider: tt.podIdentity},
+			}, logr.Discard())
+			if tt.isError {
+				if err == nil {
+					t.Error("Expected error but got success")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+
+			if meta.saslType != KafkaSASLTypeAWSMSKIAM || meta.tokenProvider != KafkaSASLOAuthTokenProviderAWSMSKIAM {
+				t.Errorf("Expected SASL type %v with token provider %v but got %v with %v", KafkaSASLTypeAWSMSKIAM, KafkaSASLOAuthTokenProviderAWSMSKIAM, meta.saslType, meta.tokenProvider)
+			}
+			if meta.awsAuthorization.AwsRegion != "eu-west-1" {
+				t.Errorf("Expected awsRegion eu-west-1 but got %v", meta.awsAuthorization.AwsRegion)
+			}
+			if meta.awsAuthorization.UsingPodIdentity != tt.expectedUsingPodIdentity {
+				t.Errorf("Expected UsingPodIdentity %v but got %v", tt.expectedUsingPodIdentity, meta.awsAuthorization.UsingPodIdentity)
+			}
+			if meta.awsAuthorization.PodIdentityOwner != tt.expectedPodIdentityOwner {
+				t.Errorf("Expected PodIdentityOwner %v but got %v", tt.expectedPodIdentityOwner, meta.awsAuthorization.PodIdentityOwner)
+			}
+			if meta.awsAuthorization.AwsAccessKeyID != tt.expectedAccessKeyID {
+				t.Errorf("Expected awsAccessKeyID %v but got %v", tt.expectedAccessKeyID, meta.awsAuthorization.AwsAccessKeyID)
+			}
+			if meta.awsAuthorization.AwsRoleArn != tt.expectedRoleArn {
+				t.Errorf("Expected awsRoleArn %v but got %v", tt.expectedRoleArn, meta.awsAuthorization.AwsRoleArn)
+			}
+
+			if tt.expectedUsingPodIdentity {
+				// getting the client config would request credentials from the pod identity
+				return
+			}
+			cfg, err := getKafkaClientConfig(context.TODO(), meta)
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			if !cfg.Net.SASL.Enable || cfg.Net.SASL.Mechanism != sarama.SASLTypeOAuth {
+				t.Errorf("Expected SASL mechanism %v to be enabled but got %v", sarama.SASLTypeOAuth, cfg.Net.SASL.Mechanism)
+			}
+			tokenProvider, ok := cfg.Net.SASL.TokenProvider.(kafka_oauth.TokenProvider)
+			if !ok || tokenProvider.String() != "MSK" {
+				t.Error("Expected MSK token provider to be set on client")
+			}
+		})
+	}
+}
+
+func TestKafkaClientConfigNetTimeouts(t *testing.T) {
+	testData := []struct {
+		name                string
+		metadata            map[string]string
+		isError             bool
+		expectedDialTimeout time.Duration
+		expectedReadTimeout time.Duration
+		expectedKeepAlive   time.Duration
+	}{
+		{"defaults", map[string]string{}, false, 10 * time.Second, 30 * time.Second, 30 * time.Second},
+		{"custom", map[string]string{"dialTimeout": "2s", "readTimeout": "1m", "keepAlive": "500ms"}, false, 2 * time.Second, time.Minute, 500 * time.Millisecond},
+		{"keepAlive left to the OS", map[string]string{"keepAlive": "0s"}, false, 10 * time.Second, 30 * time.Second, 0},
+		{"invalid dialTimeout", map[string]string{"dialTimeout": "2"}, true, 0, 0, 0},
+		{"zero dialTimeout", map[string]string{"dialTimeout": "0s"}, true, 0, 0, 0},
+		{"invalid readTimeout", map[string]string{"readTimeout": "fast"}, true, 0, 0, 0},
+		{"negative readTimeout", map[string]string{"readTimeout": "-1s"}, true, 0, 0, 0},
+		{"negative keepAlive", map[string]string{"keepAlive": "-1s"}, true, 0, 0, 0},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic"}
+			for k, v := range tt.metadata {
+				metadata[k] = v
+			}
+			meta, err := parseKafkaMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, AuthParams: validWithoutAuthParams}, logr.Discard())
+			if tt.isError {
+				if err == nil {
+					t.Error("Expected error but got success")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+
+			cfg, err := getKafkaClientConfig(context.TODO(), meta)
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			if cfg.Net.DialTimeout != tt.expectedDialTimeout {
+				t.Errorf("Expected dial timeout %v but got %v", tt.expectedDialTimeout, cfg.Net.DialTimeout)
+			}
+			if cfg.Net.ReadTimeout != tt.expectedReadTimeout {
+				t.Errorf("Expected read timeout %v but got %v", tt.expectedReadTimeout, cfg.Net.ReadTimeout)
+			}
+			if cfg.Net.KeepAlive != tt.expectedKeepAlive {
+				t.Errorf("Expected keep alive %v but got %v", tt.expectedKeepAlive, cfg.Net.KeepAlive)
+			}
+		})
+	}
+}
+
+func TestKafkaClientConfigTLSServerName(t *testing.T) {
+	meta, err := parseKafkaMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"bootstrapServers": "10.0.0.5:9093", "consumerGroup": "my-group", "topic": "my-topic", "tlsServerName": "kafka.example.com"},
+		AuthParams:      map[string]string{"tls": "enable"},
+	}, logr.Discard())
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+
+	cfg, err := getKafkaClientConfig(context.TODO(), meta)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if cfg.Net.TLS.Config.ServerName != "kafka.example.com" {
+		t.Errorf("Expected server name kafka.example.com but got %q", cfg.Net.TLS.Config.ServerName)
+	}
+	if cfg.Net.TLS.Config.InsecureSkipVerify {
+		t.Error("Expected the certificates to be verified")
+	}
+}
+
+func TestKafkaUnresponsiveBrokerTimesOut(t *testing.T) {
+	// the broker accepts connections but never answers
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("Could not start listener:", err)
+	}
+	defer listener.Close()
+
+	meta, err := parseKafkaMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"bootstrapServers": listener.Addr().String(), "consumerGroup": "my-group", "topic": "my-topic", "dialTimeout": "100ms", "readTimeout": "100ms"},
+		AuthParams:      validWithoutAuthParams,
+	}, logr.Discard())
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+
+	start := time.Now()
+	_, _, err = getKafkaClients(context.TODO(), meta)
+	if err == nil {
+		t.Fatal("Expected error but got success")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Expected the connection to time out quickly but it took %v", elapsed)
+	}
+
+	// a timeout is a transient network error, the connection is retried on the next poll
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("Expected a timeout error but got %v", err)
+	}
+}
+
 func TestKafkaGetMetricSpecForScaling(t *testing.T) {
 	for _, testData := range kafkaMetricIdentifiers {
 		meta, err := parseKafkaMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: validWithAuthParams, TriggerIndex: testData.triggerIndex}, logr.Discard())
@@ -619,14 +888,18 @@ func TestKafkaGetMetricSpecForScaling(t *testing.T) {
 
 func TestGetTopicPartitions(t *testing.T) {
 	testData := []struct {
-		name         string
-		metadata     map[string]string
-		partitionIds []int32
-		exp          map[string][]int32
+		name                 string
+		metadata             map[string]string
+		partitionIds         []int32
+		consumerGroupOffsets map[string]map[int32]int64
+		exp                  map[string][]int32
 	}{
-		{"success_all_partitions_explicit", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "partitionLimitation": "1,2"}, []int32{1, 2}, map[string][]int32{"my-topic": {1, 2}}},
-		{"success_partial_partitions_explicit", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "partitionLimitation": "1,2,3"}, []int32{1, 2, 3, 4, 5, 6}, map[string][]int32{"my-topic": {1, 2, 3}}},
-		{"success_all_partitions_implicit", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "partitionLimitation": ""}, []int32{1, 2, 3, 4, 5, 6}, map[string][]int32{"my-topic": {1, 2, 3, 4, 5, 6}}},
+		{"success_all_partitions_explicit", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "partitionLimitation": "1,2"}, []int32{1, 2}, nil, map[string][]int32{"my-topic": {1, 2}}},
+		{"success_partial_partitions_explicit", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "partitionLimitation": "1,2,3"}, []int32{1, 2, 3, 4, 5, 6}, nil, map[string][]int32{"my-topic": {1, 2, 3}}},
+		{"success_all_partitions_implicit", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "partitionLimitation": ""}, []int32{1, 2, 3, 4, 5, 6}, nil, map[string][]int32{"my-topic": {1, 2, 3, 4, 5, 6}}},
+		{"success_all_committed_topics", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group"}, []int32{0, 1}, map[string]map[int32]int64{"orders-eu": {0: 1}, "payments": {0: 1}}, map[string][]int32{"orders-eu": {0, 1}, "payments": {0, 1}}},
+		{"success_committed_topics_matching_regex", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topicRegex": "orders-.*"}, []int32{0, 1}, map[string]map[int32]int64{"orders-eu": {0: 1}, "orders-us": {1: 1}, "payments": {0: 1}, "archived-orders-eu": {0: 1}}, map[string][]int32{"orders-eu": {0, 1}, "orders-us": {0, 1}}},
+		{"success_no_committed_topic_matching_regex", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topicRegex": "orders-.*"}, []int32{0, 1}, map[string]map[int32]int64{"payments": {0: 1}}, map[string][]int32{}},
 	}
 
 	for _, tt := range testData {
@@ -635,7 +908,7 @@ func TestGetTopicPartitions(t *testing.T) {
 			if err != nil {
 				t.Fatal("Could not parse metadata:", err)
 			}
-			mockKafkaScaler := kafkaScaler{"", meta, nil, &MockClusterAdmin{partitionIds: tt.partitionIds}, logr.Discard(), make(map[string]map[int32]int64)}
+			mockKafkaScaler := kafkaScaler{"", meta, nil, &MockClusterAdmin{partitionIds: tt.partitionIds, consumerGroupOffsets: tt.consumerGroupOffsets}, logr.Discard(), make(map[string]map[int32]int64)}
 
 			partitions, err := mockKafkaScaler.getTopicPartitions()
 
@@ -650,8 +923,391 @@ func TestGetTopicPartitions(t *testing.T) {
 	}
 }
 
+func TestKafkaTopicRegexTotalLag(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("orders-eu", 0, broker.BrokerID()).
+			SetLeader("orders-eu", 1, broker.BrokerID()).
+			SetLeader("orders-us", 0, broker.BrokerID()).
+			SetLeader("orders-us", 1, broker.BrokerID()).
+			SetLeader("payments", 0, broker.BrokerID()).
+			SetLeader("payments", 1, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetOffset("orders-eu", 0, sarama.OffsetNewest, 10).
+			SetOffset("orders-eu", 1, sarama.OffsetNewest, 20).
+			SetOffset("orders-us", 0, sarama.OffsetNewest, 30).
+			SetOffset("orders-us", 1, sarama.OffsetNewest, 40).
+			SetOffset("payments", 0, sarama.OffsetNewest, 1000).
+			SetOffset("payments", 1, sarama.OffsetNewest, 1000),
+	})
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V1_0_0_0
+	client, err := sarama.NewClient([]string{broker.Addr()}, config)
+	if err != nil {
+		t.Fatal("Could not create client:", err)
+	}
+	defer client.Close()
+
+	meta, err := parseKafkaMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"bootstrapServers": broker.Addr(), "consumerGroup": "my-group", "topicRegex": "orders-.*", "lagThreshold": "10"},
+	}, logr.Discard())
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	admin := &MockClusterAdmin{
+		partitionIds: []int32{0, 1},
+		consumerGroupOffsets: map[string]map[int32]int64{
+			"orders-eu": {0: 5, 1: 15},
+			"orders-us": {0: 25, 1: 40},
+			"payments":  {0: 0, 1: 0},
+		},
+	}
+	scaler := kafkaScaler{"", meta, client, admin, logr.Discard(), make(map[string]map[int32]int64)}
+
+	// the lag of payments isn't counted as it doesn't match the regex
+	totalLag, totalLagWithPersistent, err := scaler.getTotalLag()
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if totalLag != 15 || totalLagWithPersistent != 15 {
+		t.Errorf("Expected a lag of 15 but got %d (%d with persistent lag)", totalLag, totalLagWithPersistent)
+	}
+}
+
+func TestGetFetchedRecordTimestamp(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	batch := &sarama.RecordBatch{
+		FirstOffset:    100,
+		FirstTimestamp: base,
+		MaxTimestamp:   base.Add(2 * time.Second),
+		Records: []*sarama.Record{
+			{OffsetDelta: 0, TimestampDelta: 0},
+			{OffsetDelta: 1, TimestampDelta: time.Second},
+			{OffsetDelta: 2, TimestampDelta: 2 * time.Second},
+		},
+	}
+	logAppendTimeBatch := *batch
+	logAppendTimeBatch.LogAppendTime = true
+	msgSet := &sarama.MessageSet{Messages: []*sarama.MessageBlock{
+		{Offset: 10, Msg: &sarama.Message{Version: 1, Timestamp: base}},
+		{Offset: 11, Msg: &sarama.Message{Version: 1, Timestamp: base.Add(time.Minute)}},
+	}}
+	noTimestampMsgSet := &sarama.MessageSet{Messages: []*sarama.MessageBlock{
+		{Offset: 10, Msg: &sarama.Message{Version: 0}},
+	}}
+
+	testCases := []struct {
+		name              string
+		records           []*sarama.Records
+		offset            int64
+		expectedTimestamp time.Time
+		expectedFound     bool
+	}{
+		{"first record of the batch", []*sarama.Records{{RecordBatch: batch}}, 100, base, true},
+		{"record in the middle of the batch", []*sarama.Records{{RecordBatch: batch}}, 101, base.Add(time.Second), true},
+		{"log append time of the batch", []*sarama.Records{{RecordBatch: &logAppendTimeBatch}}, 101, base.Add(2 * time.Second), true},
+		{"offset after the batch", []*sarama.Records{{RecordBatch: batch}}, 103, time.Time{}, false},
+		{"control batch skipped", []*sarama.Records{{RecordBatch: &sarama.RecordBatch{FirstOffset: 99, Control: true, Records: []*sarama.Record{{}}}}, {RecordBatch: batch}}, 99, base, true},
+		{"legacy message set", []*sarama.Records{{MsgSet: msgSet}}, 11, base.Add(time.Minute), true},
+		{"legacy message without timestamp", []*sarama.Records{{MsgSet: noTimestampMsgSet}}, 10, time.Time{}, false},
+		{"no records", nil, 10, time.Time{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			timestamp, found := getFetchedRecordTimestamp(&sarama.FetchResponseBlock{RecordsSet: tc.records}, tc.offset)
+			if found != tc.expectedFound || !timestamp.Equal(tc.expectedTimestamp) {
+				t.Errorf("Expected (%v, %t) but got (%v, %t)", tc.expectedTimestamp, tc.expectedFound, timestamp, found)
+			}
+		})
+	}
+}
+
+func TestKafkaLagInSecondsTotalLag(t *testing.T) {
+	now := time.Now()
+	fetchResponse := &sarama.FetchResponse{Version: 4}
+	// partition 0 has been waiting for 2 minutes at offset 5, partition 1 for 30 seconds at offset 18
+	fetchResponse.AddRecordWithTimestamp("my-topic", 0, nil, sarama.StringEncoder("a"), 5, now.Add(-2*time.Minute))
+	fetchResponse.AddRecordWithTimestamp("my-topic", 0, nil, sarama.StringEncoder("b"), 6, now.Add(-time.Minute))
+	fetchResponse.AddRecordWithTimestamp("my-topic", 1, nil, sarama.StringEncoder("c"), 18, now.Add(-30*time.Second))
+
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("my-topic", 0, broker.BrokerID()).
+			SetLeader("my-topic", 1, broker.BrokerID()).
+			SetLeader("my-topic", 2, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetOffset("my-topic", 0, sarama.OffsetNewest, 10).
+			SetOffset("my-topic", 1, sarama.OffsetNewest, 20).
+			SetOffset("my-topic", 2, sarama.OffsetNewest, 30),
+		"FetchRequest": sarama.NewMockWrapper(fetchResponse),
+	})
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V1_0_0_0
+	client, err := sarama.NewClient([]string{broker.Addr()}, config)
+	if err != nil {
+		t.Fatal("Could not create client:", err)
+	}
+	defer client.Close()
+
+	meta, err := parseKafkaMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"bootstrapServers": broker.Addr(), "consumerGroup": "my-group", "topic": "my-topic", "lagThreshold": "60", "lagInSeconds": "true", "allowIdleConsumers": "true"},
+	}, logr.Discard())
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	admin := &MockClusterAdmin{
+		partitionIds: []int32{0, 1, 2},
+		// partition 2 has no unconsumed message
+		consumerGroupOffsets: map[string]map[int32]int64{"my-topic": {0: 5, 1: 18, 2: 30}},
+	}
+	scaler := kafkaScaler{"", meta, client, admin, logr.Discard(), make(map[string]map[int32]int64)}
+
+	totalLag, totalLagWithPersistent, err := scaler.getTotalLag()
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	// the oldest message of the partitions, not the sum of their ages, allowing a second for the test to run
+	if totalLag < 120 || totalLag > 121 || totalLagWithPersistent != totalLag {
+		t.Errorf("Expected a lag of 120 seconds but got %d (%d with persistent lag)", totalLag, totalLagWithPersistent)
+	}
+}
+
+func TestKafkaTargetTimestampTotalLag(t *testing.T) {
+	target := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("my-topic", 0, broker.BrokerID()).
+			SetLeader("my-topic", 1, broker.BrokerID()).
+			SetLeader("my-topic", 2, broker.BrokerID()),
+		// partition 2 has no message produced since the target timestamp
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetOffset("my-topic", 0, sarama.OffsetNewest, 100).
+			SetOffset("my-topic", 1, sarama.OffsetNewest, 200).
+			SetOffset("my-topic", 2, sarama.OffsetNewest, 30).
+			SetOffset("my-topic", 0, target.UnixMilli(), 40).
+			SetOffset("my-topic", 1, target.UnixMilli(), 150).
+			SetOffset("my-topic", 2, target.UnixMilli(), -1),
+	})
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V1_0_0_0
+	client, err := sarama.NewClient([]string{broker.Addr()}, config)
+	if err != nil {
+		t.Fatal("Could not create client:", err)
+	}
+	defer client.Close()
+
+	meta, err := parseKafkaMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"bootstrapServers": broker.Addr(), "consumerGroup": "my-group", "topic": "my-topic", "lagThreshold": "10", "allowIdleConsumers": "true", "targetTimestamp": target.Format(time.RFC3339)},
+	}, logr.Discard())
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+
+	testCases := []struct {
+		name            string
+		consumerOffsets map[int32]int64
+		expectedLag     int64
+	}{
+		{name: "behind the target", consumerOffsets: map[int32]int64{0: 10, 1: 100, 2: 20}, expectedLag: 30 + 50 + 10},
+		{name: "partially caught up", consumerOffsets: map[int32]int64{0: 60, 1: 120, 2: 30}, expectedLag: 30},
+		{name: "caught up", consumerOffsets: map[int32]int64{0: 40, 1: 180, 2: 30}, expectedLag: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			admin := &MockClusterAdmin{
+				partitionIds:         []int32{0, 1, 2},
+				consumerGroupOffsets: map[string]map[int32]int64{"my-topic": tc.consumerOffsets},
+			}
+			scaler := kafkaScaler{"", meta, client, admin, logr.Discard(), make(map[string]map[int32]int64)}
+
+			totalLag, totalLagWithPersistent, err := scaler.getTotalLag()
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			if totalLag != tc.expectedLag || totalLagWithPersistent != tc.expectedLag {
+				t.Errorf("Expected a lag of %d but got %d (%d with persistent lag)", tc.expectedLag, totalLag, totalLagWithPersistent)
+			}
+		})
+	}
+}
+
+func TestKafkaLogStartOffsetTotalLag(t *testing.T) {
+	// sample offsets of a topic with tiered storage: the records below the log start offset were deleted by the
+	// retention, and most of the records left are in segments moved to remote storage. The local segments of
+	// partition 0 start at offset 900 and the ones of partition 1 at offset 450, which the lag doesn't depend on
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("my-topic", 0, broker.BrokerID()).
+			SetLeader("my-topic", 1, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetOffset("my-topic", 0, sarama.OffsetNewest, 1000).
+			SetOffset("my-topic", 1, sarama.OffsetNewest, 500).
+			SetOffset("my-topic", 0, sarama.OffsetOldest, 200).
+			SetOffset("my-topic", 1, sarama.OffsetOldest, 0),
+	})
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V1_0_0_0
+	client, err := sarama.NewClient([]string{broker.Addr()}, config)
+	if err != nil {
+		t.Fatal("Could not create client:", err)
+	}
+	defer client.Close()
+
+	testCases := []struct {
+		name                     string
+		clampLagToLogStartOffset bool
+		offsetResetPolicy        string
+		consumerOffsets          map[int32]int64
+		expectedLag              int64
+	}{
+		{name: "committed offsets", clampLagToLogStartOffset: true, offsetResetPolicy: "earliest", consumerOffsets: map[int32]int64{0: 300, 1: 100}, expectedLag: 700 + 400},
+		{name: "committed offset deleted", clampLagToLogStartOffset: true, offsetResetPolicy: "earliest", consumerOffsets: map[int32]int64{0: 50, 1: 100}, expectedLag: 800 + 400},
+		{name: "no committed offset", clampLagToLogStartOffset: true, offsetResetPolicy: "earliest", consumerOffsets: map[int32]int64{0: -1, 1: -1}, expectedLag: 800 + 500},
+		{name: "no committed offset with latest policy", clampLagToLogStartOffset: true, offsetResetPolicy: "latest", consumerOffsets: map[int32]int64{0: -1, 1: -1}, expectedLag: 1 + 1},
+		{name: "no committed offset without the log start offset", clampLagToLogStartOffset: false, offsetResetPolicy: "earliest", consumerOffsets: map[int32]int64{0: -1, 1: -1}, expectedLag: 1000 + 500},
+		{name: "committed offset deleted without the log start offset", clampLagToLogStartOffset: false, offsetResetPolicy: "earliest", consumerOffsets: map[int32]int64{0: 50, 1: 100}, expectedLag: 950 + 400},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			meta, err := parseKafkaMetadata(&scalersconfig.ScalerConfig{
+				TriggerMetadata: map[string]string{"bootstrapServers": broker.Addr(), "consumerGroup": "my-group", "topic": "my-topic", "lagThreshold": "10", "allowIdleConsumers": "true", "offsetResetPolicy": tc.offsetResetPolicy, "clampLagToLogStartOffset": strconv.FormatBool(tc.clampLagToLogStartOffset)},
+			}, logr.Discard())
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			admin := &MockClusterAdmin{
+				partitionIds:         []int32{0, 1},
+				consumerGroupOffsets: map[string]map[int32]int64{"my-topic": tc.consumerOffsets},
+			}
+			scaler := kafkaScaler{"", meta, client, admin, logr.Discard(), make(map[string]map[int32]int64)}
+
+			totalLag, totalLagWithPersistent, err := scaler.getTotalLag()
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			if totalLag != tc.expectedLag || totalLagWithPersistent != tc.expectedLag {
+				t.Errorf("Expected a lag of %d but got %d (%d with persistent lag)", tc.expectedLag, totalLag, totalLagWithPersistent)
+			}
+		})
+	}
+}
+
+func TestKafkaGetPartitionSizes(t *testing.T) {
+	// partition 0 is led by broker 1 and partition 1 by broker 2, each being replicated on the other broker
+	leaders := map[string]map[int32]int32{"my-topic": {0: 1, 1: 2}, "empty-topic": {0: 1}}
+	logDirs := map[int32][]sarama.DescribeLogDirsResponseDirMetadata{
+		1: {
+			{ErrorCode: sarama.ErrNoError, Path: "/data/1", Topics: []sarama.DescribeLogDirsResponseTopic{
+				{Topic: "my-topic", Partitions: []sarama.DescribeLogDirsResponsePartition{{PartitionID: 0, Size: 1000}, {PartitionID: 1, Size: 390}}},
+				{Topic: "other-topic", Partitions: []sarama.DescribeLogDirsResponsePartition{{PartitionID: 0, Size: 5000}}},
+			}},
+			// partition 0 is being moved to another log dir of the broker
+			{ErrorCode: sarama.ErrNoError, Path: "/data/2", Topics: []sarama.DescribeLogDirsResponseTopic{
+				{Topic: "my-topic", Partitions: []sarama.DescribeLogDirsResponsePartition{{PartitionID: 0, Size: 600, IsTemporary: true}}},
+			}},
+		},
+		2: {
+			{ErrorCode: sarama.ErrNoError, Path: "/data/1", Topics: []sarama.DescribeLogDirsResponseTopic{
+				{Topic: "my-topic", Partitions: []sarama.DescribeLogDirsResponsePartition{{PartitionID: 0, Size: 980}, {PartitionID: 1, Size: 400}}},
+			}},
+			{ErrorCode: sarama.ErrKafkaStorageError, Path: "/data/2", Topics: []sarama.DescribeLogDirsResponseTopic{
+				{Topic: "my-topic", Partitions: []sarama.DescribeLogDirsResponsePartition{{PartitionID: 1, Size: 7000}}},
+			}},
+		},
+	}
+
+	sizes := getPartitionSizes(leaders, logDirs)
+	expected := map[string]map[int32]int64{"my-topic": {0: 1000, 1: 400}, "empty-topic": {0: 0}}
+	if !reflect.DeepEqual(expected, sizes) {
+		t.Errorf("Expected %v but got %v", expected, sizes)
+	}
+}
+
+func TestKafkaScaleOnBytesTotalBytes(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("my-topic", 0, broker.BrokerID()).
+			SetLeader("my-topic", 1, broker.BrokerID()),
+	})
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V1_0_0_0
+	client, err := sarama.NewClient([]string{broker.Addr()}, config)
+	if err != nil {
+		t.Fatal("Could not create client:", err)
+	}
+	defer client.Close()
+
+	logDirs := map[int32][]sarama.DescribeLogDirsResponseDirMetadata{
+		broker.BrokerID(): {{ErrorCode: sarama.ErrNoError, Path: "/data", Topics: []sarama.DescribeLogDirsResponseTopic{
+			{Topic: "my-topic", Partitions: []sarama.DescribeLogDirsResponsePartition{{PartitionID: 0, Size: 3000}, {PartitionID: 1, Size: 500}}},
+		}}},
+	}
+
+	testCases := []struct {
+		name               string
+		allowIdleConsumers string
+		expectedBytes      int64
+	}{
+		// 2 partitions can't have more than 2 consumers of 1000 bytes each
+		{"capped to the partitions", "false", 2000},
+		{"allowIdleConsumers", "true", 3500},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			meta, err := parseKafkaMetadata(&scalersconfig.ScalerConfig{
+				TriggerMetadata: map[string]string{"bootstrapServers": broker.Addr(), "consumerGroup": "my-group", "topic": "my-topic", "scaleOnBytes": "true", "bytesThreshold": "1000", "activationBytesThreshold": "100", "allowIdleConsumers": tc.allowIdleConsumers},
+			}, logr.Discard())
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			admin := &MockClusterAdmin{partitionIds: []int32{0, 1}, logDirs: logDirs}
+			scaler := kafkaScaler{"", meta, client, admin, logr.Discard(), make(map[string]map[int32]int64)}
+
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-kafka-my-topic")
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			if value := metrics[0].Value.Value(); value != tc.expectedBytes || !active {
+				t.Errorf("Expected an active metric of %d bytes but got %d (active %t)", tc.expectedBytes, value, active)
+			}
+
+			target := scaler.GetMetricSpecForScaling(context.Background())[0].External.Target.Value.Value()
+			if target != 1000 {
+				t.Errorf("Expected a target of 1000 bytes but got %d", target)
+			}
+		})
+	}
+}
+
 type MockClusterAdmin struct {
-	partitionIds []int32
+	partitionIds         []int32
+	consumerGroupOffsets map[string]map[int32]int64
+	logDirs              map[int32][]sarama.DescribeLogDirsResponseDirMetadata
 }
 
 func (m *MockClusterAdmin) CreateTopic(_ string, _ *sarama.TopicDetail, _ bool) error {
@@ -732,7 +1388,13 @@ func (m *MockClusterAdmin) DescribeConsumerGroups(_ []string) ([]*sarama.GroupDe
 }
 
 func (m *MockClusterAdmin) ListConsumerGroupOffsets(_ string, _ map[string][]int32) (*sarama.OffsetFetchResponse, error) {
-	return nil, nil
+	response := &sarama.OffsetFetchResponse{}
+	for topic, partitions := range m.consumerGroupOffsets {
+		for partition, offset := range partitions {
+			response.AddBlock(topic, partition, &sarama.OffsetFetchResponseBlock{Offset: offset})
+		}
+	}
+	return response, nil
 }
 
 func (m *MockClusterAdmin) DeleteConsumerGroupOffset(_ string, _ string, _ int32) error {
@@ -748,7 +1410,7 @@ func (m *MockClusterAdmin) DescribeCluster() (brokers []*sarama.Broker, controll
 }
 
 func (m *MockClusterAdmin) DescribeLogDirs(_ []int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error) {
-	return nil, nil
+	return m.logDirs, nil
 }
 
 func (m *MockClusterAdmin) DescribeUserScramCredentials(_ []string) ([]*sarama.DescribeUserScramCredentialsResult, error) {
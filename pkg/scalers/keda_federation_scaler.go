@@ -0,0 +1,249 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	externalmetricsv1beta1 "k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// kedaFederationScaledObjectLabel selects the ScaledObject the KEDA metrics server serves the metric of
+const kedaFederationScaledObjectLabel = "scaledobject.keda.sh/name"
+
+type kedaFederationScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *kedaFederationMetadata
+	httpClient *http.Client
+	host       string
+	logger     logr.Logger
+}
+
+type kedaFederationMetadata struct {
+	// Server is the API server of the remote cluster, taken from the kubeconfig when one is given
+	Server           string `keda:"name=server,           order=triggerMetadata;authParams, optional"`
+	Namespace        string `keda:"name=namespace,        order=triggerMetadata, optional"`
+	ScaledObjectName string `keda:"name=scaledObjectName, order=triggerMetadata"`
+	// MetricName is the name of the external metric of the remote ScaledObject, e.g. s0-prometheus
+	MetricName            string  `keda:"name=metricName,            order=triggerMetadata"`
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata, optional"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+	UnsafeSsl             bool    `keda:"name=unsafeSsl,             order=triggerMetadata, default=false"`
+
+	// Kubeconfig of the remote cluster, or else its server with Token and CA
+	Kubeconfig string `keda:"name=kubeconfig, order=authParams, optional"`
+	Token      string `keda:"name=token,      order=authParams, optional"`
+	CA         string `keda:"name=ca,         order=authParams, optional"`
+
+	triggerIndex int
+}
+
+func (m *kedaFederationMetadata) Validate() error {
+	if m.Kubeconfig == "" && m.Server == "" {
+		return errors.New("either kubeconfig or server must be given")
+	}
+	if m.Kubeconfig != "" && (m.Token != "" || m.CA != "") {
+		return errors.New("token and ca can't be used together with kubeconfig")
+	}
+	return nil
+}
+
+// NewKedaFederationScaler creates a new kedaFederationScaler
+func NewKedaFederationScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseKedaFederationMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing keda-federation metadata: %w", err)
+	}
+
+	restConfig, err := getKedaFederationRESTConfig(meta)
+	if err != nil {
+		return nil, err
+	}
+	if restConfig.Timeout == 0 {
+		restConfig.Timeout = config.GlobalHTTPTimeout
+	}
+	httpClient, err := rest.HTTPClientFor(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the client of the remote cluster: %w", err)
+	}
+
+	return &kedaFederationScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		host:       strings.TrimSuffix(restConfig.Host, "/"),
+		logger:     InitializeLogger(config, "keda_federation_scaler"),
+	}, nil
+}
+
+func parseKedaFederationMetadata(config *scalersconfig.ScalerConfig) (*kedaFederationMetadata, error) {
+	meta := &kedaFederationMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+
+	if meta.TargetValue <= 0 && !config.AsMetricSource {
+		return nil, errors.New("targetValue must be greater than 0")
+	}
+	if meta.Namespace == "" {
+		meta.Namespace = config.ScalableObjectNamespace
+	}
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// getKedaFederationRESTConfig returns the config to reach the API server of the remote cluster
+func getKedaFederationRESTConfig(meta *kedaFederationMetadata) (*rest.Config, error) {
+	if meta.Kubeconfig != "" {
+		kubeconfig, err := clientcmd.Load([]byte(meta.Kubeconfig))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing kubeconfig: %w", err)
+		}
+		// the kubeconfig is checked before building the config, which already reads its files
+		if err := checkKedaFederationKubeconfig(kubeconfig); err != nil {
+			return nil, err
+		}
+		restConfig, err := clientcmd.NewDefaultClientConfig(*kubeconfig, &clientcmd.ConfigOverrides{}).ClientConfig()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing kubeconfig: %w", err)
+		}
+		if meta.Server != "" {
+			restConfig.Host = meta.Server
+		}
+		if meta.UnsafeSsl {
+			restConfig.TLSClientConfig.Insecure = true
+			restConfig.TLSClientConfig.CAData = nil
+			restConfig.TLSClientConfig.CAFile = ""
+		}
+		return restConfig, nil
+	}
+
+	restConfig := &rest.Config{
+		Host:        meta.Server,
+		BearerToken: meta.Token,
+	}
+	if meta.UnsafeSsl {
+		restConfig.TLSClientConfig.Insecure = true
+	} else if meta.CA != "" {
+		restConfig.TLSClientConfig.CAData = []byte(meta.CA)
+	}
+	return restConfig, nil
+}
+
+// checkKedaFederationKubeconfig rejects the kubeconfigs running commands or reading files of the operator,
+// the credentials of the remote cluster must be inlined
+func checkKedaFederationKubeconfig(kubeconfig *clientcmdapi.Config) error {
+	for name, authInfo := range kubeconfig.AuthInfos {
+		switch {
+		case authInfo.Exec != nil:
+			return fmt.Errorf("user %s: kubeconfig exec credential plugins aren't supported", name)
+		case authInfo.AuthProvider != nil:
+			return fmt.Errorf("user %s: kubeconfig auth providers aren't supported", name)
+		case authInfo.TokenFile != "":
+			return fmt.Errorf("user %s: kubeconfig tokenFile isn't supported, use token", name)
+		case authInfo.ClientCertificate != "", authInfo.ClientKey != "":
+			return fmt.Errorf("user %s: kubeconfig client-certificate and client-key files aren't supported, use client-certificate-data and client-key-data", name)
+		}
+	}
+	for name, cluster := range kubeconfig.Clusters {
+		if cluster.CertificateAuthority != "" {
+			return fmt.Errorf("cluster %s: kubeconfig certificate-authority file isn't supported, use certificate-authority-data", name)
+		}
+	}
+	return nil
+}
+
+func (s *kedaFederationScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (s *kedaFederationScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("keda-federation-%s-%s", s.metadata.ScaledObjectName, s.metadata.MetricName))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
+func (s *kedaFederationScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getRemoteMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting the metric of the remote cluster")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+// getRemoteMetricValue queries the external metrics API of the remote cluster, served by its KEDA metrics server,
+// for the metric of the remote ScaledObject. The values of the returned items are summed up
+func (s *kedaFederationScaler) getRemoteMetricValue(ctx context.Context) (float64, error) {
+	requestURL := fmt.Sprintf("%s/apis/%s/namespaces/%s/%s?labelSelector=%s",
+		s.host,
+		externalmetricsv1beta1.SchemeGroupVersion.String(),
+		url.PathEscape(s.metadata.Namespace),
+		url.PathEscape(s.metadata.MetricName),
+		url.QueryEscape(fmt.Sprintf("%s=%s", kedaFederationScaledObjectLabel, s.metadata.ScaledObjectName)),
+	)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return 0, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("remote external metrics API returned %d: %s", response.StatusCode, string(body))
+	}
+
+	var metrics externalmetricsv1beta1.ExternalMetricValueList
+	if err := json.Unmarshal(body, &metrics); err != nil {
+		return 0, fmt.Errorf("error decoding the response of the remote external metrics API: %w", err)
+	}
+	if len(metrics.Items) == 0 {
+		return 0, fmt.Errorf("remote external metrics API returned no value for metric %s", s.metadata.MetricName)
+	}
+
+	value := 0.0
+	for _, item := range metrics.Items {
+		value += item.Value.AsApproximateFloat64()
+	}
+	return value, nil
+}
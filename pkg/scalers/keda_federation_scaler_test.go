@@ -0,0 +1,248 @@
+package scalers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseKedaFederationMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type kedaFederationMetricIdentifier struct {
+	metadataTestData *parseKedaFederationMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+const testKedaFederationKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: remote
+  cluster:
+    server: %s
+    certificate-authority-data: %s
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: remote
+current-context: remote
+users:
+- name: remote
+  user:
+    token: %s
+`
+
+var testKedaFederationMetadata = []parseKedaFederationMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "metadata empty"},
+	{map[string]string{"server": "https://remote:6443", "namespace": "apps", "scaledObjectName": "orders", "metricName": "s0-rabbitmq-orders", "targetValue": "10"}, map[string]string{"token": "t0ken"}, false, "server and token"},
+	{map[string]string{"namespace": "apps", "scaledObjectName": "orders", "metricName": "s0-rabbitmq-orders", "targetValue": "10"}, map[string]string{"kubeconfig": fmt.Sprintf(testKedaFederationKubeconfig, "https://remote:6443", "", "t0ken")}, false, "kubeconfig"},
+	{map[string]string{"namespace": "apps", "scaledObjectName": "orders", "metricName": "s0-rabbitmq-orders", "targetValue": "10"}, map[string]string{"token": "t0ken"}, true, "missing server and kubeconfig"},
+	{map[string]string{"server": "https://remote:6443", "namespace": "apps", "metricName": "s0-rabbitmq-orders", "targetValue": "10"}, map[string]string{"token": "t0ken"}, true, "missing scaledObjectName"},
+	{map[string]string{"server": "https://remote:6443", "namespace": "apps", "scaledObjectName": "orders", "targetValue": "10"}, map[string]string{"token": "t0ken"}, true, "missing metricName"},
+	{map[string]string{"server": "https://remote:6443", "namespace": "apps", "scaledObjectName": "orders", "metricName": "s0-rabbitmq-orders"}, map[string]string{"token": "t0ken"}, true, "missing targetValue"},
+	{map[string]string{"server": "https://remote:6443", "namespace": "apps", "scaledObjectName": "orders", "metricName": "s0-rabbitmq-orders", "targetValue": "ten"}, map[string]string{"token": "t0ken"}, true, "invalid targetValue"},
+	{map[string]string{"namespace": "apps", "scaledObjectName": "orders", "metricName": "s0-rabbitmq-orders", "targetValue": "10"}, map[string]string{"kubeconfig": fmt.Sprintf(testKedaFederationKubeconfig, "https://remote:6443", "", "t0ken"), "token": "t0ken"}, true, "kubeconfig with token"},
+	{map[string]string{"namespace": "apps", "scaledObjectName": "orders", "metricName": "s0-rabbitmq-orders", "targetValue": "10"}, map[string]string{"kubeconfig": "not a kubeconfig"}, true, "invalid kubeconfig"},
+}
+
+var kedaFederationMetricIdentifiers = []kedaFederationMetricIdentifier{
+	{&testKedaFederationMetadata[1], 0, "s0-keda-federation-orders-s0-rabbitmq-orders"},
+	{&testKedaFederationMetadata[2], 1, "s1-keda-federation-orders-s0-rabbitmq-orders"},
+}
+
+func TestKedaFederationParseMetadata(t *testing.T) {
+	for _, testData := range testKedaFederationMetadata {
+		_, err := NewKedaFederationScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("%s: expected success but got error %s", testData.comment, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("%s: expected error but got success", testData.comment)
+		}
+	}
+}
+
+func TestKedaFederationRejectsKubeconfigReadingLocalCredentials(t *testing.T) {
+	testCases := []struct {
+		name     string
+		user     string
+		expected string
+	}{
+		{name: "exec", expected: "exec credential plugins aren't supported", user: "exec:\n      apiVersion: client.authentication.k8s.io/v1\n      command: /bin/sh\n      args: [\"-c\", \"cat /etc/shadow\"]"},
+		{name: "auth provider", expected: "auth providers aren't supported", user: "auth-provider:\n      name: oidc"},
+		{name: "tokenFile", expected: "tokenFile isn't supported", user: "tokenFile: /var/run/secrets/kubernetes.io/serviceaccount/token"},
+		{name: "client certificate file", expected: "client-key files aren't supported", user: "client-certificate: /etc/keda/tls.crt\n    client-key: /etc/keda/tls.key"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: remote
+  cluster:
+    server: https://remote:6443
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: remote
+current-context: remote
+users:
+- name: remote
+  user:
+    %s
+`, tc.user)
+			_, err := getKedaFederationRESTConfig(&kedaFederationMetadata{Kubeconfig: kubeconfig})
+			assert.ErrorContains(t, err, tc.expected)
+		})
+	}
+
+	kubeconfig := strings.Replace(fmt.Sprintf(testKedaFederationKubeconfig, "https://remote:6443", "", "t0ken"), "certificate-authority-data: ", "certificate-authority: /etc/keda/ca.crt", 1)
+	_, err := getKedaFederationRESTConfig(&kedaFederationMetadata{Kubeconfig: kubeconfig})
+	assert.ErrorContains(t, err, "certificate-authority file isn't supported")
+}
+
+func TestKedaFederationNamespaceDefaultsToScaledObjectNamespace(t *testing.T) {
+	meta, err := parseKedaFederationMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata:         map[string]string{"server": "https://remote:6443", "scaledObjectName": "orders", "metricName": "s0-rabbitmq-orders", "targetValue": "10"},
+		AuthParams:              map[string]string{"token": "t0ken"},
+		ScalableObjectNamespace: "local",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "local", meta.Namespace)
+}
+
+func TestKedaFederationGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range kedaFederationMetricIdentifiers {
+		s, err := NewKedaFederationScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		require.NoError(t, err)
+
+		metricSpec := s.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+// newKedaFederationRemoteStub serves the external metrics API of a remote cluster
+func newKedaFederationRemoteStub(t *testing.T, status int, response string) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/apis/external.metrics.k8s.io/v1beta1/namespaces/apps/s0-rabbitmq-orders", r.URL.Path)
+		assert.Equal(t, "scaledobject.keda.sh/name=orders", r.URL.Query().Get("labelSelector"))
+		if r.Header.Get("Authorization") != "Bearer t0ken" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+}
+
+func TestKedaFederationGetMetricsAndActivity(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   int
+		response string
+		token    string
+		value    float64
+		isActive bool
+		isError  bool
+	}{
+		{
+			name:     "single value",
+			status:   http.StatusOK,
+			response: `{"kind":"ExternalMetricValueList","apiVersion":"external.metrics.k8s.io/v1beta1","metadata":{},"items":[{"metricName":"s0-rabbitmq-orders","metricLabels":null,"timestamp":"2024-05-17T10:30:00Z","value":"12500m"}]}`,
+			token:    "t0ken",
+			value:    12.5,
+			isActive: true,
+		},
+		{
+			name:     "values summed up",
+			status:   http.StatusOK,
+			response: `{"kind":"ExternalMetricValueList","apiVersion":"external.metrics.k8s.io/v1beta1","metadata":{},"items":[{"metricName":"s0-rabbitmq-orders","timestamp":"2024-05-17T10:30:00Z","value":"3"},{"metricName":"s0-rabbitmq-orders","timestamp":"2024-05-17T10:30:00Z","value":"4"}]}`,
+			token:    "t0ken",
+			value:    7,
+			isActive: true,
+		},
+		{
+			name:     "zero value",
+			status:   http.StatusOK,
+			response: `{"kind":"ExternalMetricValueList","apiVersion":"external.metrics.k8s.io/v1beta1","metadata":{},"items":[{"metricName":"s0-rabbitmq-orders","timestamp":"2024-05-17T10:30:00Z","value":"0"}]}`,
+			token:    "t0ken",
+			value:    0,
+			isActive: false,
+		},
+		{
+			name:     "no value",
+			status:   http.StatusOK,
+			response: `{"kind":"ExternalMetricValueList","apiVersion":"external.metrics.k8s.io/v1beta1","metadata":{},"items":[]}`,
+			token:    "t0ken",
+			isError:  true,
+		},
+		{
+			name:     "remote error",
+			status:   http.StatusNotFound,
+			response: `{"kind":"Status","apiVersion":"v1","status":"Failure","message":"scaledobject not found","code":404}`,
+			token:    "t0ken",
+			isError:  true,
+		},
+		{
+			name:     "invalid response",
+			status:   http.StatusOK,
+			response: `not json`,
+			token:    "t0ken",
+			isError:  true,
+		},
+		{
+			name:    "wrong token",
+			status:  http.StatusOK,
+			token:   "wrong",
+			isError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, useKubeconfig := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s kubeconfig=%t", tc.name, useKubeconfig), func(t *testing.T) {
+				remote := newKedaFederationRemoteStub(t, tc.status, tc.response)
+				defer remote.Close()
+
+				ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: remote.Certificate().Raw})
+				metadata := map[string]string{"namespace": "apps", "scaledObjectName": "orders", "metricName": "s0-rabbitmq-orders", "targetValue": "10"}
+				authParams := map[string]string{"kubeconfig": fmt.Sprintf(testKedaFederationKubeconfig, remote.URL, base64.StdEncoding.EncodeToString(ca), tc.token)}
+				if !useKubeconfig {
+					metadata["server"] = remote.URL
+					authParams = map[string]string{"token": tc.token, "ca": string(ca)}
+				}
+				s, err := NewKedaFederationScaler(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, AuthParams: authParams})
+				require.NoError(t, err)
+				defer s.Close(context.Background())
+
+				metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "s0-keda-federation-orders-s0-rabbitmq-orders")
+				if tc.isError {
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tc.value, metrics[0].Value.AsApproximateFloat64())
+				assert.Equal(t, tc.isActive, isActive)
+			})
+		}
+	}
+}
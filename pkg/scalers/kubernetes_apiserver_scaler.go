@@ -0,0 +1,272 @@
+package scalers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/client-go/rest"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type kubernetesAPIServerScaler struct {
+	metricType v2.MetricTargetType
+	metadata   kubernetesAPIServerMetadata
+	httpClient *http.Client
+	metricsURL string
+	logger     logr.Logger
+
+	// the metrics of the apiserver are large, so a reading is reused for the polling interval
+	// instead of downloading them again for each metric request of the HPA
+	cacheInterval time.Duration
+	lock          sync.Mutex
+	cachedAt      time.Time
+	cachedValue   float64
+	now           func() time.Time
+}
+
+const (
+	kubernetesAPIServerMetricType = "External"
+
+	kubernetesAPIServerMetricsPath = "/metrics"
+	// kubernetesAPIServerInflightRequests is the number of requests being handled by the apiserver, by request_kind
+	kubernetesAPIServerInflightRequests = "apiserver_current_inflight_requests"
+
+	kubernetesAPIServerRequestKindAll = "all"
+)
+
+type kubernetesAPIServerMetadata struct {
+	// RequestKind is the request_kind of the inflight requests, readOnly or mutating, all sums both
+	RequestKind     string  `keda:"name=requestKind,     order=triggerMetadata, enum=all;readOnly;mutating, default=all"`
+	Value           float64 `keda:"name=value,           order=triggerMetadata, default=0"`
+	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, default=0"`
+
+	triggerIndex   int
+	asMetricSource bool
+}
+
+func (m *kubernetesAPIServerMetadata) Validate() error {
+	if m.Value <= 0 && !m.asMetricSource {
+		return fmt.Errorf("value must be a float greater than 0")
+	}
+
+	return nil
+}
+
+// NewKubernetesAPIServerScaler creates a new kubernetesAPIServerScaler reading the metrics of the apiserver
+// of the cluster KEDA runs in, with the credentials of KEDA
+func NewKubernetesAPIServerScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	restConfig, err := ctrlconfig.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error getting the kubernetes client config: %w", err)
+	}
+	return newKubernetesAPIServerScaler(config, restConfig)
+}
+
+func newKubernetesAPIServerScaler(config *scalersconfig.ScalerConfig, restConfig *rest.Config) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseKubernetesAPIServerMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing kubernetes apiserver metadata: %w", err)
+	}
+
+	// the client authenticates like the kubernetes clients of KEDA, e.g. with the rotated service account token
+	httpClient, err := rest.HTTPClientFor(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the kubernetes apiserver client: %w", err)
+	}
+	if config.GlobalHTTPTimeout > 0 {
+		httpClient.Timeout = config.GlobalHTTPTimeout
+	}
+
+	return &kubernetesAPIServerScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		metricsURL: strings.TrimSuffix(restConfig.Host, "/") + kubernetesAPIServerMetricsPath,
+		logger:     InitializeLogger(config, "kubernetes_apiserver_scaler"),
+
+		cacheInterval: getKubernetesAPIServerCacheInterval(config),
+		now:           time.Now,
+	}, nil
+}
+
+// getKubernetesAPIServerCacheInterval returns the polling interval of the trigger, readings aren't cached without one
+func getKubernetesAPIServerCacheInterval(config *scalersconfig.ScalerConfig) time.Duration {
+	if config.TriggerPollingInterval > 0 {
+		return config.TriggerPollingInterval
+	}
+	if withTriggers, ok := config.ScaledObject.(*kedav1alpha1.WithTriggers); ok && withTriggers != nil {
+		return withTriggers.GetPollingInterval()
+	}
+	return 0
+}
+
+func parseKubernetesAPIServerMetadata(config *scalersconfig.ScalerConfig) (kubernetesAPIServerMetadata, error) {
+	meta := kubernetesAPIServerMetadata{}
+	meta.triggerIndex = config.TriggerIndex
+	meta.asMetricSource = config.AsMetricSource
+
+	err := config.TypedConfig(&meta)
+	if err != nil {
+		return meta, fmt.Errorf("error parsing kubernetes apiserver metadata: %w", err)
+	}
+
+	return meta, nil
+}
+
+func (s *kubernetesAPIServerScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *kubernetesAPIServerScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, "apiserver-inflight-requests"),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: kubernetesAPIServerMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns value for a supported metric
+func (s *kubernetesAPIServerScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	requests, err := s.getMetricValue(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting kubernetes apiserver: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, requests)
+
+	return []external_metrics.ExternalMetricValue{metric}, requests > s.metadata.ActivationValue, nil
+}
+
+// getMetricValue reads the inflight requests from the metrics of the apiserver, at most once per polling interval.
+// With several apiserver instances, the metrics are the ones of the instance the request is balanced to
+func (s *kubernetesAPIServerScaler) getMetricValue(ctx context.Context) (float64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.cacheInterval > 0 && !s.cachedAt.IsZero() && s.now().Sub(s.cachedAt) < s.cacheInterval {
+		return s.cachedValue, nil
+	}
+
+	// the reading is timestamped when it starts, so that the next poll of the scale loop reads the metrics again
+	readAt := s.now()
+	requests, err := s.readMetricValue(ctx)
+	if err != nil {
+		return 0, err
+	}
+	s.cachedAt = readAt
+	s.cachedValue = requests
+	return requests, nil
+}
+
+func (s *kubernetesAPIServerScaler) readMetricValue(ctx context.Context) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metricsURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", string(expfmt.NewFormat(expfmt.TypeTextPlain)))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%s: apiserver returned %d", kubernetesAPIServerMetricsPath, resp.StatusCode)
+	}
+
+	return getAPIServerInflightRequests(resp.Body, s.metadata.RequestKind)
+}
+
+// getAPIServerInflightRequests sums the apiserver_current_inflight_requests of the requestKind in the metrics of an apiserver
+func getAPIServerInflightRequests(body io.Reader, requestKind string) (float64, error) {
+	inflightRequests, err := filterMetricFamily(body, kubernetesAPIServerInflightRequests)
+	if err != nil {
+		return 0, fmt.Errorf("error reading the apiserver metrics: %w", err)
+	}
+
+	familiesParser := expfmt.TextParser{}
+	families, err := familiesParser.TextToMetricFamilies(inflightRequests)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing the apiserver metrics: %w", err)
+	}
+	family, ok := families[kubernetesAPIServerInflightRequests]
+	if !ok {
+		return 0, fmt.Errorf("metric '%s' not found", kubernetesAPIServerInflightRequests)
+	}
+
+	var requests float64
+	var found bool
+	for _, metric := range family.GetMetric() {
+		if requestKind != kubernetesAPIServerRequestKindAll && getRequestKind(metric) != requestKind {
+			continue
+		}
+		found = true
+		requests += metric.GetGauge().GetValue()
+	}
+	if !found {
+		return 0, fmt.Errorf("metric '%s' not found for request_kind %s", kubernetesAPIServerInflightRequests, requestKind)
+	}
+	return requests, nil
+}
+
+func getRequestKind(metric *dto.Metric) string {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == "request_kind" {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+// filterMetricFamily streams metrics in the text format and only keeps the lines of the metric family name,
+// so that the thousands of other apiserver metrics are never parsed nor kept in memory
+func filterMetricFamily(body io.Reader, name string) (io.Reader, error) {
+	prefixes := []string{name + " ", name + "{", "# HELP " + name + " ", "# TYPE " + name + " "}
+
+	var family bytes.Buffer
+	reader := bufio.NewReader(body)
+	for {
+		line, err := reader.ReadString('\n')
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(line, prefix) {
+				family.WriteString(strings.TrimSuffix(line, "\n") + "\n")
+				break
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return &family, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+const testAPIServerMetrics = `# HELP apiserver_current_inflight_requests [STABLE] Maximal number of currently used inflight request limit of this apiserver per request kind in last second.
+# TYPE apiserver_current_inflight_requests gauge
+apiserver_current_inflight_requests{request_kind="mutating"} 7
+apiserver_current_inflight_requests{request_kind="readOnly"} 35
+# HELP apiserver_flowcontrol_current_inqueue_requests [BETA] Number of requests currently pending in queues of the API Priority and Fairness subsystem
+# TYPE apiserver_flowcontrol_current_inqueue_requests gauge
+apiserver_flowcontrol_current_inqueue_requests{flow_schema="catch-all",priority_level="catch-all"} 0
+# HELP apiserver_request_total [STABLE] Counter of apiserver requests broken out for each verb, dry run value, group, version, resource, scope, component, and HTTP response code.
+# TYPE apiserver_request_total counter
+apiserver_request_total{code="200",component="apiserver",dry_run="",group="",resource="pods",scope="namespace",subresource="",verb="LIST",version="v1"} 1337
+`
+
+type kubernetesAPIServerMetadataTestData struct {
+	name     string
+	metadata map[string]string
+	isError  bool
+}
+
+var parseKubernetesAPIServerMetadataTestDataset = []kubernetesAPIServerMetadataTestData{
+	{"default request kind", map[string]string{"value": "100"}, false},
+	{"read only requests", map[string]string{"value": "100", "requestKind": "readOnly", "activationValue": "10"}, false},
+	{"mutating requests", map[string]string{"value": "100", "requestKind": "mutating"}, false},
+	{"unknown request kind", map[string]string{"value": "100", "requestKind": "watch"}, true},
+	{"missing value", map[string]string{}, true},
+	{"invalid value", map[string]string{"value": "a"}, true},
+	{"invalid activation value", map[string]string{"value": "100", "activationValue": "a"}, true},
+}
+
+func TestParseKubernetesAPIServerMetadata(t *testing.T) {
+	for _, testData := range parseKubernetesAPIServerMetadataTestDataset {
+		t.Run(testData.name, func(t *testing.T) {
+			_, err := parseKubernetesAPIServerMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata})
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetAPIServerInflightRequests(t *testing.T) {
+	testCases := []struct {
+		name        string
+		metrics     string
+		requestKind string
+		expected    float64
+		isError     bool
+	}{
+		{name: "all requests", metrics: testAPIServerMetrics, requestKind: "all", expected: 42},
+		{name: "read only requests", metrics: testAPIServerMetrics, requestKind: "readOnly", expected: 35},
+		{name: "mutating requests", metrics: testAPIServerMetrics, requestKind: "mutating", expected: 7},
+		{
+			name:        "missing request kind",
+			metrics:     "# TYPE apiserver_current_inflight_requests gauge\napiserver_current_inflight_requests{request_kind=\"readOnly\"} 3\n",
+			requestKind: "mutating",
+			isError:     true,
+		},
+		{name: "missing metric", metrics: "# TYPE apiserver_request_total counter\napiserver_request_total 1\n", requestKind: "all", isError: true},
+		{
+			name:        "invalid other metrics are ignored",
+			metrics:     testAPIServerMetrics + "apiserver_request_total{code=\"200\" 3\napiserver_current_inflight_requests_total 3",
+			requestKind: "all",
+			expected:    42,
+		},
+		{name: "invalid metrics", metrics: "apiserver_current_inflight_requests{request_kind=\"readOnly\" 3\n", requestKind: "all", isError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests, err := getAPIServerInflightRequests(strings.NewReader(tc.metrics), tc.requestKind)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, requests)
+		})
+	}
+}
+
+func TestKubernetesAPIServerScaler(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" || r.Header.Get("Authorization") != "Bearer keda-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(testAPIServerMetrics))
+	}))
+	defer apiServer.Close()
+
+	testCases := []struct {
+		name     string
+		metadata map[string]string
+		expected float64
+		isActive bool
+	}{
+		{name: "active", metadata: map[string]string{"value": "20", "activationValue": "40"}, expected: 42, isActive: true},
+		{name: "below activation", metadata: map[string]string{"value": "20", "requestKind": "mutating", "activationValue": "10"}, expected: 7, isActive: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := newKubernetesAPIServerScaler(
+				&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, GlobalHTTPTimeout: 3000 * time.Millisecond},
+				&rest.Config{Host: apiServer.URL, BearerToken: "keda-token"},
+			)
+			require.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.TODO(), "s0-apiserver-inflight-requests")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, metrics[0].Value.AsApproximateFloat64())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
+
+func TestKubernetesAPIServerScalerCachesReadings(t *testing.T) {
+	var requests int
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(testAPIServerMetrics))
+	}))
+	defer apiServer.Close()
+
+	s, err := newKubernetesAPIServerScaler(
+		&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{"value": "20"}, TriggerPollingInterval: 30 * time.Second},
+		&rest.Config{Host: apiServer.URL},
+	)
+	require.NoError(t, err)
+	now := time.Now()
+	s.(*kubernetesAPIServerScaler).now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		_, _, err = s.GetMetricsAndActivity(context.TODO(), "s0-apiserver-inflight-requests")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, requests)
+
+	now = now.Add(30 * time.Second)
+	_, _, err = s.GetMetricsAndActivity(context.TODO(), "s0-apiserver-inflight-requests")
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+}
+
+func TestKubernetesAPIServerScalerForbidden(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer apiServer.Close()
+
+	s, err := newKubernetesAPIServerScaler(
+		&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{"value": "20"}},
+		&rest.Config{Host: apiServer.URL},
+	)
+	require.NoError(t, err)
+
+	_, _, err = s.GetMetricsAndActivity(context.TODO(), "s0-apiserver-inflight-requests")
+	assert.ErrorContains(t, err, "apiserver returned 403")
+}
+
+func TestKubernetesAPIServerGetMetricSpecForScaling(t *testing.T) {
+	s, err := newKubernetesAPIServerScaler(
+		&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{"value": "20"}, TriggerIndex: 2},
+		&rest.Config{Host: "https://kubernetes.default.svc"},
+	)
+	require.NoError(t, err)
+
+	metricSpec := s.GetMetricSpecForScaling(context.TODO())
+	assert.Equal(t, "s2-apiserver-inflight-requests", metricSpec[0].External.Metric.Name)
+}
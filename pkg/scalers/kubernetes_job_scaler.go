@@ -0,0 +1,188 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+type kubernetesJobScaler struct {
+	metricType v2.MetricTargetType
+	metadata   kubernetesJobMetadata
+	kubeClient client.Client
+	logger     logr.Logger
+}
+
+const (
+	kubernetesJobMetricType = "External"
+
+	// jobStatusPending is an unfinished Job with no ready pod yet
+	jobStatusPending = "pending"
+	// jobStatusRunning is an unfinished Job with at least one ready pod
+	jobStatusRunning = "running"
+	// jobStatusSuspended is an unfinished Job suspended through spec.suspend
+	jobStatusSuspended = "suspended"
+	// jobStatusSucceeded is a Job with the Complete condition
+	jobStatusSucceeded = "succeeded"
+	// jobStatusFailed is a Job with the Failed condition
+	jobStatusFailed = "failed"
+)
+
+// defaultJobStatuses are the statuses counted when jobStatus isn't given, i.e. the Jobs still queued or running
+var defaultJobStatuses = []string{jobStatusPending, jobStatusRunning}
+
+type kubernetesJobMetadata struct {
+	JobSelector     string   `keda:"name=jobSelector,     order=triggerMetadata"`
+	JobStatus       []string `keda:"name=jobStatus,       order=triggerMetadata, enum=pending;running;suspended;succeeded;failed, optional"`
+	Value           float64  `keda:"name=value,           order=triggerMetadata, default=0"`
+	ActivationValue float64  `keda:"name=activationValue, order=triggerMetadata, default=0"`
+
+	namespace      string
+	triggerIndex   int
+	jobSelector    labels.Selector
+	asMetricSource bool
+}
+
+func (m *kubernetesJobMetadata) Validate() error {
+	if m.Value <= 0 && !m.asMetricSource {
+		return fmt.Errorf("value must be a float greater than 0")
+	}
+
+	return nil
+}
+
+// NewKubernetesJobScaler creates a new kubernetesJobScaler
+func NewKubernetesJobScaler(kubeClient client.Client, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseKubernetesJobMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing kubernetes job metadata: %w", err)
+	}
+
+	return &kubernetesJobScaler{
+		metricType: metricType,
+		metadata:   meta,
+		kubeClient: kubeClient,
+		logger:     InitializeLogger(config, "kubernetes_job_scaler"),
+	}, nil
+}
+
+func parseKubernetesJobMetadata(config *scalersconfig.ScalerConfig) (kubernetesJobMetadata, error) {
+	meta := kubernetesJobMetadata{}
+	meta.namespace = config.ScalableObjectNamespace
+	meta.triggerIndex = config.TriggerIndex
+	meta.asMetricSource = config.AsMetricSource
+
+	err := config.TypedConfig(&meta)
+	if err != nil {
+		return meta, fmt.Errorf("error parsing kubernetes job metadata: %w", err)
+	}
+
+	meta.jobSelector, err = labels.Parse(meta.JobSelector)
+	if err != nil {
+		return meta, fmt.Errorf("error parsing job selector %q: %w", meta.JobSelector, err)
+	}
+	if meta.jobSelector.Empty() {
+		return meta, fmt.Errorf("no job selector given")
+	}
+	if len(meta.JobStatus) == 0 {
+		meta.JobStatus = defaultJobStatuses
+	}
+
+	return meta, nil
+}
+
+func (s *kubernetesJobScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *kubernetesJobScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("job-%s", s.metadata.namespace))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: kubernetesJobMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns value for a supported metric
+func (s *kubernetesJobScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	jobs, err := s.getMetricValue(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting kubernetes jobs: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(jobs))
+
+	return []external_metrics.ExternalMetricValue{metric}, float64(jobs) > s.metadata.ActivationValue, nil
+}
+
+// getMetricValue counts the Jobs matching the selector whose status is one of the configured ones
+func (s *kubernetesJobScaler) getMetricValue(ctx context.Context) (int64, error) {
+	jobList := &batchv1.JobList{}
+	listOptions := client.ListOptions{
+		LabelSelector: s.metadata.jobSelector,
+		Namespace:     s.metadata.namespace,
+	}
+
+	err := s.kubeClient.List(ctx, jobList, &listOptions)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, job := range jobList.Items {
+		if slices.Contains(s.metadata.JobStatus, getJobStatus(job)) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func getJobStatus(job batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return jobStatusSucceeded
+		case batchv1.JobFailed:
+			return jobStatusFailed
+		}
+	}
+
+	if job.Spec.Suspend != nil && *job.Spec.Suspend {
+		return jobStatusSuspended
+	}
+
+	// status.ready isn't set by clusters older than 1.24, the active pods are considered ready there
+	ready := job.Status.Active
+	if job.Status.Ready != nil {
+		ready = *job.Status.Ready
+	}
+	if ready > 0 {
+		return jobStatusRunning
+	}
+	return jobStatusPending
+}
@@ -0,0 +1,166 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type jobMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+var parseJobMetadataTestDataset = []jobMetadataTestData{
+	{map[string]string{"value": "1", "jobSelector": "app=demo"}, false},
+	{map[string]string{"value": "1", "jobSelector": "app in (demo1, demo2)"}, false},
+	{map[string]string{"value": "1", "jobSelector": "app=demo", "jobStatus": "failed"}, false},
+	{map[string]string{"value": "1", "jobSelector": "app=demo", "jobStatus": "pending,running,suspended,succeeded,failed"}, false},
+	{map[string]string{"value": "1", "jobSelector": "app=demo", "activationValue": "2"}, false},
+	{map[string]string{"value": "1"}, true},
+	{map[string]string{"value": "1", "jobSelector": ""}, true},
+	{map[string]string{"value": "1", "jobSelector": "app in (demo1"}, true},
+	{map[string]string{"jobSelector": "app=demo"}, true},
+	{map[string]string{"value": "0", "jobSelector": "app=demo"}, true},
+	{map[string]string{"value": "a", "jobSelector": "app=demo"}, true},
+	{map[string]string{"value": "1", "jobSelector": "app=demo", "activationValue": "aa"}, true},
+	{map[string]string{"value": "1", "jobSelector": "app=demo", "jobStatus": "queued"}, true},
+}
+
+func TestParseJobMetadata(t *testing.T) {
+	for _, testData := range parseJobMetadataTestDataset {
+		_, err := NewKubernetesJobScaler(
+			fake.NewClientBuilder().Build(),
+			&scalersconfig.ScalerConfig{
+				TriggerMetadata:         testData.metadata,
+				ScalableObjectNamespace: "default",
+			},
+		)
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for %v", testData.metadata)
+		}
+	}
+}
+
+func TestJobGetMetricSpecForScaling(t *testing.T) {
+	s, err := NewKubernetesJobScaler(
+		fake.NewClientBuilder().Build(),
+		&scalersconfig.ScalerConfig{
+			TriggerMetadata:         map[string]string{"value": "1", "jobSelector": "app=demo"},
+			ScalableObjectNamespace: "test",
+			TriggerIndex:            2,
+		},
+	)
+	if err != nil {
+		t.Fatal("Error creating scaler", err)
+	}
+	metric := s.GetMetricSpecForScaling(context.Background())
+
+	if metric[0].External.Metric.Name != "s2-job-test" {
+		t.Errorf("Expected 's2-job-test' as metric name and got '%s'", metric[0].External.Metric.Name)
+	}
+}
+
+func TestJobGetMetricsAndActivity(t *testing.T) {
+	newJob := func(name, namespace string, labels map[string]string, mutate func(*batchv1.Job)) batchv1.Job {
+		job := batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}}
+		if mutate != nil {
+			mutate(&job)
+		}
+		return job
+	}
+	withCondition := func(conditionType batchv1.JobConditionType) func(*batchv1.Job) {
+		return func(job *batchv1.Job) {
+			job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: v1.ConditionTrue}}
+		}
+	}
+	demo := map[string]string{"app": "demo"}
+	list := &batchv1.JobList{Items: []batchv1.Job{
+		newJob("pending-1", "default", demo, nil),
+		newJob("pending-2", "default", demo, func(job *batchv1.Job) {
+			job.Status.Active = 1
+			job.Status.Ready = ptr.To(int32(0))
+		}),
+		newJob("running-1", "default", demo, func(job *batchv1.Job) {
+			job.Status.Active = 2
+			job.Status.Ready = ptr.To(int32(1))
+		}),
+		newJob("running-without-ready-1", "default", demo, func(job *batchv1.Job) {
+			job.Status.Active = 1
+		}),
+		newJob("suspended-1", "default", demo, func(job *batchv1.Job) {
+			job.Spec.Suspend = ptr.To(true)
+		}),
+		newJob("succeeded-1", "default", demo, withCondition(batchv1.JobComplete)),
+		newJob("succeeded-2", "default", demo, withCondition(batchv1.JobComplete)),
+		newJob("failed-1", "default", demo, withCondition(batchv1.JobFailed)),
+		newJob("suspended-failed-1", "default", demo, func(job *batchv1.Job) {
+			job.Spec.Suspend = ptr.To(true)
+			withCondition(batchv1.JobFailed)(job)
+		}),
+		newJob("other-app-1", "default", map[string]string{"app": "other"}, nil),
+		newJob("other-namespace-1", "other", demo, nil),
+	}}
+
+	testCases := []struct {
+		name            string
+		jobStatus       string
+		activationValue string
+		expected        int64
+		active          bool
+	}{
+		{"default statuses", "", "0", 4, true},
+		{"pending", "pending", "0", 2, true},
+		{"running", "running", "0", 2, true},
+		{"suspended", "suspended", "0", 1, true},
+		{"succeeded", "succeeded", "0", 2, true},
+		{"failed", "failed", "0", 2, true},
+		{"finished", "succeeded, failed", "0", 4, true},
+		{"all", "pending,running,suspended,succeeded,failed", "0", 9, true},
+		{"below activation", "pending", "2", 2, false},
+		{"above activation", "pending,running", "3", 4, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata := map[string]string{
+				"jobSelector":     "app=demo",
+				"value":           "1",
+				"activationValue": tc.activationValue,
+			}
+			if tc.jobStatus != "" {
+				metadata["jobStatus"] = tc.jobStatus
+			}
+			s, err := NewKubernetesJobScaler(
+				fake.NewClientBuilder().WithRuntimeObjects(list).Build(),
+				&scalersconfig.ScalerConfig{
+					TriggerMetadata:         metadata,
+					ScalableObjectNamespace: "default",
+				},
+			)
+			if err != nil {
+				t.Fatal("Error creating scaler", err)
+			}
+			metrics, isActive, err := s.GetMetricsAndActivity(context.TODO(), "Metric")
+			if err != nil {
+				t.Fatal("Error getting metrics", err)
+			}
+			if value := metrics[0].Value.MilliValue() / 1000; value != tc.expected {
+				t.Errorf("Expected %d jobs but got %d", tc.expected, value)
+			}
+			if isActive != tc.active {
+				t.Errorf("Expected active=%t but got %t", tc.active, isActive)
+			}
+		})
+	}
+}
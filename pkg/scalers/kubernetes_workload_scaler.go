@@ -2,9 +2,13 @@ package scalers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	v2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -20,10 +24,20 @@ type kubernetesWorkloadScaler struct {
 	metadata   kubernetesWorkloadMetadata
 	kubeClient client.Client
 	logger     logr.Logger
+
+	// unreadySince is when the selected workloads started to lack ready replicas, in mode unready
+	lock         sync.Mutex
+	unreadySince time.Time
+	now          func() time.Time
 }
 
 const (
 	kubernetesWorkloadMetricType = "External"
+
+	// kubernetesWorkloadModeCount counts the pods, kubernetesWorkloadModeUnready counts the replicas the
+	// workloads of the pods are missing to be ready
+	kubernetesWorkloadModeCount   = "count"
+	kubernetesWorkloadModeUnready = "unready"
 )
 
 var phasesCountedAsTerminated = []corev1.PodPhase{
@@ -32,14 +46,22 @@ var phasesCountedAsTerminated = []corev1.PodPhase{
 }
 
 type kubernetesWorkloadMetadata struct {
-	PodSelector     string  `keda:"name=podSelector,     order=triggerMetadata"`
-	Value           float64 `keda:"name=value,           order=triggerMetadata, default=0"`
-	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, default=0"`
-
-	namespace      string
-	triggerIndex   int
-	podSelector    labels.Selector
-	asMetricSource bool
+	// PodSelector holds one or more label selectors separated by ';', as ',' is already part of the selector syntax
+	PodSelector     []string `keda:"name=podSelector,     order=triggerMetadata, separator=;"`
+	Value           float64  `keda:"name=value,           order=triggerMetadata, default=0"`
+	ActivationValue float64  `keda:"name=activationValue, order=triggerMetadata, default=0"`
+	// Mode unready reports the desired replicas minus the ready ones of the Deployments and StatefulSets
+	// whose pods are selected, as stated by their status
+	Mode string `keda:"name=mode, order=triggerMetadata, enum=count;unready, default=count"`
+	// MinUnreadyDuration is how long the workloads have to lack ready replicas to be reported in mode unready,
+	// so pods which are starting or briefly failing their probes don't count
+	MinUnreadyDuration string `keda:"name=minUnreadyDuration, order=triggerMetadata, optional"`
+
+	namespace          string
+	triggerIndex       int
+	podSelectors       []labels.Selector
+	asMetricSource     bool
+	minUnreadyDuration time.Duration
 }
 
 func (m *kubernetesWorkloadMetadata) Validate() error {
@@ -47,6 +69,20 @@ func (m *kubernetesWorkloadMetadata) Validate() error {
 		return fmt.Errorf("value must be a float greater than 0")
 	}
 
+	if m.MinUnreadyDuration != "" {
+		if m.Mode != kubernetesWorkloadModeUnready {
+			return errors.New("minUnreadyDuration requires mode unready")
+		}
+		minUnreadyDuration, err := time.ParseDuration(m.MinUnreadyDuration)
+		if err != nil {
+			return fmt.Errorf("error parsing minUnreadyDuration: %w", err)
+		}
+		if minUnreadyDuration < 0 {
+			return errors.New("minUnreadyDuration must not be negative")
+		}
+		m.minUnreadyDuration = minUnreadyDuration
+	}
+
 	return nil
 }
 
@@ -67,6 +103,7 @@ func NewKubernetesWorkloadScaler(kubeClient client.Client, config *scalersconfig
 		metadata:   meta,
 		kubeClient: kubeClient,
 		logger:     InitializeLogger(config, "kubernetes_workload_scaler"),
+		now:        time.Now,
 	}, nil
 }
 
@@ -81,11 +118,30 @@ func parseKubernetesWorkloadMetadata(config *scalersconfig.ScalerConfig) (kubern
 		return meta, fmt.Errorf("error parsing kubernetes workload metadata: %w", err)
 	}
 
-	selector, err := labels.Parse(meta.PodSelector)
-	if err != nil {
-		return meta, fmt.Errorf("error parsing pod selector: %w", err)
+	for _, podSelector := range meta.PodSelector {
+		if podSelector == "" {
+			continue
+		}
+		selector, err := labels.Parse(podSelector)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing pod selector %q: %w", podSelector, err)
+		}
+		meta.podSelectors = append(meta.podSelectors, selector)
+	}
+	if len(meta.podSelectors) == 0 {
+		return meta, fmt.Errorf("no pod selector given")
+	}
+
+	// the pods added by scaling the target up are unready until they start, so they would
+	// scale it up further, and the pods of a broken rollout would scale it up without bound
+	if meta.Mode == kubernetesWorkloadModeUnready && len(config.ScaleTargetPodLabels) > 0 {
+		targetLabels := labels.Set(config.ScaleTargetPodLabels)
+		for i, selector := range meta.podSelectors {
+			if selector.Matches(targetLabels) {
+				return meta, fmt.Errorf("pod selector %q matches the pods of the scale target, mode unready has to select the pods of another workload", meta.PodSelector[i])
+			}
+		}
 	}
-	meta.podSelector = selector
 
 	return meta, nil
 }
@@ -97,6 +153,9 @@ func (s *kubernetesWorkloadScaler) Close(context.Context) error {
 // GetMetricSpecForScaling returns the metric spec for the HPA
 func (s *kubernetesWorkloadScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	metricName := kedautil.NormalizeString(fmt.Sprintf("workload-%s", s.metadata.namespace))
+	if s.metadata.Mode == kubernetesWorkloadModeUnready {
+		metricName = kedautil.NormalizeString(fmt.Sprintf("workload-unready-%s", s.metadata.namespace))
+	}
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
@@ -119,21 +178,34 @@ func (s *kubernetesWorkloadScaler) GetMetricsAndActivity(ctx context.Context, me
 	return []external_metrics.ExternalMetricValue{metric}, float64(pods) > s.metadata.ActivationValue, nil
 }
 
+// getMetricValue counts the pods matching any of the selectors, pods matched by more than one
+// selector are only counted once. In mode unready, the unready replicas of their workloads are counted instead
 func (s *kubernetesWorkloadScaler) getMetricValue(ctx context.Context) (int64, error) {
-	podList := &corev1.PodList{}
-	listOptions := client.ListOptions{
-		LabelSelector: s.metadata.podSelector,
-		Namespace:     s.metadata.namespace,
-	}
-
-	err := s.kubeClient.List(ctx, podList, &listOptions)
-	if err != nil {
-		return 0, err
+	if s.metadata.Mode == kubernetesWorkloadModeUnready {
+		return s.getUnreadyReplicas(ctx)
 	}
 
+	counted := make(map[string]struct{})
 	var count int64
-	for _, pod := range podList.Items {
-		count += getCountValue(pod)
+	for _, selector := range s.metadata.podSelectors {
+		podList := &corev1.PodList{}
+		listOptions := client.ListOptions{
+			LabelSelector: selector,
+			Namespace:     s.metadata.namespace,
+		}
+
+		err := s.kubeClient.List(ctx, podList, &listOptions)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, pod := range podList.Items {
+			if _, ok := counted[pod.Name]; ok {
+				continue
+			}
+			counted[pod.Name] = struct{}{}
+			count += getCountValue(pod)
+		}
 	}
 
 	return count, nil
@@ -147,3 +219,64 @@ func getCountValue(pod corev1.Pod) int64 {
 	}
 	return 1
 }
+
+// getUnreadyReplicas returns the desired replicas minus the ready ones of the Deployments and StatefulSets
+// whose pod template is matched by any of the selectors, once they've lacked ready replicas for minUnreadyDuration
+func (s *kubernetesWorkloadScaler) getUnreadyReplicas(ctx context.Context) (int64, error) {
+	var unready int64
+
+	deployments := &appsv1.DeploymentList{}
+	if err := s.kubeClient.List(ctx, deployments, client.InNamespace(s.metadata.namespace)); err != nil {
+		return 0, err
+	}
+	for _, deployment := range deployments.Items {
+		if s.selectsPodsOf(deployment.Spec.Template.Labels) {
+			unready += getMissingReadyReplicas(deployment.Spec.Replicas, deployment.Status.ReadyReplicas)
+		}
+	}
+
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := s.kubeClient.List(ctx, statefulSets, client.InNamespace(s.metadata.namespace)); err != nil {
+		return 0, err
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if s.selectsPodsOf(statefulSet.Spec.Template.Labels) {
+			unready += getMissingReadyReplicas(statefulSet.Spec.Replicas, statefulSet.Status.ReadyReplicas)
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if unready == 0 {
+		s.unreadySince = time.Time{}
+		return 0, nil
+	}
+	now := s.now()
+	if s.unreadySince.IsZero() {
+		s.unreadySince = now
+	}
+	if now.Sub(s.unreadySince) < s.metadata.minUnreadyDuration {
+		return 0, nil
+	}
+	return unready, nil
+}
+
+// selectsPodsOf returns whether any of the selectors matches the labels of a pod template
+func (s *kubernetesWorkloadScaler) selectsPodsOf(podLabels map[string]string) bool {
+	for _, selector := range s.metadata.podSelectors {
+		if selector.Matches(labels.Set(podLabels)) {
+			return true
+		}
+	}
+	return false
+}
+
+// getMissingReadyReplicas returns the desired replicas of a workload minus its ready ones, the surge of a rollout
+// being ignored. The desired replicas default to 1 like the apiserver does
+func getMissingReadyReplicas(desired *int32, ready int32) int64 {
+	desiredReplicas := int32(1)
+	if desired != nil {
+		desiredReplicas = *desired
+	}
+	return int64(max(desiredReplicas-ready, 0))
+}
@@ -7,8 +7,11 @@ import (
 	"testing"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
@@ -34,6 +37,14 @@ var parseWorkloadMetadataTestDataset = []workloadMetadataTestData{
 	{map[string]string{"value": "0", "podSelector": "app=demo"}, "test", true},
 	{map[string]string{"value": "0", "podSelector": "app=demo"}, "default", true},
 	{map[string]string{"value": "1", "activationValue": "aa", "podSelector": "app=demo"}, "test", true},
+	{map[string]string{"value": "1", "podSelector": "app=demo;app in (demo1, demo2),deploy=deploy1"}, "test", false},
+	{map[string]string{"value": "1", "podSelector": "app=demo;"}, "test", false},
+	{map[string]string{"value": "1", "podSelector": ";"}, "test", true},
+	{map[string]string{"value": "1", "podSelector": "app=demo;app in (demo1"}, "test", true},
+	{map[string]string{"value": "1", "podSelector": "app=demo", "mode": "unready", "minUnreadyDuration": "2m"}, "test", false},
+	{map[string]string{"value": "1", "podSelector": "app=demo", "mode": "ready"}, "test", true},
+	{map[string]string{"value": "1", "podSelector": "app=demo", "mode": "unready", "minUnreadyDuration": "2"}, "test", true},
+	{map[string]string{"value": "1", "podSelector": "app=demo", "minUnreadyDuration": "2m"}, "test", true},
 }
 
 func TestParseWorkloadMetadata(t *testing.T) {
@@ -113,6 +124,8 @@ var getMetricSpecForScalingTestDataset = []workloadGetMetricSpecForScalingTestDa
 	{parseWorkloadMetadataTestDataset[2].metadata, parseWorkloadMetadataTestDataset[2].namespace, 2, "s2-workload-test"},
 	// "podSelector": "app in (demo1, demo2),deploy in (deploy1, deploy2)", "namespace": "test"
 	{parseWorkloadMetadataTestDataset[3].metadata, parseWorkloadMetadataTestDataset[3].namespace, 3, "s3-workload-test"},
+	// "podSelector": "app=demo", "mode": "unready", "namespace": "test"
+	{parseWorkloadMetadataTestDataset[17].metadata, parseWorkloadMetadataTestDataset[17].namespace, 4, "s4-workload-unready-test"},
 }
 
 func TestWorkloadGetMetricSpecForScaling(t *testing.T) {
@@ -139,6 +152,173 @@ func TestWorkloadGetMetricSpecForScaling(t *testing.T) {
 	}
 }
 
+func TestWorkloadMultiplePodSelectors(t *testing.T) {
+	newPod := func(name string, labels map[string]string) v1.Pod {
+		return v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels}}
+	}
+	list := &v1.PodList{Items: []v1.Pod{
+		newPod("frontend-1", map[string]string{"app": "frontend", "group": "shop"}),
+		newPod("frontend-2", map[string]string{"app": "frontend", "group": "shop"}),
+		newPod("backend-1", map[string]string{"app": "backend", "group": "shop"}),
+		newPod("worker-1", map[string]string{"app": "worker"}),
+		newPod("other-1", map[string]string{"app": "other"}),
+	}}
+
+	testCases := []struct {
+		name        string
+		podSelector string
+		expected    int64
+	}{
+		{"single selector", "app=frontend", 2},
+		{"disjoint selectors", "app=frontend;app=worker", 3},
+		{"overlapping selectors", "group=shop;app=frontend;app in (backend, worker)", 4},
+		{"identical selectors", "app=frontend;app=frontend", 2},
+		{"empty selectors are ignored", ";app=frontend; ;app=backend;", 3},
+		{"selector without matches", "app=frontend;app=missing", 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewKubernetesWorkloadScaler(
+				fake.NewClientBuilder().WithRuntimeObjects(list).Build(),
+				&scalersconfig.ScalerConfig{
+					TriggerMetadata: map[string]string{
+						"podSelector": tc.podSelector,
+						"value":       "1",
+					},
+					ScalableObjectNamespace: "default",
+				},
+			)
+			if err != nil {
+				t.Fatal("Error creating scaler", err)
+			}
+			metrics, _, err := s.GetMetricsAndActivity(context.TODO(), "Metric")
+			if err != nil {
+				t.Fatal("Error getting metrics", err)
+			}
+			if value := metrics[0].Value.MilliValue() / 1000; value != tc.expected {
+				t.Errorf("Expected %d pods but got %d", tc.expected, value)
+			}
+		})
+	}
+}
+
+func TestWorkloadUnready(t *testing.T) {
+	newDeployment := func(name string, app string, desired *int32, ready int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: desired,
+				Template: v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": app}}},
+			},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: ready},
+		}
+	}
+	newStatefulSet := func(name string, app string, desired int32, ready int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: &desired,
+				Template: v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": app}}},
+			},
+			Status: appsv1.StatefulSetStatus{ReadyReplicas: ready},
+		}
+	}
+	objects := []client.Object{
+		// 2 of the 5 desired replicas aren't ready
+		newDeployment("flaky", "flaky", ptr.To[int32](5), 3),
+		// the surge of a rollout doesn't make up for the replicas of other workloads
+		newDeployment("rolling", "flaky", ptr.To[int32](2), 3),
+		// 1 desired replica by default
+		newDeployment("defaulted", "flaky", nil, 0),
+		newStatefulSet("flaky-db", "flaky", 3, 2),
+		// not selected
+		newDeployment("other", "other", ptr.To[int32](10), 0),
+		newStatefulSet("other-db", "other", 3, 0),
+	}
+
+	testCases := []struct {
+		name               string
+		minUnreadyDuration string
+		activationValue    string
+		objects            []client.Object
+		expected           []int64
+		isActive           bool
+	}{
+		{name: "unready replicas", objects: objects, expected: []int64{4, 4}, isActive: true},
+		{name: "below activation", activationValue: "4", objects: objects, expected: []int64{4, 4}, isActive: false},
+		{name: "sustained unready replicas", minUnreadyDuration: "5m", objects: objects, expected: []int64{0, 4}, isActive: true},
+		{name: "all ready", minUnreadyDuration: "5m", objects: []client.Object{newDeployment("ready", "flaky", ptr.To[int32](2), 2)}, expected: []int64{0, 0}, isActive: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata := map[string]string{"podSelector": "app=flaky", "value": "1", "mode": "unready"}
+			if tc.minUnreadyDuration != "" {
+				metadata["minUnreadyDuration"] = tc.minUnreadyDuration
+			}
+			if tc.activationValue != "" {
+				metadata["activationValue"] = tc.activationValue
+			}
+			s, err := NewKubernetesWorkloadScaler(
+				fake.NewClientBuilder().WithObjects(tc.objects...).Build(),
+				&scalersconfig.ScalerConfig{
+					TriggerMetadata:         metadata,
+					ScalableObjectNamespace: "default",
+					ScaleTargetPodLabels:    map[string]string{"app": "companion"},
+				},
+			)
+			if err != nil {
+				t.Fatal("Error creating scaler", err)
+			}
+			now := time.Now()
+			s.(*kubernetesWorkloadScaler).now = func() time.Time { return now }
+
+			// the unready replicas are reported once they're unready for minUnreadyDuration
+			for i, expected := range tc.expected {
+				metrics, isActive, err := s.GetMetricsAndActivity(context.TODO(), "Metric")
+				if err != nil {
+					t.Fatal("Error getting metrics", err)
+				}
+				if value := metrics[0].Value.MilliValue() / 1000; value != expected {
+					t.Errorf("Expected %d unready replicas on poll %d but got %d", expected, i, value)
+				}
+				if i == len(tc.expected)-1 && isActive != tc.isActive {
+					t.Errorf("Expected active %t but got %t", tc.isActive, isActive)
+				}
+				now = now.Add(10 * time.Minute)
+			}
+		})
+	}
+}
+
+func TestWorkloadUnreadySelectingScaleTarget(t *testing.T) {
+	_, err := NewKubernetesWorkloadScaler(
+		fake.NewClientBuilder().Build(),
+		&scalersconfig.ScalerConfig{
+			TriggerMetadata:         map[string]string{"podSelector": "app=other;app=flaky", "value": "1", "mode": "unready"},
+			ScalableObjectNamespace: "default",
+			ScaleTargetPodLabels:    map[string]string{"app": "flaky", "pod-template-hash": "abc"},
+		},
+	)
+	if err == nil || !strings.Contains(err.Error(), "matches the pods of the scale target") {
+		t.Errorf("Expected the selector of the scale target to be rejected but got %v", err)
+	}
+
+	// counting the pods of the scale target itself is fine
+	_, err = NewKubernetesWorkloadScaler(
+		fake.NewClientBuilder().Build(),
+		&scalersconfig.ScalerConfig{
+			TriggerMetadata:         map[string]string{"podSelector": "app=flaky", "value": "1"},
+			ScalableObjectNamespace: "default",
+			ScaleTargetPodLabels:    map[string]string{"app": "flaky"},
+		},
+	)
+	if err != nil {
+		t.Error("Expected success but got error", err)
+	}
+}
+
 func createPodlist(count int) *v1.PodList {
 	list := &v1.PodList{}
 	for i := 0; i < count; i++ {
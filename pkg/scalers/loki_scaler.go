@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
@@ -38,8 +39,34 @@ type lokiMetadata struct {
 	TenantName          string  `keda:"name=tenantName,order=triggerMetadata,optional"`
 	IgnoreNullValues    bool    `keda:"name=ignoreNullValues,order=triggerMetadata,default=true"`
 	UnsafeSsl           bool    `keda:"name=unsafeSsl,order=triggerMetadata,default=false"`
+	QueryRange          string  `keda:"name=queryRange,order=triggerMetadata,optional"`
+	QueryStep           string  `keda:"name=queryStep,order=triggerMetadata,optional"`
 	TriggerIndex        int
 	Auth                *authentication.AuthMeta
+
+	queryRange time.Duration
+	queryStep  time.Duration
+}
+
+func (m *lokiMetadata) Validate() error {
+	if m.QueryRange != "" {
+		queryRange, err := time.ParseDuration(m.QueryRange)
+		if err != nil || queryRange <= 0 {
+			return fmt.Errorf("queryRange must be a positive duration, got %q", m.QueryRange)
+		}
+		m.queryRange = queryRange
+	}
+	if m.QueryStep != "" {
+		if m.queryRange == 0 {
+			return fmt.Errorf("queryStep requires queryRange")
+		}
+		queryStep, err := time.ParseDuration(m.QueryStep)
+		if err != nil || queryStep <= 0 {
+			return fmt.Errorf("queryStep must be a positive duration, got %q", m.QueryStep)
+		}
+		m.queryStep = queryStep
+	}
+	return nil
 }
 
 type lokiQueryResult struct {
@@ -47,8 +74,9 @@ type lokiQueryResult struct {
 	Data   struct {
 		ResultType string `json:"resultType"`
 		Result     []struct {
-			Metric struct{}      `json:"metric"`
-			Value  []interface{} `json:"value"`
+			Metric struct{}        `json:"metric"`
+			Value  []interface{}   `json:"value"`
+			Values [][]interface{} `json:"values"`
 		} `json:"result"`
 	} `json:"data"`
 }
@@ -118,8 +146,21 @@ func (s *lokiScaler) ExecuteLokiQuery(ctx context.Context) (float64, error) {
 	if err != nil {
 		return -1, err
 	}
-	u.Path = "/loki/api/v1/query"
-	u.RawQuery = url.Values{"query": []string{s.metadata.Query}}.Encode()
+	query := url.Values{"query": []string{s.metadata.Query}}
+	if s.metadata.queryRange > 0 {
+		// a range query returns a matrix, of which the latest value is used, e.g. the log volume of
+		// sum(count_over_time({app="x"}[1m])) in the last minute
+		u.Path = "/loki/api/v1/query_range"
+		end := time.Now()
+		query.Set("start", strconv.FormatInt(end.Add(-s.metadata.queryRange).UnixNano(), 10))
+		query.Set("end", strconv.FormatInt(end.UnixNano(), 10))
+		if s.metadata.queryStep > 0 {
+			query.Set("step", strconv.FormatFloat(s.metadata.queryStep.Seconds(), 'f', -1, 64))
+		}
+	} else {
+		u.Path = "/loki/api/v1/query"
+	}
+	u.RawQuery = query.Encode()
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
@@ -174,6 +215,12 @@ func (s *lokiScaler) parseQueryResult(result lokiQueryResult) (float64, error) {
 	}
 
 	values := result.Data.Result[0].Value
+	if result.Data.ResultType == "matrix" {
+		values = nil
+		if samples := result.Data.Result[0].Values; len(samples) > 0 {
+			values = samples[len(samples)-1]
+		}
+	}
 	if len(values) == 0 {
 		if s.metadata.IgnoreNullValues {
 			return 0, nil
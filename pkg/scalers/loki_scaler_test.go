@@ -4,8 +4,10 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
@@ -40,6 +42,14 @@ var testLokiMetadata = []parseLokiMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(rate({filename=\"/var/log/syslog\"}[1m])) by (level)", "ignoreNullValues": "xxxx"}, true},
 	// with unsafeSsl
 	{map[string]string{"serverAddress": "https://localhost:3100", "threshold": "1", "query": "sum(rate({filename=\"/var/log/syslog\"}[1m])) by (level)", "unsafeSsl": "true"}, false},
+	// range query
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1000", "query": "sum(count_over_time({app=\"x\"}[1m]))", "queryRange": "5m", "queryStep": "1m"}, false},
+	// malformed queryRange
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1000", "query": "sum(count_over_time({app=\"x\"}[1m]))", "queryRange": "five"}, true},
+	// malformed queryStep
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1000", "query": "sum(count_over_time({app=\"x\"}[1m]))", "queryRange": "5m", "queryStep": "-1m"}, true},
+	// queryStep without queryRange
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1000", "query": "sum(count_over_time({app=\"x\"}[1m]))", "queryStep": "1m"}, true},
 }
 
 type lokiAuthMetadataTestData struct {
@@ -247,3 +257,93 @@ func TestLokiScalerTenantHeader(t *testing.T) {
 	_, err := scaler.ExecuteLokiQuery(context.TODO())
 	assert.NoError(t, err)
 }
+
+var testLokiRangeQueryResult = []lokiQueryResultTestData{
+	{
+		name:             "latest value of the matrix",
+		bodyStr:          `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[1700000000,"120"],[1700000060,"340"],[1700000120,"1250"]]}]}}`,
+		responseStatus:   http.StatusOK,
+		expectedValue:    1250,
+		ignoreNullValues: true,
+	},
+	{
+		name:             "empty matrix",
+		bodyStr:          `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+		responseStatus:   http.StatusOK,
+		expectedValue:    0,
+		ignoreNullValues: true,
+	},
+	{
+		name:             "empty matrix but shouldn't ignore",
+		bodyStr:          `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+		responseStatus:   http.StatusOK,
+		expectedValue:    -1,
+		isError:          true,
+		ignoreNullValues: false,
+	},
+	{
+		name:             "series without values",
+		bodyStr:          `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[]}]}}`,
+		responseStatus:   http.StatusOK,
+		expectedValue:    0,
+		ignoreNullValues: true,
+	},
+	{
+		name:             "multiple series",
+		bodyStr:          `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"level":"info"},"values":[[1700000000,"1"]]},{"metric":{"level":"error"},"values":[[1700000000,"2"]]}]}}`,
+		responseStatus:   http.StatusOK,
+		expectedValue:    -1,
+		isError:          true,
+		ignoreNullValues: true,
+	},
+}
+
+func TestLokiScalerExecuteLogQLRangeQuery(t *testing.T) {
+	for _, testData := range testLokiRangeQueryResult {
+		t.Run(testData.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				assert.Equal(t, "/loki/api/v1/query_range", request.URL.Path)
+				query := request.URL.Query()
+				assert.Equal(t, `sum(count_over_time({app="x"}[1m]))`, query.Get("query"))
+				assert.Equal(t, "60", query.Get("step"))
+				start, err := strconv.ParseInt(query.Get("start"), 10, 64)
+				assert.NoError(t, err)
+				end, err := strconv.ParseInt(query.Get("end"), 10, 64)
+				assert.NoError(t, err)
+				assert.Equal(t, (5 * time.Minute).Nanoseconds(), end-start)
+
+				writer.WriteHeader(testData.responseStatus)
+				if _, err := writer.Write([]byte(testData.bodyStr)); err != nil {
+					t.Fatal(err)
+				}
+			}))
+			defer server.Close()
+
+			meta, err := parseLokiMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{
+				"serverAddress":    server.URL,
+				"threshold":        "1000",
+				"query":            `sum(count_over_time({app="x"}[1m]))`,
+				"queryRange":       "5m",
+				"queryStep":        "1m",
+				"ignoreNullValues": strconv.FormatBool(testData.ignoreNullValues),
+			}})
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			scaler := lokiScaler{
+				metadata:   meta,
+				httpClient: http.DefaultClient,
+				logger:     logr.Discard(),
+			}
+
+			value, err := scaler.ExecuteLokiQuery(context.TODO())
+
+			assert.Equal(t, testData.expectedValue, value)
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -2,14 +2,18 @@ package scalers
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	neturl "net/url"
+	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/common/expfmt"
@@ -29,6 +33,9 @@ type metricsAPIScaler struct {
 	metricType v2.MetricTargetType
 	metadata   *metricsAPIScalerMetadata
 	httpClient *http.Client
+	clientCert *metricsAPIClientCert
+	tokenFile  *metricsAPITokenFile
+	counter    *metricsAPICounter
 	logger     logr.Logger
 }
 
@@ -40,6 +47,23 @@ type metricsAPIScalerMetadata struct {
 	valueLocation         string
 	unsafeSsl             bool
 
+	// useResponseTime scales on the round-trip time of the request in milliseconds instead of a value
+	// of the response body. Latency is only a heuristic for load, as it also depends on the network
+	// and on anything else happening on the endpoint's side
+	useResponseTime bool
+
+	// valueType counter means the value is a cumulative counter, and the metric is its increase
+	// over counterWindow instead of the value itself
+	valueType     string
+	counterWindow time.Duration
+	counterReset  string
+
+	// timestampLocation points to the time the value was computed at in the response, values older
+	// than maxAge are stale and either fail the poll or are read as zero depending on staleValue
+	timestampLocation string
+	maxAge            time.Duration
+	staleValue        string
+
 	// apiKeyAuth
 	enableAPIKeyAuth bool
 	method           string // way of providing auth key, either "header" (default) or "query"
@@ -58,15 +82,38 @@ type metricsAPIScalerMetadata struct {
 	cert      string
 	key       string
 	ca        string
+	// rotatingClientCert resolves the client certificate again on each poll
+	rotatingClientCert bool
 
 	// bearer
 	enableBearerAuth bool
 	bearerToken      string
+	// tokenFile is read for the bearer token instead, e.g. a projected service account token
+	// to query the external metrics API of the cluster, which the kubelet refreshes in place.
+	// It must be within the directory of KEDA_TOKEN_FILE_DIRECTORY
+	tokenFile string
 
 	triggerIndex int
 }
 
 const (
+	// metricsAPITokenFileRefreshInterval is how long a token read from tokenFile is used before the file is read again
+	metricsAPITokenFileRefreshInterval = time.Minute
+
+	// metricsAPIDefaultCounterWindow is the default pollingInterval of a ScaledObject
+	metricsAPIDefaultCounterWindow = 30 * time.Second
+
+	metricsAPIValueTypeGauge   = "gauge"
+	metricsAPIValueTypeCounter = "counter"
+
+	// metricsAPICounterResetRestart counts the value read after a reset as the increase since the reset,
+	// metricsAPICounterResetIgnore doesn't count anything for the interval in which the counter reset
+	metricsAPICounterResetRestart = "restart"
+	metricsAPICounterResetIgnore  = "ignore"
+
+	metricsAPIStaleValueError = "error"
+	metricsAPIStaleValueZero  = "zero"
+
 	methodValueQuery           = "query"
 	valueLocationWrongErrorMsg = "valueLocation must point to value of type number or a string representing a Quantity got: '%s'"
 )
@@ -104,18 +151,50 @@ func NewMetricsAPIScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 
 	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.unsafeSsl)
 
+	var clientCert *metricsAPIClientCert
+	if meta.rotatingClientCert {
+		if config.ResolveAuthParams == nil {
+			return nil, errors.New("rotatingClientCert isn't supported for this trigger")
+		}
+		clientCert = &metricsAPIClientCert{resolveAuthParams: config.ResolveAuthParams}
+		if err := clientCert.update(meta.cert, meta.key); err != nil {
+			return nil, err
+		}
+	}
+
+	var tokenFile *metricsAPITokenFile
+	if meta.tokenFile != "" {
+		tokenFile = &metricsAPITokenFile{path: meta.tokenFile, refreshInterval: metricsAPITokenFileRefreshInterval}
+		// fail fast on a missing file instead of on each poll
+		if _, err := tokenFile.get(); err != nil {
+			return nil, err
+		}
+	}
+
+	var counter *metricsAPICounter
+	if meta.valueType == metricsAPIValueTypeCounter {
+		counter = &metricsAPICounter{window: meta.counterWindow, reset: meta.counterReset}
+	}
+
 	if meta.enableTLS || len(meta.ca) > 0 {
-		config, err := kedautil.NewTLSConfig(meta.cert, meta.key, meta.ca, meta.unsafeSsl)
+		tlsConfig, err := kedautil.NewTLSConfig(meta.cert, meta.key, meta.ca, meta.unsafeSsl)
 		if err != nil {
 			return nil, err
 		}
-		httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(config)
+		if clientCert != nil {
+			tlsConfig.Certificates = nil
+			tlsConfig.GetClientCertificate = clientCert.getClientCertificate
+		}
+		httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(tlsConfig)
 	}
 
 	return &metricsAPIScaler{
 		metricType: metricType,
 		metadata:   meta,
 		httpClient: httpClient,
+		clientCert: clientCert,
+		tokenFile:  tokenFile,
+		counter:    counter,
 		logger:     InitializeLogger(config, "metrics_api_scaler"),
 	}, nil
 }
@@ -172,15 +251,48 @@ func parseMetricsAPIMetadata(config *scalersconfig.ScalerConfig) (*metricsAPISca
 		meta.format = JSONFormat
 	}
 
+	if val, ok := config.TriggerMetadata["useResponseTime"]; ok {
+		useResponseTime, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing useResponseTime: %w", err)
+		}
+		meta.useResponseTime = useResponseTime
+	}
+
 	if val, ok := config.TriggerMetadata["valueLocation"]; ok {
+		if meta.useResponseTime {
+			return nil, fmt.Errorf("valueLocation can't be used together with useResponseTime")
+		}
 		meta.valueLocation = val
-	} else {
+	} else if !meta.useResponseTime {
 		return nil, fmt.Errorf("no valueLocation given in metadata")
 	}
 
+	if err := parseMetricsAPICounterMetadata(config, &meta); err != nil {
+		return nil, err
+	}
+
+	if err := parseMetricsAPIFreshnessMetadata(config, &meta); err != nil {
+		return nil, err
+	}
+
+	if val, ok := config.TriggerMetadata["rotatingClientCert"]; ok {
+		rotatingClientCert, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing rotatingClientCert: %w", err)
+		}
+		meta.rotatingClientCert = rotatingClientCert
+	}
+
 	authMode, ok := config.TriggerMetadata["authMode"]
 	// no authMode specified
 	if !ok {
+		if meta.rotatingClientCert {
+			return nil, errors.New("rotatingClientCert requires authMode tls")
+		}
+		if config.TriggerMetadata["tokenFile"] != "" {
+			return nil, errors.New("tokenFile requires authMode bearer")
+		}
 		return &meta, nil
 	}
 
@@ -230,9 +342,18 @@ func parseMetricsAPIMetadata(config *scalersconfig.ScalerConfig) (*metricsAPISca
 		meta.key = config.AuthParams["key"]
 		meta.enableTLS = true
 	case authentication.BearerAuthType:
-		if len(config.AuthParams["token"]) == 0 {
+		meta.tokenFile = config.TriggerMetadata["tokenFile"]
+		switch {
+		case meta.tokenFile != "" && len(config.AuthParams["token"]) > 0:
+			return nil, errors.New("token and tokenFile can't be used together")
+		case meta.tokenFile == "" && len(config.AuthParams["token"]) == 0:
 			return nil, errors.New("no token provided")
 		}
+		if meta.tokenFile != "" {
+			if err := kedautil.ValidateTokenFilePath(meta.tokenFile); err != nil {
+				return nil, err
+			}
+		}
 
 		meta.bearerToken = config.AuthParams["token"]
 		meta.enableBearerAuth = true
@@ -240,12 +361,233 @@ func parseMetricsAPIMetadata(config *scalersconfig.ScalerConfig) (*metricsAPISca
 		return nil, fmt.Errorf("err incorrect value for authMode is given: %s", authMode)
 	}
 
+	if config.TriggerMetadata["tokenFile"] != "" && !meta.enableBearerAuth {
+		return nil, errors.New("tokenFile requires authMode bearer")
+	}
+
+	if meta.rotatingClientCert && !meta.enableTLS {
+		return nil, errors.New("rotatingClientCert requires authMode tls")
+	}
+
 	if len(config.AuthParams["ca"]) > 0 {
 		meta.ca = config.AuthParams["ca"]
 	}
 	return &meta, nil
 }
 
+func parseMetricsAPICounterMetadata(config *scalersconfig.ScalerConfig, meta *metricsAPIScalerMetadata) error {
+	meta.valueType = metricsAPIValueTypeGauge
+	if val, ok := config.TriggerMetadata["valueType"]; ok {
+		meta.valueType = strings.TrimSpace(val)
+	}
+	switch meta.valueType {
+	case metricsAPIValueTypeGauge:
+		if config.TriggerMetadata["counterWindow"] != "" || config.TriggerMetadata["counterReset"] != "" {
+			return errors.New("counterWindow and counterReset require valueType counter")
+		}
+		return nil
+	case metricsAPIValueTypeCounter:
+		if meta.useResponseTime {
+			return errors.New("valueType counter can't be used together with useResponseTime")
+		}
+	default:
+		return fmt.Errorf("valueType %s not supported", meta.valueType)
+	}
+
+	// by default the increase is computed over the polling interval of the trigger
+	meta.counterWindow = config.TriggerPollingInterval
+	if meta.counterWindow == 0 {
+		meta.counterWindow = metricsAPIDefaultCounterWindow
+	}
+	if val, ok := config.TriggerMetadata["counterWindow"]; ok {
+		counterWindow, err := time.ParseDuration(val)
+		if err != nil {
+			return fmt.Errorf("error parsing counterWindow: %w", err)
+		}
+		if counterWindow <= 0 {
+			return errors.New("counterWindow must be positive")
+		}
+		meta.counterWindow = counterWindow
+	}
+
+	meta.counterReset = metricsAPICounterResetRestart
+	if val, ok := config.TriggerMetadata["counterReset"]; ok {
+		meta.counterReset = strings.TrimSpace(val)
+	}
+	if meta.counterReset != metricsAPICounterResetRestart && meta.counterReset != metricsAPICounterResetIgnore {
+		return fmt.Errorf("counterReset %s not supported, must be %s or %s", meta.counterReset, metricsAPICounterResetRestart, metricsAPICounterResetIgnore)
+	}
+	return nil
+}
+
+func parseMetricsAPIFreshnessMetadata(config *scalersconfig.ScalerConfig, meta *metricsAPIScalerMetadata) error {
+	meta.timestampLocation = config.TriggerMetadata["timestampLocation"]
+	maxAge, hasMaxAge := config.TriggerMetadata["maxAge"]
+	staleValue, hasStaleValue := config.TriggerMetadata["staleValue"]
+	if meta.timestampLocation == "" {
+		if hasMaxAge || hasStaleValue {
+			return errors.New("maxAge and staleValue require timestampLocation")
+		}
+		return nil
+	}
+
+	if meta.useResponseTime {
+		return errors.New("timestampLocation can't be used together with useResponseTime")
+	}
+	if meta.format == PrometheusFormat {
+		return errors.New("timestampLocation isn't supported with format prometheus")
+	}
+	if !hasMaxAge {
+		return errors.New("no maxAge given for timestampLocation")
+	}
+	var err error
+	meta.maxAge, err = time.ParseDuration(maxAge)
+	if err != nil {
+		return fmt.Errorf("error parsing maxAge: %w", err)
+	}
+	if meta.maxAge <= 0 {
+		return errors.New("maxAge must be positive")
+	}
+
+	meta.staleValue = metricsAPIStaleValueError
+	if hasStaleValue {
+		meta.staleValue = strings.TrimSpace(staleValue)
+	}
+	if meta.staleValue != metricsAPIStaleValueError && meta.staleValue != metricsAPIStaleValueZero {
+		return fmt.Errorf("staleValue %s not supported, must be %s or %s", meta.staleValue, metricsAPIStaleValueError, metricsAPIStaleValueZero)
+	}
+	return nil
+}
+
+// metricsAPICounterSample is a value of the counter read at a time
+type metricsAPICounterSample struct {
+	time  time.Time
+	value float64
+}
+
+// metricsAPICounter computes the increase of a cumulative counter over a sliding window, from the
+// values read on each poll. The polls aren't evenly spaced, as the metrics server reads the metric
+// in between the polls of the scale loop, so the samples of the window are kept instead of the last one
+type metricsAPICounter struct {
+	window time.Duration
+	reset  string
+
+	lock    sync.Mutex
+	samples []metricsAPICounterSample
+}
+
+// increase records the value read at now and returns the increase of the counter since the newest
+// sample that is at least window old, or since the oldest sample if there isn't one yet.
+// The first value has nothing to compare with, so its increase is zero
+func (c *metricsAPICounter) increase(now time.Time, value float64) float64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.samples = append(c.samples, metricsAPICounterSample{time: now, value: value})
+	start := 0
+	for i, sample := range c.samples {
+		if now.Sub(sample.time) < c.window {
+			break
+		}
+		start = i
+	}
+	c.samples = c.samples[start:]
+
+	increase := 0.0
+	for i := 1; i < len(c.samples); i++ {
+		delta := c.samples[i].value - c.samples[i-1].value
+		if delta < 0 {
+			// the counter decreased, so it was reset, e.g. the process exposing it restarted
+			if c.reset == metricsAPICounterResetIgnore {
+				continue
+			}
+			delta = c.samples[i].value
+		}
+		increase += delta
+	}
+	return increase
+}
+
+// metricsAPIClientCert holds the rotating client certificate of the scaler
+type metricsAPIClientCert struct {
+	resolveAuthParams func(ctx context.Context) (map[string]string, error)
+
+	lock        sync.RWMutex
+	cert        string
+	key         string
+	certificate *tls.Certificate
+}
+
+// update parses and stores the certificate, unless it's the one already stored
+func (c *metricsAPIClientCert) update(cert, key string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.certificate != nil && cert == c.cert && key == c.key {
+		return nil
+	}
+
+	certificate, err := tls.X509KeyPair([]byte(cert), []byte(key))
+	if err != nil {
+		return fmt.Errorf("error parse X509KeyPair: %w", err)
+	}
+	c.cert, c.key, c.certificate = cert, key, &certificate
+	return nil
+}
+
+// refresh resolves the auth params again and updates the certificate, it returns true if the certificate changed
+func (c *metricsAPIClientCert) refresh(ctx context.Context) (bool, error) {
+	authParams, err := c.resolveAuthParams(ctx)
+	if err != nil {
+		return false, err
+	}
+	c.lock.RLock()
+	changed := authParams["cert"] != c.cert || authParams["key"] != c.key
+	c.lock.RUnlock()
+	if !changed {
+		return false, nil
+	}
+	return true, c.update(authParams["cert"], authParams["key"])
+}
+
+// getClientCertificate returns the current certificate, it's the tls.Config GetClientCertificate callback
+func (c *metricsAPIClientCert) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.certificate, nil
+}
+
+// metricsAPITokenFile holds the bearer token read from a file, which is read again once refreshInterval
+// passed, so a token rotated by the kubelet is used without restarting the scaler
+type metricsAPITokenFile struct {
+	path            string
+	refreshInterval time.Duration
+
+	lock   sync.Mutex
+	token  string
+	readAt time.Time
+}
+
+// get returns the token, reading the file again if the token is older than refreshInterval.
+// If the file can't be read anymore, the previous token is returned along with the error
+func (f *metricsAPITokenFile) get() (string, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.token != "" && time.Since(f.readAt) < f.refreshInterval {
+		return f.token, nil
+	}
+
+	content, err := os.ReadFile(f.path)
+	if err != nil {
+		return f.token, fmt.Errorf("error reading tokenFile: %w", err)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return f.token, fmt.Errorf("tokenFile %s is empty", f.path)
+	}
+	f.token, f.readAt = token, time.Now()
+	return f.token, nil
+}
+
 // GetValueFromResponse uses provided valueLocation to access the numeric value in provided body using the format specified.
 func GetValueFromResponse(body []byte, valueLocation string, format APIFormat) (float64, error) {
 	switch format {
@@ -408,12 +750,112 @@ func getValueFromYAMLResponse(body []byte, valueLocation string) (float64, error
 	}
 }
 
+// GetTimestampFromResponse uses provided timestampLocation to access the time in provided body using the format specified.
+// A number is read as seconds since the Unix epoch, a string either as RFC 3339 or as such a number.
+func GetTimestampFromResponse(body []byte, timestampLocation string, format APIFormat) (time.Time, error) {
+	var raw interface{}
+	switch format {
+	case JSONFormat:
+		r := gjson.GetBytes(body, timestampLocation)
+		if !r.Exists() {
+			return time.Time{}, fmt.Errorf("timestamp %s not found", timestampLocation)
+		}
+		raw = r.Value()
+	case XMLFormat, YAMLFormat:
+		var bodyMap map[string]interface{}
+		var err error
+		if format == XMLFormat {
+			err = xml.Unmarshal(body, &bodyMap)
+		} else {
+			err = yaml.Unmarshal(body, &bodyMap)
+		}
+		if err != nil {
+			return time.Time{}, err
+		}
+		raw, err = kedautil.GetValueByPath(bodyMap, timestampLocation)
+		if err != nil {
+			return time.Time{}, err
+		}
+	default:
+		return time.Time{}, fmt.Errorf("timestampLocation isn't supported with format %s", format)
+	}
+
+	switch v := raw.(type) {
+	case time.Time:
+		// YAML decodes the unquoted timestamps itself
+		return v, nil
+	case int:
+		return time.Unix(int64(v), 0), nil
+	case int64:
+		return time.Unix(v, 0), nil
+	case float64:
+		return unixSecondsToTime(v), nil
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(v)); err == nil {
+			return t, nil
+		}
+		if seconds, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return unixSecondsToTime(seconds), nil
+		}
+		return time.Time{}, fmt.Errorf("timestampLocation must point to a Unix time in seconds or an RFC 3339 time, got: '%s'", v)
+	default:
+		return time.Time{}, fmt.Errorf("timestampLocation must point to a Unix time in seconds or an RFC 3339 time, got: '%v'", v)
+	}
+}
+
+func unixSecondsToTime(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
+
+// checkFreshness returns if the value of the response is stale, or an error when its timestamp can't be read
+func (s *metricsAPIScaler) checkFreshness(body []byte, now time.Time) (bool, error) {
+	timestamp, err := GetTimestampFromResponse(body, s.metadata.timestampLocation, s.metadata.format)
+	if err != nil {
+		return false, err
+	}
+	// a timestamp in the future, e.g. because of a clock skew, is fresh
+	age := now.Sub(timestamp)
+	if age <= s.metadata.maxAge {
+		return false, nil
+	}
+	if s.metadata.staleValue == metricsAPIStaleValueError {
+		return true, fmt.Errorf("value is stale, its timestamp %s is older than maxAge %s", timestamp.UTC().Format(time.RFC3339), s.metadata.maxAge)
+	}
+	s.logger.V(1).Info("value is stale, using zero instead", "timestamp", timestamp, "maxAge", s.metadata.maxAge)
+	return true, nil
+}
+
 func (s *metricsAPIScaler) getMetricValue(ctx context.Context) (float64, error) {
+	if s.clientCert != nil {
+		changed, err := s.clientCert.refresh(ctx)
+		switch {
+		case err != nil:
+			// keep using the previous certificate, it may still be valid
+			s.logger.Error(err, "error refreshing the client certificate")
+		case changed:
+			// the open connections were authenticated with the previous certificate
+			s.logger.V(1).Info("client certificate rotated, closing the open connections")
+			s.httpClient.CloseIdleConnections()
+		}
+	}
+
 	request, err := getMetricAPIServerRequest(ctx, s.metadata)
 	if err != nil {
 		return 0, err
 	}
+	if s.tokenFile != nil {
+		token, err := s.tokenFile.get()
+		if err != nil {
+			if token == "" {
+				return 0, err
+			}
+			// keep using the previous token, it may still be valid
+			s.logger.Error(err, "error refreshing the bearer token")
+		}
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
 
+	start := time.Now()
 	r, err := s.httpClient.Do(request)
 	if err != nil {
 		return 0, err
@@ -429,10 +871,27 @@ func (s *metricsAPIScaler) getMetricValue(ctx context.Context) (float64, error)
 	if err != nil {
 		return 0, err
 	}
+	if s.metadata.useResponseTime {
+		// the round-trip time includes reading the whole body
+		return float64(time.Since(start).Microseconds()) / 1000, nil
+	}
+	if s.metadata.timestampLocation != "" {
+		stale, err := s.checkFreshness(b, time.Now())
+		if err != nil {
+			return 0, err
+		}
+		if stale {
+			// a stale value isn't recorded by the counter either, as it isn't a new reading
+			return 0, nil
+		}
+	}
 	v, err := GetValueFromResponse(b, s.metadata.valueLocation, s.metadata.format)
 	if err != nil {
 		return 0, err
 	}
+	if s.counter != nil {
+		return s.counter.increase(time.Now(), v), nil
+	}
 	return v, nil
 }
 
@@ -446,9 +905,13 @@ func (s *metricsAPIScaler) Close(context.Context) error {
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
 func (s *metricsAPIScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("metric-api-%s", s.metadata.valueLocation))
+	if s.metadata.useResponseTime {
+		metricName = "metric-api-response-time"
+	}
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("metric-api-%s", s.metadata.valueLocation))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
 		},
 		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
 	}
@@ -2,16 +2,29 @@ package scalers
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 type metricsAPIMetadataTestData struct {
@@ -34,6 +47,42 @@ var testMetricsAPIMetadata = []metricsAPIMetadataTestData{
 	{metadata: map[string]string{"valueLocation": "metric", "targetValue": "aa"}, raisesError: true},
 	// Missing targetValue
 	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric"}, raisesError: true},
+	// OK response time
+	{metadata: map[string]string{"url": "http://dummy:1230/healthz", "useResponseTime": "true", "targetValue": "200"}, raisesError: false},
+	// useResponseTime not a bool
+	{metadata: map[string]string{"url": "http://dummy:1230/healthz", "useResponseTime": "yes", "targetValue": "200"}, raisesError: true},
+	// response time with valueLocation
+	{metadata: map[string]string{"url": "http://dummy:1230/healthz", "useResponseTime": "true", "valueLocation": "metric", "targetValue": "200"}, raisesError: true},
+	// OK counter
+	{metadata: map[string]string{"url": "http://dummy:1230/metrics", "format": "prometheus", "valueLocation": "registry_pulls_total", "targetValue": "100", "valueType": "counter", "counterWindow": "1m", "counterReset": "ignore"}, raisesError: false},
+	// unknown valueType
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "valueType": "histogram"}, raisesError: true},
+	// counterWindow not a duration
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "valueType": "counter", "counterWindow": "60"}, raisesError: true},
+	// counterWindow not positive
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "valueType": "counter", "counterWindow": "0s"}, raisesError: true},
+	// unknown counterReset
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "valueType": "counter", "counterReset": "zero"}, raisesError: true},
+	// counterWindow without valueType counter
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "counterWindow": "1m"}, raisesError: true},
+	// counter with response time
+	{metadata: map[string]string{"url": "http://dummy:1230/healthz", "useResponseTime": "true", "targetValue": "200", "valueType": "counter"}, raisesError: true},
+	// OK freshness guard
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "timestampLocation": "timestamp", "maxAge": "2m", "staleValue": "zero"}, raisesError: false},
+	// timestampLocation without maxAge
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "timestampLocation": "timestamp"}, raisesError: true},
+	// maxAge without timestampLocation
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "maxAge": "2m"}, raisesError: true},
+	// maxAge not a duration
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "timestampLocation": "timestamp", "maxAge": "120"}, raisesError: true},
+	// maxAge not positive
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "timestampLocation": "timestamp", "maxAge": "0s"}, raisesError: true},
+	// unknown staleValue
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "timestampLocation": "timestamp", "maxAge": "2m", "staleValue": "last"}, raisesError: true},
+	// timestampLocation with format prometheus
+	{metadata: map[string]string{"url": "http://dummy:1230/metrics", "format": "prometheus", "valueLocation": "queue_size", "targetValue": "42", "timestampLocation": "timestamp", "maxAge": "2m"}, raisesError: true},
+	// timestampLocation with response time
+	{metadata: map[string]string{"url": "http://dummy:1230/healthz", "useResponseTime": "true", "targetValue": "200", "timestampLocation": "timestamp", "maxAge": "2m"}, raisesError: true},
 }
 
 type metricAPIAuthMetadataTestData struct {
@@ -69,12 +118,32 @@ var testMetricsAPIAuthMetadata = []metricAPIAuthMetadataTestData{
 	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "bearer"}, map[string]string{"token": "bearerTokenValue"}, false},
 	// fail bearerAuth without token
 	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "bearer"}, map[string]string{}, true},
+	// success bearerAuth with tokenFile
+	{map[string]string{"url": "https://kubernetes.default.svc/apis/external.metrics.k8s.io/v1beta1/namespaces/default/s0-metric", "valueLocation": "items.0.value", "targetValue": "42", "authMode": "bearer", "tokenFile": "/var/run/secrets/tokens/keda"}, map[string]string{"ca": "caaa"}, false},
+	// fail bearerAuth with tokenFile outside of the token file directory
+	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "bearer", "tokenFile": "/etc/shadow"}, map[string]string{}, true},
+	// fail bearerAuth with tokenFile escaping the token file directory
+	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "bearer", "tokenFile": "/var/run/secrets/tokens/../kubernetes.io/serviceaccount/token"}, map[string]string{}, true},
+	// fail bearerAuth with token and tokenFile
+	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "bearer", "tokenFile": "/var/run/secrets/tokens/keda"}, map[string]string{"token": "bearerTokenValue"}, true},
+	// fail tokenFile without authMode
+	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "tokenFile": "/var/run/secrets/tokens/keda"}, map[string]string{}, true},
+	// fail tokenFile with another authMode
+	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "basic", "tokenFile": "/var/run/secrets/tokens/keda"}, map[string]string{"username": "user"}, true},
 	// success unsafeSsl true
 	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "unsafeSsl": "true"}, map[string]string{}, false},
 	// success unsafeSsl false
 	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "unsafeSsl": "false"}, map[string]string{}, false},
 	// failed unsafeSsl non bool
 	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "unsafeSsl": "yes"}, map[string]string{}, true},
+	// success rotatingClientCert with TLS
+	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "tls", "rotatingClientCert": "true"}, map[string]string{"ca": "caaa", "cert": "ceert", "key": "keey"}, false},
+	// fail rotatingClientCert non bool
+	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "tls", "rotatingClientCert": "yes"}, map[string]string{"ca": "caaa", "cert": "ceert", "key": "keey"}, true},
+	// fail rotatingClientCert without authMode
+	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "rotatingClientCert": "true"}, map[string]string{}, true},
+	// fail rotatingClientCert with another authMode
+	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "bearer", "rotatingClientCert": "true"}, map[string]string{"token": "bearerTokenValue"}, true},
 }
 
 func TestParseMetricsAPIMetadata(t *testing.T) {
@@ -97,6 +166,7 @@ type metricsAPIMetricIdentifier struct {
 
 var metricsAPIMetricIdentifiers = []metricsAPIMetricIdentifier{
 	{metadataTestData: &testMetricsAPIMetadata[1], triggerIndex: 1, name: "s1-metric-api-metric-test"},
+	{metadataTestData: &testMetricsAPIMetadata[7], triggerIndex: 2, name: "s2-metric-api-response-time"},
 }
 
 func TestMetricsAPIGetMetricSpecForScaling(t *testing.T) {
@@ -175,6 +245,7 @@ func TestGetValueFromResponse(t *testing.T) {
 }
 
 func TestMetricAPIScalerAuthParams(t *testing.T) {
+	t.Setenv(kedautil.TokenFileDirectoryEnvVar, "/var/run/secrets/tokens")
 	for _, testData := range testMetricsAPIAuthMetadata {
 		meta, err := parseMetricsAPIMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
 
@@ -239,6 +310,160 @@ func TestBearerAuth(t *testing.T) {
 	}
 }
 
+func TestMetricsAPIResponseTime(t *testing.T) {
+	testCases := []struct {
+		name     string
+		delay    time.Duration
+		isActive bool
+	}{
+		{name: "slow endpoint", delay: 150 * time.Millisecond, isActive: true},
+		{name: "fast endpoint", delay: 0, isActive: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			apiStub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				time.Sleep(tc.delay)
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`not a metric`))
+			}))
+			defer apiStub.Close()
+
+			s, err := NewMetricsAPIScaler(
+				&scalersconfig.ScalerConfig{
+					TriggerMetadata: map[string]string{
+						"url":                   apiStub.URL,
+						"useResponseTime":       "true",
+						"targetValue":           "200",
+						"activationTargetValue": "100",
+					},
+					GlobalHTTPTimeout: 3000 * time.Millisecond,
+				},
+			)
+			assert.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.TODO(), "test-metric")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.isActive, isActive)
+
+			responseTime := metrics[0].Value.AsApproximateFloat64()
+			assert.GreaterOrEqual(t, responseTime, float64(tc.delay.Milliseconds()))
+			// generous upper bound, only to make sure the value is in milliseconds
+			assert.Less(t, responseTime, float64(tc.delay.Milliseconds()+1000))
+		})
+	}
+}
+
+func TestMetricsAPICounterIncrease(t *testing.T) {
+	start := time.Now()
+	testCases := []struct {
+		name      string
+		reset     string
+		values    []float64
+		increases []float64
+	}{
+		{name: "first value has no baseline", reset: metricsAPICounterResetRestart, values: []float64{40}, increases: []float64{0}},
+		{name: "increase over the window", reset: metricsAPICounterResetRestart, values: []float64{40, 45, 60, 60}, increases: []float64{0, 5, 15, 0}},
+		{name: "reset counts the value since the reset", reset: metricsAPICounterResetRestart, values: []float64{40, 45, 3, 10}, increases: []float64{0, 5, 3, 7}},
+		{name: "reset is ignored", reset: metricsAPICounterResetIgnore, values: []float64{40, 45, 3, 10}, increases: []float64{0, 5, 0, 7}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			counter := &metricsAPICounter{window: 30 * time.Second, reset: tc.reset}
+			for i, value := range tc.values {
+				// polled once per window
+				now := start.Add(time.Duration(i) * 30 * time.Second)
+				assert.Equal(t, tc.increases[i], counter.increase(now, value), "poll %d", i)
+			}
+		})
+	}
+}
+
+func TestMetricsAPICounterIncreaseSlidingWindow(t *testing.T) {
+	start := time.Now()
+	counter := &metricsAPICounter{window: 30 * time.Second, reset: metricsAPICounterResetRestart}
+
+	assert.Equal(t, 0.0, counter.increase(start, 100))
+	// the metrics server reads the metric in between the polls, the increase still covers the whole window
+	assert.Equal(t, 10.0, counter.increase(start.Add(10*time.Second), 110))
+	assert.Equal(t, 20.0, counter.increase(start.Add(20*time.Second), 120))
+	assert.Equal(t, 30.0, counter.increase(start.Add(30*time.Second), 130))
+	// the counter reset in the window, 110 -> 130 counts 20 and 130 -> 5 counts 5
+	assert.Equal(t, 25.0, counter.increase(start.Add(40*time.Second), 5))
+	// the samples before the window are dropped, 120 is the newest value at least 30s old
+	assert.Equal(t, 20.0, counter.increase(start.Add(50*time.Second), 10))
+	assert.Len(t, counter.samples, 4)
+}
+
+func TestMetricsAPICounter(t *testing.T) {
+	var lock sync.Mutex
+	pulls := 0
+	apiStub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"pulls": %d}`, pulls)))
+	}))
+	defer apiStub.Close()
+	setPulls := func(value int) {
+		lock.Lock()
+		defer lock.Unlock()
+		pulls = value
+	}
+
+	s, err := NewMetricsAPIScaler(
+		&scalersconfig.ScalerConfig{
+			TriggerMetadata: map[string]string{
+				"url":           apiStub.URL,
+				"valueLocation": "pulls",
+				"targetValue":   "10",
+				"valueType":     "counter",
+				"counterWindow": "1ms",
+			},
+			GlobalHTTPTimeout: 3000 * time.Millisecond,
+		},
+	)
+	require.NoError(t, err)
+
+	for _, step := range []struct {
+		pulls    int
+		increase float64
+		isActive bool
+	}{
+		{pulls: 1000, increase: 0, isActive: false},
+		{pulls: 1025, increase: 25, isActive: true},
+		{pulls: 1025, increase: 0, isActive: false},
+		// the endpoint restarted
+		{pulls: 4, increase: 4, isActive: true},
+	} {
+		setPulls(step.pulls)
+		time.Sleep(2 * time.Millisecond)
+		metrics, isActive, err := s.GetMetricsAndActivity(context.TODO(), "test-metric")
+		require.NoError(t, err)
+		assert.Equal(t, step.increase, metrics[0].Value.AsApproximateFloat64())
+		assert.Equal(t, step.isActive, isActive)
+	}
+}
+
+func TestMetricsAPIResponseTimeErrorStatus(t *testing.T) {
+	apiStub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer apiStub.Close()
+
+	s, err := NewMetricsAPIScaler(
+		&scalersconfig.ScalerConfig{
+			TriggerMetadata:   map[string]string{"url": apiStub.URL, "useResponseTime": "true", "targetValue": "200"},
+			GlobalHTTPTimeout: 3000 * time.Millisecond,
+		},
+	)
+	assert.NoError(t, err)
+
+	_, _, err = s.GetMetricsAndActivity(context.TODO(), "test-metric")
+	assert.ErrorContains(t, err, "api returned 503")
+}
+
 type MockHTTPRoundTripper struct {
 	mock.Mock
 }
@@ -265,3 +490,236 @@ func TestGetMetricValueErrorMessage(t *testing.T) {
 
 	assert.Equal(t, err.Error(), "/api/v1/: api returned 418")
 }
+
+// generateTestClientCert returns a self-signed client certificate and its key, in PEM
+func generateTestClientCert(t *testing.T, commonName string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
+
+func TestMetricsAPIRotatingClientCert(t *testing.T) {
+	var lock sync.Mutex
+	var lastClient string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		lastClient = r.TLS.PeerCertificates[0].Subject.CommonName
+		lock.Unlock()
+		fmt.Fprint(w, `{"value":1}`)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+	ca := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	cert, key := generateTestClientCert(t, "first")
+	resolveAuthParams := func(context.Context) (map[string]string, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		return map[string]string{"ca": ca, "cert": cert, "key": key}, nil
+	}
+	authParams, _ := resolveAuthParams(context.Background())
+
+	s, err := NewMetricsAPIScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata:   map[string]string{"url": server.URL, "valueLocation": "value", "targetValue": "1", "authMode": "tls", "rotatingClientCert": "true"},
+		AuthParams:        authParams,
+		ResolveAuthParams: resolveAuthParams,
+		GlobalHTTPTimeout: 3 * time.Second,
+	})
+	require.NoError(t, err)
+	scaler := s.(*metricsAPIScaler)
+	defer scaler.Close(context.Background())
+
+	getClient := func() string {
+		_, err := scaler.getMetricValue(context.Background())
+		require.NoError(t, err)
+		lock.Lock()
+		defer lock.Unlock()
+		return lastClient
+	}
+	assert.Equal(t, "first", getClient())
+
+	// the certificate rotates in the secret
+	lock.Lock()
+	cert, key = generateTestClientCert(t, "second")
+	lock.Unlock()
+	assert.Equal(t, "second", getClient())
+
+	// an invalid certificate keeps the previous one in use
+	lock.Lock()
+	cert = "invalid"
+	lock.Unlock()
+	assert.Equal(t, "second", getClient())
+}
+
+func TestMetricsAPITokenFile(t *testing.T) {
+	var lock sync.Mutex
+	var lastAuthorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		lastAuthorization = r.Header.Get("Authorization")
+		lock.Unlock()
+		fmt.Fprint(w, `{"items":[{"value":"3"}]}`)
+	}))
+	defer server.Close()
+
+	tokenFileDirectory := t.TempDir()
+	t.Setenv(kedautil.TokenFileDirectoryEnvVar, tokenFileDirectory)
+	tokenFile := filepath.Join(tokenFileDirectory, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("first-token\n"), 0600))
+
+	s, err := NewMetricsAPIScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata:   map[string]string{"url": server.URL, "valueLocation": "items.0.value", "targetValue": "1", "authMode": "bearer", "tokenFile": tokenFile},
+		GlobalHTTPTimeout: 3 * time.Second,
+	})
+	require.NoError(t, err)
+	scaler := s.(*metricsAPIScaler)
+	defer scaler.Close(context.Background())
+
+	getAuthorization := func() string {
+		value, err := scaler.getMetricValue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, float64(3), value)
+		lock.Lock()
+		defer lock.Unlock()
+		return lastAuthorization
+	}
+	assert.Equal(t, "Bearer first-token", getAuthorization())
+
+	// the kubelet rotates the token, which is read again once the refresh interval passed
+	require.NoError(t, os.WriteFile(tokenFile, []byte("second-token\n"), 0600))
+	assert.Equal(t, "Bearer first-token", getAuthorization())
+	scaler.tokenFile.refreshInterval = 0
+	assert.Equal(t, "Bearer second-token", getAuthorization())
+
+	// a token which can't be read anymore keeps the previous one in use
+	require.NoError(t, os.Remove(tokenFile))
+	assert.Equal(t, "Bearer second-token", getAuthorization())
+}
+
+func TestMetricsAPITokenFileMissing(t *testing.T) {
+	tokenFileDirectory := t.TempDir()
+	t.Setenv(kedautil.TokenFileDirectoryEnvVar, tokenFileDirectory)
+	_, err := NewMetricsAPIScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata:   map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "1", "authMode": "bearer", "tokenFile": filepath.Join(tokenFileDirectory, "token")},
+		GlobalHTTPTimeout: 3 * time.Second,
+	})
+	assert.ErrorContains(t, err, "error reading tokenFile")
+}
+
+func TestMetricsAPITokenFileWithoutDirectory(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token"), 0600))
+	_, err := NewMetricsAPIScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata:   map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "1", "authMode": "bearer", "tokenFile": tokenFile},
+		GlobalHTTPTimeout: 3 * time.Second,
+	})
+	assert.ErrorContains(t, err, "token files can't be read unless KEDA_TOKEN_FILE_DIRECTORY is set")
+}
+
+func TestGetTimestampFromResponse(t *testing.T) {
+	expected := time.Date(2024, 5, 17, 10, 30, 0, 0, time.UTC)
+	testCases := []struct {
+		name     string
+		body     string
+		format   APIFormat
+		location string
+		isError  bool
+	}{
+		{name: "json unix seconds", body: `{"stats": {"updatedAt": 1715941800}}`, format: JSONFormat, location: "stats.updatedAt"},
+		{name: "json unix seconds string", body: `{"updatedAt": "1715941800"}`, format: JSONFormat, location: "updatedAt"},
+		{name: "json rfc3339", body: `{"updatedAt": "2024-05-17T12:30:00+02:00"}`, format: JSONFormat, location: "updatedAt"},
+		{name: "yaml timestamp", body: "updatedAt: 2024-05-17T10:30:00Z\n", format: YAMLFormat, location: "updatedAt"},
+		{name: "yaml unix seconds", body: "updatedAt: 1715941800\n", format: YAMLFormat, location: "updatedAt"},
+		{name: "json missing", body: `{"value": 1}`, format: JSONFormat, location: "updatedAt", isError: true},
+		{name: "json invalid", body: `{"updatedAt": "yesterday"}`, format: JSONFormat, location: "updatedAt", isError: true},
+		{name: "json object", body: `{"updatedAt": {"seconds": 1715941800}}`, format: JSONFormat, location: "updatedAt", isError: true},
+		{name: "yaml missing", body: "value: 1\n", format: YAMLFormat, location: "updatedAt", isError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			timestamp, err := GetTimestampFromResponse([]byte(tc.body), tc.location, tc.format)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, expected.Equal(timestamp), "expected %s, got %s", expected, timestamp)
+		})
+	}
+}
+
+func TestMetricsAPIFreshness(t *testing.T) {
+	var lock sync.Mutex
+	body := ""
+	apiStub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer apiStub.Close()
+	setBody := func(value string) {
+		lock.Lock()
+		defer lock.Unlock()
+		body = value
+	}
+	freshTimestamp := time.Now().Add(-10 * time.Second).Unix()
+	staleTimestamp := time.Now().Add(-10 * time.Minute).Unix()
+
+	testCases := []struct {
+		name       string
+		staleValue string
+		body       string
+		value      float64
+		isActive   bool
+		isError    bool
+	}{
+		{name: "fresh value", body: fmt.Sprintf(`{"queue": 12, "timestamp": %d}`, freshTimestamp), value: 12, isActive: true},
+		{name: "fresh value with a timestamp in the future", body: fmt.Sprintf(`{"queue": 12, "timestamp": "%s"}`, time.Now().Add(time.Minute).Format(time.RFC3339)), value: 12, isActive: true},
+		{name: "stale value fails by default", body: fmt.Sprintf(`{"queue": 12, "timestamp": %d}`, staleTimestamp), isError: true},
+		{name: "stale value read as zero", staleValue: "zero", body: fmt.Sprintf(`{"queue": 12, "timestamp": %d}`, staleTimestamp), value: 0, isActive: false},
+		{name: "missing timestamp", staleValue: "zero", body: `{"queue": 12}`, isError: true},
+		{name: "invalid timestamp", staleValue: "zero", body: `{"queue": 12, "timestamp": "last tuesday"}`, isError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata := map[string]string{
+				"url":               apiStub.URL,
+				"valueLocation":     "queue",
+				"targetValue":       "10",
+				"timestampLocation": "timestamp",
+				"maxAge":            "2m",
+			}
+			if tc.staleValue != "" {
+				metadata["staleValue"] = tc.staleValue
+			}
+			s, err := NewMetricsAPIScaler(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, GlobalHTTPTimeout: 3000 * time.Millisecond})
+			require.NoError(t, err)
+
+			setBody(tc.body)
+			metrics, isActive, err := s.GetMetricsAndActivity(context.TODO(), "test-metric")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.value, metrics[0].Value.AsApproximateFloat64())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
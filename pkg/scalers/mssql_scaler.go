@@ -2,12 +2,16 @@ package scalers
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
 	"net/url"
 	"strconv"
+	"sync"
+	"time"
 
 	// mssql driver required for this scaler
 	_ "github.com/denisenkom/go-mssqldb"
@@ -26,12 +30,37 @@ var (
 	ErrMsSQLNoTargetValue = errors.New("no targetValue given")
 )
 
+const (
+	mssqlDefaultConnectionPoolSize = 10
+	mssqlDefaultCommandTimeout     = 30 * time.Second
+	// mssqlPingTimeout bounds the ping checking a new connection pool, whatever the commandTimeout
+	mssqlPingTimeout = 30 * time.Second
+)
+
+// mssqlDriverName is the database/sql driver the scaler connects with
+var mssqlDriverName = "sqlserver"
+
+// mssqlConnectionPool is a connection pool shared by the scalers connecting with the same connection string and pool size
+type mssqlConnectionPool struct {
+	db   *sql.DB
+	refs int
+}
+
+var (
+	// mssqlConnectionPools are keyed by the hash of the connection string and the pool size, so a rotated credential,
+	// which changes it, gives a new pool to the rebuilt scalers while the stale one is closed with its last scaler
+	mssqlConnectionPools     = map[string]*mssqlConnectionPool{}
+	mssqlConnectionPoolsLock sync.Mutex
+)
+
 // mssqlScaler exposes a data pointer to mssqlMetadata and sql.DB connection
 type mssqlScaler struct {
 	metricType v2.MetricTargetType
 	metadata   *mssqlMetadata
 	connection *sql.DB
-	logger     logr.Logger
+	// poolKey is the key of the shared connection pool of the scaler
+	poolKey string
+	logger  logr.Logger
 }
 
 // mssqlMetadata defines metadata used by KEDA to query a Microsoft SQL database
@@ -64,6 +93,12 @@ type mssqlMetadata struct {
 	// The threshold that is used in activation phase
 	// +optional
 	activationTargetValue float64
+	// The max number of open connections of the pool shared by the scalers with the same connection string.
+	// +optional
+	connectionPoolSize int
+	// The time after which the query is aborted, zero disables it.
+	// +optional
+	commandTimeout time.Duration
 	// The index of the scaler inside the ScaledObject
 	// +internal
 	triggerIndex int
@@ -83,7 +118,7 @@ func NewMSSQLScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing mssql metadata: %w", err)
 	}
 
-	conn, err := newMSSQLConnection(meta, logger)
+	conn, poolKey, err := acquireMSSQLConnection(meta, logger)
 	if err != nil {
 		return nil, fmt.Errorf("error establishing mssql connection: %w", err)
 	}
@@ -92,6 +127,7 @@ func NewMSSQLScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		metricType: metricType,
 		metadata:   meta,
 		connection: conn,
+		poolKey:    poolKey,
 		logger:     logger,
 	}, nil
 }
@@ -132,6 +168,32 @@ func parseMSSQLMetadata(config *scalersconfig.ScalerConfig) (*mssqlMetadata, err
 		meta.activationTargetValue = activationTargetValue
 	}
 
+	// Connection pool size
+	meta.connectionPoolSize = mssqlDefaultConnectionPoolSize
+	if val, ok := config.TriggerMetadata["connectionPoolSize"]; ok {
+		connectionPoolSize, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("connectionPoolSize parsing error %w", err)
+		}
+		if connectionPoolSize <= 0 {
+			return nil, fmt.Errorf("connectionPoolSize must be greater than 0")
+		}
+		meta.connectionPoolSize = connectionPoolSize
+	}
+
+	// Command timeout, in seconds
+	meta.commandTimeout = mssqlDefaultCommandTimeout
+	if val, ok := config.TriggerMetadata["commandTimeout"]; ok {
+		commandTimeout, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("commandTimeout parsing error %w", err)
+		}
+		if commandTimeout < 0 {
+			return nil, fmt.Errorf("commandTimeout must not be negative")
+		}
+		meta.commandTimeout = time.Duration(commandTimeout) * time.Second
+	}
+
 	// Connection string, which can either be provided explicitly or via the helper fields
 	switch {
 	case config.AuthParams["connectionString"] != "":
@@ -173,19 +235,84 @@ func parseMSSQLMetadata(config *scalersconfig.ScalerConfig) (*mssqlMetadata, err
 	return &meta, nil
 }
 
-// newMSSQLConnection returns a new, opened SQL connection for the provided mssqlMetadata
-func newMSSQLConnection(meta *mssqlMetadata, logger logr.Logger) (*sql.DB, error) {
+// acquireMSSQLConnection returns the connection pool shared by the scalers with the same connection string
+// and connectionPoolSize, opening it if it doesn't exist yet, with the key to release it
+func acquireMSSQLConnection(meta *mssqlMetadata, logger logr.Logger) (*sql.DB, string, error) {
 	connStr := getMSSQLConnectionString(meta)
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%d:%s", meta.connectionPoolSize, connStr)))
+	key := hex.EncodeToString(hash[:])
+
+	if db, ok := referenceMSSQLConnection(key); ok {
+		return db, key, nil
+	}
 
-	db, err := sql.Open("sqlserver", connStr)
+	// the new pool is opened and pinged without holding the lock, so an unreachable server
+	// doesn't block the scalers of the other servers
+	db, err := newMSSQLConnection(connStr, meta, logger)
+	if err != nil {
+		return nil, "", err
+	}
+
+	mssqlConnectionPoolsLock.Lock()
+	defer mssqlConnectionPoolsLock.Unlock()
+
+	// another scaler may have opened the same pool in the meantime
+	if pool, ok := mssqlConnectionPools[key]; ok {
+		pool.refs++
+		db.Close()
+		return pool.db, key, nil
+	}
+	mssqlConnectionPools[key] = &mssqlConnectionPool{db: db, refs: 1}
+	return db, key, nil
+}
+
+// referenceMSSQLConnection returns the existing connection pool with the given key, counting the new reference to it
+func referenceMSSQLConnection(key string) (*sql.DB, bool) {
+	mssqlConnectionPoolsLock.Lock()
+	defer mssqlConnectionPoolsLock.Unlock()
+
+	pool, ok := mssqlConnectionPools[key]
+	if !ok {
+		return nil, false
+	}
+	pool.refs++
+	return pool.db, true
+}
+
+// releaseMSSQLConnection releases the connection pool of a scaler, closing it when no scaler uses it anymore
+func releaseMSSQLConnection(key string) error {
+	mssqlConnectionPoolsLock.Lock()
+	defer mssqlConnectionPoolsLock.Unlock()
+
+	pool, ok := mssqlConnectionPools[key]
+	if !ok {
+		return nil
+	}
+	pool.refs--
+	if pool.refs > 0 {
+		return nil
+	}
+	delete(mssqlConnectionPools, key)
+	return pool.db.Close()
+}
+
+// newMSSQLConnection returns a new, opened SQL connection pool for the provided connection string
+func newMSSQLConnection(connStr string, meta *mssqlMetadata, logger logr.Logger) (*sql.DB, error) {
+	db, err := sql.Open(mssqlDriverName, connStr)
 	if err != nil {
 		logger.Error(err, fmt.Sprintf("Found error opening mssql: %s", err))
 		return nil, err
 	}
+	db.SetMaxOpenConns(meta.connectionPoolSize)
+	db.SetMaxIdleConns(meta.connectionPoolSize)
+
+	ctx, cancel := context.WithTimeout(context.Background(), mssqlPingTimeout)
+	defer cancel()
 
-	err = db.Ping()
+	err = db.PingContext(ctx)
 	if err != nil {
 		logger.Error(err, fmt.Sprintf("Found error pinging mssql: %s", err))
+		db.Close()
 		return nil, err
 	}
 
@@ -255,6 +382,12 @@ func (s *mssqlScaler) GetMetricsAndActivity(ctx context.Context, metricName stri
 
 // getQueryResult returns the result of the scaler query
 func (s *mssqlScaler) getQueryResult(ctx context.Context) (float64, error) {
+	if s.metadata.commandTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.metadata.commandTimeout)
+		defer cancel()
+	}
+
 	var value float64
 	err := s.connection.QueryRowContext(ctx, s.metadata.query).Scan(&value)
 	switch {
@@ -268,9 +401,13 @@ func (s *mssqlScaler) getQueryResult(ctx context.Context) (float64, error) {
 	return value, nil
 }
 
-// Close closes the mssql database connections
+// Close releases the mssql connection pool, which is closed once no scaler uses it anymore
 func (s *mssqlScaler) Close(context.Context) error {
-	err := s.connection.Close()
+	if s.poolKey == "" {
+		return nil
+	}
+	err := releaseMSSQLConnection(s.poolKey)
+	s.poolKey = ""
 	if err != nil {
 		s.logger.Error(err, "Error closing mssql connection")
 		return err
@@ -30,12 +30,30 @@ type mySQLMetadata struct {
 	Host                 string  `keda:"name=host,                       order=triggerMetadata;authParams, optional"`
 	Port                 string  `keda:"name=port,                       order=triggerMetadata;authParams, optional"`
 	DBName               string  `keda:"name=dbName,                     order=triggerMetadata;authParams, optional"`
-	Query                string  `keda:"name=query,                      order=triggerMetadata"`
+	Query                string  `keda:"name=query,                      order=triggerMetadata, optional"`
+	QueryTemplate        string  `keda:"name=queryTemplate,              order=triggerMetadata, enum=activeConnections, optional"`
 	QueryValue           float64 `keda:"name=queryValue,                 order=triggerMetadata"`
 	ActivationQueryValue float64 `keda:"name=activationQueryValue,       order=triggerMetadata, default=0"`
 	MetricName           string  `keda:"name=metricName,                 order=triggerMetadata, optional"`
 }
 
+// mySQLQueryTemplates are the queries that can be given through queryTemplate instead of query
+var mySQLQueryTemplates = map[string]string{
+	// the number of connections running a command, idle pooled connections are sleeping. The system threads
+	// and the connection of the scaler, which runs this query, aren't counted
+	"activeConnections": "SELECT COUNT(*) FROM information_schema.PROCESSLIST WHERE COMMAND <> 'Sleep' AND COMMAND <> 'Daemon' AND ID <> CONNECTION_ID()",
+}
+
+func (m *mySQLMetadata) Validate() error {
+	if m.Query != "" && m.QueryTemplate != "" {
+		return fmt.Errorf("query and queryTemplate cannot be set together")
+	}
+	if m.Query == "" && m.QueryTemplate == "" {
+		return fmt.Errorf("no query given")
+	}
+	return nil
+}
+
 // NewMySQLScaler creates a new MySQL scaler
 func NewMySQLScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
@@ -68,6 +86,9 @@ func parseMySQLMetadata(config *scalersconfig.ScalerConfig) (*mySQLMetadata, err
 	if err := config.TypedConfig(meta); err != nil {
 		return nil, fmt.Errorf("error parsing mysql metadata: %w", err)
 	}
+	if meta.QueryTemplate != "" {
+		meta.Query = mySQLQueryTemplates[meta.QueryTemplate]
+	}
 
 	if meta.ConnectionString != "" {
 		meta.DBName = parseMySQLDbNameFromConnectionStr(meta.ConnectionString)
@@ -1,8 +1,13 @@
 package scalers
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"testing"
 
+	"github.com/go-logr/logr"
+
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
 
@@ -61,6 +66,34 @@ var testMySQLMetadata = []parseMySQLMetadataTestData{
 		resolvedEnv: testMySQLResolvedEnv,
 		raisesError: false,
 	},
+	// queryTemplate instead of query
+	{
+		metadata:    map[string]string{"queryTemplate": "activeConnections", "queryValue": "12", "connectionStringFromEnv": "MYSQL_CONN_STR"},
+		authParams:  map[string]string{},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: false,
+	},
+	// Unknown queryTemplate
+	{
+		metadata:    map[string]string{"queryTemplate": "unknown", "queryValue": "12", "connectionStringFromEnv": "MYSQL_CONN_STR"},
+		authParams:  map[string]string{},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: true,
+	},
+	// Both query and queryTemplate
+	{
+		metadata:    map[string]string{"query": "query", "queryTemplate": "activeConnections", "queryValue": "12", "connectionStringFromEnv": "MYSQL_CONN_STR"},
+		authParams:  map[string]string{},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: true,
+	},
+	// Neither query nor queryTemplate
+	{
+		metadata:    map[string]string{"queryValue": "12", "connectionStringFromEnv": "MYSQL_CONN_STR"},
+		authParams:  map[string]string{},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: true,
+	},
 	// Invalid activationQueryValue
 	{
 		metadata:    map[string]string{"query": "query", "queryValue": "12", "activationQueryValue": "AA"},
@@ -94,6 +127,19 @@ func TestParseMySQLMetadata(t *testing.T) {
 	}
 }
 
+func TestMySQLQueryTemplate(t *testing.T) {
+	meta, err := parseMySQLMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"queryTemplate": "activeConnections", "queryValue": "12", "connectionStringFromEnv": "MYSQL_CONN_STR"},
+		ResolvedEnv:     testMySQLResolvedEnv,
+	})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	if meta.Query != mySQLQueryTemplates["activeConnections"] {
+		t.Errorf("Expected the query of the activeConnections template but got %q", meta.Query)
+	}
+}
+
 func TestMetadataToConnectionStrUseConnStr(t *testing.T) {
 	// Use existing ConnStr
 	testMeta := map[string]string{"query": "query", "queryValue": "12", "connectionStringFromEnv": "MYSQL_CONN_STR"}
@@ -126,3 +172,63 @@ func TestMySQLGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+// testMySQLActiveConnectionsQuery counts the connections running a command, including the one running it
+// and the system threads
+const testMySQLActiveConnectionsQuery = "SELECT count(*) FROM information_schema.processlist WHERE command != 'Sleep'"
+
+// testMySQLConnectionCountDatabase answers the connection-count queries of a database where otherConnections
+// connections run a command besides the one of the scaler, which runs its query, and a system thread
+func testMySQLConnectionCountDatabase(otherConnections float64) *fakeSQLConnector {
+	return &fakeSQLConnector{values: map[string]driver.Value{
+		testMySQLActiveConnectionsQuery:          otherConnections + 2,
+		mySQLQueryTemplates["activeConnections"]: otherConnections,
+	}}
+}
+
+var testMySQLConnectionCountActivation = []struct {
+	name             string
+	metadata         map[string]string
+	otherConnections float64
+	expectedValue    float64
+	expectedIsActive bool
+}{
+	{name: "idle", metadata: map[string]string{"queryTemplate": "activeConnections", "queryValue": "20"}, otherConnections: 0, expectedValue: 0, expectedIsActive: false},
+	{name: "single connection", metadata: map[string]string{"queryTemplate": "activeConnections", "queryValue": "20"}, otherConnections: 1, expectedValue: 1, expectedIsActive: true},
+	{name: "below activation", metadata: map[string]string{"queryTemplate": "activeConnections", "queryValue": "20", "activationQueryValue": "5"}, otherConnections: 5, expectedValue: 5, expectedIsActive: false},
+	{name: "above activation", metadata: map[string]string{"queryTemplate": "activeConnections", "queryValue": "20", "activationQueryValue": "5"}, otherConnections: 6, expectedValue: 6, expectedIsActive: true},
+	// a query counting the connection of the scaler and the system threads never reports an idle database
+	{name: "idle counting the scaler connection", metadata: map[string]string{"query": testMySQLActiveConnectionsQuery, "queryValue": "20"}, otherConnections: 0, expectedValue: 2, expectedIsActive: true},
+}
+
+func TestMySQLConnectionCountActivation(t *testing.T) {
+	for _, testData := range testMySQLConnectionCountActivation {
+		t.Run(testData.name, func(t *testing.T) {
+			metadata := map[string]string{"connectionStringFromEnv": "MYSQL_CONN_STR"}
+			for key, value := range testData.metadata {
+				metadata[key] = value
+			}
+			meta, err := parseMySQLMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, ResolvedEnv: testMySQLResolvedEnv})
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			scaler := mySQLScaler{
+				metadata:   meta,
+				connection: sql.OpenDB(testMySQLConnectionCountDatabase(testData.otherConnections)),
+				logger:     logr.Discard(),
+			}
+			defer scaler.Close(context.Background())
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "s0-mysql-stats_db")
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			if isActive != testData.expectedIsActive {
+				t.Errorf("Expected isActive %v but got %v", testData.expectedIsActive, isActive)
+			}
+			if value := metrics[0].Value.AsApproximateFloat64(); value != testData.expectedValue {
+				t.Errorf("Expected value %v but got %v", testData.expectedValue, value)
+			}
+		})
+	}
+}
@@ -25,6 +25,10 @@ const (
 	natsHTTPProtocol                = "http"
 	natsHTTPSProtocol               = "https"
 	jetStreamLagThresholdMetricName = "lagThreshold"
+
+	defaultJetStreamRedeliveryThreshold = 10
+	jetStreamScaleOnLag                 = "lag"
+	jetStreamScaleOnRedeliveries        = "redeliveries"
 )
 
 type natsJetStreamScaler struct {
@@ -46,6 +50,11 @@ type natsJetStreamMetadata struct {
 	activationLagThreshold int64
 	clusterSize            int
 	triggerIndex           int
+
+	// scaleOn selects the consumer metric to scale on, either the lag or the number of redelivered messages
+	scaleOn                       string
+	redeliveryThreshold           int64
+	activationRedeliveryThreshold int64
 }
 
 type jetStreamEndpointResponse struct {
@@ -92,7 +101,7 @@ type consumerDetail struct {
 	StreamName     string                 `json:"stream_name"`
 	Name           string                 `json:"name"`
 	NumAckPending  int                    `json:"num_ack_pending"`
-	NumRedelivered int                    `json:"num_redelivered"`
+	NumRedelivered *int                   `json:"num_redelivered"`
 	NumWaiting     int                    `json:"num_waiting"`
 	NumPending     int                    `json:"num_pending"`
 	Config         consumerConfig         `json:"config"`
@@ -172,6 +181,35 @@ func parseNATSJetStreamMetadata(config *scalersconfig.ScalerConfig) (natsJetStre
 		meta.activationLagThreshold = activationTargetQueryValue
 	}
 
+	meta.scaleOn = jetStreamScaleOnLag
+	if val, ok := config.TriggerMetadata["scaleOn"]; ok && val != "" {
+		if val != jetStreamScaleOnLag && val != jetStreamScaleOnRedeliveries {
+			return meta, fmt.Errorf("scaleOn must be either %q or %q, got %q", jetStreamScaleOnLag, jetStreamScaleOnRedeliveries, val)
+		}
+		meta.scaleOn = val
+	}
+
+	meta.redeliveryThreshold = defaultJetStreamRedeliveryThreshold
+	if val, ok := config.TriggerMetadata["redeliveryThreshold"]; ok {
+		t, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return meta, fmt.Errorf("redeliveryThreshold parsing error %w", err)
+		}
+		if t <= 0 {
+			return meta, fmt.Errorf("redeliveryThreshold must be a positive number, got %d", t)
+		}
+		meta.redeliveryThreshold = t
+	}
+
+	meta.activationRedeliveryThreshold = 0
+	if val, ok := config.TriggerMetadata["activationRedeliveryThreshold"]; ok {
+		t, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return meta, fmt.Errorf("activationRedeliveryThreshold parsing error %w", err)
+		}
+		meta.activationRedeliveryThreshold = t
+	}
+
 	meta.triggerIndex = config.TriggerIndex
 
 	natsServerEndpoint, err := GetFromAuthOrMeta(config, "natsServerMonitoringEndpoint")
@@ -437,13 +475,33 @@ func (s *natsJetStreamScaler) getMaxMsgLag() int64 {
 	return s.stream.State.LastSequence
 }
 
+// getRedeliveredCount returns the number of redelivered messages of the consumer. Older servers
+// don't report it, in that case there is nothing to scale on and 0 is returned
+func (s *natsJetStreamScaler) getRedeliveredCount() int64 {
+	for _, consumer := range s.stream.Consumers {
+		if consumer.Name == s.metadata.consumer {
+			if consumer.NumRedelivered == nil {
+				s.logger.V(1).Info("NATS JetStream Scaler: the server doesn't report num_redelivered for the consumer, assuming no redeliveries", "consumer", consumer.Name)
+				return 0
+			}
+			return int64(*consumer.NumRedelivered)
+		}
+	}
+	return 0
+}
+
 func (s *natsJetStreamScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	metricName := kedautil.NormalizeString(fmt.Sprintf("nats-jetstream-%s", s.metadata.stream))
+	threshold := s.metadata.lagThreshold
+	if s.metadata.scaleOn == jetStreamScaleOnRedeliveries {
+		metricName = kedautil.NormalizeString(fmt.Sprintf("nats-jetstream-%s-redeliveries", s.metadata.stream))
+		threshold = s.metadata.redeliveryThreshold
+	}
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.lagThreshold),
+		Target: GetMetricTarget(s.metricType, threshold),
 	}
 	metricSpec := v2.MetricSpec{
 		External: externalMetric,
@@ -462,6 +520,15 @@ func (s *natsJetStreamScaler) GetMetricsAndActivity(ctx context.Context, metricN
 		return []external_metrics.ExternalMetricValue{}, false, errors.New("stream not found")
 	}
 
+	if s.metadata.scaleOn == jetStreamScaleOnRedeliveries {
+		redelivered := s.getRedeliveredCount()
+		s.logger.V(1).Info("NATS JetStream Scaler: Providing metrics based on redeliveries, threshold", "redelivered", redelivered, "redeliveryThreshold", s.metadata.redeliveryThreshold)
+
+		metric := GenerateMetricInMili(metricName, float64(redelivered))
+
+		return []external_metrics.ExternalMetricValue{metric}, redelivered > s.metadata.activationRedeliveryThreshold, nil
+	}
+
 	totalLag := s.getMaxMsgLag()
 	s.logger.V(1).Info("NATS JetStream Scaler: Providing metrics based on totalLag, threshold", "totalLag", totalLag, "lagThreshold", s.metadata.lagThreshold)
 
@@ -69,11 +69,22 @@ var testNATSJetStreamMetadata = []parseNATSJetStreamMetadataTestData{
 	{map[string]string{"stream": "mystream", "consumer": "pull_consumer"}, map[string]string{"account": "$G", "natsServerMonitoringEndpoint": "nats.nats:8222"}, false},
 	// Misconfigured account
 	{map[string]string{"stream": "mystream", "consumer": "pull_consumer"}, map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, true},
+	// All good + scale on redeliveries
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "scaleOn": "redeliveries", "redeliveryThreshold": "3", "activationRedeliveryThreshold": "1"}, map[string]string{}, false},
+	// Misconfigured scaleOn
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "scaleOn": "pending"}, map[string]string{}, true},
+	// Misconfigured redeliveryThreshold
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "scaleOn": "redeliveries", "redeliveryThreshold": "Y"}, map[string]string{}, true},
+	// Zero redeliveryThreshold
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "scaleOn": "redeliveries", "redeliveryThreshold": "0"}, map[string]string{}, true},
+	// Misconfigured activationRedeliveryThreshold
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "scaleOn": "redeliveries", "activationRedeliveryThreshold": "Y"}, map[string]string{}, true},
 }
 
 var natsJetStreamMetricIdentifiers = []natsJetStreamMetricIdentifier{
 	{&testNATSJetStreamMetadata[0], 0, "s0-nats-jetstream-mystream"},
 	{&testNATSJetStreamMetadata[0], 1, "s1-nats-jetstream-mystream"},
+	{&testNATSJetStreamMetadata[16], 2, "s2-nats-jetstream-mystream-redeliveries"},
 }
 
 func TestNATSJetStreamParseMetadata(t *testing.T) {
@@ -310,6 +321,72 @@ func TestNATSJetStreamGetMetrics(t *testing.T) {
 	}
 }
 
+func TestNATSJetStreamGetMetricsRedeliveries(t *testing.T) {
+	testCases := []struct {
+		name          string
+		response      string
+		expectedValue int64
+		isActive      bool
+	}{
+		{
+			name:          "redelivered messages above activation",
+			response:      `{"account_details": [{"name": "$G", "stream_detail": [{"name": "mystream", "consumer_detail": [{"name": "pull_consumer", "num_pending": 0, "num_ack_pending": 4, "num_redelivered": 4}]}]}]}`,
+			expectedValue: 4,
+			isActive:      true,
+		},
+		{
+			name:          "redelivered messages below activation",
+			response:      `{"account_details": [{"name": "$G", "stream_detail": [{"name": "mystream", "consumer_detail": [{"name": "pull_consumer", "num_pending": 500, "num_redelivered": 1}]}]}]}`,
+			expectedValue: 1,
+			isActive:      false,
+		},
+		{
+			name:          "num_redelivered not reported by the server",
+			response:      `{"account_details": [{"name": "$G", "stream_detail": [{"name": "mystream", "consumer_detail": [{"name": "pull_consumer", "num_pending": 500}]}]}]}`,
+			expectedValue: 0,
+			isActive:      false,
+		},
+		{
+			name:          "consumer not found",
+			response:      `{"account_details": [{"name": "$G", "stream_detail": [{"name": "mystream", "state": {"last_seq": 10}, "consumer_detail": [{"name": "other_consumer", "num_redelivered": 8}]}]}]}`,
+			expectedValue: 0,
+			isActive:      false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, srv := natsMockHTTPJetStreamServer(t, []byte(tc.response))
+			defer srv.Close()
+
+			config := &scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{
+				"natsServerMonitoringEndpoint":  "localhost:8222",
+				"account":                       "$G",
+				"stream":                        "mystream",
+				"consumer":                      "pull_consumer",
+				"scaleOn":                       "redeliveries",
+				"activationRedeliveryThreshold": "1",
+			}}
+			meta, err := parseNATSJetStreamMetadata(config)
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+
+			mockJetStreamScaler := natsJetStreamScaler{
+				stream:     nil,
+				metadata:   meta,
+				httpClient: client,
+				logger:     InitializeLogger(config, "nats_jetstream_scaler"),
+			}
+
+			metrics, isActive, err := mockJetStreamScaler.GetMetricsAndActivity(context.Background(), "metric_name")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.isActive, isActive)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+		})
+	}
+}
+
 func natsMockHTTPJetStreamServer(t *testing.T, mockResponseJSON []byte) (*http.Client, *httptest.Server) {
 	dialer := &net.Dialer{
 		Timeout:   30 * time.Second,
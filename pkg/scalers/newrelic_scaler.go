@@ -19,10 +19,11 @@ const (
 )
 
 type newrelicScaler struct {
-	metricType v2.MetricTargetType
-	metadata   newrelicMetadata
-	nrClient   *newrelic.NewRelic
-	logger     logr.Logger
+	metricType    v2.MetricTargetType
+	metadata      newrelicMetadata
+	nrClient      *newrelic.NewRelic
+	httpClientKey string
+	logger        logr.Logger
 }
 
 type newrelicMetadata struct {
@@ -33,6 +34,7 @@ type newrelicMetadata struct {
 	NRQL                string  `keda:"name=nrql,                order=triggerMetadata"`
 	Threshold           float64 `keda:"name=threshold,           order=triggerMetadata"`
 	ActivationThreshold float64 `keda:"name=activationThreshold, order=triggerMetadata, default=0"`
+	UseHTTP2            bool    `keda:"name=useHTTP2,            order=triggerMetadata, default=true"`
 	TriggerIndex        int
 }
 
@@ -49,20 +51,27 @@ func NewNewRelicScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing %s metadata: %w", scalerName, err)
 	}
 
+	httpClientKey := httpClientCacheKey(config.GlobalHTTPTimeout, false, meta.UseHTTP2, meta.Region, meta.QueryKey)
+	httpClient := sharedHTTPClients.acquire(httpClientKey, config.GlobalHTTPTimeout, false, meta.UseHTTP2)
+
 	nrClient, err := newrelic.New(
 		newrelic.ConfigPersonalAPIKey(meta.QueryKey),
-		newrelic.ConfigRegion(meta.Region))
+		newrelic.ConfigRegion(meta.Region),
+		newrelic.ConfigHTTPTransport(httpClient.Transport),
+		newrelic.ConfigHTTPTimeout(httpClient.Timeout))
 	if err != nil {
+		sharedHTTPClients.release(httpClientKey)
 		return nil, fmt.Errorf("error initializing client: %w", err)
 	}
 
 	logger.Info(fmt.Sprintf("Initializing New Relic Scaler (account %d in region %s)", meta.Account, meta.Region))
 
 	return &newrelicScaler{
-		metricType: metricType,
-		metadata:   meta,
-		nrClient:   nrClient,
-		logger:     logger,
+		metricType:    metricType,
+		metadata:      meta,
+		nrClient:      nrClient,
+		httpClientKey: httpClientKey,
+		logger:        logger,
 	}, nil
 }
 
@@ -81,7 +90,12 @@ func parseNewRelicMetadata(config *scalersconfig.ScalerConfig) (newrelicMetadata
 	return meta, nil
 }
 
+// Close releases the shared HTTP client, its connections are closed once no scaler uses it anymore
 func (s *newrelicScaler) Close(context.Context) error {
+	if s.httpClientKey != "" {
+		sharedHTTPClients.release(s.httpClientKey)
+		s.httpClientKey = ""
+	}
 	return nil
 }
 
@@ -0,0 +1,324 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// otelTargetInfoMetric is the metric the Prometheus exporter of the collector puts the resource attributes on,
+	// unless they are converted to labels of every series with resource_to_telemetry_conversion
+	otelTargetInfoMetric = "target_info"
+
+	otelServiceNameAttribute       = "service.name"
+	otelServiceNamespaceAttribute  = "service.namespace"
+	otelServiceInstanceIDAttribute = "service.instance.id"
+)
+
+type openTelemetryScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *openTelemetryMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type openTelemetryMetadata struct {
+	triggerIndex int
+
+	Endpoint           string            `keda:"name=endpoint,           order=triggerMetadata"`
+	MetricName         string            `keda:"name=metricName,         order=triggerMetadata"`
+	ResourceAttributes map[string]string `keda:"name=resourceAttributes, order=triggerMetadata, optional"`
+	MetricAttributes   map[string]string `keda:"name=metricAttributes,   order=triggerMetadata, optional"`
+	Aggregation        string            `keda:"name=aggregation,        order=triggerMetadata, enum=sum;avg;min;max, default=sum"`
+	Value              float64           `keda:"name=value,              order=triggerMetadata, default=0"`
+	ActivationValue    float64           `keda:"name=activationValue,    order=triggerMetadata, default=0"`
+	UnsafeSsl          bool              `keda:"name=unsafeSsl,          order=triggerMetadata, default=false"`
+
+	asMetricSource bool
+}
+
+func (m *openTelemetryMetadata) Validate() error {
+	if m.Value <= 0 && !m.asMetricSource {
+		return fmt.Errorf("value must be a float greater than 0")
+	}
+	return nil
+}
+
+// NewOpenTelemetryScaler creates a new scaler reading the metrics exposed by the Prometheus exporter of an OpenTelemetry Collector
+func NewOpenTelemetryScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseOpenTelemetryMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing OpenTelemetry metadata: %w", err)
+	}
+
+	return &openTelemetryScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "opentelemetry_scaler"),
+	}, nil
+}
+
+func parseOpenTelemetryMetadata(config *scalersconfig.ScalerConfig) (*openTelemetryMetadata, error) {
+	meta := &openTelemetryMetadata{}
+	meta.triggerIndex = config.TriggerIndex
+	meta.asMetricSource = config.AsMetricSource
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing opentelemetry metadata: %w", err)
+	}
+	return meta, nil
+}
+
+func (s *openTelemetryScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *openTelemetryScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("opentelemetry-%s", s.metadata.MetricName))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns value for a supported metric
+func (s *openTelemetryScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting metric from the OpenTelemetry Collector: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationValue, nil
+}
+
+func (s *openTelemetryScaler) getMetricValue(ctx context.Context) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.Endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", string(expfmt.NewFormat(expfmt.TypeTextPlain)))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("collector endpoint returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	return getOpenTelemetryMetricValue(body, s.metadata)
+}
+
+// getOpenTelemetryMetricValue aggregates the values of the series of the metric whose resource and metric
+// attributes match the ones of the trigger
+func getOpenTelemetryMetricValue(body []byte, meta *openTelemetryMetadata) (float64, error) {
+	// Ensure EOL
+	reader := strings.NewReader(strings.ReplaceAll(string(body), "\r\n", "\n"))
+	familiesParser := expfmt.TextParser{}
+	families, err := familiesParser.TextToMetricFamilies(reader)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing collector metrics: %w", err)
+	}
+
+	family, getValue, err := getOpenTelemetryMetricFamily(families, meta.MetricName)
+	if err != nil {
+		return 0, err
+	}
+
+	targets := map[otelTarget]map[string]string{}
+	if targetInfo, ok := families[otelTargetInfoMetric]; ok {
+		for _, metric := range targetInfo.GetMetric() {
+			labels := getOpenTelemetryLabels(metric)
+			targets[newOtelTarget(labels)] = labels
+		}
+	}
+
+	var values []float64
+	for _, metric := range family.GetMetric() {
+		labels := getOpenTelemetryLabels(metric)
+		if !matchOpenTelemetryAttributes(labels, targets[newOtelTarget(labels)], meta.ResourceAttributes, meta.MetricAttributes) {
+			continue
+		}
+		values = append(values, getValue(metric))
+	}
+
+	if len(values) == 0 {
+		return 0, fmt.Errorf("no series of metric %q matches the given attributes", meta.MetricName)
+	}
+
+	return aggregateOpenTelemetryValues(values, meta.Aggregation), nil
+}
+
+// getOpenTelemetryMetricFamily finds the family of the metric, the _count and _sum series of histograms
+// and summaries being parsed as part of the family of their base name
+func getOpenTelemetryMetricFamily(families map[string]*dto.MetricFamily, metricName string) (*dto.MetricFamily, func(*dto.Metric) float64, error) {
+	if family, ok := families[metricName]; ok {
+		switch family.GetType() {
+		case dto.MetricType_GAUGE:
+			return family, func(m *dto.Metric) float64 { return m.GetGauge().GetValue() }, nil
+		case dto.MetricType_COUNTER:
+			return family, func(m *dto.Metric) float64 { return m.GetCounter().GetValue() }, nil
+		case dto.MetricType_UNTYPED:
+			return family, func(m *dto.Metric) float64 { return m.GetUntyped().GetValue() }, nil
+		default:
+			return nil, nil, fmt.Errorf("metric %q is a %s, use its _count or _sum series", metricName, strings.ToLower(family.GetType().String()))
+		}
+	}
+
+	for suffix, getValue := range map[string]func(*dto.Metric) float64{
+		"_count": func(m *dto.Metric) float64 {
+			if m.GetHistogram() != nil {
+				return float64(m.GetHistogram().GetSampleCount())
+			}
+			return float64(m.GetSummary().GetSampleCount())
+		},
+		"_sum": func(m *dto.Metric) float64 {
+			if m.GetHistogram() != nil {
+				return m.GetHistogram().GetSampleSum()
+			}
+			return m.GetSummary().GetSampleSum()
+		},
+	} {
+		baseName, found := strings.CutSuffix(metricName, suffix)
+		if !found {
+			continue
+		}
+		if family, ok := families[baseName]; ok && (family.GetType() == dto.MetricType_HISTOGRAM || family.GetType() == dto.MetricType_SUMMARY) {
+			return family, getValue, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("metric %q not found", metricName)
+}
+
+// otelTarget identifies the resource a series comes from, the exporter setting job to
+// "service.namespace/service.name" and instance to service.instance.id
+type otelTarget struct {
+	job      string
+	instance string
+}
+
+func newOtelTarget(labels map[string]string) otelTarget {
+	return otelTarget{job: labels["job"], instance: labels["instance"]}
+}
+
+func getOpenTelemetryLabels(metric *dto.Metric) map[string]string {
+	labels := make(map[string]string, len(metric.GetLabel()))
+	for _, label := range metric.GetLabel() {
+		labels[label.GetName()] = label.GetValue()
+	}
+	return labels
+}
+
+// matchOpenTelemetryAttributes checks the attributes against the labels of the series, the resource attributes
+// being also looked up on the target_info of the resource of the series
+func matchOpenTelemetryAttributes(labels, targetInfo, resourceAttributes, metricAttributes map[string]string) bool {
+	for name, value := range metricAttributes {
+		if labels[sanitizeOpenTelemetryLabelName(name)] != value {
+			return false
+		}
+	}
+	for name, value := range resourceAttributes {
+		actual, ok := getOpenTelemetryResourceAttribute(labels, name)
+		if !ok && targetInfo != nil {
+			actual, ok = getOpenTelemetryResourceAttribute(targetInfo, name)
+		}
+		if !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
+
+func getOpenTelemetryResourceAttribute(labels map[string]string, name string) (string, bool) {
+	if value, ok := labels[sanitizeOpenTelemetryLabelName(name)]; ok {
+		return value, true
+	}
+
+	job, hasJob := labels["job"]
+	switch name {
+	case otelServiceNameAttribute:
+		if hasJob {
+			_, serviceName, found := strings.Cut(job, "/")
+			if !found {
+				return job, true
+			}
+			return serviceName, true
+		}
+	case otelServiceNamespaceAttribute:
+		if serviceNamespace, _, found := strings.Cut(job, "/"); hasJob && found {
+			return serviceNamespace, true
+		}
+	case otelServiceInstanceIDAttribute:
+		instance, ok := labels["instance"]
+		return instance, ok
+	}
+	return "", false
+}
+
+// sanitizeOpenTelemetryLabelName translates an attribute name to the label name the exporter uses for it,
+// e.g. k8s.namespace.name becomes k8s_namespace_name
+func sanitizeOpenTelemetryLabelName(name string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+	if sanitized != "" && sanitized[0] >= '0' && sanitized[0] <= '9' {
+		sanitized = "key_" + sanitized
+	}
+	return sanitized
+}
+
+func aggregateOpenTelemetryValues(values []float64, aggregation string) float64 {
+	result := values[0]
+	for _, value := range values[1:] {
+		switch aggregation {
+		case "min":
+			result = min(result, value)
+		case "max":
+			result = max(result, value)
+		default:
+			result += value
+		}
+	}
+	if aggregation == "avg" {
+		result /= float64(len(values))
+	}
+	return result
+}
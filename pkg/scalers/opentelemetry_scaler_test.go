@@ -0,0 +1,185 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+// sampleCollectorMetrics is the output of the Prometheus exporter of a collector receiving the metrics of
+// two services, with the resource attributes on target_info
+const sampleCollectorMetrics = `# HELP target_info Target metadata
+# TYPE target_info gauge
+target_info{instance="checkout-7d9f",job="shop/checkout",k8s_deployment_name="checkout",k8s_namespace_name="shop"} 1
+target_info{instance="checkout-b2c4",job="shop/checkout",k8s_deployment_name="checkout",k8s_namespace_name="shop"} 1
+target_info{instance="payment-1a2b",job="payment",k8s_deployment_name="payment",k8s_namespace_name="billing"} 1
+# HELP queue_messages_pending Messages waiting in the queue
+# TYPE queue_messages_pending gauge
+queue_messages_pending{instance="checkout-7d9f",job="shop/checkout",queue="orders"} 12
+queue_messages_pending{instance="checkout-b2c4",job="shop/checkout",queue="orders"} 8
+queue_messages_pending{instance="checkout-b2c4",job="shop/checkout",queue="refunds"} 3
+queue_messages_pending{instance="payment-1a2b",job="payment",queue="orders"} 40
+# HELP http_server_requests_total Requests handled by the server
+# TYPE http_server_requests_total counter
+http_server_requests_total{instance="payment-1a2b",job="payment",http_route="/pay"} 1027
+# HELP http_server_duration Duration of the requests
+# TYPE http_server_duration histogram
+http_server_duration_bucket{instance="checkout-7d9f",job="shop/checkout",le="100"} 90
+http_server_duration_bucket{instance="checkout-7d9f",job="shop/checkout",le="+Inf"} 100
+http_server_duration_sum{instance="checkout-7d9f",job="shop/checkout"} 4520.5
+http_server_duration_count{instance="checkout-7d9f",job="shop/checkout"} 100
+`
+
+// sampleConvertedCollectorMetrics is the output of the exporter with resource_to_telemetry_conversion enabled,
+// the resource attributes being labels of every series
+const sampleConvertedCollectorMetrics = `# HELP queue_messages_pending Messages waiting in the queue
+# TYPE queue_messages_pending gauge
+queue_messages_pending{instance="checkout-7d9f",job="shop/checkout",k8s_namespace_name="shop",service_name="checkout",service_namespace="shop",queue="orders"} 12
+queue_messages_pending{instance="payment-1a2b",job="payment",k8s_namespace_name="billing",service_name="payment",queue="orders"} 40
+`
+
+type parseOpenTelemetryMetadataTestData struct {
+	name     string
+	metadata map[string]string
+	isError  bool
+}
+
+var testOpenTelemetryMetadata = []parseOpenTelemetryMetadataTestData{
+	{"valid", map[string]string{"endpoint": "http://otel-collector:8889/metrics", "metricName": "queue_messages_pending", "value": "10"}, false},
+	{"valid with attributes", map[string]string{"endpoint": "http://otel-collector:8889/metrics", "metricName": "queue_messages_pending", "value": "10", "resourceAttributes": "service.name=checkout,k8s.namespace.name=shop", "metricAttributes": "queue=orders", "aggregation": "max", "activationValue": "2"}, false},
+	{"missing endpoint", map[string]string{"metricName": "queue_messages_pending", "value": "10"}, true},
+	{"missing metricName", map[string]string{"endpoint": "http://otel-collector:8889/metrics", "value": "10"}, true},
+	{"missing value", map[string]string{"endpoint": "http://otel-collector:8889/metrics", "metricName": "queue_messages_pending"}, true},
+	{"invalid value", map[string]string{"endpoint": "http://otel-collector:8889/metrics", "metricName": "queue_messages_pending", "value": "ten"}, true},
+	{"invalid aggregation", map[string]string{"endpoint": "http://otel-collector:8889/metrics", "metricName": "queue_messages_pending", "value": "10", "aggregation": "median"}, true},
+	{"invalid activationValue", map[string]string{"endpoint": "http://otel-collector:8889/metrics", "metricName": "queue_messages_pending", "value": "10", "activationValue": "two"}, true},
+}
+
+func TestOpenTelemetryParseMetadata(t *testing.T) {
+	for _, testData := range testOpenTelemetryMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			_, err := parseOpenTelemetryMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata})
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOpenTelemetryGetMetricSpecForScaling(t *testing.T) {
+	scaler, err := NewOpenTelemetryScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"endpoint": "http://otel-collector:8889/metrics", "metricName": "queue_messages_pending", "value": "10"},
+		TriggerIndex:    1,
+	})
+	assert.NoError(t, err)
+
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, "s1-opentelemetry-queue_messages_pending", metricSpec[0].External.Metric.Name)
+}
+
+func TestGetOpenTelemetryMetricValue(t *testing.T) {
+	testCases := []struct {
+		name               string
+		body               string
+		metricName         string
+		resourceAttributes map[string]string
+		metricAttributes   map[string]string
+		aggregation        string
+		expected           float64
+		expectedErr        string
+	}{
+		{name: "all series", body: sampleCollectorMetrics, metricName: "queue_messages_pending", expected: 63},
+		{name: "resource attribute from target_info", body: sampleCollectorMetrics, metricName: "queue_messages_pending", resourceAttributes: map[string]string{"k8s.namespace.name": "shop"}, expected: 23},
+		{name: "service attributes from job", body: sampleCollectorMetrics, metricName: "queue_messages_pending", resourceAttributes: map[string]string{"service.namespace": "shop", "service.name": "checkout"}, expected: 23},
+		{name: "service name without namespace", body: sampleCollectorMetrics, metricName: "queue_messages_pending", resourceAttributes: map[string]string{"service.name": "payment"}, expected: 40},
+		{name: "service instance", body: sampleCollectorMetrics, metricName: "queue_messages_pending", resourceAttributes: map[string]string{"service.instance.id": "checkout-b2c4"}, expected: 11},
+		{name: "resource and metric attributes", body: sampleCollectorMetrics, metricName: "queue_messages_pending", resourceAttributes: map[string]string{"k8s.deployment.name": "checkout"}, metricAttributes: map[string]string{"queue": "orders"}, expected: 20},
+		{name: "max aggregation", body: sampleCollectorMetrics, metricName: "queue_messages_pending", resourceAttributes: map[string]string{"k8s.namespace.name": "shop"}, aggregation: "max", expected: 12},
+		{name: "min aggregation", body: sampleCollectorMetrics, metricName: "queue_messages_pending", resourceAttributes: map[string]string{"k8s.namespace.name": "shop"}, aggregation: "min", expected: 3},
+		{name: "avg aggregation", body: sampleCollectorMetrics, metricName: "queue_messages_pending", metricAttributes: map[string]string{"queue": "orders"}, aggregation: "avg", expected: 20},
+		{name: "counter", body: sampleCollectorMetrics, metricName: "http_server_requests_total", resourceAttributes: map[string]string{"service.name": "payment"}, expected: 1027},
+		{name: "histogram count", body: sampleCollectorMetrics, metricName: "http_server_duration_count", expected: 100},
+		{name: "histogram sum", body: sampleCollectorMetrics, metricName: "http_server_duration_sum", expected: 4520.5},
+		{name: "converted resource attributes", body: sampleConvertedCollectorMetrics, metricName: "queue_messages_pending", resourceAttributes: map[string]string{"k8s.namespace.name": "billing", "service.name": "payment"}, expected: 40},
+		{name: "no matching series", body: sampleCollectorMetrics, metricName: "queue_messages_pending", resourceAttributes: map[string]string{"k8s.namespace.name": "shipping"}, expectedErr: "no series of metric \"queue_messages_pending\" matches the given attributes"},
+		{name: "unknown metric", body: sampleCollectorMetrics, metricName: "queue_messages_processed", expectedErr: "metric \"queue_messages_processed\" not found"},
+		{name: "histogram without suffix", body: sampleCollectorMetrics, metricName: "http_server_duration", expectedErr: "metric \"http_server_duration\" is a histogram, use its _count or _sum series"},
+		{name: "invalid exposition", body: "queue_messages_pending{queue=\"orders\" 12\n", metricName: "queue_messages_pending", expectedErr: "error parsing collector metrics"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			aggregation := tc.aggregation
+			if aggregation == "" {
+				aggregation = "sum"
+			}
+			value, err := getOpenTelemetryMetricValue([]byte(tc.body), &openTelemetryMetadata{
+				MetricName:         tc.metricName,
+				ResourceAttributes: tc.resourceAttributes,
+				MetricAttributes:   tc.metricAttributes,
+				Aggregation:        aggregation,
+			})
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestOpenTelemetryGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(sampleCollectorMetrics))
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name            string
+		endpoint        string
+		activationValue string
+		expected        int64
+		active          bool
+		isError         bool
+	}{
+		{name: "active", endpoint: server.URL + "/metrics", activationValue: "20", expected: 23000, active: true},
+		{name: "below activation", endpoint: server.URL + "/metrics", activationValue: "23", expected: 23000, active: false},
+		{name: "endpoint error", endpoint: server.URL + "/missing", activationValue: "0", isError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scaler, err := NewOpenTelemetryScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata: map[string]string{
+					"endpoint":           tc.endpoint,
+					"metricName":         "queue_messages_pending",
+					"resourceAttributes": "service.name=checkout",
+					"value":              "10",
+					"activationValue":    tc.activationValue,
+				},
+			})
+			assert.NoError(t, err)
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "s0-opentelemetry-queue_messages_pending")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, metrics[0].Value.MilliValue())
+			assert.Equal(t, tc.active, isActive)
+		})
+	}
+}
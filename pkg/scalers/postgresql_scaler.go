@@ -33,18 +33,23 @@ var (
 )
 
 type postgreSQLScaler struct {
-	metricType  v2.MetricTargetType
-	metadata    *postgreSQLMetadata
-	connection  *sql.DB
-	podIdentity kedav1alpha1.AuthPodIdentity
-	logger      logr.Logger
+	metricType v2.MetricTargetType
+	metadata   *postgreSQLMetadata
+	connection *sql.DB
+	// replicaConnection is queried first when a read replica is configured,
+	// the primary connection is only used when querying the replica fails
+	replicaConnection *sql.DB
+	podIdentity       kedav1alpha1.AuthPodIdentity
+	logger            logr.Logger
 }
 
 type postgreSQLMetadata struct {
 	TargetQueryValue           float64 `keda:"name=targetQueryValue,           order=triggerMetadata, optional"`
 	ActivationTargetQueryValue float64 `keda:"name=activationTargetQueryValue, order=triggerMetadata, optional"`
 	Connection                 string  `keda:"name=connection,                 order=authParams;resolvedEnv, optional"`
-	Query                      string  `keda:"name=query,                      order=triggerMetadata"`
+	ReplicaConnection          string  `keda:"name=replicaConnection,          order=authParams;resolvedEnv, optional"`
+	Query                      string  `keda:"name=query,                      order=triggerMetadata, optional"`
+	QueryTemplate              string  `keda:"name=queryTemplate,              order=triggerMetadata, enum=activeConnections, optional"`
 	triggerIndex               int
 	azureAuthContext           azureAuthContext
 
@@ -57,7 +62,21 @@ type postgreSQLMetadata struct {
 	Password string `keda:"name=password, order=authParams;resolvedEnv, optional"`
 }
 
+// postgreSQLQueryTemplates are the queries that can be given through queryTemplate instead of query
+var postgreSQLQueryTemplates = map[string]string{
+	// the number of connections running a query, idle pooled connections aren't counted and neither is the
+	// connection of the scaler, which is active while it runs this query
+	"activeConnections": "SELECT count(*) FROM pg_stat_activity WHERE state = 'active' AND pid <> pg_backend_pid()",
+}
+
 func (p *postgreSQLMetadata) Validate() error {
+	if p.Query != "" && p.QueryTemplate != "" {
+		return fmt.Errorf("query and queryTemplate cannot be set together")
+	}
+	if p.Query == "" && p.QueryTemplate == "" {
+		return fmt.Errorf("no query given")
+	}
+
 	if p.Connection == "" {
 		if p.Host == "" {
 			return fmt.Errorf("no host given")
@@ -102,16 +121,17 @@ func NewPostgreSQLScaler(ctx context.Context, config *scalersconfig.ScalerConfig
 		return nil, fmt.Errorf("error parsing postgreSQL metadata: %w", err)
 	}
 
-	conn, err := getConnection(ctx, meta, podIdentity, logger)
+	conn, replicaConn, err := getConnections(ctx, meta, podIdentity, logger)
 	if err != nil {
 		return nil, fmt.Errorf("error establishing postgreSQL connection: %w", err)
 	}
 	return &postgreSQLScaler{
-		metricType:  metricType,
-		metadata:    meta,
-		connection:  conn,
-		podIdentity: podIdentity,
-		logger:      logger,
+		metricType:        metricType,
+		metadata:          meta,
+		connection:        conn,
+		replicaConnection: replicaConn,
+		podIdentity:       podIdentity,
+		logger:            logger,
 	}, nil
 }
 
@@ -122,6 +142,9 @@ func parsePostgreSQLMetadata(logger logr.Logger, config *scalersconfig.ScalerCon
 	if err := config.TypedConfig(meta); err != nil {
 		return nil, authPodIdentity, fmt.Errorf("error parsing postgresql metadata: %w", err)
 	}
+	if meta.QueryTemplate != "" {
+		meta.Query = postgreSQLQueryTemplates[meta.QueryTemplate]
+	}
 
 	if !config.AsMetricSource && meta.TargetQueryValue == 0 {
 		return nil, authPodIdentity, fmt.Errorf("no targetQueryValue given")
@@ -163,16 +186,59 @@ func buildConnArray(meta *postgreSQLMetadata) []string {
 	return params
 }
 
-func getConnection(ctx context.Context, meta *postgreSQLMetadata, podIdentity kedav1alpha1.AuthPodIdentity, logger logr.Logger) (*sql.DB, error) {
-	connectionString := meta.Connection
+// getConnections returns the primary connection and the read replica connection, if one is configured.
+// Only one of them has to be reachable, as the primary is used as a fallback for the replica.
+func getConnections(ctx context.Context, meta *postgreSQLMetadata, podIdentity kedav1alpha1.AuthPodIdentity, logger logr.Logger) (*sql.DB, *sql.DB, error) {
+	if meta.ReplicaConnection == "" {
+		db, err := getConnection(ctx, meta, meta.Connection, podIdentity, logger)
+		return db, nil, err
+	}
+
+	replicaDB, err := openConnection(ctx, meta, meta.ReplicaConnection, podIdentity, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	db, err := openConnection(ctx, meta, meta.Connection, podIdentity, logger)
+	if err != nil {
+		replicaDB.Close()
+		return nil, nil, err
+	}
+
+	// the connections are reestablished by database/sql when needed, so an unreachable
+	// replica is tried again on the next query
+	if err := replicaDB.PingContext(ctx); err != nil {
+		logger.Info("PostgreSQL read replica is unavailable, failing over to the primary", "error", err.Error())
+		if err := db.PingContext(ctx); err != nil {
+			logger.Error(err, fmt.Sprintf("Found error pinging postgreSQL: %s", err))
+			replicaDB.Close()
+			db.Close()
+			return nil, nil, err
+		}
+	}
+	return db, replicaDB, nil
+}
+
+func getConnection(ctx context.Context, meta *postgreSQLMetadata, connectionString string, podIdentity kedav1alpha1.AuthPodIdentity, logger logr.Logger) (*sql.DB, error) {
+	db, err := openConnection(ctx, meta, connectionString, podIdentity, logger)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Ping()
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("Found error pinging postgreSQL: %s", err))
+		return nil, err
+	}
+	return db, nil
+}
 
+func openConnection(ctx context.Context, meta *postgreSQLMetadata, connectionString string, podIdentity kedav1alpha1.AuthPodIdentity, logger logr.Logger) (*sql.DB, error) {
 	if podIdentity.Provider == kedav1alpha1.PodIdentityProviderAzureWorkload {
 		accessToken, err := getAzureAccessToken(ctx, meta, azureDatabasePostgresResource)
 		if err != nil {
 			return nil, err
 		}
 		newPasswordField := "password=" + escapePostgreConnectionParameter(accessToken)
-		connectionString = passwordConnPattern.ReplaceAllString(meta.Connection, newPasswordField)
+		connectionString = passwordConnPattern.ReplaceAllString(connectionString, newPasswordField)
 	}
 
 	db, err := sql.Open("pgx", connectionString)
@@ -180,16 +246,17 @@ func getConnection(ctx context.Context, meta *postgreSQLMetadata, podIdentity ke
 		logger.Error(err, fmt.Sprintf("Found error opening postgreSQL: %s", err))
 		return nil, err
 	}
-	err = db.Ping()
-	if err != nil {
-		logger.Error(err, fmt.Sprintf("Found error pinging postgreSQL: %s", err))
-		return nil, err
-	}
 	return db, nil
 }
 
 // Close disposes of postgres connections
 func (s *postgreSQLScaler) Close(context.Context) error {
+	if s.replicaConnection != nil {
+		if err := s.replicaConnection.Close(); err != nil {
+			s.logger.Error(err, "Error closing postgreSQL read replica connection")
+			return err
+		}
+	}
 	err := s.connection.Close()
 	if err != nil {
 		s.logger.Error(err, "Error closing postgreSQL connection")
@@ -205,12 +272,24 @@ func (s *postgreSQLScaler) getActiveNumber(ctx context.Context) (float64, error)
 		if s.metadata.azureAuthContext.token.ExpiresOn.Before(time.Now()) {
 			s.logger.Info("The Azure Access Token expired, retrieving a new Azure Access Token and instantiating a new Postgres connection object.")
 			s.connection.Close()
-			newConnection, err := getConnection(ctx, s.metadata, s.podIdentity, s.logger)
+			if s.replicaConnection != nil {
+				s.replicaConnection.Close()
+			}
+			newConnection, newReplicaConnection, err := getConnections(ctx, s.metadata, s.podIdentity, s.logger)
 			if err != nil {
 				return 0, fmt.Errorf("error establishing postgreSQL connection: %w", err)
 			}
 			s.connection = newConnection
+			s.replicaConnection = newReplicaConnection
+		}
+	}
+
+	if s.replicaConnection != nil {
+		err := s.replicaConnection.QueryRowContext(ctx, s.metadata.Query).Scan(&id)
+		if err == nil {
+			return id, nil
 		}
+		s.logger.Info("Could not query postgreSQL read replica, failing over to the primary", "error", err.Error())
 	}
 
 	err := s.connection.QueryRowContext(ctx, s.metadata.Query).Scan(&id)
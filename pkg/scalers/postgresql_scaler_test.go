@@ -2,6 +2,9 @@ package scalers
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -50,7 +53,7 @@ func TestPosgresSQLGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockPostgresSQLScaler := postgreSQLScaler{"", meta, nil, kedav1alpha1.AuthPodIdentity{}, logr.Discard()}
+		mockPostgresSQLScaler := postgreSQLScaler{"", meta, nil, nil, kedav1alpha1.AuthPodIdentity{}, logr.Discard()}
 
 		metricSpec := mockPostgresSQLScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
@@ -151,6 +154,41 @@ var testPostgresMetadata = []parsePostgresMetadataTestData{
 		resolvedEnv: testPostgresResolvedEnv,
 		raisesError: false,
 	},
+	// read replica connection string
+	{
+		metadata:    map[string]string{"query": "query", "targetQueryValue": "12", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		authParams:  map[string]string{"replicaConnection": "test_replica_conn_str"},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: false,
+	},
+	// queryTemplate instead of query
+	{
+		metadata:    map[string]string{"queryTemplate": "activeConnections", "targetQueryValue": "12", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		authParams:  map[string]string{},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: false,
+	},
+	// unknown queryTemplate
+	{
+		metadata:    map[string]string{"queryTemplate": "unknown", "targetQueryValue": "12", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		authParams:  map[string]string{},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: true,
+	},
+	// both query and queryTemplate
+	{
+		metadata:    map[string]string{"query": "query", "queryTemplate": "activeConnections", "targetQueryValue": "12", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		authParams:  map[string]string{},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: true,
+	},
+	// neither query nor queryTemplate
+	{
+		metadata:    map[string]string{"targetQueryValue": "12", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		authParams:  map[string]string{},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: true,
+	},
 }
 
 func TestParsePosgresSQLMetadata(t *testing.T) {
@@ -164,3 +202,136 @@ func TestParsePosgresSQLMetadata(t *testing.T) {
 		}
 	}
 }
+
+func TestPostgreSQLQueryTemplate(t *testing.T) {
+	meta, _, err := parsePostgreSQLMetadata(logr.Discard(), &scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"queryTemplate": "activeConnections", "targetQueryValue": "12", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		ResolvedEnv:     testPostgresResolvedEnv,
+	})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	if meta.Query != postgreSQLQueryTemplates["activeConnections"] {
+		t.Errorf("Expected the query of the activeConnections template but got %q", meta.Query)
+	}
+}
+
+type postgreSQLReplicaFailoverTestData struct {
+	name          string
+	primary       *fakeSQLConnector
+	replica       *fakeSQLConnector
+	expectedValue float64
+	raisesError   bool
+}
+
+var testPostgreSQLReplicaFailover = []postgreSQLReplicaFailoverTestData{
+	{
+		name:          "no replica",
+		primary:       &fakeSQLConnector{value: 1},
+		expectedValue: 1,
+	},
+	{
+		name:          "replica available",
+		primary:       &fakeSQLConnector{value: 1},
+		replica:       &fakeSQLConnector{value: 2},
+		expectedValue: 2,
+	},
+	{
+		name:          "replica down",
+		primary:       &fakeSQLConnector{value: 1},
+		replica:       &fakeSQLConnector{err: errors.New("connection refused")},
+		expectedValue: 1,
+	},
+	{
+		name:        "replica and primary down",
+		primary:     &fakeSQLConnector{err: errors.New("connection refused")},
+		replica:     &fakeSQLConnector{err: errors.New("connection refused")},
+		raisesError: true,
+	},
+}
+
+func TestPostgreSQLReplicaFailover(t *testing.T) {
+	for _, testData := range testPostgreSQLReplicaFailover {
+		t.Run(testData.name, func(t *testing.T) {
+			scaler := postgreSQLScaler{
+				metadata:   &postgreSQLMetadata{Query: "test_query"},
+				connection: sql.OpenDB(testData.primary),
+				logger:     logr.Discard(),
+			}
+			if testData.replica != nil {
+				scaler.replicaConnection = sql.OpenDB(testData.replica)
+			}
+			defer scaler.Close(context.Background())
+
+			value, err := scaler.getActiveNumber(context.Background())
+			if err != nil && !testData.raisesError {
+				t.Error("Expected success but got error", err)
+			}
+			if err == nil && testData.raisesError {
+				t.Error("Expected error but got success")
+			}
+			if value != testData.expectedValue {
+				t.Errorf("Expected value %v but got %v", testData.expectedValue, value)
+			}
+		})
+	}
+}
+
+// testPostgreSQLActiveConnectionsQuery counts the active connections including the one running it
+const testPostgreSQLActiveConnectionsQuery = "SELECT count(*) FROM pg_stat_activity WHERE state = 'active'"
+
+// testPostgreSQLConnectionCountDatabase answers the connection-count queries of a database where otherConnections
+// connections are active besides the one of the scaler, which is active while it runs its query
+func testPostgreSQLConnectionCountDatabase(otherConnections float64) *fakeSQLConnector {
+	return &fakeSQLConnector{values: map[string]driver.Value{
+		testPostgreSQLActiveConnectionsQuery:          otherConnections + 1,
+		postgreSQLQueryTemplates["activeConnections"]: otherConnections,
+	}}
+}
+
+var testPostgreSQLConnectionCountActivation = []struct {
+	name             string
+	metadata         map[string]string
+	otherConnections float64
+	expectedValue    float64
+	expectedIsActive bool
+}{
+	{name: "idle", metadata: map[string]string{"queryTemplate": "activeConnections", "targetQueryValue": "20"}, otherConnections: 0, expectedValue: 0, expectedIsActive: false},
+	{name: "single connection", metadata: map[string]string{"queryTemplate": "activeConnections", "targetQueryValue": "20"}, otherConnections: 1, expectedValue: 1, expectedIsActive: true},
+	{name: "below activation", metadata: map[string]string{"queryTemplate": "activeConnections", "targetQueryValue": "20", "activationTargetQueryValue": "5"}, otherConnections: 5, expectedValue: 5, expectedIsActive: false},
+	{name: "above activation", metadata: map[string]string{"queryTemplate": "activeConnections", "targetQueryValue": "20", "activationTargetQueryValue": "5"}, otherConnections: 6, expectedValue: 6, expectedIsActive: true},
+	// a query counting the connection of the scaler never reports an idle database
+	{name: "idle counting the scaler connection", metadata: map[string]string{"query": testPostgreSQLActiveConnectionsQuery, "targetQueryValue": "20"}, otherConnections: 0, expectedValue: 1, expectedIsActive: true},
+}
+
+func TestPostgreSQLConnectionCountActivation(t *testing.T) {
+	for _, testData := range testPostgreSQLConnectionCountActivation {
+		t.Run(testData.name, func(t *testing.T) {
+			metadata := map[string]string{"connectionFromEnv": "test_connection_string"}
+			for key, value := range testData.metadata {
+				metadata[key] = value
+			}
+			meta, _, err := parsePostgreSQLMetadata(logr.Discard(), &scalersconfig.ScalerConfig{TriggerMetadata: metadata, ResolvedEnv: map[string]string{"test_connection_string": "postgresql://localhost:5432"}})
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			scaler := postgreSQLScaler{
+				metadata:   meta,
+				connection: sql.OpenDB(testPostgreSQLConnectionCountDatabase(testData.otherConnections)),
+				logger:     logr.Discard(),
+			}
+			defer scaler.Close(context.Background())
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "s0-postgresql")
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			if isActive != testData.expectedIsActive {
+				t.Errorf("Expected isActive %v but got %v", testData.expectedIsActive, isActive)
+			}
+			if value := metrics[0].Value.AsApproximateFloat64(); value != testData.expectedValue {
+				t.Errorf("Expected value %v but got %v", testData.expectedValue, value)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package scalers
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,7 +10,9 @@ import (
 	"math"
 	"net/http"
 	url_pkg "net/url"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -25,6 +28,50 @@ import (
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
+const (
+	prometheusQueryTypeInstant = "instant"
+	prometheusQueryTypeRange   = "range"
+
+	prometheusReducerP50 = "p50"
+	prometheusReducerP95 = "p95"
+	prometheusReducerMax = "max"
+	prometheusReducerAvg = "avg"
+
+	prometheusDefaultRangeWindow = "5m"
+	prometheusDefaultRangeStep   = "30s"
+
+	prometheusMeshIstio   = "istio"
+	prometheusMeshLinkerd = "linkerd"
+
+	prometheusDefaultHistogramWindow = "5m"
+
+	// prometheusQueryTimeoutGrace is added to the queryTimeout for the deadline of the request,
+	// so the error of Prometheus is received when it aborts the query itself
+	prometheusQueryTimeoutGrace = time.Second
+)
+
+// newPrometheusTLSConfig parses the certificates of the authentication, replaced in tests to count the parsings
+var newPrometheusTLSConfig = authentication.NewTLSConfig
+
+// prometheusMeshDefaults are the latency histogram of a service mesh, the label holding the name of the
+// service and the labels restricting the histogram to the requests received by the service
+var prometheusMeshDefaults = map[string]struct {
+	metricName   string
+	serviceLabel string
+	labels       map[string]string
+}{
+	prometheusMeshIstio: {
+		metricName:   "istio_request_duration_milliseconds",
+		serviceLabel: "destination_service_name",
+		labels:       map[string]string{"reporter": "destination"},
+	},
+	prometheusMeshLinkerd: {
+		metricName:   "response_latency_ms",
+		serviceLabel: "deployment",
+		labels:       map[string]string{"direction": "inbound"},
+	},
+}
+
 type prometheusScaler struct {
 	metricType v2.MetricTargetType
 	metadata   *prometheusMetadata
@@ -41,7 +88,7 @@ type prometheusMetadata struct {
 
 	PrometheusAuth      *authentication.Config `keda:"optional"`
 	ServerAddress       string                 `keda:"name=serverAddress,       order=triggerMetadata"`
-	Query               string                 `keda:"name=query,               order=triggerMetadata"`
+	Query               string                 `keda:"name=query,               order=triggerMetadata, optional"`
 	QueryParameters     map[string]string      `keda:"name=queryParameters,     order=triggerMetadata,    				optional"`
 	Threshold           float64                `keda:"name=threshold,           order=triggerMetadata"`
 	ActivationThreshold float64                `keda:"name=activationThreshold, order=triggerMetadata, 				    optional"`
@@ -49,18 +96,195 @@ type prometheusMetadata struct {
 	CustomHeaders       map[string]string      `keda:"name=customHeaders,       order=triggerMetadata, 				    optional"`
 	IgnoreNullValues    bool                   `keda:"name=ignoreNullValues,    order=triggerMetadata, 				    default=true"`
 	UnsafeSSL           bool                   `keda:"name=unsafeSsl,           order=triggerMetadata, 				    optional"`
+	TLSServerName       string                 `keda:"name=tlsServerName,       order=triggerMetadata, optional"`
 	AwsRegion           string                 `keda:"name=awsRegion, 			    order=triggerMetadata;authParams, optional"`
+
+	// the tenants are sent in tenantHeader joined by "|", which Mimir and Cortex read as a federated query across them
+	TenantIDs    []string `keda:"name=tenantIDs,    order=triggerMetadata, separator=|, optional"`
+	TenantHeader string   `keda:"name=tenantHeader, order=triggerMetadata, default=X-Scope-OrgID"`
+
+	// range queries are reduced client-side to a single value
+	QueryType string `keda:"name=queryType, order=triggerMetadata, enum=instant;range, default=instant"`
+	Window    string `keda:"name=window,    order=triggerMetadata, optional"`
+	Step      string `keda:"name=step,      order=triggerMetadata, optional"`
+	Reducer   string `keda:"name=reducer,   order=triggerMetadata, enum=p50;p95;max;avg, optional"`
+
+	window time.Duration
+	step   time.Duration
+
+	// the queryTimeout is sent to Prometheus, which aborts the query once it elapsed
+	QueryTimeout string `keda:"name=queryTimeout, order=triggerMetadata, optional"`
+	QueryStats   bool   `keda:"name=queryStats,   order=triggerMetadata, optional"`
+
+	queryTimeout time.Duration
+
+	// the query is evaluated at now - queryTimeOffset, for metrics that settle with a delay
+	QueryTimeOffset string `keda:"name=queryTimeOffset, order=triggerMetadata, optional"`
+
+	queryTimeOffset time.Duration
+
+	// the query of the histogram mode is built from the buckets of metricName, or of the latency histogram of the mesh
+	HistogramQuantile float64           `keda:"name=histogramQuantile, order=triggerMetadata, optional"`
+	MetricName        string            `keda:"name=metricName,        order=triggerMetadata, optional"`
+	Labels            map[string]string `keda:"name=labels,            order=triggerMetadata, optional"`
+	HistogramWindow   string            `keda:"name=histogramWindow,   order=triggerMetadata, optional"`
+	Mesh              string            `keda:"name=mesh,              order=triggerMetadata, enum=istio;linkerd, optional"`
+	Service           string            `keda:"name=service,           order=triggerMetadata, optional"`
+}
+
+func (m *prometheusMetadata) Validate() error {
+	if err := m.validateHistogram(); err != nil {
+		return err
+	}
+
+	if err := m.validateTenants(); err != nil {
+		return err
+	}
+
+	if m.QueryTimeout != "" {
+		var err error
+		if m.queryTimeout, err = time.ParseDuration(m.QueryTimeout); err != nil || m.queryTimeout <= 0 {
+			return fmt.Errorf("queryTimeout must be a positive duration, got %q", m.QueryTimeout)
+		}
+	}
+
+	if m.QueryTimeOffset != "" {
+		var err error
+		if m.queryTimeOffset, err = time.ParseDuration(m.QueryTimeOffset); err != nil || m.queryTimeOffset < 0 {
+			return fmt.Errorf("queryTimeOffset must be a duration greater than or equal to 0, got %q", m.QueryTimeOffset)
+		}
+	}
+
+	if m.QueryType != prometheusQueryTypeRange {
+		if m.Window != "" || m.Step != "" || m.Reducer != "" {
+			return fmt.Errorf("window, step and reducer can only be used with queryType %q", prometheusQueryTypeRange)
+		}
+		return nil
+	}
+
+	if m.Window == "" {
+		m.Window = prometheusDefaultRangeWindow
+	}
+	if m.Step == "" {
+		m.Step = prometheusDefaultRangeStep
+	}
+	if m.Reducer == "" {
+		m.Reducer = prometheusReducerAvg
+	}
+
+	var err error
+	if m.window, err = time.ParseDuration(m.Window); err != nil || m.window <= 0 {
+		return fmt.Errorf("window must be a positive duration, got %q", m.Window)
+	}
+	if m.step, err = time.ParseDuration(m.Step); err != nil || m.step <= 0 {
+		return fmt.Errorf("step must be a positive duration, got %q", m.Step)
+	}
+	if m.step > m.window {
+		return fmt.Errorf("step %s must not be greater than window %s", m.Step, m.Window)
+	}
+	return nil
+}
+
+// validateHistogram builds the query of the histogram mode, or checks that a query is given otherwise
+func (m *prometheusMetadata) validateHistogram() error {
+	if m.HistogramQuantile == 0 {
+		// metricName isn't checked, as it was part of the metadata of the scaler before the histogram mode
+		if len(m.Labels) > 0 || m.HistogramWindow != "" || m.Mesh != "" || m.Service != "" {
+			return fmt.Errorf("labels, histogramWindow, mesh and service can only be used with histogramQuantile")
+		}
+		if m.Query == "" {
+			return fmt.Errorf("query or histogramQuantile must be set")
+		}
+		return nil
+	}
+
+	if m.Query != "" {
+		return fmt.Errorf("query and histogramQuantile can't be set together")
+	}
+	if m.HistogramQuantile <= 0 || m.HistogramQuantile >= 1 {
+		return fmt.Errorf("histogramQuantile must be between 0 and 1 exclusive, got %v", m.HistogramQuantile)
+	}
+	if m.HistogramWindow == "" {
+		m.HistogramWindow = prometheusDefaultHistogramWindow
+	}
+	if window, err := time.ParseDuration(m.HistogramWindow); err != nil || window <= 0 {
+		return fmt.Errorf("histogramWindow must be a positive duration, got %q", m.HistogramWindow)
+	}
+
+	labels := map[string]string{}
+	metricName := m.MetricName
+	if m.Mesh != "" {
+		mesh := prometheusMeshDefaults[m.Mesh]
+		if metricName == "" {
+			metricName = mesh.metricName
+		}
+		for k, v := range mesh.labels {
+			labels[k] = v
+		}
+		if m.Service != "" {
+			labels[mesh.serviceLabel] = m.Service
+		}
+	} else if m.Service != "" {
+		return fmt.Errorf("service can only be used with mesh")
+	}
+	if metricName == "" {
+		return fmt.Errorf("metricName or mesh must be set with histogramQuantile")
+	}
+	// the labels of the metadata override the ones of the mesh
+	for k, v := range m.Labels {
+		labels[k] = v
+	}
+
+	m.Query = buildPrometheusHistogramQuery(m.HistogramQuantile, metricName, labels, m.HistogramWindow)
+	return nil
+}
+
+// buildPrometheusHistogramQuery returns the query of the quantile of a histogram over the window, the
+// buckets of all the series being summed so the query returns a single element
+func buildPrometheusHistogramQuery(quantile float64, metricName string, labels map[string]string, window string) string {
+	if !strings.HasSuffix(metricName, "_bucket") {
+		metricName += "_bucket"
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	matchers := make([]string, 0, len(names))
+	for _, name := range names {
+		matchers = append(matchers, fmt.Sprintf("%s=%s", name, strconv.Quote(labels[name])))
+	}
+
+	selector := metricName
+	if len(matchers) > 0 {
+		selector = fmt.Sprintf("%s{%s}", metricName, strings.Join(matchers, ","))
+	}
+
+	return fmt.Sprintf("histogram_quantile(%s, sum by (le) (rate(%s[%s])))", strconv.FormatFloat(quantile, 'f', -1, 64), selector, window)
 }
 
 type promQueryResult struct {
-	Status string `json:"status"`
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	// Warnings come along with the data, e.g. when Thanos Query returns a partial response
+	Warnings []string `json:"warnings"`
 
 	Data struct {
 		ResultType string `json:"resultType"`
 		Result     []struct {
-			Metric struct{}      `json:"metric"`
-			Value  []interface{} `json:"value"`
+			Metric struct{}        `json:"metric"`
+			Value  []interface{}   `json:"value"`
+			Values [][]interface{} `json:"values"`
 		} `json:"result"`
+		// Stats are returned with the stats=all query parameter
+		Stats *struct {
+			Samples struct {
+				TotalQueryableSamples int64 `json:"totalQueryableSamples"`
+				PeakSamples           int64 `json:"peakSamples"`
+			} `json:"samples"`
+		} `json:"stats"`
 	} `json:"data"`
 }
 
@@ -79,18 +303,30 @@ func NewPrometheusScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	}
 
 	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSSL)
+	setPrometheusTLSServerName(httpClient.Transport, meta.TLSServerName)
 
 	if !meta.PrometheusAuth.Disabled() {
 		if meta.PrometheusAuth.CA != "" || meta.PrometheusAuth.EnabledTLS() {
+			authMeta := meta.PrometheusAuth.ToAuthMeta()
+			// the triggers sharing the TriggerAuthentication share the parsed certificates
+			authMeta.TLSConfig, err = config.TLSConfigCache.GetOrCreate(func() (*tls.Config, error) {
+				return newPrometheusTLSConfig(authMeta, false)
+			}, authMeta.Cert, authMeta.Key, authMeta.CA)
+			if err != nil {
+				logger.V(1).Error(err, "init Prometheus client TLS config")
+				return nil, fmt.Errorf("error creating the TLS config: %w", err)
+			}
+
 			// create http.RoundTripper with auth settings from ScalerConfig
 			transport, err := authentication.CreateHTTPRoundTripper(
 				authentication.NetHTTP,
-				meta.PrometheusAuth.ToAuthMeta(),
+				authMeta,
 			)
 			if err != nil {
 				logger.V(1).Error(err, "init Prometheus client http transport")
 				return nil, err
 			}
+			setPrometheusTLSServerName(transport, meta.TLSServerName)
 			httpClient.Transport = transport
 		}
 	} else {
@@ -136,6 +372,33 @@ func NewPrometheusScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	}, nil
 }
 
+// validateTenants trims the tenant IDs and checks the tenant header isn't given twice
+func (m *prometheusMetadata) validateTenants() error {
+	for i, tenantID := range m.TenantIDs {
+		m.TenantIDs[i] = strings.TrimSpace(tenantID)
+		if m.TenantIDs[i] == "" {
+			return errors.New("tenantIDs must not contain an empty tenant ID")
+		}
+	}
+	if len(m.TenantIDs) == 0 {
+		return nil
+	}
+	for headerName := range m.CustomHeaders {
+		if http.CanonicalHeaderKey(headerName) == http.CanonicalHeaderKey(m.TenantHeader) {
+			return fmt.Errorf("header %s can't be given in customHeaders together with tenantIDs", m.TenantHeader)
+		}
+	}
+	return nil
+}
+
+// setPrometheusTLSServerName sets the tlsServerName on the TLS config of the transport, before it's wrapped by
+// the transports of the cloud providers
+func setPrometheusTLSServerName(transport http.RoundTripper, serverName string) {
+	if t, ok := transport.(*http.Transport); ok {
+		kedautil.SetTLSServerName(t.TLSClientConfig, serverName)
+	}
+}
+
 func parsePrometheusMetadata(config *scalersconfig.ScalerConfig) (meta *prometheusMetadata, err error) {
 	meta = &prometheusMetadata{}
 	if err := config.TypedConfig(meta); err != nil {
@@ -183,15 +446,33 @@ func (s *prometheusScaler) GetMetricSpecForScaling(context.Context) []v2.MetricS
 }
 
 func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error) {
-	t := time.Now().UTC().Format(time.RFC3339)
+	now := time.Now().UTC().Add(-s.metadata.queryTimeOffset)
 	queryEscaped := url_pkg.QueryEscape(s.metadata.Query)
-	url := fmt.Sprintf("%s/api/v1/query?query=%s&time=%s", s.metadata.ServerAddress, queryEscaped, t)
+	var url string
+	if s.metadata.QueryType == prometheusQueryTypeRange {
+		url = fmt.Sprintf("%s/api/v1/query_range?query=%s&start=%s&end=%s&step=%s", s.metadata.ServerAddress, queryEscaped,
+			now.Add(-s.metadata.window).Format(time.RFC3339), now.Format(time.RFC3339), strconv.FormatFloat(s.metadata.step.Seconds(), 'f', -1, 64))
+	} else {
+		url = fmt.Sprintf("%s/api/v1/query?query=%s&time=%s", s.metadata.ServerAddress, queryEscaped, now.Format(time.RFC3339))
+	}
 
 	// set 'namespace' parameter for namespaced Prometheus requests (e.g. for Thanos Querier)
 	if s.metadata.Namespace != "" {
 		url = fmt.Sprintf("%s&namespace=%s", url, s.metadata.Namespace)
 	}
 
+	if s.metadata.queryTimeout > 0 {
+		url = fmt.Sprintf("%s&timeout=%s", url, strconv.FormatFloat(s.metadata.queryTimeout.Seconds(), 'f', -1, 64))
+
+		// a slow query is abandoned even if Prometheus doesn't abort it
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.metadata.queryTimeout+prometheusQueryTimeoutGrace)
+		defer cancel()
+	}
+	if s.metadata.QueryStats {
+		url = fmt.Sprintf("%s&stats=all", url)
+	}
+
 	for queryParameterKey, queryParameterValue := range s.metadata.QueryParameters {
 		queryParameterKeyEscaped := url_pkg.QueryEscape(queryParameterKey)
 		queryParameterValueEscaped := url_pkg.QueryEscape(queryParameterValue)
@@ -206,6 +487,9 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 	for headerName, headerValue := range s.metadata.CustomHeaders {
 		req.Header.Add(headerName, headerValue)
 	}
+	if len(s.metadata.TenantIDs) > 0 {
+		req.Header.Set(s.metadata.TenantHeader, strings.Join(s.metadata.TenantIDs, "|"))
+	}
 
 	switch {
 	case s.metadata.PrometheusAuth.Disabled():
@@ -220,12 +504,12 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 
 	r, err := s.httpClient.Do(req)
 	if err != nil {
-		return -1, err
+		return -1, s.wrapQueryTimeoutError(err)
 	}
 
 	b, err := io.ReadAll(r.Body)
 	if err != nil {
-		return -1, err
+		return -1, s.wrapQueryTimeoutError(err)
 	}
 	defer r.Body.Close()
 
@@ -241,6 +525,20 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 		return -1, err
 	}
 
+	if result.Status == "error" {
+		err := fmt.Errorf("prometheus query api returned error. type: %s error: %s", result.ErrorType, result.Error)
+		s.logger.Error(err, "prometheus query api returned error")
+		return -1, err
+	}
+	// the data of a response with warnings is still used, partial responses can be rejected
+	// with the partial_response=false query parameter of Thanos instead
+	if len(result.Warnings) > 0 {
+		s.logger.V(1).Info("prometheus query api returned warnings", "warnings", result.Warnings)
+	}
+	if stats := result.Data.Stats; stats != nil {
+		s.logger.V(1).Info("prometheus query stats", "totalQueryableSamples", stats.Samples.TotalQueryableSamples, "peakSamples", stats.Samples.PeakSamples)
+	}
+
 	var v float64 = -1
 
 	// allow for zero element or single element result sets
@@ -248,11 +546,15 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 		if s.metadata.IgnoreNullValues {
 			return 0, nil
 		}
-		return -1, fmt.Errorf("prometheus metrics 'prometheus' target may be lost, the result is empty")
+		return -1, fmt.Errorf("prometheus metrics 'prometheus' target may be lost, the result is empty%s", formatPromWarnings(result.Warnings))
 	} else if len(result.Data.Result) > 1 {
 		return -1, fmt.Errorf("prometheus query %s returned multiple elements", s.metadata.Query)
 	}
 
+	if s.metadata.QueryType == prometheusQueryTypeRange {
+		return s.reduceRangeValues(result.Data.Result[0].Values)
+	}
+
 	valueLen := len(result.Data.Result[0].Value)
 	if valueLen == 0 {
 		if s.metadata.IgnoreNullValues {
@@ -282,9 +584,105 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 		return -1, err
 	}
 
+	// histogram_quantile returns NaN when no request was observed over the window
+	if math.IsNaN(v) && s.metadata.HistogramQuantile != 0 {
+		if s.metadata.IgnoreNullValues {
+			return 0, nil
+		}
+		return -1, fmt.Errorf("prometheus query %s returned NaN, no request was observed", s.metadata.Query)
+	}
+
+	return v, nil
+}
+
+// wrapQueryTimeoutError returns the error of a request abandoned at the deadline of the queryTimeout,
+// the query being retried on the next poll
+func (s *prometheusScaler) wrapQueryTimeoutError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) && s.metadata.queryTimeout > 0 {
+		return fmt.Errorf("prometheus query didn't complete within the queryTimeout of %s: %w", s.metadata.QueryTimeout, err)
+	}
+	return err
+}
+
+// formatPromWarnings returns the warnings to add to an error, as they may explain a missing result
+func formatPromWarnings(warnings []string) string {
+	if len(warnings) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (warnings: %s)", strings.Join(warnings, "; "))
+}
+
+// reduceRangeValues reduces the samples of a range query result to a single value, NaN samples are skipped
+func (s *prometheusScaler) reduceRangeValues(samples [][]interface{}) (float64, error) {
+	values := make([]float64, 0, len(samples))
+	for _, sample := range samples {
+		if len(sample) < 2 {
+			return -1, fmt.Errorf("prometheus query %s didn't return enough values", s.metadata.Query)
+		}
+		str, ok := sample[1].(string)
+		if !ok {
+			return -1, fmt.Errorf("prometheus query %s returned an invalid sample %v", s.metadata.Query, sample)
+		}
+		v, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			s.logger.Error(err, "Error converting prometheus value", "prometheus_value", str)
+			return -1, err
+		}
+		if !math.IsNaN(v) {
+			values = append(values, v)
+		}
+	}
+
+	if len(values) == 0 {
+		if s.metadata.IgnoreNullValues {
+			return 0, nil
+		}
+		return -1, fmt.Errorf("prometheus metrics 'prometheus' target may be lost, the range is empty")
+	}
+
+	var v float64
+	switch s.metadata.Reducer {
+	case prometheusReducerP50:
+		v = prometheusQuantile(0.5, values)
+	case prometheusReducerP95:
+		v = prometheusQuantile(0.95, values)
+	case prometheusReducerMax:
+		v = values[0]
+		for _, value := range values[1:] {
+			v = math.Max(v, value)
+		}
+	default:
+		for _, value := range values {
+			v += value
+		}
+		v /= float64(len(values))
+	}
+
+	if math.IsInf(v, 0) {
+		if s.metadata.IgnoreNullValues {
+			return 0, nil
+		}
+		err := fmt.Errorf("promtheus query returns %f", v)
+		s.logger.Error(err, "Error converting prometheus value")
+		return -1, err
+	}
+
 	return v, nil
 }
 
+// prometheusQuantile returns the q-quantile of the values using linear interpolation between the closest ranks,
+// the same way as the Prometheus quantile_over_time function
+func prometheusQuantile(q float64, values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	rank := q * float64(len(sorted)-1)
+	lower := math.Floor(rank)
+	upper := math.Ceil(rank)
+	weight := rank - lower
+	return sorted[int(lower)]*(1-weight) + sorted[int(upper)]*weight
+}
+
 func (s *prometheusScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
 	val, err := s.ExecutePromQuery(ctx)
 	if err != nil {
@@ -2,12 +2,15 @@ package scalers
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -18,6 +21,7 @@ import (
 	"golang.org/x/oauth2"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
 
@@ -60,10 +64,62 @@ var testPromMetadata = []parsePrometheusMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "customHeaders": "key1=value1,key2=value2"}, false},
 	// customHeaders with wrong format
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "customHeaders": "key1=value1,key2"}, true},
+	// tenantIDs
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "tenantIDs": "team-a|team-b"}, false},
+	// tenantIDs with a custom tenant header
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "tenantIDs": "team-a", "tenantHeader": "X-Tenant"}, false},
+	// tenantIDs with an empty tenant
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "tenantIDs": "team-a||team-b"}, true},
+	// tenantIDs with the tenant header also in customHeaders
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "tenantIDs": "team-a", "customHeaders": "x-scope-orgid=team-b"}, true},
 	// queryParameters
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "queryParameters": "key1=value1,key2=value2"}, false},
 	// queryParameters with wrong format
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "queryParameters": "key1=value1,key2"}, true},
+	// range query
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "queryType": "range", "window": "10m", "step": "1m", "reducer": "p95"}, false},
+	// range query with defaults
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "queryType": "range"}, false},
+	// unknown query type
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "queryType": "series"}, true},
+	// unknown reducer
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "queryType": "range", "reducer": "p99"}, true},
+	// malformed window
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "queryType": "range", "window": "five minutes"}, true},
+	// step greater than window
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "queryType": "range", "window": "1m", "step": "5m"}, true},
+	// range settings on an instant query
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "reducer": "max"}, true},
+	// histogram quantile of a mesh
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "200", "histogramQuantile": "0.95", "mesh": "istio", "service": "reviews"}, false},
+	// histogram quantile of a metric
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "200", "histogramQuantile": "0.99", "metricName": "http_request_duration_seconds", "labels": "job=api"}, false},
+	// histogram quantile and query
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "200", "histogramQuantile": "0.95", "mesh": "istio", "query": "up"}, true},
+	// histogram quantile out of range
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "200", "histogramQuantile": "95", "mesh": "istio"}, true},
+	// histogram quantile without metricName nor mesh
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "200", "histogramQuantile": "0.95"}, true},
+	// unknown mesh
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "200", "histogramQuantile": "0.95", "mesh": "consul"}, true},
+	// service without mesh
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "200", "histogramQuantile": "0.95", "metricName": "http_request_duration_seconds", "service": "reviews"}, true},
+	// malformed histogramWindow
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "200", "histogramQuantile": "0.95", "mesh": "linkerd", "histogramWindow": "five minutes"}, true},
+	// histogram settings on a query
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "mesh": "istio"}, true},
+	// queryTimeout and queryStats
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "queryTimeout": "10s", "queryStats": "true"}, false},
+	// malformed queryTimeout
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "queryTimeout": "10"}, true},
+	// negative queryTimeout
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "queryTimeout": "-1s"}, true},
+	// queryTimeOffset
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "queryTimeOffset": "5m"}, false},
+	// malformed queryTimeOffset
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "queryTimeOffset": "5"}, true},
+	// negative queryTimeOffset
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "queryTimeOffset": "-5m"}, true},
 }
 
 var prometheusMetricIdentifiers = []prometheusMetricIdentifier{
@@ -129,6 +185,72 @@ func TestPrometheusParseMetadata(t *testing.T) {
 	}
 }
 
+func TestPrometheusHistogramQuery(t *testing.T) {
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedQuery string
+	}{
+		{
+			name:          "istio service",
+			metadata:      map[string]string{"histogramQuantile": "0.95", "mesh": "istio", "service": "reviews"},
+			expectedQuery: `histogram_quantile(0.95, sum by (le) (rate(istio_request_duration_milliseconds_bucket{destination_service_name="reviews",reporter="destination"}[5m])))`,
+		},
+		{
+			name:          "linkerd deployment with labels and window",
+			metadata:      map[string]string{"histogramQuantile": "0.5", "mesh": "linkerd", "service": "web", "labels": "namespace=shop", "histogramWindow": "1m"},
+			expectedQuery: `histogram_quantile(0.5, sum by (le) (rate(response_latency_ms_bucket{deployment="web",direction="inbound",namespace="shop"}[1m])))`,
+		},
+		{
+			name:          "labels override the ones of the mesh",
+			metadata:      map[string]string{"histogramQuantile": "0.95", "mesh": "istio", "labels": "reporter=source"},
+			expectedQuery: `histogram_quantile(0.95, sum by (le) (rate(istio_request_duration_milliseconds_bucket{reporter="source"}[5m])))`,
+		},
+		{
+			name:          "bucket metric",
+			metadata:      map[string]string{"histogramQuantile": "0.999", "metricName": "http_request_duration_seconds_bucket"},
+			expectedQuery: `histogram_quantile(0.999, sum by (le) (rate(http_request_duration_seconds_bucket[5m])))`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["serverAddress"] = "http://localhost:9090"
+			tc.metadata["threshold"] = "200"
+			meta, err := parsePrometheusMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedQuery, meta.Query)
+		})
+	}
+}
+
+func TestPrometheusHistogramQuantileWithoutRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte(`{"data":{"result":[{"metric":{},"value":[1590000000.0,"NaN"]}]}}`))
+	}))
+	defer server.Close()
+
+	for _, ignoreNullValues := range []bool{true, false} {
+		meta, err := parsePrometheusMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{
+			"serverAddress":     server.URL,
+			"threshold":         "200",
+			"histogramQuantile": "0.95",
+			"mesh":              "istio",
+			"ignoreNullValues":  strconv.FormatBool(ignoreNullValues),
+		}})
+		require.NoError(t, err)
+		scaler := prometheusScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+		value, err := scaler.ExecutePromQuery(context.TODO())
+		if ignoreNullValues {
+			assert.NoError(t, err)
+			assert.Equal(t, float64(0), value)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+}
+
 func TestPrometheusGetMetricSpecForScaling(t *testing.T) {
 	for _, testData := range prometheusMetricIdentifiers {
 		meta, err := parsePrometheusMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
@@ -172,6 +294,34 @@ func TestPrometheusScalerAuthParams(t *testing.T) {
 	}
 }
 
+func TestPrometheusScalerTLSServerName(t *testing.T) {
+	testCases := []struct {
+		name       string
+		metadata   map[string]string
+		authParams map[string]string
+	}{
+		{"no auth", map[string]string{}, map[string]string{}},
+		{"auth with ca", map[string]string{"authModes": "basic"}, map[string]string{"username": "user", "password": "pass", "ca": "caaa"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata := map[string]string{"serverAddress": "https://10.0.0.5:9090", "query": "up", "threshold": "10", "tlsServerName": "prometheus.example.com"}
+			for k, v := range tc.metadata {
+				metadata[k] = v
+			}
+			s, err := NewPrometheusScaler(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, AuthParams: tc.authParams})
+			if err != nil {
+				t.Fatal("Could not create scaler:", err)
+			}
+			transport, ok := s.(*prometheusScaler).httpClient.Transport.(*http.Transport)
+			if assert.True(t, ok) {
+				assert.Equal(t, "prometheus.example.com", transport.TLSClientConfig.ServerName)
+				assert.False(t, transport.TLSClientConfig.InsecureSkipVerify)
+			}
+		})
+	}
+}
+
 type prometheusPromQueryResultTestData struct {
 	name             string
 	bodyStr          string
@@ -300,6 +450,33 @@ var testPromQueryResult = []prometheusPromQueryResultTestData{
 		ignoreNullValues: false,
 		unsafeSsl:        true,
 	},
+	{
+		name:             "partial response with warnings",
+		bodyStr:          `{"status":"success","data":{"result":[{"value": ["1", "4"]}]},"warnings":["receive-0: context deadline exceeded"]}`,
+		responseStatus:   http.StatusOK,
+		expectedValue:    4,
+		isError:          false,
+		ignoreNullValues: false,
+		unsafeSsl:        true,
+	},
+	{
+		name:             "empty partial response with warnings",
+		bodyStr:          `{"status":"success","data":{"result":[]},"warnings":["receive-0: context deadline exceeded"]}`,
+		responseStatus:   http.StatusOK,
+		expectedValue:    0,
+		isError:          false,
+		ignoreNullValues: true,
+		unsafeSsl:        true,
+	},
+	{
+		name:             "error status",
+		bodyStr:          `{"status":"error","errorType":"execution","error":"query timed out"}`,
+		responseStatus:   http.StatusOK,
+		expectedValue:    -1,
+		isError:          true,
+		ignoreNullValues: true,
+		unsafeSsl:        true,
+	},
 }
 
 func TestPrometheusScalerExecutePromQuery(t *testing.T) {
@@ -336,6 +513,82 @@ func TestPrometheusScalerExecutePromQuery(t *testing.T) {
 	}
 }
 
+func TestPrometheusScalerExecutePromRangeQuery(t *testing.T) {
+	// 20 samples from 1 to 20, with a NaN sample which is skipped
+	samples := make([]string, 0, 21)
+	for i := 1; i <= 20; i++ {
+		samples = append(samples, fmt.Sprintf(`[%d, "%d"]`, 1700000000+i*30, i))
+	}
+	samples = append(samples, `[1700000630, "NaN"]`)
+	body := fmt.Sprintf(`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[%s]}]}}`, strings.Join(samples, ","))
+
+	testCases := []struct {
+		name             string
+		reducer          string
+		body             string
+		ignoreNullValues bool
+		expectedValue    float64
+		isError          bool
+	}{
+		{name: "p50", reducer: "p50", body: body, expectedValue: 10.5},
+		{name: "p95", reducer: "p95", body: body, expectedValue: 19.05},
+		{name: "max", reducer: "max", body: body, expectedValue: 20},
+		{name: "avg", reducer: "avg", body: body, expectedValue: 10.5},
+		{name: "single sample", reducer: "p95", body: `{"data":{"result":[{"values":[[1700000000, "3"]]}]}}`, expectedValue: 3},
+		{name: "empty range", reducer: "avg", body: `{"data":{"result":[{"values":[]}]}}`, ignoreNullValues: true, expectedValue: 0},
+		{name: "empty range but shouldn't ignore", reducer: "avg", body: `{"data":{"result":[{"values":[]}]}}`, expectedValue: -1, isError: true},
+		{name: "only NaN samples", reducer: "max", body: `{"data":{"result":[{"values":[[1700000000, "NaN"]]}]}}`, ignoreNullValues: true, expectedValue: 0},
+		{name: "+Inf", reducer: "max", body: `{"data":{"result":[{"values":[[1700000000, "+Inf"]]}]}}`, expectedValue: -1, isError: true},
+		{name: "multiple series", reducer: "max", body: `{"data":{"result":[{"values":[]},{"values":[]}]}}`, expectedValue: -1, isError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var query url.Values
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				assert.Equal(t, "/api/v1/query_range", request.URL.Path)
+				query = request.URL.Query()
+				_, _ = writer.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			meta, err := parsePrometheusMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{
+				"serverAddress":    server.URL,
+				"threshold":        "10",
+				"query":            "sum(rate(http_requests_total[1m]))",
+				"queryType":        "range",
+				"window":           "10m",
+				"step":             "30s",
+				"reducer":          tc.reducer,
+				"ignoreNullValues": strconv.FormatBool(tc.ignoreNullValues),
+			}})
+			assert.NoError(t, err)
+
+			scaler := prometheusScaler{
+				metadata:   meta,
+				httpClient: http.DefaultClient,
+				logger:     logr.Discard(),
+			}
+
+			value, err := scaler.ExecutePromQuery(context.TODO())
+			if tc.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.InDelta(t, tc.expectedValue, value, 1e-9)
+
+			assert.Equal(t, "sum(rate(http_requests_total[1m]))", query.Get("query"))
+			assert.Equal(t, "30", query.Get("step"))
+			start, err := time.Parse(time.RFC3339, query.Get("start"))
+			assert.NoError(t, err)
+			end, err := time.Parse(time.RFC3339, query.Get("end"))
+			assert.NoError(t, err)
+			assert.Equal(t, 10*time.Minute, end.Sub(start))
+		})
+	}
+}
+
 func TestPrometheusScalerCustomHeaders(t *testing.T) {
 	testData := prometheusPromQueryResultTestData{
 		name:             "no values",
@@ -376,6 +629,71 @@ func TestPrometheusScalerCustomHeaders(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestPrometheusScalerTenantHeader(t *testing.T) {
+	testCases := []struct {
+		name           string
+		metadata       map[string]string
+		expectedHeader string
+		expectedValue  string
+	}{
+		{
+			name:           "single tenant",
+			metadata:       map[string]string{"tenantIDs": "team-a"},
+			expectedHeader: "X-Scope-OrgID",
+			expectedValue:  "team-a",
+		},
+		{
+			name:           "multiple tenants",
+			metadata:       map[string]string{"tenantIDs": "team-a| team-b |team-c"},
+			expectedHeader: "X-Scope-OrgID",
+			expectedValue:  "team-a|team-b|team-c",
+		},
+		{
+			name:           "custom tenant header",
+			metadata:       map[string]string{"tenantIDs": "team-a|team-b", "tenantHeader": "X-Tenant"},
+			expectedHeader: "X-Tenant",
+			expectedValue:  "team-a|team-b",
+		},
+		{
+			name:           "no tenants",
+			metadata:       map[string]string{},
+			expectedHeader: "X-Scope-OrgID",
+			expectedValue:  "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Mimir serves the Prometheus API of its query-frontend under the /prometheus prefix
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				assert.Equal(t, "/prometheus/api/v1/query", request.URL.Path)
+				assert.Equal(t, testCase.expectedValue, request.Header.Get(testCase.expectedHeader))
+
+				writer.WriteHeader(http.StatusOK)
+				if _, err := writer.Write([]byte(`{"data":{"result":[{"value": ["1", "2"]}]}}`)); err != nil {
+					t.Fatal(err)
+				}
+			}))
+			defer server.Close()
+
+			metadata := map[string]string{"serverAddress": server.URL + "/prometheus", "threshold": "100", "query": "up"}
+			for key, value := range testCase.metadata {
+				metadata[key] = value
+			}
+			meta, err := parsePrometheusMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: metadata})
+			require.NoError(t, err)
+
+			scaler := prometheusScaler{
+				metadata:   meta,
+				httpClient: http.DefaultClient,
+			}
+			value, err := scaler.ExecutePromQuery(context.TODO())
+			require.NoError(t, err)
+			assert.Equal(t, float64(2), value)
+		})
+	}
+}
+
 func TestPrometheusScalerExecutePromQueryParameters(t *testing.T) {
 	testData := prometheusPromQueryResultTestData{
 		name:             "no values",
@@ -419,6 +737,30 @@ func TestPrometheusScalerExecutePromQueryParameters(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestPrometheusScalerThanosPartialResponse(t *testing.T) {
+	meta, err := parsePrometheusMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"serverAddress": "http://thanos-query:9090", "threshold": "100", "query": "up", "queryParameters": "partial_response=false,dedup=true", "ignoreNullValues": "false"},
+	})
+	require.NoError(t, err)
+
+	var requestedParameters url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requestedParameters = request.URL.Query()
+		_, _ = writer.Write([]byte(`{"status":"success","data":{"result":[]},"warnings":["store-0: no such host"]}`))
+	}))
+	defer server.Close()
+	meta.ServerAddress = server.URL
+
+	scaler := prometheusScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+	_, err = scaler.ExecutePromQuery(context.TODO())
+
+	assert.Equal(t, "false", requestedParameters.Get("partial_response"))
+	assert.Equal(t, "true", requestedParameters.Get("dedup"))
+	assert.Equal(t, "up", requestedParameters.Get("query"))
+	// the warnings explain why the result is empty
+	assert.ErrorContains(t, err, "the result is empty (warnings: store-0: no such host)")
+}
+
 func TestPrometheusScaler_ExecutePromQuery_WithGCPNativeAuthentication(t *testing.T) {
 	fakeGoogleOAuthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintln(w, `{"token_type": "Bearer", "access_token": "fake_access_token"}`)
@@ -544,3 +886,163 @@ IisErx3ap2o99Zn+Yotv/TGZkS+lfMLdbcOBr8a57Q==
 		})
 	}
 }
+
+func TestPrometheusScalerQueryTimeoutAndStats(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = writer.Write([]byte(`{"data":{"result":[{"metric":{},"value":[1590000000.0,"7"]}],"stats":{"samples":{"totalQueryableSamples":120,"peakSamples":12}}}}`))
+	}))
+	defer server.Close()
+
+	meta, err := parsePrometheusMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{
+		"serverAddress": server.URL,
+		"threshold":     "10",
+		"query":         "up",
+		"queryTimeout":  "2500ms",
+		"queryStats":    "true",
+	}})
+	require.NoError(t, err)
+	scaler := prometheusScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+	value, err := scaler.ExecutePromQuery(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, float64(7), value)
+	assert.Equal(t, "2.5", query.Get("timeout"))
+	assert.Equal(t, "all", query.Get("stats"))
+}
+
+func TestPrometheusScalerQueryTimeOffset(t *testing.T) {
+	testCases := []struct {
+		name                string
+		queryType           string
+		activationThreshold string
+		value               string
+		isActive            bool
+	}{
+		{"instant query", "instant", "5", "7", true},
+		{"instant query below activation", "instant", "7", "7", false},
+		{"range query", "range", "5", "7", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var query url.Values
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+				query = r.URL.Query()
+				if tc.queryType == "range" {
+					_, _ = writer.Write([]byte(fmt.Sprintf(`{"data":{"resultType":"matrix","result":[{"metric":{},"values":[[1590000000.0,%q]]}]}}`, tc.value)))
+					return
+				}
+				_, _ = writer.Write([]byte(fmt.Sprintf(`{"data":{"result":[{"metric":{},"value":[1590000000.0,%q]}]}}`, tc.value)))
+			}))
+			defer server.Close()
+
+			meta, err := parsePrometheusMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{
+				"serverAddress":       server.URL,
+				"threshold":           "10",
+				"activationThreshold": tc.activationThreshold,
+				"query":               "up",
+				"queryType":           tc.queryType,
+				"queryTimeOffset":     "10m",
+			}})
+			require.NoError(t, err)
+			scaler := prometheusScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+			before := time.Now().UTC().Add(-10 * time.Minute).Truncate(time.Second)
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.TODO(), "s0-prometheus")
+			after := time.Now().UTC().Add(-10 * time.Minute)
+			require.NoError(t, err)
+
+			timeParameter := "time"
+			if tc.queryType == "range" {
+				timeParameter = "end"
+			}
+			evaluationTime, err := time.Parse(time.RFC3339, query.Get(timeParameter))
+			require.NoError(t, err)
+			assert.False(t, evaluationTime.Before(before), "evaluation time %s before %s", evaluationTime, before)
+			assert.False(t, evaluationTime.After(after), "evaluation time %s after %s", evaluationTime, after)
+
+			value, _ := strconv.ParseFloat(tc.value, 64)
+			assert.Equal(t, value, metrics[0].Value.AsApproximateFloat64())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
+
+func TestPrometheusScalerQueryTimeoutSlowServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+		_, _ = writer.Write([]byte(`{"data":{"result":[{"metric":{},"value":[1590000000.0,"7"]}]}}`))
+	}))
+	defer server.Close()
+
+	meta, err := parsePrometheusMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{
+		"serverAddress": server.URL,
+		"threshold":     "10",
+		"query":         "up",
+		"queryTimeout":  "100ms",
+	}})
+	require.NoError(t, err)
+	scaler := prometheusScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+	start := time.Now()
+	_, err = scaler.ExecutePromQuery(context.TODO())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "prometheus query didn't complete within the queryTimeout of 100ms")
+	assert.Less(t, time.Since(start), 100*time.Millisecond+prometheusQueryTimeoutGrace+time.Second)
+}
+
+func TestPrometheusScalerTLSConfigSharedAcrossTriggers(t *testing.T) {
+	parsings := 0
+	newPrometheusTLSConfigOriginal := newPrometheusTLSConfig
+	newPrometheusTLSConfig = func(auth *authentication.AuthMeta, unsafeSsl bool) (*tls.Config, error) {
+		parsings++
+		return newPrometheusTLSConfigOriginal(auth, unsafeSsl)
+	}
+	defer func() { newPrometheusTLSConfig = newPrometheusTLSConfigOriginal }()
+
+	tlsConfigCache := scalersconfig.NewTLSConfigCache()
+	sharedAuth := map[string]string{"bearerToken": "tooooken", "ca": serverRootCA}
+	buildScaler := func(triggerIndex int, authParams map[string]string, serverName string) *prometheusScaler {
+		s, err := NewPrometheusScaler(&scalersconfig.ScalerConfig{
+			TriggerMetadata: map[string]string{"serverAddress": "https://localhost:9090", "threshold": "100", "query": "up", "authModes": "bearer", "tlsServerName": serverName},
+			AuthParams:      authParams,
+			TriggerIndex:    triggerIndex,
+			TLSConfigCache:  tlsConfigCache,
+		})
+		require.NoError(t, err)
+		return s.(*prometheusScaler)
+	}
+	tlsClientConfig := func(s *prometheusScaler) *tls.Config {
+		return s.httpClient.Transport.(*http.Transport).TLSClientConfig
+	}
+
+	first := buildScaler(0, sharedAuth, "prometheus-a.monitoring.svc")
+	second := buildScaler(1, sharedAuth, "prometheus-b.monitoring.svc")
+	third := buildScaler(2, sharedAuth, "")
+	assert.Equal(t, 1, parsings, "the certificates of the shared authentication should be parsed once")
+
+	// every trigger gets its own copy of the parsed config, sharing the parsed certificates
+	assert.Equal(t, "prometheus-a.monitoring.svc", tlsClientConfig(first).ServerName)
+	assert.Equal(t, "prometheus-b.monitoring.svc", tlsClientConfig(second).ServerName)
+	assert.Equal(t, "", tlsClientConfig(third).ServerName)
+	assert.Same(t, tlsClientConfig(first).RootCAs, tlsClientConfig(second).RootCAs)
+
+	// a different authentication is parsed on its own
+	buildScaler(3, map[string]string{"bearerToken": "tooooken", "ca": clientCert}, "")
+	assert.Equal(t, 2, parsings)
+
+	// without a cache, as when the scaler is built on its own, the certificates are parsed every time
+	for i := 0; i < 2; i++ {
+		_, err := NewPrometheusScaler(&scalersconfig.ScalerConfig{
+			TriggerMetadata: map[string]string{"serverAddress": "https://localhost:9090", "threshold": "100", "query": "up", "authModes": "bearer"},
+			AuthParams:      sharedAuth,
+		})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 4, parsings)
+}
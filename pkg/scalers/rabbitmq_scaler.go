@@ -3,6 +3,7 @@ package scalers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -37,6 +38,7 @@ const (
 	rabbitModeUnknown                      = "Unknown"
 	rabbitModeQueueLength                  = "QueueLength"
 	rabbitModeMessageRate                  = "MessageRate"
+	rabbitModeNetRate                      = "NetRate"
 	defaultRabbitMQQueueLength             = 20
 	rabbitMetricType                       = "External"
 	rabbitRootVhostPath                    = "/%2F"
@@ -51,6 +53,11 @@ const (
 	defaultProtocol = autoProtocol
 )
 
+const (
+	rabbitVhostErrorPolicyFail = "fail"
+	rabbitVhostErrorPolicySkip = "skip"
+)
+
 const (
 	sumOperation     = "sum"
 	avgOperation     = "avg"
@@ -72,12 +79,12 @@ type rabbitMQMetadata struct {
 	connectionName string // name used for the AMQP connection
 	triggerIndex   int    // scaler index
 
-	QueueName string `keda:"name=queueName,                       order=triggerMetadata"`
-	// QueueLength or MessageRate
+	QueueName string `keda:"name=queueName,                       order=triggerMetadata, optional"`
+	// QueueLength, MessageRate or NetRate
 	Mode string `keda:"name=mode,                                 order=triggerMetadata, optional, default=Unknown"`
 	//
 	QueueLength float64 `keda:"name=queueLength,                  order=triggerMetadata, optional"`
-	// trigger value (queue length or publish/sec. rate)
+	// trigger value (queue length, publish/sec. rate or publish/sec. minus ack/sec. rate)
 	Value float64 `keda:"name=value,                              order=triggerMetadata, optional"`
 	// activation value
 	ActivationValue float64 `keda:"name=activationValue,          order=triggerMetadata, optional"`
@@ -87,6 +94,10 @@ type rabbitMQMetadata struct {
 	Protocol string `keda:"name=protocol,                         order=triggerMetadata;authParams, default=auto"`
 	// override the vhost from the connection info
 	VhostName string `keda:"name=vhostName,                       order=triggerMetadata, optional"`
+	// vhost:queueName pairs whose queues are combined with the operation, instead of queueName
+	VhostQueues []string `keda:"name=vhostQueues,                 order=triggerMetadata, optional"`
+	// whether an unavailable vhost of vhostQueues fails the poll or is skipped
+	VhostErrorPolicy string `keda:"name=vhostErrorPolicy,         order=triggerMetadata, enum=fail;skip, default=fail"`
 	// specify if the queueName contains a rexeg
 	UseRegex bool `keda:"name=useRegex,                           order=triggerMetadata, optional"`
 	// specify if the QueueLength value should exclude Unacknowledged messages (Ready messages only)
@@ -114,6 +125,15 @@ type rabbitMQMetadata struct {
 	workloadIdentityClientID      string
 	workloadIdentityTenantID      string
 	workloadIdentityAuthorityHost string
+
+	// parsed from VhostQueues
+	vhostQueues []rabbitMQVhostQueue
+}
+
+// rabbitMQVhostQueue is a queue of a vhost of the vhostQueues of the trigger
+type rabbitMQVhostQueue struct {
+	vhost     string
+	queueName string
 }
 
 func (r *rabbitMQMetadata) Validate() error {
@@ -168,6 +188,10 @@ func (r *rabbitMQMetadata) Validate() error {
 		return fmt.Errorf("configure excludeUnacknowledged=true with http protocol only")
 	}
 
+	if err := r.validateVhostQueues(); err != nil {
+		return err
+	}
+
 	if err := r.validateTrigger(); err != nil {
 		return err
 	}
@@ -175,6 +199,34 @@ func (r *rabbitMQMetadata) Validate() error {
 	return nil
 }
 
+// validateVhostQueues checks that either queueName or vhostQueues is given and parses the vhost:queueName pairs
+// of vhostQueues, the vhost ending at the first colon
+func (r *rabbitMQMetadata) validateVhostQueues() error {
+	if len(r.VhostQueues) == 0 {
+		if r.QueueName == "" {
+			return fmt.Errorf("either queueName or vhostQueues must be specified")
+		}
+		return nil
+	}
+
+	if r.QueueName != "" || r.VhostName != "" {
+		return fmt.Errorf("vhostQueues can't be used with queueName or vhostName")
+	}
+	if r.Protocol != httpProtocol {
+		return fmt.Errorf("configure vhostQueues with http protocol only")
+	}
+
+	r.vhostQueues = make([]rabbitMQVhostQueue, 0, len(r.VhostQueues))
+	for _, pair := range r.VhostQueues {
+		vhost, queueName, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if !found || vhost == "" || queueName == "" {
+			return fmt.Errorf("vhostQueues entry %q must be formatted as vhost:queueName", pair)
+		}
+		r.vhostQueues = append(r.vhostQueues, rabbitMQVhostQueue{vhost: vhost, queueName: queueName})
+	}
+	return nil
+}
+
 func (r *rabbitMQMetadata) validateTrigger() error {
 	// If nothing is specified for the trigger then return the default
 	if r.QueueLength == 0 && r.Mode == rabbitModeUnknown && r.Value == 0 {
@@ -202,12 +254,12 @@ func (r *rabbitMQMetadata) validateTrigger() error {
 		return fmt.Errorf("%s must be specified", rabbitValueTriggerConfigName)
 	}
 
-	if r.Mode != rabbitModeQueueLength && r.Mode != rabbitModeMessageRate {
-		return fmt.Errorf("trigger mode %s must be one of %s, %s", r.Mode, rabbitModeQueueLength, rabbitModeMessageRate)
+	if r.Mode != rabbitModeQueueLength && r.Mode != rabbitModeMessageRate && r.Mode != rabbitModeNetRate {
+		return fmt.Errorf("trigger mode %s must be one of %s, %s, %s", r.Mode, rabbitModeQueueLength, rabbitModeMessageRate, rabbitModeNetRate)
 	}
 
-	if r.Mode == rabbitModeMessageRate && r.Protocol != httpProtocol {
-		return fmt.Errorf("protocol %s not supported; must be http to use mode %s", r.Protocol, rabbitModeMessageRate)
+	if (r.Mode == rabbitModeMessageRate || r.Mode == rabbitModeNetRate) && r.Protocol != httpProtocol {
+		return fmt.Errorf("protocol %s not supported; must be http to use mode %s", r.Protocol, r.Mode)
 	}
 
 	if r.Protocol == amqpProtocol && r.TimeoutMs != 0 {
@@ -235,12 +287,23 @@ type regexQueueInfo struct {
 
 type messageStat struct {
 	PublishDetail publishDetail `json:"publish_details"`
+	AckDetail     ackDetail     `json:"ack_details"`
 }
 
 type publishDetail struct {
 	Rate float64 `json:"rate"`
 }
 
+type ackDetail struct {
+	Rate float64 `json:"rate"`
+}
+
+// getNetRate returns the rate at which the messages accumulate in the queue, i.e. the publish rate minus the
+// ack rate, a queue consumed as fast as or faster than it's published to having no accumulation
+func (m messageStat) getNetRate() float64 {
+	return max(m.PublishDetail.Rate-m.AckDetail.Rate, 0)
+}
+
 // NewRabbitMQScaler creates a new rabbitMQ scaler
 func NewRabbitMQScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	s := &rabbitMQScaler{}
@@ -375,6 +438,8 @@ func (s *rabbitMQScaler) Close(context.Context) error {
 	return nil
 }
 
+// getQueueStatus returns the messages count and the rate of the mode, i.e. the net rate in NetRate mode
+// and the publish rate otherwise
 func (s *rabbitMQScaler) getQueueStatus(ctx context.Context) (int64, float64, error) {
 	if s.metadata.Protocol == httpProtocol {
 		info, err := s.getQueueInfoViaHTTP(ctx)
@@ -382,12 +447,17 @@ func (s *rabbitMQScaler) getQueueStatus(ctx context.Context) (int64, float64, er
 			return -1, -1, err
 		}
 
+		rate := info.MessageStat.PublishDetail.Rate
+		if s.metadata.Mode == rabbitModeNetRate {
+			rate = info.MessageStat.getNetRate()
+		}
+
 		if s.metadata.ExcludeUnacknowledged {
 			// messages count includes only ready
-			return int64(info.MessagesReady), info.MessageStat.PublishDetail.Rate, nil
+			return int64(info.MessagesReady), rate, nil
 		}
 		// messages count includes count of ready and unack-ed
-		return int64(info.Messages), info.MessageStat.PublishDetail.Rate, nil
+		return int64(info.Messages), rate, nil
 	}
 
 	// QueueDeclarePassive assumes that the queue exists and fails if it doesn't
@@ -468,13 +538,48 @@ func getVhostAndPathFromURL(rawPath, vhostName string) (resolvedVhostPath, resol
 }
 
 func (s *rabbitMQScaler) getQueueInfoViaHTTP(ctx context.Context) (*queueInfo, error) {
+	if len(s.metadata.vhostQueues) > 0 {
+		return s.getVhostQueuesInfoViaHTTP(ctx)
+	}
+	return s.getVhostQueueInfoViaHTTP(ctx, s.metadata.VhostName, s.metadata.QueueName)
+}
+
+// getVhostQueuesInfoViaHTTP combines the queues of all the vhostQueues with the operation, an unavailable vhost
+// being skipped with the skip policy as long as one of them is available
+func (s *rabbitMQScaler) getVhostQueuesInfoViaHTTP(ctx context.Context) (*queueInfo, error) {
+	infos := make([]queueInfo, 0, len(s.metadata.vhostQueues))
+	var errs []error
+	for _, vhostQueue := range s.metadata.vhostQueues {
+		info, err := s.getVhostQueueInfoViaHTTP(ctx, vhostQueue.vhost, vhostQueue.queueName)
+		if err != nil {
+			if s.metadata.VhostErrorPolicy == rabbitVhostErrorPolicyFail {
+				return nil, fmt.Errorf("error getting queue %s of vhost %s: %w", vhostQueue.queueName, vhostQueue.vhost, err)
+			}
+			s.logger.V(1).Info("Skipping unavailable vhost", "vhost", vhostQueue.vhost, "queueName", vhostQueue.queueName, "error", s.anonymizeRabbitMQError(err))
+			errs = append(errs, fmt.Errorf("vhost %s: %w", vhostQueue.vhost, err))
+			continue
+		}
+		infos = append(infos, *info)
+	}
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("no vhost is available: %w", errors.Join(errs...))
+	}
+
+	info, err := getComposedQueue(s, infos)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func (s *rabbitMQScaler) getVhostQueueInfoViaHTTP(ctx context.Context, vhostName, queueName string) (*queueInfo, error) {
 	parsedURL, err := url.Parse(s.metadata.Host)
 
 	if err != nil {
 		return nil, err
 	}
 
-	vhost, subpaths := getVhostAndPathFromURL(parsedURL.Path, s.metadata.VhostName)
+	vhost, subpaths := getVhostAndPathFromURL(parsedURL.Path, vhostName)
 	parsedURL.Path = subpaths
 
 	if s.metadata.Username != "" && s.metadata.Password != "" {
@@ -483,9 +588,9 @@ func (s *rabbitMQScaler) getQueueInfoViaHTTP(ctx context.Context) (*queueInfo, e
 
 	var getQueueInfoManagementURI string
 	if s.metadata.UseRegex {
-		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s?page=1&use_regex=true&pagination=false&name=%s&page_size=%d", parsedURL.String(), vhost, url.QueryEscape(s.metadata.QueueName), s.metadata.PageSize)
+		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s?page=1&use_regex=true&pagination=false&name=%s&page_size=%d", parsedURL.String(), vhost, url.QueryEscape(queueName), s.metadata.PageSize)
 	} else {
-		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s/%s", parsedURL.String(), vhost, url.QueryEscape(s.metadata.QueueName))
+		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s/%s", parsedURL.String(), vhost, url.QueryEscape(queueName))
 	}
 
 	var info queueInfo
@@ -500,9 +605,17 @@ func (s *rabbitMQScaler) getQueueInfoViaHTTP(ctx context.Context) (*queueInfo, e
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
 func (s *rabbitMQScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	queueName := url.QueryEscape(s.metadata.QueueName)
+	if len(s.metadata.vhostQueues) > 0 {
+		names := make([]string, 0, len(s.metadata.vhostQueues))
+		for _, vhostQueue := range s.metadata.vhostQueues {
+			names = append(names, url.QueryEscape(vhostQueue.vhost)+"-"+url.QueryEscape(vhostQueue.queueName))
+		}
+		queueName = strings.Join(names, "-")
+	}
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("rabbitmq-%s", url.QueryEscape(s.metadata.QueueName)))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("rabbitmq-%s", queueName))),
 		},
 		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
 	}
@@ -515,19 +628,24 @@ func (s *rabbitMQScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpe
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *rabbitMQScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	messages, publishRate, err := s.getQueueStatus(ctx)
+	messages, rate, err := s.getQueueStatus(ctx)
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, false, s.anonymizeRabbitMQError(err)
 	}
 
 	var metric external_metrics.ExternalMetricValue
 	var isActive bool
-	if s.metadata.Mode == rabbitModeQueueLength {
+	switch s.metadata.Mode {
+	case rabbitModeQueueLength:
 		metric = GenerateMetricInMili(metricName, float64(messages))
 		isActive = float64(messages) > s.metadata.ActivationValue
-	} else {
-		metric = GenerateMetricInMili(metricName, publishRate)
-		isActive = publishRate > s.metadata.ActivationValue || float64(messages) > s.metadata.ActivationValue
+	case rabbitModeNetRate:
+		// the depth of the queue doesn't matter, only whether the messages accumulate
+		metric = GenerateMetricInMili(metricName, rate)
+		isActive = rate > s.metadata.ActivationValue
+	default:
+		metric = GenerateMetricInMili(metricName, rate)
+		isActive = rate > s.metadata.ActivationValue || float64(messages) > s.metadata.ActivationValue
 	}
 
 	return []external_metrics.ExternalMetricValue{metric}, isActive, nil
@@ -540,54 +658,67 @@ func getComposedQueue(s *rabbitMQScaler, q []queueInfo) (queueInfo, error) {
 	if len(q) > 0 {
 		switch s.metadata.Operation {
 		case sumOperation:
-			sumMessages, sumReady, sumRate := getSum(q)
+			sumMessages, sumReady, sumRate, sumAckRate := getSum(q)
 			queue.Messages = sumMessages
 			queue.MessagesReady = sumReady
 			queue.MessageStat.PublishDetail.Rate = sumRate
+			queue.MessageStat.AckDetail.Rate = sumAckRate
 		case avgOperation:
-			avgMessages, avgReady, avgRate := getAverage(q)
+			avgMessages, avgReady, avgRate, avgAckRate := getAverage(q)
 			queue.Messages = avgMessages
 			queue.MessagesReady = avgReady
 			queue.MessageStat.PublishDetail.Rate = avgRate
+			queue.MessageStat.AckDetail.Rate = avgAckRate
 		case maxOperation:
-			maxMessages, maxReady, maxRate := getMaximum(q)
+			maxMessages, maxReady, maxRate, maxNetRateStat := getMaximum(q)
 			queue.Messages = maxMessages
 			queue.MessagesReady = maxReady
 			queue.MessageStat.PublishDetail.Rate = maxRate
+			// the ack rate of the queue accumulating the fastest, so the composed net rate is the max net rate
+			queue.MessageStat.AckDetail.Rate = maxRate - maxNetRateStat.getNetRate()
 		default:
 			return queue, fmt.Errorf("operation mode %s must be one of %s, %s, %s", s.metadata.Operation, sumOperation, avgOperation, maxOperation)
 		}
 	} else {
 		queue.Messages = 0
 		queue.MessageStat.PublishDetail.Rate = 0
+		queue.MessageStat.AckDetail.Rate = 0
 	}
 
 	return queue, nil
 }
 
-func getSum(q []queueInfo) (int, int, float64) {
+func getSum(q []queueInfo) (int, int, float64, float64) {
 	var sumMessages int
 	var sumMessagesReady int
 	var sumRate float64
+	var sumAckRate float64
 	for _, value := range q {
 		sumMessages += value.Messages
 		sumMessagesReady += value.MessagesReady
 		sumRate += value.MessageStat.PublishDetail.Rate
+		sumAckRate += value.MessageStat.AckDetail.Rate
 	}
-	return sumMessages, sumMessagesReady, sumRate
+	return sumMessages, sumMessagesReady, sumRate, sumAckRate
 }
 
-func getAverage(q []queueInfo) (int, int, float64) {
-	sumMessages, sumReady, sumRate := getSum(q)
+func getAverage(q []queueInfo) (int, int, float64, float64) {
+	sumMessages, sumReady, sumRate, sumAckRate := getSum(q)
 	length := len(q)
-	return sumMessages / length, sumReady / length, sumRate / float64(length)
+	return sumMessages / length, sumReady / length, sumRate / float64(length), sumAckRate / float64(length)
 }
 
-func getMaximum(q []queueInfo) (int, int, float64) {
+// getMaximum returns the max messages, ready messages and publish rate, and the message stats of the queue
+// with the max net rate
+func getMaximum(q []queueInfo) (int, int, float64, messageStat) {
 	var maxMessages int
 	var maxReady int
 	var maxRate float64
+	var maxNetRateStat messageStat
 	for _, value := range q {
+		if value.MessageStat.getNetRate() > maxNetRateStat.getNetRate() {
+			maxNetRateStat = value.MessageStat
+		}
 		if value.Messages > maxMessages {
 			maxMessages = value.Messages
 		}
@@ -598,7 +729,7 @@ func getMaximum(q []queueInfo) (int, int, float64) {
 			maxRate = value.MessageStat.PublishDetail.Rate
 		}
 	}
-	return maxMessages, maxReady, maxRate
+	return maxMessages, maxReady, maxRate, maxNetRateStat
 }
 
 // Mask host for log purposes
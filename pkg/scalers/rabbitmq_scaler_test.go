@@ -2,6 +2,7 @@ package scalers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -105,6 +106,10 @@ var testRabbitMQMetadata = []parseRabbitMQMetadataTestData{
 	{map[string]string{"mode": "MessageRate", "value": "1000", "queueName": "sample", "host": "http://"}, false, map[string]string{}},
 	// message rate amqp
 	{map[string]string{"mode": "MessageRate", "value": "1000", "queueName": "sample", "host": "https://"}, false, map[string]string{}},
+	// net rate amqp
+	{map[string]string{"mode": "NetRate", "value": "10", "queueName": "sample", "host": "amqps://"}, true, map[string]string{}},
+	// net rate http
+	{map[string]string{"mode": "NetRate", "value": "10", "activationValue": "1", "queueName": "sample", "host": "https://"}, false, map[string]string{}},
 	// amqp host and useRegex
 	{map[string]string{"queueName": "sample", "host": "amqps://", "useRegex": "true"}, true, map[string]string{}},
 	// http host and useRegex
@@ -139,6 +144,20 @@ var testRabbitMQMetadata = []parseRabbitMQMetadataTestData{
 	{map[string]string{"queueName": "sample", "host": "https://", "unsafeSsl": "true"}, false, map[string]string{}},
 	// unsafeSsl wrong input
 	{map[string]string{"queueName": "sample", "host": "https://", "unsafeSsl": "random"}, true, map[string]string{}},
+	// vhostQueues
+	{map[string]string{"vhostQueues": "tenant-a:orders, tenant-b:orders,/:orders", "host": "http://"}, false, map[string]string{}},
+	// vhostQueues with skip policy
+	{map[string]string{"vhostQueues": "tenant-a:orders,tenant-b:orders", "vhostErrorPolicy": "skip", "host": "http://"}, false, map[string]string{}},
+	// invalid vhostErrorPolicy
+	{map[string]string{"vhostQueues": "tenant-a:orders", "vhostErrorPolicy": "ignore", "host": "http://"}, true, map[string]string{}},
+	// vhostQueues entry without queue
+	{map[string]string{"vhostQueues": "tenant-a:orders,tenant-b", "host": "http://"}, true, map[string]string{}},
+	// vhostQueues and queueName
+	{map[string]string{"vhostQueues": "tenant-a:orders", "queueName": "sample", "host": "http://"}, true, map[string]string{}},
+	// vhostQueues and vhostName
+	{map[string]string{"vhostQueues": "tenant-a:orders", "vhostName": "tenant-a", "host": "http://"}, true, map[string]string{}},
+	// vhostQueues and amqp
+	{map[string]string{"vhostQueues": "tenant-a:orders", "host": "amqp://"}, true, map[string]string{}},
 }
 
 var testRabbitMQAuthParamData = []parseRabbitMQAuthParamTestData{
@@ -175,6 +194,7 @@ var testRabbitMQAuthParamData = []parseRabbitMQAuthParamTestData{
 var rabbitMQMetricIdentifiers = []rabbitMQMetricIdentifier{
 	{&testRabbitMQMetadata[1], 0, "s0-rabbitmq-sample"},
 	{&testRabbitMQMetadata[7], 1, "s1-rabbitmq-namespace-2Fname"},
+	{&testRabbitMQMetadata[46], 2, "s2-rabbitmq-tenant-a-orders-tenant-b-orders--2F-orders"},
 }
 
 func TestRabbitMQParseMetadata(t *testing.T) {
@@ -291,6 +311,11 @@ var testQueueInfoTestData = []getQueueInfoTestData{
 	{response: `{"messages": 1, "messages_unacknowledged": 1, "message_stats": {"publish_details": {"rate": 1.4}}, "name": "evaluate_trials"}`, responseStatus: http.StatusOK, isActive: true, extraMetadata: map[string]string{"value": "100", "mode": "MessageRate"}},
 	{response: `{"messages": 1, "messages_unacknowledged": 0, "message_stats": {"publish_details": {"rate": 1.4}}, "name": "evaluate_trials"}`, responseStatus: http.StatusOK, isActive: true, extraMetadata: map[string]string{"value": "100", "mode": "MessageRate"}},
 	{response: `{"messages": 0, "messages_unacknowledged": 0, "message_stats": {"publish_details": {"rate": 1.4}}, "name": "evaluate_trials"}`, responseStatus: http.StatusOK, isActive: true, extraMetadata: map[string]string{"value": "100", "mode": "MessageRate"}},
+	// mode NetRate
+	{response: `{"messages": 0, "messages_unacknowledged": 0, "message_stats": {"publish_details": {"rate": 5}, "ack_details": {"rate": 3}}, "name": "evaluate_trials"}`, responseStatus: http.StatusOK, isActive: true, extraMetadata: map[string]string{"value": "10", "mode": "NetRate"}},
+	{response: `{"messages": 1000, "messages_unacknowledged": 0, "message_stats": {"publish_details": {"rate": 5}, "ack_details": {"rate": 5}}, "name": "evaluate_trials"}`, responseStatus: http.StatusOK, extraMetadata: map[string]string{"value": "10", "mode": "NetRate"}},
+	{response: `{"messages": 1000, "messages_unacknowledged": 0, "message_stats": {"publish_details": {"rate": 5}, "ack_details": {"rate": 8}}, "name": "evaluate_trials"}`, responseStatus: http.StatusOK, extraMetadata: map[string]string{"value": "10", "mode": "NetRate"}},
+	{response: `{"messages": 0, "messages_unacknowledged": 0, "message_stats": {"publish_details": {"rate": 5}, "ack_details": {"rate": 3}}, "name": "evaluate_trials"}`, responseStatus: http.StatusOK, extraMetadata: map[string]string{"value": "10", "activationValue": "2", "mode": "NetRate"}},
 	// error response
 	{response: `Password is incorrect`, responseStatus: http.StatusUnauthorized},
 }
@@ -491,6 +516,70 @@ var testRegexQueueInfoTestData = []getQueueInfoTestData{
 	{response: `{"items":[{"messages": 0, "messages_unacknowledged": 1, "message_stats": {"publish_details": {"rate": 4}}, "name": "evaluate_trial2"}]}`, responseStatus: http.StatusOK, isActive: true, extraMetadata: map[string]string{"mode": "MessageRate", "value": "1000", "useRegex": "true", "operation": "avg"}},
 	{response: `{"items":[{"messages": 0, "messages_unacknowledged": 1, "message_stats": {"publish_details": {"rate": 0}}, "name": "evaluate_trials"},{"messages": 0, "messages_unacknowledged": 1, "message_stats": {"publish_details": {"rate": 0}}, "name": "evaluate_trial2"}]}`, responseStatus: http.StatusOK, extraMetadata: map[string]string{"mode": "MessageRate", "value": "1000", "useRegex": "true", "operation": "avg"}},
 	{response: `{"items":[]}`, responseStatus: http.StatusOK, extraMetadata: map[string]string{"mode": "MessageRate", "value": "1000", "useRegex": "true", "operation": "avg"}},
+	// sum net rate
+	{response: `{"items":[{"messages": 0, "message_stats": {"publish_details": {"rate": 4}, "ack_details": {"rate": 1}}, "name": "evaluate_trials"},{"messages": 0, "message_stats": {"publish_details": {"rate": 1}, "ack_details": {"rate": 2}}, "name": "evaluate_trial2"}]}`, responseStatus: http.StatusOK, isActive: true, extraMetadata: map[string]string{"mode": "NetRate", "value": "10", "useRegex": "true", "operation": "sum"}},
+	{response: `{"items":[{"messages": 10, "message_stats": {"publish_details": {"rate": 4}, "ack_details": {"rate": 4}}, "name": "evaluate_trials"},{"messages": 10, "message_stats": {"publish_details": {"rate": 1}, "ack_details": {"rate": 2}}, "name": "evaluate_trial2"}]}`, responseStatus: http.StatusOK, extraMetadata: map[string]string{"mode": "NetRate", "value": "10", "useRegex": "true", "operation": "sum"}},
+	// max net rate
+	{response: `{"items":[{"messages": 0, "message_stats": {"publish_details": {"rate": 1}, "ack_details": {"rate": 0}}, "name": "evaluate_trials"},{"messages": 0, "message_stats": {"publish_details": {"rate": 8}, "ack_details": {"rate": 8}}, "name": "evaluate_trial2"}]}`, responseStatus: http.StatusOK, isActive: true, extraMetadata: map[string]string{"mode": "NetRate", "value": "10", "useRegex": "true", "operation": "max"}},
+	{response: `{"items":[{"messages": 10, "message_stats": {"publish_details": {"rate": 1}, "ack_details": {"rate": 3}}, "name": "evaluate_trials"},{"messages": 10, "message_stats": {"publish_details": {"rate": 8}, "ack_details": {"rate": 8}}, "name": "evaluate_trial2"}]}`, responseStatus: http.StatusOK, extraMetadata: map[string]string{"mode": "NetRate", "value": "10", "useRegex": "true", "operation": "max"}},
+	{response: `{"items":[]}`, responseStatus: http.StatusOK, extraMetadata: map[string]string{"mode": "NetRate", "value": "10", "useRegex": "true", "operation": "max"}},
+}
+
+func TestRabbitMQNetRate(t *testing.T) {
+	testCases := []struct {
+		name            string
+		messageStats    string
+		expectedNetRate float64
+	}{
+		{"published faster than acked", `{"publish_details": {"rate": 12.5}, "ack_details": {"rate": 10}}`, 2.5},
+		{"acked as fast as published", `{"publish_details": {"rate": 10}, "ack_details": {"rate": 10}}`, 0},
+		{"acked faster than published", `{"publish_details": {"rate": 2}, "ack_details": {"rate": 10}}`, 0},
+		{"no ack details", `{"publish_details": {"rate": 4}}`, 4},
+		{"no message stats", `{}`, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stat messageStat
+			if err := json.Unmarshal([]byte(tc.messageStats), &stat); err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			if netRate := stat.getNetRate(); netRate != tc.expectedNetRate {
+				t.Errorf("Expected net rate %v but got %v", tc.expectedNetRate, netRate)
+			}
+		})
+	}
+}
+
+func TestGetComposedQueueNetRate(t *testing.T) {
+	queues := []queueInfo{
+		{MessageStat: messageStat{PublishDetail: publishDetail{Rate: 4}, AckDetail: ackDetail{Rate: 1}}},
+		{MessageStat: messageStat{PublishDetail: publishDetail{Rate: 10}, AckDetail: ackDetail{Rate: 9}}},
+		{MessageStat: messageStat{PublishDetail: publishDetail{Rate: 1}, AckDetail: ackDetail{Rate: 6}}},
+	}
+	testCases := []struct {
+		operation       string
+		expectedNetRate float64
+	}{
+		// 15 published - 16 acked
+		{sumOperation, 0},
+		{avgOperation, 0},
+		// the net rate of the first queue
+		{maxOperation, 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.operation, func(t *testing.T) {
+			s := &rabbitMQScaler{metadata: &rabbitMQMetadata{Operation: tc.operation}}
+			queue, err := getComposedQueue(s, queues)
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			if netRate := queue.MessageStat.getNetRate(); netRate != tc.expectedNetRate {
+				t.Errorf("Expected net rate %v but got %v", tc.expectedNetRate, netRate)
+			}
+		})
+	}
 }
 
 func TestGetQueueInfoWithRegex(t *testing.T) {
@@ -775,3 +864,94 @@ func TestConnectionName(t *testing.T) {
 		t.Error("Expected connection name to be keda-test-namespace-test-name but got", connectionName)
 	}
 }
+
+func TestRabbitMQVhostQueues(t *testing.T) {
+	queues := map[string]string{
+		"/api/queues/tenant-a/orders": `{"messages": 4, "messages_ready": 3, "messages_unacknowledged": 1, "message_stats": {"publish_details": {"rate": 1.5}}, "name": "orders"}`,
+		"/api/queues/tenant-b/orders": `{"messages": 6, "messages_ready": 6, "messages_unacknowledged": 0, "message_stats": {"publish_details": {"rate": 2.5}}, "name": "orders"}`,
+		"/api/queues/%2F/orders":      `{"messages": 10, "messages_ready": 10, "messages_unacknowledged": 0, "message_stats": {"publish_details": {"rate": 0}}, "name": "orders"}`,
+	}
+	apiStub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := queues[r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Object Not Found","reason":"Not Found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	defer apiStub.Close()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+		isError       bool
+	}{
+		{
+			name:          "sum of all vhosts",
+			metadata:      map[string]string{"vhostQueues": "tenant-a:orders,tenant-b:orders,/:orders"},
+			expectedValue: 20,
+			isActive:      true,
+		},
+		{
+			name:          "max of all vhosts",
+			metadata:      map[string]string{"vhostQueues": "tenant-a:orders,tenant-b:orders", "operation": "max"},
+			expectedValue: 6,
+			isActive:      true,
+		},
+		{
+			name:          "ready messages only",
+			metadata:      map[string]string{"vhostQueues": "tenant-a:orders,tenant-b:orders", "excludeUnacknowledged": "true"},
+			expectedValue: 9,
+			isActive:      true,
+		},
+		{
+			name:          "below activation",
+			metadata:      map[string]string{"vhostQueues": "tenant-a:orders,tenant-b:orders", "activationValue": "10"},
+			expectedValue: 10,
+			isActive:      false,
+		},
+		{
+			name:     "unavailable vhost fails",
+			metadata: map[string]string{"vhostQueues": "tenant-a:orders,tenant-c:orders"},
+			isError:  true,
+		},
+		{
+			name:          "unavailable vhost skipped",
+			metadata:      map[string]string{"vhostQueues": "tenant-a:orders,tenant-c:orders,tenant-b:orders", "vhostErrorPolicy": "skip"},
+			expectedValue: 10,
+			isActive:      true,
+		},
+		{
+			name:     "all vhosts unavailable",
+			metadata: map[string]string{"vhostQueues": "tenant-c:orders,tenant-d:orders", "vhostErrorPolicy": "skip"},
+			isError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata := map[string]string{"host": apiStub.URL, "mode": "QueueLength", "value": "10"}
+			for k, v := range tc.metadata {
+				metadata[k] = v
+			}
+			s, err := NewRabbitMQScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata:   metadata,
+				AuthParams:        map[string]string{},
+				GlobalHTTPTimeout: 1000 * time.Millisecond,
+			})
+			assert.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "Metric")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
@@ -2,10 +2,13 @@ package scalers
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/redis/go-redis/v9"
@@ -27,6 +30,9 @@ var (
 	// ErrRedisNoListName is returned when "listName" is missing from the config.
 	ErrRedisNoListName = errors.New("no list name given")
 
+	// ErrRedisListNameAndScript is returned when both "listName" and "script" are in the config.
+	ErrRedisListNameAndScript = errors.New("listName and script can't be set together")
+
 	// ErrRedisNoAddresses is returned when the "addresses" in the connection info is empty.
 	ErrRedisNoAddresses = errors.New("no addresses or hosts given. address should be a comma separated list of host:port or set the host/port values")
 
@@ -42,6 +48,8 @@ type redisScaler struct {
 	metadata        *redisMetadata
 	closeFn         func() error
 	getListLengthFn func(context.Context) (int64, error)
+	// getPrioritiesFn is nil unless the scores of the sorted set are the priorities of its members
+	getPrioritiesFn func(context.Context) ([]int64, error)
 	logger          logr.Logger
 }
 
@@ -55,52 +63,189 @@ type redisConnectionInfo struct {
 	Hosts            []string `keda:"name=host;hosts,        order=triggerMetadata;resolvedEnv;authParams"`
 	Ports            []string `keda:"name=port;ports,        order=triggerMetadata;resolvedEnv;authParams"`
 	EnableTLS        bool
-	UnsafeSsl        bool   `keda:"name=unsafeSsl,   order=triggerMetadata, default=false"`
-	Cert             string `keda:"name=Cert;cert,   order=authParams"`
-	Key              string `keda:"name=key,         order=authParams"`
-	KeyPassword      string `keda:"name=keyPassword, order=authParams"`
-	Ca               string `keda:"name=ca,          order=authParams"`
+	UnsafeSsl        bool   `keda:"name=unsafeSsl,     order=triggerMetadata, default=false"`
+	Cert             string `keda:"name=Cert;cert,     order=authParams"`
+	Key              string `keda:"name=key,           order=authParams"`
+	KeyPassword      string `keda:"name=keyPassword,   order=authParams"`
+	Ca               string `keda:"name=ca,            order=authParams"`
+	TLSServerName    string `keda:"name=tlsServerName, order=triggerMetadata"`
+
+	// Sentinel specific TLS. go-redis dials the sentinels, including the ones it discovers, and the redis nodes
+	// with the same TLS config, so these settings must match the ones of the redis nodes when they're set
+	MetadataSentinelEnableTLS  string `keda:"name=sentinelEnableTLS,   order=triggerMetadata"`
+	AuthParamSentinelEnableTLS string `keda:"name=sentinelTls,         order=authParams"`
+	SentinelEnableTLS          *bool
+	SentinelUnsafeSsl          bool   `keda:"name=sentinelUnsafeSsl,   order=triggerMetadata, default=false"`
+	SentinelCert               string `keda:"name=sentinelCert,        order=authParams"`
+	SentinelKey                string `keda:"name=sentinelKey,         order=authParams"`
+	SentinelKeyPassword        string `keda:"name=sentinelKeyPassword, order=authParams"`
+	SentinelCa                 string `keda:"name=sentinelCa,          order=authParams"`
 }
 
 type redisMetadata struct {
 	ListLength           int64               `keda:"name=listLength,           order=triggerMetadata, default=5"`
 	ActivationListLength int64               `keda:"name=activationListLength, order=triggerMetadata, optional"`
-	ListName             string              `keda:"name=listName,             order=triggerMetadata"`
+	ListName             string              `keda:"name=listName,             order=triggerMetadata, optional"`
 	DatabaseIndex        int                 `keda:"name=databaseIndex,        order=triggerMetadata, optional"`
 	MetadataEnableTLS    string              `keda:"name=enableTLS,            order=triggerMetadata, optional"`
 	AuthParamEnableTLS   string              `keda:"name=tls,                  order=authParams, optional"`
 	ConnectionInfo       redisConnectionInfo `keda:"optional"`
 	triggerIndex         int
+
+	// Lua script returning the metric as an integer instead of the length of listName,
+	// the scriptKeys being passed as its KEYS
+	Script          string   `keda:"name=script,          order=triggerMetadata, optional"`
+	ScriptKeys      []string `keda:"name=scriptKeys,      order=triggerMetadata, optional"`
+	TargetValue     int64    `keda:"name=targetValue,     order=triggerMetadata, optional"`
+	ActivationValue int64    `keda:"name=activationValue, order=triggerMetadata, optional"`
+
+	// ZCOUNT of the sorted set listName between scoreMin and scoreMax instead of its length, the bounds
+	// can be relative to the current time, e.g. the jobs due now of a sorted set scored by due time
+	ScoreMin      string `keda:"name=scoreMin,      order=triggerMetadata, optional"`
+	ScoreMax      string `keda:"name=scoreMax,      order=triggerMetadata, optional"`
+	ScoreTimeUnit string `keda:"name=scoreTimeUnit, order=triggerMetadata, enum=s;ms, optional"`
+	scoreMin      *redisScoreBound
+	scoreMax      *redisScoreBound
+
+	// the scores of the sorted set listName are the priorities of its members, which are
+	// reported to the ScaledJobs creating their Jobs in the priority job creation order
+	PriorityFromScore bool `keda:"name=priorityFromScore, order=triggerMetadata, default=false"`
 }
 
-func (rci *redisConnectionInfo) SetEnableTLS(metadataEnableTLS string, authParamEnableTLS string) error {
-	EnableTLS := defaultEnableTLS
+// redisScoreBound is a bound of a ZCOUNT, a score or an offset to the current time
+type redisScoreBound struct {
+	exclusive bool
+	// value is a score, or -inf/+inf, when the bound isn't relative to the current time
+	value string
+	// relative bounds are the current time in scoreTimeUnit, seconds by default, plus the offset
+	relative bool
+	offset   float64
+}
+
+// parseRedisScoreBound parses a bound like redis does, "(" makes it exclusive, and supports
+// "now", "now-<offset>" and "now+<offset>", the offset being in the unit of the scores
+func parseRedisScoreBound(bound, defaultValue string) (*redisScoreBound, error) {
+	if bound == "" {
+		bound = defaultValue
+	}
+	result := &redisScoreBound{}
+	if after, ok := strings.CutPrefix(bound, "("); ok {
+		result.exclusive, bound = true, after
+	}
+
+	switch {
+	case bound == "-inf" || bound == "+inf":
+		result.value = bound
+	case strings.HasPrefix(bound, "now"):
+		result.relative = true
+		if offset := strings.TrimPrefix(bound, "now"); offset != "" {
+			if offset[0] != '-' && offset[0] != '+' {
+				return nil, fmt.Errorf("invalid score bound %q, the offset to now must start with - or +", bound)
+			}
+			parsed, err := strconv.ParseFloat(offset, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid score bound %q: %w", bound, err)
+			}
+			result.offset = parsed
+		}
+	default:
+		if _, err := strconv.ParseFloat(bound, 64); err != nil {
+			return nil, fmt.Errorf("invalid score bound %q, must be a number, -inf, +inf or relative to now", bound)
+		}
+		result.value = bound
+	}
+	return result, nil
+}
+
+// at returns the bound as passed to ZCOUNT, relative bounds being resolved with now
+func (b *redisScoreBound) at(now time.Time, timeUnit string) string {
+	value := b.value
+	if b.relative {
+		current := float64(now.Unix())
+		if timeUnit == "ms" {
+			current = float64(now.UnixMilli())
+		}
+		value = strconv.FormatFloat(current+b.offset, 'f', -1, 64)
+	}
+	if b.exclusive {
+		return "(" + value
+	}
+	return value
+}
 
+func (rci *redisConnectionInfo) SetEnableTLS(metadataEnableTLS string, authParamEnableTLS string) error {
 	if metadataEnableTLS != "" && authParamEnableTLS != "" {
 		return errors.New("unable to set `tls` in both ScaledObject and TriggerAuthentication together")
 	}
 
+	enableTLS, err := parseRedisEnableTLS(metadataEnableTLS, authParamEnableTLS)
+	if err != nil {
+		return err
+	}
+	rci.EnableTLS = enableTLS
+
+	return rci.setSentinelEnableTLS()
+}
+
+// setSentinelEnableTLS parses the sentinel specific TLS settings and validates that they match the ones of the nodes
+func (rci *redisConnectionInfo) setSentinelEnableTLS() error {
+	if rci.MetadataSentinelEnableTLS != "" && rci.AuthParamSentinelEnableTLS != "" {
+		return errors.New("unable to set `sentinelTls` in both ScaledObject and TriggerAuthentication together")
+	}
+	if (rci.SentinelCert == "") != (rci.SentinelKey == "") {
+		return errors.New("both sentinelCert and sentinelKey must be provided")
+	}
+
+	if rci.MetadataSentinelEnableTLS != "" || rci.AuthParamSentinelEnableTLS != "" {
+		enableTLS, err := parseRedisEnableTLS(rci.MetadataSentinelEnableTLS, rci.AuthParamSentinelEnableTLS)
+		if err != nil {
+			return err
+		}
+		rci.SentinelEnableTLS = &enableTLS
+		rci.MetadataSentinelEnableTLS, rci.AuthParamSentinelEnableTLS = "", ""
+	}
+
+	hasSentinelTLSSettings := rci.SentinelUnsafeSsl || rci.SentinelCert != "" || rci.SentinelKeyPassword != "" || rci.SentinelCa != ""
+	if hasSentinelTLSSettings && (rci.SentinelEnableTLS == nil || !*rci.SentinelEnableTLS) {
+		return errors.New("sentinel TLS settings require `sentinelTls` to be enabled")
+	}
+
+	if rci.SentinelEnableTLS != nil && *rci.SentinelEnableTLS != rci.EnableTLS {
+		return errors.New("`sentinelTls` must match the TLS setting of the redis nodes, the sentinels and the nodes are dialed with the same TLS config")
+	}
+	if (rci.SentinelUnsafeSsl && !rci.UnsafeSsl) ||
+		(rci.SentinelCert != "" && rci.SentinelCert != rci.Cert) ||
+		(rci.SentinelKey != "" && rci.SentinelKey != rci.Key) ||
+		(rci.SentinelKeyPassword != "" && rci.SentinelKeyPassword != rci.KeyPassword) ||
+		(rci.SentinelCa != "" && rci.SentinelCa != rci.Ca) {
+		return errors.New("sentinel TLS settings must match the TLS settings of the redis nodes, the sentinels and the nodes are dialed with the same TLS config")
+	}
+	return nil
+}
+
+func parseRedisEnableTLS(metadataEnableTLS string, authParamEnableTLS string) (bool, error) {
+	enableTLS := defaultEnableTLS
+
 	if metadataEnableTLS != "" {
 		tls, err := strconv.ParseBool(metadataEnableTLS)
 		if err != nil {
-			return fmt.Errorf("EnableTLS parsing error %w", err)
+			return false, fmt.Errorf("EnableTLS parsing error %w", err)
 		}
-		EnableTLS = tls
+		enableTLS = tls
 	}
 
 	// parse tls config defined in auth params
 	if authParamEnableTLS != "" {
 		switch authParamEnableTLS {
 		case stringEnable:
-			EnableTLS = true
+			enableTLS = true
 		case stringDisable:
-			EnableTLS = false
+			enableTLS = false
 		default:
-			return fmt.Errorf("error incorrect TLS value given, got %s", authParamEnableTLS)
+			return false, fmt.Errorf("error incorrect TLS value given, got %s", authParamEnableTLS)
 		}
 	}
-	rci.EnableTLS = EnableTLS
-	return nil
+	return enableTLS, nil
 }
 
 func (r *redisMetadata) Validate() error {
@@ -111,29 +256,103 @@ func (r *redisMetadata) Validate() error {
 	}
 
 	err = r.ConnectionInfo.SetEnableTLS(r.MetadataEnableTLS, r.AuthParamEnableTLS)
-	if err == nil {
-		r.MetadataEnableTLS, r.AuthParamEnableTLS = "", ""
+	if err != nil {
+		return err
 	}
+	r.MetadataEnableTLS, r.AuthParamEnableTLS = "", ""
 
-	return err
+	if err := r.validateScript(); err != nil {
+		return err
+	}
+	if err := r.validateScoreRange(); err != nil {
+		return err
+	}
+	return r.validatePriorityFromScore()
 }
 
-// NewRedisScaler creates a new redisScaler
-func NewRedisScaler(ctx context.Context, isClustered, isSentinel bool, config *scalersconfig.ScalerConfig) (Scaler, error) {
-	luaScript := `
-		local listName = KEYS[1]
-		local listType = redis.call('type', listName).ok
-		local cmd = {
-			zset = 'zcard',
-			set = 'scard',
-			list = 'llen',
-			hash = 'hlen',
-			none = 'llen'
+// validateScript checks that the metric is computed either from listName or from a script
+func (r *redisMetadata) validateScript() error {
+	if r.Script == "" {
+		if r.ListName == "" {
+			return ErrRedisNoListName
 		}
+		return nil
+	}
+
+	if r.ListName != "" {
+		return ErrRedisListNameAndScript
+	}
+	if r.TargetValue <= 0 {
+		return errors.New("targetValue must be positive when script is set")
+	}
+	if r.ActivationValue < 0 {
+		return errors.New("activationValue must not be negative")
+	}
+	return nil
+}
+
+// validateScoreRange parses the bounds of the ZCOUNT, if any
+func (r *redisMetadata) validateScoreRange() error {
+	if r.ScoreMin == "" && r.ScoreMax == "" {
+		return nil
+	}
+	if r.Script != "" {
+		return errors.New("scoreMin and scoreMax can't be used together with script")
+	}
+
+	var err error
+	if r.scoreMin, err = parseRedisScoreBound(r.ScoreMin, "-inf"); err != nil {
+		return err
+	}
+	if r.scoreMax, err = parseRedisScoreBound(r.ScoreMax, "+inf"); err != nil {
+		return err
+	}
+	if r.TargetValue <= 0 {
+		return errors.New("targetValue must be positive when scoreMin or scoreMax is set")
+	}
+	if r.ActivationValue < 0 {
+		return errors.New("activationValue must not be negative")
+	}
+	return nil
+}
 
-		return redis.call(cmd[listType], listName)
-	`
+// validatePriorityFromScore checks that the scores are priorities and not times when priorityFromScore is set
+func (r *redisMetadata) validatePriorityFromScore() error {
+	if !r.PriorityFromScore {
+		return nil
+	}
+	if r.Script != "" {
+		return errors.New("priorityFromScore can't be used together with script")
+	}
+	if r.ScoreTimeUnit != "" || (r.scoreMin != nil && (r.scoreMin.relative || r.scoreMax.relative)) {
+		return errors.New("priorityFromScore can't be used with scores relative to the current time")
+	}
+	return nil
+}
+
+// usesTargetValue returns whether the metric is scaled on targetValue and activationValue,
+// i.e. it is computed by a script or over a score range, instead of listLength and activationListLength
+func (r *redisMetadata) usesTargetValue() bool {
+	return r.Script != "" || r.scoreMin != nil
+}
+
+// redisListLengthScript returns the length of the list, set, sorted set or hash of KEYS[1]
+const redisListLengthScript = `
+	local listName = KEYS[1]
+	local listType = redis.call('type', listName).ok
+	local cmd = {
+		zset = 'zcard',
+		set = 'scard',
+		list = 'llen',
+		hash = 'hlen',
+		none = 'llen'
+	}
 
+	return redis.call(cmd[listType], listName)
+`
+
+// NewRedisScaler creates a new redisScaler
+func NewRedisScaler(ctx context.Context, isClustered, isSentinel bool, config *scalersconfig.ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
 		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
@@ -147,19 +366,104 @@ func NewRedisScaler(ctx context.Context, isClustered, isSentinel bool, config *s
 	}
 
 	if isClustered {
-		return createClusteredRedisScaler(ctx, meta, luaScript, metricType, logger)
+		return createClusteredRedisScaler(ctx, meta, metricType, logger)
 	} else if isSentinel {
-		return createSentinelRedisScaler(ctx, meta, luaScript, metricType, logger)
+		return createSentinelRedisScaler(ctx, meta, metricType, logger)
 	}
-	return createRedisScaler(ctx, meta, luaScript, metricType, logger)
+	return createRedisScaler(ctx, meta, metricType, logger)
 }
 
-func createClusteredRedisScaler(ctx context.Context, meta *redisMetadata, script string, metricType v2.MetricTargetType, logger logr.Logger) (Scaler, error) {
+// newRedisScript returns the script computing the metric and its keys, the script of the
+// metadata or the one returning the length of listName
+func newRedisScript(meta *redisMetadata) (*redis.Script, []string) {
+	if meta.Script != "" {
+		return redis.NewScript(meta.Script), meta.ScriptKeys
+	}
+	return redis.NewScript(redisListLengthScript), []string{meta.ListName}
+}
+
+// loadRedisScript loads the script of the metadata with SCRIPT LOAD, so a script which doesn't compile fails
+// the creation of the scaler. The script is then run with EVALSHA, falling back to EVAL if it was flushed
+func loadRedisScript(ctx context.Context, client redis.Scripter, meta *redisMetadata, script *redis.Script) error {
+	if meta.Script == "" {
+		return nil
+	}
+	if err := script.Load(ctx, client).Err(); err != nil {
+		return fmt.Errorf("error loading redis script: %w", err)
+	}
+	return nil
+}
+
+// newRedisListLengthFn returns the function computing the metric, with ZCOUNT over the score range
+// if there is one, otherwise with the script
+func newRedisListLengthFn(client redis.Cmdable, meta *redisMetadata, script *redis.Script, keys []string) func(context.Context) (int64, error) {
+	if meta.scoreMin != nil {
+		return func(ctx context.Context) (int64, error) {
+			now := time.Now()
+			cmd := client.ZCount(ctx, meta.ListName, meta.scoreMin.at(now, meta.ScoreTimeUnit), meta.scoreMax.at(now, meta.ScoreTimeUnit))
+			if cmd.Err() != nil {
+				return -1, cmd.Err()
+			}
+			return cmd.Val(), nil
+		}
+	}
+
+	return func(ctx context.Context) (int64, error) {
+		cmd := script.Run(ctx, client, keys)
+		if cmd.Err() != nil {
+			return -1, cmd.Err()
+		}
+
+		return cmd.Int64()
+	}
+}
+
+// redisMaxPriorities is the maximum number of members of the sorted set whose priorities are reported,
+// a ScaledJob creates no more Jobs than its maxReplicaCount in one polling interval anyway
+const redisMaxPriorities = 1000
+
+// newRedisPrioritiesFn returns the function listing the scores of the highest scored members of the
+// sorted set within the score range, if priorityFromScore is set
+func newRedisPrioritiesFn(client redis.Cmdable, meta *redisMetadata) func(context.Context) ([]int64, error) {
+	if !meta.PriorityFromScore {
+		return nil
+	}
+
+	minScore, maxScore := "-inf", "+inf"
+	if meta.scoreMin != nil {
+		// the bounds are not relative to the current time, see validatePriorityFromScore
+		minScore, maxScore = meta.scoreMin.at(time.Time{}, ""), meta.scoreMax.at(time.Time{}, "")
+	}
+	return func(ctx context.Context) ([]int64, error) {
+		members, err := client.ZRevRangeByScoreWithScores(ctx, meta.ListName, &redis.ZRangeBy{
+			Min:   minScore,
+			Max:   maxScore,
+			Count: redisMaxPriorities,
+		}).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		priorities := make([]int64, 0, len(members))
+		for _, member := range members {
+			priorities = append(priorities, int64(member.Score))
+		}
+		return priorities, nil
+	}
+}
+
+func createClusteredRedisScaler(ctx context.Context, meta *redisMetadata, metricType v2.MetricTargetType, logger logr.Logger) (Scaler, error) {
 	client, err := getRedisClusterClient(ctx, meta.ConnectionInfo)
 	if err != nil {
 		return nil, fmt.Errorf("connection to redis cluster failed: %w", err)
 	}
 
+	script, keys := newRedisScript(meta)
+	if err := loadRedisScript(ctx, client, meta, script); err != nil {
+		client.Close()
+		return nil, err
+	}
+
 	closeFn := func() error {
 		if err := client.Close(); err != nil {
 			logger.Error(err, "error closing redis client")
@@ -168,43 +472,41 @@ func createClusteredRedisScaler(ctx context.Context, meta *redisMetadata, script
 		return nil
 	}
 
-	listLengthFn := func(ctx context.Context) (int64, error) {
-		cmd := client.Eval(ctx, script, []string{meta.ListName})
-		if cmd.Err() != nil {
-			return -1, cmd.Err()
-		}
-
-		return cmd.Int64()
-	}
-
 	return &redisScaler{
 		metricType:      metricType,
 		metadata:        meta,
 		closeFn:         closeFn,
-		getListLengthFn: listLengthFn,
+		getListLengthFn: newRedisListLengthFn(client, meta, script, keys),
+		getPrioritiesFn: newRedisPrioritiesFn(client, meta),
 		logger:          logger,
 	}, nil
 }
 
-func createSentinelRedisScaler(ctx context.Context, meta *redisMetadata, script string, metricType v2.MetricTargetType, logger logr.Logger) (Scaler, error) {
+func createSentinelRedisScaler(ctx context.Context, meta *redisMetadata, metricType v2.MetricTargetType, logger logr.Logger) (Scaler, error) {
 	client, err := getRedisSentinelClient(ctx, meta.ConnectionInfo, meta.DatabaseIndex)
 	if err != nil {
 		return nil, fmt.Errorf("connection to redis sentinel failed: %w", err)
 	}
 
-	return createRedisScalerWithClient(client, meta, script, metricType, logger), nil
+	return createRedisScalerWithClient(ctx, client, meta, metricType, logger)
 }
 
-func createRedisScaler(ctx context.Context, meta *redisMetadata, script string, metricType v2.MetricTargetType, logger logr.Logger) (Scaler, error) {
+func createRedisScaler(ctx context.Context, meta *redisMetadata, metricType v2.MetricTargetType, logger logr.Logger) (Scaler, error) {
 	client, err := getRedisClient(ctx, meta.ConnectionInfo, meta.DatabaseIndex)
 	if err != nil {
 		return nil, fmt.Errorf("connection to redis failed: %w", err)
 	}
 
-	return createRedisScalerWithClient(client, meta, script, metricType, logger), nil
+	return createRedisScalerWithClient(ctx, client, meta, metricType, logger)
 }
 
-func createRedisScalerWithClient(client *redis.Client, meta *redisMetadata, script string, metricType v2.MetricTargetType, logger logr.Logger) Scaler {
+func createRedisScalerWithClient(ctx context.Context, client *redis.Client, meta *redisMetadata, metricType v2.MetricTargetType, logger logr.Logger) (Scaler, error) {
+	script, keys := newRedisScript(meta)
+	if err := loadRedisScript(ctx, client, meta, script); err != nil {
+		client.Close()
+		return nil, err
+	}
+
 	closeFn := func() error {
 		if err := client.Close(); err != nil {
 			logger.Error(err, "error closing redis client")
@@ -213,22 +515,14 @@ func createRedisScalerWithClient(client *redis.Client, meta *redisMetadata, scri
 		return nil
 	}
 
-	listLengthFn := func(ctx context.Context) (int64, error) {
-		cmd := client.Eval(ctx, script, []string{meta.ListName})
-		if cmd.Err() != nil {
-			return -1, cmd.Err()
-		}
-
-		return cmd.Int64()
-	}
-
 	return &redisScaler{
 		metricType:      metricType,
 		metadata:        meta,
 		closeFn:         closeFn,
-		getListLengthFn: listLengthFn,
+		getListLengthFn: newRedisListLengthFn(client, meta, script, keys),
+		getPrioritiesFn: newRedisPrioritiesFn(client, meta),
 		logger:          logger,
-	}
+	}, nil
 }
 
 func parseRedisMetadata(config *scalersconfig.ScalerConfig) (*redisMetadata, error) {
@@ -248,11 +542,18 @@ func (s *redisScaler) Close(context.Context) error {
 // GetMetricSpecForScaling returns the metric spec for the HPA
 func (s *redisScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	metricName := util.NormalizeString(fmt.Sprintf("redis-%s", s.metadata.ListName))
+	targetValue := s.metadata.ListLength
+	if s.metadata.usesTargetValue() {
+		targetValue = s.metadata.TargetValue
+	}
+	if s.metadata.Script != "" {
+		metricName = "redis-script"
+	}
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.ListLength),
+		Target: GetMetricTarget(s.metricType, targetValue),
 	}
 	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
@@ -260,7 +561,7 @@ func (s *redisScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	return []v2.MetricSpec{metricSpec}
 }
 
-// GetMetricsAndActivity connects to Redis and finds the length of the list
+// GetMetricsAndActivity connects to Redis and finds the length of the list, or runs the script or the ZCOUNT
 func (s *redisScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
 	listLen, err := s.getListLengthFn(ctx)
 
@@ -271,7 +572,19 @@ func (s *redisScaler) GetMetricsAndActivity(ctx context.Context, metricName stri
 
 	metric := GenerateMetricInMili(metricName, float64(listLen))
 
-	return []external_metrics.ExternalMetricValue{metric}, listLen > s.metadata.ActivationListLength, nil
+	activationValue := s.metadata.ActivationListLength
+	if s.metadata.usesTargetValue() {
+		activationValue = s.metadata.ActivationValue
+	}
+	return []external_metrics.ExternalMetricValue{metric}, listLen > activationValue, nil
+}
+
+// GetPriorities returns the scores of the members of the sorted set, highest first, when they are their priorities
+func (s *redisScaler) GetPriorities(ctx context.Context, _ string) ([]int64, error) {
+	if s.getPrioritiesFn == nil {
+		return nil, nil
+	}
+	return s.getPrioritiesFn(ctx)
 }
 
 func validateRedisAddress(c *redisConnectionInfo) error {
@@ -291,6 +604,16 @@ func validateRedisAddress(c *redisConnectionInfo) error {
 	return nil
 }
 
+// newTLSConfig returns the TLS config of the redis nodes
+func (rci *redisConnectionInfo) newTLSConfig() (*tls.Config, error) {
+	tlsConfig, err := util.NewTLSConfigWithPassword(rci.Cert, rci.Key, rci.KeyPassword, rci.Ca, rci.UnsafeSsl)
+	if err != nil {
+		return nil, err
+	}
+	util.SetTLSServerName(tlsConfig, rci.TLSServerName)
+	return tlsConfig, nil
+}
+
 func getRedisClusterClient(ctx context.Context, info redisConnectionInfo) (*redis.ClusterClient, error) {
 	options := &redis.ClusterOptions{
 		Addrs:    info.Addresses,
@@ -298,7 +621,7 @@ func getRedisClusterClient(ctx context.Context, info redisConnectionInfo) (*redi
 		Password: info.Password,
 	}
 	if info.EnableTLS {
-		tlsConfig, err := util.NewTLSConfigWithPassword(info.Cert, info.Key, info.KeyPassword, info.Ca, info.UnsafeSsl)
+		tlsConfig, err := info.newTLSConfig()
 		if err != nil {
 			return nil, err
 		}
@@ -314,6 +637,22 @@ func getRedisClusterClient(ctx context.Context, info redisConnectionInfo) (*redi
 }
 
 func getRedisSentinelClient(ctx context.Context, info redisConnectionInfo, dbIndex int) (*redis.Client, error) {
+	options, err := getRedisSentinelOptions(info, dbIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	// confirm if connected
+	c := redis.NewFailoverClient(options)
+	if err := c.Ping(ctx).Err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// getRedisSentinelOptions returns the failover options, the sentinels and the redis nodes use their own credentials.
+// They share the TLS config, as go-redis also dials the sentinels it discovers with it
+func getRedisSentinelOptions(info redisConnectionInfo, dbIndex int) (*redis.FailoverOptions, error) {
 	options := &redis.FailoverOptions{
 		Username:         info.Username,
 		Password:         info.Password,
@@ -323,20 +662,15 @@ func getRedisSentinelClient(ctx context.Context, info redisConnectionInfo, dbInd
 		SentinelPassword: info.SentinelPassword,
 		MasterName:       info.SentinelMaster,
 	}
+
 	if info.EnableTLS {
-		tlsConfig, err := util.NewTLSConfigWithPassword(info.Cert, info.Key, info.KeyPassword, info.Ca, info.UnsafeSsl)
+		tlsConfig, err := info.newTLSConfig()
 		if err != nil {
 			return nil, err
 		}
 		options.TLSConfig = tlsConfig
 	}
-
-	// confirm if connected
-	c := redis.NewFailoverClient(options)
-	if err := c.Ping(ctx).Err(); err != nil {
-		return nil, err
-	}
-	return c, nil
+	return options, nil
 }
 
 func getRedisClient(ctx context.Context, info redisConnectionInfo, dbIndex int) (*redis.Client, error) {
@@ -347,7 +681,7 @@ func getRedisClient(ctx context.Context, info redisConnectionInfo, dbIndex int)
 		DB:       dbIndex,
 	}
 	if info.EnableTLS {
-		tlsConfig, err := util.NewTLSConfigWithPassword(info.Cert, info.Key, info.KeyPassword, info.Ca, info.UnsafeSsl)
+		tlsConfig, err := info.newTLSConfig()
 		if err != nil {
 			return nil, err
 		}
@@ -2,9 +2,18 @@ package scalers
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/go-logr/logr"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
@@ -71,11 +80,37 @@ var testRedisMetadata = []parseRedisMetadataTestData{
 	// enableTLS is defined both in authParams and metadata
 	{map[string]string{"listName": "mylist", "listLength": "0", "enableTLS": "true"}, true, map[string]string{"address": "localhost:6379", "tls": "disable"}, true},
 	// host only is defined in the authParams
-	{map[string]string{"listName": "mylist", "listLength": "0"}, true, map[string]string{"host": "localhost"}, false}}
+	{map[string]string{"listName": "mylist", "listLength": "0"}, true, map[string]string{"host": "localhost"}, false},
+	// properly formed script
+	{map[string]string{"script": "return redis.call('llen', KEYS[1]) + redis.call('llen', KEYS[2])", "scriptKeys": "orders,payments", "targetValue": "10", "activationValue": "2"}, false, map[string]string{"address": "localhost:6379"}, false},
+	// script without targetValue
+	{map[string]string{"script": "return 1"}, true, map[string]string{"address": "localhost:6379"}, false},
+	// script with negative activationValue
+	{map[string]string{"script": "return 1", "targetValue": "10", "activationValue": "-1"}, true, map[string]string{"address": "localhost:6379"}, false},
+	// script and listName
+	{map[string]string{"script": "return 1", "targetValue": "10", "listName": "mylist"}, true, map[string]string{"address": "localhost:6379"}, false},
+	// neither script nor listName
+	{map[string]string{"listLength": "10"}, true, map[string]string{"address": "localhost:6379"}, false},
+	// properly formed score range
+	{map[string]string{"listName": "delayed", "scoreMin": "-inf", "scoreMax": "now", "targetValue": "10", "activationValue": "1"}, false, map[string]string{"address": "localhost:6379"}, false},
+	// score range in milliseconds with an exclusive relative bound
+	{map[string]string{"listName": "delayed", "scoreMax": "(now+5000", "scoreTimeUnit": "ms", "targetValue": "10"}, false, map[string]string{"address": "localhost:6379"}, false},
+	// score range without targetValue
+	{map[string]string{"listName": "delayed", "scoreMax": "now"}, true, map[string]string{"address": "localhost:6379"}, false},
+	// invalid score bound
+	{map[string]string{"listName": "delayed", "scoreMax": "tomorrow", "targetValue": "10"}, true, map[string]string{"address": "localhost:6379"}, false},
+	// invalid offset to now
+	{map[string]string{"listName": "delayed", "scoreMax": "now5", "targetValue": "10"}, true, map[string]string{"address": "localhost:6379"}, false},
+	// unsupported scoreTimeUnit
+	{map[string]string{"listName": "delayed", "scoreMax": "now", "scoreTimeUnit": "h", "targetValue": "10"}, true, map[string]string{"address": "localhost:6379"}, false},
+	// score range and script
+	{map[string]string{"script": "return 1", "scoreMax": "now", "targetValue": "10"}, true, map[string]string{"address": "localhost:6379"}, false}}
 
 var redisMetricIdentifiers = []redisMetricIdentifier{
 	{&testRedisMetadata[1], 0, "s0-redis-mylist"},
 	{&testRedisMetadata[1], 1, "s1-redis-mylist"},
+	{&testRedisMetadata[19], 2, "s2-redis-script"},
+	{&testRedisMetadata[24], 3, "s3-redis-delayed"},
 }
 
 func TestRedisParseMetadata(t *testing.T) {
@@ -125,6 +160,7 @@ func TestRedisGetMetricSpecForScaling(t *testing.T) {
 			meta,
 			closeFn,
 			lengthFn,
+			nil,
 			logr.Discard(),
 		}
 
@@ -814,6 +850,134 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "sentinel tls enabled in authParams with the settings of the nodes",
+			metadata: map[string]string{
+				"listName":          "mylist",
+				"enableTLS":         "true",
+				"unsafeSsl":         "true",
+				"sentinelUnsafeSsl": "true",
+			},
+			authParams: map[string]string{
+				"addresses":    ":26379",
+				"cert":         "ceert",
+				"key":          "keey",
+				"ca":           "caaa",
+				"sentinelTls":  "enable",
+				"sentinelCert": "ceert",
+				"sentinelKey":  "keey",
+				"sentinelCa":   "caaa",
+			},
+			wantMeta: &redisMetadata{
+				ListLength: 5,
+				ListName:   "mylist",
+				ConnectionInfo: redisConnectionInfo{
+					Addresses:         []string{":26379"},
+					EnableTLS:         true,
+					UnsafeSsl:         true,
+					Cert:              "ceert",
+					Key:               "keey",
+					Ca:                "caaa",
+					SentinelEnableTLS: &[]bool{true}[0],
+					SentinelUnsafeSsl: true,
+					SentinelCert:      "ceert",
+					SentinelKey:       "keey",
+					SentinelCa:        "caaa",
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "sentinel tls settings differing from the ones of the nodes",
+			metadata: map[string]string{
+				"listName":  "mylist",
+				"enableTLS": "true",
+			},
+			authParams: map[string]string{
+				"addresses":   ":26379",
+				"ca":          "caaa",
+				"sentinelTls": "enable",
+				"sentinelCa":  "sentinel-caaa",
+			},
+			wantMeta: nil,
+			wantErr:  errors.New("sentinel TLS settings must match the TLS settings of the redis nodes"),
+		},
+		{
+			name: "sentinel tls enabled while nodes don't use tls",
+			metadata: map[string]string{
+				"listName": "mylist",
+			},
+			authParams: map[string]string{
+				"addresses":   ":26379",
+				"sentinelTls": "enable",
+			},
+			wantMeta: nil,
+			wantErr:  errors.New("`sentinelTls` must match the TLS setting of the redis nodes"),
+		},
+		{
+			name: "sentinel tls disabled while nodes use tls",
+			metadata: map[string]string{
+				"listName":          "mylist",
+				"enableTLS":         "true",
+				"sentinelEnableTLS": "false",
+			},
+			authParams: map[string]string{
+				"addresses": ":26379",
+			},
+			wantMeta: nil,
+			wantErr:  errors.New("`sentinelTls` must match the TLS setting of the redis nodes"),
+		},
+		{
+			name: "sentinel tls in both metadata and authParams",
+			metadata: map[string]string{
+				"listName":          "mylist",
+				"sentinelEnableTLS": "true",
+			},
+			authParams: map[string]string{
+				"addresses":   ":26379",
+				"sentinelTls": "enable",
+			},
+			wantMeta: nil,
+			wantErr:  errors.New("unable to set `sentinelTls` in both ScaledObject and TriggerAuthentication together"),
+		},
+		{
+			name: "sentinel cert without key",
+			metadata: map[string]string{
+				"listName": "mylist",
+			},
+			authParams: map[string]string{
+				"addresses":    ":26379",
+				"sentinelTls":  "enable",
+				"sentinelCert": "ceert",
+			},
+			wantMeta: nil,
+			wantErr:  errors.New("both sentinelCert and sentinelKey must be provided"),
+		},
+		{
+			name: "sentinel tls settings without sentinel tls enabled",
+			metadata: map[string]string{
+				"listName":  "mylist",
+				"enableTLS": "true",
+			},
+			authParams: map[string]string{
+				"addresses":  ":26379",
+				"sentinelCa": "caaa",
+			},
+			wantMeta: nil,
+			wantErr:  errors.New("sentinel TLS settings require `sentinelTls` to be enabled"),
+		},
+		{
+			name: "invalid sentinel tls value",
+			metadata: map[string]string{
+				"listName": "mylist",
+			},
+			authParams: map[string]string{
+				"addresses":   ":26379",
+				"sentinelTls": "yes",
+			},
+			wantMeta: nil,
+			wantErr:  errors.New("error incorrect TLS value given, got yes"),
+		},
 	}
 
 	for _, testCase := range cases {
@@ -834,3 +998,312 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestRedisSentinelOptions(t *testing.T) {
+	baseInfo := redisConnectionInfo{
+		Addresses:        []string{"sentinel-0:26379", "sentinel-1:26379"},
+		Username:         "node-user",
+		Password:         "node-password",
+		SentinelUsername: "sentinel-user",
+		SentinelPassword: "sentinel-password",
+		SentinelMaster:   "mymaster",
+	}
+
+	for _, enableTLS := range []bool{false, true} {
+		t.Run(fmt.Sprintf("tls %v", enableTLS), func(t *testing.T) {
+			info := baseInfo
+			info.EnableTLS = enableTLS
+			info.UnsafeSsl = true
+
+			options, err := getRedisSentinelOptions(info, 2)
+			assert.NoError(t, err)
+
+			assert.Equal(t, "node-user", options.Username)
+			assert.Equal(t, "node-password", options.Password)
+			assert.Equal(t, "sentinel-user", options.SentinelUsername)
+			assert.Equal(t, "sentinel-password", options.SentinelPassword)
+			assert.Equal(t, "mymaster", options.MasterName)
+			assert.Equal(t, []string{"sentinel-0:26379", "sentinel-1:26379"}, options.SentinelAddrs)
+			assert.Equal(t, 2, options.DB)
+
+			// the sentinels and the nodes share the TLS config, go-redis has no dialer of its own for the sentinels
+			assert.Equal(t, enableTLS, options.TLSConfig != nil)
+			assert.Nil(t, options.Dialer)
+		})
+	}
+}
+
+func TestRedisSentinelOptionsDialDiscoveredSentinel(t *testing.T) {
+	// the certificate of the test server is valid for 127.0.0.1 and example.com
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+	discoveredSentinel := miniredis.NewMiniRedis()
+	if err := discoveredSentinel.StartTLS(&tls.Config{Certificates: server.TLS.Certificates, MinVersion: tls.VersionTLS12}); err != nil {
+		t.Fatal("Could not start the sentinel:", err)
+	}
+	defer discoveredSentinel.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	options, err := getRedisSentinelOptions(redisConnectionInfo{
+		Addresses:      []string{"sentinel-0:26379"},
+		EnableTLS:      true,
+		Ca:             string(ca),
+		TLSServerName:  "example.com",
+		SentinelMaster: "mymaster",
+	}, 0)
+	assert.NoError(t, err)
+
+	// go-redis dials a sentinel it discovers with the dialer and the TLS config of the options,
+	// although its address isn't among the configured ones
+	client := redis.NewSentinelClient(&redis.Options{Addr: discoveredSentinel.Addr(), Dialer: options.Dialer, TLSConfig: options.TLSConfig})
+	defer client.Close()
+	assert.NoError(t, client.Ping(context.Background()).Err())
+}
+
+func TestRedisTLSServerName(t *testing.T) {
+	meta, err := parseRedisMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"listName": "mylist", "address": "10.0.0.5:6379", "enableTLS": "true", "tlsServerName": "redis.example.com"},
+	})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+
+	tlsConfig, err := meta.ConnectionInfo.newTLSConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "redis.example.com", tlsConfig.ServerName)
+	assert.False(t, tlsConfig.InsecureSkipVerify)
+
+	// the sentinels sharing the TLS settings of the nodes use the same server name
+	options, err := getRedisSentinelOptions(redisConnectionInfo{Addresses: []string{"10.0.0.5:26379"}, EnableTLS: true, TLSServerName: "redis.example.com"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "redis.example.com", options.TLSConfig.ServerName)
+}
+
+const testRedisBacklogScript = `
+	local backlog = redis.call('llen', KEYS[1])
+	for _, key in ipairs(redis.call('keys', KEYS[2])) do
+		backlog = backlog + redis.call('zcard', key)
+	end
+	return backlog
+`
+
+func TestRedisScript(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.Lpush("orders", "o1")
+	server.Lpush("orders", "o2")
+	server.ZAdd("retries:eu", 1, "o3")
+	server.ZAdd("retries:us", 1, "o4")
+	server.ZAdd("retries:us", 2, "o5")
+
+	testCases := []struct {
+		name           string
+		metadata       map[string]string
+		expectedValue  float64
+		expectedActive bool
+		expectedErr    string
+	}{
+		{
+			name:           "backlog over several keys",
+			metadata:       map[string]string{"script": testRedisBacklogScript, "scriptKeys": "orders,retries:*", "targetValue": "10", "activationValue": "2"},
+			expectedValue:  5,
+			expectedActive: true,
+		},
+		{
+			name:          "below activationValue",
+			metadata:      map[string]string{"script": "return redis.call('llen', KEYS[1])", "scriptKeys": "orders", "targetValue": "10", "activationValue": "2"},
+			expectedValue: 2,
+		},
+		{
+			name:        "script which doesn't compile",
+			metadata:    map[string]string{"script": "return redis.call(", "targetValue": "10"},
+			expectedErr: "error loading redis script",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scaler, err := NewRedisScaler(context.Background(), false, false, &scalersconfig.ScalerConfig{
+				TriggerMetadata: tc.metadata,
+				AuthParams:      map[string]string{"address": server.Addr()},
+			})
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			defer scaler.Close(context.Background())
+
+			// the script is run from its SHA, even after the scripts were flushed
+			client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+			defer client.Close()
+			assert.NoError(t, client.ScriptFlush(context.Background()).Err())
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-redis-script")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.AsApproximateFloat64())
+			assert.Equal(t, tc.expectedActive, active)
+		})
+	}
+}
+
+func TestRedisScoreBound(t *testing.T) {
+	now := time.Unix(1700000000, 500*int64(time.Millisecond))
+	testCases := []struct {
+		bound    string
+		timeUnit string
+		expected string
+	}{
+		{bound: "", expected: "-inf"},
+		{bound: "+inf", expected: "+inf"},
+		{bound: "(-inf", expected: "(-inf"},
+		{bound: "42.5", expected: "42.5"},
+		{bound: "(42", expected: "(42"},
+		{bound: "now", timeUnit: "s", expected: "1700000000"},
+		{bound: "now-60", timeUnit: "s", expected: "1699999940"},
+		{bound: "(now+30", timeUnit: "s", expected: "(1700000030"},
+		{bound: "now", timeUnit: "ms", expected: "1700000000500"},
+		{bound: "now-1000", timeUnit: "ms", expected: "1699999999500"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.bound, func(t *testing.T) {
+			bound, err := parseRedisScoreBound(tc.bound, "-inf")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, bound.at(now, tc.timeUnit))
+		})
+	}
+}
+
+func TestRedisScoreRange(t *testing.T) {
+	server := miniredis.RunT(t)
+	now := time.Now()
+	// jobs scored by their due time, across the boundary of now
+	for i, offset := range []time.Duration{-time.Hour, -time.Minute, -10 * time.Second, 10 * time.Minute, time.Hour} {
+		member := fmt.Sprintf("job-%d", i)
+		server.ZAdd("delayed", float64(now.Add(offset).Unix()), member)
+		server.ZAdd("delayed-ms", float64(now.Add(offset).UnixMilli()), member)
+	}
+
+	testCases := []struct {
+		name           string
+		metadata       map[string]string
+		expectedValue  float64
+		expectedActive bool
+	}{
+		{
+			name:           "due now",
+			metadata:       map[string]string{"listName": "delayed", "scoreMax": "now", "targetValue": "10", "activationValue": "2"},
+			expectedValue:  3,
+			expectedActive: true,
+		},
+		{
+			name:          "below activationValue",
+			metadata:      map[string]string{"listName": "delayed", "scoreMax": "now", "targetValue": "10", "activationValue": "3"},
+			expectedValue: 3,
+		},
+		{
+			name:           "overdue for more than 5 minutes",
+			metadata:       map[string]string{"listName": "delayed", "scoreMax": "now-300", "targetValue": "10"},
+			expectedValue:  1,
+			expectedActive: true,
+		},
+		{
+			name:           "due within the next 30 minutes",
+			metadata:       map[string]string{"listName": "delayed", "scoreMin": "(now", "scoreMax": "now+1800", "targetValue": "10"},
+			expectedValue:  1,
+			expectedActive: true,
+		},
+		{
+			name:           "due now in milliseconds",
+			metadata:       map[string]string{"listName": "delayed-ms", "scoreMax": "now", "scoreTimeUnit": "ms", "targetValue": "10"},
+			expectedValue:  3,
+			expectedActive: true,
+		},
+		{
+			name:          "missing sorted set",
+			metadata:      map[string]string{"listName": "missing", "scoreMax": "now", "targetValue": "10"},
+			expectedValue: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scaler, err := NewRedisScaler(context.Background(), false, false, &scalersconfig.ScalerConfig{
+				TriggerMetadata: tc.metadata,
+				AuthParams:      map[string]string{"address": server.Addr()},
+			})
+			assert.NoError(t, err)
+			defer scaler.Close(context.Background())
+
+			metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+			assert.Equal(t, int64(10), metricSpec[0].External.Target.AverageValue.Value())
+
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-redis-delayed")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.AsApproximateFloat64())
+			assert.Equal(t, tc.expectedActive, active)
+		})
+	}
+}
+
+func TestRedisPriorities(t *testing.T) {
+	server := miniredis.RunT(t)
+	for i, priority := range []float64{3, 10, 1, 7, 5} {
+		server.ZAdd("tasks", priority, fmt.Sprintf("task-%d", i))
+	}
+
+	testCases := []struct {
+		name               string
+		metadata           map[string]string
+		expectedPriorities []int64
+		expectedError      string
+	}{
+		{
+			name:     "priorities not reported by default",
+			metadata: map[string]string{"listName": "tasks"},
+		},
+		{
+			name:               "all members, highest first",
+			metadata:           map[string]string{"listName": "tasks", "priorityFromScore": "true"},
+			expectedPriorities: []int64{10, 7, 5, 3, 1},
+		},
+		{
+			name:               "members within the score range",
+			metadata:           map[string]string{"listName": "tasks", "priorityFromScore": "true", "scoreMin": "(1", "scoreMax": "7", "targetValue": "10"},
+			expectedPriorities: []int64{7, 5, 3},
+		},
+		{
+			name:               "missing sorted set",
+			metadata:           map[string]string{"listName": "missing", "priorityFromScore": "true"},
+			expectedPriorities: []int64{},
+		},
+		{
+			name:          "scores relative to now",
+			metadata:      map[string]string{"listName": "tasks", "priorityFromScore": "true", "scoreMax": "now", "targetValue": "10"},
+			expectedError: "priorityFromScore can't be used with scores relative to the current time",
+		},
+		{
+			name:          "script",
+			metadata:      map[string]string{"script": "return 1", "priorityFromScore": "true", "targetValue": "10"},
+			expectedError: "priorityFromScore can't be used together with script",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scaler, err := NewRedisScaler(context.Background(), false, false, &scalersconfig.ScalerConfig{
+				TriggerMetadata: tc.metadata,
+				AuthParams:      map[string]string{"address": server.Addr()},
+			})
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			defer scaler.Close(context.Background())
+
+			priorities, err := scaler.(PriorityScaler).GetPriorities(context.Background(), "s0-redis-tasks")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedPriorities, priorities)
+		})
+	}
+}
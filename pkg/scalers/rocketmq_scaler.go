@@ -0,0 +1,552 @@
+package scalers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // HMAC-SHA1 is the signature of the RocketMQ ACL
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// request codes of the RocketMQ remoting protocol, the one used by mqadmin
+	rocketmqGetRouteInfoByTopicCode = 105
+	rocketmqGetConsumeStatsCode     = 208
+
+	rocketmqSuccessCode        = 0
+	rocketmqResponseFlag       = 1
+	rocketmqRemotingVersion    = 317
+	rocketmqJSONSerialization  = 0
+	rocketmqMasterBrokerID     = "0"
+	rocketmqMaxFrameLength     = 16 << 20
+	rocketmqAccessKeyField     = "AccessKey"
+	rocketmqSignatureField     = "Signature"
+	rocketmqDefaultDialTimeout = 5 * time.Second
+)
+
+type rocketmqScaler struct {
+	metricType  v2.MetricTargetType
+	metadata    *rocketmqMetadata
+	tlsConfig   *tls.Config
+	dialTimeout time.Duration
+	opaque      atomic.Int32
+	logger      logr.Logger
+}
+
+type rocketmqMetadata struct {
+	triggerIndex int
+
+	// NameServer is the list of the RocketMQ name servers, e.g. rocketmq-namesrv:9876
+	NameServer             []string `keda:"name=nameServer,             order=triggerMetadata;authParams;resolvedEnv"`
+	ConsumerGroup          string   `keda:"name=consumerGroup,          order=triggerMetadata"`
+	Topic                  string   `keda:"name=topic,                  order=triggerMetadata"`
+	LagThreshold           int64    `keda:"name=lagThreshold,           order=triggerMetadata, default=10"`
+	ActivationLagThreshold int64    `keda:"name=activationLagThreshold, order=triggerMetadata, default=0"`
+	Operation              string   `keda:"name=operation,              order=triggerMetadata, enum=sum;max, default=sum"`
+
+	// ACL
+	AccessKey string `keda:"name=accessKey, order=authParams;resolvedEnv, optional"`
+	SecretKey string `keda:"name=secretKey, order=authParams;resolvedEnv, optional"`
+
+	// TLS
+	TLS         string `keda:"name=tls,         order=triggerMetadata;authParams, enum=enable;disable, default=disable"`
+	UnsafeSsl   bool   `keda:"name=unsafeSsl,   order=triggerMetadata, default=false"`
+	CA          string `keda:"name=ca,          order=authParams, optional"`
+	Cert        string `keda:"name=cert,        order=authParams, optional"`
+	Key         string `keda:"name=key,         order=authParams, optional"`
+	KeyPassword string `keda:"name=keyPassword, order=authParams, optional"`
+}
+
+// rocketmqRemotingCommand is the header of a command of the RocketMQ remoting protocol, serialized in JSON
+type rocketmqRemotingCommand struct {
+	Code      int               `json:"code"`
+	Language  string            `json:"language"`
+	Version   int               `json:"version"`
+	Opaque    int32             `json:"opaque"`
+	Flag      int               `json:"flag"`
+	Remark    string            `json:"remark,omitempty"`
+	ExtFields map[string]string `json:"extFields,omitempty"`
+}
+
+// rocketmqTopicRouteData is the route of a topic returned by the name servers
+type rocketmqTopicRouteData struct {
+	BrokerDatas []rocketmqBrokerData `json:"brokerDatas"`
+}
+
+// rocketmqBrokerData holds the addresses of a broker, indexed by broker id, 0 being the master
+type rocketmqBrokerData struct {
+	BrokerName  string            `json:"brokerName"`
+	BrokerAddrs map[string]string `json:"brokerAddrs"`
+}
+
+// rocketmqConsumeStats holds the offsets of a consumer group, indexed by message queue, on a broker
+type rocketmqConsumeStats struct {
+	OffsetTable map[string]rocketmqOffsetWrapper `json:"offsetTable"`
+}
+
+type rocketmqMessageQueue struct {
+	Topic      string `json:"topic"`
+	BrokerName string `json:"brokerName"`
+	QueueID    int    `json:"queueId"`
+}
+
+type rocketmqOffsetWrapper struct {
+	BrokerOffset   int64 `json:"brokerOffset"`
+	ConsumerOffset int64 `json:"consumerOffset"`
+}
+
+func (m *rocketmqMetadata) Validate() error {
+	for _, nameServer := range m.NameServer {
+		if _, _, err := net.SplitHostPort(nameServer); err != nil {
+			return fmt.Errorf("invalid nameServer %q: %w", nameServer, err)
+		}
+	}
+	if m.LagThreshold <= 0 {
+		return fmt.Errorf("lagThreshold must be a positive number")
+	}
+	if m.ActivationLagThreshold < 0 {
+		return fmt.Errorf("activationLagThreshold must be a non-negative number")
+	}
+	if (m.AccessKey == "") != (m.SecretKey == "") {
+		return fmt.Errorf("both accessKey and secretKey must be provided when using ACL")
+	}
+	if (m.Cert == "") != (m.Key == "") {
+		return fmt.Errorf("both cert and key must be provided when using TLS")
+	}
+	return nil
+}
+
+// NewRocketMQScaler creates a new RocketMQ scaler
+func NewRocketMQScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseRocketMQMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing RocketMQ metadata: %w", err)
+	}
+
+	var tlsConfig *tls.Config
+	if meta.TLS == "enable" {
+		tlsConfig, err = kedautil.NewTLSConfigWithPassword(meta.Cert, meta.Key, meta.KeyPassword, meta.CA, meta.UnsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dialTimeout := config.GlobalHTTPTimeout
+	if dialTimeout <= 0 {
+		dialTimeout = rocketmqDefaultDialTimeout
+	}
+
+	return &rocketmqScaler{
+		metricType:  metricType,
+		metadata:    meta,
+		tlsConfig:   tlsConfig,
+		dialTimeout: dialTimeout,
+		logger:      InitializeLogger(config, "rocketmq_scaler"),
+	}, nil
+}
+
+func parseRocketMQMetadata(config *scalersconfig.ScalerConfig) (*rocketmqMetadata, error) {
+	meta := &rocketmqMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// getConsumerGroupLag returns the lag of the consumer group on the topic, computed from the
+// per queue offsets reported by every master broker hosting the topic
+func (s *rocketmqScaler) getConsumerGroupLag(ctx context.Context) (int64, error) {
+	route, err := s.getTopicRoute(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	var queues []rocketmqOffsetWrapper
+	for _, broker := range route.BrokerDatas {
+		addr, ok := broker.BrokerAddrs[rocketmqMasterBrokerID]
+		if !ok {
+			continue
+		}
+		body, err := s.invoke(ctx, addr, rocketmqGetConsumeStatsCode, map[string]string{
+			"consumerGroup": s.metadata.ConsumerGroup,
+			"topic":         s.metadata.Topic,
+		})
+		if err != nil {
+			return -1, fmt.Errorf("error getting the consume stats from broker %s: %w", broker.BrokerName, err)
+		}
+
+		var stats rocketmqConsumeStats
+		if err := unmarshalRocketMQJSON(body, &stats); err != nil {
+			return -1, fmt.Errorf("error parsing the consume stats of broker %s: %w", broker.BrokerName, err)
+		}
+		for key, offsets := range stats.OffsetTable {
+			var queue rocketmqMessageQueue
+			if err := json.Unmarshal([]byte(key), &queue); err != nil {
+				return -1, fmt.Errorf("error parsing the message queue of broker %s: %w", broker.BrokerName, err)
+			}
+			if queue.Topic == s.metadata.Topic {
+				queues = append(queues, offsets)
+			}
+		}
+	}
+
+	if len(queues) == 0 {
+		return -1, fmt.Errorf("consumer group %s has no offsets for topic %s", s.metadata.ConsumerGroup, s.metadata.Topic)
+	}
+	return calculateRocketMQLag(queues, s.metadata.Operation), nil
+}
+
+// getTopicRoute returns the route of the topic from the first name server answering
+func (s *rocketmqScaler) getTopicRoute(ctx context.Context) (*rocketmqTopicRouteData, error) {
+	var errs []error
+	for _, nameServer := range s.metadata.NameServer {
+		body, err := s.invoke(ctx, nameServer, rocketmqGetRouteInfoByTopicCode, map[string]string{"topic": s.metadata.Topic})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error getting the route of topic %s from name server %s: %w", s.metadata.Topic, nameServer, err))
+			continue
+		}
+		route := &rocketmqTopicRouteData{}
+		if err := unmarshalRocketMQJSON(body, route); err != nil {
+			return nil, fmt.Errorf("error parsing the route of topic %s: %w", s.metadata.Topic, err)
+		}
+		return route, nil
+	}
+	return nil, errors.Join(errs...)
+}
+
+// invoke sends a request to the RocketMQ server and returns the body of its response,
+// an error is returned if the response code isn't a success
+func (s *rocketmqScaler) invoke(ctx context.Context, addr string, code int, extFields map[string]string) ([]byte, error) {
+	request := &rocketmqRemotingCommand{
+		Code:      code,
+		Language:  "GO",
+		Version:   rocketmqRemotingVersion,
+		Opaque:    s.opaque.Add(1),
+		ExtFields: extFields,
+	}
+	if s.metadata.AccessKey != "" {
+		signRocketMQRequest(request, s.metadata.AccessKey, s.metadata.SecretKey)
+	}
+
+	conn, err := s.dial(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(s.dialTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	if err := writeRocketMQCommand(conn, request); err != nil {
+		return nil, err
+	}
+	response, body, err := readRocketMQCommand(conn)
+	if err != nil {
+		return nil, err
+	}
+	if response.Flag&rocketmqResponseFlag == 0 || response.Opaque != request.Opaque {
+		return nil, fmt.Errorf("unexpected response to request %d", request.Opaque)
+	}
+	if response.Code != rocketmqSuccessCode {
+		return nil, fmt.Errorf("request failed with code %d: %s", response.Code, response.Remark)
+	}
+	return body, nil
+}
+
+func (s *rocketmqScaler) dial(ctx context.Context, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: s.dialTimeout}
+	if s.tlsConfig != nil {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}
+		return tlsDialer.DialContext(ctx, "tcp", addr)
+	}
+	return dialer.DialContext(ctx, "tcp", addr)
+}
+
+// signRocketMQRequest adds the RocketMQ ACL signature to the request, the HMAC-SHA1 of the values
+// of its fields sorted by name. The requests sent by the scaler don't have a body
+func signRocketMQRequest(request *rocketmqRemotingCommand, accessKey, secretKey string) {
+	fields := make(map[string]string, len(request.ExtFields)+2)
+	for name, value := range request.ExtFields {
+		fields[name] = value
+	}
+	fields[rocketmqAccessKeyField] = accessKey
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	mac := hmac.New(sha1.New, []byte(secretKey))
+	for _, name := range names {
+		mac.Write([]byte(fields[name]))
+	}
+	fields[rocketmqSignatureField] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	request.ExtFields = fields
+}
+
+// writeRocketMQCommand writes the frame of a command without body, made of the frame length,
+// the serialization type and header length, and the header
+func writeRocketMQCommand(w io.Writer, command *rocketmqRemotingCommand) error {
+	header, err := json.Marshal(command)
+	if err != nil {
+		return err
+	}
+	frame := make([]byte, 8, 8+len(header))
+	binary.BigEndian.PutUint32(frame[0:4], uint32(4+len(header)))
+	binary.BigEndian.PutUint32(frame[4:8], uint32(rocketmqJSONSerialization<<24|len(header)))
+	_, err = w.Write(append(frame, header...))
+	return err
+}
+
+// readRocketMQCommand reads the frame of a command and returns its header and body
+func readRocketMQCommand(r io.Reader) (*rocketmqRemotingCommand, []byte, error) {
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, nil, fmt.Errorf("error reading response: %w", err)
+	}
+	if length < 4 || length > rocketmqMaxFrameLength {
+		return nil, nil, fmt.Errorf("invalid response length %d", length)
+	}
+	frame := make([]byte, length)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		return nil, nil, fmt.Errorf("error reading response: %w", err)
+	}
+
+	headerLength := binary.BigEndian.Uint32(frame[0:4]) & 0xFFFFFF
+	if frame[0] != rocketmqJSONSerialization {
+		return nil, nil, fmt.Errorf("unsupported response serialization type %d", frame[0])
+	}
+	if headerLength > length-4 {
+		return nil, nil, fmt.Errorf("invalid response header length %d", headerLength)
+	}
+	command := &rocketmqRemotingCommand{}
+	if err := json.Unmarshal(frame[4:4+headerLength], command); err != nil {
+		return nil, nil, fmt.Errorf("error parsing response header: %w", err)
+	}
+	return command, frame[4+headerLength:], nil
+}
+
+// unmarshalRocketMQJSON parses the JSON written by the RocketMQ servers. It isn't standard JSON,
+// the keys of the maps which aren't strings, like broker ids or message queues, are written unquoted.
+// They are turned into strings holding their JSON before parsing
+func unmarshalRocketMQJSON(data []byte, v any) error {
+	n := &rocketmqJSONNormalizer{data: data}
+	if err := n.value(); err != nil {
+		return err
+	}
+	return json.Unmarshal(n.out.Bytes(), v)
+}
+
+type rocketmqJSONNormalizer struct {
+	data []byte
+	pos  int
+	out  bytes.Buffer
+}
+
+func (n *rocketmqJSONNormalizer) skipSpaces() {
+	for n.pos < len(n.data) && strings.IndexByte(" \t\r\n", n.data[n.pos]) >= 0 {
+		n.pos++
+	}
+}
+
+func (n *rocketmqJSONNormalizer) value() error {
+	n.skipSpaces()
+	if n.pos >= len(n.data) {
+		return io.ErrUnexpectedEOF
+	}
+	switch n.data[n.pos] {
+	case '{':
+		return n.object()
+	case '[':
+		return n.array()
+	default:
+		start := n.pos
+		if err := n.skipValue(); err != nil {
+			return err
+		}
+		n.out.Write(n.data[start:n.pos])
+		return nil
+	}
+}
+
+func (n *rocketmqJSONNormalizer) object() error {
+	n.pos++
+	n.out.WriteByte('{')
+	for {
+		n.skipSpaces()
+		if n.pos >= len(n.data) {
+			return io.ErrUnexpectedEOF
+		}
+		switch n.data[n.pos] {
+		case '}':
+			n.pos++
+			n.out.WriteByte('}')
+			return nil
+		case ',':
+			n.pos++
+			n.out.WriteByte(',')
+			continue
+		}
+
+		start := n.pos
+		if err := n.skipValue(); err != nil {
+			return err
+		}
+		key := n.data[start:n.pos]
+		if len(key) == 0 {
+			return fmt.Errorf("expected a key at offset %d", n.pos)
+		}
+		if key[0] == '"' {
+			n.out.Write(key)
+		} else {
+			quoted, _ := json.Marshal(string(key))
+			n.out.Write(quoted)
+		}
+
+		n.skipSpaces()
+		if n.pos >= len(n.data) || n.data[n.pos] != ':' {
+			return fmt.Errorf("expected ':' at offset %d", n.pos)
+		}
+		n.pos++
+		n.out.WriteByte(':')
+		if err := n.value(); err != nil {
+			return err
+		}
+	}
+}
+
+func (n *rocketmqJSONNormalizer) array() error {
+	n.pos++
+	n.out.WriteByte('[')
+	for {
+		n.skipSpaces()
+		if n.pos >= len(n.data) {
+			return io.ErrUnexpectedEOF
+		}
+		switch n.data[n.pos] {
+		case ']':
+			n.pos++
+			n.out.WriteByte(']')
+			return nil
+		case ',':
+			n.pos++
+			n.out.WriteByte(',')
+			continue
+		}
+		if err := n.value(); err != nil {
+			return err
+		}
+	}
+}
+
+// skipValue moves past the value at the current position, without normalizing it
+func (n *rocketmqJSONNormalizer) skipValue() error {
+	depth := 0
+	for n.pos < len(n.data) {
+		c := n.data[n.pos]
+		switch {
+		case c == '"':
+			n.pos++
+			for n.pos < len(n.data) && n.data[n.pos] != '"' {
+				if n.data[n.pos] == '\\' {
+					n.pos++
+				}
+				n.pos++
+			}
+			if n.pos >= len(n.data) {
+				return io.ErrUnexpectedEOF
+			}
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			if depth == 0 {
+				return nil
+			}
+			depth--
+		case depth == 0 && strings.IndexByte(",: \t\r\n", c) >= 0:
+			return nil
+		}
+		n.pos++
+		if depth == 0 && (c == '"' || c == '}' || c == ']') {
+			return nil
+		}
+	}
+	if depth > 0 {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+// calculateRocketMQLag sums or maxes the lag of every queue. Queues with a consumer offset ahead of
+// the broker offset, which can briefly happen while offsets are being committed, count as no lag
+func calculateRocketMQLag(queues []rocketmqOffsetWrapper, operation string) int64 {
+	var result int64
+	for _, queue := range queues {
+		lag := max(queue.BrokerOffset-queue.ConsumerOffset, 0)
+		switch operation {
+		case maxOperation:
+			result = max(result, lag)
+		default:
+			result += lag
+		}
+	}
+	return result
+}
+
+func (s *rocketmqScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("rocketmq-%s-%s", s.metadata.ConsumerGroup, s.metadata.Topic))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.LagThreshold),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *rocketmqScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	lag, err := s.getConsumerGroupLag(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting consumer group lag")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(lag))
+
+	return []external_metrics.ExternalMetricValue{metric}, lag > s.metadata.ActivationLagThreshold, nil
+}
+
+// Close does nothing, a connection is opened for each request
+func (s *rocketmqScaler) Close(context.Context) error {
+	return nil
+}
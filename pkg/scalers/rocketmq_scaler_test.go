@@ -0,0 +1,419 @@
+package scalers
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseRocketMQMetadataTestData struct {
+	name        string
+	metadata    map[string]string
+	authParams  map[string]string
+	resolvedEnv map[string]string
+	isError     bool
+	expected    *rocketmqMetadata
+}
+
+type rocketmqMetricIdentifier struct {
+	triggerIndex int
+	name         string
+}
+
+var testRocketMQMetadata = []parseRocketMQMetadataTestData{
+	{
+		name:     "properly formed",
+		metadata: map[string]string{"nameServer": "namesrv-0:9876,namesrv-1:9876", "consumerGroup": "cg", "topic": "orders", "lagThreshold": "50", "activationLagThreshold": "3", "operation": "max"},
+		expected: &rocketmqMetadata{
+			NameServer:             []string{"namesrv-0:9876", "namesrv-1:9876"},
+			ConsumerGroup:          "cg",
+			Topic:                  "orders",
+			LagThreshold:           50,
+			ActivationLagThreshold: 3,
+			Operation:              "max",
+			TLS:                    "disable",
+		},
+	},
+	{
+		name:        "defaults with name server from env and auth params",
+		metadata:    map[string]string{"nameServerFromEnv": "ROCKETMQ_NAMESRV", "consumerGroup": "cg", "topic": "orders"},
+		resolvedEnv: map[string]string{"ROCKETMQ_NAMESRV": "namesrv:9876"},
+		authParams:  map[string]string{"accessKey": "admin", "secretKey": "secret", "tls": "enable", "ca": "caaa"},
+		expected: &rocketmqMetadata{
+			NameServer:    []string{"namesrv:9876"},
+			ConsumerGroup: "cg",
+			Topic:         "orders",
+			LagThreshold:  10,
+			Operation:     "sum",
+			AccessKey:     "admin",
+			SecretKey:     "secret",
+			TLS:           "enable",
+			CA:            "caaa",
+		},
+	},
+	{
+		name:     "missing nameServer",
+		metadata: map[string]string{"consumerGroup": "cg", "topic": "orders"},
+		isError:  true,
+	},
+	{
+		name:     "nameServer without port",
+		metadata: map[string]string{"nameServer": "namesrv", "consumerGroup": "cg", "topic": "orders"},
+		isError:  true,
+	},
+	{
+		name:     "missing consumerGroup",
+		metadata: map[string]string{"nameServer": "namesrv:9876", "topic": "orders"},
+		isError:  true,
+	},
+	{
+		name:     "missing topic",
+		metadata: map[string]string{"nameServer": "namesrv:9876", "consumerGroup": "cg"},
+		isError:  true,
+	},
+	{
+		name:     "invalid lagThreshold",
+		metadata: map[string]string{"nameServer": "namesrv:9876", "consumerGroup": "cg", "topic": "orders", "lagThreshold": "0"},
+		isError:  true,
+	},
+	{
+		name:     "negative activationLagThreshold",
+		metadata: map[string]string{"nameServer": "namesrv:9876", "consumerGroup": "cg", "topic": "orders", "activationLagThreshold": "-1"},
+		isError:  true,
+	},
+	{
+		name:     "unknown operation",
+		metadata: map[string]string{"nameServer": "namesrv:9876", "consumerGroup": "cg", "topic": "orders", "operation": "avg"},
+		isError:  true,
+	},
+	{
+		name:       "secretKey without accessKey",
+		metadata:   map[string]string{"nameServer": "namesrv:9876", "consumerGroup": "cg", "topic": "orders"},
+		authParams: map[string]string{"secretKey": "secret"},
+		isError:    true,
+	},
+	{
+		name:       "cert without key",
+		metadata:   map[string]string{"nameServer": "namesrv:9876", "consumerGroup": "cg", "topic": "orders"},
+		authParams: map[string]string{"tls": "enable", "cert": "ceert"},
+		isError:    true,
+	},
+}
+
+var rocketmqMetricIdentifiers = []rocketmqMetricIdentifier{
+	{0, "s0-rocketmq-cg-orders"},
+	{1, "s1-rocketmq-cg-orders"},
+}
+
+func TestRocketMQParseMetadata(t *testing.T) {
+	for _, testData := range testRocketMQMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			meta, err := parseRocketMQMetadata(&scalersconfig.ScalerConfig{
+				TriggerMetadata: testData.metadata,
+				AuthParams:      testData.authParams,
+				ResolvedEnv:     testData.resolvedEnv,
+			})
+			if testData.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, meta)
+		})
+	}
+}
+
+func TestRocketMQGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range rocketmqMetricIdentifiers {
+		meta, err := parseRocketMQMetadata(&scalersconfig.ScalerConfig{
+			TriggerMetadata: testRocketMQMetadata[0].metadata,
+			TriggerIndex:    testData.triggerIndex,
+		})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockRocketMQScaler := rocketmqScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockRocketMQScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+// startFakeRocketMQServer starts a fake RocketMQ server answering each request with the code and body returned by handle
+func startFakeRocketMQServer(t *testing.T, handle func(request *rocketmqRemotingCommand) (int, string)) string {
+	return startRocketMQFrameServer(t, func(request *rocketmqRemotingCommand) []byte {
+		code, body := handle(request)
+		header, _ := json.Marshal(&rocketmqRemotingCommand{Code: code, Opaque: request.Opaque, Flag: rocketmqResponseFlag, Remark: body})
+		return rocketmqFrame(string(header), body)
+	})
+}
+
+// startRocketMQFrameServer starts a fake RocketMQ server answering each request with the frame returned by handle
+func startRocketMQFrameServer(t *testing.T, handle func(request *rocketmqRemotingCommand) []byte) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				request, _, err := readRocketMQCommand(conn)
+				if err != nil {
+					return
+				}
+				_, _ = conn.Write(handle(request))
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+// rocketmqFrame returns the frame of a response with the given JSON header and body
+func rocketmqFrame(header, body string) []byte {
+	frame := binary.BigEndian.AppendUint32(nil, uint32(4+len(header)+len(body)))
+	frame = binary.BigEndian.AppendUint32(frame, uint32(len(header)))
+	frame = append(frame, header...)
+	return append(frame, body...)
+}
+
+// rocketmqConsumeStatsBody writes the consume stats the way the brokers do, with message queues as keys
+func rocketmqConsumeStatsBody(brokerName string, offsets ...[2]int64) string {
+	var entries []string
+	for i, offset := range offsets {
+		entries = append(entries, fmt.Sprintf(`{"brokerName":"%s","queueId":%d,"topic":"orders"}:{"brokerOffset":%d,"consumerOffset":%d,"lastTimestamp":0}`, brokerName, i, offset[0], offset[1]))
+	}
+	// the retry topic of the group must be ignored
+	entries = append(entries, fmt.Sprintf(`{"brokerName":"%s","queueId":0,"topic":"%%RETRY%%cg"}:{"brokerOffset":1000,"consumerOffset":0,"lastTimestamp":0}`, brokerName))
+	return fmt.Sprintf(`{"consumeTps":0.0,"offsetTable":{%s}}`, strings.Join(entries, ","))
+}
+
+func TestRocketMQGetMetricsAndActivity(t *testing.T) {
+	testCases := []struct {
+		name           string
+		operation      string
+		brokerA        string
+		brokerACode    int
+		brokerB        string
+		routeCode      int
+		expectedValue  int64
+		expectedActive bool
+		expectedError  string
+	}{
+		{
+			name:           "sum across brokers",
+			operation:      "sum",
+			brokerA:        rocketmqConsumeStatsBody("broker-a", [2]int64{120, 100}, [2]int64{80, 80}),
+			brokerB:        rocketmqConsumeStatsBody("broker-b", [2]int64{60, 48}, [2]int64{10, 7}, [2]int64{5, 9}),
+			expectedValue:  35,
+			expectedActive: true,
+		},
+		{
+			name:           "max across brokers",
+			operation:      "max",
+			brokerA:        rocketmqConsumeStatsBody("broker-a", [2]int64{120, 100}, [2]int64{80, 80}),
+			brokerB:        rocketmqConsumeStatsBody("broker-b", [2]int64{60, 48}, [2]int64{10, 7}, [2]int64{5, 9}),
+			expectedValue:  20,
+			expectedActive: true,
+		},
+		{
+			name:           "no lag",
+			operation:      "sum",
+			brokerA:        rocketmqConsumeStatsBody("broker-a", [2]int64{7, 7}),
+			brokerB:        `{"consumeTps":0.0,"offsetTable":{}}`,
+			expectedValue:  0,
+			expectedActive: false,
+		},
+		{
+			name:          "topic not consumed by the group",
+			operation:     "sum",
+			brokerA:       `{"consumeTps":0.0,"offsetTable":{}}`,
+			brokerB:       `{"consumeTps":0.0,"offsetTable":{}}`,
+			expectedError: "consumer group cg has no offsets for topic orders",
+		},
+		{
+			name:          "broker error",
+			operation:     "sum",
+			brokerA:       "the consumer group not exist",
+			brokerACode:   26,
+			expectedError: "request failed with code 26: the consumer group not exist",
+		},
+		{
+			name:          "topic not found",
+			operation:     "sum",
+			routeCode:     17,
+			expectedError: "request failed with code 17",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checkACL := func(request *rocketmqRemotingCommand) {
+				signed := &rocketmqRemotingCommand{ExtFields: map[string]string{}}
+				for name, value := range request.ExtFields {
+					if name != rocketmqAccessKeyField && name != rocketmqSignatureField {
+						signed.ExtFields[name] = value
+					}
+				}
+				signRocketMQRequest(signed, "admin", "secret")
+				assert.Equal(t, "admin", request.ExtFields[rocketmqAccessKeyField])
+				assert.Equal(t, signed.ExtFields[rocketmqSignatureField], request.ExtFields[rocketmqSignatureField])
+			}
+			brokerHandler := func(body string, code int) func(*rocketmqRemotingCommand) (int, string) {
+				return func(request *rocketmqRemotingCommand) (int, string) {
+					checkACL(request)
+					assert.Equal(t, rocketmqGetConsumeStatsCode, request.Code)
+					assert.Equal(t, "cg", request.ExtFields["consumerGroup"])
+					assert.Equal(t, "orders", request.ExtFields["topic"])
+					return code, body
+				}
+			}
+			brokerA := startFakeRocketMQServer(t, brokerHandler(tc.brokerA, tc.brokerACode))
+			brokerB := startFakeRocketMQServer(t, brokerHandler(tc.brokerB, 0))
+			nameServer := startFakeRocketMQServer(t, func(request *rocketmqRemotingCommand) (int, string) {
+				checkACL(request)
+				assert.Equal(t, rocketmqGetRouteInfoByTopicCode, request.Code)
+				assert.Equal(t, "orders", request.ExtFields["topic"])
+				// the broker ids are written unquoted, only the masters are queried
+				return tc.routeCode, fmt.Sprintf(`{"brokerDatas":[{"brokerAddrs":{0:"%s",1:"127.0.0.1:1"},"brokerName":"broker-a","cluster":"c"},{"brokerAddrs":{0:"%s"},"brokerName":"broker-b","cluster":"c"}],"queueDatas":[]}`, brokerA, brokerB)
+			})
+
+			s := &rocketmqScaler{
+				metadata: &rocketmqMetadata{
+					// the first name server is down
+					NameServer:             []string{"127.0.0.1:1", nameServer},
+					ConsumerGroup:          "cg",
+					Topic:                  "orders",
+					LagThreshold:           10,
+					ActivationLagThreshold: 5,
+					Operation:              tc.operation,
+					AccessKey:              "admin",
+					SecretKey:              "secret",
+				},
+				dialTimeout: time.Second,
+				logger:      logr.Discard(),
+			}
+
+			metrics, active, err := s.GetMetricsAndActivity(context.Background(), "s0-rocketmq-cg-orders")
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedActive, active)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+		})
+	}
+}
+
+func TestUnmarshalRocketMQJSON(t *testing.T) {
+	var v map[string]any
+	err := unmarshalRocketMQJSON([]byte(`{"a":{0:"x", 1 : "y"},"b":[{"c":"}:,\"{"},{{"k":[1,2]}:true}],"d":null}`), &v)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"a": map[string]any{"0": "x", "1": "y"},
+		"b": []any{map[string]any{"c": "}:,\"{"}, map[string]any{`{"k":[1,2]}`: true}},
+		"d": nil,
+	}, v)
+
+	assert.Error(t, unmarshalRocketMQJSON([]byte(`{"a":{0:"x"}`), &v))
+}
+
+// The responses of a RocketMQ 5.1 cluster of two brokers, each with a master and a slave, written the way
+// the Java name server and brokers serialize them with fastjson: the header has the fields of the Java RemotingCommand,
+// the broker ids and the message queues keys of the maps are written unquoted, and the bodies have fields the scaler ignores.
+const (
+	rocketmqFixtureHeader = `{"code":%d,"extFields":{},"flag":1,"language":"JAVA","opaque":%d,%s"serializeTypeCurrentRPC":"JSON","version":453}`
+
+	rocketmqFixtureTopicRoute = `{"brokerDatas":[{"brokerAddrs":{0:"%s",1:"10.0.0.12:10911"},"brokerName":"broker-a","cluster":"DefaultCluster","enableActingMaster":false},` +
+		`{"brokerAddrs":{0:"%s",1:"10.0.0.22:10911"},"brokerName":"broker-b","cluster":"DefaultCluster","enableActingMaster":false}],"filterServerTable":{},` +
+		`"queueDatas":[{"brokerName":"broker-a","perm":6,"readQueueNums":2,"topicSysFlag":0,"writeQueueNums":2},{"brokerName":"broker-b","perm":6,"readQueueNums":2,"topicSysFlag":0,"writeQueueNums":2}]}`
+
+	rocketmqFixtureConsumeStatsA = `{"consumeTps":1.5,"offsetTable":{{"brokerName":"broker-a","queueId":1,"topic":"orders"}:{"brokerOffset":250,"consumerOffset":240,"lastTimestamp":1712131415161,"pullOffset":245},` +
+		`{"brokerName":"broker-a","queueId":0,"topic":"orders"}:{"brokerOffset":300,"consumerOffset":300,"lastTimestamp":1712131415000,"pullOffset":300}}}`
+
+	rocketmqFixtureConsumeStatsB = `{"consumeTps":0.0,"offsetTable":{{"brokerName":"broker-b","queueId":0,"topic":"orders"}:{"brokerOffset":1200,"consumerOffset":1150,"lastTimestamp":1712131409876,"pullOffset":1160},` +
+		`{"brokerName":"broker-b","queueId":1,"topic":"orders"}:{"brokerOffset":80,"consumerOffset":80,"lastTimestamp":0,"pullOffset":80}}}`
+
+	rocketmqFixtureNoRouteRemark = `"remark":"No topic route info in name server for the topic: orders\nSee https://rocketmq.apache.org/docs/bestPractice/06FAQ for further details.",`
+)
+
+func TestRocketMQGetMetricsAndActivityFromClusterResponses(t *testing.T) {
+	testCases := []struct {
+		name          string
+		operation     string
+		routeCode     int
+		routeRemark   string
+		expectedValue int64
+		expectedError string
+	}{
+		{name: "sum", operation: "sum", expectedValue: 60},
+		{name: "max", operation: "max", expectedValue: 50},
+		{
+			name:          "topic not found",
+			operation:     "sum",
+			routeCode:     17,
+			routeRemark:   rocketmqFixtureNoRouteRemark,
+			expectedError: "request failed with code 17: No topic route info in name server for the topic: orders\nSee",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			broker := func(body string) func(*rocketmqRemotingCommand) []byte {
+				return func(request *rocketmqRemotingCommand) []byte {
+					return rocketmqFrame(fmt.Sprintf(rocketmqFixtureHeader, 0, request.Opaque, ""), body)
+				}
+			}
+			brokerA := startRocketMQFrameServer(t, broker(rocketmqFixtureConsumeStatsA))
+			brokerB := startRocketMQFrameServer(t, broker(rocketmqFixtureConsumeStatsB))
+			nameServer := startRocketMQFrameServer(t, func(request *rocketmqRemotingCommand) []byte {
+				header := fmt.Sprintf(rocketmqFixtureHeader, tc.routeCode, request.Opaque, tc.routeRemark)
+				if tc.routeCode != rocketmqSuccessCode {
+					return rocketmqFrame(header, "")
+				}
+				return rocketmqFrame(header, fmt.Sprintf(rocketmqFixtureTopicRoute, brokerA, brokerB))
+			})
+
+			s := &rocketmqScaler{
+				metadata: &rocketmqMetadata{
+					NameServer:    []string{nameServer},
+					ConsumerGroup: "cg",
+					Topic:         "orders",
+					LagThreshold:  10,
+					Operation:     tc.operation,
+				},
+				dialTimeout: time.Second,
+				logger:      logr.Discard(),
+			}
+
+			metrics, active, err := s.GetMetricsAndActivity(context.Background(), "s0-rocketmq-cg-orders")
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, active)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+		})
+	}
+}
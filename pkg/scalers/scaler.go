@@ -62,6 +62,15 @@ type PushScaler interface {
 	Run(ctx context.Context, active chan<- bool)
 }
 
+// PriorityScaler interface is implemented by the scalers able to tell the priority of the units of work behind a metric
+type PriorityScaler interface {
+	Scaler
+
+	// GetPriorities returns the priority of each unit of work of the metric, the work of the higher
+	// priorities gets its Jobs first in a ScaledJob with the priority job creation order
+	GetPriorities(ctx context.Context, metricName string) ([]int64, error)
+}
+
 var (
 	// ErrScalerUnsupportedUtilizationMetricType is returned when v2.UtilizationMetricType
 	// is provided as the metric target type for scaler.
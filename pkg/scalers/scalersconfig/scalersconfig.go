@@ -17,6 +17,9 @@ limitations under the License.
 package scalersconfig
 
 import (
+	"context"
+	"net/url"
+	"strings"
 	"time"
 
 	v2 "k8s.io/api/autoscaling/v2"
@@ -43,6 +46,9 @@ type ScalerConfig struct {
 	// Name of the trigger
 	TriggerName string
 
+	// Name overriding the auto-generated name of the external metric of the trigger
+	TriggerMetricName string
+
 	// Trigger type (name of the trigger, also the scaler name)
 	TriggerType string
 
@@ -50,6 +56,9 @@ type ScalerConfig struct {
 	// Any requests for metrics in between are read from the cache
 	TriggerUseCachedMetrics bool
 
+	// Overrides the polling interval of the ScaledObject for the trigger, zero when it doesn't
+	TriggerPollingInterval time.Duration
+
 	// TriggerMetadata
 	TriggerMetadata map[string]string
 
@@ -59,6 +68,9 @@ type ScalerConfig struct {
 	// AuthParams
 	AuthParams map[string]string
 
+	// ResolveAuthParams resolves the AuthParams again, for the scalers whose credentials rotate while they run
+	ResolveAuthParams func(ctx context.Context) (map[string]string, error)
+
 	// PodIdentity
 	PodIdentity kedav1alpha1.AuthPodIdentity
 
@@ -79,4 +91,76 @@ type ScalerConfig struct {
 
 	// ScaledObjct
 	ScaledObject runtime.Object
+
+	// Labels of the pod template of the scale target, nil when it has none or it isn't resolved
+	ScaleTargetPodLabels map[string]string
+
+	// TLSConfigCache is shared by the triggers of the scalable object, nil when the scaler is built on its own
+	TLSConfigCache *TLSConfigCache
+}
+
+// RedactedValue is used instead of any value that could contain a secret
+const RedactedValue = "<redacted>"
+
+// sensitiveKeyMarkers are the (lowercase) fragments of metadata keys whose values are treated as secrets
+var sensitiveKeyMarkers = []string{"password", "passwd", "secret", "token", "credential", "connection", "apikey", "accesskey", "privatekey"}
+
+// RedactedMetadata returns the metadata the trigger has been resolved with, suitable for exposing
+// on the ScaledObject status. Values resolved from env (`*FromEnv`) and from the authentication are
+// always redacted, as well as values of keys that look sensitive and credentials embedded in URLs.
+func (c *ScalerConfig) RedactedMetadata() map[string]string {
+	result := make(map[string]string, len(c.TriggerMetadata)+len(c.AuthParams))
+	for key, value := range c.TriggerMetadata {
+		if isSensitiveKey(key) {
+			result[key] = RedactedValue
+			continue
+		}
+		result[key] = redactURLCredentials(value)
+	}
+
+	// values resolved from the container env can originate from secrets, we never expose them
+	for key := range c.TriggerMetadata {
+		resolvedKey, fromEnv := strings.CutSuffix(key, "FromEnv")
+		if !fromEnv || resolvedKey == "" {
+			continue
+		}
+		if _, found := c.TriggerMetadata[resolvedKey]; !found {
+			result[resolvedKey] = RedactedValue
+		}
+	}
+
+	for key := range c.AuthParams {
+		result[key] = RedactedValue
+	}
+	return result
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	if strings.HasSuffix(key, "fromenv") {
+		// holds the name of the env variable, not its value
+		return false
+	}
+	if strings.HasSuffix(key, "key") {
+		return true
+	}
+	for _, marker := range sensitiveKeyMarkers {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURLCredentials masks the password of a URL, other values are returned as they are
+func redactURLCredentials(value string) string {
+	if !strings.Contains(value, "://") {
+		return value
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		// we can't tell which part is sensitive, so none of it is exposed
+		return RedactedValue
+	}
+	return u.Redacted()
 }
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalersconfig
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"sync"
+)
+
+// TLSConfigCache keeps the TLS configs parsed from the certificates of the authentication of the triggers,
+// so the triggers of a scalable object sharing a TriggerAuthentication parse its certificates only once
+type TLSConfigCache struct {
+	configs map[string]*tls.Config
+	lock    sync.Mutex
+}
+
+// NewTLSConfigCache creates an empty TLSConfigCache
+func NewTLSConfigCache() *TLSConfigCache {
+	return &TLSConfigCache{
+		configs: map[string]*tls.Config{},
+	}
+}
+
+// GetOrCreate returns a copy of the TLS config cached for the given certificate material, calling create
+// to parse it the first time. The copy can be modified by the caller, e.g. to set its ServerName.
+// A nil cache doesn't cache anything.
+func (c *TLSConfigCache) GetOrCreate(create func() (*tls.Config, error), material ...string) (*tls.Config, error) {
+	if c == nil {
+		return create()
+	}
+
+	key := tlsConfigCacheKey(material)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if config, ok := c.configs[key]; ok {
+		return config.Clone(), nil
+	}
+	config, err := create()
+	if err != nil {
+		return nil, err
+	}
+	c.configs[key] = config
+	return config.Clone(), nil
+}
+
+// tlsConfigCacheKey hashes the certificate material, the cache shouldn't keep the keys in clear
+func tlsConfigCacheKey(material []string) string {
+	hash := sha256.New()
+	for _, m := range material {
+		hash.Write([]byte(m))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,249 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+type sftpScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *sftpMetadata
+	sshConfig  *ssh.ClientConfig
+	logger     logr.Logger
+
+	// the connection is kept between polls and reopened after a failure
+	mu         sync.Mutex
+	sshClient  *ssh.Client
+	sftpClient *sftp.Client
+}
+
+type sftpMetadata struct {
+	Host            string  `keda:"name=host,            order=triggerMetadata;authParams;resolvedEnv"`
+	Port            int     `keda:"name=port,            order=triggerMetadata;authParams, default=22"`
+	Directory       string  `keda:"name=directory,       order=triggerMetadata"`
+	Pattern         string  `keda:"name=pattern,         order=triggerMetadata, optional"`
+	Value           float64 `keda:"name=value,           order=triggerMetadata, default=10"`
+	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, default=0"`
+
+	// Authentication
+	Username             string `keda:"name=username,             order=authParams;resolvedEnv;triggerMetadata"`
+	Password             string `keda:"name=password,             order=authParams;resolvedEnv, optional"`
+	PrivateKey           string `keda:"name=privateKey,           order=authParams, optional"`
+	PrivateKeyPassphrase string `keda:"name=privateKeyPassphrase, order=authParams, optional"`
+
+	// HostKey is the public key of the server in authorized_keys format, it is verified unless UnsafeIgnoreHostKey is set
+	HostKey             string `keda:"name=hostKey,             order=authParams;triggerMetadata, optional"`
+	UnsafeIgnoreHostKey bool   `keda:"name=unsafeIgnoreHostKey, order=triggerMetadata, default=false"`
+
+	triggerIndex int
+}
+
+func (m *sftpMetadata) Validate() error {
+	if m.Port <= 0 || m.Port > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535, got %d", m.Port)
+	}
+	if m.Pattern != "" {
+		if _, err := path.Match(m.Pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", m.Pattern, err)
+		}
+	}
+	if m.Password == "" && m.PrivateKey == "" {
+		return fmt.Errorf("either password or privateKey must be provided")
+	}
+	if m.PrivateKeyPassphrase != "" && m.PrivateKey == "" {
+		return fmt.Errorf("privateKeyPassphrase requires privateKey")
+	}
+	if m.HostKey == "" && !m.UnsafeIgnoreHostKey {
+		return fmt.Errorf("hostKey must be provided unless unsafeIgnoreHostKey is set")
+	}
+	return nil
+}
+
+// NewSftpScaler creates a new SFTP scaler
+func NewSftpScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseSftpMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing sftp metadata: %w", err)
+	}
+
+	sshConfig, err := newSftpSSHConfig(meta)
+	if err != nil {
+		return nil, err
+	}
+	sshConfig.Timeout = config.GlobalHTTPTimeout
+
+	return &sftpScaler{
+		metricType: metricType,
+		metadata:   meta,
+		sshConfig:  sshConfig,
+		logger:     InitializeLogger(config, "sftp_scaler"),
+	}, nil
+}
+
+func parseSftpMetadata(config *scalersconfig.ScalerConfig) (*sftpMetadata, error) {
+	meta := &sftpMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+func newSftpSSHConfig(meta *sftpMetadata) (*ssh.ClientConfig, error) {
+	var authMethods []ssh.AuthMethod
+	if meta.PrivateKey != "" {
+		var signer ssh.Signer
+		var err error
+		if meta.PrivateKeyPassphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(meta.PrivateKey), []byte(meta.PrivateKeyPassphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey([]byte(meta.PrivateKey))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing privateKey: %w", err)
+		}
+		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	}
+	if meta.Password != "" {
+		authMethods = append(authMethods, ssh.Password(meta.Password))
+	}
+
+	hostKeyCallback := ssh.InsecureIgnoreHostKey()
+	if meta.HostKey != "" {
+		hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(meta.HostKey))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing hostKey: %w", err)
+		}
+		hostKeyCallback = ssh.FixedHostKey(hostKey)
+	}
+
+	return &ssh.ClientConfig{
+		User:            meta.Username,
+		Auth:            authMethods,
+		HostKeyCallback: hostKeyCallback,
+	}, nil
+}
+
+// getClient returns the SFTP client, connecting to the server if there is no open connection
+func (s *sftpScaler) getClient() (*sftp.Client, error) {
+	if s.sftpClient != nil {
+		return s.sftpClient, nil
+	}
+
+	address := net.JoinHostPort(s.metadata.Host, strconv.Itoa(s.metadata.Port))
+	sshClient, err := ssh.Dial("tcp", address, s.sshConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s: %w", address, err)
+	}
+	sftpClient, err := sftp.NewClient(sshClient)
+	if err != nil {
+		sshClient.Close()
+		return nil, fmt.Errorf("error starting sftp session: %w", err)
+	}
+
+	s.sshClient = sshClient
+	s.sftpClient = sftpClient
+	return sftpClient, nil
+}
+
+// closeClient closes the current connection, if any
+func (s *sftpScaler) closeClient() error {
+	var errs []error
+	if s.sftpClient != nil {
+		if err := s.sftpClient.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			errs = append(errs, err)
+		}
+		s.sftpClient = nil
+	}
+	if s.sshClient != nil {
+		if err := s.sshClient.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			errs = append(errs, err)
+		}
+		s.sshClient = nil
+	}
+	return errors.Join(errs...)
+}
+
+// getFileCount returns the number of regular files in the directory matching the pattern
+func (s *sftpScaler) getFileCount() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	client, err := s.getClient()
+	if err != nil {
+		return 0, err
+	}
+
+	entries, err := client.ReadDir(s.metadata.Directory)
+	if err != nil {
+		// drop the connection, it is reopened on the next poll
+		if closeErr := s.closeClient(); closeErr != nil {
+			s.logger.V(1).Info("error closing sftp connection", "error", closeErr)
+		}
+		return 0, fmt.Errorf("error listing directory %s: %w", s.metadata.Directory, err)
+	}
+
+	var count int64
+	for _, entry := range entries {
+		if !entry.Mode().IsRegular() {
+			continue
+		}
+		if s.metadata.Pattern != "" {
+			// the pattern has been validated, so Match can't fail
+			if matched, _ := path.Match(s.metadata.Pattern, entry.Name()); !matched {
+				continue
+			}
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (s *sftpScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("sftp-%s", strings.Trim(s.metadata.Directory, "/")))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *sftpScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	count, err := s.getFileCount()
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(count))
+
+	return []external_metrics.ExternalMetricValue{metric}, float64(count) > s.metadata.ActivationValue, nil
+}
+
+// Close closes the SFTP connection
+func (s *sftpScaler) Close(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closeClient()
+}
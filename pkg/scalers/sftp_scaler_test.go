@@ -0,0 +1,302 @@
+package scalers
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseSftpMetadataTestData struct {
+	name       string
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+var testSftpMetadata = []parseSftpMetadataTestData{
+	{
+		name:       "password auth",
+		metadata:   map[string]string{"host": "sftp.example.com", "directory": "/upload", "value": "5", "activationValue": "1"},
+		authParams: map[string]string{"username": "user", "password": "pass", "hostKey": "ssh-ed25519 AAAA"},
+	},
+	{
+		name:       "key auth with pattern",
+		metadata:   map[string]string{"host": "sftp.example.com", "port": "2222", "directory": "/upload", "pattern": "*.csv", "unsafeIgnoreHostKey": "true"},
+		authParams: map[string]string{"username": "user", "privateKey": "key"},
+	},
+	{
+		name:       "missing host",
+		metadata:   map[string]string{"directory": "/upload", "unsafeIgnoreHostKey": "true"},
+		authParams: map[string]string{"username": "user", "password": "pass"},
+		isError:    true,
+	},
+	{
+		name:       "missing directory",
+		metadata:   map[string]string{"host": "sftp.example.com", "unsafeIgnoreHostKey": "true"},
+		authParams: map[string]string{"username": "user", "password": "pass"},
+		isError:    true,
+	},
+	{
+		name:       "missing username",
+		metadata:   map[string]string{"host": "sftp.example.com", "directory": "/upload", "unsafeIgnoreHostKey": "true"},
+		authParams: map[string]string{"password": "pass"},
+		isError:    true,
+	},
+	{
+		name:       "missing credentials",
+		metadata:   map[string]string{"host": "sftp.example.com", "directory": "/upload", "unsafeIgnoreHostKey": "true"},
+		authParams: map[string]string{"username": "user"},
+		isError:    true,
+	},
+	{
+		name:       "passphrase without key",
+		metadata:   map[string]string{"host": "sftp.example.com", "directory": "/upload", "unsafeIgnoreHostKey": "true"},
+		authParams: map[string]string{"username": "user", "password": "pass", "privateKeyPassphrase": "secret"},
+		isError:    true,
+	},
+	{
+		name:       "missing host key",
+		metadata:   map[string]string{"host": "sftp.example.com", "directory": "/upload"},
+		authParams: map[string]string{"username": "user", "password": "pass"},
+		isError:    true,
+	},
+	{
+		name:       "invalid port",
+		metadata:   map[string]string{"host": "sftp.example.com", "port": "70000", "directory": "/upload", "unsafeIgnoreHostKey": "true"},
+		authParams: map[string]string{"username": "user", "password": "pass"},
+		isError:    true,
+	},
+	{
+		name:       "invalid pattern",
+		metadata:   map[string]string{"host": "sftp.example.com", "directory": "/upload", "pattern": "[a-", "unsafeIgnoreHostKey": "true"},
+		authParams: map[string]string{"username": "user", "password": "pass"},
+		isError:    true,
+	},
+}
+
+func TestSftpParseMetadata(t *testing.T) {
+	for _, testData := range testSftpMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			_, err := parseSftpMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSftpGetMetricSpecForScaling(t *testing.T) {
+	meta, err := parseSftpMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: testSftpMetadata[0].metadata,
+		AuthParams:      testSftpMetadata[0].authParams,
+		TriggerIndex:    1,
+	})
+	require.NoError(t, err)
+	s := sftpScaler{metadata: meta, logger: logr.Discard()}
+
+	metricSpec := s.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, "s1-sftp-upload", metricSpec[0].External.Metric.Name)
+}
+
+// fakeSftpServer is an SSH server serving the SFTP subsystem on a local directory
+type fakeSftpServer struct {
+	address   string
+	hostKey   ssh.PublicKey
+	clientKey []byte
+}
+
+func newFakeSftpServer(t *testing.T, root string) *fakeSftpServer {
+	_, hostPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostPrivateKey)
+	require.NoError(t, err)
+
+	clientPublicKey, clientPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	authorizedKey, err := ssh.NewPublicKey(clientPublicKey)
+	require.NoError(t, err)
+	clientKeyBlock, err := ssh.MarshalPrivateKey(clientPrivateKey, "")
+	require.NoError(t, err)
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "user" && string(password) == "pass" {
+				return nil, nil
+			}
+			return nil, errors.New("invalid password")
+		},
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if conn.User() == "user" && string(key.Marshal()) == string(authorizedKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, errors.New("unknown public key")
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeSftpConn(conn, config, root)
+		}
+	}()
+
+	return &fakeSftpServer{
+		address:   listener.Addr().String(),
+		hostKey:   hostSigner.PublicKey(),
+		clientKey: pem.EncodeToMemory(clientKeyBlock),
+	}
+}
+
+func serveFakeSftpConn(conn net.Conn, config *ssh.ServerConfig, root string) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			for req := range channelRequests {
+				_ = req.Reply(req.Type == "subsystem" && string(req.Payload[4:]) == "sftp", nil)
+			}
+		}()
+		server, err := sftp.NewServer(channel, sftp.WithServerWorkingDirectory(root))
+		if err != nil {
+			channel.Close()
+			return
+		}
+		go func() {
+			_ = server.Serve()
+			server.Close()
+		}()
+	}
+}
+
+func TestSftpGetMetricsAndActivity(t *testing.T) {
+	root := t.TempDir()
+	uploadDir := filepath.Join(root, "upload")
+	require.NoError(t, os.MkdirAll(filepath.Join(uploadDir, "archive"), 0o755))
+	for _, name := range []string{"a.csv", "b.csv", "c.csv", "d.json", "archive/old.csv"} {
+		require.NoError(t, os.WriteFile(filepath.Join(uploadDir, name), []byte("data"), 0o600))
+	}
+	emptyDir := filepath.Join(root, "empty")
+	require.NoError(t, os.Mkdir(emptyDir, 0o755))
+
+	server := newFakeSftpServer(t, root)
+	host, port, err := net.SplitHostPort(server.address)
+	require.NoError(t, err)
+	hostKey := string(ssh.MarshalAuthorizedKey(server.hostKey))
+
+	testCases := []struct {
+		name           string
+		metadata       map[string]string
+		authParams     map[string]string
+		expectedValue  int64
+		expectedActive bool
+		expectedError  string
+	}{
+		{
+			name:           "password auth counts all files",
+			metadata:       map[string]string{"directory": uploadDir, "activationValue": "2"},
+			authParams:     map[string]string{"username": "user", "password": "pass", "hostKey": hostKey},
+			expectedValue:  4,
+			expectedActive: true,
+		},
+		{
+			name:           "key auth with pattern",
+			metadata:       map[string]string{"directory": uploadDir, "pattern": "*.csv", "unsafeIgnoreHostKey": "true"},
+			authParams:     map[string]string{"username": "user", "privateKey": string(server.clientKey)},
+			expectedValue:  3,
+			expectedActive: true,
+		},
+		{
+			name:           "pattern below activation",
+			metadata:       map[string]string{"directory": uploadDir, "pattern": "*.json", "activationValue": "1"},
+			authParams:     map[string]string{"username": "user", "password": "pass", "hostKey": hostKey},
+			expectedValue:  1,
+			expectedActive: false,
+		},
+		{
+			name:           "empty directory",
+			metadata:       map[string]string{"directory": emptyDir},
+			authParams:     map[string]string{"username": "user", "password": "pass", "hostKey": hostKey},
+			expectedValue:  0,
+			expectedActive: false,
+		},
+		{
+			name:          "missing directory",
+			metadata:      map[string]string{"directory": filepath.Join(root, "missing")},
+			authParams:    map[string]string{"username": "user", "password": "pass", "hostKey": hostKey},
+			expectedError: "error listing directory",
+		},
+		{
+			name:          "wrong password",
+			metadata:      map[string]string{"directory": uploadDir},
+			authParams:    map[string]string{"username": "user", "password": "wrong", "hostKey": hostKey},
+			expectedError: "unable to authenticate",
+		},
+		{
+			name:          "host key mismatch",
+			metadata:      map[string]string{"directory": uploadDir},
+			authParams:    map[string]string{"username": "user", "password": "pass", "hostKey": "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl"},
+			expectedError: "host key mismatch",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["host"] = host
+			tc.metadata["port"] = port
+
+			s, err := NewSftpScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata: tc.metadata,
+				AuthParams:      tc.authParams,
+				TriggerIndex:    i,
+			})
+			require.NoError(t, err)
+			defer s.Close(context.Background())
+
+			metrics, active, err := s.GetMetricsAndActivity(context.Background(), "s"+strconv.Itoa(i)+"-sftp")
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedActive, active)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.MilliValue()/1000)
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
@@ -34,19 +36,33 @@ type solrMetadata struct {
 	// Authentication
 	Username string `keda:"name=username, order=authParams;triggerMetadata"`
 	Password string `keda:"name=password, order=authParams;triggerMetadata"`
+
+	// TLS
+	UnsafeSsl   bool   `keda:"name=unsafeSsl,   order=triggerMetadata, default=false"`
+	Cert        string `keda:"name=cert,        order=authParams, optional"`
+	Key         string `keda:"name=key,         order=authParams, optional"`
+	KeyPassword string `keda:"name=keyPassword, order=authParams, optional"`
+	Ca          string `keda:"name=ca,          order=authParams, optional"`
 }
 
 func (s *solrMetadata) Validate() error {
 	if s.Query == "" {
 		s.Query = "*:*"
 	}
+	if (s.Cert == "") != (s.Key == "") {
+		return fmt.Errorf("both cert and key must be provided for client authentication")
+	}
 	return nil
 }
 
 type solrResponse struct {
-	Response struct {
-		NumFound int `json:"numFound"`
+	Response *struct {
+		NumFound int64 `json:"numFound"`
 	} `json:"response"`
+	Error *struct {
+		Msg  string `json:"msg"`
+		Code int    `json:"code"`
+	} `json:"error"`
 }
 
 // NewSolrScaler creates a new solr Scaler
@@ -60,7 +76,14 @@ func NewSolrScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error parsing Solr metadata: %w", err)
 	}
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
+	if meta.Ca != "" || meta.Cert != "" {
+		tlsConfig, err := kedautil.NewTLSConfigWithPassword(meta.Cert, meta.Key, meta.KeyPassword, meta.Ca, meta.UnsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(tlsConfig)
+	}
 
 	logger := InitializeLogger(config, "solr_scaler")
 
@@ -87,14 +110,16 @@ func parseSolrMetadata(config *scalersconfig.ScalerConfig) (*solrMetadata, error
 	return meta, nil
 }
 
+// getItemCount returns the number of documents matching the query, without fetching any of them
 func (s *solrScaler) getItemCount(ctx context.Context) (float64, error) {
-	var SolrResponse1 *solrResponse
-	var itemCount float64
-
-	url := fmt.Sprintf("%s/solr/%s/select?q=%s&wt=json",
-		s.metadata.Host, s.metadata.Collection, s.metadata.Query)
+	query := url.Values{
+		"q":    []string{s.metadata.Query},
+		"rows": []string{"0"},
+		"wt":   []string{"json"},
+	}
+	u := fmt.Sprintf("%s/solr/%s/select?%s", strings.TrimSuffix(s.metadata.Host, "/"), url.PathEscape(s.metadata.Collection), query.Encode())
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return -1, err
 	}
@@ -112,12 +137,28 @@ func (s *solrScaler) getItemCount(ctx context.Context) (float64, error) {
 		return -1, err
 	}
 
-	err = json.Unmarshal(body, &SolrResponse1)
-	if err != nil {
+	return parseSolrItemCount(resp.StatusCode, body)
+}
+
+// parseSolrItemCount returns numFound from the body of a select response, or the error Solr reported
+func parseSolrItemCount(statusCode int, body []byte) (float64, error) {
+	var solrResp solrResponse
+	if err := json.Unmarshal(body, &solrResp); err != nil {
+		if statusCode != http.StatusOK {
+			return -1, fmt.Errorf("solr returned status %d: %s", statusCode, string(body))
+		}
 		return -1, fmt.Errorf("%w, make sure you enter username, password and collection values correctly in the yaml file", err)
 	}
-	itemCount = float64(SolrResponse1.Response.NumFound)
-	return itemCount, nil
+	if solrResp.Error != nil {
+		return -1, fmt.Errorf("solr returned error %d: %s", solrResp.Error.Code, solrResp.Error.Msg)
+	}
+	if statusCode != http.StatusOK {
+		return -1, fmt.Errorf("solr returned status %d: %s", statusCode, string(body))
+	}
+	if solrResp.Response == nil {
+		return -1, fmt.Errorf("solr response doesn't contain numFound")
+	}
+	return float64(solrResp.Response.NumFound), nil
 }
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
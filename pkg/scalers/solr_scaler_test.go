@@ -2,12 +2,27 @@ package scalers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
 
+const solrSelectResponse = `{
+  "responseHeader":{"status":0,"QTime":2,"params":{"q":"status:pending","rows":"0","wt":"json"}},
+  "response":{"numFound":42,"start":0,"numFoundExact":true,"docs":[]}
+}`
+
+const solrUndefinedFieldResponse = `{
+  "responseHeader":{"status":400,"QTime":1},
+  "error":{"metadata":["error-class","org.apache.solr.common.SolrException"],"msg":"undefined field unknown","code":400}
+}`
+
 type parseSolrMetadataTestData struct {
 	metadata   map[string]string
 	isError    bool
@@ -37,6 +52,14 @@ var testSolrMetadata = []parseSolrMetadataTestData{
 	{map[string]string{"host": "http://192.168.49.2:30217", "collection": "my_core", "query": "*:*", "targetQueryValue": "1"}, true, map[string]string{"password": "test_password"}},
 	// no password passed
 	{map[string]string{"host": "http://192.168.49.2:30217", "collection": "my_core", "query": "*:*", "targetQueryValue": "1"}, true, map[string]string{"username": "test_username"}},
+	// tls with ca and unsafeSsl
+	{map[string]string{"host": "https://192.168.49.2:30217", "collection": "my_core", "targetQueryValue": "1", "unsafeSsl": "true"}, false, map[string]string{"username": "test_username", "password": "test_password", "ca": "caaa"}},
+	// tls with client certificate
+	{map[string]string{"host": "https://192.168.49.2:30217", "collection": "my_core", "targetQueryValue": "1"}, false, map[string]string{"username": "test_username", "password": "test_password", "cert": "ceert", "key": "keey"}},
+	// tls with cert but no key
+	{map[string]string{"host": "https://192.168.49.2:30217", "collection": "my_core", "targetQueryValue": "1"}, true, map[string]string{"username": "test_username", "password": "test_password", "cert": "ceert"}},
+	// invalid unsafeSsl
+	{map[string]string{"host": "https://192.168.49.2:30217", "collection": "my_core", "targetQueryValue": "1", "unsafeSsl": "maybe"}, true, map[string]string{"username": "test_username", "password": "test_password"}},
 }
 
 var solrMetricIdentifiers = []solrMetricIdentifier{
@@ -77,3 +100,83 @@ func TestSolrGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestParseSolrItemCount(t *testing.T) {
+	testCases := []struct {
+		name       string
+		statusCode int
+		body       string
+		expected   float64
+		isError    bool
+	}{
+		{name: "documents found", statusCode: http.StatusOK, body: solrSelectResponse, expected: 42},
+		{name: "no document found", statusCode: http.StatusOK, body: `{"response":{"numFound":0,"start":0,"docs":[]}}`, expected: 0},
+		{name: "solr error", statusCode: http.StatusBadRequest, body: solrUndefinedFieldResponse, isError: true},
+		{name: "unauthorized html page", statusCode: http.StatusUnauthorized, body: `<html><body>HTTP ERROR 401</body></html>`, isError: true},
+		{name: "invalid json", statusCode: http.StatusOK, body: `{"response":`, isError: true},
+		{name: "missing response", statusCode: http.StatusOK, body: `{"responseHeader":{"status":0}}`, isError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			count, err := parseSolrItemCount(tc.statusCode, []byte(tc.body))
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, count)
+		})
+	}
+}
+
+func TestSolrGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "test_username" || pass != "test_password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/solr/my_core/select" || r.URL.Query().Get("rows") != "0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("q") != "status:pending AND type:\"a b\"" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, solrUndefinedFieldResponse)
+			return
+		}
+		fmt.Fprint(w, solrSelectResponse)
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name       string
+		query      string
+		activation string
+		isActive   bool
+		isError    bool
+	}{
+		{name: "active", query: "status:pending AND type:\"a b\"", activation: "10", isActive: true},
+		{name: "below activation", query: "status:pending AND type:\"a b\"", activation: "42", isActive: false},
+		{name: "solr error", query: "unknown:1", isError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scaler, err := NewSolrScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata: map[string]string{"host": server.URL, "collection": "my_core", "query": tc.query, "targetQueryValue": "10", "activationTargetQueryValue": tc.activation, "unsafeSsl": "true"},
+				AuthParams:      map[string]string{"username": "test_username", "password": "test_password"},
+			})
+			require.NoError(t, err)
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "metric")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(42), metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
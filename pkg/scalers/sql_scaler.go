@@ -0,0 +1,151 @@
+package scalers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// sqlScaler runs a query returning a single value against any database whose database/sql driver
+// is registered in KEDA, e.g. pgx, mysql or sqlserver
+type sqlScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *sqlMetadata
+	connection *sql.DB
+	logger     logr.Logger
+}
+
+type sqlMetadata struct {
+	Driver             string  `keda:"name=driver,             order=triggerMetadata"`
+	DSN                string  `keda:"name=dsn,                order=authParams;resolvedEnv"`
+	Query              string  `keda:"name=query,              order=triggerMetadata"`
+	TargetValue        float64 `keda:"name=targetValue,        order=triggerMetadata, optional"`
+	ActivationValue    float64 `keda:"name=activationValue,    order=triggerMetadata, default=0"`
+	MaxOpenConnections int     `keda:"name=maxOpenConnections, order=triggerMetadata, default=2"`
+	MaxIdleConnections int     `keda:"name=maxIdleConnections, order=triggerMetadata, default=2"`
+	triggerIndex       int
+}
+
+func (m *sqlMetadata) Validate() error {
+	if !slices.Contains(sql.Drivers(), m.Driver) {
+		return fmt.Errorf("driver %q isn't registered, the registered drivers are %v", m.Driver, sql.Drivers())
+	}
+	if m.MaxOpenConnections <= 0 {
+		return fmt.Errorf("maxOpenConnections must be positive")
+	}
+	if m.MaxIdleConnections < 0 {
+		return fmt.Errorf("maxIdleConnections must not be negative")
+	}
+	return nil
+}
+
+// NewSQLScaler creates a new generic SQL scaler
+func NewSQLScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	logger := InitializeLogger(config, "sql_scaler")
+
+	meta, err := parseSQLMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing SQL metadata: %w", err)
+	}
+
+	conn, err := newSQLConnection(ctx, meta, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error establishing SQL connection: %w", err)
+	}
+	return &sqlScaler{
+		metricType: metricType,
+		metadata:   meta,
+		connection: conn,
+		logger:     logger,
+	}, nil
+}
+
+func parseSQLMetadata(config *scalersconfig.ScalerConfig) (*sqlMetadata, error) {
+	meta := &sqlMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing sql metadata: %w", err)
+	}
+
+	if !config.AsMetricSource && meta.TargetValue == 0 {
+		return nil, fmt.Errorf("no targetValue given")
+	}
+
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// newSQLConnection opens the connection pool of the scaler with the registered driver and checks the database is reachable
+func newSQLConnection(ctx context.Context, meta *sqlMetadata, logger logr.Logger) (*sql.DB, error) {
+	db, err := sql.Open(meta.Driver, meta.DSN)
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("Found error opening %s connection: %s", meta.Driver, err))
+		return nil, err
+	}
+	db.SetMaxOpenConns(meta.MaxOpenConnections)
+	db.SetMaxIdleConns(meta.MaxIdleConnections)
+
+	if err := db.PingContext(ctx); err != nil {
+		logger.Error(err, fmt.Sprintf("Found error pinging %s database: %s", meta.Driver, err))
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Close disposes of the SQL connection pool
+func (s *sqlScaler) Close(context.Context) error {
+	if err := s.connection.Close(); err != nil {
+		s.logger.Error(err, "Error closing SQL connection")
+		return err
+	}
+	return nil
+}
+
+// getQueryResult returns the single value returned by the query
+func (s *sqlScaler) getQueryResult(ctx context.Context) (float64, error) {
+	var value float64
+	if err := s.connection.QueryRowContext(ctx, s.metadata.Query).Scan(&value); err != nil {
+		s.logger.Error(err, fmt.Sprintf("Could not query %s database: %s", s.metadata.Driver, err))
+		return 0, fmt.Errorf("could not query %s database: %w", s.metadata.Driver, err)
+	}
+	return value, nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *sqlScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("sql-%s", s.metadata.Driver))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
+func (s *sqlScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	num, err := s.getQueryResult(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting SQL database: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, num)
+
+	return []external_metrics.ExternalMetricValue{metric}, num > s.metadata.ActivationValue, nil
+}
@@ -0,0 +1,233 @@
+package scalers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+// fakeSQLDriverName is registered as a database/sql driver whose databases are the DSNs of fakeSQLDatabases
+const fakeSQLDriverName = "keda-fake-sql"
+
+// fakeSQLDatabases maps a DSN to the values returned by its queries
+var fakeSQLDatabases = map[string]map[string]driver.Value{
+	"fake://orders": {
+		"SELECT COUNT(*) FROM orders": int64(12),
+		"SELECT AVG(age) FROM orders": 2.5,
+		"SELECT NULL":                 nil,
+	},
+}
+
+func init() {
+	sql.Register(fakeSQLDriverName, fakeSQLDriver{})
+}
+
+type fakeSQLDriver struct{}
+
+func (fakeSQLDriver) Open(dsn string) (driver.Conn, error) {
+	values, ok := fakeSQLDatabases[dsn]
+	if !ok {
+		return nil, fmt.Errorf("unknown database %q", dsn)
+	}
+	return &fakeSQLConn{values: values}, nil
+}
+
+// fakeSQLConnector returns connections whose queries return their value in values, or always return value
+// when values is nil. It fails to connect with err
+type fakeSQLConnector struct {
+	values map[string]driver.Value
+	value  driver.Value
+	err    error
+}
+
+func (c *fakeSQLConnector) Connect(context.Context) (driver.Conn, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &fakeSQLConn{values: c.values, value: c.value}, nil
+}
+
+func (c *fakeSQLConnector) Driver() driver.Driver {
+	return fakeSQLDriver{}
+}
+
+type fakeSQLConn struct {
+	// values maps the queries to their value, all the queries return value when it's nil
+	values map[string]driver.Value
+	value  driver.Value
+}
+
+func (c *fakeSQLConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeSQLConn) Close() error {
+	return nil
+}
+
+func (c *fakeSQLConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeSQLConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if c.values == nil {
+		return &fakeSQLRows{value: c.value}, nil
+	}
+	value, ok := c.values[query]
+	if !ok {
+		return nil, fmt.Errorf("unknown query %q", query)
+	}
+	return &fakeSQLRows{value: value}, nil
+}
+
+type fakeSQLRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (r *fakeSQLRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (r *fakeSQLRows) Close() error {
+	return nil
+}
+
+func (r *fakeSQLRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	dest[0] = r.value
+	r.done = true
+	return nil
+}
+
+type parseSQLMetadataTestData struct {
+	name           string
+	metadata       map[string]string
+	authParams     map[string]string
+	resolvedEnv    map[string]string
+	asMetricSource bool
+	isError        bool
+}
+
+var testSQLMetadata = []parseSQLMetadataTestData{
+	{name: "dsn from authParams", metadata: map[string]string{"driver": fakeSQLDriverName, "query": "SELECT 1", "targetValue": "5"}, authParams: map[string]string{"dsn": "fake://orders"}},
+	{name: "dsn from env", metadata: map[string]string{"driver": fakeSQLDriverName, "query": "SELECT 1", "targetValue": "5", "dsnFromEnv": "DSN"}, resolvedEnv: map[string]string{"DSN": "fake://orders"}},
+	{name: "activationValue and pool size", metadata: map[string]string{"driver": fakeSQLDriverName, "query": "SELECT 1", "targetValue": "5", "activationValue": "2", "maxOpenConnections": "5", "maxIdleConnections": "0"}, authParams: map[string]string{"dsn": "fake://orders"}},
+	{name: "registered driver of another scaler", metadata: map[string]string{"driver": "pgx", "query": "SELECT 1", "targetValue": "5"}, authParams: map[string]string{"dsn": "postgresql://localhost:5432"}},
+	{name: "no targetValue as metric source", metadata: map[string]string{"driver": fakeSQLDriverName, "query": "SELECT 1"}, authParams: map[string]string{"dsn": "fake://orders"}, asMetricSource: true},
+	{name: "no targetValue", metadata: map[string]string{"driver": fakeSQLDriverName, "query": "SELECT 1"}, authParams: map[string]string{"dsn": "fake://orders"}, isError: true},
+	{name: "no driver", metadata: map[string]string{"query": "SELECT 1", "targetValue": "5"}, authParams: map[string]string{"dsn": "fake://orders"}, isError: true},
+	{name: "unregistered driver", metadata: map[string]string{"driver": "oracle", "query": "SELECT 1", "targetValue": "5"}, authParams: map[string]string{"dsn": "fake://orders"}, isError: true},
+	{name: "no dsn", metadata: map[string]string{"driver": fakeSQLDriverName, "query": "SELECT 1", "targetValue": "5"}, isError: true},
+	{name: "dsn in metadata", metadata: map[string]string{"driver": fakeSQLDriverName, "query": "SELECT 1", "targetValue": "5", "dsn": "fake://orders"}, isError: true},
+	{name: "no query", metadata: map[string]string{"driver": fakeSQLDriverName, "targetValue": "5"}, authParams: map[string]string{"dsn": "fake://orders"}, isError: true},
+	{name: "maxOpenConnections isn't positive", metadata: map[string]string{"driver": fakeSQLDriverName, "query": "SELECT 1", "targetValue": "5", "maxOpenConnections": "0"}, authParams: map[string]string{"dsn": "fake://orders"}, isError: true},
+	{name: "maxIdleConnections is negative", metadata: map[string]string{"driver": fakeSQLDriverName, "query": "SELECT 1", "targetValue": "5", "maxIdleConnections": "-1"}, authParams: map[string]string{"dsn": "fake://orders"}, isError: true},
+}
+
+func TestParseSQLMetadata(t *testing.T) {
+	for _, testData := range testSQLMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			_, err := parseSQLMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams, ResolvedEnv: testData.resolvedEnv, AsMetricSource: testData.asMetricSource})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if err == nil && testData.isError {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+type sqlMetricIdentifier struct {
+	metadataTestData *parseSQLMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var sqlMetricIdentifiers = []sqlMetricIdentifier{
+	{&testSQLMetadata[0], 0, "s0-sql-keda-fake-sql"},
+	{&testSQLMetadata[3], 1, "s1-sql-pgx"},
+}
+
+func TestSQLGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range sqlMetricIdentifiers {
+		meta, err := parseSQLMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockSQLScaler := sqlScaler{metadata: meta}
+
+		metricSpec := mockSQLScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+type sqlQueryTestData struct {
+	name           string
+	dsn            string
+	query          string
+	expectedValue  float64
+	expectedActive bool
+	isError        bool
+}
+
+var testSQLQueries = []sqlQueryTestData{
+	{name: "integer result", dsn: "fake://orders", query: "SELECT COUNT(*) FROM orders", expectedValue: 12, expectedActive: true},
+	{name: "float result below activation", dsn: "fake://orders", query: "SELECT AVG(age) FROM orders", expectedValue: 2.5},
+	{name: "null result", dsn: "fake://orders", query: "SELECT NULL", isError: true},
+	{name: "failing query", dsn: "fake://orders", query: "SELECT * FROM missing", isError: true},
+}
+
+func TestSQLGetMetricsAndActivity(t *testing.T) {
+	for _, testData := range testSQLQueries {
+		t.Run(testData.name, func(t *testing.T) {
+			scaler, err := NewSQLScaler(context.Background(), &scalersconfig.ScalerConfig{
+				TriggerMetadata: map[string]string{"driver": fakeSQLDriverName, "query": testData.query, "targetValue": "5", "activationValue": "3"},
+				AuthParams:      map[string]string{"dsn": testData.dsn},
+			})
+			if err != nil {
+				t.Fatal("Could not create scaler:", err)
+			}
+			defer scaler.Close(context.Background())
+
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-sql-keda-fake-sql")
+			if err != nil && !testData.isError {
+				t.Fatal("Expected success but got error", err)
+			}
+			if err == nil && testData.isError {
+				t.Fatal("Expected error but got success")
+			}
+			if testData.isError {
+				return
+			}
+			if value := metrics[0].Value.AsApproximateFloat64(); value != testData.expectedValue {
+				t.Errorf("Expected value %v but got %v", testData.expectedValue, value)
+			}
+			if active != testData.expectedActive {
+				t.Errorf("Expected active %t but got %t", testData.expectedActive, active)
+			}
+		})
+	}
+}
+
+func TestNewSQLScalerUnreachableDatabase(t *testing.T) {
+	_, err := NewSQLScaler(context.Background(), &scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"driver": fakeSQLDriverName, "query": "SELECT 1", "targetValue": "5"},
+		AuthParams:      map[string]string{"dsn": "fake://missing"},
+	})
+	if err == nil {
+		t.Error("Expected error but got success")
+	}
+}
@@ -0,0 +1,284 @@
+package scalers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	"github.com/trinodb/trino-go-client/trino"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const trinoSource = "keda"
+
+type trinoScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *trinoMetadata
+	querier    trinoQuerier
+	logger     logr.Logger
+}
+
+type trinoMetadata struct {
+	triggerIndex int
+
+	ServerAddress   string  `keda:"name=serverAddress,   order=triggerMetadata"`
+	Query           string  `keda:"name=query,           order=triggerMetadata"`
+	Value           float64 `keda:"name=value,           order=triggerMetadata, default=0"`
+	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, default=0"`
+	Catalog         string  `keda:"name=catalog,         order=triggerMetadata, optional"`
+	Schema          string  `keda:"name=schema,          order=triggerMetadata, optional"`
+	QueryTimeout    string  `keda:"name=queryTimeout,    order=triggerMetadata, default=30s"`
+	UnsafeSsl       bool    `keda:"name=unsafeSsl,       order=triggerMetadata, default=false"`
+
+	Username string `keda:"name=username, order=authParams;triggerMetadata;resolvedEnv, default=keda"`
+	Password string `keda:"name=password, order=authParams;resolvedEnv, optional"`
+
+	// TLS
+	Ca   string `keda:"name=ca,   order=authParams, optional"`
+	Cert string `keda:"name=cert, order=authParams, optional"`
+	Key  string `keda:"name=key,  order=authParams, optional"`
+
+	queryTimeout   time.Duration
+	asMetricSource bool
+}
+
+func (m *trinoMetadata) Validate() error {
+	if m.Value <= 0 && !m.asMetricSource {
+		return fmt.Errorf("value must be a float greater than 0")
+	}
+
+	u, err := url.Parse(m.ServerAddress)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("serverAddress must be an http or https url, got %q", m.ServerAddress)
+	}
+	if m.Password != "" && u.Scheme != "https" {
+		return errors.New("password requires an https serverAddress, as trino only accepts basic auth over TLS")
+	}
+
+	if (m.Cert == "") != (m.Key == "") {
+		return errors.New("cert and key must be provided together")
+	}
+
+	if m.queryTimeout, err = time.ParseDuration(m.QueryTimeout); err != nil || m.queryTimeout <= 0 {
+		return fmt.Errorf("queryTimeout must be a positive duration, got %q", m.QueryTimeout)
+	}
+	return nil
+}
+
+// trinoQuerier runs a query returning a single numeric value
+type trinoQuerier interface {
+	QueryValue(ctx context.Context, query string) (float64, error)
+	Close()
+}
+
+// NewTrinoScaler creates a new scaler on the single numeric value returned by a Trino (or Presto) query
+func NewTrinoScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseTrinoMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing trino metadata: %w", err)
+	}
+
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
+	if meta.Ca != "" || meta.Cert != "" {
+		tlsConfig, err := kedautil.NewTLSConfig(meta.Cert, meta.Key, meta.Ca, meta.UnsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(tlsConfig)
+	}
+
+	querier, err := newTrinoSQLClient(meta, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("error creating trino client: %w", err)
+	}
+
+	return &trinoScaler{
+		metricType: metricType,
+		metadata:   meta,
+		querier:    querier,
+		logger:     InitializeLogger(config, "trino_scaler"),
+	}, nil
+}
+
+func parseTrinoMetadata(config *scalersconfig.ScalerConfig) (*trinoMetadata, error) {
+	meta := &trinoMetadata{}
+	meta.triggerIndex = config.TriggerIndex
+	meta.asMetricSource = config.AsMetricSource
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing trino metadata: %w", err)
+	}
+	return meta, nil
+}
+
+// Close closes the connections to the coordinator
+func (s *trinoScaler) Close(context.Context) error {
+	if s.querier != nil {
+		s.querier.Close()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *trinoScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString("trino")),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
+func (s *trinoScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	// the query is canceled once it runs longer than queryTimeout, so a slow query doesn't block the polls
+	queryCtx, cancel := context.WithTimeout(ctx, s.metadata.queryTimeout)
+	defer cancel()
+
+	value, err := s.querier.QueryValue(queryCtx, s.metadata.Query)
+	if err != nil {
+		s.logger.Error(err, "error executing trino query")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationValue, nil
+}
+
+// trinoSQLClient runs queries with the Trino Go client, which cancels the query on the coordinator
+// when the context ends before the results are read
+type trinoSQLClient struct {
+	db *sql.DB
+	// httpClientName is the name the HTTP client of the scaler is registered with in the Trino Go client
+	httpClientName string
+}
+
+func newTrinoSQLClient(meta *trinoMetadata, httpClient *http.Client) (*trinoSQLClient, error) {
+	serverURL, err := url.Parse(meta.ServerAddress)
+	if err != nil {
+		return nil, err
+	}
+	if meta.Password != "" {
+		serverURL.User = url.UserPassword(meta.Username, meta.Password)
+	} else {
+		serverURL.User = url.User(meta.Username)
+	}
+
+	// the registered clients are global, so each scaler registers its own under a unique name
+	httpClientName := fmt.Sprintf("keda-%s", uuid.NewString())
+	if err := trino.RegisterCustomClient(httpClientName, httpClient); err != nil {
+		return nil, err
+	}
+
+	config := trino.Config{
+		ServerURI: serverURL.String(),
+		Source:    trinoSource,
+		Catalog:   meta.Catalog,
+		Schema:    meta.Schema,
+		// the query is also stopped by the coordinator, should the cancellation from the client be lost
+		SessionProperties: map[string]string{"query_max_run_time": fmt.Sprintf("%dms", max(meta.queryTimeout.Milliseconds(), 1))},
+		CustomClientName:  httpClientName,
+	}
+	dsn, err := config.FormatDSN()
+	if err != nil {
+		trino.DeregisterCustomClient(httpClientName)
+		return nil, err
+	}
+	db, err := sql.Open("trino", dsn)
+	if err != nil {
+		trino.DeregisterCustomClient(httpClientName)
+		return nil, err
+	}
+	return &trinoSQLClient{db: db, httpClientName: httpClientName}, nil
+}
+
+// QueryValue runs the query and returns the value of its single row and column, a null value being 0
+func (c *trinoSQLClient) QueryValue(ctx context.Context, query string) (float64, error) {
+	// the Trino Go client only cancels the query on the coordinator when its context is canceled, not when
+	// the deadline is exceeded, so the end of ctx is turned into a cancellation
+	queryCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	rows, err := c.db.QueryContext(queryCtx, query)
+	if err != nil {
+		return -1, trinoQueryError(ctx, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return -1, trinoQueryError(ctx, err)
+	}
+	if len(columns) != 1 {
+		return -1, fmt.Errorf("trino query must return a single row with a single column, got %d columns", len(columns))
+	}
+
+	var values []any
+	for rows.Next() {
+		var value any
+		if err := rows.Scan(&value); err != nil {
+			return -1, fmt.Errorf("error reading trino query result: %w", err)
+		}
+		values = append(values, value)
+	}
+	if err := rows.Err(); err != nil {
+		return -1, trinoQueryError(ctx, err)
+	}
+	if len(values) != 1 {
+		return -1, fmt.Errorf("trino query must return a single row with a single column, got %d rows", len(values))
+	}
+	return parseTrinoValue(values[0])
+}
+
+// trinoQueryError returns the error of the context when it ended, rather than the cancellation it caused
+func trinoQueryError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return fmt.Errorf("trino query canceled: %w", ctx.Err())
+	}
+	return fmt.Errorf("trino query failed: %w", err)
+}
+
+// Close closes the connections to the coordinator
+func (c *trinoSQLClient) Close() {
+	_ = c.db.Close()
+	trino.DeregisterCustomClient(c.httpClientName)
+}
+
+// parseTrinoValue converts a value of a Trino row to a float, decimals being returned as strings
+func parseTrinoValue(value any) (float64, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return -1, fmt.Errorf("trino query returned a non numeric value %q", v)
+		}
+		return f, nil
+	default:
+		return -1, fmt.Errorf("trino query returned a non numeric value %v", v)
+	}
+}
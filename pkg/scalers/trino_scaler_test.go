@@ -0,0 +1,273 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	v2 "k8s.io/api/autoscaling/v2"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseTrinoMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type trinoMetricIdentifier struct {
+	metadataTestData *parseTrinoMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testTrinoMetadata = []parseTrinoMetadataTestData{
+	// success
+	{map[string]string{"serverAddress": "http://trino:8080", "query": "SELECT count(*) FROM orders", "value": "10"}, map[string]string{}, false},
+	// success, catalog, schema, activationValue and queryTimeout
+	{map[string]string{"serverAddress": "http://trino:8080", "query": "SELECT count(*) FROM orders", "value": "10", "activationValue": "2", "catalog": "hive", "schema": "sales", "queryTimeout": "10s"}, map[string]string{}, false},
+	// success, basic auth over https
+	{map[string]string{"serverAddress": "https://trino:8443", "query": "SELECT 1", "value": "10"}, map[string]string{"username": "keda", "password": "secret"}, false},
+	// failure, no serverAddress
+	{map[string]string{"query": "SELECT 1", "value": "10"}, map[string]string{}, true},
+	// failure, serverAddress isn't an url
+	{map[string]string{"serverAddress": "trino:8080", "query": "SELECT 1", "value": "10"}, map[string]string{}, true},
+	// failure, no query
+	{map[string]string{"serverAddress": "http://trino:8080", "value": "10"}, map[string]string{}, true},
+	// failure, no value
+	{map[string]string{"serverAddress": "http://trino:8080", "query": "SELECT 1"}, map[string]string{}, true},
+	// failure, invalid value
+	{map[string]string{"serverAddress": "http://trino:8080", "query": "SELECT 1", "value": "many"}, map[string]string{}, true},
+	// failure, password over http
+	{map[string]string{"serverAddress": "http://trino:8080", "query": "SELECT 1", "value": "10"}, map[string]string{"password": "secret"}, true},
+	// failure, cert without key
+	{map[string]string{"serverAddress": "https://trino:8443", "query": "SELECT 1", "value": "10"}, map[string]string{"cert": "cert"}, true},
+	// failure, invalid queryTimeout
+	{map[string]string{"serverAddress": "http://trino:8080", "query": "SELECT 1", "value": "10", "queryTimeout": "soon"}, map[string]string{}, true},
+	// failure, negative queryTimeout
+	{map[string]string{"serverAddress": "http://trino:8080", "query": "SELECT 1", "value": "10", "queryTimeout": "-1s"}, map[string]string{}, true},
+}
+
+var trinoMetricIdentifiers = []trinoMetricIdentifier{
+	{&testTrinoMetadata[0], 0, "s0-trino"},
+	{&testTrinoMetadata[1], 1, "s1-trino"},
+}
+
+func TestParseTrinoMetadata(t *testing.T) {
+	for _, testData := range testTrinoMetadata {
+		_, err := parseTrinoMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestTrinoGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range trinoMetricIdentifiers {
+		meta, err := parseTrinoMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := trinoScaler{metricType: v2.AverageValueMetricType, metadata: meta, logger: logr.Discard()}
+
+		metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+type fakeTrinoQuerier struct {
+	value float64
+	err   error
+	// delay simulates a slow query, which returns early once the context is done
+	delay time.Duration
+}
+
+func (q *fakeTrinoQuerier) QueryValue(ctx context.Context, _ string) (float64, error) {
+	select {
+	case <-ctx.Done():
+		return -1, ctx.Err()
+	case <-time.After(q.delay):
+	}
+	return q.value, q.err
+}
+
+func (q *fakeTrinoQuerier) Close() {}
+
+func TestTrinoGetMetricsAndActivity(t *testing.T) {
+	meta, err := parseTrinoMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"serverAddress": "http://trino:8080", "query": "SELECT 1", "value": "10", "activationValue": "2", "queryTimeout": "100ms"},
+	})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+
+	testCases := []struct {
+		name           string
+		querier        *fakeTrinoQuerier
+		expectedValue  int64
+		expectedActive bool
+		expectedError  string
+	}{
+		{"active", &fakeTrinoQuerier{value: 25}, 25000, true, ""},
+		{"below activation", &fakeTrinoQuerier{value: 2}, 2000, false, ""},
+		{"query error", &fakeTrinoQuerier{err: errors.New("table not found")}, 0, false, "table not found"},
+		{"query timeout", &fakeTrinoQuerier{value: 25, delay: time.Minute}, 0, false, context.DeadlineExceeded.Error()},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scaler := trinoScaler{metricType: v2.AverageValueMetricType, metadata: meta, querier: tc.querier, logger: logr.Discard()}
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "s0-trino")
+			assert.Equal(t, tc.expectedActive, isActive)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.MilliValue())
+		})
+	}
+}
+
+// fakeTrinoCoordinator answers the statement with the pages, each page being fetched from the nextUri of the
+// previous one, and records the cancellation of the query
+type fakeTrinoCoordinator struct {
+	t     *testing.T
+	pages []string
+	// block makes the coordinator wait for the end of the request instead of answering the pages
+	block bool
+
+	mu        sync.Mutex
+	fetched   int
+	canceled  bool
+	statement *http.Request
+}
+
+func (c *fakeTrinoCoordinator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/statement":
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(c.t, "SELECT count(*) FROM orders", string(body))
+		c.statement = r
+		fmt.Fprintf(w, `{"id": "q1", "nextUri": "http://%s/v1/statement/q1/0", "stats": {"state": "QUEUED"}}`, r.Host)
+	case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf("/v1/statement/q1/%d", c.fetched):
+		if c.block {
+			c.mu.Unlock()
+			<-r.Context().Done()
+			c.mu.Lock()
+			return
+		}
+		page := c.pages[c.fetched]
+		c.fetched++
+		nextURI := ""
+		if c.fetched < len(c.pages) {
+			nextURI = fmt.Sprintf(`"nextUri": "http://%s/v1/statement/q1/%d", `, r.Host, c.fetched)
+		}
+		fmt.Fprintf(w, `{"id": "q1", %s%s, "stats": {"state": "RUNNING"}}`, nextURI, page)
+	case r.Method == http.MethodDelete && r.URL.Path == "/v1/query/q1":
+		c.canceled = true
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		c.t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+const trinoBigintColumn = `"columns": [{"name": "_col0", "type": "bigint", "typeSignature": {"rawType": "bigint", "arguments": []}}]`
+
+func newTestTrinoSQLClient(t *testing.T, serverURL string, queryTimeout string) *trinoSQLClient {
+	meta, err := parseTrinoMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"serverAddress": serverURL, "query": "SELECT count(*) FROM orders", "value": "10", "catalog": "hive", "schema": "sales", "queryTimeout": queryTimeout},
+	})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	client, err := newTrinoSQLClient(meta, http.DefaultClient)
+	if err != nil {
+		t.Fatal("Could not create client:", err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+func TestTrinoSQLClientQueryValue(t *testing.T) {
+	testCases := []struct {
+		name          string
+		pages         []string
+		expectedValue float64
+		expectedError string
+	}{
+		{"single page", []string{trinoBigintColumn + `, "data": [[42]]`}, 42, ""},
+		{"data after columns", []string{trinoBigintColumn, `"data": [[12]]`, `"data": []`}, 12, ""},
+		{"decimal", []string{`"columns": [{"name": "_col0", "type": "decimal(10,2)", "typeSignature": {"rawType": "decimal", "arguments": [{"kind": "LONG", "value": 10}, {"kind": "LONG", "value": 2}]}}], "data": [["12.50"]]`}, 12.5, ""},
+		{"null value", []string{trinoBigintColumn + `, "data": [[null]]`}, 0, ""},
+		{"query error", []string{`"error": {"errorName": "TABLE_NOT_FOUND", "message": "Table 'orders' does not exist"}`}, 0, "Table 'orders' does not exist"},
+		{"several rows", []string{trinoBigintColumn + `, "data": [[1], [2]]`}, 0, "single row"},
+		{"several columns", []string{`"columns": [{"name": "a", "type": "bigint", "typeSignature": {"rawType": "bigint", "arguments": []}}, {"name": "b", "type": "bigint", "typeSignature": {"rawType": "bigint", "arguments": []}}], "data": [[1, 2]]`}, 0, "single column"},
+		{"no rows", []string{trinoBigintColumn}, 0, "single row"},
+		{"not a number", []string{`"columns": [{"name": "_col0", "type": "varchar", "typeSignature": {"rawType": "varchar", "arguments": []}}], "data": [["many"]]`}, 0, "non numeric"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			coordinator := &fakeTrinoCoordinator{t: t, pages: tc.pages}
+			server := httptest.NewServer(coordinator)
+			defer server.Close()
+			client := newTestTrinoSQLClient(t, server.URL, "1500ms")
+
+			value, err := client.QueryValue(context.Background(), "SELECT count(*) FROM orders")
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, value)
+
+			coordinator.mu.Lock()
+			defer coordinator.mu.Unlock()
+			assert.Equal(t, len(tc.pages), coordinator.fetched)
+			assert.Equal(t, "keda", coordinator.statement.Header.Get("X-Trino-User"))
+			assert.Equal(t, "keda", coordinator.statement.Header.Get("X-Trino-Source"))
+			assert.Equal(t, "hive", coordinator.statement.Header.Get("X-Trino-Catalog"))
+			assert.Equal(t, "sales", coordinator.statement.Header.Get("X-Trino-Schema"))
+			// the sub-second part of the timeout isn't truncated
+			assert.Equal(t, "query_max_run_time=1500ms", coordinator.statement.Header.Get("X-Trino-Session"))
+		})
+	}
+}
+
+func TestTrinoSQLClientCancelsQuery(t *testing.T) {
+	coordinator := &fakeTrinoCoordinator{t: t, block: true}
+	server := httptest.NewServer(coordinator)
+	defer server.Close()
+	client := newTestTrinoSQLClient(t, server.URL, "30s")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := client.QueryValue(ctx, "SELECT count(*) FROM orders")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the query is canceled on the coordinator once its results can't be read anymore
+	assert.Eventually(t, func() bool {
+		coordinator.mu.Lock()
+		defer coordinator.mu.Unlock()
+		return coordinator.canceled
+	}, 5*time.Second, 10*time.Millisecond)
+}
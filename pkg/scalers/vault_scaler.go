@@ -0,0 +1,261 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	vaultapi "github.com/hashicorp/vault/api"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	vaultOperationRead    = "read"
+	vaultOperationList    = "list"
+	vaultDefaultListField = "keys"
+	// the token of the pod service account, used by the kubernetes authentication when no serviceAccount is given
+	vaultDefaultServiceAccount = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+// vaultClient is the subset of the Vault logical API used by the scaler
+type vaultClient interface {
+	Read(path string) (*vaultapi.Secret, error)
+	List(path string) (*vaultapi.Secret, error)
+}
+
+type vaultScaler struct {
+	metricType v2.MetricTargetType
+	metadata   vaultMetadata
+	client     vaultClient
+	logger     logr.Logger
+}
+
+type vaultMetadata struct {
+	// the settings of the Vault connection and authentication can only come from a TriggerAuthentication,
+	// as the ones of the TriggerAuthentication Vault provider
+	Address         string  `keda:"name=address,         order=authParams"`
+	Namespace       string  `keda:"name=namespace,       order=authParams, optional"`
+	Authentication  string  `keda:"name=authentication,  order=authParams, enum=token;kubernetes, default=token"`
+	Token           string  `keda:"name=token,           order=authParams;resolvedEnv, optional"`
+	Role            string  `keda:"name=role,            order=authParams, optional"`
+	Mount           string  `keda:"name=mount,           order=authParams, optional"`
+	ServiceAccount  string  `keda:"name=serviceAccount,  order=authParams, optional"`
+	Path            string  `keda:"name=path,            order=triggerMetadata"`
+	Operation       string  `keda:"name=operation,       order=triggerMetadata, enum=read;list, default=read"`
+	ValueField      string  `keda:"name=valueField,      order=triggerMetadata, optional"`
+	TargetValue     float64 `keda:"name=targetValue,     order=triggerMetadata"`
+	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, default=0"`
+
+	triggerIndex int
+}
+
+func (m *vaultMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return fmt.Errorf("targetValue must be greater than 0")
+	}
+
+	if m.Authentication == string(kedav1alpha1.VaultAuthenticationKubernetes) && (m.Role == "" || m.Mount == "") {
+		return fmt.Errorf("role and mount must be provided with kubernetes authentication")
+	}
+
+	// the service account token is read by the operator, see ValidateTokenFilePath
+	if m.ServiceAccount != "" {
+		if err := kedautil.ValidateTokenFilePath(m.ServiceAccount); err != nil {
+			return fmt.Errorf("invalid serviceAccount: %w", err)
+		}
+	}
+
+	if m.ValueField == "" {
+		if m.Operation == vaultOperationRead {
+			return fmt.Errorf("valueField must be provided with the read operation")
+		}
+		m.ValueField = vaultDefaultListField
+	}
+
+	return nil
+}
+
+// NewVaultScaler creates a new Vault scaler
+func NewVaultScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	logger := InitializeLogger(config, "vault_scaler")
+
+	meta, err := parseVaultMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing vault metadata: %w", err)
+	}
+
+	client, err := newVaultClient(meta)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing vault client: %w", err)
+	}
+
+	return &vaultScaler{
+		metricType: metricType,
+		metadata:   meta,
+		client:     client.Logical(),
+		logger:     logger,
+	}, nil
+}
+
+func parseVaultMetadata(config *scalersconfig.ScalerConfig) (vaultMetadata, error) {
+	meta := vaultMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(&meta); err != nil {
+		return meta, fmt.Errorf("error parsing vault metadata: %w", err)
+	}
+
+	return meta, nil
+}
+
+// newVaultClient returns a Vault client authenticated as the TriggerAuthentication Vault provider does,
+// the token isn't renewed: once it expired the failing scaler is rebuilt by the scalers cache, which logs in again
+func newVaultClient(meta vaultMetadata) (*vaultapi.Client, error) {
+	client, err := vaultapi.NewClient(vaultapi.DefaultConfig())
+	if err != nil {
+		return nil, err
+	}
+	if err := client.SetAddress(meta.Address); err != nil {
+		return nil, err
+	}
+	if meta.Namespace != "" {
+		client.SetNamespace(meta.Namespace)
+	}
+
+	switch kedav1alpha1.VaultAuthentication(meta.Authentication) {
+	case kedav1alpha1.VaultAuthenticationKubernetes:
+		serviceAccount := meta.ServiceAccount
+		if serviceAccount == "" {
+			serviceAccount = vaultDefaultServiceAccount
+		}
+		jwt, err := os.ReadFile(serviceAccount)
+		if err != nil {
+			return nil, err
+		}
+
+		secret, err := client.Logical().Write(fmt.Sprintf("auth/%s/login", meta.Mount), map[string]interface{}{"jwt": string(jwt), "role": meta.Role})
+		if err != nil {
+			return nil, err
+		}
+		if secret == nil || secret.Auth == nil {
+			return nil, fmt.Errorf("no token returned by the kubernetes login")
+		}
+		client.SetToken(secret.Auth.ClientToken)
+	default:
+		// without token, the one of the VAULT_TOKEN env variable is used
+		if meta.Token != "" {
+			client.SetToken(meta.Token)
+		} else if client.Token() == "" {
+			return nil, fmt.Errorf("could not get Vault token")
+		}
+	}
+
+	// the token is checked upfront, so an invalid one fails the scaler creation
+	if _, err := client.Auth().Token().LookupSelf(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (s *vaultScaler) Close(context.Context) error {
+	return nil
+}
+
+func (s *vaultScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("vault-%s", s.metadata.Path))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *vaultScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getValue()
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting vault value: %w", err)
+	}
+
+	s.logger.V(1).Info("GetMetricsAndActivity", "metricName", metricName, "path", s.metadata.Path, "value", value)
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationValue, nil
+}
+
+// getValue reads the configured path from Vault and extracts the numeric value of valueField
+func (s *vaultScaler) getValue() (float64, error) {
+	var secret *vaultapi.Secret
+	var err error
+	if s.metadata.Operation == vaultOperationList {
+		secret, err = s.client.List(s.metadata.Path)
+	} else {
+		secret, err = s.client.Read(s.metadata.Path)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if secret == nil || secret.Data == nil {
+		// Vault returns no data when listing an empty path
+		if s.metadata.Operation == vaultOperationList {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("no data found at path %s", s.metadata.Path)
+	}
+
+	return getVaultFieldValue(secret.Data, s.metadata.ValueField)
+}
+
+// getVaultFieldValue follows the dot separated field through the secret data and converts the value found to a number,
+// lists and maps are counted
+func getVaultFieldValue(data map[string]interface{}, field string) (float64, error) {
+	var value interface{} = data
+	for _, key := range strings.Split(field, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return 0, fmt.Errorf("field %s not found", field)
+		}
+		if value, ok = m[key]; !ok {
+			return 0, fmt.Errorf("field %s not found", field)
+		}
+	}
+
+	switch v := value.(type) {
+	case json.Number:
+		return v.Float64()
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("field %s is not a number: %w", field, err)
+		}
+		return f, nil
+	case []interface{}:
+		return float64(len(v)), nil
+	case map[string]interface{}:
+		return float64(len(v)), nil
+	default:
+		return 0, fmt.Errorf("field %s has an unsupported type %T", field, value)
+	}
+}
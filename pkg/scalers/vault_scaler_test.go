@@ -0,0 +1,300 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+type parseVaultMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type vaultMetricIdentifier struct {
+	metadataTestData *parseVaultMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+type vaultValueTestData struct {
+	name          string
+	metadata      map[string]string
+	data          map[string]interface{}
+	readErr       error
+	expectedValue float64
+	isActive      bool
+	isError       bool
+}
+
+type mockVaultClient struct {
+	path      string
+	operation string
+	data      map[string]interface{}
+	err       error
+}
+
+func (c *mockVaultClient) Read(path string) (*vaultapi.Secret, error) {
+	c.path, c.operation = path, vaultOperationRead
+	return c.secret()
+}
+
+func (c *mockVaultClient) List(path string) (*vaultapi.Secret, error) {
+	c.path, c.operation = path, vaultOperationList
+	return c.secret()
+}
+
+func (c *mockVaultClient) secret() (*vaultapi.Secret, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.data == nil {
+		return nil, nil
+	}
+	return &vaultapi.Secret{Data: c.data}, nil
+}
+
+var testVaultMetadata = []parseVaultMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed read
+	{map[string]string{"path": "secret/data/app", "valueField": "data.count", "targetValue": "5"}, map[string]string{"address": "http://vault:8200", "token": "token"}, false},
+	// properly formed list
+	{map[string]string{"path": "sys/leases/lookup/database/creds/app", "operation": "list", "targetValue": "10", "activationValue": "2"}, map[string]string{"address": "http://vault:8200", "token": "token"}, false},
+	// address from triggerMetadata
+	{map[string]string{"address": "http://vault:8200", "path": "secret/data/app", "valueField": "data.count", "targetValue": "5"}, map[string]string{"token": "token"}, true},
+	// missing address
+	{map[string]string{"path": "secret/data/app", "valueField": "data.count", "targetValue": "5"}, map[string]string{"token": "token"}, true},
+	// missing path
+	{map[string]string{"valueField": "data.count", "targetValue": "5"}, map[string]string{"address": "http://vault:8200", "token": "token"}, true},
+	// missing targetValue
+	{map[string]string{"path": "secret/data/app", "valueField": "data.count"}, map[string]string{"address": "http://vault:8200", "token": "token"}, true},
+	// invalid targetValue
+	{map[string]string{"path": "secret/data/app", "valueField": "data.count", "targetValue": "0"}, map[string]string{"address": "http://vault:8200", "token": "token"}, true},
+	// read without valueField
+	{map[string]string{"path": "secret/data/app", "targetValue": "5"}, map[string]string{"address": "http://vault:8200", "token": "token"}, true},
+	// invalid operation
+	{map[string]string{"path": "secret/data/app", "operation": "write", "valueField": "data.count", "targetValue": "5"}, map[string]string{"address": "http://vault:8200", "token": "token"}, true},
+	// invalid authentication
+	{map[string]string{"path": "secret/data/app", "valueField": "data.count", "targetValue": "5"}, map[string]string{"address": "http://vault:8200", "authentication": "ldap"}, true},
+	// kubernetes authentication
+	{map[string]string{"path": "secret/data/app", "valueField": "data.count", "targetValue": "5"}, map[string]string{"address": "http://vault:8200", "authentication": "kubernetes", "role": "keda", "mount": "kubernetes"}, false},
+	// kubernetes authentication without role
+	{map[string]string{"path": "secret/data/app", "valueField": "data.count", "targetValue": "5"}, map[string]string{"address": "http://vault:8200", "authentication": "kubernetes", "mount": "kubernetes"}, true},
+	// service account token outside of the token file directory
+	{map[string]string{"path": "secret/data/app", "valueField": "data.count", "targetValue": "5"}, map[string]string{"address": "http://vault:8200", "authentication": "kubernetes", "role": "keda", "mount": "kubernetes", "serviceAccount": "/etc/shadow"}, true},
+	// service account token within the token file directory
+	{map[string]string{"path": "secret/data/app", "valueField": "data.count", "targetValue": "5"}, map[string]string{"address": "http://vault:8200", "authentication": "kubernetes", "role": "keda", "mount": "kubernetes", "serviceAccount": "/var/run/secrets/vault/token"}, false},
+}
+
+var vaultMetricIdentifiers = []vaultMetricIdentifier{
+	{&testVaultMetadata[1], 0, "s0-vault-secret-data-app"},
+	{&testVaultMetadata[2], 1, "s1-vault-sys-leases-lookup-database-creds-app"},
+}
+
+var vaultValueTestDataset = []vaultValueTestData{
+	{
+		name:          "lease count",
+		metadata:      map[string]string{"operation": "list", "targetValue": "10"},
+		data:          map[string]interface{}{"keys": []interface{}{"lease-1", "lease-2", "lease-3"}},
+		expectedValue: 3,
+		isActive:      true,
+	},
+	{
+		name:          "lease count below activation",
+		metadata:      map[string]string{"operation": "list", "targetValue": "10", "activationValue": "3"},
+		data:          map[string]interface{}{"keys": []interface{}{"lease-1", "lease-2", "lease-3"}},
+		expectedValue: 3,
+		isActive:      false,
+	},
+	{
+		name:          "no leases",
+		metadata:      map[string]string{"operation": "list", "targetValue": "10"},
+		expectedValue: 0,
+		isActive:      false,
+	},
+	{
+		name:          "kv v2 field",
+		metadata:      map[string]string{"valueField": "data.count", "targetValue": "5"},
+		data:          map[string]interface{}{"data": map[string]interface{}{"count": "12"}},
+		expectedValue: 12,
+		isActive:      true,
+	},
+	{
+		name:          "kv metadata number",
+		metadata:      map[string]string{"valueField": "current_version", "targetValue": "5"},
+		data:          map[string]interface{}{"current_version": json.Number("4")},
+		expectedValue: 4,
+		isActive:      true,
+	},
+	{
+		name:     "missing field",
+		metadata: map[string]string{"valueField": "data.missing", "targetValue": "5"},
+		data:     map[string]interface{}{"data": map[string]interface{}{"count": "12"}},
+		isError:  true,
+	},
+	{
+		name:     "field not a number",
+		metadata: map[string]string{"valueField": "data.count", "targetValue": "5"},
+		data:     map[string]interface{}{"data": map[string]interface{}{"count": "many"}},
+		isError:  true,
+	},
+	{
+		name:     "read without data",
+		metadata: map[string]string{"valueField": "data.count", "targetValue": "5"},
+		isError:  true,
+	},
+	{
+		name:     "vault error",
+		metadata: map[string]string{"valueField": "data.count", "targetValue": "5"},
+		readErr:  fmt.Errorf("permission denied"),
+		isError:  true,
+	},
+}
+
+func TestVaultParseMetadata(t *testing.T) {
+	t.Setenv(kedautil.TokenFileDirectoryEnvVar, "/var/run/secrets/vault")
+	for _, testData := range testVaultMetadata {
+		_, err := parseVaultMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for %v", testData.metadata)
+		}
+	}
+}
+
+func TestVaultParseMetadataIgnoresAuthenticationFromTriggerMetadata(t *testing.T) {
+	meta, err := parseVaultMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"path": "secret/data/app", "authentication": "kubernetes", "serviceAccount": "/etc/shadow", "valueField": "data.count", "targetValue": "5"},
+		AuthParams:      map[string]string{"address": "http://vault:8200", "token": "token"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, string(kedav1alpha1.VaultAuthenticationToken), meta.Authentication)
+	assert.Empty(t, meta.ServiceAccount)
+}
+
+func TestVaultGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range vaultMetricIdentifiers {
+		meta, err := parseVaultMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockVaultScaler := vaultScaler{
+			metadata: meta,
+			logger:   logr.Discard(),
+		}
+
+		metricSpec := mockVaultScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestVaultGetMetricsAndActivity(t *testing.T) {
+	for _, testData := range vaultValueTestDataset {
+		t.Run(testData.name, func(t *testing.T) {
+			metadata := map[string]string{"path": "secret/data/app"}
+			for k, v := range testData.metadata {
+				metadata[k] = v
+			}
+			meta, err := parseVaultMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{"address": "http://vault:8200", "token": "token"}})
+			assert.NoError(t, err)
+
+			client := &mockVaultClient{data: testData.data, err: testData.readErr}
+			scaler := vaultScaler{
+				metadata: meta,
+				client:   client,
+				logger:   logr.Discard(),
+			}
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "vault")
+			assert.Equal(t, "secret/data/app", client.path)
+			assert.Equal(t, meta.Operation, client.operation)
+			if testData.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expectedValue, metrics[0].Value.AsApproximateFloat64())
+			assert.Equal(t, testData.isActive, isActive)
+		})
+	}
+}
+
+// newFakeVaultServer returns a fake Vault API accepting the given token and logging in the kubernetes role keda
+// with the given service account token
+func newFakeVaultServer(t *testing.T, token, jwt string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/auth/kubernetes/login":
+			var login map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&login); err != nil || login["jwt"] != jwt || login["role"] != "keda" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"auth": {"client_token": %q}}`, token)
+		case "/v1/auth/token/lookup-self":
+			if r.Header.Get("X-Vault-Token") != token {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors": ["permission denied"]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data": {"renewable": false}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestVaultNewClient(t *testing.T) {
+	serviceAccount := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(serviceAccount, []byte("jwt"), 0600))
+	server := newFakeVaultServer(t, "valid", "jwt")
+
+	testCases := []struct {
+		name          string
+		meta          vaultMetadata
+		expectedToken string
+		isError       bool
+	}{
+		{name: "token", meta: vaultMetadata{Authentication: "token", Token: "valid"}, expectedToken: "valid"},
+		{name: "invalid token", meta: vaultMetadata{Authentication: "token", Token: "invalid"}, isError: true},
+		{name: "kubernetes", meta: vaultMetadata{Authentication: "kubernetes", Role: "keda", Mount: "kubernetes", ServiceAccount: serviceAccount}, expectedToken: "valid"},
+		{name: "kubernetes with another role", meta: vaultMetadata{Authentication: "kubernetes", Role: "other", Mount: "kubernetes", ServiceAccount: serviceAccount}, isError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("VAULT_TOKEN", "")
+			tc.meta.Address = server.URL
+			client, err := newVaultClient(tc.meta)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedToken, client.Token())
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+	"github.com/kedacore/keda/v2/pkg/webhookreceiver"
+)
+
+type webhookScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *webhookMetadata
+	store      *webhookreceiver.Store
+	now        func() time.Time
+	logger     logr.Logger
+}
+
+type webhookMetadata struct {
+	triggerIndex int
+
+	Key             string  `keda:"name=key,             order=triggerMetadata"`
+	Token           string  `keda:"name=token,           order=authParams"`
+	Value           float64 `keda:"name=value,           order=triggerMetadata, default=0"`
+	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, default=0"`
+	MaxAge          string  `keda:"name=maxAge,          order=triggerMetadata, default=5m"`
+
+	namespace      string
+	maxAge         time.Duration
+	asMetricSource bool
+}
+
+func (m *webhookMetadata) Validate() error {
+	if m.Value <= 0 && !m.asMetricSource {
+		return fmt.Errorf("value must be a float greater than 0")
+	}
+
+	var err error
+	if m.maxAge, err = time.ParseDuration(m.MaxAge); err != nil || m.maxAge <= 0 {
+		return fmt.Errorf("maxAge must be a positive duration, got %q", m.MaxAge)
+	}
+	return nil
+}
+
+// NewWebhookScaler creates a new scaler on the last value POSTed for its key to the webhook receiver
+// hosted by the operator, the value being ignored once older than maxAge
+func NewWebhookScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseWebhookMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing webhook metadata: %w", err)
+	}
+
+	return newWebhookScaler(metricType, meta, webhookreceiver.DefaultStore(), InitializeLogger(config, "webhook_scaler")), nil
+}
+
+func newWebhookScaler(metricType v2.MetricTargetType, meta *webhookMetadata, store *webhookreceiver.Store, logger logr.Logger) *webhookScaler {
+	store.Register(webhookreceiver.StoreKey(meta.namespace, meta.Key), meta.Token)
+	return &webhookScaler{
+		metricType: metricType,
+		metadata:   meta,
+		store:      store,
+		now:        time.Now,
+		logger:     logger,
+	}
+}
+
+func parseWebhookMetadata(config *scalersconfig.ScalerConfig) (*webhookMetadata, error) {
+	meta := &webhookMetadata{}
+	meta.triggerIndex = config.TriggerIndex
+	meta.namespace = config.ScalableObjectNamespace
+	meta.asMetricSource = config.AsMetricSource
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing webhook metadata: %w", err)
+	}
+	return meta, nil
+}
+
+// Close unregisters the key of the scaler, so its values aren't accepted anymore once no scaler uses it
+func (s *webhookScaler) Close(context.Context) error {
+	s.store.Unregister(webhookreceiver.StoreKey(s.metadata.namespace, s.metadata.Key), s.metadata.Token)
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *webhookScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("webhook-%s", s.metadata.Key))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns value for a supported metric
+func (s *webhookScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getMetricValue()
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationValue, nil
+}
+
+// getMetricValue returns the last value POSTed for the key, failing when none has been received within maxAge
+// so the fallback of the ScaledObject applies while the external system doesn't send values
+func (s *webhookScaler) getMetricValue() (float64, error) {
+	value, found := s.store.Get(webhookreceiver.StoreKey(s.metadata.namespace, s.metadata.Key))
+	if !found {
+		return 0, fmt.Errorf("no value received for key %q", s.metadata.Key)
+	}
+	if age := s.now().Sub(value.ReceivedAt); age > s.metadata.maxAge {
+		return 0, fmt.Errorf("last value for key %q received %s ago, older than maxAge %s", s.metadata.Key, age.Round(time.Second), s.metadata.maxAge)
+	}
+	return value.Value, nil
+}
@@ -0,0 +1,139 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	v2 "k8s.io/api/autoscaling/v2"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/webhookreceiver"
+)
+
+type parseWebhookMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type webhookMetricIdentifier struct {
+	metadataTestData *parseWebhookMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testWebhookMetadata = []parseWebhookMetadataTestData{
+	// success
+	{map[string]string{"key": "orders", "value": "10"}, map[string]string{"token": "secret"}, false},
+	// success, maxAge and activationValue
+	{map[string]string{"key": "orders", "value": "10", "activationValue": "2", "maxAge": "30s"}, map[string]string{"token": "secret"}, false},
+	// failure, no key
+	{map[string]string{"value": "10"}, map[string]string{"token": "secret"}, true},
+	// failure, no token
+	{map[string]string{"key": "orders", "value": "10"}, map[string]string{}, true},
+	// failure, token in the trigger metadata
+	{map[string]string{"key": "orders", "value": "10", "token": "secret"}, map[string]string{}, true},
+	// failure, no value
+	{map[string]string{"key": "orders"}, map[string]string{"token": "secret"}, true},
+	// failure, invalid maxAge
+	{map[string]string{"key": "orders", "value": "10", "maxAge": "soon"}, map[string]string{"token": "secret"}, true},
+	// failure, negative maxAge
+	{map[string]string{"key": "orders", "value": "10", "maxAge": "-1m"}, map[string]string{"token": "secret"}, true},
+}
+
+var webhookMetricIdentifiers = []webhookMetricIdentifier{
+	{&testWebhookMetadata[0], 0, "s0-webhook-orders"},
+	{&testWebhookMetadata[1], 1, "s1-webhook-orders"},
+}
+
+func TestParseWebhookMetadata(t *testing.T) {
+	for _, testData := range testWebhookMetadata {
+		_, err := parseWebhookMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestWebhookGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range webhookMetricIdentifiers {
+		meta, err := parseWebhookMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := newWebhookScaler(v2.AverageValueMetricType, meta, webhookreceiver.NewStore(), logr.Discard())
+
+		metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestWebhookGetMetricsAndActivity(t *testing.T) {
+	store := webhookreceiver.NewStore()
+	receiver := webhookreceiver.NewServer(store)
+	meta, err := parseWebhookMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata:         map[string]string{"key": "orders", "value": "10", "activationValue": "2", "maxAge": "1m"},
+		AuthParams:              map[string]string{"token": "secret"},
+		ScalableObjectNamespace: "default",
+	})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	scaler := newWebhookScaler(v2.AverageValueMetricType, meta, store, logr.Discard())
+
+	// no value received yet
+	_, _, err = scaler.GetMetricsAndActivity(context.Background(), "s0-webhook-orders")
+	assert.Error(t, err)
+
+	post := func(value string) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/default/values/orders", strings.NewReader(`{"value": `+value+`}`))
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		receiver.Handler().ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	}
+
+	post("25")
+	metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "s0-webhook-orders")
+	assert.NoError(t, err)
+	assert.True(t, isActive)
+	assert.Equal(t, int64(25000), metrics[0].Value.MilliValue())
+
+	post("1")
+	metrics, isActive, err = scaler.GetMetricsAndActivity(context.Background(), "s0-webhook-orders")
+	assert.NoError(t, err)
+	assert.False(t, isActive)
+	assert.Equal(t, int64(1000), metrics[0].Value.MilliValue())
+
+	// the value expires after maxAge
+	scaler.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	_, isActive, err = scaler.GetMetricsAndActivity(context.Background(), "s0-webhook-orders")
+	assert.ErrorContains(t, err, "older than maxAge")
+	assert.False(t, isActive)
+
+	// a new value is fresh again
+	scaler.now = time.Now
+	post("5")
+	_, isActive, err = scaler.GetMetricsAndActivity(context.Background(), "s0-webhook-orders")
+	assert.NoError(t, err)
+	assert.True(t, isActive)
+
+	// values aren't accepted anymore once the scaler is closed, the last one being kept for a scaler created again
+	assert.NoError(t, scaler.Close(context.Background()))
+	assert.False(t, store.Set(webhookreceiver.StoreKey("default", "orders"), "secret", 42))
+	value, found := store.Get(webhookreceiver.StoreKey("default", "orders"))
+	assert.True(t, found)
+	assert.Equal(t, float64(5), value.Value)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// MetricDerivative keeps the last value of the metric of scalingModifiers.derivative, to compute
+// its rate of change across the polls of the scale loop. It lives as long as the cache, so it starts
+// over whenever the scalers are rebuilt.
+type MetricDerivative struct {
+	mutex     sync.Mutex
+	hasValue  bool
+	value     float64
+	timestamp time.Time
+	rate      float64
+}
+
+// Update records the value of the metric at the given time and returns its per-second rate of change
+// since the previous value. The first value, and the first one after a reset, have a rate of 0, and
+// a decreasing metric has a rate of 0 too, as it's used as a scaling metric.
+func (d *MetricDerivative) Update(value float64, timestamp time.Time) float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// a value older than the previous one can't be ordered with it, so it starts over
+	if !d.hasValue || timestamp.Before(d.timestamp) {
+		d.hasValue = true
+		d.value = value
+		d.timestamp = timestamp
+		d.rate = 0
+		return d.rate
+	}
+
+	// the same value read again, e.g. from the metrics cache, keeps the last rate
+	elapsed := timestamp.Sub(d.timestamp).Seconds()
+	if elapsed == 0 {
+		return d.rate
+	}
+
+	d.rate = max((value-d.value)/elapsed, 0)
+	d.value = value
+	d.timestamp = timestamp
+	return d.rate
+}
+
+// Rate returns the rate returned by the last Update
+func (d *MetricDerivative) Rate() float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.rate
+}
+
+// Reset forgets the previous value, e.g. when the metric couldn't be read
+func (d *MetricDerivative) Reset() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.hasValue = false
+	d.rate = 0
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricDerivative(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	type poll struct {
+		value    float64
+		offset   time.Duration
+		reset    bool
+		expected float64
+	}
+	tests := []struct {
+		name  string
+		polls []poll
+	}{
+		{
+			name: "growing backlog",
+			polls: []poll{
+				{value: 100, offset: 0, expected: 0},
+				{value: 130, offset: 30 * time.Second, expected: 1},
+				{value: 190, offset: 60 * time.Second, expected: 2},
+			},
+		},
+		{
+			name: "shrinking backlog is clamped to 0",
+			polls: []poll{
+				{value: 200, offset: 0, expected: 0},
+				{value: 150, offset: 10 * time.Second, expected: 0},
+				{value: 150, offset: 20 * time.Second, expected: 0},
+			},
+		},
+		{
+			name: "same value read again keeps the rate",
+			polls: []poll{
+				{value: 10, offset: 0, expected: 0},
+				{value: 40, offset: 15 * time.Second, expected: 2},
+				{value: 40, offset: 15 * time.Second, expected: 2},
+				{value: 70, offset: 30 * time.Second, expected: 2},
+			},
+		},
+		{
+			name: "reset starts over",
+			polls: []poll{
+				{value: 10, offset: 0, expected: 0},
+				{value: 20, offset: 10 * time.Second, expected: 1},
+				{value: 500, offset: 20 * time.Second, reset: true, expected: 0},
+				{value: 530, offset: 30 * time.Second, expected: 3},
+			},
+		},
+		{
+			name: "older value starts over",
+			polls: []poll{
+				{value: 10, offset: 30 * time.Second, expected: 0},
+				{value: 40, offset: 0, expected: 0},
+				{value: 50, offset: 5 * time.Second, expected: 2},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			derivative := &MetricDerivative{}
+			for i, p := range test.polls {
+				if p.reset {
+					derivative.Reset()
+				}
+				rate := derivative.Update(p.value, start.Add(p.offset))
+				assert.InDelta(t, p.expected, rate, 1e-9, "poll %d", i)
+				assert.Equal(t, rate, derivative.Rate(), "poll %d", i)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package cache
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
@@ -41,6 +43,7 @@ type ScalersCache struct {
 	ScalableObjectGeneration int64
 	Recorder                 record.EventRecorder
 	CompiledFormula          *vm.Program
+	Derivative               *MetricDerivative
 	mutex                    sync.RWMutex
 }
 
@@ -48,6 +51,9 @@ type ScalerBuilder struct {
 	Scaler       scalers.Scaler
 	ScalerConfig scalersconfig.ScalerConfig
 	Factory      func() (scalers.Scaler, *scalersconfig.ScalerConfig, error)
+
+	// lastPoll is the last time the scale loop polled the scaler
+	lastPoll time.Time
 }
 
 // GetScalers returns array of scalers and scaler config stored in the cache
@@ -76,6 +82,35 @@ func (c *ScalersCache) getScalerBuilder(index int) (ScalerBuilder, error) {
 	return c.Scalers[index], nil
 }
 
+// triggerPollTolerance is subtracted from the polling interval of the scalers, so the jitter of the
+// scale loop doesn't postpone their polls to the next loop
+const triggerPollTolerance = 500 * time.Millisecond
+
+// IsTriggerPollDue returns whether the scaler at the index has to be polled at the given time, i.e. whether
+// the interval elapsed since its last poll, the poll being then recorded
+func (c *ScalersCache) IsTriggerPollDue(index int, interval time.Duration, now time.Time) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if index < 0 || index >= len(c.Scalers) {
+		return false
+	}
+
+	if !c.Scalers[index].lastPoll.IsZero() && now.Sub(c.Scalers[index].lastPoll) < interval-triggerPollTolerance {
+		return false
+	}
+	c.Scalers[index].lastPoll = now
+	return true
+}
+
+// ResetTriggerPolls makes all the scalers due for the next poll
+func (c *ScalersCache) ResetTriggerPolls() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for i := range c.Scalers {
+		c.Scalers[i].lastPoll = time.Time{}
+	}
+}
+
 // GetPushScalers returns array of push scalers stored in the cache
 func (c *ScalersCache) GetPushScalers() []scalers.PushScaler {
 	var result []scalers.PushScaler
@@ -107,7 +142,7 @@ func (c *ScalersCache) GetMetricSpecForScaling(ctx context.Context) []v2.MetricS
 	defer c.mutex.RUnlock()
 	var spec []v2.MetricSpec
 	for _, s := range c.Scalers {
-		spec = append(spec, s.Scaler.GetMetricSpecForScaling(ctx)...)
+		spec = append(spec, overrideMetricName(s.Scaler.GetMetricSpecForScaling(ctx), s.ScalerConfig.TriggerMetricName)...)
 	}
 	return spec
 }
@@ -137,7 +172,7 @@ func (c *ScalersCache) GetMetricSpecForScalingForScaler(ctx context.Context, ind
 		}
 	}
 
-	return metricSpecs, err
+	return overrideMetricName(metricSpecs, sb.ScalerConfig.TriggerMetricName), err
 }
 
 // GetMetricsAndActivityForScaler returns metric value, activity and latency for a scaler identified by the metric name
@@ -148,9 +183,11 @@ func (c *ScalersCache) GetMetricsAndActivityForScaler(ctx context.Context, index
 		return nil, false, -1, err
 	}
 	startTime := time.Now()
-	metric, activity, err := sb.Scaler.GetMetricsAndActivity(ctx, metricName)
+	metric, activity, err := getMetricsAndActivity(ctx, sb.Scaler, sb.ScalerConfig.TriggerMetricName, metricName)
+	latency := time.Since(startTime)
+	metricscollector.RecordScalerDuration(sb.ScalerConfig.TriggerType, latency)
 	if err == nil {
-		return metric, activity, time.Since(startTime), nil
+		return metric, activity, latency, nil
 	}
 
 	ns, err := c.refreshScaler(ctx, index)
@@ -158,8 +195,55 @@ func (c *ScalersCache) GetMetricsAndActivityForScaler(ctx context.Context, index
 		return nil, false, -1, err
 	}
 	startTime = time.Now()
-	metric, activity, err = ns.GetMetricsAndActivity(ctx, metricName)
-	return metric, activity, time.Since(startTime), err
+	metric, activity, err = getMetricsAndActivity(ctx, ns, sb.ScalerConfig.TriggerMetricName, metricName)
+	latency = time.Since(startTime)
+	metricscollector.RecordScalerDuration(sb.ScalerConfig.TriggerType, latency)
+	return metric, activity, latency, err
+}
+
+// overrideMetricName renames the first external metric of the scaler to the metricName defined on the trigger
+func overrideMetricName(metricSpecs []v2.MetricSpec, metricName string) []v2.MetricSpec {
+	if metricName == "" {
+		return metricSpecs
+	}
+
+	// the scaler might reuse the returned specs, so they are copied before being renamed
+	result := slices.Clone(metricSpecs)
+	for i, spec := range result {
+		if spec.External == nil {
+			continue
+		}
+		external := *spec.External
+		external.Metric.Name = metricName
+		result[i].External = &external
+		break
+	}
+	return result
+}
+
+// getMetricsAndActivity queries the scaler for the metric, translating the metricName defined on the trigger
+// to the name of the metric generated by the scaler
+func getMetricsAndActivity(ctx context.Context, scaler scalers.Scaler, metricNameOverride string, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	if metricNameOverride == "" || metricName != metricNameOverride {
+		return scaler.GetMetricsAndActivity(ctx, metricName)
+	}
+
+	scalerMetricName := ""
+	for _, spec := range scaler.GetMetricSpecForScaling(ctx) {
+		if spec.External != nil {
+			scalerMetricName = spec.External.Metric.Name
+			break
+		}
+	}
+	if scalerMetricName == "" {
+		return nil, false, fmt.Errorf("no external metric found for metricName %q", metricName)
+	}
+
+	metrics, activity, err := scaler.GetMetricsAndActivity(ctx, scalerMetricName)
+	for i := range metrics {
+		metrics[i].MetricName = metricName
+	}
+	return metrics, activity, err
 }
 
 func (c *ScalersCache) refreshScaler(ctx context.Context, index int) (scalers.Scaler, error) {
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/kedacore/keda/v2/pkg/metricscollector"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+// slowScaler is a sample scaler taking a fixed time to return its metric
+type slowScaler struct {
+	delay time.Duration
+}
+
+func (s *slowScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	time.Sleep(s.delay)
+	return []external_metrics.ExternalMetricValue{{MetricName: metricName}}, true, nil
+}
+
+func (s *slowScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	return nil
+}
+
+func (s *slowScaler) Close(context.Context) error {
+	return nil
+}
+
+func TestGetMetricsAndActivityForScalerRecordsDuration(t *testing.T) {
+	metricscollector.NewMetricsCollectors(true, false)
+
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{{
+			Scaler:       &slowScaler{delay: 20 * time.Millisecond},
+			ScalerConfig: scalersconfig.ScalerConfig{TriggerType: "sample"},
+		}},
+	}
+
+	for i := 0; i < 3; i++ {
+		_, _, latency, err := cache.GetMetricsAndActivityForScaler(context.Background(), 0, "s0-sample")
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, latency, 20*time.Millisecond)
+	}
+
+	families, err := metrics.Registry.Gather()
+	require.NoError(t, err)
+
+	found := false
+	for _, family := range families {
+		if family.GetName() != "keda_scaler_metrics_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			// the histogram is only labeled by the scaler type
+			require.Len(t, metric.GetLabel(), 1)
+			assert.Equal(t, "type", metric.GetLabel()[0].GetName())
+			if metric.GetLabel()[0].GetValue() != "sample" {
+				continue
+			}
+			found = true
+			assert.Equal(t, uint64(3), metric.GetHistogram().GetSampleCount())
+			assert.GreaterOrEqual(t, metric.GetHistogram().GetSampleSum(), 0.06)
+		}
+	}
+	assert.True(t, found, "the duration of the sample scaler wasn't observed")
+}
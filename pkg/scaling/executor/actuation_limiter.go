@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/utils/clock"
+)
+
+// ActuationLimiter bounds the number of activations and deactivations of the ScaledObjects done by KEDA per interval,
+// so a cluster-wide event activating many ScaledObjects at once doesn't overwhelm the scheduler.
+// It only limits the scaling from and to zero (or idleReplicaCount) done by KEDA itself, the scaling between
+// minReplicaCount and maxReplicaCount is done by the HPA and isn't limited. The paused and fallback replicas
+// aren't limited either.
+//
+// An actuation above the limit is deferred: the scale loop of the ScaledObject waits for its turn without holding
+// the scaling lock of the ScaledObject, then checks it again and actuates it if it's still needed.
+type ActuationLimiter struct {
+	limiter *rate.Limiter
+	clock   clock.Clock
+
+	lock sync.Mutex
+	// deferred are the ScaledObjects whose actuation has been refused
+	deferred map[string]bool
+	// granted are the ScaledObjects that have waited for their actuation
+	granted map[string]bool
+}
+
+// NewActuationLimiter creates an ActuationLimiter allowing maxActuations per interval on average, with bursts of
+// up to maxActuations after a calm period.
+// It returns nil, which doesn't limit anything, if maxActuations or interval isn't positive
+func NewActuationLimiter(maxActuations int, interval time.Duration) *ActuationLimiter {
+	return newActuationLimiter(maxActuations, interval, clock.RealClock{})
+}
+
+func newActuationLimiter(maxActuations int, interval time.Duration, clock clock.Clock) *ActuationLimiter {
+	if maxActuations <= 0 || interval <= 0 {
+		return nil
+	}
+	return &ActuationLimiter{
+		limiter:  rate.NewLimiter(rate.Every(interval/time.Duration(maxActuations)), maxActuations),
+		clock:    clock,
+		deferred: map[string]bool{},
+		granted:  map[string]bool{},
+	}
+}
+
+// allow returns whether the ScaledObject can be actuated now, using the actuation it has waited for if any.
+// The actuation is deferred when it isn't allowed
+func (l *ActuationLimiter) allow(scaledObjectIdentifier string) bool {
+	if l == nil {
+		return true
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.granted[scaledObjectIdentifier] {
+		delete(l.granted, scaledObjectIdentifier)
+		return true
+	}
+	if l.limiter.AllowN(l.clock.Now(), 1) {
+		return true
+	}
+	l.deferred[scaledObjectIdentifier] = true
+	return false
+}
+
+// WaitDeferred blocks until the deferred actuation of the ScaledObject is allowed, the ScaledObject can then be
+// actuated once without being limited. It returns false without waiting if no actuation of the ScaledObject has
+// been deferred, and an error if the context ends before
+func (l *ActuationLimiter) WaitDeferred(ctx context.Context, scaledObjectIdentifier string) (bool, error) {
+	if l == nil {
+		return false, nil
+	}
+	l.lock.Lock()
+	deferred := l.deferred[scaledObjectIdentifier]
+	delete(l.deferred, scaledObjectIdentifier)
+	var reservation *rate.Reservation
+	if deferred {
+		// the reservations are served in order, the actuations deferred first are allowed first
+		reservation = l.limiter.ReserveN(l.clock.Now(), 1)
+	}
+	l.lock.Unlock()
+	if !deferred {
+		return false, nil
+	}
+
+	if delay := reservation.DelayFrom(l.clock.Now()); delay > 0 {
+		timer := l.clock.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			reservation.CancelAt(l.clock.Now())
+			return false, ctx.Err()
+		case <-timer.C():
+		}
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.granted[scaledObjectIdentifier] = true
+	return true, nil
+}
+
+// Release drops the actuation the ScaledObject has waited for when it hasn't been used,
+// e.g. when the ScaledObject doesn't need to be actuated anymore
+func (l *ActuationLimiter) Release(scaledObjectIdentifier string) {
+	if l == nil {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	delete(l.granted, scaledObjectIdentifier)
+}
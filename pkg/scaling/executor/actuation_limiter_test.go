@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_scale"
+)
+
+func TestNewActuationLimiterDisabled(t *testing.T) {
+	assert.Nil(t, NewActuationLimiter(0, time.Second))
+	assert.Nil(t, NewActuationLimiter(-1, time.Second))
+	assert.Nil(t, NewActuationLimiter(10, 0))
+
+	var limiter *ActuationLimiter
+	assert.True(t, limiter.allow("ScaledObject.namespace.name"))
+	waited, err := limiter.WaitDeferred(context.Background(), "ScaledObject.namespace.name")
+	assert.NoError(t, err)
+	assert.False(t, waited)
+	limiter.Release("ScaledObject.namespace.name")
+}
+
+func TestActuationLimiterDefersActuationsAboveTheLimit(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	limiter := newActuationLimiter(2, time.Second, fakeClock)
+
+	// the burst is allowed, the next actuations are deferred
+	assert.True(t, limiter.allow("a"))
+	assert.True(t, limiter.allow("b"))
+	assert.False(t, limiter.allow("c"))
+	assert.False(t, limiter.allow("d"))
+
+	// an object without a deferred actuation doesn't wait
+	waited, err := limiter.WaitDeferred(context.Background(), "a")
+	assert.NoError(t, err)
+	assert.False(t, waited)
+
+	// the deferred actuations are allowed in order, one per half interval
+	cDone := waitDeferredInBackground(limiter, "c")
+	require.Eventually(t, fakeClock.HasWaiters, time.Second, time.Millisecond)
+	dDone := waitDeferredInBackground(limiter, "d")
+
+	fakeClock.Step(500 * time.Millisecond)
+	assert.True(t, <-cDone)
+	require.Eventually(t, fakeClock.HasWaiters, time.Second, time.Millisecond)
+	select {
+	case <-dDone:
+		t.Fatal("the actuation of d shouldn't be allowed yet")
+	default:
+	}
+	fakeClock.Step(500 * time.Millisecond)
+	assert.True(t, <-dDone)
+
+	// the actuation waited for is used once
+	assert.True(t, limiter.allow("c"))
+	assert.False(t, limiter.allow("c"))
+
+	// and dropped when it isn't needed anymore
+	limiter.Release("d")
+	assert.False(t, limiter.allow("d"))
+}
+
+func TestActuationLimiterWaitDeferredCanceled(t *testing.T) {
+	limiter := newActuationLimiter(1, time.Hour, clocktesting.NewFakeClock(time.Now()))
+	assert.True(t, limiter.allow("a"))
+	assert.False(t, limiter.allow("b"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	waited, err := limiter.WaitDeferred(ctx, "b")
+	assert.Error(t, err)
+	assert.False(t, waited)
+	assert.False(t, limiter.allow("b"))
+}
+
+func waitDeferredInBackground(limiter *ActuationLimiter, scaledObjectIdentifier string) <-chan bool {
+	done := make(chan bool, 1)
+	go func() {
+		waited, _ := limiter.WaitDeferred(context.Background(), scaledObjectIdentifier)
+		done <- waited
+	}()
+	return done
+}
+
+func TestActivationsAreLimitedButNotFallbackReplicas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(10)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+	logger := logf.Log.WithName("ActuationLimiterTest")
+
+	limiter := newActuationLimiter(1, time.Hour, clocktesting.NewFakeClock(time.Now()))
+	executor := NewScaleExecutor(client, mockScaleClient, nil, recorder, 0, limiter).(*scaleExecutor)
+
+	newScaledObject := func(name string) *v1alpha1.ScaledObject {
+		scaledObject := &v1alpha1.ScaledObject{
+			ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "namespace"},
+			Spec: v1alpha1.ScaledObjectSpec{
+				ScaleTargetRef: &v1alpha1.ScaleTarget{Name: name},
+				Fallback:       &v1alpha1.Fallback{FailureThreshold: 3, Replicas: 5},
+			},
+			Status: v1alpha1.ScaledObjectStatus{
+				ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{Group: "apps", Kind: "Deployment"},
+			},
+		}
+		scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+		return scaledObject
+	}
+
+	client.EXPECT().Status().Return(statusWriter).AnyTimes()
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	// the first activation is done, the second one is deferred
+	executor.scaleFromZeroOrIdle(context.TODO(), logger, newScaledObject("first"), &autoscalingv1.Scale{}, 1, nil)
+	deferred := newScaledObject("deferred")
+	executor.scaleFromZeroOrIdle(context.TODO(), logger, deferred, &autoscalingv1.Scale{}, 1, nil)
+	assert.True(t, limiter.deferred[deferred.GenerateIdentifier()])
+
+	// the fallback replicas are set although the limit is reached
+	scale := &autoscalingv1.Scale{}
+	executor.doFallbackScaling(context.TODO(), newScaledObject("fallback"), scale, logger, 0)
+	assert.Equal(t, int32(5), scale.Spec.Replicas)
+}
@@ -19,6 +19,7 @@ package executor
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,13 +41,17 @@ const (
 
 // ScaleExecutor contains methods RequestJobScale and RequestScale
 type ScaleExecutor interface {
-	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, isError bool, scaleTo int64, maxScale int64)
+	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, isError bool, scaleTo int64, maxScale int64, options *ScaleExecutorOptions)
 	RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, options *ScaleExecutorOptions)
 }
 
-// ScaleExecutorOptions contains the optional parameters for the RequestScale method.
+// ScaleExecutorOptions contains the optional parameters for the RequestScale and RequestJobScale methods.
 type ScaleExecutorOptions struct {
 	ActiveTriggers []string
+	// ActiveMetricNames contains the names of the metrics of the active triggers of a ScaledJob
+	ActiveMetricNames []string
+	// Priorities contains the priorities of the units of work of the active triggers of a ScaledJob
+	Priorities []int64
 }
 
 type scaleExecutor struct {
@@ -55,21 +60,31 @@ type scaleExecutor struct {
 	reconcilerScheme *runtime.Scheme
 	logger           logr.Logger
 	recorder         record.EventRecorder
+
+	// statusUpdateMinInterval is the minimum interval between two updates of the lastActiveTime
+	statusUpdateMinInterval time.Duration
+	// actuationLimiter bounds the number of activations and deactivations per interval, nil for no limit
+	actuationLimiter *ActuationLimiter
 }
 
 // NewScaleExecutor creates a ScaleExecutor object
-func NewScaleExecutor(client runtimeclient.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, recorder record.EventRecorder) ScaleExecutor {
+func NewScaleExecutor(client runtimeclient.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, recorder record.EventRecorder, statusUpdateMinInterval time.Duration, actuationLimiter *ActuationLimiter) ScaleExecutor {
 	return &scaleExecutor{
-		client:           client,
-		scaleClient:      scaleClient,
-		reconcilerScheme: reconcilerScheme,
-		logger:           logf.Log.WithName("scaleexecutor"),
-		recorder:         recorder,
+		client:                  client,
+		scaleClient:             scaleClient,
+		reconcilerScheme:        reconcilerScheme,
+		logger:                  logf.Log.WithName("scaleexecutor"),
+		recorder:                recorder,
+		statusUpdateMinInterval: statusUpdateMinInterval,
+		actuationLimiter:        actuationLimiter,
 	}
 }
 
 func (e *scaleExecutor) updateLastActiveTime(ctx context.Context, logger logr.Logger, object interface{}) error {
 	now := metav1.Now()
+	if !shouldUpdateLastActiveTime(object, now, e.statusUpdateMinInterval) {
+		return nil
+	}
 	transform := func(runtimeObj runtimeclient.Object, target interface{}) error {
 		now, ok := target.(metav1.Time)
 		if !ok {
@@ -87,6 +102,21 @@ func (e *scaleExecutor) updateLastActiveTime(ctx context.Context, logger logr.Lo
 	return kedastatus.TransformObject(ctx, e.client, logger, object, now, transform)
 }
 
+// shouldUpdateLastActiveTime returns false if the lastActiveTime has been updated less than minInterval ago
+func shouldUpdateLastActiveTime(object interface{}, now metav1.Time, minInterval time.Duration) bool {
+	if minInterval <= 0 {
+		return true
+	}
+	var lastActiveTime *metav1.Time
+	switch obj := object.(type) {
+	case *kedav1alpha1.ScaledObject:
+		lastActiveTime = obj.Status.LastActiveTime
+	case *kedav1alpha1.ScaledJob:
+		lastActiveTime = obj.Status.LastActiveTime
+	}
+	return lastActiveTime == nil || now.Sub(lastActiveTime.Time) >= minInterval
+}
+
 func (e *scaleExecutor) setCondition(ctx context.Context, logger logr.Logger, object interface{}, status metav1.ConditionStatus, reason string, message string, setCondition func(kedav1alpha1.Conditions, metav1.ConditionStatus, string, string)) error {
 	type transformStruct struct {
 		status  metav1.ConditionStatus
@@ -20,11 +20,16 @@ import (
 	"context"
 	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/storage/names"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -36,11 +41,32 @@ import (
 const (
 	defaultSuccessfulJobsHistoryLimit = int32(100)
 	defaultFailedJobsHistoryLimit     = int32(100)
+
+	metricNameEnvName  = "KEDA_SCALER_METRIC_NAME"
+	metricValueEnvName = "KEDA_SCALER_METRIC_VALUE"
+	priorityEnvName    = "KEDA_JOB_PRIORITY"
+
+	priorityAnnotation = "scaledjob.keda.sh/priority"
 )
 
-func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive, isError bool, scaleTo int64, maxScale int64) {
+// jobCreationBackoff bounds the retries of the Job creations of a scaling rejected by the API server with a
+// transient error, its steps are shared by all the Jobs created
+var jobCreationBackoff = wait.Backoff{
+	Steps:    4,
+	Duration: 100 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// jobCreationMaxRetryAfter caps the delay the API server asks for with Retry-After before a creation is retried
+var jobCreationMaxRetryAfter = 10 * time.Second
+
+func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive, isError bool, scaleTo int64, maxScale int64, options *ScaleExecutorOptions) {
 	logger := e.logger.WithValues("scaledJob.Name", scaledJob.Name, "scaledJob.Namespace", scaledJob.Namespace)
 
+	// scaleTo is the metric value before any scaling decision is applied to it
+	metricEnv := getMetricEnv(scaledJob, scaleTo, options)
+
 	runningJobCount := e.getRunningJobCount(ctx, scaledJob)
 	pendingJobCount := e.getPendingJobCount(ctx, scaledJob)
 	logger.Info("Scaling Jobs", "Number of running Jobs", runningJobCount)
@@ -60,7 +86,7 @@ func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1al
 		if err != nil {
 			logger.Error(err, "Failed to update last active time")
 		}
-		e.createJobs(ctx, logger, scaledJob, scaleTo, effectiveMaxScale)
+		e.createJobs(ctx, logger, scaledJob, scaleTo, effectiveMaxScale, metricEnv, options)
 	} else {
 		logger.V(1).Info("No change in activity")
 	}
@@ -122,7 +148,7 @@ func (e *scaleExecutor) getScalingDecision(scaledJob *kedav1alpha1.ScaledJob, ru
 	return effectiveMaxScale, scaleTo
 }
 
-func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, scaleTo int64, maxScale int64) {
+func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, scaleTo int64, maxScale int64, metricEnv []corev1.EnvVar, options *ScaleExecutorOptions) {
 	if maxScale <= 0 {
 		logger.Info("No need to create jobs - all requested jobs already exist", "jobs", maxScale)
 		return
@@ -133,19 +159,87 @@ func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scal
 	}
 	logger.Info("Creating jobs", "Number of jobs", scaleTo)
 
-	jobs := e.generateJobs(logger, scaledJob, scaleTo)
+	priorities := getJobPriorities(scaledJob, scaleTo, options)
+	jobs := e.generateJobs(logger, scaledJob, scaleTo, metricEnv, priorities)
+	createdJobs := 0
+	var lastErr error
+	backoff := jobCreationBackoff
 	for _, job := range jobs {
-		err := e.client.Create(ctx, job)
+		err := e.createJob(ctx, job, &backoff)
 		if err != nil {
 			logger.Error(err, "Failed to create a new Job")
+			lastErr = err
+			if isTransientJobCreationError(err) {
+				// the retries are exhausted, the remaining Jobs are left to the next polls
+				break
+			}
+			continue
+		}
+		createdJobs++
+	}
+
+	logger.Info("Created jobs", "Number of jobs", createdJobs)
+	if createdJobs > 0 {
+		e.recorder.Eventf(scaledJob, corev1.EventTypeNormal, eventreason.KEDAJobsCreated, "Created %d jobs", createdJobs)
+	}
+	if lastErr != nil {
+		e.recorder.Eventf(scaledJob, corev1.EventTypeWarning, eventreason.KEDAJobCreateFailed, "Failed to create %d of %d jobs: %v", len(jobs)-createdJobs, len(jobs), lastErr)
+	}
+}
+
+// createJob creates the Job, retrying while the API server rejects it with a transient error as long as the backoff
+// has steps left. The name is generated upfront so a retry after a creation that actually succeeded can't create a second Job
+func (e *scaleExecutor) createJob(ctx context.Context, job *batchv1.Job, backoff *wait.Backoff) error {
+	if job.Name == "" {
+		job.Name = names.SimpleNameGenerator.GenerateName(job.GenerateName)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := e.client.Create(ctx, job)
+		if attempt > 1 && apierrors.IsAlreadyExists(err) {
+			// a previous attempt has created the Job although its response was an error
+			return nil
+		}
+		if err == nil || !isTransientJobCreationError(err) || backoff.Steps <= 1 {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(getJobCreationRetryDelay(backoff, err)):
+		}
+	}
+}
+
+// getJobCreationRetryDelay returns the delay before retrying a creation, the next step of the backoff
+// or the Retry-After of the API server when it's longer
+func getJobCreationRetryDelay(backoff *wait.Backoff, err error) time.Duration {
+	delay := backoff.Step()
+	if seconds, ok := apierrors.SuggestsClientDelay(err); ok {
+		retryAfter := time.Duration(seconds) * time.Second
+		if retryAfter > jobCreationMaxRetryAfter {
+			retryAfter = jobCreationMaxRetryAfter
+		}
+		if retryAfter > delay {
+			delay = retryAfter
 		}
 	}
+	return delay
+}
 
-	logger.Info("Created jobs", "Number of jobs", scaleTo)
-	e.recorder.Eventf(scaledJob, corev1.EventTypeNormal, eventreason.KEDAJobsCreated, "Created %d jobs", scaleTo)
+// isTransientJobCreationError returns whether the Job creation may succeed when retried
+func isTransientJobCreationError(err error) bool {
+	return apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsInternalError(err)
 }
 
-func (e *scaleExecutor) generateJobs(logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, scaleTo int64) []*batchv1.Job {
+// generateJobs returns the Jobs to create, in creation order. The first Jobs get the priorities,
+// the remaining ones are created without a priority
+func (e *scaleExecutor) generateJobs(logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, scaleTo int64, metricEnv []corev1.EnvVar, priorities []int64) []*batchv1.Job {
 	scaledJob.Spec.JobTargetRef.Template.GenerateName = scaledJob.GetName() + "-"
 	if scaledJob.Spec.JobTargetRef.Template.Labels == nil {
 		scaledJob.Spec.JobTargetRef.Template.Labels = map[string]string{}
@@ -172,12 +266,24 @@ func (e *scaleExecutor) generateJobs(logger logr.Logger, scaledJob *kedav1alpha1
 
 	jobs := make([]*batchv1.Job, int(scaleTo))
 	for i := 0; i < int(scaleTo); i++ {
+		jobAnnotations := annotations
+		jobEnv := metricEnv
+		if i < len(priorities) {
+			priority := strconv.FormatInt(priorities[i], 10)
+			jobAnnotations = make(map[string]string, len(annotations)+1)
+			for key, value := range annotations {
+				jobAnnotations[key] = value
+			}
+			jobAnnotations[priorityAnnotation] = priority
+			jobEnv = mergeEnv(metricEnv, []corev1.EnvVar{{Name: priorityEnvName, Value: priority}})
+		}
+
 		job := &batchv1.Job{
 			ObjectMeta: metav1.ObjectMeta{
 				GenerateName: scaledJob.GetName() + "-",
 				Namespace:    scaledJob.GetNamespace(),
 				Labels:       labels,
-				Annotations:  annotations,
+				Annotations:  jobAnnotations,
 			},
 			Spec: *scaledJob.Spec.JobTargetRef.DeepCopy(),
 		}
@@ -189,6 +295,10 @@ func (e *scaleExecutor) generateJobs(logger logr.Logger, scaledJob *kedav1alpha1
 			job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
 		}
 
+		for c := range job.Spec.Template.Spec.Containers {
+			job.Spec.Template.Spec.Containers[c].Env = mergeEnv(job.Spec.Template.Spec.Containers[c].Env, jobEnv)
+		}
+
 		// Set ScaledJob instance as the owner and controller
 		err := controllerutil.SetControllerReference(scaledJob, job, e.reconcilerScheme)
 		if err != nil {
@@ -200,6 +310,60 @@ func (e *scaleExecutor) generateJobs(logger logr.Logger, scaledJob *kedav1alpha1
 	return jobs
 }
 
+// getMetricEnv returns the environment variables describing the metric the Jobs are created for,
+// if the ScaledJob opted in to them
+func getMetricEnv(scaledJob *kedav1alpha1.ScaledJob, metricValue int64, options *ScaleExecutorOptions) []corev1.EnvVar {
+	if !scaledJob.Spec.InjectMetricEnv {
+		return nil
+	}
+
+	var metricNames []string
+	if options != nil {
+		metricNames = options.ActiveMetricNames
+	}
+	return []corev1.EnvVar{
+		{Name: metricNameEnvName, Value: strings.Join(metricNames, ",")},
+		{Name: metricValueEnvName, Value: strconv.FormatInt(metricValue, 10)},
+	}
+}
+
+// getJobPriorities returns the priorities of the Jobs to create, the highest first, if the ScaledJob creates
+// its Jobs in priority order. When maxReplicaCount caps the number of Jobs, only the highest priorities are kept
+func getJobPriorities(scaledJob *kedav1alpha1.ScaledJob, scaleTo int64, options *ScaleExecutorOptions) []int64 {
+	if scaledJob.Spec.ScalingStrategy.JobCreationOrder != kedav1alpha1.JobCreationOrderPriority || options == nil || len(options.Priorities) == 0 {
+		return nil
+	}
+
+	priorities := make([]int64, len(options.Priorities))
+	copy(priorities, options.Priorities)
+	sort.SliceStable(priorities, func(i, j int) bool {
+		return priorities[i] > priorities[j]
+	})
+	if int64(len(priorities)) > scaleTo {
+		priorities = priorities[:scaleTo]
+	}
+	return priorities
+}
+
+// mergeEnv returns env with the variables of override added, replacing the ones with the same name
+func mergeEnv(env []corev1.EnvVar, override []corev1.EnvVar) []corev1.EnvVar {
+	if len(override) == 0 {
+		return env
+	}
+
+	overridden := make(map[string]bool, len(override))
+	for _, e := range override {
+		overridden[e.Name] = true
+	}
+	merged := make([]corev1.EnvVar, 0, len(env)+len(override))
+	for _, e := range env {
+		if !overridden[e.Name] {
+			merged = append(merged, e)
+		}
+	}
+	return append(merged, override...)
+}
+
 func (e *scaleExecutor) isJobFinished(j *batchv1.Job) bool {
 	for _, c := range j.Status.Conditions {
 		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
@@ -26,9 +26,12 @@ import (
 	"go.uber.org/mock/gomock"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -338,7 +341,144 @@ func TestCreateJobs(t *testing.T) {
 		Return(nil)
 
 	scaledJob := getMockScaledJobWithDefaultStrategyAndMeta("test")
-	scaleExecutor.createJobs(ctx, logger, scaledJob, 2, 2)
+	scaleExecutor.createJobs(ctx, logger, scaledJob, 2, 2, nil, nil)
+}
+
+func TestCreateJobsRetriesTransientErrors(t *testing.T) {
+	ctx := context.Background()
+	logger := logf.Log.WithName("CreateJobsTest")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock_client.NewMockClient(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	recorder := record.NewFakeRecorder(2)
+	scaleExecutor.recorder = recorder
+	setFastJobCreationBackoff(t)
+
+	var attempts []string
+	client.EXPECT().
+		Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, obj runtime.Object, _ ...runtimeclient.CreateOption) error {
+		j := obj.(*batchv1.Job)
+		attempts = append(attempts, j.Name)
+		if len(attempts) <= 2 {
+			return apierrors.NewTooManyRequests("too many requests", 1)
+		}
+		return nil
+	}).Times(4)
+
+	scaledJob := getMockScaledJobWithDefaultStrategyAndMeta("test")
+	scaleExecutor.createJobs(ctx, logger, scaledJob, 2, 2, nil, nil)
+
+	// the first Job is created on its third attempt, always with the same name
+	assert.Equal(t, attempts[0], attempts[1])
+	assert.Equal(t, attempts[0], attempts[2])
+	assert.NotEqual(t, attempts[0], attempts[3])
+	assert.Equal(t, "Normal KEDAJobsCreated Created 2 jobs", <-recorder.Events)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestCreateJobsDoesNotDuplicateJobCreatedByFailedAttempt(t *testing.T) {
+	ctx := context.Background()
+	logger := logf.Log.WithName("CreateJobsTest")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock_client.NewMockClient(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	setFastJobCreationBackoff(t)
+
+	created := map[string]bool{}
+	client.EXPECT().
+		Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, obj runtime.Object, _ ...runtimeclient.CreateOption) error {
+		j := obj.(*batchv1.Job)
+		if created[j.Name] {
+			return apierrors.NewAlreadyExists(schema.GroupResource{Group: "batch", Resource: "jobs"}, j.Name)
+		}
+		// the Job is persisted, but the response times out
+		created[j.Name] = true
+		return apierrors.NewServerTimeout(schema.GroupResource{Group: "batch", Resource: "jobs"}, "create", 1)
+	}).Times(2)
+
+	scaledJob := getMockScaledJobWithDefaultStrategyAndMeta("test")
+	scaleExecutor.createJobs(ctx, logger, scaledJob, 1, 1, nil, nil)
+
+	assert.Equal(t, 1, len(created))
+}
+
+func TestCreateJobsReportsPersistentErrors(t *testing.T) {
+	ctx := context.Background()
+	logger := logf.Log.WithName("CreateJobsTest")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock_client.NewMockClient(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	recorder := record.NewFakeRecorder(2)
+	scaleExecutor.recorder = recorder
+	setFastJobCreationBackoff(t)
+
+	unavailable := apierrors.NewServiceUnavailable("unavailable")
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Group: "batch", Resource: "jobs"}, "", fmt.Errorf("exceeded quota"))
+	gomock.InOrder(
+		// other errors aren't retried
+		client.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(forbidden).Times(1),
+		client.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1),
+		// a transient error is retried until the backoff is exhausted
+		client.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(unavailable).Times(jobCreationBackoff.Steps),
+	)
+
+	scaledJob := getMockScaledJobWithDefaultStrategyAndMeta("test")
+	scaleExecutor.createJobs(ctx, logger, scaledJob, 3, 3, nil, nil)
+
+	assert.Equal(t, "Normal KEDAJobsCreated Created 1 jobs", <-recorder.Events)
+	assert.Equal(t, fmt.Sprintf("Warning KEDAJobCreateFailed Failed to create 2 of 3 jobs: %v", unavailable), <-recorder.Events)
+}
+
+func TestCreateJobsSharesTheBackoffAndStopsOnceExhausted(t *testing.T) {
+	ctx := context.Background()
+	logger := logf.Log.WithName("CreateJobsTest")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock_client.NewMockClient(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	recorder := record.NewFakeRecorder(2)
+	scaleExecutor.recorder = recorder
+	setFastJobCreationBackoff(t)
+
+	unavailable := apierrors.NewServiceUnavailable("unavailable")
+	gomock.InOrder(
+		// the first Job uses two retries of the backoff
+		client.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(unavailable).Times(2),
+		client.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1),
+		// the second Job only has the last retry left, and the third Job isn't created
+		client.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(unavailable).Times(2),
+	)
+
+	scaledJob := getMockScaledJobWithDefaultStrategyAndMeta("test")
+	scaleExecutor.createJobs(ctx, logger, scaledJob, 3, 3, nil, nil)
+
+	assert.Equal(t, "Normal KEDAJobsCreated Created 1 jobs", <-recorder.Events)
+	assert.Equal(t, fmt.Sprintf("Warning KEDAJobCreateFailed Failed to create 2 of 3 jobs: %v", unavailable), <-recorder.Events)
+}
+
+func TestGetJobCreationRetryDelay(t *testing.T) {
+	backoff := wait.Backoff{Steps: 4, Duration: 100 * time.Millisecond, Factor: 2.0}
+
+	// Retry-After is honored when longer than the backoff
+	assert.Equal(t, 2*time.Second, getJobCreationRetryDelay(&backoff, apierrors.NewTooManyRequests("too many requests", 2)))
+	assert.Equal(t, 3, backoff.Steps)
+	assert.Equal(t, 200*time.Millisecond, getJobCreationRetryDelay(&backoff, apierrors.NewServiceUnavailable("unavailable")))
+	// and capped
+	assert.Equal(t, jobCreationMaxRetryAfter, getJobCreationRetryDelay(&backoff, apierrors.NewTooManyRequests("too many requests", 3600)))
+}
+
+func setFastJobCreationBackoff(t *testing.T) {
+	backoff := jobCreationBackoff
+	maxRetryAfter := jobCreationMaxRetryAfter
+	jobCreationBackoff = wait.Backoff{Steps: backoff.Steps, Duration: time.Millisecond}
+	jobCreationMaxRetryAfter = time.Millisecond
+	t.Cleanup(func() {
+		jobCreationBackoff = backoff
+		jobCreationMaxRetryAfter = maxRetryAfter
+	})
 }
 
 func TestGenerateJobs(t *testing.T) {
@@ -364,7 +504,7 @@ func TestGenerateJobs(t *testing.T) {
 	scaleExecutor := getMockScaleExecutor(client)
 	scaledJob := getMockScaledJobWithDefaultStrategyAndMeta("test")
 
-	jobs := scaleExecutor.generateJobs(logger, scaledJob, 2)
+	jobs := scaleExecutor.generateJobs(logger, scaledJob, 2, nil, nil)
 
 	assert.Equal(t, 2, len(jobs))
 	for _, j := range jobs {
@@ -374,6 +514,110 @@ func TestGenerateJobs(t *testing.T) {
 	}
 }
 
+func TestGenerateJobsWithMetricEnv(t *testing.T) {
+	logger := logf.Log.WithName("GenerateJobsTest")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock_client.NewMockClient(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	scaledJob := getMockScaledJobWithDefaultStrategyAndMeta("test")
+	scaledJob.Spec.InjectMetricEnv = true
+	scaledJob.Spec.JobTargetRef.Template.Spec.Containers = []v1.Container{
+		{Name: "worker", Env: []v1.EnvVar{{Name: "QUEUE", Value: "orders"}, {Name: "KEDA_SCALER_METRIC_VALUE", Value: "0"}}},
+		{Name: "sidecar"},
+	}
+
+	metricEnv := getMetricEnv(scaledJob, 42, &ScaleExecutorOptions{ActiveMetricNames: []string{"s0-rabbitmq-orders", "s1-rabbitmq-payments"}})
+	jobs := scaleExecutor.generateJobs(logger, scaledJob, 2, metricEnv, nil)
+
+	assert.Equal(t, 2, len(jobs))
+	for _, j := range jobs {
+		assert.Equal(t, []v1.EnvVar{
+			{Name: "QUEUE", Value: "orders"},
+			{Name: "KEDA_SCALER_METRIC_NAME", Value: "s0-rabbitmq-orders,s1-rabbitmq-payments"},
+			{Name: "KEDA_SCALER_METRIC_VALUE", Value: "42"},
+		}, j.Spec.Template.Spec.Containers[0].Env)
+		assert.Equal(t, []v1.EnvVar{
+			{Name: "KEDA_SCALER_METRIC_NAME", Value: "s0-rabbitmq-orders,s1-rabbitmq-payments"},
+			{Name: "KEDA_SCALER_METRIC_VALUE", Value: "42"},
+		}, j.Spec.Template.Spec.Containers[1].Env)
+	}
+	// the template of the ScaledJob is left untouched
+	assert.Equal(t, []v1.EnvVar{{Name: "QUEUE", Value: "orders"}, {Name: "KEDA_SCALER_METRIC_VALUE", Value: "0"}}, scaledJob.Spec.JobTargetRef.Template.Spec.Containers[0].Env)
+	assert.Nil(t, scaledJob.Spec.JobTargetRef.Template.Spec.Containers[1].Env)
+}
+
+func TestGetMetricEnvNotInjectedByDefault(t *testing.T) {
+	scaledJob := getMockScaledJobWithDefaultStrategyAndMeta("test")
+	assert.Nil(t, getMetricEnv(scaledJob, 42, &ScaleExecutorOptions{ActiveMetricNames: []string{"s0-rabbitmq-orders"}}))
+}
+
+func TestCreateJobsInPriorityOrderAtMaxReplicaCount(t *testing.T) {
+	ctx := context.Background()
+	logger := logf.Log.WithName("CreateJobsTest")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock_client.NewMockClient(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+
+	var created []string
+	client.EXPECT().
+		Create(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ context.Context, obj runtime.Object, _ ...runtimeclient.CreateOption) {
+		j := obj.(*batchv1.Job)
+		created = append(created, j.ObjectMeta.Annotations["scaledjob.keda.sh/priority"])
+		assert.Contains(t, j.Spec.Template.Spec.Containers[0].Env, v1.EnvVar{Name: "KEDA_JOB_PRIORITY", Value: j.ObjectMeta.Annotations["scaledjob.keda.sh/priority"]})
+	}).Times(2).
+		Return(nil)
+
+	scaledJob := getMockScaledJobWithDefaultStrategyAndMeta("test")
+	scaledJob.Spec.ScalingStrategy.JobCreationOrder = kedav1alpha1.JobCreationOrderPriority
+	scaledJob.Spec.JobTargetRef.Template.Spec.Containers = []v1.Container{{Name: "worker"}}
+
+	// 4 units of work are waiting, but only 2 Jobs can be created
+	scaleExecutor.createJobs(ctx, logger, scaledJob, 4, 2, nil, &ScaleExecutorOptions{Priorities: []int64{1, 5, 3, 9}})
+
+	assert.Equal(t, []string{"9", "5"}, created)
+}
+
+func TestGenerateJobsWithPriorities(t *testing.T) {
+	logger := logf.Log.WithName("GenerateJobsTest")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock_client.NewMockClient(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	scaledJob := getMockScaledJobWithDefaultStrategyAndMeta("test")
+	scaledJob.Spec.JobTargetRef.Template.Spec.Containers = []v1.Container{{Name: "worker"}}
+
+	// the scalers reported the priorities of 2 units of work, the third Job is created without a priority
+	jobs := scaleExecutor.generateJobs(logger, scaledJob, 3, nil, []int64{7, 2})
+
+	assert.Equal(t, 3, len(jobs))
+	assert.Equal(t, "7", jobs[0].ObjectMeta.Annotations["scaledjob.keda.sh/priority"])
+	assert.Equal(t, []v1.EnvVar{{Name: "KEDA_JOB_PRIORITY", Value: "7"}}, jobs[0].Spec.Template.Spec.Containers[0].Env)
+	assert.Equal(t, "2", jobs[1].ObjectMeta.Annotations["scaledjob.keda.sh/priority"])
+	assert.Equal(t, []v1.EnvVar{{Name: "KEDA_JOB_PRIORITY", Value: "2"}}, jobs[1].Spec.Template.Spec.Containers[0].Env)
+	assert.NotContains(t, jobs[2].ObjectMeta.Annotations, "scaledjob.keda.sh/priority")
+	assert.Nil(t, jobs[2].Spec.Template.Spec.Containers[0].Env)
+	// the annotations of the ScaledJob are shared by the Jobs, but not the priority
+	assert.Equal(t, "test", jobs[0].ObjectMeta.Annotations["test"])
+	assert.NotContains(t, scaledJob.ObjectMeta.Annotations, "scaledjob.keda.sh/priority")
+}
+
+func TestGetJobPriorities(t *testing.T) {
+	options := &ScaleExecutorOptions{Priorities: []int64{1, 5, 3, 9, 5}}
+
+	scaledJob := getMockScaledJobWithDefaultStrategyAndMeta("test")
+	assert.Nil(t, getJobPriorities(scaledJob, 2, options), "jobs are created in fifo order by default")
+
+	scaledJob.Spec.ScalingStrategy.JobCreationOrder = kedav1alpha1.JobCreationOrderPriority
+	assert.Equal(t, []int64{9, 5}, getJobPriorities(scaledJob, 2, options))
+	assert.Equal(t, []int64{9, 5, 5, 3, 1}, getJobPriorities(scaledJob, 10, options))
+	assert.Nil(t, getJobPriorities(scaledJob, 2, &ScaleExecutorOptions{}))
+	assert.Nil(t, getJobPriorities(scaledJob, 2, nil))
+	// the priorities reported by the scalers are left untouched
+	assert.Equal(t, []int64{1, 5, 3, 9, 5}, options.Priorities)
+}
+
 type mockJobParameter struct {
 	Name             string
 	CompletionTime   string
@@ -18,31 +18,42 @@ package executor
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/robfig/cron/v3"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, options *ScaleExecutorOptions) {
 	logger := e.logger.WithValues("scaledobject.Name", scaledObject.Name,
 		"scaledObject.Namespace", scaledObject.Namespace,
-		"scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
+		"scaleTarget.Name", scaledObject.ScaleTargetName())
 	// Get the current replica count. As a special case, Deployments and StatefulSets fetch directly from the object so they can use the informer cache
 	// to reduce API calls. Everything else uses the scale subresource.
 	var currentScale *autoscalingv1.Scale
 	var currentReplicas int32
-	targetName := scaledObject.Spec.ScaleTargetRef.Name
+	targetName := scaledObject.ScaleTargetName()
 	targetGVKR := scaledObject.Status.ScaleTargetGVKR
 	switch {
 	case targetGVKR.Group == "apps" && targetGVKR.Kind == "Deployment":
@@ -61,6 +72,14 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			return
 		}
 		currentReplicas = *statefulSet.Spec.Replicas
+	case targetGVKR.IsArgoRollout():
+		var err error
+		currentScale, err = e.getArgoRolloutScale(ctx, scaledObject)
+		if err != nil {
+			logger.Error(err, "Error getting information on the current Scale (ie. replicas count) on the scaleTarget")
+			return
+		}
+		currentReplicas = currentScale.Spec.Replicas
 	default:
 		var err error
 		currentScale, err = e.getScaleTargetScale(ctx, scaledObject)
@@ -118,8 +137,13 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 
 	// if scaledObject.Spec.MinReplicaCount is not set, then set the default value (0)
 	minReplicas := int32(0)
-	if scaledObject.Spec.MinReplicaCount != nil {
-		minReplicas = *scaledObject.Spec.MinReplicaCount
+	minReplicaCount, err := GetMinReplicaCount(scaledObject, time.Now())
+	if err != nil {
+		logger.Error(err, "error getting the scheduled minReplicaCount, using minReplicaCount")
+		minReplicaCount = scaledObject.Spec.MinReplicaCount
+	}
+	if minReplicaCount != nil {
+		minReplicas = *minReplicaCount
 	}
 
 	if isActive {
@@ -135,7 +159,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// replica count is equal to 0
 
 			// Scale the ScaleTarget up
-			e.scaleFromZeroOrIdle(ctx, logger, scaledObject, currentScale, options.ActiveTriggers)
+			e.scaleFromZeroOrIdle(ctx, logger, scaledObject, currentScale, minReplicas, options.ActiveTriggers)
 		case isError:
 			// some triggers are active, but some responded with error
 
@@ -191,7 +215,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// there is no minimum configured or minimum is set to ZERO
 
 			// Try to scale the deployment down, HPA will handle other scale in operations
-			e.scaleToZeroOrIdle(ctx, logger, scaledObject, currentScale)
+			e.scaleToZeroOrIdle(ctx, logger, scaledObject, currentScale, minReplicas)
 		case currentReplicas < minReplicas && scaledObject.Spec.IdleReplicaCount == nil:
 			// there are no active triggers
 			// AND
@@ -200,11 +224,11 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// Idle Replicas mode is disabled
 
 			// ScaleTarget replicas count to correct value
-			_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, *scaledObject.Spec.MinReplicaCount)
+			_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, minReplicas)
 			if err == nil {
 				logger.Info("Successfully set ScaleTarget replicas count to ScaledObject minReplicaCount",
 					"Original Replicas Count", currentReplicas,
-					"New Replicas Count", *scaledObject.Spec.MinReplicaCount)
+					"New Replicas Count", minReplicas)
 			}
 		default:
 			// there are no active triggers
@@ -231,20 +255,39 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 }
 
 func (e *scaleExecutor) doFallbackScaling(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, currentScale *autoscalingv1.Scale, logger logr.Logger, currentReplicas int32) {
-	_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, scaledObject.Spec.Fallback.Replicas)
+	fallbackReplicas := e.getFallbackReplicas(ctx, logger, scaledObject)
+	_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, fallbackReplicas)
 	if err == nil {
 		logger.Info("Successfully set ScaleTarget replicas count to ScaledObject fallback.replicas",
 			"Original Replicas Count", currentReplicas,
-			"New Replicas Count", scaledObject.Spec.Fallback.Replicas)
+			"New Replicas Count", fallbackReplicas)
 	}
 	if e := e.setFallbackCondition(ctx, logger, scaledObject, metav1.ConditionTrue, "FallbackExists", "At least one trigger is falling back on this scaled object"); e != nil {
 		logger.Error(e, "Error setting fallback condition")
 	}
 }
 
+// getFallbackReplicas returns the fallback.replicas of the ScaledObject, capped by its maxReplicaCountCapacity
+// like the maxReplicas of its HPA
+func (e *scaleExecutor) getFallbackReplicas(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) int32 {
+	replicas := scaledObject.Spec.Fallback.Replicas
+	if scaledObject.Spec.MaxReplicaCountCapacity == nil {
+		return replicas
+	}
+	maxReplicaCount, err := GetMaxReplicaCount(ctx, e.client, scaledObject, scaledObject.Status.ScaleTargetGVKR)
+	if err != nil {
+		logger.Error(err, "error resolving maxReplicaCountCapacity, using fallback.replicas")
+		return replicas
+	}
+	if maxReplicaCount < replicas {
+		return maxReplicaCount
+	}
+	return replicas
+}
+
 // An object will be scaled down to 0 only if it's passed its cooldown period
 // or if LastActiveTime is nil
-func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale) {
+func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, minReplicas int32) {
 	var initialCooldownPeriod, cooldownPeriod time.Duration
 
 	if scaledObject.Spec.InitialCooldownPeriod != nil {
@@ -264,13 +307,46 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 		scaledObject.ObjectMeta.CreationTimestamp = metav1.NewTime(time.Now())
 	}
 
+	// The ScaleTarget isn't scaled in during the initialCooldownPeriod after the creation of the ScaledObject,
+	// even if its triggers have been active and then cooled down in the meantime
+	initialCooldownElapsed := !scaledObject.ObjectMeta.CreationTimestamp.Add(initialCooldownPeriod).After(time.Now())
+
 	// LastActiveTime can be nil if the ScaleTarget was scaled outside of KEDA.
 	// In this case we will ignore the cooldown period and scale it down
-	if (scaledObject.Status.LastActiveTime == nil && scaledObject.ObjectMeta.CreationTimestamp.Add(initialCooldownPeriod).Before(time.Now())) || (scaledObject.Status.LastActiveTime != nil &&
+	if initialCooldownElapsed && (scaledObject.Status.LastActiveTime == nil ||
 		scaledObject.Status.LastActiveTime.Add(cooldownPeriod).Before(time.Now())) {
 		// or last time a trigger was active was > cooldown period, so scale in.
-		idleValue, scaleToReplicas := getIdleOrMinimumReplicaCount(scaledObject)
+		idleValue, scaleToReplicas := getIdleOrMinimumReplicaCount(scaledObject, minReplicas)
+
+		if scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.RespectPodDisruptionBudget {
+			if scale == nil {
+				var err error
+				scale, err = e.getScaleTargetScale(ctx, scaledObject)
+				if err != nil {
+					logger.Error(err, "error getting scale target scale")
+					return
+				}
+			}
+			pdbName, err := e.getViolatedPodDisruptionBudget(ctx, scaledObject.Namespace, scale, scaleToReplicas)
+			if err != nil {
+				// the deactivation is refused when the budgets can't be checked, as it has been opted in
+				logger.Error(err, "error checking PodDisruptionBudgets of the scale target")
+				e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetDeactivationBlocked,
+					"Not deactivating %s %s/%s, its PodDisruptionBudgets couldn't be checked: %s", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.ScaleTargetName(), err)
+				return
+			}
+			if pdbName != "" {
+				logger.Info("Not deactivating ScaleTarget, it would violate a PodDisruptionBudget", "PodDisruptionBudget", pdbName, "Original Replicas Count", scale.Spec.Replicas, "New Replicas Count", scaleToReplicas)
+				e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetDeactivationBlocked,
+					"Not deactivating %s %s/%s from %d to %d, it would violate PodDisruptionBudget %s", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.ScaleTargetName(), scale.Spec.Replicas, scaleToReplicas, pdbName)
+				return
+			}
+		}
 
+		if !e.actuationLimiter.allow(scaledObject.GenerateIdentifier()) {
+			logger.Info("Deferring the deactivation of the ScaleTarget, the scale actuations are limited")
+			return
+		}
 		currentReplicas, err := e.updateScaleOnScaleTarget(ctx, scaledObject, scale, scaleToReplicas)
 		if err == nil {
 			msg := "Successfully set ScaleTarget replicas count to ScaledObject"
@@ -282,19 +358,20 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 			logger.Info(msg, "Original Replicas Count", currentReplicas, "New Replicas Count", scaleToReplicas)
 
 			e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetDeactivated,
-				"Deactivated %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, scaleToReplicas)
+				"Deactivated %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.ScaleTargetName(), currentReplicas, scaleToReplicas)
 			if err := e.setActiveCondition(ctx, logger, scaledObject, metav1.ConditionFalse, "ScalerNotActive", "Scaling is not performed because triggers are not active"); err != nil {
 				logger.Error(err, "Error in setting active condition")
 				return
 			}
 		} else {
 			e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetDeactivationFailed,
-				"Failed to deactivate %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, scaleToReplicas)
+				"Failed to deactivate %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.ScaleTargetName(), currentReplicas, scaleToReplicas)
 		}
 	} else {
 		logger.V(1).Info("ScaleTarget cooling down",
 			"LastActiveTime", scaledObject.Status.LastActiveTime,
-			"CoolDownPeriod", cooldownPeriod)
+			"CoolDownPeriod", cooldownPeriod,
+			"InitialCoolDownPeriod", initialCooldownPeriod)
 
 		activeCondition := scaledObject.Status.Conditions.GetActiveCondition()
 		if !activeCondition.IsFalse() || activeCondition.Reason != "ScalerCooldown" {
@@ -306,21 +383,25 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 	}
 }
 
-func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, activeTriggers []string) {
+func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, minReplicas int32, activeTriggers []string) {
 	var replicas int32
-	if scaledObject.Spec.MinReplicaCount != nil && *scaledObject.Spec.MinReplicaCount > 0 {
-		replicas = *scaledObject.Spec.MinReplicaCount
+	if minReplicas > 0 {
+		replicas = minReplicas
 	} else {
 		replicas = 1
 	}
 
+	if !e.actuationLimiter.allow(scaledObject.GenerateIdentifier()) {
+		logger.Info("Deferring the activation of the ScaleTarget, the scale actuations are limited")
+		return
+	}
 	currentReplicas, err := e.updateScaleOnScaleTarget(ctx, scaledObject, scale, replicas)
 
 	if err == nil {
 		logger.Info("Successfully updated ScaleTarget",
 			"Original Replicas Count", currentReplicas,
 			"New Replicas Count", replicas)
-		e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Scaled %s %s/%s from %d to %d, triggered by %s", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas, strings.Join(activeTriggers, ";"))
+		e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Scaled %s %s/%s from %d to %d, triggered by %s", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.ScaleTargetName(), currentReplicas, replicas, strings.Join(activeTriggers, ";"))
 
 		// Scale was successful. Update lastScaleTime and lastActiveTime on the scaledObject
 		if err := e.updateLastActiveTime(ctx, logger, scaledObject); err != nil {
@@ -328,12 +409,31 @@ func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Log
 			return
 		}
 	} else {
-		e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetActivationFailed, "Failed to scaled %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas)
+		e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetActivationFailed, "Failed to scaled %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.ScaleTargetName(), currentReplicas, replicas)
 	}
 }
 
 func (e *scaleExecutor) getScaleTargetScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (*autoscalingv1.Scale, error) {
-	return e.scaleClient.Scales(scaledObject.Namespace).Get(ctx, scaledObject.Status.ScaleTargetGVKR.GroupResource(), scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
+	return e.scaleClient.Scales(scaledObject.Namespace).Get(ctx, scaledObject.Status.ScaleTargetGVKR.GroupResource(), scaledObject.ScaleTargetName(), metav1.GetOptions{})
+}
+
+// getArgoRolloutScale returns the scale of an Argo Rollout. The replicas of a Rollout default to 1 when its
+// spec.replicas isn't set, while its scale subresource reports 0 replicas, which would prevent scaling it to zero
+func (e *scaleExecutor) getArgoRolloutScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (*autoscalingv1.Scale, error) {
+	scale, err := e.getScaleTargetScale(ctx, scaledObject)
+	if err != nil {
+		return nil, err
+	}
+
+	rollout := &unstructured.Unstructured{}
+	rollout.SetGroupVersionKind(scaledObject.Status.ScaleTargetGVKR.GroupVersionKind())
+	if err := e.client.Get(ctx, client.ObjectKey{Name: scaledObject.ScaleTargetName(), Namespace: scaledObject.Namespace}, rollout); err != nil {
+		return nil, err
+	}
+	if _, found, err := unstructured.NestedInt64(rollout.Object, "spec", "replicas"); err == nil && !found {
+		scale.Spec.Replicas = 1
+	}
+	return scale, nil
 }
 
 func (e *scaleExecutor) updateScaleOnScaleTarget(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, replicas int32) (int32, error) {
@@ -354,18 +454,66 @@ func (e *scaleExecutor) updateScaleOnScaleTarget(ctx context.Context, scaledObje
 	return currentReplicas, err
 }
 
+// getViolatedPodDisruptionBudget returns the name of the first PodDisruptionBudget covering pods of the scale
+// target whose minAvailable would be violated by scaling it to the given replicas, or an empty string if there is none.
+// maxUnavailable bounds the voluntary disruptions, not the replica count, it doesn't block the deactivation
+func (e *scaleExecutor) getViolatedPodDisruptionBudget(ctx context.Context, namespace string, scale *autoscalingv1.Scale, replicas int32) (string, error) {
+	if scale.Status.Selector == "" {
+		return "", fmt.Errorf("the scale target doesn't expose the selector of its pods")
+	}
+	podSelector, err := labels.Parse(scale.Status.Selector)
+	if err != nil {
+		return "", fmt.Errorf("error parsing the selector of the scale target pods: %w", err)
+	}
+
+	pods := &corev1.PodList{}
+	if err := e.client.List(ctx, pods, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: podSelector}); err != nil {
+		return "", err
+	}
+	if len(pods.Items) == 0 {
+		return "", nil
+	}
+
+	pdbs := &policyv1.PodDisruptionBudgetList{}
+	if err := e.client.List(ctx, pdbs, client.InNamespace(namespace)); err != nil {
+		return "", err
+	}
+
+	currentReplicas := int(scale.Spec.Replicas)
+	for _, pdb := range pdbs.Items {
+		// a nil selector selects no pods, an empty one selects all of them
+		if pdb.Spec.Selector == nil {
+			continue
+		}
+		pdbSelector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			return "", fmt.Errorf("error parsing the selector of PodDisruptionBudget %s: %w", pdb.Name, err)
+		}
+		if !slices.ContainsFunc(pods.Items, func(pod corev1.Pod) bool { return pdbSelector.Matches(labels.Set(pod.Labels)) }) {
+			continue
+		}
+
+		if pdb.Spec.MinAvailable != nil {
+			minAvailable, err := intstr.GetScaledValueFromIntOrPercent(pdb.Spec.MinAvailable, currentReplicas, true)
+			if err != nil {
+				return "", fmt.Errorf("error parsing minAvailable of PodDisruptionBudget %s: %w", pdb.Name, err)
+			}
+			if int(replicas) < minAvailable {
+				return pdb.Name, nil
+			}
+		}
+	}
+	return "", nil
+}
+
 // getIdleOrMinimumReplicaCount returns true if the second value returned is from IdleReplicaCount
 // it returns false if it is from MinReplicaCount followed by the actual value
-func getIdleOrMinimumReplicaCount(scaledObject *kedav1alpha1.ScaledObject) (bool, int32) {
+func getIdleOrMinimumReplicaCount(scaledObject *kedav1alpha1.ScaledObject, minReplicas int32) (bool, int32) {
 	if scaledObject.Spec.IdleReplicaCount != nil {
 		return true, *scaledObject.Spec.IdleReplicaCount
 	}
 
-	if scaledObject.Spec.MinReplicaCount == nil {
-		return false, 0
-	}
-
-	return false, *scaledObject.Spec.MinReplicaCount
+	return false, minReplicas
 }
 
 // GetPausedReplicaCount returns the paused replica count of the ScaledObject.
@@ -383,3 +531,245 @@ func GetPausedReplicaCount(scaledObject *kedav1alpha1.ScaledObject) (*int32, err
 	}
 	return nil, nil
 }
+
+// scheduleLookbackWindows are the growing windows searched for the last time a schedule fired,
+// they keep frequent schedules cheap to evaluate while supporting schedules firing once a year
+var scheduleLookbackWindows = []time.Duration{time.Hour, 24 * time.Hour, 7 * 24 * time.Hour, 31 * 24 * time.Hour, 366 * 24 * time.Hour}
+
+// GetMinReplicaCount returns the minReplicaCount of the ScaledObject at the given time.
+// It's the minReplicaCount of the open replicaWindow if it sets one, else the minReplicaCount of the
+// minReplicaCountSchedule which fired last, or spec.minReplicaCount if none fired.
+func GetMinReplicaCount(scaledObject *kedav1alpha1.ScaledObject, now time.Time) (*int32, error) {
+	window, err := GetReplicaWindow(scaledObject, now)
+	if err != nil {
+		return nil, err
+	}
+	if window != nil && window.MinReplicaCount != nil {
+		return window.MinReplicaCount, nil
+	}
+
+	minReplicaCount := scaledObject.Spec.MinReplicaCount
+	var lastFired time.Time
+	for i := range scaledObject.Spec.MinReplicaCountSchedules {
+		minReplicaCountSchedule := &scaledObject.Spec.MinReplicaCountSchedules[i]
+		schedule, location, err := minReplicaCountSchedule.GetCronSchedule()
+		if err != nil {
+			return nil, err
+		}
+
+		// if several schedules fire at the same time, the last one defined wins
+		fired, found := getLastScheduleTime(schedule, now.In(location))
+		if found && !fired.Before(lastFired) {
+			lastFired = fired
+			minReplicaCount = &minReplicaCountSchedule.MinReplicaCount
+		}
+	}
+	return minReplicaCount, nil
+}
+
+// GetHPAMinReplicaCount returns the minReplicas of the HPA at the given time, it's the minReplicaCount returned by
+// GetMinReplicaCount, or the HPA default if it's 0 as the HPA can't have 0 minReplicas. Scaling to zero (or to
+// idleReplicaCount) is done by the executor when the ScaledObject isn't active.
+func GetHPAMinReplicaCount(scaledObject *kedav1alpha1.ScaledObject, now time.Time) (*int32, error) {
+	minReplicaCount, err := GetMinReplicaCount(scaledObject, now)
+	if err != nil {
+		return nil, err
+	}
+	if minReplicaCount == nil || *minReplicaCount == 0 {
+		// a ScaledObject without minReplicaCount gives the HPA default
+		return (&kedav1alpha1.ScaledObject{}).GetHPAMinReplicas(), nil
+	}
+	return minReplicaCount, nil
+}
+
+// GetNextMinReplicaCountScheduleTime returns the next time one of the minReplicaCountSchedules fires,
+// it returns false if the ScaledObject doesn't have any schedule
+func GetNextMinReplicaCountScheduleTime(scaledObject *kedav1alpha1.ScaledObject, now time.Time) (time.Time, bool, error) {
+	var next time.Time
+	for i := range scaledObject.Spec.MinReplicaCountSchedules {
+		schedule, location, err := scaledObject.Spec.MinReplicaCountSchedules[i].GetCronSchedule()
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		if t := schedule.Next(now.In(location)); !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	return next, !next.IsZero(), nil
+}
+
+// GetReplicaWindow returns the replicaWindow of the ScaledObject open at the given time, the first one listed
+// if several are open, or nil if none is
+func GetReplicaWindow(scaledObject *kedav1alpha1.ScaledObject, now time.Time) (*kedav1alpha1.ReplicaWindow, error) {
+	if scaledObject.Spec.Advanced == nil {
+		return nil, nil
+	}
+	for i := range scaledObject.Spec.Advanced.ReplicaWindows {
+		window := &scaledObject.Spec.Advanced.ReplicaWindows[i]
+		schedule, location, err := window.GetCronSchedule()
+		if err != nil {
+			return nil, err
+		}
+		duration, err := window.GetDuration()
+		if err != nil {
+			return nil, err
+		}
+
+		opened, found := getLastScheduleTime(schedule, now.In(location))
+		if found && now.Before(opened.Add(duration)) {
+			return window, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetNextReplicaWindowTime returns the next time one of the replicaWindows opens or closes,
+// it returns false if the ScaledObject doesn't have any window
+func GetNextReplicaWindowTime(scaledObject *kedav1alpha1.ScaledObject, now time.Time) (time.Time, bool, error) {
+	if scaledObject.Spec.Advanced == nil {
+		return time.Time{}, false, nil
+	}
+	var next time.Time
+	updateNext := func(t time.Time) {
+		if t.After(now) && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	for i := range scaledObject.Spec.Advanced.ReplicaWindows {
+		window := &scaledObject.Spec.Advanced.ReplicaWindows[i]
+		schedule, location, err := window.GetCronSchedule()
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		duration, err := window.GetDuration()
+		if err != nil {
+			return time.Time{}, false, err
+		}
+
+		if opened, found := getLastScheduleTime(schedule, now.In(location)); found {
+			updateNext(opened.Add(duration))
+		}
+		if opens := schedule.Next(now.In(location)); !opens.IsZero() {
+			updateNext(opens)
+		}
+	}
+	return next, !next.IsZero(), nil
+}
+
+// getLastScheduleTime returns the last time the schedule fired before or at the given time
+func getLastScheduleTime(schedule cron.Schedule, now time.Time) (time.Time, bool) {
+	for _, window := range scheduleLookbackWindows {
+		fired := schedule.Next(now.Add(-window))
+		// cron returns a zero time for schedules which never fire, e.g. on February 30th
+		if fired.IsZero() {
+			return time.Time{}, false
+		}
+		if fired.After(now) {
+			continue
+		}
+		for next := schedule.Next(fired); !next.After(now); next = schedule.Next(next) {
+			fired = next
+		}
+		return fired, true
+	}
+	return time.Time{}, false
+}
+
+// maxReplicaCountCapacityEnabled is whether the operator is allowed to list the nodes to resolve the maxReplicaCountCapacity
+var maxReplicaCountCapacityEnabled = kedautil.GetMaxReplicaCountCapacityEnabled()
+
+// GetMaxReplicaCount returns the maxReplicaCount of the ScaledObject, its maxReplicaCountCapacity being resolved against
+// the current schedulable capacity of the cluster. It's never above spec.maxReplicaCount when set, or the maxReplicaCount
+// of the open replicaWindow, nor below the minReplicaCount so the HPA stays valid.
+func GetMaxReplicaCount(ctx context.Context, kubeClient client.Client, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource) (int32, error) {
+	maxReplicaCount := scaledObject.GetHPAMaxReplicas()
+	maxReplicaCountSet := scaledObject.Spec.MaxReplicaCount != nil
+	window, err := GetReplicaWindow(scaledObject, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if window != nil && window.MaxReplicaCount != nil {
+		maxReplicaCount = *window.MaxReplicaCount
+		maxReplicaCountSet = true
+	}
+
+	capacity := scaledObject.Spec.MaxReplicaCountCapacity
+	if capacity == nil {
+		return maxReplicaCount, nil
+	}
+	if !maxReplicaCountCapacityEnabled {
+		return 0, fmt.Errorf("maxReplicaCountCapacity requires the operator to run with %s=true and to be allowed to list the nodes", kedautil.MaxReplicaCountCapacityEnvVar)
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := kubeClient.List(ctx, nodeList, client.MatchingLabels(capacity.NodeSelector)); err != nil {
+		return 0, fmt.Errorf("error listing nodes: %w", err)
+	}
+	nodes := getSchedulableNodes(nodeList.Items)
+
+	var capacityReplicas int64
+	switch capacity.Resource {
+	case kedav1alpha1.MaxReplicaCountCapacityCPU:
+		replicaCPU, err := getScaleTargetCPURequest(ctx, kubeClient, scaledObject, gvkr)
+		if err != nil {
+			return 0, err
+		}
+		allocatableCPU := resource.Quantity{}
+		for _, node := range nodes {
+			allocatableCPU.Add(node.Status.Allocatable[corev1.ResourceCPU])
+		}
+		capacityReplicas = allocatableCPU.MilliValue() * int64(capacity.Percentage) / 100 / replicaCPU.MilliValue()
+	default:
+		capacityReplicas = int64(len(nodes)) * int64(capacity.Percentage) / 100
+	}
+
+	if !maxReplicaCountSet || capacityReplicas < int64(maxReplicaCount) {
+		maxReplicaCount = int32(min(capacityReplicas, math.MaxInt32))
+	}
+	return max(maxReplicaCount, *scaledObject.GetHPAMinReplicas()), nil
+}
+
+// getSchedulableNodes returns the ready nodes which aren't cordoned
+func getSchedulableNodes(nodes []corev1.Node) []corev1.Node {
+	schedulable := make([]corev1.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				schedulable = append(schedulable, node)
+				break
+			}
+		}
+	}
+	return schedulable
+}
+
+// getScaleTargetCPURequest returns the CPU requested by the containers of the pod template of the scale target,
+// e.g. of a Deployment or a StatefulSet
+func getScaleTargetCPURequest(ctx context.Context, kubeClient client.Client, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource) (resource.Quantity, error) {
+	target := &unstructured.Unstructured{}
+	target.SetGroupVersionKind(gvkr.GroupVersionKind())
+	if err := kubeClient.Get(ctx, client.ObjectKey{Namespace: scaledObject.Namespace, Name: scaledObject.ScaleTargetName()}, target); err != nil {
+		return resource.Quantity{}, fmt.Errorf("error getting scale target %s: %w", scaledObject.ScaleTargetName(), err)
+	}
+
+	template, found, err := unstructured.NestedMap(target.Object, "spec", "template")
+	if err != nil || !found {
+		return resource.Quantity{}, fmt.Errorf("scale target %s of kind %s doesn't have a pod template", scaledObject.ScaleTargetName(), gvkr.Kind)
+	}
+	podTemplate := &corev1.PodTemplateSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template, podTemplate); err != nil {
+		return resource.Quantity{}, fmt.Errorf("error parsing the pod template of scale target %s: %w", scaledObject.ScaleTargetName(), err)
+	}
+
+	cpu := resource.Quantity{}
+	for _, container := range podTemplate.Spec.Containers {
+		cpu.Add(container.Resources.Requests[corev1.ResourceCPU])
+	}
+	if cpu.IsZero() {
+		return resource.Quantity{}, fmt.Errorf("the containers of scale target %s don't request CPU", scaledObject.ScaleTargetName())
+	}
+	return cpu, nil
+}
@@ -20,13 +20,24 @@ import (
 	"context"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
@@ -41,7 +52,7 @@ func TestScaleToFallbackReplicasWhenNotActiveAndIsError(t *testing.T) {
 	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 
-	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder, 0, nil)
 
 	scaledObject := v1alpha1.ScaledObject{
 		ObjectMeta: v1.ObjectMeta{
@@ -103,7 +114,7 @@ func TestScaleToMinReplicasWhenNotActive(t *testing.T) {
 	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 
-	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder, 0, nil)
 
 	minReplicas := int32(0)
 
@@ -164,7 +175,7 @@ func TestScaleToMinReplicasFromLowerInitialReplicaCount(t *testing.T) {
 	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 
-	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder, 0, nil)
 
 	minReplicas := int32(5)
 
@@ -217,6 +228,401 @@ func TestScaleToMinReplicasFromLowerInitialReplicaCount(t *testing.T) {
 	assert.Equal(t, true, condition.IsFalse())
 }
 
+func TestScaleToScheduledMinReplicasWhenNotActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder, 0, nil)
+
+	minReplicas := int32(1)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			MinReplicaCount: &minReplicas,
+			MinReplicaCountSchedules: []v1alpha1.MinReplicaCountSchedule{
+				{Schedule: "* * * * *", MinReplicaCount: 5},
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	numberOfReplicas := int32(2)
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numberOfReplicas,
+		},
+	})
+
+	scale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: numberOfReplicas,
+		},
+	}
+
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+
+	client.EXPECT().Status().Return(statusWriter).Times(2)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false, &ScaleExecutorOptions{})
+
+	assert.Equal(t, int32(5), scale.Spec.Replicas)
+}
+
+func TestScaleToZeroWithScheduledMinReplicasOfZeroWhenNotActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder, 0, nil)
+
+	minReplicas := int32(2)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			MinReplicaCount: &minReplicas,
+			MinReplicaCountSchedules: []v1alpha1.MinReplicaCountSchedule{
+				{Schedule: "* * * * *", MinReplicaCount: 0},
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	numberOfReplicas := int32(2)
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numberOfReplicas,
+		},
+	})
+
+	scale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: numberOfReplicas,
+		},
+	}
+
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+
+	client.EXPECT().Status().Return(statusWriter).AnyTimes()
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false, &ScaleExecutorOptions{})
+
+	assert.Equal(t, int32(0), scale.Spec.Replicas)
+}
+
+func TestGetMinReplicaCount(t *testing.T) {
+	minReplicas := int32(1)
+	businessHours := []v1alpha1.MinReplicaCountSchedule{
+		{Schedule: "0 8 * * 1-5", MinReplicaCount: 10},
+		{Schedule: "0 20 * * *", MinReplicaCount: 2},
+	}
+
+	// 2024-01-08 is a Monday
+	tests := []struct {
+		name      string
+		schedules []v1alpha1.MinReplicaCountSchedule
+		now       time.Time
+		expected  int32
+	}{
+		{"no schedules", nil, time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC), 1},
+		{"before business hours", businessHours, time.Date(2024, 1, 8, 7, 59, 59, 0, time.UTC), 2},
+		{"business hours start", businessHours, time.Date(2024, 1, 8, 8, 0, 0, 0, time.UTC), 10},
+		{"during business hours", businessHours, time.Date(2024, 1, 8, 19, 59, 59, 0, time.UTC), 10},
+		{"business hours end", businessHours, time.Date(2024, 1, 8, 20, 0, 0, 0, time.UTC), 2},
+		{"weekend", businessHours, time.Date(2024, 1, 13, 12, 0, 0, 0, time.UTC), 2},
+		{
+			"schedule in a timezone",
+			[]v1alpha1.MinReplicaCountSchedule{
+				{Schedule: "0 8 * * *", Timezone: "Europe/Paris", MinReplicaCount: 10},
+				{Schedule: "0 20 * * *", Timezone: "Europe/Paris", MinReplicaCount: 2},
+			},
+			// 07:30 UTC is 08:30 in Paris
+			time.Date(2024, 1, 8, 7, 30, 0, 0, time.UTC),
+			10,
+		},
+		{
+			"schedule which fires rarely",
+			[]v1alpha1.MinReplicaCountSchedule{
+				{Schedule: "0 0 1 1 *", MinReplicaCount: 3},
+			},
+			time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC),
+			3,
+		},
+		{
+			"schedule which never fires",
+			[]v1alpha1.MinReplicaCountSchedule{
+				{Schedule: "0 0 30 2 *", MinReplicaCount: 3},
+			},
+			time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC),
+			1,
+		},
+		{
+			"schedules firing at the same time",
+			[]v1alpha1.MinReplicaCountSchedule{
+				{Schedule: "0 8 * * *", MinReplicaCount: 4},
+				{Schedule: "0 8 * * 1", MinReplicaCount: 6},
+			},
+			time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC),
+			6,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scaledObject := &v1alpha1.ScaledObject{
+				Spec: v1alpha1.ScaledObjectSpec{
+					MinReplicaCount:          &minReplicas,
+					MinReplicaCountSchedules: test.schedules,
+				},
+			}
+			minReplicaCount, err := GetMinReplicaCount(scaledObject, test.now)
+			assert.NoError(t, err)
+			if assert.NotNil(t, minReplicaCount) {
+				assert.Equal(t, test.expected, *minReplicaCount)
+			}
+		})
+	}
+}
+
+func TestGetMinReplicaCountInvalidSchedule(t *testing.T) {
+	scaledObject := &v1alpha1.ScaledObject{
+		Spec: v1alpha1.ScaledObjectSpec{
+			MinReplicaCountSchedules: []v1alpha1.MinReplicaCountSchedule{
+				{Schedule: "every morning", MinReplicaCount: 10},
+			},
+		},
+	}
+	_, err := GetMinReplicaCount(scaledObject, time.Now())
+	assert.Error(t, err)
+}
+
+func TestGetNextMinReplicaCountScheduleTime(t *testing.T) {
+	scaledObject := &v1alpha1.ScaledObject{}
+	_, found, err := GetNextMinReplicaCountScheduleTime(scaledObject, time.Now())
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	scaledObject.Spec.MinReplicaCountSchedules = []v1alpha1.MinReplicaCountSchedule{
+		{Schedule: "0 8 * * 1-5", MinReplicaCount: 10},
+		{Schedule: "0 20 * * *", MinReplicaCount: 2},
+	}
+
+	// on Friday evening, the next transition is at 20:00
+	next, found, err := GetNextMinReplicaCountScheduleTime(scaledObject, time.Date(2024, 1, 12, 19, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.True(t, time.Date(2024, 1, 12, 20, 0, 0, 0, time.UTC).Equal(next))
+
+	// a schedule firing now is already applied, the next transition is the following one
+	next, _, err = GetNextMinReplicaCountScheduleTime(scaledObject, time.Date(2024, 1, 12, 20, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2024, 1, 13, 20, 0, 0, 0, time.UTC).Equal(next))
+}
+
+func TestGetReplicaWindow(t *testing.T) {
+	// business hours on week days, the weekend overlaps them on Saturday morning and is listed first
+	windows := []v1alpha1.ReplicaWindow{
+		{Schedule: "0 0 * * 6", Duration: "48h", MaxReplicaCount: ptr.To[int32](3)},
+		{Schedule: "0 8 * * 1-6", Duration: "10h", MinReplicaCount: ptr.To[int32](5), MaxReplicaCount: ptr.To[int32](50)},
+		{Schedule: "0 1 * * *", Timezone: "America/New_York", Duration: "1h", MinReplicaCount: ptr.To[int32](8)},
+	}
+
+	// 2024-01-08 is a Monday
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected int
+	}{
+		{"before business hours", time.Date(2024, 1, 8, 7, 59, 59, 0, time.UTC), -1},
+		{"business hours open", time.Date(2024, 1, 8, 8, 0, 0, 0, time.UTC), 1},
+		{"during business hours", time.Date(2024, 1, 8, 13, 0, 0, 0, time.UTC), 1},
+		{"business hours closed", time.Date(2024, 1, 8, 18, 0, 0, 0, time.UTC), -1},
+		// 01:00 in New York is 06:00 UTC in January
+		{"window in a timezone", time.Date(2024, 1, 8, 6, 30, 0, 0, time.UTC), 2},
+		{"window in a timezone closed", time.Date(2024, 1, 8, 7, 0, 0, 0, time.UTC), -1},
+		{"weekend overlapping business hours", time.Date(2024, 1, 13, 9, 0, 0, 0, time.UTC), 0},
+		{"window open over the day boundary", time.Date(2024, 1, 14, 23, 59, 0, 0, time.UTC), 0},
+		{"weekend closed", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scaledObject := &v1alpha1.ScaledObject{
+				Spec: v1alpha1.ScaledObjectSpec{
+					Advanced: &v1alpha1.AdvancedConfig{ReplicaWindows: windows},
+				},
+			}
+			window, err := GetReplicaWindow(scaledObject, test.now)
+			assert.NoError(t, err)
+			if test.expected < 0 {
+				assert.Nil(t, window)
+				return
+			}
+			assert.Same(t, &scaledObject.Spec.Advanced.ReplicaWindows[test.expected], window)
+		})
+	}
+
+	window, err := GetReplicaWindow(&v1alpha1.ScaledObject{}, time.Now())
+	assert.NoError(t, err)
+	assert.Nil(t, window)
+
+	_, err = GetReplicaWindow(&v1alpha1.ScaledObject{
+		Spec: v1alpha1.ScaledObjectSpec{
+			Advanced: &v1alpha1.AdvancedConfig{ReplicaWindows: []v1alpha1.ReplicaWindow{{Schedule: "0 8 * * *", Duration: "all day"}}},
+		},
+	}, time.Now())
+	assert.Error(t, err)
+}
+
+func TestGetMinReplicaCountWithReplicaWindows(t *testing.T) {
+	scaledObject := &v1alpha1.ScaledObject{
+		Spec: v1alpha1.ScaledObjectSpec{
+			MinReplicaCount: ptr.To[int32](1),
+			MinReplicaCountSchedules: []v1alpha1.MinReplicaCountSchedule{
+				{Schedule: "0 6 * * *", MinReplicaCount: 2},
+			},
+			Advanced: &v1alpha1.AdvancedConfig{
+				ReplicaWindows: []v1alpha1.ReplicaWindow{
+					{Schedule: "0 8 * * 1-5", Duration: "10h", MinReplicaCount: ptr.To[int32](10)},
+					{Schedule: "0 20 * * *", Duration: "2h", MaxReplicaCount: ptr.To[int32](4)},
+				},
+			},
+		},
+	}
+
+	// 2024-01-08 is a Monday
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected int32
+	}{
+		{"before the schedule", time.Date(2024, 1, 8, 5, 0, 0, 0, time.UTC), 2},
+		{"schedule outside windows", time.Date(2024, 1, 8, 7, 0, 0, 0, time.UTC), 2},
+		{"window takes precedence over the schedule", time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC), 10},
+		{"window without minReplicaCount", time.Date(2024, 1, 8, 21, 0, 0, 0, time.UTC), 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			minReplicaCount, err := GetMinReplicaCount(scaledObject, test.now)
+			assert.NoError(t, err)
+			if assert.NotNil(t, minReplicaCount) {
+				assert.Equal(t, test.expected, *minReplicaCount)
+			}
+		})
+	}
+}
+
+func TestGetHPAMinReplicaCount(t *testing.T) {
+	scaledObject := &v1alpha1.ScaledObject{
+		Spec: v1alpha1.ScaledObjectSpec{
+			MinReplicaCount: ptr.To[int32](5),
+			MinReplicaCountSchedules: []v1alpha1.MinReplicaCountSchedule{
+				{Schedule: "0 6 * * *", MinReplicaCount: 0},
+				{Schedule: "0 7 * * *", MinReplicaCount: 3},
+			},
+			Advanced: &v1alpha1.AdvancedConfig{
+				ReplicaWindows: []v1alpha1.ReplicaWindow{
+					{Schedule: "0 20 * * *", Duration: "2h", MinReplicaCount: ptr.To[int32](0)},
+				},
+			},
+		},
+	}
+
+	// 2024-01-08 is a Monday
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected int32
+	}{
+		{"schedule fired the day before", time.Date(2024, 1, 8, 5, 0, 0, 0, time.UTC), 3},
+		{"schedule to zero gives the HPA default", time.Date(2024, 1, 8, 6, 30, 0, 0, time.UTC), 1},
+		{"schedule above zero", time.Date(2024, 1, 8, 8, 0, 0, 0, time.UTC), 3},
+		{"window to zero gives the HPA default", time.Date(2024, 1, 8, 21, 0, 0, 0, time.UTC), 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			minReplicaCount, err := GetHPAMinReplicaCount(scaledObject, test.now)
+			assert.NoError(t, err)
+			if assert.NotNil(t, minReplicaCount) {
+				assert.Equal(t, test.expected, *minReplicaCount)
+			}
+		})
+	}
+}
+
+func TestGetNextReplicaWindowTime(t *testing.T) {
+	scaledObject := &v1alpha1.ScaledObject{}
+	_, found, err := GetNextReplicaWindowTime(scaledObject, time.Now())
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	scaledObject.Spec.Advanced = &v1alpha1.AdvancedConfig{
+		ReplicaWindows: []v1alpha1.ReplicaWindow{
+			{Schedule: "0 8 * * 1-5", Duration: "10h", MinReplicaCount: ptr.To[int32](10)},
+		},
+	}
+
+	// before the window opens, the next transition is its opening
+	next, found, err := GetNextReplicaWindowTime(scaledObject, time.Date(2024, 1, 8, 7, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.True(t, time.Date(2024, 1, 8, 8, 0, 0, 0, time.UTC).Equal(next))
+
+	// while the window is open, the next transition is its closing
+	next, _, err = GetNextReplicaWindowTime(scaledObject, time.Date(2024, 1, 8, 8, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2024, 1, 8, 18, 0, 0, 0, time.UTC).Equal(next))
+
+	// on Friday evening, the next transition is the opening on Monday
+	next, _, err = GetNextReplicaWindowTime(scaledObject, time.Date(2024, 1, 12, 18, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC).Equal(next))
+}
+
 func TestScaleFromMinReplicasWhenActive(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock_client.NewMockClient(ctrl)
@@ -225,7 +631,7 @@ func TestScaleFromMinReplicasWhenActive(t *testing.T) {
 	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 
-	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder, 0, nil)
 
 	minReplicas := int32(0)
 
@@ -284,7 +690,7 @@ func TestScaleToIdleReplicasWhenNotActive(t *testing.T) {
 	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 
-	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder, 0, nil)
 
 	idleReplicas := int32(0)
 	minReplicas := int32(5)
@@ -347,7 +753,7 @@ func TestScaleFromIdleToMinReplicasWhenActive(t *testing.T) {
 	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 
-	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder, 0, nil)
 
 	idleReplicas := int32(0)
 	minReplicas := int32(5)
@@ -408,7 +814,7 @@ func TestScaleToPausedReplicasCount(t *testing.T) {
 	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 
-	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder, 0, nil)
 
 	pausedReplicaCount := int32(0)
 	replicaCount := int32(2)
@@ -462,6 +868,99 @@ func TestScaleToPausedReplicasCount(t *testing.T) {
 	assert.Equal(t, false, condition.IsTrue())
 }
 
+func newTestArgoRolloutScaledObject(annotations map[string]string) v1alpha1.ScaledObject {
+	minReplicas := int32(0)
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "name",
+			Namespace:   "namespace",
+			Annotations: annotations,
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "rollout",
+			},
+			MinReplicaCount: &minReplicas,
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group:    "argoproj.io",
+				Version:  "v1alpha1",
+				Kind:     "Rollout",
+				Resource: "rollouts",
+			},
+		},
+	}
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+	return scaledObject
+}
+
+// expectArgoRolloutGet returns the Rollout with the given spec on the Get of the client
+func expectArgoRolloutGet(client *mock_client.MockClient, spec map[string]interface{}) {
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ runtimeclient.ObjectKey, obj runtimeclient.Object, _ ...runtimeclient.GetOption) error {
+		obj.(*unstructured.Unstructured).Object["spec"] = spec
+		return nil
+	})
+}
+
+func TestScaleArgoRolloutWithoutReplicasToZero(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder, 0, nil)
+	scaledObject := newTestArgoRolloutScaledObject(nil)
+
+	// the scale subresource reports 0 replicas while the Rollout runs its default single replica
+	expectArgoRolloutGet(client, map[string]interface{}{"strategy": map[string]interface{}{}})
+	scale := &autoscalingv1.Scale{}
+
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+
+	client.EXPECT().Status().Return(statusWriter).AnyTimes()
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false, &ScaleExecutorOptions{})
+
+	assert.Equal(t, int32(0), scale.Spec.Replicas)
+}
+
+func TestScaleArgoRolloutToPausedReplicasCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder, 0, nil)
+	scaledObject := newTestArgoRolloutScaledObject(map[string]string{"autoscaling.keda.sh/paused-replicas": "5"})
+
+	expectArgoRolloutGet(client, map[string]interface{}{"replicas": int64(2)})
+	scale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: 2,
+		},
+	}
+
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+
+	client.EXPECT().Status().Return(statusWriter).AnyTimes()
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{})
+
+	assert.Equal(t, int32(5), scale.Spec.Replicas)
+	assert.Equal(t, int32(5), *scaledObject.Status.PausedReplicaCount)
+}
+
 func TestEventWitTriggerInfo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock_client.NewMockClient(ctrl)
@@ -470,7 +969,7 @@ func TestEventWitTriggerInfo(t *testing.T) {
 	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 
-	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder, 0, nil)
 
 	replicaCount := int32(2)
 	idleReplicas := int32(0)
@@ -522,3 +1021,615 @@ func TestEventWitTriggerInfo(t *testing.T) {
 	eventstring := <-recorder.Events
 	assert.Equal(t, "Normal KEDAScaleTargetActivated Scaled  namespace/name from 2 to 5, triggered by testTrigger", eventstring)
 }
+
+func newTestPodDisruptionBudget(name string, selector *v1.LabelSelector, minAvailable, maxUnavailable *intstr.IntOrString) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "namespace"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector:       selector,
+			MinAvailable:   minAvailable,
+			MaxUnavailable: maxUnavailable,
+		},
+	}
+}
+
+func TestGetViolatedPodDisruptionBudget(t *testing.T) {
+	appSelector := &v1.LabelSelector{MatchLabels: map[string]string{"app": "name"}}
+	pods := []*corev1.Pod{
+		{ObjectMeta: v1.ObjectMeta{Name: "name-1", Namespace: "namespace", Labels: map[string]string{"app": "name"}}},
+		{ObjectMeta: v1.ObjectMeta{Name: "name-2", Namespace: "namespace", Labels: map[string]string{"app": "name"}}},
+		{ObjectMeta: v1.ObjectMeta{Name: "other-1", Namespace: "namespace", Labels: map[string]string{"app": "other"}}},
+	}
+
+	tests := []struct {
+		name        string
+		pdbs        []*policyv1.PodDisruptionBudget
+		withoutPods bool
+		selector    string
+		replicas    int32
+		expected    string
+		expectedErr string
+	}{
+		{name: "no PodDisruptionBudget", expected: ""},
+		{
+			name:     "minAvailable violated",
+			pdbs:     []*policyv1.PodDisruptionBudget{newTestPodDisruptionBudget("name-pdb", appSelector, ptr.To(intstr.FromInt32(1)), nil)},
+			expected: "name-pdb",
+		},
+		{
+			name:     "minAvailable kept with idle replicas",
+			pdbs:     []*policyv1.PodDisruptionBudget{newTestPodDisruptionBudget("name-pdb", appSelector, ptr.To(intstr.FromInt32(1)), nil)},
+			replicas: 1,
+			expected: "",
+		},
+		{
+			name:     "minAvailable percentage violated",
+			pdbs:     []*policyv1.PodDisruptionBudget{newTestPodDisruptionBudget("name-pdb", appSelector, ptr.To(intstr.FromString("50%")), nil)},
+			expected: "name-pdb",
+		},
+		{
+			name:     "minAvailable zero",
+			pdbs:     []*policyv1.PodDisruptionBudget{newTestPodDisruptionBudget("name-pdb", appSelector, ptr.To(intstr.FromInt32(0)), nil)},
+			expected: "",
+		},
+		{
+			name:     "maxUnavailable ignored",
+			pdbs:     []*policyv1.PodDisruptionBudget{newTestPodDisruptionBudget("name-pdb", appSelector, nil, ptr.To(intstr.FromInt32(1)))},
+			expected: "",
+		},
+		{
+			name:     "maxUnavailable zero ignored",
+			pdbs:     []*policyv1.PodDisruptionBudget{newTestPodDisruptionBudget("name-pdb", appSelector, nil, ptr.To(intstr.FromInt32(0)))},
+			expected: "",
+		},
+		{
+			name:     "PodDisruptionBudget of other pods",
+			pdbs:     []*policyv1.PodDisruptionBudget{newTestPodDisruptionBudget("other-pdb", &v1.LabelSelector{MatchLabels: map[string]string{"app": "other"}}, ptr.To(intstr.FromInt32(1)), nil)},
+			expected: "",
+		},
+		{
+			name:     "empty selector selects all pods",
+			pdbs:     []*policyv1.PodDisruptionBudget{newTestPodDisruptionBudget("namespace-pdb", &v1.LabelSelector{}, ptr.To(intstr.FromInt32(1)), nil)},
+			expected: "namespace-pdb",
+		},
+		{
+			name:     "nil selector selects no pods",
+			pdbs:     []*policyv1.PodDisruptionBudget{newTestPodDisruptionBudget("namespace-pdb", nil, ptr.To(intstr.FromInt32(1)), nil)},
+			expected: "",
+		},
+		{
+			name:        "no pods to disrupt",
+			pdbs:        []*policyv1.PodDisruptionBudget{newTestPodDisruptionBudget("name-pdb", appSelector, ptr.To(intstr.FromInt32(1)), nil)},
+			withoutPods: true,
+			expected:    "",
+		},
+		{
+			name:        "scale target without selector",
+			selector:    "-",
+			expectedErr: "the scale target doesn't expose the selector of its pods",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme.Scheme)
+			for _, pdb := range test.pdbs {
+				builder = builder.WithObjects(pdb)
+			}
+			if !test.withoutPods {
+				for _, pod := range pods {
+					builder = builder.WithObjects(pod)
+				}
+			}
+			e := &scaleExecutor{client: builder.Build()}
+
+			selector := "app=name"
+			if test.selector == "-" {
+				selector = ""
+			}
+			scale := &autoscalingv1.Scale{
+				Spec:   autoscalingv1.ScaleSpec{Replicas: 2},
+				Status: autoscalingv1.ScaleStatus{Replicas: 2, Selector: selector},
+			}
+
+			pdbName, err := e.getViolatedPodDisruptionBudget(context.TODO(), "namespace", scale, test.replicas)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, pdbName)
+		})
+	}
+}
+
+func TestScaleToZeroWithPodDisruptionBudget(t *testing.T) {
+	tests := []struct {
+		name            string
+		respectPDB      bool
+		pdb             *policyv1.PodDisruptionBudget
+		expectedReplica int32
+		expectedEvent   string
+	}{
+		{
+			name:            "PodDisruptionBudget present",
+			respectPDB:      true,
+			pdb:             newTestPodDisruptionBudget("name-pdb", &v1.LabelSelector{MatchLabels: map[string]string{"app": "name"}}, ptr.To(intstr.FromInt32(1)), nil),
+			expectedReplica: 2,
+			expectedEvent:   "Warning KEDAScaleTargetDeactivationBlocked Not deactivating Deployment namespace/name from 2 to 0, it would violate PodDisruptionBudget name-pdb",
+		},
+		{
+			name:            "PodDisruptionBudget absent",
+			respectPDB:      true,
+			expectedReplica: 0,
+			expectedEvent:   "Normal KEDAScaleTargetDeactivated Deactivated Deployment namespace/name from 2 to 0",
+		},
+		{
+			name:            "PodDisruptionBudget not respected",
+			pdb:             newTestPodDisruptionBudget("name-pdb", &v1.LabelSelector{MatchLabels: map[string]string{"app": "name"}}, ptr.To(intstr.FromInt32(1)), nil),
+			expectedReplica: 0,
+			expectedEvent:   "Normal KEDAScaleTargetDeactivated Deactivated Deployment namespace/name from 2 to 0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			recorder := record.NewFakeRecorder(10)
+			mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+			mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+
+			minReplicas := int32(0)
+			scaledObject := &v1alpha1.ScaledObject{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "name",
+					Namespace: "namespace",
+				},
+				Spec: v1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &v1alpha1.ScaleTarget{
+						Name: "name",
+					},
+					MinReplicaCount: &minReplicas,
+					Advanced: &v1alpha1.AdvancedConfig{
+						RespectPodDisruptionBudget: test.respectPDB,
+					},
+				},
+				Status: v1alpha1.ScaledObjectStatus{
+					ScaleTargetKind: "Deployment",
+					ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+						Group: "apps",
+						Kind:  "Deployment",
+					},
+					Conditions: *v1alpha1.GetInitializedConditions(),
+				},
+			}
+
+			testScheme := runtime.NewScheme()
+			assert.NoError(t, scheme.AddToScheme(testScheme))
+			assert.NoError(t, v1alpha1.AddToScheme(testScheme))
+			builder := fake.NewClientBuilder().WithScheme(testScheme).
+				WithObjects(scaledObject.DeepCopy()).
+				WithStatusSubresource(&v1alpha1.ScaledObject{}).
+				WithObjects(
+					&appsv1.Deployment{ObjectMeta: v1.ObjectMeta{Name: "name", Namespace: "namespace"}, Spec: appsv1.DeploymentSpec{Replicas: ptr.To[int32](2)}},
+					&corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "name-1", Namespace: "namespace", Labels: map[string]string{"app": "name"}}},
+					&corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "name-2", Namespace: "namespace", Labels: map[string]string{"app": "name"}}},
+				)
+			if test.pdb != nil {
+				builder = builder.WithObjects(test.pdb)
+			}
+			scaleExecutor := NewScaleExecutor(builder.Build(), mockScaleClient, nil, recorder, 0, nil)
+
+			scale := &autoscalingv1.Scale{
+				Spec:   autoscalingv1.ScaleSpec{Replicas: 2},
+				Status: autoscalingv1.ScaleStatus{Replicas: 2, Selector: "app=name"},
+			}
+			mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).AnyTimes()
+			mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
+			if test.expectedReplica != scale.Spec.Replicas {
+				mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+			}
+
+			scaleExecutor.RequestScale(context.TODO(), scaledObject, false, false, &ScaleExecutorOptions{})
+
+			assert.Equal(t, test.expectedReplica, scale.Spec.Replicas)
+			assert.Equal(t, test.expectedEvent, <-recorder.Events)
+		})
+	}
+}
+
+func TestScaleToZeroWithinInitialCooldownPeriod(t *testing.T) {
+	tests := []struct {
+		name            string
+		createdAgo      time.Duration
+		lastActiveAgo   *time.Duration
+		expectedReplica int32
+	}{
+		{
+			name:            "within the initial cooldown period",
+			createdAgo:      10 * time.Second,
+			expectedReplica: 2,
+		},
+		{
+			name:            "within the initial cooldown period after the cooldown of an activation",
+			createdAgo:      10 * time.Minute,
+			lastActiveAgo:   ptr.To(6 * time.Minute),
+			expectedReplica: 2,
+		},
+		{
+			name:            "initial cooldown period elapsed",
+			createdAgo:      time.Hour,
+			expectedReplica: 0,
+		},
+		{
+			name:            "initial cooldown period and cooldown period elapsed",
+			createdAgo:      time.Hour,
+			lastActiveAgo:   ptr.To(6 * time.Minute),
+			expectedReplica: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			recorder := record.NewFakeRecorder(10)
+			mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+			mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+
+			minReplicas := int32(0)
+			initialCooldownPeriod := int32(30 * 60)
+			scaledObject := &v1alpha1.ScaledObject{
+				ObjectMeta: v1.ObjectMeta{
+					Name:              "name",
+					Namespace:         "namespace",
+					CreationTimestamp: v1.NewTime(time.Now().Add(-test.createdAgo)),
+				},
+				Spec: v1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &v1alpha1.ScaleTarget{
+						Name: "name",
+					},
+					MinReplicaCount:       &minReplicas,
+					InitialCooldownPeriod: &initialCooldownPeriod,
+				},
+				Status: v1alpha1.ScaledObjectStatus{
+					ScaleTargetKind: "Deployment",
+					ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+						Group: "apps",
+						Kind:  "Deployment",
+					},
+					Conditions: *v1alpha1.GetInitializedConditions(),
+				},
+			}
+			if test.lastActiveAgo != nil {
+				scaledObject.Status.LastActiveTime = ptr.To(v1.NewTime(time.Now().Add(-*test.lastActiveAgo)))
+			}
+
+			testScheme := runtime.NewScheme()
+			assert.NoError(t, scheme.AddToScheme(testScheme))
+			assert.NoError(t, v1alpha1.AddToScheme(testScheme))
+			client := fake.NewClientBuilder().WithScheme(testScheme).
+				WithObjects(scaledObject.DeepCopy()).
+				WithStatusSubresource(&v1alpha1.ScaledObject{}).
+				WithObjects(&appsv1.Deployment{ObjectMeta: v1.ObjectMeta{Name: "name", Namespace: "namespace"}, Spec: appsv1.DeploymentSpec{Replicas: ptr.To[int32](2)}}).
+				Build()
+			scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder, 0, nil)
+
+			scale := &autoscalingv1.Scale{
+				Spec: autoscalingv1.ScaleSpec{Replicas: 2},
+			}
+			if test.expectedReplica != scale.Spec.Replicas {
+				mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
+				mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
+				mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+			}
+
+			// the triggers are inactive, the ScaledObject is only scaled to zero once its cooldown periods elapsed
+			scaleExecutor.RequestScale(context.TODO(), scaledObject, false, false, &ScaleExecutorOptions{})
+
+			assert.Equal(t, test.expectedReplica, scale.Spec.Replicas)
+			if test.expectedReplica != 0 {
+				assert.Equal(t, "ScalerCooldown", scaledObject.Status.Conditions.GetActiveCondition().Reason)
+			}
+		})
+	}
+}
+
+func TestGetMaxReplicaCount(t *testing.T) {
+	maxReplicaCountCapacityEnabled = true
+	defer func() { maxReplicaCountCapacityEnabled = false }()
+	newNode := func(name string, cpu string, ready bool, unschedulable bool, labels map[string]string) *corev1.Node {
+		status := corev1.ConditionTrue
+		if !ready {
+			status = corev1.ConditionFalse
+		}
+		return &corev1.Node{
+			ObjectMeta: v1.ObjectMeta{Name: name, Labels: labels},
+			Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+				Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+			},
+		}
+	}
+	// 4 schedulable nodes with 16 allocatable CPUs, 2 of them in the gpu pool
+	nodes := []*corev1.Node{
+		newNode("node-1", "4", true, false, nil),
+		newNode("node-2", "4", true, false, nil),
+		newNode("node-3", "4", true, false, map[string]string{"pool": "gpu"}),
+		newNode("node-4", "4", true, false, map[string]string{"pool": "gpu"}),
+		newNode("not-ready", "4", false, false, nil),
+		newNode("cordoned", "4", true, true, map[string]string{"pool": "gpu"}),
+	}
+	newDeployment := func(name string, cpuRequests ...string) *appsv1.Deployment {
+		containers := make([]corev1.Container, 0, len(cpuRequests))
+		for i, cpu := range cpuRequests {
+			containers = append(containers, corev1.Container{
+				Name:      "container-" + strconv.Itoa(i),
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}},
+			})
+		}
+		return &appsv1.Deployment{
+			ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "namespace"},
+			Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: containers}}},
+		}
+	}
+	// a replica requests a CPU
+	deployment := newDeployment("name", "750m", "250m")
+	noRequestsDeployment := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{Name: "no-requests", Namespace: "namespace"},
+		Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "container"}}}}},
+	}
+	gvkr := &v1alpha1.GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments"}
+
+	tests := []struct {
+		name            string
+		target          string
+		minReplicaCount *int32
+		maxReplicaCount *int32
+		capacity        *v1alpha1.MaxReplicaCountCapacity
+		windows         []v1alpha1.ReplicaWindow
+		expected        int32
+		expectedErr     string
+	}{
+		{name: "maxReplicaCount", maxReplicaCount: ptr.To[int32](10), expected: 10},
+		{
+			name:            "maxReplicaCount of the open window",
+			maxReplicaCount: ptr.To[int32](10),
+			windows:         []v1alpha1.ReplicaWindow{{Schedule: "* * * * *", Duration: "1h", MaxReplicaCount: ptr.To[int32](20)}},
+			expected:        20,
+		},
+		{
+			name:            "window not open",
+			maxReplicaCount: ptr.To[int32](10),
+			windows:         []v1alpha1.ReplicaWindow{{Schedule: "0 0 30 2 *", Duration: "1h", MaxReplicaCount: ptr.To[int32](20)}},
+			expected:        10,
+		},
+		{
+			name:            "open window without maxReplicaCount",
+			maxReplicaCount: ptr.To[int32](10),
+			windows:         []v1alpha1.ReplicaWindow{{Schedule: "* * * * *", Duration: "1h", MinReplicaCount: ptr.To[int32](5)}},
+			expected:        10,
+		},
+		{
+			name:     "window capped by the capacity",
+			windows:  []v1alpha1.ReplicaWindow{{Schedule: "* * * * *", Duration: "1h", MaxReplicaCount: ptr.To[int32](20)}},
+			capacity: &v1alpha1.MaxReplicaCountCapacity{Percentage: 50, Resource: v1alpha1.MaxReplicaCountCapacityCPU},
+			expected: 8,
+		},
+		{
+			name:            "capacity capped by the window",
+			maxReplicaCount: ptr.To[int32](10),
+			windows:         []v1alpha1.ReplicaWindow{{Schedule: "* * * * *", Duration: "1h", MaxReplicaCount: ptr.To[int32](4)}},
+			capacity:        &v1alpha1.MaxReplicaCountCapacity{Percentage: 50, Resource: v1alpha1.MaxReplicaCountCapacityCPU},
+			expected:        4,
+		},
+		{name: "default maxReplicaCount", expected: 100},
+		{
+			name:     "percentage of the schedulable nodes",
+			capacity: &v1alpha1.MaxReplicaCountCapacity{Percentage: 50, Resource: v1alpha1.MaxReplicaCountCapacityNodes},
+			expected: 2,
+		},
+		{
+			name:     "percentage of the selected nodes",
+			capacity: &v1alpha1.MaxReplicaCountCapacity{Percentage: 100, NodeSelector: map[string]string{"pool": "gpu"}},
+			expected: 2,
+		},
+		{
+			name:     "percentage of the allocatable CPU",
+			capacity: &v1alpha1.MaxReplicaCountCapacity{Percentage: 50, Resource: v1alpha1.MaxReplicaCountCapacityCPU},
+			expected: 8,
+		},
+		{
+			name:     "percentage above the capacity",
+			capacity: &v1alpha1.MaxReplicaCountCapacity{Percentage: 300, Resource: v1alpha1.MaxReplicaCountCapacityNodes},
+			expected: 12,
+		},
+		{
+			name:            "capped by maxReplicaCount",
+			maxReplicaCount: ptr.To[int32](5),
+			capacity:        &v1alpha1.MaxReplicaCountCapacity{Percentage: 50, Resource: v1alpha1.MaxReplicaCountCapacityCPU},
+			expected:        5,
+		},
+		{
+			name:            "not below minReplicaCount",
+			minReplicaCount: ptr.To[int32](3),
+			capacity:        &v1alpha1.MaxReplicaCountCapacity{Percentage: 25, Resource: v1alpha1.MaxReplicaCountCapacityNodes},
+			expected:        3,
+		},
+		{
+			name:     "no node selected",
+			capacity: &v1alpha1.MaxReplicaCountCapacity{Percentage: 100, NodeSelector: map[string]string{"pool": "none"}},
+			expected: 1,
+		},
+		{
+			name:        "scale target without CPU requests",
+			target:      "no-requests",
+			capacity:    &v1alpha1.MaxReplicaCountCapacity{Percentage: 50, Resource: v1alpha1.MaxReplicaCountCapacityCPU},
+			expectedErr: "the containers of scale target no-requests don't request CPU",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(deployment, noRequestsDeployment)
+			for _, node := range nodes {
+				builder = builder.WithObjects(node)
+			}
+			target := test.target
+			if target == "" {
+				target = "name"
+			}
+			scaledObject := &v1alpha1.ScaledObject{
+				ObjectMeta: v1.ObjectMeta{Name: "name", Namespace: "namespace"},
+				Spec: v1alpha1.ScaledObjectSpec{
+					ScaleTargetRef:          &v1alpha1.ScaleTarget{Name: target},
+					MinReplicaCount:         test.minReplicaCount,
+					MaxReplicaCount:         test.maxReplicaCount,
+					MaxReplicaCountCapacity: test.capacity,
+					Advanced:                &v1alpha1.AdvancedConfig{ReplicaWindows: test.windows},
+				},
+			}
+
+			maxReplicaCount, err := GetMaxReplicaCount(context.TODO(), builder.Build(), scaledObject, gvkr)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, maxReplicaCount)
+		})
+	}
+}
+
+func TestGetMaxReplicaCountCapacityDisabled(t *testing.T) {
+	scaledObject := &v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{Name: "name", Namespace: "namespace"},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef:          &v1alpha1.ScaleTarget{Name: "name"},
+			MaxReplicaCountCapacity: &v1alpha1.MaxReplicaCountCapacity{Percentage: 50},
+		},
+	}
+
+	// the nodes aren't listed unless the operator opted in
+	_, err := GetMaxReplicaCount(context.TODO(), fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(), scaledObject, nil)
+	assert.EqualError(t, err, "maxReplicaCountCapacity requires the operator to run with KEDA_ENABLE_MAX_REPLICA_COUNT_CAPACITY=true and to be allowed to list the nodes")
+}
+
+func TestGetFallbackReplicasCappedByMaxReplicaCountCapacity(t *testing.T) {
+	maxReplicaCountCapacityEnabled = true
+	defer func() { maxReplicaCountCapacityEnabled = false }()
+
+	builder := fake.NewClientBuilder().WithScheme(scheme.Scheme)
+	for _, name := range []string{"node-1", "node-2", "node-3", "node-4"} {
+		builder = builder.WithObjects(&corev1.Node{
+			ObjectMeta: v1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}},
+		})
+	}
+	scaleExecutor := NewScaleExecutor(builder.Build(), nil, nil, record.NewFakeRecorder(1), 0, nil).(*scaleExecutor)
+	scaledObject := &v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{Name: "name", Namespace: "namespace"},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{Name: "name"},
+			Fallback:       &v1alpha1.Fallback{FailureThreshold: 3, Replicas: 5},
+		},
+		Status: v1alpha1.ScaledObjectStatus{ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{Group: "apps", Kind: "Deployment"}},
+	}
+
+	assert.Equal(t, int32(5), scaleExecutor.getFallbackReplicas(context.TODO(), scaleExecutor.logger, scaledObject))
+
+	// the fallback replicas don't exceed the maxReplicas of the HPA
+	scaledObject.Spec.MaxReplicaCountCapacity = &v1alpha1.MaxReplicaCountCapacity{Percentage: 50}
+	assert.Equal(t, int32(2), scaleExecutor.getFallbackReplicas(context.TODO(), scaleExecutor.logger, scaledObject))
+
+	scaledObject.Spec.MaxReplicaCountCapacity = &v1alpha1.MaxReplicaCountCapacity{Percentage: 200}
+	assert.Equal(t, int32(5), scaleExecutor.getFallbackReplicas(context.TODO(), scaleExecutor.logger, scaledObject))
+}
+
+func TestLastActiveTimeStatusUpdateMinInterval(t *testing.T) {
+	tests := []struct {
+		name                    string
+		statusUpdateMinInterval time.Duration
+		lastActiveTime          time.Duration
+		expectedPatches         int
+	}{
+		{name: "without min interval", statusUpdateMinInterval: 0, lastActiveTime: 10 * time.Second, expectedPatches: 1},
+		{name: "within min interval", statusUpdateMinInterval: time.Minute, lastActiveTime: 10 * time.Second, expectedPatches: 0},
+		{name: "after min interval", statusUpdateMinInterval: time.Minute, lastActiveTime: 2 * time.Minute, expectedPatches: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			client := mock_client.NewMockClient(ctrl)
+			recorder := record.NewFakeRecorder(1)
+			mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+			statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+			scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder, tt.statusUpdateMinInterval, nil)
+
+			lastActiveTime := v1.NewTime(time.Now().Add(-tt.lastActiveTime))
+			scaledObject := v1alpha1.ScaledObject{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "name",
+					Namespace: "namespace",
+				},
+				Spec: v1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &v1alpha1.ScaleTarget{
+						Name: "name",
+					},
+				},
+				Status: v1alpha1.ScaledObjectStatus{
+					ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+						Group: "apps",
+						Kind:  "Deployment",
+					},
+					LastActiveTime: &lastActiveTime,
+				},
+			}
+			scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+			scaledObject.Status.Conditions.SetReadyCondition(v1.ConditionTrue, v1alpha1.ScaledObjectConditionReadySuccessReason, v1alpha1.ScaledObjectConditionReadySuccessMessage)
+			scaledObject.Status.Conditions.SetActiveCondition(v1.ConditionTrue, "ScalerActive", "Scaling is performed because triggers are active")
+
+			numberOfReplicas := int32(2)
+			client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Replicas: &numberOfReplicas,
+				},
+			})
+			client.EXPECT().Status().Times(tt.expectedPatches).Return(statusWriter)
+			statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(tt.expectedPatches)
+
+			scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{})
+		})
+	}
+}
+
+func TestIdenticalStatusIsNotWritten(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	executor := NewScaleExecutor(client, mockScaleClient, nil, recorder, 0, nil).(*scaleExecutor)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+	}
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	// only the first update changes the fallback condition
+	client.EXPECT().Status().Times(1).Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	for i := 0; i < 3; i++ {
+		err := executor.setFallbackCondition(context.TODO(), executor.logger, &scaledObject, v1.ConditionTrue, "FallbackExists", "At least one trigger is falling back on this scaled object")
+		assert.NoError(t, err)
+	}
+	condition := scaledObject.Status.Conditions.GetFallbackCondition()
+	assert.True(t, condition.IsTrue())
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+// calculateScalingModifiersDerivative creates the composite metric from the per-second
+// rate of change of the metric of the given trigger since the previous poll. Only the scale loop
+// updates the rate, the other callers, like the metrics server, get the last rate of the scale loop
+func calculateScalingModifiersDerivative(trigger string, list []external_metrics.ExternalMetricValue, cacheObj *cache.ScalersCache, pairList map[string]string, now time.Time, update bool) ([]external_metrics.ExternalMetricValue, error) {
+	if cacheObj.Derivative == nil {
+		return nil, fmt.Errorf("cached derivative is nil during its calculation")
+	}
+	if !update {
+		return newDerivativeMetric(cacheObj.Derivative.Rate(), now), nil
+	}
+
+	var metric *external_metrics.ExternalMetricValue
+	for i, v := range list {
+		if pairList[v.MetricName] == trigger {
+			metric = &list[i]
+			break
+		}
+	}
+	if metric == nil {
+		// the next value can't be compared to the last known one anymore
+		cacheObj.Derivative.Reset()
+		return nil, fmt.Errorf("no metric found for trigger %q of the derivative", trigger)
+	}
+
+	// the timestamp of the metric tells apart a new value from one read again from the metrics cache
+	timestamp := now
+	if !metric.Timestamp.IsZero() {
+		timestamp = metric.Timestamp.Time
+	}
+	rate := cacheObj.Derivative.Update(metric.Value.AsApproximateFloat64(), timestamp)
+	return newDerivativeMetric(rate, now), nil
+}
+
+func newDerivativeMetric(rate float64, now time.Time) []external_metrics.ExternalMetricValue {
+	var ret external_metrics.ExternalMetricValue
+	ret.MetricName = kedav1alpha1.CompositeMetricName
+	ret.Timestamp = v1.NewTime(now)
+	ret.Value.SetMilli(int64(rate * 1000))
+	return []external_metrics.ExternalMetricValue{ret}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+func newTestMetric(name string, value float64, timestamp time.Time) external_metrics.ExternalMetricValue {
+	return external_metrics.ExternalMetricValue{
+		MetricName: name,
+		Value:      *resource.NewMilliQuantity(int64(value*1000), resource.DecimalSI),
+		Timestamp:  v1.NewTime(timestamp),
+	}
+}
+
+func TestCalculateScalingModifiersDerivative(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	pairs := map[string]string{"s0-queue-backlog": "backlog", "s1-cpu-load": "load"}
+	cacheObj := &cache.ScalersCache{Derivative: &cache.MetricDerivative{}}
+
+	polls := []struct {
+		backlog  float64
+		offset   time.Duration
+		missing  bool
+		expected float64
+	}{
+		// first poll, no prior value
+		{backlog: 120, offset: 0, expected: 0},
+		{backlog: 180, offset: 30 * time.Second, expected: 2},
+		{backlog: 195, offset: 60 * time.Second, expected: 0.5},
+		// a shrinking backlog is clamped to 0
+		{backlog: 135, offset: 90 * time.Second, expected: 0},
+		// the metric couldn't be read, so the next poll starts over
+		{offset: 120 * time.Second, missing: true},
+		{backlog: 400, offset: 150 * time.Second, expected: 0},
+		{backlog: 430, offset: 165 * time.Second, expected: 2},
+	}
+
+	for i, poll := range polls {
+		now := start.Add(poll.offset)
+		metrics := []external_metrics.ExternalMetricValue{newTestMetric("s1-cpu-load", 75, now)}
+		if !poll.missing {
+			metrics = append(metrics, newTestMetric("s0-queue-backlog", poll.backlog, now))
+		}
+
+		result, err := calculateScalingModifiersDerivative("backlog", metrics, cacheObj, pairs, now, true)
+		if poll.missing {
+			assert.ErrorContains(t, err, "no metric found for trigger \"backlog\"", "poll %d", i)
+			continue
+		}
+		assert.NoError(t, err, "poll %d", i)
+		if assert.Len(t, result, 1, "poll %d", i) {
+			assert.Equal(t, kedav1alpha1.CompositeMetricName, result[0].MetricName)
+			assert.InDelta(t, poll.expected, result[0].Value.AsApproximateFloat64(), 1e-9, "poll %d", i)
+		}
+	}
+}
+
+func TestHandleScalingModifiersDerivative(t *testing.T) {
+	so := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				ScalingModifiers: kedav1alpha1.ScalingModifiers{Derivative: "backlog", Target: "1"},
+			},
+		},
+	}
+	pairs := map[string]string{"s0-queue-backlog": "backlog"}
+	cacheObj := &cache.ScalersCache{Derivative: &cache.MetricDerivative{}}
+	start := time.Now().Add(-time.Minute)
+
+	metrics := HandleScalingModifiers(so, []external_metrics.ExternalMetricValue{newTestMetric("s0-queue-backlog", 10, start)}, pairs, nil, false, nil, cacheObj, true, logr.Discard())
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, float64(0), metrics[0].Value.AsApproximateFloat64())
+	}
+
+	metrics = HandleScalingModifiers(so, []external_metrics.ExternalMetricValue{newTestMetric("s0-queue-backlog", 70, start.Add(20*time.Second))}, pairs, nil, false, nil, cacheObj, true, logr.Discard())
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, kedav1alpha1.CompositeMetricName, metrics[0].MetricName)
+		assert.Equal(t, float64(3), metrics[0].Value.AsApproximateFloat64())
+	}
+}
+
+func TestHandleScalingModifiersDerivativeWithMixedTriggers(t *testing.T) {
+	so := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				ScalingModifiers: kedav1alpha1.ScalingModifiers{Derivative: "backlog", Target: "1"},
+			},
+		},
+	}
+	pairs := map[string]string{"s0-cpu-load": "load", "s1-queue-backlog": "backlog", "s2-cron": "cron"}
+	cacheObj := &cache.ScalersCache{Derivative: &cache.MetricDerivative{}}
+	start := time.Now().Add(-time.Minute)
+	pollMetrics := func(backlog float64, timestamp time.Time) []external_metrics.ExternalMetricValue {
+		return []external_metrics.ExternalMetricValue{
+			newTestMetric("s0-cpu-load", 80, timestamp),
+			newTestMetric("s1-queue-backlog", backlog, timestamp),
+			newTestMetric("s2-cron", 1, timestamp),
+		}
+	}
+
+	// the scale loop polls every 10 seconds
+	HandleScalingModifiers(so, pollMetrics(100, start), pairs, nil, false, nil, cacheObj, true, logr.Discard())
+	metrics := HandleScalingModifiers(so, pollMetrics(150, start.Add(10*time.Second)), pairs, nil, false, nil, cacheObj, true, logr.Discard())
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, kedav1alpha1.CompositeMetricName, metrics[0].MetricName)
+		assert.Equal(t, float64(5), metrics[0].Value.AsApproximateFloat64())
+	}
+
+	// the metrics server polls the triggers in between, it gets the rate of the scale loop without changing it
+	metrics = HandleScalingModifiers(so, pollMetrics(400, start.Add(12*time.Second)), pairs, nil, false, nil, cacheObj, false, logr.Discard())
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, float64(5), metrics[0].Value.AsApproximateFloat64())
+	}
+
+	metrics = HandleScalingModifiers(so, pollMetrics(170, start.Add(20*time.Second)), pairs, nil, false, nil, cacheObj, true, logr.Discard())
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, float64(2), metrics[0].Value.AsApproximateFloat64())
+	}
+
+	// the backlog shrinks
+	metrics = HandleScalingModifiers(so, pollMetrics(120, start.Add(30*time.Second)), pairs, nil, false, nil, cacheObj, true, logr.Discard())
+	if assert.Len(t, metrics, 1) {
+		assert.Equal(t, float64(0), metrics[0].Value.AsApproximateFloat64())
+	}
+}
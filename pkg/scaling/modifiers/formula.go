@@ -27,7 +27,9 @@ package modifiers
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/expr-lang/expr"
 	"github.com/go-logr/logr"
@@ -40,8 +42,9 @@ import (
 
 // HandleScalingModifiers is the parent function for scalingModifiers structure.
 // If the structure is defined and conditions are met, apply the formula to
-// manipulate the metrics and return them
-func HandleScalingModifiers(so *kedav1alpha1.ScaledObject, metrics []external_metrics.ExternalMetricValue, metricTriggerList map[string]string, fallbackActive bool, fallbackMetrics []external_metrics.ExternalMetricValue, cacheObj *cache.ScalersCache, log logr.Logger) []external_metrics.ExternalMetricValue {
+// manipulate the metrics and return them. The rate of scalingModifiers.derivative
+// is only updated when updateDerivative is set, i.e. from the scale loop
+func HandleScalingModifiers(so *kedav1alpha1.ScaledObject, metrics []external_metrics.ExternalMetricValue, metricTriggerList map[string]string, triggerActivity map[string]bool, fallbackActive bool, fallbackMetrics []external_metrics.ExternalMetricValue, cacheObj *cache.ScalersCache, updateDerivative bool, log logr.Logger) []external_metrics.ExternalMetricValue {
 	var err error
 	if so == nil || !so.IsUsingModifiers() {
 		return metrics
@@ -50,12 +53,20 @@ func HandleScalingModifiers(so *kedav1alpha1.ScaledObject, metrics []external_me
 	if !fallbackActive {
 		sm := so.Spec.Advanced.ScalingModifiers
 
-		// apply formula if defined
-		metrics, err = applyScalingModifiersFormula(sm, metrics, metricTriggerList, cacheObj)
-		if err != nil {
-			log.Error(err, "error applying custom scalingModifiers.Formula")
+		if sm.Derivative != "" {
+			metrics, err = calculateScalingModifiersDerivative(sm.Derivative, metrics, cacheObj, metricTriggerList, time.Now(), updateDerivative)
+			if err != nil {
+				log.Error(err, "error applying scalingModifiers.Derivative")
+			}
+			log.V(1).Info("returned metrics after derivative is applied", "metrics", metrics)
+		} else {
+			// apply formula if defined
+			metrics, err = applyScalingModifiersFormula(so, metrics, metricTriggerList, triggerActivity, cacheObj)
+			if err != nil {
+				log.Error(err, "error applying custom scalingModifiers.Formula")
+			}
+			log.V(1).Info("returned metrics after formula is applied", "metrics", metrics)
 		}
-		log.V(1).Info("returned metrics after formula is applied", "metrics", metrics)
 	} else if len(fallbackMetrics) > 0 {
 		metrics = []external_metrics.ExternalMetricValue{
 			{
@@ -79,9 +90,14 @@ func ArrayContainsElement(el string, arr []string) bool {
 
 // applyScalingModifiersFormula applies formula if formula is defined, otherwise
 // skip
-func applyScalingModifiersFormula(sm kedav1alpha1.ScalingModifiers, metrics []external_metrics.ExternalMetricValue, pairList map[string]string, cacheObj *cache.ScalersCache) ([]external_metrics.ExternalMetricValue, error) {
+func applyScalingModifiersFormula(so *kedav1alpha1.ScaledObject, metrics []external_metrics.ExternalMetricValue, pairList map[string]string, triggerActivity map[string]bool, cacheObj *cache.ScalersCache) ([]external_metrics.ExternalMetricValue, error) {
+	sm := so.Spec.Advanced.ScalingModifiers
 	if sm.Formula != "" {
-		metrics, err := calculateScalingModifiersFormula(metrics, cacheObj, pairList)
+		target, err := strconv.ParseFloat(sm.Target, 64)
+		if err != nil {
+			return metrics, fmt.Errorf("error parsing scalingModifiers.Target: %w", err)
+		}
+		metrics, err := calculateScalingModifiersFormula(metrics, cacheObj, pairList, triggerActivity, target, hasTriggerNamed(so, kedav1alpha1.ScalingModifiersBaselineFunction))
 		return metrics, err
 	}
 	return metrics, nil
@@ -89,17 +105,22 @@ func applyScalingModifiersFormula(sm kedav1alpha1.ScalingModifiers, metrics []ex
 
 // calculateScalingModifiersFormula creates custom composite metric & calculates
 // custom formula and returns this finalized metric
-func calculateScalingModifiersFormula(list []external_metrics.ExternalMetricValue, cacheObj *cache.ScalersCache, pairList map[string]string) ([]external_metrics.ExternalMetricValue, error) {
+func calculateScalingModifiersFormula(list []external_metrics.ExternalMetricValue, cacheObj *cache.ScalersCache, pairList map[string]string, triggerActivity map[string]bool, target float64, baselineIsTrigger bool) ([]external_metrics.ExternalMetricValue, error) {
 	var ret external_metrics.ExternalMetricValue
 	var out float64
 	ret.MetricName = kedav1alpha1.CompositeMetricName
 	ret.Timestamp = v1.Now()
 
 	// using https://github.com/antonmedv/expr to evaluate formula expression
-	data := make(map[string]float64)
+	data := make(map[string]any)
 	for _, v := range list {
 		data[pairList[v.MetricName]] = v.Value.AsApproximateFloat64()
 	}
+	if !baselineIsTrigger {
+		data[kedav1alpha1.ScalingModifiersBaselineFunction] = func(trigger string) (float64, error) {
+			return calculateBaseline(trigger, data, triggerActivity, target)
+		}
+	}
 
 	if cacheObj.CompiledFormula == nil {
 		return nil, fmt.Errorf("cached compiled formula is nil during its calculation")
@@ -117,12 +138,38 @@ func calculateScalingModifiersFormula(list []external_metrics.ExternalMetricValu
 	return []external_metrics.ExternalMetricValue{ret}, nil
 }
 
+// calculateBaseline returns the value of the trigger in units of the composite metric, so that the
+// replica count of e.g. a cron trigger becomes a floor of replicas, or 0 when the trigger isn't active
+// for the floor not to keep the ScaledObject active on its own
+func calculateBaseline(trigger string, data map[string]any, triggerActivity map[string]bool, target float64) (float64, error) {
+	value, found := data[trigger].(float64)
+	if !found {
+		return 0, fmt.Errorf("no metric found for trigger %q of the baseline", trigger)
+	}
+	if !triggerActivity[trigger] {
+		return 0, nil
+	}
+	return value * target, nil
+}
+
+// hasTriggerNamed determines whether the ScaledObject has a trigger with the given name and a metric in the formula,
+// cpu/memory triggers having none
+func hasTriggerNamed(so *kedav1alpha1.ScaledObject, name string) bool {
+	for _, trigger := range so.Spec.Triggers {
+		if trigger.Name == name && trigger.Type != "cpu" && trigger.Type != "memory" {
+			return true
+		}
+	}
+	return false
+}
+
 // GetPairTriggerAndMetric adds new pair of trigger-metric to the list for
 // scalingModifiers formula list thats needed to map the metric value to
-// trigger name. This is only ran if scalingModifiers.Formula is defined in SO.
+// trigger name. This is only ran if scalingModifiers.Formula or
+// scalingModifiers.Derivative is defined in SO.
 func GetPairTriggerAndMetric(so *kedav1alpha1.ScaledObject, metric string, trigger string) (map[string]string, error) {
 	list := map[string]string{}
-	if so.Spec.Advanced != nil && so.Spec.Advanced.ScalingModifiers.Formula != "" {
+	if so.Spec.Advanced != nil && (so.Spec.Advanced.ScalingModifiers.Formula != "" || so.Spec.Advanced.ScalingModifiers.Derivative != "") {
 		if trigger == "" {
 			return list, fmt.Errorf("trigger name not given with compositeScaler for metric %s", metric)
 		}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+func TestHandleScalingModifiersBaseline(t *testing.T) {
+	so := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				ScalingModifiers: kedav1alpha1.ScalingModifiers{Formula: `max(queue, baseline("office-hours"))`, Target: "10"},
+			},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Name: "queue", Type: "rabbitmq"},
+				{Name: "office-hours", Type: "cron"},
+			},
+		},
+	}
+	program, err := kedav1alpha1.ValidateAndCompileScalingModifiers(so)
+	if err != nil {
+		t.Fatal("Could not compile formula:", err)
+	}
+	cacheObj := &cache.ScalersCache{CompiledFormula: program}
+	pairs := map[string]string{"s0-rabbitmq-orders": "queue", "s1-cron": "office-hours"}
+
+	tests := []struct {
+		name          string
+		queue         float64
+		cronReplicas  float64
+		cronActive    bool
+		expectedValue float64
+	}{
+		// the cron trigger asks for 4 replicas, i.e. 4 times the target
+		{name: "baseline above queue", queue: 25, cronReplicas: 4, cronActive: true, expectedValue: 40},
+		{name: "queue above baseline", queue: 75, cronReplicas: 4, cronActive: true, expectedValue: 75},
+		// outside of its window the cron trigger reports 1, which mustn't keep a replica
+		{name: "cron not active", queue: 25, cronReplicas: 1, cronActive: false, expectedValue: 25},
+		{name: "nothing to do", queue: 0, cronReplicas: 1, cronActive: false, expectedValue: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			now := time.Now()
+			metrics := []external_metrics.ExternalMetricValue{
+				newTestMetric("s0-rabbitmq-orders", test.queue, now),
+				newTestMetric("s1-cron", test.cronReplicas, now),
+			}
+			activity := map[string]bool{"queue": test.queue > 0, "office-hours": test.cronActive}
+
+			result := HandleScalingModifiers(so, metrics, pairs, activity, false, nil, cacheObj, true, logr.Discard())
+			if assert.Len(t, result, 1) {
+				assert.Equal(t, kedav1alpha1.CompositeMetricName, result[0].MetricName)
+				assert.Equal(t, test.expectedValue, result[0].Value.AsApproximateFloat64())
+			}
+		})
+	}
+}
+
+func TestCalculateBaselineMissingMetric(t *testing.T) {
+	_, err := calculateBaseline("office-hours", map[string]any{"queue": float64(3)}, map[string]bool{"office-hours": true}, 10)
+	assert.ErrorContains(t, err, "no metric found for trigger \"office-hours\" of the baseline")
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"fmt"
+
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// ApplyTriggerWeight multiplies the metric values of the trigger at triggerIndex by its weight. As the HPA
+// scales on the max of the replicas desired by the metrics, which are proportional to the metric values
+// for both the AverageValue and Value metric types, the trigger weighs accordingly in the replica count.
+// The weights aren't applied with scalingModifiers, the formula composing the metrics itself
+func ApplyTriggerWeight(so *kedav1alpha1.ScaledObject, triggerIndex int, metrics []external_metrics.ExternalMetricValue) ([]external_metrics.ExternalMetricValue, error) {
+	if so == nil || so.IsUsingModifiers() || triggerIndex < 0 || triggerIndex >= len(so.Spec.Triggers) {
+		return metrics, nil
+	}
+	trigger := so.Spec.Triggers[triggerIndex]
+	if trigger.Weight == "" {
+		return metrics, nil
+	}
+	weight, err := trigger.GetWeight()
+	if err != nil {
+		return metrics, fmt.Errorf("error getting the weight of trigger %d: %w", triggerIndex, err)
+	}
+
+	weighted := make([]external_metrics.ExternalMetricValue, len(metrics))
+	for i, metric := range metrics {
+		weighted[i] = metric
+		weighted[i].Value.SetMilli(int64(metric.Value.AsApproximateFloat64() * weight * 1000))
+	}
+	return weighted, nil
+}
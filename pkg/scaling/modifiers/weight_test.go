@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// desiredReplicas returns the max of the replicas desired by the metrics with an AverageValue target,
+// like the HPA does when composing the metrics of several triggers
+func desiredReplicas(metrics []external_metrics.ExternalMetricValue, target float64) float64 {
+	replicas := 0.0
+	for _, metric := range metrics {
+		replicas = math.Max(replicas, math.Ceil(metric.Value.AsApproximateFloat64()/target))
+	}
+	return replicas
+}
+
+func TestApplyTriggerWeight(t *testing.T) {
+	tests := []struct {
+		name             string
+		queueWeight      string
+		backlogWeight    string
+		expectedReplicas float64
+	}{
+		// queue asks for 4 replicas, backlog for 6
+		{name: "no weights", expectedReplicas: 6},
+		{name: "backlog weighted down", backlogWeight: "0.5", expectedReplicas: 4},
+		{name: "queue weighted up", queueWeight: "2", expectedReplicas: 8},
+		{name: "both weighted", queueWeight: "0.5", backlogWeight: "0.25", expectedReplicas: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &kedav1alpha1.ScaledObject{
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					Triggers: []kedav1alpha1.ScaleTriggers{
+						{Name: "queue", Type: "rabbitmq", Weight: test.queueWeight},
+						{Name: "backlog", Type: "kafka", Weight: test.backlogWeight},
+					},
+				},
+			}
+			now := time.Now()
+
+			var composed []external_metrics.ExternalMetricValue
+			for i, value := range []float64{40, 60} {
+				metrics, err := ApplyTriggerWeight(so, i, []external_metrics.ExternalMetricValue{newTestMetric("metric", value, now)})
+				assert.NoError(t, err)
+				composed = append(composed, metrics...)
+			}
+			assert.Equal(t, test.expectedReplicas, desiredReplicas(composed, 10))
+		})
+	}
+}
+
+func TestApplyTriggerWeightWithScalingModifiers(t *testing.T) {
+	so := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				ScalingModifiers: kedav1alpha1.ScalingModifiers{Formula: "queue", Target: "10"},
+			},
+			Triggers: []kedav1alpha1.ScaleTriggers{{Name: "queue", Type: "rabbitmq", Weight: "2"}},
+		},
+	}
+
+	metrics, err := ApplyTriggerWeight(so, 0, []external_metrics.ExternalMetricValue{newTestMetric("metric", 40, time.Now())})
+	assert.NoError(t, err)
+	assert.Equal(t, 40.0, metrics[0].Value.AsApproximateFloat64())
+}
+
+func TestApplyTriggerWeightInvalid(t *testing.T) {
+	so := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Triggers: []kedav1alpha1.ScaleTriggers{{Name: "queue", Type: "rabbitmq", Weight: "0"}},
+		},
+	}
+
+	_, err := ApplyTriggerWeight(so, 0, []external_metrics.ExternalMetricValue{newTestMetric("metric", 40, time.Now())})
+	assert.ErrorContains(t, err, "weight \"0\" must be a number greater than 0")
+}
@@ -181,6 +181,11 @@ func (vh *HashicorpVaultHandler) Read(path string) (*vaultapi.Secret, error) {
 	return vh.client.Logical().Read(path)
 }
 
+// List is used to get the keys at a path from vault List api. (e.g. leases)
+func (vh *HashicorpVaultHandler) List(path string) (*vaultapi.Secret, error) {
+	return vh.client.Logical().List(path)
+}
+
 // Write is used to get a secret from vault that needs to pass along data and uses the vault Write api. (e.g. pki)
 func (vh *HashicorpVaultHandler) Write(path string, data map[string]interface{}) (*vaultapi.Secret, error) {
 	return vh.client.Logical().Write(path, data)
@@ -26,7 +26,10 @@ import (
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"knative.dev/pkg/apis/duck"
@@ -94,7 +97,7 @@ func ResolveScaleTargetPodSpec(ctx context.Context, kubeClient client.Client, sc
 		}
 
 		gvk := obj.Status.ScaleTargetGVKR.GroupVersionKind()
-		objKey := client.ObjectKey{Namespace: obj.Namespace, Name: obj.Spec.ScaleTargetRef.Name}
+		objKey := client.ObjectKey{Namespace: obj.Namespace, Name: obj.ScaleTargetName()}
 
 		logger := log.WithValues("scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name, "resource", gvk.String(), "name", objKey.Name)
 
@@ -118,6 +121,13 @@ func ResolveScaleTargetPodSpec(ctx context.Context, kubeClient client.Client, sc
 			}
 			podTemplateSpec.ObjectMeta = statefulSet.ObjectMeta
 			podTemplateSpec.Spec = statefulSet.Spec.Template.Spec
+		case obj.Status.ScaleTargetGVKR.IsArgoRollout():
+			template, err := resolveArgoRolloutPodTemplate(ctx, kubeClient, gvk, objKey)
+			if err != nil {
+				logger.Error(err, "error resolving the pod template of the target rollout")
+				return nil, "", err
+			}
+			podTemplateSpec = *template
 		default:
 			unstruct := &unstructured.Unstructured{}
 			unstruct.SetGroupVersionKind(gvk)
@@ -135,7 +145,7 @@ func ResolveScaleTargetPodSpec(ctx context.Context, kubeClient client.Client, sc
 		}
 
 		if len(podTemplateSpec.Spec.Containers) == 0 {
-			logger.V(1).Info("There aren't any containers found in the ScaleTarget, therefore it is no possible to inject environment properties", "scaleTargetRef.Name", obj.Spec.ScaleTargetRef.Name)
+			logger.V(1).Info("There aren't any containers found in the ScaleTarget, therefore it is no possible to inject environment properties", "scaleTargetRef.Name", obj.ScaleTargetName())
 			return nil, "", nil
 		}
 
@@ -147,6 +157,43 @@ func ResolveScaleTargetPodSpec(ctx context.Context, kubeClient client.Client, sc
 	}
 }
 
+// resolveArgoRolloutPodTemplate returns the pod template of an Argo Rollout, which is either in the Rollout
+// or in the Deployment referenced by its spec.workloadRef
+func resolveArgoRolloutPodTemplate(ctx context.Context, kubeClient client.Client, gvk schema.GroupVersionKind, objKey client.ObjectKey) (*corev1.PodTemplateSpec, error) {
+	rollout := &unstructured.Unstructured{}
+	rollout.SetGroupVersionKind(gvk)
+	if err := kubeClient.Get(ctx, objKey, rollout); err != nil {
+		return nil, err
+	}
+
+	podTemplateSpec := &corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Name: rollout.GetName(), Namespace: rollout.GetNamespace()}}
+	workloadRef, found, err := unstructured.NestedStringMap(rollout.Object, "spec", "workloadRef")
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec.workloadRef of rollout %s: %w", objKey.Name, err)
+	}
+	if found {
+		if workloadRef["kind"] != "Deployment" {
+			return nil, fmt.Errorf("unsupported kind %q of the spec.workloadRef of rollout %s", workloadRef["kind"], objKey.Name)
+		}
+		deployment := &appsv1.Deployment{}
+		if err := kubeClient.Get(ctx, client.ObjectKey{Namespace: objKey.Namespace, Name: workloadRef["name"]}, deployment); err != nil {
+			return nil, fmt.Errorf("error getting the deployment %s referenced by rollout %s: %w", workloadRef["name"], objKey.Name, err)
+		}
+		podTemplateSpec.Spec = deployment.Spec.Template.Spec
+		return podTemplateSpec, nil
+	}
+
+	template, found, err := unstructured.NestedMap(rollout.Object, "spec", "template")
+	if err != nil || !found {
+		return podTemplateSpec, nil
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template, podTemplateSpec); err != nil {
+		return nil, fmt.Errorf("error parsing the pod template of rollout %s: %w", objKey.Name, err)
+	}
+	podTemplateSpec.Name, podTemplateSpec.Namespace = rollout.GetName(), rollout.GetNamespace()
+	return podTemplateSpec, nil
+}
+
 // ResolveContainerEnv resolves all environment variables in a container.
 // It returns either map of env variable key and value or error if there is any.
 func ResolveContainerEnv(ctx context.Context, client client.Client, logger logr.Logger, podSpec *corev1.PodSpec, containerName, namespace string, secretsLister corev1listers.SecretLister) (map[string]string, error) {
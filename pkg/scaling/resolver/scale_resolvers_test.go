@@ -23,9 +23,12 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"go.uber.org/mock/gomock"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/scheme"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -871,3 +874,76 @@ func TestEnvWithRestrictedNamespace(t *testing.T) {
 		})
 	}
 }
+
+func newTestArgoRollout(name string, spec map[string]interface{}) *unstructured.Unstructured {
+	rollout := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	rollout.SetGroupVersionKind(schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"})
+	rollout.SetName(name)
+	rollout.SetNamespace(namespace)
+	return rollout
+}
+
+func TestResolveScaleTargetPodSpecArgoRollout(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: namespace},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "from-workload-ref"}}}},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		rollout           *unstructured.Unstructured
+		expectedContainer string
+		expectedErr       bool
+	}{
+		{
+			name: "pod template of the rollout",
+			rollout: newTestArgoRollout("rollout", map[string]interface{}{
+				"template": map[string]interface{}{"spec": map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "from-template"}}}},
+			}),
+			expectedContainer: "from-template",
+		},
+		{
+			name: "pod template of the workloadRef",
+			rollout: newTestArgoRollout("rollout", map[string]interface{}{
+				"workloadRef": map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "workload"},
+			}),
+			expectedContainer: "from-workload-ref",
+		},
+		{
+			name: "missing deployment of the workloadRef",
+			rollout: newTestArgoRollout("rollout", map[string]interface{}{
+				"workloadRef": map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "missing"},
+			}),
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(deployment, test.rollout).Build()
+			scaledObject := &kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{Name: "so", Namespace: namespace},
+				Spec:       kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "rollout"}},
+				Status: kedav1alpha1.ScaledObjectStatus{
+					ScaleTargetGVKR: &kedav1alpha1.GroupVersionKindResource{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout", Resource: "rollouts"},
+				},
+			}
+
+			podTemplateSpec, _, err := ResolveScaleTargetPodSpec(context.Background(), kubeClient, scaledObject)
+			if test.expectedErr {
+				if err == nil {
+					t.Error("Expected error but got success")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			if name := podTemplateSpec.Spec.Containers[0].Name; name != test.expectedContainer {
+				t.Errorf("Expected container %s but got %s", test.expectedContainer, name)
+			}
+		})
+	}
+}
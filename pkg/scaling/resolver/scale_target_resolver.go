@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// ResolveScaleTargetName returns the name of the only workload of the given kind in the namespace matching
+// the selector of a scaleTargetRef, or an error if none or several of them match
+func ResolveScaleTargetName(ctx context.Context, kubeClient client.Client, gvk schema.GroupVersionKind, namespace string, labelSelector *metav1.LabelSelector) (string, error) {
+	if !kedav1alpha1.IsScaleTargetSelectorSupported(gvk.GroupKind()) {
+		return "", fmt.Errorf("scaleTargetRef.selector isn't supported for %s", gvk.GroupKind())
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return "", fmt.Errorf("invalid scaleTargetRef.selector: %w", err)
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := kubeClient.List(ctx, list, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return "", fmt.Errorf("error listing %s matching scaleTargetRef.selector %q: %w", gvk.Kind, selector, err)
+	}
+
+	switch len(list.Items) {
+	case 0:
+		return "", fmt.Errorf("no %s matches scaleTargetRef.selector %q", gvk.Kind, selector)
+	case 1:
+		return list.Items[0].GetName(), nil
+	default:
+		names := make([]string, 0, len(list.Items))
+		for _, item := range list.Items {
+			names = append(names, item.GetName())
+		}
+		return "", fmt.Errorf("scaleTargetRef.selector %q must match exactly one %s, but it matches %s", selector, gvk.Kind, strings.Join(names, ", "))
+	}
+}
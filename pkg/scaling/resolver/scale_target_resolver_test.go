@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestDeployment(name, ns string, labels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, Labels: labels}}
+}
+
+func TestResolveScaleTargetName(t *testing.T) {
+	deploymentGVK := appsv1.SchemeGroupVersion.WithKind("Deployment")
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-app"}}
+
+	tests := []struct {
+		name           string
+		existing       []runtime.Object
+		expectedName   string
+		expectedErrMsg string
+	}{
+		{
+			name: "unique match",
+			existing: []runtime.Object{
+				newTestDeployment("my-app", namespace, map[string]string{"app": "my-app"}),
+				newTestDeployment("other-app", namespace, map[string]string{"app": "other-app"}),
+				newTestDeployment("my-app", "other-namespace", map[string]string{"app": "my-app"}),
+			},
+			expectedName: "my-app",
+		},
+		{
+			name: "no match",
+			existing: []runtime.Object{
+				newTestDeployment("other-app", namespace, map[string]string{"app": "other-app"}),
+				newTestDeployment("my-app", "other-namespace", map[string]string{"app": "my-app"}),
+			},
+			expectedErrMsg: "no Deployment matches scaleTargetRef.selector \"app=my-app\"",
+		},
+		{
+			name: "ambiguous match",
+			existing: []runtime.Object{
+				newTestDeployment("my-app-blue", namespace, map[string]string{"app": "my-app"}),
+				newTestDeployment("my-app-green", namespace, map[string]string{"app": "my-app"}),
+			},
+			expectedErrMsg: "scaleTargetRef.selector \"app=my-app\" must match exactly one Deployment, but it matches my-app-blue, my-app-green",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(test.existing...).Build()
+
+			name, err := ResolveScaleTargetName(context.Background(), kubeClient, deploymentGVK, namespace, selector)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedName, name)
+			} else {
+				assert.EqualError(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
+
+func TestResolveScaleTargetNameWithoutScaleSubresource(t *testing.T) {
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-app"}}
+
+	_, err := ResolveScaleTargetName(context.Background(), kubeClient, corev1.SchemeGroupVersion.WithKind("Pod"), namespace, selector)
+	assert.EqualError(t, err, "scaleTargetRef.selector isn't supported for Pod")
+}
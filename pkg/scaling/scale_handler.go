@@ -27,12 +27,14 @@ import (
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/scale"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/metrics/pkg/apis/external_metrics"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -49,6 +51,7 @@ import (
 	"github.com/kedacore/keda/v2/pkg/scaling/modifiers"
 	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
 	"github.com/kedacore/keda/v2/pkg/scaling/scaledjob"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 )
 
 var log = logf.Log.WithName("scale_handler")
@@ -60,37 +63,60 @@ type ScaleHandler interface {
 	DeleteScalableObject(ctx context.Context, scalableObject interface{}) error
 	GetScalersCache(ctx context.Context, scalableObject interface{}) (*cache.ScalersCache, error)
 	ClearScalersCache(ctx context.Context, scalableObject interface{}) error
+	RequestImmediatePoll(ctx context.Context, scalableObject interface{}) error
 
 	GetScaledObjectMetrics(ctx context.Context, scaledObjectName, scaledObjectNamespace, metricName string) (*external_metrics.ExternalMetricValueList, error)
+	GetScaledObjectTriggerStates(scaledObjectName, scaledObjectNamespace string) ([]TriggerState, bool)
 }
 
 type scaleHandler struct {
 	client                   client.Client
 	scaleLoopContexts        *sync.Map
+	scaleLoopPollRequests    *sync.Map
+	reconcileNowValues       *sync.Map
 	scaleExecutor            executor.ScaleExecutor
 	globalHTTPTimeout        time.Duration
 	recorder                 record.EventRecorder
 	scalerCaches             map[string]*cache.ScalersCache
 	scalerCachesLock         *sync.RWMutex
 	scaledObjectsMetricCache metricscache.MetricsCache
+	triggerStates            triggerStateStore
 	secretsLister            corev1listers.SecretLister
+	scaledObjectDefaults     *kedav1alpha1.ScaledObjectDefaults
+	// actuationLimiter is shared with the scaleExecutor, nil for no limit
+	actuationLimiter *executor.ActuationLimiter
+	// clock tells when the triggers with their own pollingInterval are due, the real time when nil
+	clock clock.PassiveClock
 }
 
 // NewScaleHandler creates a ScaleHandler object
-func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, recorder record.EventRecorder, secretsLister corev1listers.SecretLister) ScaleHandler {
+func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, recorder record.EventRecorder, secretsLister corev1listers.SecretLister, scaledObjectDefaults *kedav1alpha1.ScaledObjectDefaults, statusUpdateMinInterval time.Duration, actuationLimiter *executor.ActuationLimiter) ScaleHandler {
 	return &scaleHandler{
 		client:                   client,
 		scaleLoopContexts:        &sync.Map{},
-		scaleExecutor:            executor.NewScaleExecutor(client, scaleClient, reconcilerScheme, recorder),
+		scaleLoopPollRequests:    &sync.Map{},
+		reconcileNowValues:       &sync.Map{},
+		scaleExecutor:            executor.NewScaleExecutor(client, scaleClient, reconcilerScheme, recorder, statusUpdateMinInterval, actuationLimiter),
 		globalHTTPTimeout:        globalHTTPTimeout,
 		recorder:                 recorder,
 		scalerCaches:             map[string]*cache.ScalersCache{},
 		scalerCachesLock:         &sync.RWMutex{},
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 		secretsLister:            secretsLister,
+		scaledObjectDefaults:     scaledObjectDefaults,
+		actuationLimiter:         actuationLimiter,
+		clock:                    clock.RealClock{},
 	}
 }
 
+// now returns the current time of the clock of the handler
+func (h *scaleHandler) now() time.Time {
+	if h.clock == nil {
+		return time.Now()
+	}
+	return h.clock.Now()
+}
+
 /// --------------------------------------------------------------------------- ///
 /// ----------            Scaling logic related methods               --------- ///
 /// --------------------------------------------------------------------------- ///
@@ -121,14 +147,49 @@ func (h *scaleHandler) HandleScalableObject(ctx context.Context, scalableObject
 	// a mutex is used to synchronize scale requests per scalableObject
 	scalingMutex := &sync.Mutex{}
 
+	// the new ScaleLoop polls right away, so the current reconcile-now value is already handled
+	pollRequests := make(chan struct{}, 1)
+	h.scaleLoopPollRequests.Store(key, pollRequests)
+	h.reconcileNowValues.Store(key, withTriggers.GetAnnotations()[kedav1alpha1.ReconcileNowAnnotation])
+
 	// passing deep copy of ScaledObject/ScaledJob to the scaleLoop go routines, it's a precaution to not have global objects shared between threads
 	switch obj := scalableObject.(type) {
 	case *kedav1alpha1.ScaledObject:
 		go h.startPushScalers(ctx, withTriggers, obj.DeepCopy(), scalingMutex)
-		go h.startScaleLoop(ctx, withTriggers, obj.DeepCopy(), scalingMutex, true)
+		go h.startScaleLoop(ctx, withTriggers, obj.DeepCopy(), scalingMutex, pollRequests, true)
 	case *kedav1alpha1.ScaledJob:
 		go h.startPushScalers(ctx, withTriggers, obj.DeepCopy(), scalingMutex)
-		go h.startScaleLoop(ctx, withTriggers, obj.DeepCopy(), scalingMutex, false)
+		go h.startScaleLoop(ctx, withTriggers, obj.DeepCopy(), scalingMutex, pollRequests, false)
+	}
+	return nil
+}
+
+// RequestImmediatePoll makes the ScaleLoop of the scalableObject poll its scalers right away, without waiting
+// for the pollingInterval, when the value of its reconcile-now annotation changed since the last request
+func (h *scaleHandler) RequestImmediatePoll(_ context.Context, scalableObject interface{}) error {
+	withTriggers, err := kedav1alpha1.AsDuckWithTriggers(scalableObject)
+	if err != nil {
+		log.Error(err, "error duck typing object into withTrigger", "scalableObject", scalableObject)
+		return err
+	}
+
+	key := withTriggers.GenerateIdentifier()
+	value := withTriggers.GetAnnotations()[kedav1alpha1.ReconcileNowAnnotation]
+	previous, loaded := h.reconcileNowValues.Swap(key, value)
+	if value == "" || (loaded && previous == value) {
+		return nil
+	}
+
+	pollRequests, ok := h.scaleLoopPollRequests.Load(key)
+	if !ok {
+		log.V(1).Info("ScalableObject was not found in controller cache", "key", key)
+		return nil
+	}
+	select {
+	case pollRequests.(chan struct{}) <- struct{}{}:
+		log.V(1).Info("Requested an immediate poll", "key", key, "reconcileNow", value)
+	default:
+		// a poll is already pending
 	}
 	return nil
 }
@@ -149,6 +210,9 @@ func (h *scaleHandler) DeleteScalableObject(ctx context.Context, scalableObject
 			cancel()
 		}
 		h.scaleLoopContexts.Delete(key)
+		h.scaleLoopPollRequests.Delete(key)
+		h.reconcileNowValues.Delete(key)
+		h.triggerStates.Delete(key)
 		err := h.ClearScalersCache(ctx, scalableObject)
 		if err != nil {
 			log.Error(err, "error clearing scalers cache", "scalableObject", scalableObject, "key", key)
@@ -161,14 +225,20 @@ func (h *scaleHandler) DeleteScalableObject(ctx context.Context, scalableObject
 	return nil
 }
 
-// startScaleLoop blocks forever and checks the scalableObject based on its pollingInterval
-func (h *scaleHandler) startScaleLoop(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, scalableObject interface{}, scalingMutex sync.Locker, isScaledObject bool) {
+// startScaleLoop blocks forever and checks the scalableObject based on its pollingInterval,
+// or right away when an immediate poll is requested on pollRequests
+func (h *scaleHandler) startScaleLoop(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, scalableObject interface{}, scalingMutex sync.Locker, pollRequests <-chan struct{}, isScaledObject bool) {
 	logger := log.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
 
 	pollingInterval := withTriggers.GetPollingInterval()
+	if isScaledObject {
+		// the triggers with their own pollingInterval are polled by the loop once their interval elapsed
+		pollingInterval = withTriggers.GetScaleLoopInterval()
+	}
 	logger.V(1).Info("Watching with pollingInterval", "PollingInterval", pollingInterval)
 
 	next := time.Now()
+	var consecutiveErrors int32
 
 	for {
 		// we calculate the next execution time based on the pollingInterval and record the difference
@@ -176,14 +246,27 @@ func (h *scaleHandler) startScaleLoop(ctx context.Context, withTriggers *kedav1a
 		delay := time.Since(next)
 		metricscollector.RecordScalableObjectLatency(withTriggers.Namespace, withTriggers.Name, isScaledObject, delay)
 
-		tmr := time.NewTimer(pollingInterval)
-		next = time.Now().Add(pollingInterval)
+		start := time.Now()
+		isError := h.checkScalers(ctx, scalableObject, scalingMutex)
+		interval := pollingInterval
+		if isScaledObject {
+			consecutiveErrors, interval = h.updatePollingBackoff(ctx, scalableObject, scalingMutex, pollingInterval, consecutiveErrors, isError)
+		}
 
-		h.checkScalers(ctx, scalableObject, scalingMutex)
+		tmr := time.NewTimer(time.Until(start.Add(interval)))
+		next = start.Add(interval)
+
+		h.updateNextPollTime(ctx, scalableObject, scalingMutex, next)
 
 		select {
 		case <-tmr.C:
 			tmr.Stop()
+		case <-pollRequests:
+			logger.V(1).Info("Polling immediately as requested by the reconcile-now annotation")
+			if cache, err := h.GetScalersCache(ctx, scalableObject); err == nil {
+				cache.ResetTriggerPolls()
+			}
+			tmr.Stop()
 		case <-ctx.Done():
 			logger.V(1).Info("Context canceled")
 			err := h.ClearScalersCache(ctx, scalableObject)
@@ -229,8 +312,27 @@ func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav
 }
 
 // checkScalers contains the main logic for the ScaleHandler scaling logic.
-// It'll check each trigger active status then call RequestScale
-func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interface{}, scalingMutex sync.Locker) {
+// It'll check each trigger active status then call RequestScale, and returns if a trigger failed
+func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interface{}, scalingMutex sync.Locker) bool {
+	isError, scaledObjectIdentifier := h.checkScalersLocked(ctx, scalableObject, scalingMutex)
+	if scaledObjectIdentifier == "" {
+		return isError
+	}
+
+	// an activation or deactivation deferred by the actuation limiter is waited for without holding the scaling lock,
+	// then the ScaledObject is checked again to actuate it on its current state rather than the one before the wait
+	waited, err := h.actuationLimiter.WaitDeferred(ctx, scaledObjectIdentifier)
+	if err != nil || !waited {
+		return isError
+	}
+	defer h.actuationLimiter.Release(scaledObjectIdentifier)
+	isError, _ = h.checkScalersLocked(ctx, scalableObject, scalingMutex)
+	return isError
+}
+
+// checkScalersLocked checks the scalers of the object holding its scaling lock, it returns whether they are
+// in error and the identifier of the object if it's a ScaledObject
+func (h *scaleHandler) checkScalersLocked(ctx context.Context, scalableObject interface{}, scalingMutex sync.Locker) (bool, string) {
 	scalingMutex.Lock()
 	defer scalingMutex.Unlock()
 	switch obj := scalableObject.(type) {
@@ -238,12 +340,13 @@ func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interfac
 		err := h.client.Get(ctx, types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}, obj)
 		if err != nil {
 			log.Error(err, "error getting scaledObject", "object", scalableObject)
-			return
+			return false, ""
 		}
+		h.scaledObjectDefaults.Apply(obj)
 		isActive, isError, metricsRecords, activeTriggers, err := h.getScaledObjectState(ctx, obj)
 		if err != nil {
 			log.Error(err, "error getting state of scaledObject", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name)
-			return
+			return true, ""
 		}
 
 		h.scaleExecutor.RequestScale(ctx, obj, isActive, isError, &executor.ScaleExecutorOptions{ActiveTriggers: activeTriggers})
@@ -252,16 +355,152 @@ func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interfac
 			log.V(1).Info("Storing metrics to cache", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name, "metricsRecords", metricsRecords)
 			h.scaledObjectsMetricCache.StoreRecords(obj.GenerateIdentifier(), metricsRecords)
 		}
+		return isError, obj.GenerateIdentifier()
 	case *kedav1alpha1.ScaledJob:
 		err := h.client.Get(ctx, types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}, obj)
 		if err != nil {
 			log.Error(err, "error getting scaledJob", "scaledJob.Namespace", obj.Namespace, "scaledJob.Name", obj.Name)
-			return
+			return false, ""
+		}
+
+		isActive, isError, scaleTo, maxScale, options := h.isScaledJobActive(ctx, obj)
+		h.scaleExecutor.RequestJobScale(ctx, obj, isActive, isError, scaleTo, maxScale, options)
+		return isError, ""
+	}
+	return false, ""
+}
+
+const (
+	defaultErrorBackoffFailureThreshold = 3
+	defaultErrorBackoffMaxInterval      = 300 * time.Second
+)
+
+// updatePollingBackoff counts the consecutive failed polls of the ScaledObject and returns the count along with
+// the polling interval backed off accordingly. The backoff is recorded in the status when it changes
+func (h *scaleHandler) updatePollingBackoff(ctx context.Context, scalableObject interface{}, scalingMutex sync.Locker, pollingInterval time.Duration, consecutiveErrors int32, isError bool) (int32, time.Duration) {
+	scaledObject, ok := scalableObject.(*kedav1alpha1.ScaledObject)
+	if !ok {
+		return 0, pollingInterval
+	}
+
+	// the first successful poll resets the backoff
+	consecutiveErrors++
+	if !isError {
+		consecutiveErrors = 0
+	}
+
+	scalingMutex.Lock()
+	defer scalingMutex.Unlock()
+
+	var errorBackoff *kedav1alpha1.ErrorBackoff
+	if scaledObject.Spec.Advanced != nil {
+		errorBackoff = scaledObject.Spec.Advanced.ErrorBackoff
+	}
+	interval := getErrorBackoffInterval(errorBackoff, pollingInterval, consecutiveErrors)
+
+	// the status is only patched when the effective interval changes, not on every failed poll
+	statusInterval := pollingInterval
+	if scaledObject.Status.PollingBackoff != nil {
+		statusInterval = time.Duration(scaledObject.Status.PollingBackoff.Interval) * time.Second
+	}
+	if interval == statusInterval {
+		return consecutiveErrors, interval
+	}
+
+	var backoff *kedav1alpha1.PollingBackoffStatus
+	if interval != pollingInterval {
+		backoff = &kedav1alpha1.PollingBackoffStatus{ConsecutiveErrors: consecutiveErrors, Interval: int32(interval / time.Second)}
+	}
+
+	if backoff != nil {
+		log.V(1).Info("Backing off the polling interval after consecutive failed polls", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "consecutiveErrors", consecutiveErrors, "interval", interval)
+	} else {
+		log.V(1).Info("Polling interval isn't backed off anymore", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+	}
+	transform := func(runtimeObj client.Object, target interface{}) error {
+		backoff, ok := target.(*kedav1alpha1.PollingBackoffStatus)
+		if !ok {
+			return fmt.Errorf("transform target is not PollingBackoffStatus type %v", target)
+		}
+		runtimeObj.(*kedav1alpha1.ScaledObject).Status.PollingBackoff = backoff
+		return nil
+	}
+	if err := kedastatus.TransformObject(ctx, h.client, log, scaledObject, backoff, transform); err != nil {
+		log.Error(err, "error updating pollingBackoff", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+	}
+	return consecutiveErrors, interval
+}
+
+// getErrorBackoffInterval returns the polling interval after consecutiveErrors failed polls, it is doubled
+// on each failed poll from the failureThreshold on, up to the maxInterval
+func getErrorBackoffInterval(errorBackoff *kedav1alpha1.ErrorBackoff, pollingInterval time.Duration, consecutiveErrors int32) time.Duration {
+	if errorBackoff == nil {
+		return pollingInterval
+	}
+	failureThreshold := errorBackoff.FailureThreshold
+	if failureThreshold <= 0 {
+		failureThreshold = defaultErrorBackoffFailureThreshold
+	}
+	maxInterval := defaultErrorBackoffMaxInterval
+	if errorBackoff.MaxInterval > 0 {
+		maxInterval = time.Duration(errorBackoff.MaxInterval) * time.Second
+	}
+
+	interval := pollingInterval
+	for i := failureThreshold; i <= consecutiveErrors && interval < maxInterval; i++ {
+		interval *= 2
+	}
+	if interval > maxInterval {
+		// the backoff never shortens the polling interval
+		return max(maxInterval, pollingInterval)
+	}
+	return interval
+}
+
+// nextPollTimeMinUpdateInterval is the minimum difference between the stored and the new nextPollTime
+// of a ScaledObject for the status to be patched, so short polling intervals don't cause a write on every poll
+const nextPollTimeMinUpdateInterval = 30 * time.Second
+
+// updateNextPollTime records the time of the next poll in the ScaledObject status, if it changed meaningfully
+func (h *scaleHandler) updateNextPollTime(ctx context.Context, scalableObject interface{}, scalingMutex sync.Locker, next time.Time) {
+	scaledObject, ok := scalableObject.(*kedav1alpha1.ScaledObject)
+	if !ok {
+		return
+	}
+
+	scalingMutex.Lock()
+	defer scalingMutex.Unlock()
+
+	if !shouldUpdateNextPollTime(scaledObject.Status.NextPollTime, next) {
+		return
+	}
+
+	nextPollTime := metav1.NewTime(next.Truncate(time.Second))
+	transform := func(runtimeObj client.Object, target interface{}) error {
+		nextPollTime, ok := target.(*metav1.Time)
+		if !ok {
+			return fmt.Errorf("transform target is not metav1.Time type %v", target)
 		}
+		runtimeObj.(*kedav1alpha1.ScaledObject).Status.NextPollTime = nextPollTime
+		return nil
+	}
+	if err := kedastatus.TransformObject(ctx, h.client, log, scaledObject, &nextPollTime, transform); err != nil {
+		log.Error(err, "error updating nextPollTime", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+	}
+}
 
-		isActive, isError, scaleTo, maxScale := h.isScaledJobActive(ctx, obj)
-		h.scaleExecutor.RequestJobScale(ctx, obj, isActive, isError, scaleTo, maxScale)
+// shouldUpdateNextPollTime returns true if the stored nextPollTime is missing, moved earlier
+// or is at least nextPollTimeMinUpdateInterval behind the new one
+func shouldUpdateNextPollTime(stored *metav1.Time, next time.Time) bool {
+	if stored == nil {
+		return true
+	}
+	// metav1.Time is serialized with second precision
+	next = next.Truncate(time.Second)
+	if next.Before(stored.Time) {
+		return true
 	}
+	return next.Sub(stored.Time) >= nextPollTimeMinUpdateInterval
 }
 
 /// --------------------------------------------------------------------------- ///
@@ -318,6 +557,7 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 				log.Error(err, "failed to get ScaledObject", "name", scalableObjectName, "namespace", scalableObjectNamespace)
 				return nil, err
 			}
+			h.scaledObjectDefaults.Apply(scaledObject)
 			scalableObject = scaledObject
 		case "ScaledJob":
 			scaledJob := &kedav1alpha1.ScaledJob{}
@@ -363,7 +603,7 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 	}
 	switch obj := scalableObject.(type) {
 	case *kedav1alpha1.ScaledObject:
-		if obj.Spec.Advanced != nil && obj.Spec.Advanced.ScalingModifiers.Formula != "" {
+		if obj.Spec.Advanced != nil && (obj.Spec.Advanced.ScalingModifiers.Formula != "" || obj.Spec.Advanced.ScalingModifiers.Derivative != "") {
 			// validate scalingModifiers struct and compile formula
 			program, err := kedav1alpha1.ValidateAndCompileScalingModifiers(obj)
 			if err != nil {
@@ -371,6 +611,9 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 				return nil, err
 			}
 			newCache.CompiledFormula = program
+			if obj.Spec.Advanced.ScalingModifiers.Derivative != "" {
+				newCache.Derivative = &cache.MetricDerivative{}
+			}
 		}
 		newCache.ScaledObject = obj
 	default:
@@ -388,6 +631,7 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 	}
 
 	h.scalerCaches[key] = newCache
+	h.triggerStates.Prune(withTriggers.GenerateIdentifier(), withTriggers.Spec.Triggers)
 	return h.scalerCaches[key], nil
 }
 
@@ -417,6 +661,23 @@ func (h *scaleHandler) ClearScalersCache(ctx context.Context, scalableObject int
 /// ----------             ScaledObject related methods               --------- ///
 /// --------------------------------------------------------------------------- ///
 
+// recordFallbackTransition emits an event and counts the fallback of the trigger being activated or cleared
+func recordFallbackTransition(recorder record.EventRecorder, scaledObject *kedav1alpha1.ScaledObject, triggerName string, active bool, scalerErr error) {
+	if active {
+		recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.FallbackActivated,
+			"Trigger %s is failing, falling back to %d replicas: %v", triggerName, scaledObject.Spec.Fallback.Replicas, scalerErr)
+	} else {
+		recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.FallbackCleared, "Trigger %s isn't falling back anymore", triggerName)
+	}
+	metricscollector.RecordScaledObjectFallback(scaledObject.Namespace, scaledObject.Name, triggerName, active)
+}
+
+// GetScaledObjectTriggerStates returns the state of the last poll of every trigger of a ScaledObject identified by its name and namespace,
+// the second return value is false if the ScaledObject hasn't been polled by this instance.
+func (h *scaleHandler) GetScaledObjectTriggerStates(scaledObjectName, scaledObjectNamespace string) ([]TriggerState, bool) {
+	return h.triggerStates.Read(kedav1alpha1.GenerateIdentifier("ScaledObject", scaledObjectNamespace, scaledObjectName))
+}
+
 // GetScaledObjectMetrics returns metrics for specified metric name for a ScaledObject identified by its name and namespace.
 // It could either query the metric value directly from the scaler or from a cache, that's being stored for the scaler.
 func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectName, scaledObjectNamespace, metricsName string) (*external_metrics.ExternalMetricValueList, error) {
@@ -449,6 +710,7 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 		logger.Error(err, "error getting true metrics array, probably because of invalid cache")
 	}
 	metricTriggerPairList := make(map[string]string)
+	triggerActivity := make(map[string]bool)
 	isFallbackActive := false
 
 	// let's check metrics for all scalers in a ScaledObject
@@ -458,6 +720,7 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 	type metricResult struct {
 		metrics           []external_metrics.ExternalMetricValue
 		metricTriggerPair map[string]string
+		isActive          bool
 		metricName        string
 		triggerName       string
 		triggerIndex      int
@@ -507,21 +770,23 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 						logger.Error(err, "error pairing triggers & metrics for compositeScaler")
 					}
 					var metrics []external_metrics.ExternalMetricValue
+					var isActive bool
 
 					// if cache is defined for this scaler/metric, let's try to hit it first
 					metricsFoundInCache := false
-					if scalerConfig.TriggerUseCachedMetrics {
+					if usesCachedMetrics(scaledObject, scalerConfig) {
 						var metricsRecord metricscache.MetricsRecord
 						if metricsRecord, metricsFoundInCache = h.scaledObjectsMetricCache.ReadRecord(scaledObjectIdentifier, metricName); metricsFoundInCache {
 							logger.V(1).Info("Reading metrics from cache", "scaler", triggerName, "metricName", metricName, "metricsRecord", metricsRecord)
 							metrics = metricsRecord.Metric
+							isActive = metricsRecord.IsActive
 							err = metricsRecord.ScalerError
 						}
 					}
 
 					if !metricsFoundInCache {
 						var latency time.Duration
-						metrics, _, latency, err = cache.GetMetricsAndActivityForScaler(ctx, triggerIndex, metricName)
+						metrics, isActive, latency, err = cache.GetMetricsAndActivityForScaler(ctx, triggerIndex, metricName)
 						if latency != -1 {
 							metricscollector.RecordScalerLatency(scaledObjectNamespace, scaledObject.Name, triggerName, triggerIndex, metricName, true, latency)
 						}
@@ -532,6 +797,7 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 					result.triggerIndex = triggerIndex
 					result.metricSpec = spec
 					result.metrics = metrics
+					result.isActive = isActive
 					result.err = err
 					results <- result
 					wg.Done()
@@ -546,20 +812,33 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 		for key, value := range result.metricTriggerPair {
 			metricTriggerPairList[key] = value
 		}
+		// a trigger exposing several metrics is active as soon as one of them is
+		triggerActivity[result.triggerName] = triggerActivity[result.triggerName] || (result.isActive && result.err == nil)
 		// check if we need to set a fallback
+		wasFallbackActive := fallback.IsFallbackActive(scaledObject, result.metricName)
 		metrics, fallbackActive, err := fallback.GetMetricsWithFallback(ctx, h.client, result.metrics, result.err, result.metricName, scaledObject, result.metricSpec)
+		if fallbackActive != wasFallbackActive {
+			recordFallbackTransition(cache.Recorder, scaledObject, result.triggerName, fallbackActive, result.err)
+		}
 		if err != nil {
 			isScalerError = true
 			logger.Error(err, "error getting metric for trigger", "trigger", result.triggerName)
 		} else {
 			for _, metric := range metrics {
 				metricValue := metric.Value.AsApproximateFloat64()
-				metricscollector.RecordScalerMetric(scaledObjectNamespace, scaledObjectName, result.triggerName, result.triggerIndex, metric.MetricName, true, metricValue)
+				metricscollector.RecordScalerMetric(scaledObjectNamespace, scaledObjectName, result.triggerName, scalerConfigs[result.triggerIndex].TriggerType, scalerConfigs[result.triggerIndex].TriggerName, result.triggerIndex, metric.MetricName, true, metricValue)
 			}
 		}
 		if fallbackActive {
 			isFallbackActive = true
 			fallbackMetrics = append(fallbackMetrics, metrics...)
+		} else if err == nil {
+			// the fallback replicas aren't weighted
+			metrics, err = modifiers.ApplyTriggerWeight(scaledObject, result.triggerIndex, metrics)
+			if err != nil {
+				isScalerError = true
+				logger.Error(err, "error weighting metric for trigger", "trigger", result.triggerName)
+			}
 		}
 		metricscollector.RecordScalerError(scaledObjectNamespace, scaledObjectName, result.triggerName, result.triggerIndex, result.metricName, true, err)
 		matchingMetrics = append(matchingMetrics, metrics...)
@@ -584,7 +863,7 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 	}
 
 	// handle scalingModifiers here and simply return the matchingMetrics
-	matchingMetrics = modifiers.HandleScalingModifiers(scaledObject, matchingMetrics, metricTriggerPairList, isFallbackActive, fallbackMetrics, cache, logger)
+	matchingMetrics = modifiers.HandleScalingModifiers(scaledObject, matchingMetrics, metricTriggerPairList, triggerActivity, isFallbackActive, fallbackMetrics, cache, false, logger)
 	return &external_metrics.ExternalMetricValueList{
 		Items: matchingMetrics,
 	}, nil
@@ -602,6 +881,7 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	isScaledObjectError := false
 	metricsRecord := map[string]metricscache.MetricsRecord{}
 	metricTriggerPairList := make(map[string]string)
+	triggerActivity := make(map[string]bool)
 	var matchingMetrics []external_metrics.ExternalMetricValue
 	var activeTriggers []string
 
@@ -627,7 +907,22 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	allScalers, scalerConfigs := cache.GetScalers()
 	results := make(chan scalerState, len(allScalers))
 	wg := sync.WaitGroup{}
+	hasTriggerIntervals := hasTriggerPollingIntervals(scaledObject)
+	now := h.now()
 	for scalerIndex := 0; scalerIndex < len(allScalers); scalerIndex++ {
+		// when the triggers have their own cadence, the state of those which aren't due
+		// is the one of their last poll, they're polled anyway if it isn't cached
+		if hasTriggerIntervals {
+			interval := getTriggerPollingInterval(scaledObject, scalerConfigs[scalerIndex])
+			if interval > 0 && !cache.IsTriggerPollDue(scalerIndex, interval, now) {
+				if state, found := h.getCachedScalerState(ctx, allScalers[scalerIndex], scalerIndex, scalerConfigs[scalerIndex], cache, scaledObject); found {
+					logger.V(1).Info("Using the metrics of the last poll of the trigger", "scaler", state.TriggerName)
+					results <- state
+					continue
+				}
+			}
+		}
+
 		wg.Add(1)
 		go func(scaler scalers.Scaler, index int, scalerConfig scalersconfig.ScalerConfig, results chan scalerState, wg *sync.WaitGroup) {
 			results <- h.getScalerState(ctx, scaler, index, scalerConfig, cache, logger, scaledObject)
@@ -648,6 +943,7 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 		for k, v := range result.Pairs {
 			metricTriggerPairList[k] = v
 		}
+		triggerActivity[result.TriggerName] = result.IsActive
 		for k, v := range result.Records {
 			metricsRecord[k] = v
 		}
@@ -666,7 +962,7 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	}
 
 	// apply scaling modifiers
-	matchingMetrics = modifiers.HandleScalingModifiers(scaledObject, matchingMetrics, metricTriggerPairList, false, nil, cache, logger)
+	matchingMetrics = modifiers.HandleScalingModifiers(scaledObject, matchingMetrics, metricTriggerPairList, triggerActivity, false, nil, cache, true, logger)
 
 	// when we are using formula, we need to reevaluate if it's active here
 	if scaledObject.IsUsingModifiers() {
@@ -685,8 +981,8 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 
 			for _, metric := range matchingMetrics {
 				value := metric.Value.AsApproximateFloat64()
-				metricscollector.RecordScalerMetric(scaledObject.Namespace, scaledObject.Name, kedav1alpha1.CompositeMetricName, 0, metric.MetricName, true, value)
-				metricscollector.RecordScalerActive(scaledObject.Namespace, scaledObject.Name, kedav1alpha1.CompositeMetricName, 0, metric.MetricName, true, value > activationValue)
+				metricscollector.RecordScalerMetric(scaledObject.Namespace, scaledObject.Name, kedav1alpha1.CompositeMetricName, "", "", 0, metric.MetricName, true, value)
+				metricscollector.RecordScalerActive(scaledObject.Namespace, scaledObject.Name, kedav1alpha1.CompositeMetricName, "", "", 0, metric.MetricName, true, value > activationValue)
 				if !isScaledObjectActive {
 					isScaledObjectActive = value > activationValue
 
@@ -724,7 +1020,7 @@ type scalerState struct {
 // for an specific scaler. The state contains if it's active or
 // with erros, but also the records for the cache and he metrics
 // for the custom formulas
-func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler, triggerIndex int, scalerConfig scalersconfig.ScalerConfig,
+func (h *scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler, triggerIndex int, scalerConfig scalersconfig.ScalerConfig,
 	cache *cache.ScalersCache, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) scalerState {
 	result := scalerState{
 		IsActive:    false,
@@ -735,10 +1031,7 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 		Records:     map[string]metricscache.MetricsRecord{},
 	}
 
-	result.TriggerName = strings.Replace(fmt.Sprintf("%T", scaler), "*scalers.", "", 1)
-	if scalerConfig.TriggerName != "" {
-		result.TriggerName = scalerConfig.TriggerName
-	}
+	result.TriggerName = getTriggerName(scaler, scalerConfig)
 
 	metricSpecs, err := cache.GetMetricSpecForScalingForScaler(ctx, triggerIndex)
 	if err != nil {
@@ -749,6 +1042,18 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 
 	for _, spec := range metricSpecs {
 		if spec.External == nil {
+			// the metrics of cpu/memory triggers are read by the HPA, only their activity is taken into account
+			if resourceName := getResourceMetricName(spec); resourceName != "" {
+				_, isMetricActive, _, err := cache.GetMetricsAndActivityForScaler(ctx, triggerIndex, resourceName)
+				if err != nil {
+					result.Err = err
+					logger.Error(err, "error getting scale decision", "scaler", result.TriggerName)
+					cache.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
+				} else if isMetricActive {
+					result.IsActive = true
+					logger.V(1).Info("Scaler for scaledObject is active", "scaler", result.TriggerName, "metricName", resourceName)
+				}
+			}
 			continue
 		}
 
@@ -763,7 +1068,7 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 		result.Metrics = append(result.Metrics, metrics...)
 		logger.V(1).Info("Getting metrics and activity from scaler", "scaler", result.TriggerName, "metricName", metricName, "metrics", metrics, "activity", isMetricActive, "scalerError", err)
 
-		if scalerConfig.TriggerUseCachedMetrics {
+		if usesCachedMetrics(scaledObject, scalerConfig) {
 			result.Records[metricName] = metricscache.MetricsRecord{
 				IsActive:    isMetricActive,
 				Metric:      metrics,
@@ -781,10 +1086,11 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 				cache.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
 			}
 		} else {
-			result.IsActive = isMetricActive
+			// a trigger exposing several metrics is active as soon as one of them is
+			result.IsActive = result.IsActive || isMetricActive
 			for _, metric := range metrics {
 				metricValue := metric.Value.AsApproximateFloat64()
-				metricscollector.RecordScalerMetric(scaledObject.Namespace, scaledObject.Name, result.TriggerName, triggerIndex, metric.MetricName, true, metricValue)
+				metricscollector.RecordScalerMetric(scaledObject.Namespace, scaledObject.Name, result.TriggerName, scalerConfig.TriggerType, scalerConfig.TriggerName, triggerIndex, metric.MetricName, true, metricValue)
 			}
 			if !scaledObject.IsUsingModifiers() {
 				if isMetricActive {
@@ -795,7 +1101,7 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 						logger.V(1).Info("Scaler for scaledObject is active", "scaler", result.TriggerName, "metricName", spec.Resource.Name)
 					}
 				}
-				metricscollector.RecordScalerActive(scaledObject.Namespace, scaledObject.Name, result.TriggerName, triggerIndex, metricName, true, isMetricActive)
+				metricscollector.RecordScalerActive(scaledObject.Namespace, scaledObject.Name, result.TriggerName, scalerConfig.TriggerType, scalerConfig.TriggerName, triggerIndex, metricName, true, isMetricActive)
 			}
 		}
 
@@ -804,9 +1110,100 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 			logger.Error(err, "error pairing triggers & metrics for compositeScaler")
 		}
 	}
+
+	h.triggerStates.Store(scaledObject.GenerateIdentifier(), newTriggerState(triggerIndex, scalerConfig.TriggerType, result, time.Now()))
 	return result
 }
 
+// getCachedScalerState returns the state of a trigger from the metrics cached by its last poll,
+// the second return value is false if any of its metrics isn't cached or failed
+func (h *scaleHandler) getCachedScalerState(ctx context.Context, scaler scalers.Scaler, triggerIndex int, scalerConfig scalersconfig.ScalerConfig,
+	cache *cache.ScalersCache, scaledObject *kedav1alpha1.ScaledObject) (scalerState, bool) {
+	result := scalerState{
+		TriggerName: getTriggerName(scaler, scalerConfig),
+		Metrics:     []external_metrics.ExternalMetricValue{},
+		Pairs:       map[string]string{},
+		Records:     map[string]metricscache.MetricsRecord{},
+	}
+
+	metricSpecs, err := cache.GetMetricSpecForScalingForScaler(ctx, triggerIndex)
+	if err != nil {
+		return result, false
+	}
+	for _, spec := range metricSpecs {
+		if spec.External == nil {
+			return result, false
+		}
+
+		metricName := spec.External.Metric.Name
+		record, found := h.scaledObjectsMetricCache.ReadRecord(scaledObject.GenerateIdentifier(), metricName)
+		if !found || record.ScalerError != nil {
+			return result, false
+		}
+		result.Metrics = append(result.Metrics, record.Metric...)
+		result.IsActive = result.IsActive || record.IsActive
+		// the records are stored again, so they're kept until the next poll
+		result.Records[metricName] = record
+
+		result.Pairs, err = modifiers.GetPairTriggerAndMetric(scaledObject, metricName, scalerConfig.TriggerName)
+		if err != nil {
+			return result, false
+		}
+	}
+	return result, true
+}
+
+// getTriggerName returns the name of the trigger, or the type of its scaler if it isn't named
+func getTriggerName(scaler scalers.Scaler, scalerConfig scalersconfig.ScalerConfig) string {
+	if scalerConfig.TriggerName != "" {
+		return scalerConfig.TriggerName
+	}
+	return strings.Replace(fmt.Sprintf("%T", scaler), "*scalers.", "", 1)
+}
+
+// hasTriggerPollingIntervals returns whether any trigger of the ScaledObject has its own pollingInterval
+func hasTriggerPollingIntervals(scaledObject *kedav1alpha1.ScaledObject) bool {
+	for _, trigger := range scaledObject.Spec.Triggers {
+		if trigger.PollingInterval != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// getTriggerPollingInterval returns the interval between the polls of a trigger of a ScaledObject whose
+// triggers have their own cadence, zero for cpu/memory triggers which are checked on every loop
+func getTriggerPollingInterval(scaledObject *kedav1alpha1.ScaledObject, scalerConfig scalersconfig.ScalerConfig) time.Duration {
+	if scalerConfig.TriggerType == "cpu" || scalerConfig.TriggerType == "memory" {
+		return 0
+	}
+	if scalerConfig.TriggerPollingInterval > 0 {
+		return scalerConfig.TriggerPollingInterval
+	}
+	withTriggers, err := kedav1alpha1.AsDuckWithTriggers(scaledObject)
+	if err != nil {
+		return 0
+	}
+	return withTriggers.GetPollingInterval()
+}
+
+// usesCachedMetrics returns whether the HPA is served the metrics of a trigger from its last poll by the scale loop,
+// which is the case when the trigger opts in or when the triggers of the ScaledObject have their own cadence
+func usesCachedMetrics(scaledObject *kedav1alpha1.ScaledObject, scalerConfig scalersconfig.ScalerConfig) bool {
+	return scalerConfig.TriggerUseCachedMetrics || hasTriggerPollingIntervals(scaledObject)
+}
+
+// getResourceMetricName returns the name of the resource of a cpu/memory metric spec
+func getResourceMetricName(spec v2.MetricSpec) string {
+	switch {
+	case spec.Resource != nil:
+		return string(spec.Resource.Name)
+	case spec.ContainerResource != nil:
+		return string(spec.ContainerResource.Name)
+	}
+	return ""
+}
+
 // / --------------------------------------------------------------------------- ///
 // / ----------             ScaledJob related methods               --------- ///
 // / --------------------------------------------------------------------------- ///
@@ -824,9 +1221,9 @@ func (h *scaleHandler) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 	}
 	var isError bool
 	var scalersMetrics []scaledjob.ScalerMetrics
-	scalers, scalerConfigs := cache.GetScalers()
-	for scalerIndex, scaler := range scalers {
-		scalerName := strings.Replace(fmt.Sprintf("%T", scalers[scalerIndex]), "*scalers.", "", 1)
+	scalersList, scalerConfigs := cache.GetScalers()
+	for scalerIndex, scaler := range scalersList {
+		scalerName := strings.Replace(fmt.Sprintf("%T", scalersList[scalerIndex]), "*scalers.", "", 1)
 		if scalerConfigs[scalerIndex].TriggerName != "" {
 			scalerName = scalerConfigs[scalerIndex].TriggerName
 		}
@@ -858,18 +1255,30 @@ func (h *scaleHandler) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 			if isTriggerActive {
 				isActive = true
 			}
-			queueLength, maxValue, targetAverageValue := scaledjob.CalculateQueueLengthAndMaxValue(metrics, metricSpecs, scaledJob.MaxReplicaCount())
+			queueLength, maxValue, targetAverageValue := scaledjob.CalculateQueueLengthAndMaxValue(metrics, []v2.MetricSpec{spec}, scaledJob.MaxReplicaCount())
 
-			scalerLogger.V(1).Info("Scaler Metric value", "isTriggerActive", isTriggerActive, metricSpecs[0].External.Metric.Name, queueLength, "targetAverageValue", targetAverageValue)
+			scalerLogger.V(1).Info("Scaler Metric value", "isTriggerActive", isTriggerActive, metricName, queueLength, "targetAverageValue", targetAverageValue)
+
+			var priorities []int64
+			if priorityScaler, ok := scaler.(scalers.PriorityScaler); ok && isTriggerActive && scaledJob.Spec.ScalingStrategy.JobCreationOrder == kedav1alpha1.JobCreationOrderPriority {
+				priorities, err = priorityScaler.GetPriorities(ctx, metricName)
+				if err != nil {
+					// the jobs are still created, just not ordered by priority
+					scalerLogger.Error(err, "Error getting scaler priorities, but continue")
+					priorities = nil
+				}
+			}
 
 			scalersMetrics = append(scalersMetrics, scaledjob.ScalerMetrics{
+				MetricName:  metricName,
 				QueueLength: queueLength,
 				MaxValue:    maxValue,
 				IsActive:    isActive,
+				Priorities:  priorities,
 			})
 			for _, metric := range metrics {
 				metricValue := metric.Value.AsApproximateFloat64()
-				metricscollector.RecordScalerMetric(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metric.MetricName, false, metricValue)
+				metricscollector.RecordScalerMetric(scaledJob.Namespace, scaledJob.Name, scalerName, scalerConfigs[scalerIndex].TriggerType, scalerConfigs[scalerIndex].TriggerName, scalerIndex, metric.MetricName, false, metricValue)
 			}
 
 			if isTriggerActive {
@@ -882,7 +1291,7 @@ func (h *scaleHandler) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 			}
 
 			metricscollector.RecordScalerError(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metricName, false, err)
-			metricscollector.RecordScalerActive(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metricName, false, isTriggerActive)
+			metricscollector.RecordScalerActive(scaledJob.Namespace, scaledJob.Name, scalerName, scalerConfigs[scalerIndex].TriggerType, scalerConfigs[scalerIndex].TriggerName, scalerIndex, metricName, false, isTriggerActive)
 		}
 	}
 	return scalersMetrics, isError
@@ -890,16 +1299,25 @@ func (h *scaleHandler) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 
 // isScaledJobActive returns whether the input ScaledJob:
 // is active as the first return value,
-// the second and the third return values indicate queueLength and maxValue for scale
-func (h *scaleHandler) isScaledJobActive(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (bool, bool, int64, int64) {
+// the third and the fourth return values indicate queueLength and maxValue for scale,
+// the last return value contains the names of the metrics and the priorities of the active triggers
+func (h *scaleHandler) isScaledJobActive(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (bool, bool, int64, int64, *executor.ScaleExecutorOptions) {
 	logger := logf.Log.WithName("scalemetrics")
 
 	scalersMetrics, isError := h.getScaledJobMetrics(ctx, scaledJob)
 	isActive, queueLength, maxValue, maxFloatValue :=
 		scaledjob.IsScaledJobActive(scalersMetrics, scaledJob.Spec.ScalingStrategy.MultipleScalersCalculation, scaledJob.MinReplicaCount(), scaledJob.MaxReplicaCount())
 
+	options := &executor.ScaleExecutorOptions{}
+	for _, metrics := range scalersMetrics {
+		if metrics.IsActive {
+			options.ActiveMetricNames = append(options.ActiveMetricNames, metrics.MetricName)
+			options.Priorities = append(options.Priorities, metrics.Priorities...)
+		}
+	}
+
 	logger.V(1).WithValues("scaledJob.Name", scaledJob.Name).Info("Checking if ScaleJob Scalers are active", "isActive", isActive, "maxValue", maxFloatValue, "MultipleScalersCalculation", scaledJob.Spec.ScalingStrategy.MultipleScalersCalculation)
-	return isActive, isError, queueLength, maxValue
+	return isActive, isError, queueLength, maxValue, options
 }
 
 // getTrueMetricArray is a help function made for composite scaler to determine
@@ -25,7 +25,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/expr-lang/expr"
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	appsv1 "k8s.io/api/apps/v1"
@@ -34,18 +36,28 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/metrics/pkg/apis/external_metrics"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_externalscaler"
 	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_scaling/mock_executor"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
+	"github.com/kedacore/keda/v2/pkg/scaling/executor"
 )
 
 const testNamespaceGlobal = "testNamespace"
@@ -141,6 +153,150 @@ func TestGetScaledObjectMetrics_DirectCall(t *testing.T) {
 	scalerCache.Close(context.Background())
 }
 
+func TestGetScaledObjectMetrics_MetricNameOverride(t *testing.T) {
+	scaledObjectName := testNameGlobal
+	scaledObjectNamespace := testNamespaceGlobal
+
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+
+	newScalerBuilder := func(generatedMetricName string, metricNameOverride string, value int64) cache.ScalerBuilder {
+		scaler := mock_scalers.NewMockScaler(ctrl)
+		scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(10, generatedMetricName)}).AnyTimes()
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), generatedMetricName).Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili(generatedMetricName, float64(value))}, true, nil).AnyTimes()
+		scalerConfig := scalersconfig.ScalerConfig{TriggerMetricName: metricNameOverride}
+		return cache.ScalerBuilder{
+			Scaler:       scaler,
+			ScalerConfig: scalerConfig,
+			Factory: func() (scalers.Scaler, *scalersconfig.ScalerConfig, error) {
+				return scaler, &scalerConfig, nil
+			},
+		}
+	}
+
+	// the metric names don't depend on the order of the triggers, even if the generated ones do
+	triggerOrders := map[string][]cache.ScalerBuilder{
+		"original order": {
+			newScalerBuilder("s0-prometheus", "http-requests", 5),
+			newScalerBuilder("s1-kafka", "orders-lag", 7),
+		},
+		"reordered": {
+			newScalerBuilder("s0-kafka", "orders-lag", 7),
+			newScalerBuilder("s1-prometheus", "http-requests", 5),
+		},
+	}
+
+	for name, scalerBuilders := range triggerOrders {
+		t.Run(name, func(t *testing.T) {
+			scaledObject := kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      scaledObjectName,
+					Namespace: scaledObjectNamespace,
+				},
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+						Name: "test",
+					},
+				},
+			}
+
+			scalerCache := cache.ScalersCache{
+				ScaledObject: &scaledObject,
+				Scalers:      scalerBuilders,
+				Recorder:     recorder,
+			}
+
+			sh := scaleHandler{
+				scalerCaches:             map[string]*cache.ScalersCache{scaledObject.GenerateIdentifier(): &scalerCache},
+				scalerCachesLock:         &sync.RWMutex{},
+				scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+			}
+
+			var metricNames []string
+			for _, spec := range scalerCache.GetMetricSpecForScaling(context.TODO()) {
+				metricNames = append(metricNames, spec.External.Metric.Name)
+			}
+			assert.ElementsMatch(t, []string{"http-requests", "orders-lag"}, metricNames)
+
+			metrics, err := sh.GetScaledObjectMetrics(context.TODO(), scaledObjectName, scaledObjectNamespace, "orders-lag")
+			assert.NoError(t, err)
+			if assert.Len(t, metrics.Items, 1) {
+				assert.Equal(t, "orders-lag", metrics.Items[0].MetricName)
+				assert.Equal(t, int64(7000), metrics.Items[0].Value.MilliValue())
+			}
+		})
+	}
+}
+
+func TestGetScaledObjectMetrics_MultipleMetricsPerTrigger(t *testing.T) {
+	scaledObjectName := testNameGlobal
+	scaledObjectNamespace := testNamespaceGlobal
+
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+
+	// a single trigger, e.g. an external scaler, exposing two metrics
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{
+		createMetricSpec(10, "s0-queue-length"),
+		createMetricSpec(200, "s0-processing-time"),
+	}).AnyTimes()
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "s0-queue-length").Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-queue-length", 42)}, true, nil).AnyTimes()
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "s0-processing-time").Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-processing-time", 350)}, false, nil).AnyTimes()
+	scalerConfig := scalersconfig.ScalerConfig{}
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      scaledObjectName,
+			Namespace: scaledObjectNamespace,
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+		},
+	}
+
+	scalerCache := cache.ScalersCache{
+		ScaledObject: &scaledObject,
+		Scalers: []cache.ScalerBuilder{{
+			Scaler:       scaler,
+			ScalerConfig: scalerConfig,
+			Factory: func() (scalers.Scaler, *scalersconfig.ScalerConfig, error) {
+				return scaler, &scalerConfig, nil
+			},
+		}},
+		Recorder: recorder,
+	}
+
+	sh := scaleHandler{
+		scalerCaches:             map[string]*cache.ScalersCache{scaledObject.GenerateIdentifier(): &scalerCache},
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	var metricNames []string
+	for _, spec := range scalerCache.GetMetricSpecForScaling(context.TODO()) {
+		metricNames = append(metricNames, spec.External.Metric.Name)
+	}
+	assert.ElementsMatch(t, []string{"s0-queue-length", "s0-processing-time"}, metricNames)
+
+	for metricName, expected := range map[string]int64{"s0-queue-length": 42000, "s0-processing-time": 350000} {
+		metrics, err := sh.GetScaledObjectMetrics(context.TODO(), scaledObjectName, scaledObjectNamespace, metricName)
+		assert.NoError(t, err)
+		if assert.Len(t, metrics.Items, 1) {
+			assert.Equal(t, metricName, metrics.Items[0].MetricName)
+			assert.Equal(t, expected, metrics.Items[0].Value.MilliValue())
+		}
+	}
+
+	// the trigger is active as soon as one of its metrics is
+	state := sh.getScalerState(context.TODO(), scaler, 0, scalerConfig, &scalerCache, logr.Discard(), &scaledObject)
+	assert.NoError(t, state.Err)
+	assert.True(t, state.IsActive)
+	assert.Len(t, state.Metrics, 2)
+}
+
 func TestGetScaledObjectMetrics_FromCache(t *testing.T) {
 	scaledObjectName := "testName2"
 	scaledObjectNamespace := "testNamespace2"
@@ -229,6 +385,126 @@ func TestGetScaledObjectMetrics_FromCache(t *testing.T) {
 	scalerCache.Close(context.Background())
 }
 
+func TestCheckScaledObjectTriggerPollingIntervals(t *testing.T) {
+	fastMetricName := "fast-metric"
+	slowMetricName := "slow-metric"
+
+	ctrl := gomock.NewController(t)
+	fakeClock := clocktesting.NewFakePassiveClock(time.Now())
+	recorder := record.NewFakeRecorder(1)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
+
+	fastScaler := mock_scalers.NewMockScaler(ctrl)
+	slowScaler := mock_scalers.NewMockScaler(ctrl)
+	fastScalerConfig := scalersconfig.ScalerConfig{TriggerName: "fast", TriggerIndex: 0, TriggerPollingInterval: time.Second}
+	slowScalerConfig := scalersconfig.ScalerConfig{TriggerName: "slow", TriggerIndex: 1, TriggerPollingInterval: 3 * time.Second}
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testNameGlobal,
+			Namespace: testNamespaceGlobal,
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Name: "fast", Type: "cron", PollingInterval: ptr.To[int32](1)},
+				{Name: "slow", Type: "prometheus", PollingInterval: ptr.To[int32](3)},
+			},
+		},
+	}
+
+	scalerCache := cache.ScalersCache{
+		ScaledObject: &scaledObject,
+		Scalers: []cache.ScalerBuilder{{
+			Scaler:       fastScaler,
+			ScalerConfig: fastScalerConfig,
+			Factory: func() (scalers.Scaler, *scalersconfig.ScalerConfig, error) {
+				return fastScaler, &fastScalerConfig, nil
+			},
+		}, {
+			Scaler:       slowScaler,
+			ScalerConfig: slowScalerConfig,
+			Factory: func() (scalers.Scaler, *scalersconfig.ScalerConfig, error) {
+				return slowScaler, &slowScalerConfig, nil
+			},
+		}},
+		Recorder: recorder,
+	}
+
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &scalerCache
+
+	sh := scaleHandler{
+		client:                   mockClient,
+		scaleLoopContexts:        &sync.Map{},
+		scaleExecutor:            mockExecutor,
+		globalHTTPTimeout:        time.Duration(1000),
+		recorder:                 recorder,
+		scalerCaches:             caches,
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+		clock:                    fakeClock,
+	}
+
+	fastPolls, slowPolls := 0, 0
+	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	fastScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(10, fastMetricName)}).AnyTimes()
+	slowScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(10, slowMetricName)}).AnyTimes()
+	fastScaler.EXPECT().GetMetricsAndActivity(gomock.Any(), fastMetricName).DoAndReturn(func(context.Context, string) ([]external_metrics.ExternalMetricValue, bool, error) {
+		fastPolls++
+		return []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili(fastMetricName, float64(fastPolls))}, true, nil
+	}).AnyTimes()
+	slowScaler.EXPECT().GetMetricsAndActivity(gomock.Any(), slowMetricName).DoAndReturn(func(context.Context, string) ([]external_metrics.ExternalMetricValue, bool, error) {
+		slowPolls++
+		return []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili(slowMetricName, float64(slowPolls*100))}, false, nil
+	}).AnyTimes()
+
+	// both triggers are polled by the first loop
+	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{})
+	assert.Equal(t, 1, fastPolls)
+	assert.Equal(t, 1, slowPolls)
+
+	// none of the triggers is due yet
+	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{})
+	assert.Equal(t, 1, fastPolls)
+	assert.Equal(t, 1, slowPolls)
+
+	// only the fast trigger is due each second
+	fakeClock.SetTime(fakeClock.Now().Add(time.Second))
+	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{})
+	assert.Equal(t, 2, fastPolls)
+	assert.Equal(t, 1, slowPolls)
+
+	fakeClock.SetTime(fakeClock.Now().Add(time.Second))
+	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{})
+	assert.Equal(t, 3, fastPolls)
+	assert.Equal(t, 1, slowPolls)
+
+	// the HPA is served the metrics of the last polls
+	metrics, err := sh.GetScaledObjectMetrics(context.TODO(), testNameGlobal, testNamespaceGlobal, slowMetricName)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(100), metrics.Items[0].Value.AsApproximateFloat64())
+	metrics, err = sh.GetScaledObjectMetrics(context.TODO(), testNameGlobal, testNamespaceGlobal, fastMetricName)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(3), metrics.Items[0].Value.AsApproximateFloat64())
+	assert.Equal(t, 3, fastPolls)
+	assert.Equal(t, 1, slowPolls)
+
+	// an immediate poll makes all the triggers due
+	scalerCache.ResetTriggerPolls()
+	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{})
+	assert.Equal(t, 4, fastPolls)
+	assert.Equal(t, 2, slowPolls)
+
+	fastScaler.EXPECT().Close(gomock.Any())
+	slowScaler.EXPECT().Close(gomock.Any())
+	scalerCache.Close(context.Background())
+}
+
 // TestGetScaledObjectMetrics_InParallel executes
 // a request to multiple scalers with a delay.
 // The sum off all the scalers is more than the timeout
@@ -628,6 +904,266 @@ func TestCheckScaledObjectFindFirstActiveNotIgnoreOthers(t *testing.T) {
 	assert.Equal(t, []string{"*mock_scalers.MockScaler"}, activeTriggers)
 }
 
+func TestGetScaledObjectTriggerStates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(5)
+
+	activeScaler := mock_scalers.NewMockScaler(ctrl)
+	activeScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(1, "s0-queue")})
+	activeScaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{{
+		MetricName: "s0-queue",
+		Value:      *resource.NewQuantity(5, resource.DecimalSI),
+	}}, true, nil)
+	activeScaler.EXPECT().Close(gomock.Any())
+
+	failingScaler := mock_scalers.NewMockScaler(ctrl)
+	failingScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(1, "s1-api")})
+	failingScaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{}, false, errors.New("some error"))
+	failingScaler.EXPECT().Close(gomock.Any())
+	failingConfig := scalersconfig.ScalerConfig{TriggerType: "metrics-api", TriggerIndex: 1}
+	failingFactory := func() (scalers.Scaler, *scalersconfig.ScalerConfig, error) {
+		scaler := mock_scalers.NewMockScaler(ctrl)
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{}, false, errors.New("some error"))
+		scaler.EXPECT().Close(gomock.Any())
+		return scaler, &failingConfig, nil
+	}
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+		},
+	}
+
+	scalerCache := cache.ScalersCache{
+		Scalers: []cache.ScalerBuilder{{
+			Scaler:       activeScaler,
+			ScalerConfig: scalersconfig.ScalerConfig{TriggerName: "queue", TriggerType: "rabbitmq", TriggerIndex: 0},
+		}, {
+			Scaler:       failingScaler,
+			ScalerConfig: failingConfig,
+			Factory:      failingFactory,
+		}},
+		Recorder: recorder,
+	}
+
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &scalerCache
+
+	sh := scaleHandler{
+		scaleLoopContexts:        &sync.Map{},
+		scaleLoopPollRequests:    &sync.Map{},
+		reconcileNowValues:       &sync.Map{},
+		recorder:                 recorder,
+		scalerCaches:             caches,
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	_, found := sh.GetScaledObjectTriggerStates("test", "test")
+	assert.False(t, found)
+
+	before := time.Now()
+	sh.getScaledObjectState(context.TODO(), &scaledObject)
+
+	states, found := sh.GetScaledObjectTriggerStates("test", "test")
+	assert.True(t, found)
+	assert.Len(t, states, 2)
+
+	assert.Equal(t, 0, states[0].TriggerIndex)
+	assert.Equal(t, "queue", states[0].TriggerName)
+	assert.Equal(t, "rabbitmq", states[0].TriggerType)
+	assert.True(t, states[0].IsActive)
+	assert.Equal(t, []TriggerMetricValue{{MetricName: "s0-queue", Value: 5}}, states[0].Metrics)
+	assert.Empty(t, states[0].LastError)
+	assert.False(t, states[0].LastPollTime.Before(before))
+
+	assert.Equal(t, 1, states[1].TriggerIndex)
+	assert.Equal(t, "metrics-api", states[1].TriggerType)
+	assert.False(t, states[1].IsActive)
+	assert.Empty(t, states[1].Metrics)
+	assert.Equal(t, "some error", states[1].LastError)
+
+	_, found = sh.GetScaledObjectTriggerStates("other", "test")
+	assert.False(t, found)
+
+	// the states are dropped with the ScaledObject
+	sh.scaleLoopContexts.Store(scaledObject.GenerateIdentifier(), context.CancelFunc(func() {}))
+	assert.NoError(t, sh.DeleteScalableObject(context.TODO(), &scaledObject))
+	_, found = sh.GetScaledObjectTriggerStates("test", "test")
+	assert.False(t, found)
+}
+
+func TestPruneTriggerStates(t *testing.T) {
+	var store triggerStateStore
+	store.Store("so", TriggerState{TriggerIndex: 0, TriggerName: "queue", TriggerType: "rabbitmq"})
+	store.Store("so", TriggerState{TriggerIndex: 1, TriggerName: "kafkaScaler", TriggerType: "kafka"})
+	store.Store("so", TriggerState{TriggerIndex: 2, TriggerName: "cronScaler", TriggerType: "cron"})
+
+	// the rabbitmq trigger has been renamed and the cron trigger removed
+	store.Prune("so", []kedav1alpha1.ScaleTriggers{{Type: "rabbitmq", Name: "orders"}, {Type: "kafka"}})
+	states, found := store.Read("so")
+	assert.True(t, found)
+	assert.Equal(t, []TriggerState{{TriggerIndex: 1, TriggerName: "kafkaScaler", TriggerType: "kafka"}}, states)
+
+	// the triggers have been reordered
+	store.Prune("so", []kedav1alpha1.ScaleTriggers{{Type: "cron"}, {Type: "rabbitmq"}})
+	_, found = store.Read("so")
+	assert.False(t, found)
+}
+
+func TestCheckScaledObjectIgnoredExternalScalerFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
+	recorder := record.NewFakeRecorder(1)
+
+	address := mock_externalscaler.StartFailingExternalScaler(t)
+
+	activeFactory := func() (scalers.Scaler, *scalersconfig.ScalerConfig, error) {
+		scaler := mock_scalers.NewMockScaler(ctrl)
+		scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(1, "metric-name")})
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{}, true, nil)
+		scaler.EXPECT().Close(gomock.Any())
+		return scaler, &scalersconfig.ScalerConfig{}, nil
+	}
+	activeScaler, _, err := activeFactory()
+	assert.NoError(t, err)
+
+	ignoredConfig := &scalersconfig.ScalerConfig{
+		ScalableObjectName:      "test",
+		ScalableObjectNamespace: "test",
+		TriggerMetadata:         map[string]string{"scalerAddress": address, "failureMode": "ignore"},
+		TriggerIndex:            1,
+		MetricType:              v2.AverageValueMetricType,
+	}
+	ignoredFactory := func() (scalers.Scaler, *scalersconfig.ScalerConfig, error) {
+		scaler, err := scalers.NewExternalScaler(ignoredConfig)
+		return scaler, ignoredConfig, err
+	}
+	ignoredScaler, _, err := ignoredFactory()
+	assert.NoError(t, err)
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+		},
+	}
+
+	scalerCache := cache.ScalersCache{
+		Scalers: []cache.ScalerBuilder{{
+			Scaler:  activeScaler,
+			Factory: activeFactory,
+		}, {
+			Scaler:       ignoredScaler,
+			ScalerConfig: *ignoredConfig,
+			Factory:      ignoredFactory,
+		}},
+		Recorder: recorder,
+	}
+
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &scalerCache
+
+	sh := scaleHandler{
+		client:                   mockClient,
+		scaleLoopContexts:        &sync.Map{},
+		scaleExecutor:            mockExecutor,
+		globalHTTPTimeout:        time.Duration(1000),
+		recorder:                 recorder,
+		scalerCaches:             caches,
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	isActive, isError, _, activeTriggers, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+	scalerCache.Close(context.Background())
+
+	assert.Equal(t, true, isActive)
+	assert.Equal(t, false, isError)
+	assert.Equal(t, []string{"*mock_scalers.MockScaler"}, activeTriggers)
+}
+
+func TestCheckScaledObjectResourceTriggerActivity(t *testing.T) {
+	tests := []struct {
+		name            string
+		cpuActive       bool
+		expectedActive  bool
+		expectedTrigger []string
+	}{
+		{name: "cpu above its activation value", cpuActive: true, expectedActive: true, expectedTrigger: []string{"cpu-trigger"}},
+		{name: "cpu below its activation value", cpuActive: false, expectedActive: false, expectedTrigger: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			recorder := record.NewFakeRecorder(1)
+
+			cpuScaler := mock_scalers.NewMockScaler(ctrl)
+			cpuScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{{
+				Type:     v2.ResourceMetricSourceType,
+				Resource: &v2.ResourceMetricSource{Name: v1.ResourceCPU},
+			}})
+			cpuScaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "cpu").Return([]external_metrics.ExternalMetricValue{}, test.cpuActive, nil)
+
+			queueScaler := mock_scalers.NewMockScaler(ctrl)
+			queueScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(1, "queue")})
+			queueScaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "queue").Return([]external_metrics.ExternalMetricValue{}, false, nil)
+
+			scaledObject := kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test",
+				},
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+						Name: "test",
+					},
+					Triggers: []kedav1alpha1.ScaleTriggers{
+						{Type: "cpu", Name: "cpu-trigger"},
+						{Type: "rabbitmq", Name: "queue-trigger"},
+					},
+				},
+			}
+
+			scalerCache := cache.ScalersCache{
+				Scalers: []cache.ScalerBuilder{
+					{Scaler: cpuScaler, ScalerConfig: scalersconfig.ScalerConfig{TriggerName: "cpu-trigger"}},
+					{Scaler: queueScaler, ScalerConfig: scalersconfig.ScalerConfig{TriggerName: "queue-trigger"}},
+				},
+				Recorder: recorder,
+			}
+
+			sh := scaleHandler{
+				scaleLoopContexts:        &sync.Map{},
+				globalHTTPTimeout:        time.Duration(1000),
+				recorder:                 recorder,
+				scalerCaches:             map[string]*cache.ScalersCache{scaledObject.GenerateIdentifier(): &scalerCache},
+				scalerCachesLock:         &sync.RWMutex{},
+				scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+			}
+
+			isActive, isError, _, activeTriggers, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+
+			assert.Equal(t, test.expectedActive, isActive)
+			assert.False(t, isError)
+			assert.Equal(t, test.expectedTrigger, activeTriggers)
+		})
+	}
+}
+
 func TestIsScaledJobActive(t *testing.T) {
 	metricName := "s0-queueLength"
 	ctrl := gomock.NewController(t)
@@ -657,11 +1193,12 @@ func TestIsScaledJobActive(t *testing.T) {
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
 	// nosemgrep: context-todo
-	isActive, isError, queueLength, maxValue := sh.isScaledJobActive(context.TODO(), scaledJobSingle)
+	isActive, isError, queueLength, maxValue, options := sh.isScaledJobActive(context.TODO(), scaledJobSingle)
 	assert.Equal(t, true, isActive)
 	assert.Equal(t, false, isError)
 	assert.Equal(t, int64(20), queueLength)
 	assert.Equal(t, int64(10), maxValue)
+	assert.Equal(t, []string{metricName}, options.ActiveMetricNames)
 	scalerCache.Close(context.Background())
 
 	// Test the valiation
@@ -715,7 +1252,7 @@ func TestIsScaledJobActive(t *testing.T) {
 		}
 		fmt.Printf("index: %d", index)
 		// nosemgrep: context-todo
-		isActive, isError, queueLength, maxValue = sh.isScaledJobActive(context.TODO(), scaledJob)
+		isActive, isError, queueLength, maxValue, _ = sh.isScaledJobActive(context.TODO(), scaledJob)
 		//	assert.Equal(t, 5, index)
 		assert.Equal(t, scalerTestData.ResultIsActive, isActive)
 		assert.Equal(t, scalerTestData.ResultIsError, isError)
@@ -757,7 +1294,7 @@ func TestIsScaledJobActiveIfQueueEmptyButMinReplicaCountGreaterZero(t *testing.T
 	}
 
 	// nosemgrep: context-todo
-	isActive, isError, queueLength, maxValue := sh.isScaledJobActive(context.TODO(), scaledJobSingle)
+	isActive, isError, queueLength, maxValue, _ := sh.isScaledJobActive(context.TODO(), scaledJobSingle)
 	assert.Equal(t, true, isActive)
 	assert.Equal(t, false, isError)
 	assert.Equal(t, int64(0), queueLength)
@@ -765,6 +1302,60 @@ func TestIsScaledJobActiveIfQueueEmptyButMinReplicaCountGreaterZero(t *testing.T
 	scalerCache.Close(context.Background())
 }
 
+func TestCheckScaledJobScalersCreatesJobsInPriorityOrder(t *testing.T) {
+	server := miniredis.RunT(t)
+	// 4 tasks are waiting, the scores of the sorted set being their priorities
+	for i, priority := range []float64{3, 10, 1, 7} {
+		server.ZAdd("tasks", priority, fmt.Sprintf("task-%d", i))
+	}
+	redisScaler, err := scalers.NewRedisScaler(context.Background(), false, false, &scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"listName": "tasks", "listLength": "1", "priorityFromScore": "true"},
+		AuthParams:      map[string]string{"address": server.Addr()},
+	})
+	assert.NoError(t, err)
+
+	// only 2 Jobs can be created, they must be the ones of the highest priorities
+	scaledJob := createScaledJob(0, 2, "")
+	scaledJob.Spec.ScalingStrategy.JobCreationOrder = kedav1alpha1.JobCreationOrderPriority
+	scaledJob.Spec.JobTargetRef.Template.Spec.Containers = []v1.Container{{Name: "worker"}}
+
+	testScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(testScheme))
+	utilruntime.Must(kedav1alpha1.AddToScheme(testScheme))
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(scaledJob).WithStatusSubresource(scaledJob).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	scalerCache := cache.ScalersCache{
+		Scalers:  []cache.ScalerBuilder{{Scaler: redisScaler}},
+		Recorder: recorder,
+	}
+	defer scalerCache.Close(context.Background())
+
+	sh := scaleHandler{
+		client:                   client,
+		scaleLoopContexts:        &sync.Map{},
+		scaleExecutor:            executor.NewScaleExecutor(client, nil, testScheme, recorder, 0, nil),
+		recorder:                 recorder,
+		scalerCaches:             map[string]*cache.ScalersCache{scaledJob.GenerateIdentifier(): &scalerCache},
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	// nosemgrep: context-todo
+	isError := sh.checkScalers(context.TODO(), scaledJob, &sync.RWMutex{})
+	assert.False(t, isError)
+
+	jobs := &batchv1.JobList{}
+	assert.NoError(t, client.List(context.Background(), jobs))
+	var priorities []string
+	for _, job := range jobs.Items {
+		priority := job.Annotations["scaledjob.keda.sh/priority"]
+		priorities = append(priorities, priority)
+		assert.Equal(t, []v1.EnvVar{{Name: "KEDA_JOB_PRIORITY", Value: priority}}, job.Spec.Template.Spec.Containers[0].Env)
+	}
+	assert.ElementsMatch(t, []string{"10", "7"}, priorities)
+}
+
 func newScalerTestData(
 	metricName string,
 	maxReplicaCount int,
@@ -1012,7 +1603,178 @@ func TestScalingModifiersFormula(t *testing.T) {
 	assert.Equal(t, float64(7), metrics.Items[0].Value.AsApproximateFloat64())
 }
 
+func TestScalingModifiersBaselineActivation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+
+	queueScaler := mock_scalers.NewMockScaler(ctrl)
+	cronScaler := mock_scalers.NewMockScaler(ctrl)
+	queueConfig := scalersconfig.ScalerConfig{TriggerName: triggerName1, TriggerIndex: 0}
+	cronConfig := scalersconfig.ScalerConfig{TriggerName: triggerName2, TriggerIndex: 1}
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testNameGlobal,
+			Namespace: testNamespaceGlobal,
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				ScalingModifiers: kedav1alpha1.ScalingModifiers{
+					Target:           "10",
+					ActivationTarget: "0",
+					Formula:          fmt.Sprintf("max(%s, baseline(%q))", triggerName1, triggerName2),
+				},
+			},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Name: triggerName1, Type: "fake_queue"},
+				{Name: triggerName2, Type: "cron"},
+			},
+		},
+	}
+	compiledFormula, err := kedav1alpha1.ValidateAndCompileScalingModifiers(&scaledObject)
+	assert.NoError(t, err)
+
+	scalerCache := cache.ScalersCache{
+		ScaledObject: &scaledObject,
+		Scalers: []cache.ScalerBuilder{
+			{Scaler: queueScaler, ScalerConfig: queueConfig},
+			{Scaler: cronScaler, ScalerConfig: cronConfig},
+		},
+		Recorder:        recorder,
+		CompiledFormula: compiledFormula,
+	}
+	sh := scaleHandler{
+		scaleLoopContexts:        &sync.Map{},
+		recorder:                 recorder,
+		scalerCaches:             map[string]*cache.ScalersCache{scaledObject.GenerateIdentifier(): &scalerCache},
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	tests := []struct {
+		name         string
+		queue        float64
+		cronReplicas float64
+		cronActive   bool
+		expected     bool
+	}{
+		// the floor of the cron window keeps the ScaledObject active while the queue is empty
+		{name: "within the cron window", queue: 0, cronReplicas: 3, cronActive: true, expected: true},
+		// the value of 1 reported by cron outside of its window doesn't
+		{name: "outside of the cron window", queue: 0, cronReplicas: 1, cronActive: false, expected: false},
+		{name: "queue outside of the cron window", queue: 5, cronReplicas: 1, cronActive: false, expected: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			queueScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(10, metricName1)})
+			cronScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(1, metricName2)})
+			queueScaler.EXPECT().GetMetricsAndActivity(gomock.Any(), metricName1).Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili(metricName1, test.queue)}, test.queue > 0, nil)
+			cronScaler.EXPECT().GetMetricsAndActivity(gomock.Any(), metricName2).Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili(metricName2, test.cronReplicas)}, test.cronActive, nil)
+
+			isActive, isError, _, _, err := sh.getScaledObjectState(context.TODO(), &scaledObject)
+			assert.NoError(t, err)
+			assert.False(t, isError)
+			assert.Equal(t, test.expected, isActive)
+		})
+	}
+}
+
 // createMetricSpec creates MetricSpec for given metric name and target value.
+func TestGetScaledObjectMetricsFallbackTransitions(t *testing.T) {
+	metricName := "s0-queue"
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(10)
+	mockClient := mock_client.NewMockClient(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+	mockClient.EXPECT().Status().Return(statusWriter).AnyTimes()
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	metricscollector.NewMetricsCollectors(true, false)
+
+	metricSpec := createMetricSpec(10, metricName)
+	metricSpec.External.Target.Type = v2.AverageValueMetricType
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	scalerConfig := scalersconfig.ScalerConfig{TriggerName: "queue"}
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "fallback", Namespace: testNamespaceGlobal},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "test"},
+			Fallback:       &kedav1alpha1.Fallback{FailureThreshold: 0, Replicas: 5},
+		},
+	}
+	scalerCache := cache.ScalersCache{
+		ScaledObject: &scaledObject,
+		Scalers: []cache.ScalerBuilder{{
+			Scaler:       scaler,
+			ScalerConfig: scalerConfig,
+			// the scaler is rebuilt after an error, which fails as well while the trigger is failing
+			Factory: func() (scalers.Scaler, *scalersconfig.ScalerConfig, error) {
+				return nil, nil, errors.New("connection refused")
+			},
+		}},
+		Recorder: recorder,
+	}
+	sh := scaleHandler{
+		client:                   mockClient,
+		scalerCaches:             map[string]*cache.ScalersCache{scaledObject.GenerateIdentifier(): &scalerCache},
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{metricSpec}).AnyTimes()
+
+	getMetrics := func(err error) {
+		metricValue := scalers.GenerateMetricInMili(metricName, float64(10))
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), metricName).Return([]external_metrics.ExternalMetricValue{metricValue}, true, err)
+		metrics, err := sh.GetScaledObjectMetrics(context.TODO(), scaledObject.Name, scaledObject.Namespace, metricName)
+		assert.NoError(t, err)
+		assert.Len(t, metrics.Items, 1)
+	}
+
+	// the first failure activates the fallback
+	getMetrics(errors.New("connection refused"))
+	assert.Equal(t, "Warning FallbackActivated Trigger queue is failing, falling back to 5 replicas: connection refused", <-recorder.Events)
+	assert.Equal(t, float64(1), getFallbackTransitions(t, scaledObject.Name, "activated"))
+
+	// the fallback stays active without a new event
+	getMetrics(errors.New("connection refused"))
+	assert.Len(t, recorder.Events, 0)
+	assert.Equal(t, float64(1), getFallbackTransitions(t, scaledObject.Name, "activated"))
+
+	// the trigger recovers
+	getMetrics(nil)
+	assert.Equal(t, "Normal FallbackCleared Trigger queue isn't falling back anymore", <-recorder.Events)
+	assert.Equal(t, float64(1), getFallbackTransitions(t, scaledObject.Name, "cleared"))
+
+	getMetrics(nil)
+	assert.Len(t, recorder.Events, 0)
+	assert.Equal(t, float64(1), getFallbackTransitions(t, scaledObject.Name, "cleared"))
+}
+
+// getFallbackTransitions returns the fallback transitions of the trigger queue counted by the prometheus metrics
+func getFallbackTransitions(t *testing.T, scaledObjectName, state string) float64 {
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatal("Could not gather metrics:", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "keda_scaled_object_fallback_transitions_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["scaledObject"] == scaledObjectName && labels["trigger"] == "queue" && labels["state"] == state {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
 func createMetricSpec(averageValue int64, metricName string) v2.MetricSpec {
 	qty := resource.NewQuantity(averageValue, resource.DecimalSI)
 	return v2.MetricSpec{
@@ -1031,3 +1793,231 @@ func expectNoStatusPatch(ctrl *gomock.Controller) {
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 }
+
+func TestShouldUpdateNextPollTime(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	stored := metav1.NewTime(now)
+
+	assert.True(t, shouldUpdateNextPollTime(nil, now))
+	assert.False(t, shouldUpdateNextPollTime(&stored, now))
+	assert.False(t, shouldUpdateNextPollTime(&stored, now.Add(500*time.Millisecond)))
+	assert.False(t, shouldUpdateNextPollTime(&stored, now.Add(10*time.Second)))
+	assert.True(t, shouldUpdateNextPollTime(&stored, now.Add(30*time.Second)))
+	assert.True(t, shouldUpdateNextPollTime(&stored, now.Add(5*time.Minute)))
+	assert.True(t, shouldUpdateNextPollTime(&stored, now.Add(-10*time.Second)))
+}
+
+func TestUpdateNextPollTimeAdvancesAcrossPolls(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	sh := scaleHandler{client: client}
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: testNameGlobal, Namespace: testNamespaceGlobal},
+	}
+	mutex := &sync.Mutex{}
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// polls every 10 seconds only patch the status every 30 seconds
+	client.EXPECT().Status().Return(statusWriter).Times(2)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	expected := []time.Time{
+		start.Add(10 * time.Second),
+		start.Add(10 * time.Second),
+		start.Add(10 * time.Second),
+		start.Add(40 * time.Second),
+	}
+	for i, want := range expected {
+		next := start.Add(time.Duration(i+1)*10*time.Second + 200*time.Millisecond)
+		sh.updateNextPollTime(context.TODO(), scaledObject, mutex, next)
+		assert.NotNil(t, scaledObject.Status.NextPollTime)
+		assert.True(t, want.Equal(scaledObject.Status.NextPollTime.Time), "poll %d: expected %s, got %s", i, want, scaledObject.Status.NextPollTime.Time)
+	}
+}
+
+func TestUpdateNextPollTimeIgnoresScaledJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	client.EXPECT().Status().Times(0)
+
+	sh := scaleHandler{client: client}
+	sh.updateNextPollTime(context.TODO(), &kedav1alpha1.ScaledJob{}, &sync.Mutex{}, time.Now())
+}
+
+func TestGetErrorBackoffInterval(t *testing.T) {
+	pollingInterval := 30 * time.Second
+	errorBackoff := &kedav1alpha1.ErrorBackoff{FailureThreshold: 2, MaxInterval: 300}
+
+	testCases := []struct {
+		name              string
+		errorBackoff      *kedav1alpha1.ErrorBackoff
+		consecutiveErrors int32
+		expected          time.Duration
+	}{
+		{name: "no backoff configured", errorBackoff: nil, consecutiveErrors: 10, expected: 30 * time.Second},
+		{name: "successful poll", errorBackoff: errorBackoff, consecutiveErrors: 0, expected: 30 * time.Second},
+		{name: "below the threshold", errorBackoff: errorBackoff, consecutiveErrors: 1, expected: 30 * time.Second},
+		{name: "at the threshold", errorBackoff: errorBackoff, consecutiveErrors: 2, expected: 60 * time.Second},
+		{name: "doubled again", errorBackoff: errorBackoff, consecutiveErrors: 3, expected: 120 * time.Second},
+		{name: "doubled twice again", errorBackoff: errorBackoff, consecutiveErrors: 4, expected: 240 * time.Second},
+		{name: "capped", errorBackoff: errorBackoff, consecutiveErrors: 5, expected: 300 * time.Second},
+		{name: "stays capped", errorBackoff: errorBackoff, consecutiveErrors: 100, expected: 300 * time.Second},
+		{name: "defaults", errorBackoff: &kedav1alpha1.ErrorBackoff{}, consecutiveErrors: 3, expected: 60 * time.Second},
+		{name: "default cap", errorBackoff: &kedav1alpha1.ErrorBackoff{}, consecutiveErrors: 50, expected: 300 * time.Second},
+		{name: "cap below the polling interval", errorBackoff: &kedav1alpha1.ErrorBackoff{FailureThreshold: 1, MaxInterval: 10}, consecutiveErrors: 5, expected: 30 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, getErrorBackoffInterval(tc.errorBackoff, pollingInterval, tc.consecutiveErrors))
+		})
+	}
+}
+
+func TestUpdatePollingBackoffGrowsAndResets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	sh := scaleHandler{client: client}
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: testNameGlobal, Namespace: testNamespaceGlobal},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				ErrorBackoff: &kedav1alpha1.ErrorBackoff{FailureThreshold: 2, MaxInterval: 40},
+			},
+		},
+	}
+	mutex := &sync.Mutex{}
+	pollingInterval := 10 * time.Second
+
+	// the status is only patched when the interval changes: twice while backing off, and once on the reset
+	client.EXPECT().Status().Return(statusWriter).Times(3)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
+
+	polls := []struct {
+		isError  bool
+		interval time.Duration
+		backoff  *kedav1alpha1.PollingBackoffStatus
+	}{
+		{isError: true, interval: 10 * time.Second},
+		{isError: true, interval: 20 * time.Second, backoff: &kedav1alpha1.PollingBackoffStatus{ConsecutiveErrors: 2, Interval: 20}},
+		{isError: true, interval: 40 * time.Second, backoff: &kedav1alpha1.PollingBackoffStatus{ConsecutiveErrors: 3, Interval: 40}},
+		{isError: true, interval: 40 * time.Second, backoff: &kedav1alpha1.PollingBackoffStatus{ConsecutiveErrors: 3, Interval: 40}},
+		{isError: false, interval: 10 * time.Second},
+		{isError: true, interval: 10 * time.Second},
+	}
+	var consecutiveErrors int32
+	for i, poll := range polls {
+		var interval time.Duration
+		consecutiveErrors, interval = sh.updatePollingBackoff(context.TODO(), scaledObject, mutex, pollingInterval, consecutiveErrors, poll.isError)
+		assert.Equal(t, poll.interval, interval, "poll %d", i)
+		assert.Equal(t, poll.backoff, scaledObject.Status.PollingBackoff, "poll %d", i)
+	}
+	assert.Equal(t, int32(1), consecutiveErrors)
+}
+
+func TestUpdatePollingBackoffWithoutErrorBackoff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	client.EXPECT().Status().Times(0)
+
+	sh := scaleHandler{client: client}
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: testNameGlobal, Namespace: testNamespaceGlobal},
+	}
+	var consecutiveErrors int32
+	for i := 0; i < 10; i++ {
+		var interval time.Duration
+		consecutiveErrors, interval = sh.updatePollingBackoff(context.TODO(), scaledObject, &sync.Mutex{}, 10*time.Second, consecutiveErrors, true)
+		assert.Equal(t, 10*time.Second, interval)
+	}
+	assert.Equal(t, int32(10), consecutiveErrors)
+	assert.Nil(t, scaledObject.Status.PollingBackoff)
+}
+
+func TestRequestImmediatePoll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
+	recorder := record.NewFakeRecorder(10)
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "test",
+			Annotations: map[string]string{kedav1alpha1.ReconcileNowAnnotation: "1"},
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef:  &kedav1alpha1.ScaleTarget{Name: "test"},
+			PollingInterval: ptr.To[int32](3600),
+		},
+	}
+
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &cache.ScalersCache{Recorder: recorder}
+
+	sh := scaleHandler{
+		client:                   mockClient,
+		scaleLoopContexts:        &sync.Map{},
+		scaleLoopPollRequests:    &sync.Map{},
+		reconcileNowValues:       &sync.Map{},
+		scaleExecutor:            mockExecutor,
+		globalHTTPTimeout:        time.Duration(1000),
+		recorder:                 recorder,
+		scalerCaches:             caches,
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	polls := make(chan struct{}, 10)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockClient.EXPECT().Status().Return(statusWriter).AnyTimes()
+	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_, _, _, _, _ any) { polls <- struct{}{} }).AnyTimes()
+
+	expectPoll := func(polled bool) {
+		t.Helper()
+		select {
+		case <-polls:
+			assert.True(t, polled, "unexpected poll")
+		case <-time.After(200 * time.Millisecond):
+			assert.False(t, polled, "expected an immediate poll")
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.NoError(t, sh.HandleScalableObject(ctx, scaledObject))
+	// the ScaleLoop polls when it starts, handling the current reconcile-now value
+	expectPoll(true)
+	assert.NoError(t, sh.RequestImmediatePoll(ctx, scaledObject))
+	expectPoll(false)
+
+	// a new value polls right away, without waiting for the pollingInterval
+	scaledObject.Annotations[kedav1alpha1.ReconcileNowAnnotation] = "2"
+	assert.NoError(t, sh.RequestImmediatePoll(ctx, scaledObject))
+	expectPoll(true)
+
+	// the value is handled once
+	assert.NoError(t, sh.RequestImmediatePoll(ctx, scaledObject))
+	expectPoll(false)
+
+	// removing the annotation doesn't poll
+	delete(scaledObject.Annotations, kedav1alpha1.ReconcileNowAnnotation)
+	assert.NoError(t, sh.RequestImmediatePoll(ctx, scaledObject))
+	expectPoll(false)
+}
+
+func TestGetTriggerName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+
+	// the scaler attribute of the scaler metrics is the name of the trigger when it's given
+	scalerConfig := scalersconfig.ScalerConfig{TriggerType: "cron", TriggerName: "business-hours"}
+	assert.Equal(t, "business-hours", getTriggerName(scaler, scalerConfig))
+}
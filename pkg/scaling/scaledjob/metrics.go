@@ -45,9 +45,12 @@ func CalculateQueueLengthAndMaxValue(metrics []external_metrics.ExternalMetricVa
 }
 
 type ScalerMetrics struct {
+	MetricName  string
 	QueueLength float64
 	MaxValue    float64
 	IsActive    bool
+	// Priorities are the priorities of the units of work of the metric, if the scaler tells them
+	Priorities []int64
 }
 
 // IsScaledJobActive returns whether the input ScaledJob is active and queueLength and maxValue for scale
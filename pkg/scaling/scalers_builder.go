@@ -19,6 +19,7 @@ package scaling
 import (
 	"context"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -42,6 +43,7 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 	var err error
 	resolvedEnv := make(map[string]string)
 	result := make([]cache.ScalerBuilder, 0, len(withTriggers.Spec.Triggers))
+	tlsConfigCache := scalersconfig.NewTLSConfigCache()
 
 	for i, t := range withTriggers.Spec.Triggers {
 		triggerIndex, trigger := i, t
@@ -58,9 +60,11 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 				ScalableObjectNamespace: withTriggers.Namespace,
 				ScalableObjectType:      withTriggers.Kind,
 				TriggerName:             trigger.Name,
+				TriggerMetricName:       trigger.MetricName,
 				TriggerMetadata:         trigger.Metadata,
 				TriggerType:             trigger.Type,
 				TriggerUseCachedMetrics: trigger.UseCachedMetrics,
+				TriggerPollingInterval:  triggerPollingInterval(trigger),
 				ResolvedEnv:             resolvedEnv,
 				AuthParams:              make(map[string]string),
 				GlobalHTTPTimeout:       h.globalHTTPTimeout,
@@ -69,8 +73,12 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 				AsMetricSource:          asMetricSource,
 				ScaledObject:            withTriggers,
 				Recorder:                h.recorder,
+				TLSConfigCache:          tlsConfigCache,
 				TriggerUniqueKey:        fmt.Sprintf("%s-%s-%s-%d", withTriggers.Kind, withTriggers.Namespace, withTriggers.Name, triggerIndex),
 			}
+			if podTemplateSpec != nil {
+				config.ScaleTargetPodLabels = podTemplateSpec.Labels
+			}
 
 			authParams, podIdentity, err := resolver.ResolveAuthRefAndPodIdentity(ctx, h.client, logger, trigger.AuthenticationRef, podTemplateSpec, withTriggers.Namespace, h.secretsLister)
 			switch podIdentity.Provider {
@@ -85,6 +93,10 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 			}
 			config.AuthParams = authParams
 			config.PodIdentity = podIdentity
+			config.ResolveAuthParams = func(ctx context.Context) (map[string]string, error) {
+				authParams, _, err := resolver.ResolveAuthRefAndPodIdentity(ctx, h.client, logger, trigger.AuthenticationRef, podTemplateSpec, withTriggers.Namespace, h.secretsLister)
+				return authParams, err
+			}
 			scaler, err := buildScaler(ctx, h.client, trigger.Type, config)
 			return scaler, config, err
 		}
@@ -121,6 +133,8 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 	switch triggerType {
 	case "activemq":
 		return scalers.NewActiveMQScaler(config)
+	case "amqp":
+		return scalers.NewAmqpScaler(ctx, config)
 	case "apache-kafka":
 		return scalers.NewApacheKafkaScaler(ctx, config)
 	case "arangodb":
@@ -135,6 +149,8 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewAwsDynamoDBStreamsScaler(ctx, config)
 	case "aws-kinesis-stream":
 		return scalers.NewAwsKinesisStreamScaler(ctx, config)
+	case "aws-s3":
+		return scalers.NewAwsS3Scaler(ctx, config)
 	case "aws-sqs-queue":
 		return scalers.NewAwsSqsQueueScaler(ctx, config)
 	case "azure-app-insights":
@@ -159,14 +175,18 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewBeanstalkdScaler(config)
 	case "cassandra":
 		return scalers.NewCassandraScaler(config)
+	case "confluent-cloud":
+		return scalers.NewConfluentCloudScaler(config)
 	case "couchdb":
 		return scalers.NewCouchDBScaler(ctx, config)
 	case "cpu":
-		return scalers.NewCPUMemoryScaler(corev1.ResourceCPU, config)
+		return scalers.NewCPUMemoryScaler(client, corev1.ResourceCPU, config)
 	case "cron":
 		return scalers.NewCronScaler(config)
 	case "datadog":
 		return scalers.NewDatadogScaler(ctx, config)
+	case "debezium":
+		return scalers.NewDebeziumScaler(config)
 	case "dynatrace":
 		return scalers.NewDynatraceScaler(config)
 	case "elasticsearch":
@@ -180,6 +200,10 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewExternalMockScaler(config)
 	case "external-push":
 		return scalers.NewExternalPushScaler(config)
+	case "flink":
+		return scalers.NewFlinkScaler(config)
+	case "gcp-bigquery":
+		return scalers.NewGcpBigQueryScaler(ctx, config)
 	case "gcp-cloudtasks":
 		return scalers.NewGcpCloudTasksScaler(config)
 	case "gcp-pubsub":
@@ -190,6 +214,8 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewGcsScaler(config)
 	case "github-runner":
 		return scalers.NewGitHubRunnerScaler(config)
+	case "gitlab-runner":
+		return scalers.NewGitLabRunnerScaler(config)
 	case "graphite":
 		return scalers.NewGraphiteScaler(config)
 	case "huawei-cloudeye":
@@ -198,8 +224,16 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewIBMMQScaler(config)
 	case "influxdb":
 		return scalers.NewInfluxDBScaler(config)
+	case "kafka-metrics":
+		return scalers.NewKafkaMetricsScaler(config)
 	case "kafka":
 		return scalers.NewKafkaScaler(ctx, config)
+	case "keda-federation":
+		return scalers.NewKedaFederationScaler(config)
+	case "kubernetes-apiserver":
+		return scalers.NewKubernetesAPIServerScaler(config)
+	case "kubernetes-job":
+		return scalers.NewKubernetesJobScaler(client, config)
 	case "kubernetes-workload":
 		return scalers.NewKubernetesWorkloadScaler(client, config)
 	case "liiklus":
@@ -207,7 +241,7 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 	case "loki":
 		return scalers.NewLokiScaler(config)
 	case "memory":
-		return scalers.NewCPUMemoryScaler(corev1.ResourceMemory, config)
+		return scalers.NewCPUMemoryScaler(client, corev1.ResourceMemory, config)
 	case "metrics-api":
 		return scalers.NewMetricsAPIScaler(config)
 	case "mongodb":
@@ -226,6 +260,8 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewOpenstackMetricScaler(ctx, config)
 	case "openstack-swift":
 		return scalers.NewOpenstackSwiftScaler(config)
+	case "opentelemetry":
+		return scalers.NewOpenTelemetryScaler(config)
 	case "postgresql":
 		return scalers.NewPostgreSQLScaler(ctx, config)
 	case "predictkube":
@@ -248,18 +284,38 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewRedisStreamsScaler(ctx, false, true, config)
 	case "redis-streams":
 		return scalers.NewRedisStreamsScaler(ctx, false, false, config)
+	case "rocketmq":
+		return scalers.NewRocketMQScaler(config)
 	case "selenium-grid":
 		return scalers.NewSeleniumGridScaler(config)
+	case "sftp":
+		return scalers.NewSftpScaler(config)
 	case "solace-event-queue":
 		return scalers.NewSolaceScaler(config)
 	case "solr":
 		return scalers.NewSolrScaler(config)
 	case "splunk":
 		return scalers.NewSplunkScaler(config)
+	case "sql":
+		return scalers.NewSQLScaler(ctx, config)
 	case "stan":
 		return scalers.NewStanScaler(config)
+	case "trino":
+		return scalers.NewTrinoScaler(config)
+	case "vault":
+		return scalers.NewVaultScaler(config)
+	case "webhook":
+		return scalers.NewWebhookScaler(config)
 	default:
 		return nil, fmt.Errorf("no scaler found for type: %s", triggerType)
 	}
 	// TRIGGERS-END
 }
+
+// triggerPollingInterval returns the polling interval of the trigger, zero if it uses the one of the scalable object
+func triggerPollingInterval(trigger kedav1alpha1.ScaleTriggers) time.Duration {
+	if trigger.PollingInterval == nil {
+		return 0
+	}
+	return time.Second * time.Duration(*trigger.PollingInterval)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// TriggerMetricValue is a value of a metric returned by the last poll of a trigger
+type TriggerMetricValue struct {
+	MetricName string  `json:"metricName"`
+	Value      float64 `json:"value"`
+}
+
+// TriggerState is the state of a trigger of a ScaledObject as seen by its last poll
+type TriggerState struct {
+	TriggerIndex int                  `json:"triggerIndex"`
+	TriggerName  string               `json:"triggerName"`
+	TriggerType  string               `json:"triggerType"`
+	IsActive     bool                 `json:"isActive"`
+	Metrics      []TriggerMetricValue `json:"metrics"`
+	LastPollTime time.Time            `json:"lastPollTime"`
+	LastError    string               `json:"lastError,omitempty"`
+}
+
+// triggerStateStore keeps the state of the last poll of the triggers of every ScaledObject,
+// its zero value is ready to use
+type triggerStateStore struct {
+	states map[string]map[int]TriggerState
+	lock   sync.RWMutex
+}
+
+// newTriggerState builds the state of a trigger from the result of its poll
+func newTriggerState(triggerIndex int, triggerType string, result scalerState, pollTime time.Time) TriggerState {
+	state := TriggerState{
+		TriggerIndex: triggerIndex,
+		TriggerName:  result.TriggerName,
+		TriggerType:  triggerType,
+		IsActive:     result.IsActive,
+		Metrics:      make([]TriggerMetricValue, 0, len(result.Metrics)),
+		LastPollTime: pollTime,
+	}
+	for _, metric := range result.Metrics {
+		state.Metrics = append(state.Metrics, TriggerMetricValue{
+			MetricName: metric.MetricName,
+			Value:      metric.Value.AsApproximateFloat64(),
+		})
+	}
+	if result.Err != nil {
+		state.LastError = result.Err.Error()
+	}
+	return state
+}
+
+func (s *triggerStateStore) Store(scaledObjectIdentifier string, state TriggerState) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.states == nil {
+		s.states = map[string]map[int]TriggerState{}
+	}
+	if _, ok := s.states[scaledObjectIdentifier]; !ok {
+		s.states[scaledObjectIdentifier] = map[int]TriggerState{}
+	}
+	s.states[scaledObjectIdentifier][state.TriggerIndex] = state
+}
+
+// Read returns the states of the triggers of a ScaledObject ordered by trigger index,
+// the second return value is false if none of its triggers has been polled yet
+func (s *triggerStateStore) Read(scaledObjectIdentifier string) ([]TriggerState, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	states, ok := s.states[scaledObjectIdentifier]
+	if !ok {
+		return nil, false
+	}
+	result := make([]TriggerState, 0, len(states))
+	for _, state := range states {
+		result = append(result, state)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TriggerIndex < result[j].TriggerIndex
+	})
+	return result, true
+}
+
+func (s *triggerStateStore) Delete(scaledObjectIdentifier string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.states, scaledObjectIdentifier)
+}
+
+// Prune deletes the states of the triggers a ScaledObject doesn't have anymore at their index,
+// after its triggers have been removed or reordered
+func (s *triggerStateStore) Prune(scaledObjectIdentifier string, triggers []kedav1alpha1.ScaleTriggers) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	states, ok := s.states[scaledObjectIdentifier]
+	if !ok {
+		return
+	}
+	for index, state := range states {
+		if index >= len(triggers) ||
+			triggers[index].Type != state.TriggerType ||
+			(triggers[index].Name != "" && triggers[index].Name != state.TriggerName) {
+			delete(states, index)
+		}
+	}
+	if len(states) == 0 {
+		delete(s.states, scaledObjectIdentifier)
+	}
+}
@@ -214,6 +214,11 @@ func TransformObject(ctx context.Context, client runtimeclient.StatusClient, log
 		return err
 	}
 
+	// an identical status isn't written, not to load the API server with the frequent updates of the scale loops
+	if data, err := patch.Data(runtimeObj); err == nil && string(data) == "{}" {
+		return nil
+	}
+
 	err := client.Status().Patch(ctx, runtimeObj, patch)
 	if err != nil {
 		logger.Error(err, "failed to patch Objects")
@@ -24,6 +24,10 @@ import (
 
 const RestrictSecretAccessEnvVar = "KEDA_RESTRICT_SECRET_ACCESS"
 
+// MaxReplicaCountCapacityEnvVar enables the maxReplicaCountCapacity of the ScaledObjects, which requires
+// the operator to be granted the list and watch of the nodes of the cluster
+const MaxReplicaCountCapacityEnvVar = "KEDA_ENABLE_MAX_REPLICA_COUNT_CAPACITY"
+
 var clusterObjectNamespaceCache *string
 
 func ResolveOsEnvBool(envName string, defaultValue bool) (bool, error) {
@@ -57,6 +61,22 @@ func ResolveOsEnvDuration(envName string) (*time.Duration, error) {
 	return nil, nil
 }
 
+// ResolveOsEnvOptionalInt32 returns nil if the env var isn't set
+func ResolveOsEnvOptionalInt32(envName string) (*int32, error) {
+	valueStr, found := os.LookupEnv(envName)
+
+	if found && valueStr != "" {
+		value, err := strconv.ParseInt(valueStr, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		result := int32(value)
+		return &result, nil
+	}
+
+	return nil, nil
+}
+
 // GetClusterObjectNamespace retrieves the cluster object namespace of KEDA, default is the namespace of KEDA Operator & Metrics Server
 func GetClusterObjectNamespace() (string, error) {
 	// Check if a cached value is available.
@@ -90,3 +110,9 @@ func GetPodNamespace() string {
 func GetRestrictSecretAccess() string {
 	return os.Getenv(RestrictSecretAccessEnvVar)
 }
+
+// GetMaxReplicaCountCapacityEnabled returns whether KEDA_ENABLE_MAX_REPLICA_COUNT_CAPACITY is set to true
+func GetMaxReplicaCountCapacityEnabled() bool {
+	enabled, err := ResolveOsEnvBool(MaxReplicaCountCapacityEnvVar, false)
+	return err == nil && enabled
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+)
+
+const httpsServerShutdownTimeout = 5 * time.Second
+
+// HTTPSServer serves a handler of the operator over TLS, with the certificate of its cert dir,
+// which is reloaded when it's rotated
+type HTTPSServer struct {
+	name       string
+	address    string
+	certDir    string
+	certsReady <-chan struct{}
+	handler    http.Handler
+}
+
+// NewHTTPSServer creates a new instance of HTTPSServer, started once the certificates are ready
+func NewHTTPSServer(name, address, certDir string, certsReady <-chan struct{}, handler http.Handler) *HTTPSServer {
+	return &HTTPSServer{
+		name:       name,
+		address:    address,
+		certDir:    certDir,
+		certsReady: certsReady,
+		handler:    handler,
+	}
+}
+
+// Start starts the server, this implements Runnable interface
+// of controller-runtime Manager, so we can use mgr.Add() to start this component.
+func (s *HTTPSServer) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName(s.name)
+
+	select {
+	case <-s.certsReady:
+	case <-ctx.Done():
+		return nil
+	}
+
+	watcher, err := certwatcher.New(path.Join(s.certDir, "tls.crt"), path.Join(s.certDir, "tls.key"))
+	if err != nil {
+		return fmt.Errorf("failed to load the certificate of %s: %w", s.name, err)
+	}
+
+	lis, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	server := &http.Server{
+		Handler:           s.handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion:     GetMinTLSVersion(),
+			GetCertificate: watcher.GetCertificate,
+		},
+	}
+
+	errChan := make(chan error, 2)
+	go func() {
+		if err := watcher.Start(ctx); err != nil {
+			errChan <- fmt.Errorf("failed to watch the certificate of %s: %w", s.name, err)
+		}
+	}()
+	go func() {
+		log.Info("Starting server", "address", s.address)
+		if err := server.ServeTLS(lis, "", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			err := fmt.Errorf("unable to start %s on address %s, error: %w", s.name, s.address, err)
+			log.Error(err, "error starting server")
+			errChan <- err
+		}
+	}()
+
+	select {
+	case err := <-errChan:
+		_ = server.Close()
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), httpsServerShutdownTimeout)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// NeedLeaderElection is needed to implement LeaderElectionRunnable interface
+// of controller-runtime. The scalers are only polled by the leader,
+// which has their values and reads the ones received for them.
+func (s *HTTPSServer) NeedLeaderElection() bool {
+	return true
+}
@@ -73,6 +73,14 @@ func NewTLSConfig(clientCert, clientKey, caCert string, unsafeSsl bool) (*tls.Co
 	return NewTLSConfigWithPassword(clientCert, clientKey, "", caCert, unsafeSsl)
 }
 
+// SetTLSServerName overrides the name sent for SNI and used to verify the certificate of the server,
+// for servers reached by an address their certificate doesn't cover. Nothing is changed if serverName is empty
+func SetTLSServerName(config *tls.Config, serverName string) {
+	if config != nil && serverName != "" {
+		config.ServerName = serverName
+	}
+}
+
 // CreateTLSClientConfig returns a new TLS Config
 // unsafeSsl parameter allows to avoid tls cert validation if it's required
 func CreateTLSClientConfig(unsafeSsl bool) *tls.Config {
@@ -19,6 +19,9 @@ package util
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -300,3 +303,53 @@ func TestResolveMinTLSVersion(t *testing.T) {
 		}
 	}
 }
+
+func TestSetTLSServerName(t *testing.T) {
+	// the certificate of the test server is valid for example.com, which is reached by its IP
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	caCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	tests := []struct {
+		name       string
+		serverName string
+		wantErr    bool
+	}{
+		{name: "matching server name", serverName: "example.com"},
+		// the certificate is still verified against the server name
+		{name: "server name not in the certificate", serverName: "other.example.org", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := NewTLSConfig("", "", caCert, false)
+			if err != nil {
+				t.Fatal("Could not create TLS config:", err)
+			}
+			SetTLSServerName(config, tt.serverName)
+			if config.ServerName != tt.serverName {
+				t.Errorf("expected server name %q, got %q", tt.serverName, config.ServerName)
+			}
+			if config.InsecureSkipVerify {
+				t.Error("expected the certificate to be verified")
+			}
+
+			client := &http.Client{Transport: CreateHTTPTransportWithTLSConfig(config)}
+			resp, err := client.Get(server.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if tt.wantErr != (err != nil) {
+				t.Errorf("wantErr %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// an empty server name keeps the one derived from the address
+	config := CreateTLSClientConfig(false)
+	SetTLSServerName(config, "")
+	if config.ServerName != "" {
+		t.Errorf("expected no server name, got %q", config.ServerName)
+	}
+}
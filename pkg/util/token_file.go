@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TokenFileDirectoryEnvVar is the directory of the operator filesystem holding the token files,
+// e.g. projected service account tokens, which the triggers are allowed to read
+const TokenFileDirectoryEnvVar = "KEDA_TOKEN_FILE_DIRECTORY"
+
+// ValidateTokenFilePath checks that a token file path taken from a trigger is within the directory set with
+// KEDA_TOKEN_FILE_DIRECTORY, so a trigger can't read any file of the operator. Token files can't be read
+// at all if the directory isn't set
+func ValidateTokenFilePath(path string) error {
+	dir := os.Getenv(TokenFileDirectoryEnvVar)
+	if dir == "" {
+		return fmt.Errorf("token files can't be read unless %s is set on the operator", TokenFileDirectoryEnvVar)
+	}
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("token file %s must be an absolute path", path)
+	}
+	if !isWithinDirectory(dir, path) {
+		return fmt.Errorf("token file %s isn't within %s", path, dir)
+	}
+
+	// the file mustn't be a symlink out of the directory either, the projected volumes of the kubelet
+	// only link to files within the volume
+	resolvedDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil
+	}
+	resolvedPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil
+	}
+	if !isWithinDirectory(resolvedDir, resolvedPath) {
+		return fmt.Errorf("token file %s isn't within %s", path, dir)
+	}
+	return nil
+}
+
+func isWithinDirectory(dir, path string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	if err != nil {
+		return false
+	}
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
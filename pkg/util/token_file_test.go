@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTokenFilePath(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(outside, "secret"), []byte("secret"), 0600))
+	assert.NoError(t, os.Symlink(filepath.Join(outside, "secret"), filepath.Join(dir, "link")))
+
+	assert.ErrorContains(t, ValidateTokenFilePath(filepath.Join(dir, "token")), "token files can't be read unless KEDA_TOKEN_FILE_DIRECTORY is set")
+
+	t.Setenv(TokenFileDirectoryEnvVar, dir)
+	assert.NoError(t, ValidateTokenFilePath(filepath.Join(dir, "token")))
+	assert.NoError(t, ValidateTokenFilePath(filepath.Join(dir, "tokens", "keda")))
+	assert.ErrorContains(t, ValidateTokenFilePath("token"), "must be an absolute path")
+	assert.ErrorContains(t, ValidateTokenFilePath(dir), "isn't within")
+	assert.ErrorContains(t, ValidateTokenFilePath(filepath.Join(dir, "..", "token")), "isn't within")
+	assert.ErrorContains(t, ValidateTokenFilePath(filepath.Join(outside, "secret")), "isn't within")
+	assert.ErrorContains(t, ValidateTokenFilePath(filepath.Join(dir, "link")), "isn't within")
+}
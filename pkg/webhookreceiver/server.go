@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookreceiver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("webhook_receiver")
+
+// maxBodySize is the max size of the body of a POSTed value
+const maxBodySize = 1024
+
+// valueRequest is the body POSTed to the receiver, e.g. {"value": 42}
+type valueRequest struct {
+	Value *float64 `json:"value"`
+}
+
+// Server receives the values POSTed for the webhook scalers on /api/v1/namespaces/{namespace}/values/{key},
+// the caller passing the token of the key as a bearer token, it's served over TLS by a kedautil.HTTPSServer
+type Server struct {
+	store *Store
+}
+
+// NewServer creates a new instance of Server
+func NewServer(store *Store) Server {
+	return Server{
+		store: store,
+	}
+}
+
+// Handler returns the handler of the requests of the receiver
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/namespaces/{namespace}/values/{key}", s.handleValue)
+	return mux
+}
+
+func (s *Server) handleValue(w http.ResponseWriter, r *http.Request) {
+	key := StoreKey(r.PathValue("namespace"), r.PathValue("key"))
+
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	// an unknown key is unauthorized too, so the registered keys can't be guessed
+	if !found || token == "" || !s.store.Authorize(key, token) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		http.Error(w, "error reading the body", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxBodySize {
+		http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var request valueRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		http.Error(w, fmt.Sprintf("error parsing the body: %s", err), http.StatusBadRequest)
+		return
+	}
+	if request.Value == nil || math.IsNaN(*request.Value) || math.IsInf(*request.Value, 0) {
+		http.Error(w, "value must be a number", http.StatusBadRequest)
+		return
+	}
+
+	if !s.store.Set(key, token, *request.Value) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	log.V(1).Info("Received value", "key", key, "value", *request.Value)
+	w.WriteHeader(http.StatusNoContent)
+}
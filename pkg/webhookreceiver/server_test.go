@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookreceiver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func postValue(server *Server, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestReceiveValue(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewStore()
+	store.now = func() time.Time { return now }
+	store.Register(StoreKey("default", "orders"), "secret")
+	server := NewServer(store)
+
+	rec := postValue(&server, "/api/v1/namespaces/default/values/orders", "secret", `{"value": 42.5}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	value, found := store.Get(StoreKey("default", "orders"))
+	assert.True(t, found)
+	assert.Equal(t, Value{Value: 42.5, ReceivedAt: now}, value)
+
+	// the last value wins
+	rec = postValue(&server, "/api/v1/namespaces/default/values/orders", "secret", `{"value": 0}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	value, _ = store.Get(StoreKey("default", "orders"))
+	assert.Equal(t, float64(0), value.Value)
+}
+
+func TestReceiveValueAuth(t *testing.T) {
+	store := NewStore()
+	store.Register(StoreKey("default", "orders"), "secret")
+	server := NewServer(store)
+
+	testCases := []struct {
+		name  string
+		path  string
+		token string
+	}{
+		{"no token", "/api/v1/namespaces/default/values/orders", ""},
+		{"wrong token", "/api/v1/namespaces/default/values/orders", "guess"},
+		{"unregistered key", "/api/v1/namespaces/default/values/payments", "secret"},
+		{"key of another namespace", "/api/v1/namespaces/other/values/orders", "secret"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := postValue(&server, tc.path, tc.token, `{"value": 1}`)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		})
+	}
+
+	_, found := store.Get(StoreKey("default", "orders"))
+	assert.False(t, found)
+}
+
+func TestReceiveValueSharedKey(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewStore()
+	store.now = func() time.Time { return now }
+	store.Register(StoreKey("default", "orders"), "first")
+	store.Register(StoreKey("default", "orders"), "second")
+	server := NewServer(store)
+
+	assert.Equal(t, http.StatusNoContent, postValue(&server, "/api/v1/namespaces/default/values/orders", "first", `{"value": 1}`).Code)
+	assert.Equal(t, http.StatusNoContent, postValue(&server, "/api/v1/namespaces/default/values/orders", "second", `{"value": 2}`).Code)
+
+	// the key stays registered with the token of the remaining scaler
+	store.Unregister(StoreKey("default", "orders"), "first")
+	assert.Equal(t, http.StatusUnauthorized, postValue(&server, "/api/v1/namespaces/default/values/orders", "first", `{"value": 3}`).Code)
+	value, found := store.Get(StoreKey("default", "orders"))
+	assert.True(t, found)
+	assert.Equal(t, float64(2), value.Value)
+
+	// the value survives the scalers being recreated, but no value is accepted while no scaler uses the key
+	store.Unregister(StoreKey("default", "orders"), "second")
+	assert.Equal(t, http.StatusUnauthorized, postValue(&server, "/api/v1/namespaces/default/values/orders", "second", `{"value": 4}`).Code)
+	store.Register(StoreKey("default", "orders"), "second")
+	value, found = store.Get(StoreKey("default", "orders"))
+	assert.True(t, found)
+	assert.Equal(t, float64(2), value.Value)
+
+	// the value is dropped once no scaler has used the key for a while
+	store.Unregister(StoreKey("default", "orders"), "second")
+	now = now.Add(unregisteredValueRetention + time.Second)
+	store.Register(StoreKey("default", "payments"), "other")
+	_, found = store.Get(StoreKey("default", "orders"))
+	assert.False(t, found)
+}
+
+func TestReceiveInvalidValue(t *testing.T) {
+	store := NewStore()
+	store.Register(StoreKey("default", "orders"), "secret")
+	server := NewServer(store)
+
+	testCases := []struct {
+		name         string
+		body         string
+		expectedCode int
+	}{
+		{"not json", `42`, http.StatusBadRequest},
+		{"no value", `{}`, http.StatusBadRequest},
+		{"value not a number", `{"value": "many"}`, http.StatusBadRequest},
+		{"body too large", `{"value": 1, "padding": "` + strings.Repeat("a", maxBodySize) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := postValue(&server, "/api/v1/namespaces/default/values/orders", "secret", tc.body)
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/values/orders", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	_, found := store.Get(StoreKey("default", "orders"))
+	assert.False(t, found)
+}
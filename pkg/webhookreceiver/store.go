@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookreceiver
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Value is the last value POSTed for a key
+type Value struct {
+	Value      float64
+	ReceivedAt time.Time
+}
+
+// unregisteredValueRetention is how long the value of a key is kept once no scaler uses it, so the value
+// survives the scalers cache of a ScaledObject being rebuilt, which closes the scalers before creating them again
+const unregisteredValueRetention = 10 * time.Minute
+
+// Store keeps the last value POSTed for each of the keys registered by the webhook scalers,
+// only accepting values for registered keys from callers knowing one of their tokens
+type Store struct {
+	lock   sync.RWMutex
+	keys   map[string]*registeredKey
+	values map[string]Value
+	// unregisteredAt is when the keys having a value were last unregistered
+	unregisteredAt map[string]time.Time
+	now            func() time.Time
+}
+
+type registeredKey struct {
+	// tokens are the sha256 sums of the tokens of the scalers using the key, with the count of scalers using each
+	tokens map[[sha256.Size]byte]int
+}
+
+// NewStore creates an empty Store
+func NewStore() *Store {
+	return &Store{
+		keys:           map[string]*registeredKey{},
+		values:         map[string]Value{},
+		unregisteredAt: map[string]time.Time{},
+		now:            time.Now,
+	}
+}
+
+var defaultStore = NewStore()
+
+// DefaultStore returns the Store shared by the receiver hosted by the operator and the webhook scalers
+func DefaultStore() *Store {
+	return defaultStore
+}
+
+// StoreKey returns the key of the store for the key of a webhook scaler, the keys being scoped to namespaces
+// so a scaler can't read the values of another namespace
+func StoreKey(namespace, key string) string {
+	return fmt.Sprintf("%s/%s", namespace, key)
+}
+
+// Register accepts the values of the key POSTed with the token, until it's unregistered,
+// the last value of the key being still returned if it was unregistered recently
+func (s *Store) Register(key, token string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.pruneUnregisteredValues()
+	delete(s.unregisteredAt, key)
+	k, ok := s.keys[key]
+	if !ok {
+		k = &registeredKey{tokens: map[[sha256.Size]byte]int{}}
+		s.keys[key] = k
+	}
+	k.tokens[sha256.Sum256([]byte(token))]++
+}
+
+// Unregister undoes a Register, the value of the key being dropped once no scaler has used it
+// for unregisteredValueRetention
+func (s *Store) Unregister(key, token string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.pruneUnregisteredValues()
+	k, ok := s.keys[key]
+	if !ok {
+		return
+	}
+	sum := sha256.Sum256([]byte(token))
+	k.tokens[sum]--
+	if k.tokens[sum] <= 0 {
+		delete(k.tokens, sum)
+	}
+	if len(k.tokens) == 0 {
+		delete(s.keys, key)
+		if _, ok := s.values[key]; ok {
+			s.unregisteredAt[key] = s.now()
+		}
+	}
+}
+
+// pruneUnregisteredValues drops the values of the keys unregistered for longer than unregisteredValueRetention,
+// the caller holding the lock
+func (s *Store) pruneUnregisteredValues() {
+	now := s.now()
+	for key, unregisteredAt := range s.unregisteredAt {
+		if now.Sub(unregisteredAt) > unregisteredValueRetention {
+			delete(s.values, key)
+			delete(s.unregisteredAt, key)
+		}
+	}
+}
+
+// Authorize checks that the key is registered with the token
+func (s *Store) Authorize(key, token string) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	k, ok := s.keys[key]
+	if !ok {
+		return false
+	}
+	sum := sha256.Sum256([]byte(token))
+	authorized := false
+	for registered := range k.tokens {
+		// every token is compared so the time taken doesn't tell which one matched
+		if subtle.ConstantTimeCompare(registered[:], sum[:]) == 1 {
+			authorized = true
+		}
+	}
+	return authorized
+}
+
+// Set stores the value of the key, returning false when the key isn't registered with the token
+func (s *Store) Set(key, token string, value float64) bool {
+	if !s.Authorize(key, token) {
+		return false
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// the key may have been unregistered in the meantime
+	if _, ok := s.keys[key]; !ok {
+		return false
+	}
+	s.values[key] = Value{Value: value, ReceivedAt: s.now()}
+	return true
+}
+
+// Get returns the last value POSTed for the key
+func (s *Store) Get(key string) (Value, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	value, ok := s.values[key]
+	return value, ok
+}